@@ -0,0 +1,55 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxBodySize is the number of bytes a request body may have by default
+const DefaultMaxBodySize = 1 << 20
+
+// SetMaxBodySize sets the number of bytes a request body may have; 0 or less restores the default
+func (s *Server) SetMaxBodySize(limit int64) {
+	if limit <= 0 {
+		limit = DefaultMaxBodySize
+	}
+	s.maxBodySize = limit
+}
+
+// limitBodySize rejects request bodies larger than the maximum body size with 413. Bodies are read
+// up front, so that handlers never bind a truncated body.
+func (s *Server) limitBodySize(c *gin.Context) {
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		c.Next()
+		return
+	}
+	if c.Request.ContentLength > s.maxBodySize {
+		s.bodyTooLarge(c)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, s.maxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			s.bodyTooLarge(c)
+			return
+		}
+		s.errorResponse(c, http.StatusBadRequest, "Failed to read request body: "+err.Error())
+		c.Abort()
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Next()
+}
+
+// bodyTooLarge aborts a request whose body exceeds the maximum body size
+func (s *Server) bodyTooLarge(c *gin.Context) {
+	s.errorResponse(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body too large (max %d bytes)", s.maxBodySize))
+	c.Abort()
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// requireLLMRegistry rejects requests with 501 when the server was created without an LLM registry
+func (s *Server) requireLLMRegistry() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.llmRegistry == nil {
+			s.errorResponse(c, http.StatusNotImplemented, "LLM execution is not available: the server was started without LLM providers")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// requireScheduler rejects requests with 501 when the server was created without a scheduler
+func (s *Server) requireScheduler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.scheduler == nil {
+			s.errorResponse(c, http.StatusNotImplemented, "Schedule execution is not available: the server was started without a scheduler")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// runSchedule handles POST /api/v1/schedules/:id/run, executing the schedule in the background
+func (s *Server) runSchedule(c *gin.Context) {
+	id := c.Param("id")
+
+	schedule, err := s.scheduleService.GetSchedule(c.Request.Context(), id)
+	if err != nil {
+		s.errorResponse(c, http.StatusNotFound, "Schedule not found: "+err.Error())
+		return
+	}
+
+	go func() {
+		if err := s.scheduler.ExecuteNow(context.Background(), schedule.ID); err != nil {
+			logger.Error("Failed to execute schedule %s: %v", schedule.ID, err)
+		}
+	}()
+
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Message: "Schedule execution started",
+		Data:    gin.H{"schedule_id": schedule.ID},
+	})
+}
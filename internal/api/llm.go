@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"slices"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
 	"github.com/AI2HU/gego/internal/shared"
 )
 
@@ -15,7 +17,7 @@ import (
 func (s *Server) listLLMs(c *gin.Context) {
 	enabled := shared.ParseEnabledFilter(c)
 
-	llms, err := s.llmService.ListLLMs(c.Request.Context(), enabled)
+	llms, err := s.llmService.ListLLMs(s.ownerContext(c), enabled)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to list LLMs: "+err.Error())
 		return
@@ -24,16 +26,21 @@ func (s *Server) listLLMs(c *gin.Context) {
 	responses := make([]models.LLMResponse, len(llms))
 	for i, llm := range llms {
 		responses[i] = models.LLMResponse{
-			ID:        llm.ID,
-			Name:      llm.Name,
-			Provider:  llm.Provider,
-			Model:     llm.Model,
-			APIKey:    s.maskAPIKey(llm.APIKey),
-			BaseURL:   llm.BaseURL,
-			Config:    llm.Config,
-			Enabled:   llm.Enabled,
-			CreatedAt: llm.CreatedAt,
-			UpdatedAt: llm.UpdatedAt,
+			ID:             llm.ID,
+			Name:           llm.Name,
+			Provider:       llm.Provider,
+			Model:          llm.Model,
+			APIKey:         shared.MaskAPIKey(llm.APIKey),
+			BaseURL:        llm.BaseURL,
+			Config:         llm.Config,
+			Enabled:        llm.Enabled,
+			Owner:          llm.Owner,
+			PromptPrefix:   llm.PromptPrefix,
+			PromptSuffix:   llm.PromptSuffix,
+			AuthFailures:   llm.AuthFailures,
+			DisabledReason: llm.DisabledReason,
+			CreatedAt:      llm.CreatedAt,
+			UpdatedAt:      llm.UpdatedAt,
 		}
 	}
 
@@ -51,16 +58,21 @@ func (s *Server) getLLM(c *gin.Context) {
 	}
 
 	response := models.LLMResponse{
-		ID:        llm.ID,
-		Name:      llm.Name,
-		Provider:  llm.Provider,
-		Model:     llm.Model,
-		APIKey:    s.maskAPIKey(llm.APIKey),
-		BaseURL:   llm.BaseURL,
-		Config:    llm.Config,
-		Enabled:   llm.Enabled,
-		CreatedAt: llm.CreatedAt,
-		UpdatedAt: llm.UpdatedAt,
+		ID:             llm.ID,
+		Name:           llm.Name,
+		Provider:       llm.Provider,
+		Model:          llm.Model,
+		APIKey:         shared.MaskAPIKey(llm.APIKey),
+		BaseURL:        llm.BaseURL,
+		Config:         llm.Config,
+		Enabled:        llm.Enabled,
+		Owner:          llm.Owner,
+		PromptPrefix:   llm.PromptPrefix,
+		PromptSuffix:   llm.PromptSuffix,
+		AuthFailures:   llm.AuthFailures,
+		DisabledReason: llm.DisabledReason,
+		CreatedAt:      llm.CreatedAt,
+		UpdatedAt:      llm.UpdatedAt,
 	}
 
 	s.successResponse(c, response)
@@ -75,37 +87,69 @@ func (s *Server) createLLM(c *gin.Context) {
 	}
 
 	if !s.isValidProvider(req.Provider) {
-		s.errorResponse(c, http.StatusBadRequest, "Invalid provider. Must be one of: openai, anthropic, ollama, google, perplexity")
+		s.errorResponse(c, http.StatusBadRequest, "Invalid provider. Must be one of: openai, anthropic, ollama, google, perplexity, deepseek, xai, bedrock")
+		return
+	}
+	baseURL, err := services.NormalizeBaseURL(req.Provider, req.BaseURL)
+	if err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := services.ValidateLLMOptions(req.Config); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	llm := &models.LLMConfig{
-		ID:       uuid.New().String(),
-		Name:     req.Name,
-		Provider: req.Provider,
-		Model:    req.Model,
-		APIKey:   req.APIKey,
-		BaseURL:  req.BaseURL,
-		Config:   req.Config,
-		Enabled:  req.Enabled,
+		ID:           uuid.New().String(),
+		Name:         req.Name,
+		Provider:     req.Provider,
+		Model:        req.Model,
+		APIKey:       req.APIKey,
+		BaseURL:      baseURL,
+		Config:       req.Config,
+		Enabled:      s.createEnabled(req.Enabled),
+		Owner:        s.requestOwner(c, req.Owner),
+		PromptPrefix: req.PromptPrefix,
+		PromptSuffix: req.PromptSuffix,
 	}
 
-	if err := s.llmService.CreateLLM(c.Request.Context(), llm); err != nil {
+	force := c.Query("force") == "true"
+	if err := s.llmService.CreateLLM(c.Request.Context(), llm, force); err != nil {
+		var duplicate *services.DuplicateLLMError
+		if errors.As(err, &duplicate) {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Data:    gin.H{"existing_id": duplicate.ExistingID},
+				Error:   duplicate.Error() + ". Use ?force=true to create it anyway",
+			})
+			return
+		}
+		var invalid *services.InvalidLLMError
+		if errors.As(err, &invalid) {
+			s.errorResponse(c, http.StatusBadRequest, invalid.Error())
+			return
+		}
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to create LLM: "+err.Error())
 		return
 	}
 
 	response := models.LLMResponse{
-		ID:        llm.ID,
-		Name:      llm.Name,
-		Provider:  llm.Provider,
-		Model:     llm.Model,
-		APIKey:    s.maskAPIKey(llm.APIKey),
-		BaseURL:   llm.BaseURL,
-		Config:    llm.Config,
-		Enabled:   llm.Enabled,
-		CreatedAt: llm.CreatedAt,
-		UpdatedAt: llm.UpdatedAt,
+		ID:             llm.ID,
+		Name:           llm.Name,
+		Provider:       llm.Provider,
+		Model:          llm.Model,
+		APIKey:         shared.MaskAPIKey(llm.APIKey),
+		BaseURL:        llm.BaseURL,
+		Config:         llm.Config,
+		Enabled:        llm.Enabled,
+		Owner:          llm.Owner,
+		PromptPrefix:   llm.PromptPrefix,
+		PromptSuffix:   llm.PromptSuffix,
+		AuthFailures:   llm.AuthFailures,
+		DisabledReason: llm.DisabledReason,
+		CreatedAt:      llm.CreatedAt,
+		UpdatedAt:      llm.UpdatedAt,
 	}
 
 	c.JSON(http.StatusCreated, models.APIResponse{
@@ -131,48 +175,68 @@ func (s *Server) updateLLM(c *gin.Context) {
 		return
 	}
 
-	if req.Name != "" {
-		llm.Name = req.Name
-	}
-	if req.Provider != "" {
-		if !s.isValidProvider(req.Provider) {
-			s.errorResponse(c, http.StatusBadRequest, "Invalid provider. Must be one of: openai, anthropic, ollama, google, perplexity")
+	for field, value := range map[string]models.Nullable[string]{"name": req.Name, "provider": req.Provider, "model": req.Model} {
+		if clearsRequired(value) {
+			s.errorResponse(c, http.StatusBadRequest, field+" cannot be cleared")
 			return
 		}
-		llm.Provider = req.Provider
-	}
-	if req.Model != "" {
-		llm.Model = req.Model
 	}
-	if req.APIKey != "" {
-		llm.APIKey = req.APIKey
-	}
-	if req.BaseURL != "" {
-		llm.BaseURL = req.BaseURL
-	}
-	if req.Config != nil {
-		llm.Config = req.Config
+
+	req.Name.Apply(&llm.Name)
+	if req.Provider.Set {
+		if !s.isValidProvider(req.Provider.Value) {
+			s.errorResponse(c, http.StatusBadRequest, "Invalid provider. Must be one of: openai, anthropic, ollama, google, perplexity, deepseek, xai, bedrock")
+			return
+		}
+		llm.Provider = req.Provider.Value
 	}
+	req.Model.Apply(&llm.Model)
+	req.APIKey.Apply(&llm.APIKey)
+	req.BaseURL.Apply(&llm.BaseURL)
+	req.Config.Apply(&llm.Config)
 	if req.Enabled != nil {
 		llm.Enabled = *req.Enabled
 	}
+	req.Owner.Apply(&llm.Owner)
+	req.PromptPrefix.Apply(&llm.PromptPrefix)
+	req.PromptSuffix.Apply(&llm.PromptSuffix)
+	baseURL, err := services.NormalizeBaseURL(llm.Provider, llm.BaseURL)
+	if err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	llm.BaseURL = baseURL
+	if err := services.ValidateLLMOptions(llm.Config); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	if err := s.llmService.UpdateLLM(c.Request.Context(), llm); err != nil {
+		var invalid *services.InvalidLLMError
+		if errors.As(err, &invalid) {
+			s.errorResponse(c, http.StatusBadRequest, invalid.Error())
+			return
+		}
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to update LLM: "+err.Error())
 		return
 	}
 
 	response := models.LLMResponse{
-		ID:        llm.ID,
-		Name:      llm.Name,
-		Provider:  llm.Provider,
-		Model:     llm.Model,
-		APIKey:    s.maskAPIKey(llm.APIKey),
-		BaseURL:   llm.BaseURL,
-		Config:    llm.Config,
-		Enabled:   llm.Enabled,
-		CreatedAt: llm.CreatedAt,
-		UpdatedAt: llm.UpdatedAt,
+		ID:             llm.ID,
+		Name:           llm.Name,
+		Provider:       llm.Provider,
+		Model:          llm.Model,
+		APIKey:         shared.MaskAPIKey(llm.APIKey),
+		BaseURL:        llm.BaseURL,
+		Config:         llm.Config,
+		Enabled:        llm.Enabled,
+		Owner:          llm.Owner,
+		PromptPrefix:   llm.PromptPrefix,
+		PromptSuffix:   llm.PromptSuffix,
+		AuthFailures:   llm.AuthFailures,
+		DisabledReason: llm.DisabledReason,
+		CreatedAt:      llm.CreatedAt,
+		UpdatedAt:      llm.UpdatedAt,
 	}
 
 	s.successResponse(c, response)
@@ -195,16 +259,6 @@ func (s *Server) deleteLLM(c *gin.Context) {
 
 // Helper functions for LLM endpoints
 func (s *Server) isValidProvider(provider string) bool {
-	validProviders := []string{"openai", "anthropic", "ollama", "google", "perplexity"}
+	validProviders := []string{"openai", "anthropic", "ollama", "google", "perplexity", "deepseek", "xai", "bedrock"}
 	return slices.Contains(validProviders, provider)
 }
-
-func (s *Server) maskAPIKey(apiKey string) string {
-	if apiKey == "" {
-		return ""
-	}
-	if len(apiKey) <= 8 {
-		return "***"
-	}
-	return apiKey[:4] + "..." + apiKey[len(apiKey)-4:]
-}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// llmDB stores LLMs in memory and fails every creation when failCreate is set
+type llmDB struct {
+	db.Database
+	llms       []*models.LLMConfig
+	failCreate bool
+}
+
+func (l *llmDB) ListLLMs(ctx context.Context, enabled *bool) ([]*models.LLMConfig, error) {
+	return l.llms, nil
+}
+
+func (l *llmDB) CreateLLM(ctx context.Context, llmConfig *models.LLMConfig) error {
+	if l.failCreate {
+		return errors.New("database is locked")
+	}
+	l.llms = append(l.llms, llmConfig)
+	return nil
+}
+
+func (l *llmDB) GetLLM(ctx context.Context, id string) (*models.LLMConfig, error) {
+	for _, llmConfig := range l.llms {
+		if llmConfig.ID == id {
+			copied := *llmConfig
+			return &copied, nil
+		}
+	}
+	return nil, fmt.Errorf("LLM not found: %s", id)
+}
+
+func (l *llmDB) UpdateLLM(ctx context.Context, llmConfig *models.LLMConfig) error {
+	for i, stored := range l.llms {
+		if stored.ID == llmConfig.ID {
+			l.llms[i] = llmConfig
+			return nil
+		}
+	}
+	return fmt.Errorf("LLM not found: %s", llmConfig.ID)
+}
+
+func TestCreateLLMDuplicate(t *testing.T) {
+	body := `{"name":"GPT","provider":"openai","model":"gpt-4o","api_key":"sk-first-key-0001"}`
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+		wantLLMs int
+	}{
+		{name: "duplicate rejected", wantCode: http.StatusConflict, wantLLMs: 1},
+		{name: "duplicate forced", query: "?force=true", wantCode: http.StatusCreated, wantLLMs: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := &llmDB{llms: []*models.LLMConfig{
+				{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", APIKey: "sk-first-key-0001"},
+			}}
+			server := NewServer(database, "*", nil, nil)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/llms"+tt.query, strings.NewReader(body))
+			request.Header.Set("Content-Type", "application/json")
+			server.router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.wantCode, recorder.Body.String())
+			}
+			if len(database.llms) != tt.wantLLMs {
+				t.Errorf("stored %d LLMs, want %d", len(database.llms), tt.wantLLMs)
+			}
+			if strings.Contains(recorder.Body.String(), "sk-first-key-0001") {
+				t.Error("response contains the API key")
+			}
+		})
+	}
+}
+
+func TestCreateLLMErrorStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		failCreate bool
+		wantCode   int
+	}{
+		{name: "created", body: `{"name":"GPT","provider":"openai","model":"gpt-4o","api_key":"sk-first-key-0001"}`, wantCode: http.StatusCreated},
+		{name: "missing API key", body: `{"name":"GPT","provider":"openai","model":"gpt-4o"}`, wantCode: http.StatusBadRequest},
+		{
+			name:       "storage failure",
+			body:       `{"name":"GPT","provider":"openai","model":"gpt-4o","api_key":"sk-first-key-0001"}`,
+			failCreate: true,
+			wantCode:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(&llmDB{failCreate: tt.failCreate}, "*", nil, nil)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/llms", strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", "application/json")
+			server.router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d: %s", recorder.Code, tt.wantCode, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdateLLMNull(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+		want     func(llmConfig *models.LLMConfig) bool
+	}{
+		{
+			name:     "absent fields stay unchanged",
+			body:     `{}`,
+			wantCode: http.StatusOK,
+			want: func(l *models.LLMConfig) bool {
+				return l.Name == "GPT" && l.BaseURL == "https://proxy.example.com/v1" && l.Config["temperature"] == "0.2" && l.Owner == "marketing"
+			},
+		},
+		{
+			name:     "null clears optional fields",
+			body:     `{"base_url":null,"config":null,"owner":null}`,
+			wantCode: http.StatusOK,
+			want: func(l *models.LLMConfig) bool {
+				return l.Name == "GPT" && l.BaseURL == "" && len(l.Config) == 0 && l.Owner == ""
+			},
+		},
+		{
+			name:     "value replaces the field",
+			body:     `{"config":{"temperature":"0.5"}}`,
+			wantCode: http.StatusOK,
+			want: func(l *models.LLMConfig) bool {
+				return l.Config["temperature"] == "0.5" && l.BaseURL == "https://proxy.example.com/v1"
+			},
+		},
+		{name: "null name rejected", body: `{"name":null}`, wantCode: http.StatusBadRequest},
+		{name: "null model rejected", body: `{"model":null}`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := &models.LLMConfig{
+				ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", APIKey: "sk-first-key-0001",
+				BaseURL: "https://proxy.example.com/v1", Config: map[string]string{"temperature": "0.2"}, Owner: "marketing",
+			}
+			original := *stored
+			original.Config = maps.Clone(stored.Config)
+			database := &llmDB{llms: []*models.LLMConfig{stored}}
+			server := NewServer(database, "*", nil, nil)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPut, "/api/v1/llms/llm-1", strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", "application/json")
+			server.router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.wantCode, recorder.Body.String())
+			}
+			got := database.llms[0]
+			if tt.want == nil {
+				if got.Name != original.Name || got.Model != original.Model || got.BaseURL != original.BaseURL {
+					t.Errorf("rejected update changed the LLM: %+v", got)
+				}
+				return
+			}
+			if !tt.want(got) {
+				t.Errorf("stored LLM = %+v", got)
+			}
+			if got.APIKey != original.APIKey {
+				t.Error("absent api_key changed")
+			}
+		})
+	}
+}
+
+func TestCreateLLMEnabled(t *testing.T) {
+	tests := []struct {
+		name           string
+		enabled        string
+		createDisabled bool
+		want           bool
+	}{
+		{name: "absent defaults to enabled", want: true},
+		{name: "absent with create_disabled", createDisabled: true},
+		{name: "explicit true overrides create_disabled", enabled: `,"enabled":true`, createDisabled: true, want: true},
+		{name: "explicit false", enabled: `,"enabled":false`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := &llmDB{}
+			server := NewServer(database, "*", nil, nil)
+			server.SetCreateDisabled(tt.createDisabled)
+
+			body := `{"name":"GPT","provider":"openai","model":"gpt-4o","api_key":"sk-first-key-0001"` + tt.enabled + `}`
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/llms", strings.NewReader(body))
+			request.Header.Set("Content-Type", "application/json")
+			server.router.ServeHTTP(recorder, request)
+
+			if recorder.Code != http.StatusCreated {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusCreated, recorder.Body.String())
+			}
+			if got := database.llms[0].Enabled; got != tt.want {
+				t.Errorf("enabled = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
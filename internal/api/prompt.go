@@ -1,13 +1,16 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
 	"github.com/AI2HU/gego/internal/shared"
 )
 
@@ -17,12 +20,17 @@ func (s *Server) listPrompts(c *gin.Context) {
 
 	page, limit := s.parsePagination(c)
 
-	prompts, err := s.promptService.ListPrompts(c.Request.Context(), enabled)
+	prompts, err := s.promptService.ListPrompts(s.ownerContext(c), enabled)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to list prompts: "+err.Error())
 		return
 	}
 
+	if err := services.SortPrompts(prompts, c.Query("sort")); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	total := len(prompts)
 	start := (page - 1) * limit
 	end := start + limit
@@ -43,6 +51,7 @@ func (s *Server) listPrompts(c *gin.Context) {
 			Template:  prompt.Template,
 			Tags:      prompt.Tags,
 			Enabled:   prompt.Enabled,
+			Owner:     prompt.Owner,
 			CreatedAt: prompt.CreatedAt,
 			UpdatedAt: prompt.UpdatedAt,
 		}
@@ -76,6 +85,7 @@ func (s *Server) getPrompt(c *gin.Context) {
 		Template:  prompt.Template,
 		Tags:      prompt.Tags,
 		Enabled:   prompt.Enabled,
+		Owner:     prompt.Owner,
 		CreatedAt: prompt.CreatedAt,
 		UpdatedAt: prompt.UpdatedAt,
 	}
@@ -83,6 +93,74 @@ func (s *Server) getPrompt(c *gin.Context) {
 	s.successResponse(c, response)
 }
 
+// getPromptResponses handles GET /api/v1/prompts/:id/responses
+func (s *Server) getPromptResponses(c *gin.Context) {
+	ctx := s.ownerContext(c)
+	id := c.Param("id")
+
+	perLLM, err := strconv.Atoi(c.DefaultQuery("per_llm", "3"))
+	if err != nil || perLLM < 1 || perLLM > services.MaxResponsesPerLLM {
+		s.errorResponse(c, http.StatusBadRequest, fmt.Sprintf("per_llm must be between 1 and %d", services.MaxResponsesPerLLM))
+		return
+	}
+
+	if _, err := s.promptService.GetPrompt(ctx, id); err != nil {
+		s.errorResponse(c, http.StatusNotFound, "Prompt not found: "+err.Error())
+		return
+	}
+
+	var keywords []string
+	for _, value := range c.QueryArray("keyword") {
+		keywords = append(keywords, strings.Split(value, ",")...)
+	}
+
+	groups, err := s.promptService.GetLatestResponsesByLLM(ctx, id, perLLM, keywords)
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to get responses: "+err.Error())
+		return
+	}
+
+	s.successResponse(c, groups)
+}
+
+// getPromptDuplicates handles GET /api/v1/prompts/:id/duplicates
+func (s *Server) getPromptDuplicates(c *gin.Context) {
+	ctx := s.ownerContext(c)
+	id := c.Param("id")
+
+	if _, err := s.promptService.GetPrompt(ctx, id); err != nil {
+		s.errorResponse(c, http.StatusNotFound, "Prompt not found: "+err.Error())
+		return
+	}
+
+	groups, err := s.responseService.FindCrossLLMDuplicates(ctx, id)
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to find duplicate responses: "+err.Error())
+		return
+	}
+
+	s.successResponse(c, groups)
+}
+
+// SetCreateDisabled creates the LLMs and prompts whose create request has no enabled field disabled
+func (s *Server) SetCreateDisabled(disabled bool) {
+	s.createDisabled = disabled
+}
+
+// createEnabled returns the enabled state of a created LLM or prompt, defaulting to enabled unless
+// items are created disabled
+func (s *Server) createEnabled(enabled *bool) bool {
+	if enabled != nil {
+		return *enabled
+	}
+	return !s.createDisabled
+}
+
+// SetPreserveTagCase keeps the case of prompt tags, which are otherwise lowercased
+func (s *Server) SetPreserveTagCase(enabled bool) {
+	s.promptService.SetPreserveTagCase(enabled)
+}
+
 // createPrompt handles POST /api/v1/prompts
 func (s *Server) createPrompt(c *gin.Context) {
 	var req models.CreatePromptRequest
@@ -96,6 +174,7 @@ func (s *Server) createPrompt(c *gin.Context) {
 		return
 	}
 
+	req.Tags = s.promptService.NormalizeTags(req.Tags)
 	if len(req.Tags) > 20 {
 		s.errorResponse(c, http.StatusBadRequest, "Too many tags (max 20)")
 		return
@@ -112,7 +191,8 @@ func (s *Server) createPrompt(c *gin.Context) {
 		ID:       uuid.New().String(),
 		Template: req.Template,
 		Tags:     req.Tags,
-		Enabled:  req.Enabled,
+		Enabled:  s.createEnabled(req.Enabled),
+		Owner:    s.requestOwner(c, req.Owner),
 	}
 
 	if err := s.promptService.CreatePrompt(c.Request.Context(), prompt); err != nil {
@@ -125,6 +205,7 @@ func (s *Server) createPrompt(c *gin.Context) {
 		Template:  prompt.Template,
 		Tags:      prompt.Tags,
 		Enabled:   prompt.Enabled,
+		Owner:     prompt.Owner,
 		CreatedAt: prompt.CreatedAt,
 		UpdatedAt: prompt.UpdatedAt,
 	}
@@ -152,29 +233,35 @@ func (s *Server) updatePrompt(c *gin.Context) {
 		return
 	}
 
-	if req.Template != "" {
-		if len(req.Template) > 10000 {
+	if clearsRequired(req.Template) {
+		s.errorResponse(c, http.StatusBadRequest, "template cannot be cleared")
+		return
+	}
+	if req.Template.Set {
+		if len(req.Template.Value) > 10000 {
 			s.errorResponse(c, http.StatusBadRequest, "Template too long (max 10000 characters)")
 			return
 		}
-		prompt.Template = req.Template
+		prompt.Template = req.Template.Value
 	}
-	if req.Tags != nil {
-		if len(req.Tags) > 20 {
+	if req.Tags.Set && !req.Tags.Null {
+		req.Tags.Value = s.promptService.NormalizeTags(req.Tags.Value)
+		if len(req.Tags.Value) > 20 {
 			s.errorResponse(c, http.StatusBadRequest, "Too many tags (max 20)")
 			return
 		}
-		for i, tag := range req.Tags {
+		for i, tag := range req.Tags.Value {
 			if len(tag) > 50 {
 				s.errorResponse(c, http.StatusBadRequest, "Tag "+strconv.Itoa(i+1)+" too long (max 50 characters)")
 				return
 			}
 		}
-		prompt.Tags = req.Tags
 	}
+	req.Tags.Apply(&prompt.Tags)
 	if req.Enabled != nil {
 		prompt.Enabled = *req.Enabled
 	}
+	req.Owner.Apply(&prompt.Owner)
 
 	if err := s.promptService.UpdatePrompt(c.Request.Context(), prompt); err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to update prompt: "+err.Error())
@@ -186,6 +273,7 @@ func (s *Server) updatePrompt(c *gin.Context) {
 		Template:  prompt.Template,
 		Tags:      prompt.Tags,
 		Enabled:   prompt.Enabled,
+		Owner:     prompt.Owner,
 		CreatedAt: prompt.CreatedAt,
 		UpdatedAt: prompt.UpdatedAt,
 	}
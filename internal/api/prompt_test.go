@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// promptDB stores a single prompt in memory
+type promptDB struct {
+	db.Database
+	prompt *models.Prompt
+}
+
+func (p *promptDB) GetPrompt(ctx context.Context, id string) (*models.Prompt, error) {
+	if id != p.prompt.ID {
+		return nil, fmt.Errorf("prompt not found: %s", id)
+	}
+	copied := *p.prompt
+	return &copied, nil
+}
+
+func (p *promptDB) UpdatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	p.prompt = prompt
+	return nil
+}
+
+func TestUpdatePromptNull(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantCode  int
+		wantTags  []string
+		wantOwner string
+	}{
+		{name: "absent fields stay unchanged", body: `{}`, wantCode: http.StatusOK, wantTags: []string{"crm"}, wantOwner: "sales"},
+		{name: "null clears tags and owner", body: `{"tags":null,"owner":null}`, wantCode: http.StatusOK},
+		{name: "tags are normalized", body: `{"tags":[" CRM ","crm","Sales"]}`, wantCode: http.StatusOK, wantTags: []string{"crm", "sales"}, wantOwner: "sales"},
+		{name: "null template rejected", body: `{"template":null}`, wantCode: http.StatusBadRequest, wantTags: []string{"crm"}, wantOwner: "sales"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := &promptDB{prompt: &models.Prompt{
+				ID: "prompt-1", Template: "What is the best CRM?", Tags: []string{"crm"}, Enabled: true, Owner: "sales",
+			}}
+			server := NewServer(database, "*", nil, nil)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPut, "/api/v1/prompts/prompt-1", strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", "application/json")
+			server.router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.wantCode, recorder.Body.String())
+			}
+			got := database.prompt
+			if got.Template != "What is the best CRM?" {
+				t.Errorf("template = %q", got.Template)
+			}
+			if !slices.Equal(got.Tags, tt.wantTags) {
+				t.Errorf("tags = %v, want %v", got.Tags, tt.wantTags)
+			}
+			if got.Owner != tt.wantOwner {
+				t.Errorf("owner = %q, want %q", got.Owner, tt.wantOwner)
+			}
+		})
+	}
+}
+
+// shuffledPromptDB lists its prompts in a different order on every call, as a database without a
+// sort order may
+type shuffledPromptDB struct {
+	db.Database
+	prompts []*models.Prompt
+}
+
+func (p *shuffledPromptDB) ListPrompts(ctx context.Context, enabled *bool) ([]*models.Prompt, error) {
+	prompts := slices.Clone(p.prompts)
+	rand.Shuffle(len(prompts), func(i, j int) { prompts[i], prompts[j] = prompts[j], prompts[i] })
+	return prompts, nil
+}
+
+func TestListPromptsPagination(t *testing.T) {
+	// Three prompts share each creation time, so pages split ties
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var prompts []*models.Prompt
+	for i := 0; i < 30; i++ {
+		prompts = append(prompts, &models.Prompt{
+			ID:        fmt.Sprintf("prompt-%02d", i),
+			Template:  fmt.Sprintf("Question %d", 29-i),
+			CreatedAt: created.Add(time.Duration(i/3) * time.Hour),
+		})
+	}
+	server := NewServer(&shuffledPromptDB{prompts: prompts}, "*", nil, nil)
+
+	for _, order := range []string{"created_at", "template"} {
+		t.Run(order, func(t *testing.T) {
+			var seen []string
+			for page := 1; page <= 5; page++ {
+				recorder := httptest.NewRecorder()
+				url := fmt.Sprintf("/api/v1/prompts?page=%d&limit=7&sort=%s", page, order)
+				server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+				if recorder.Code != http.StatusOK {
+					t.Fatalf("page %d: status = %d: %s", page, recorder.Code, recorder.Body.String())
+				}
+
+				var body struct {
+					Data       []models.PromptResponse `json:"data"`
+					Pagination models.Pagination       `json:"pagination"`
+				}
+				if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+					t.Fatal(err)
+				}
+				if body.Pagination.Total != 30 || body.Pagination.TotalPages != 5 {
+					t.Errorf("page %d: pagination = %+v, want 30 prompts on 5 pages", page, body.Pagination)
+				}
+				for _, prompt := range body.Data {
+					seen = append(seen, prompt.ID)
+				}
+			}
+
+			if len(seen) != 30 {
+				t.Fatalf("walked %d prompts, want 30", len(seen))
+			}
+			sorted := slices.Clone(seen)
+			slices.Sort(sorted)
+			if len(slices.Compact(sorted)) != 30 {
+				t.Errorf("pages repeat prompts: %v", seen)
+			}
+			if order == "created_at" && (seen[0] != "prompt-27" || seen[29] != "prompt-02") {
+				t.Errorf("first and last prompts = %s and %s, want prompt-27 and prompt-02", seen[0], seen[29])
+			}
+		})
+	}
+}
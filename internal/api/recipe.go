@@ -0,0 +1,163 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
+)
+
+// listRecipes handles GET /api/v1/recipes
+func (s *Server) listRecipes(c *gin.Context) {
+	recipes, err := s.recipeService.ListRecipes(c.Request.Context())
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to list recipes: "+err.Error())
+		return
+	}
+
+	if recipes == nil {
+		recipes = []*models.GenerationRecipe{}
+	}
+
+	s.successResponse(c, recipes)
+}
+
+// getRecipe handles GET /api/v1/recipes/:id
+func (s *Server) getRecipe(c *gin.Context) {
+	id := c.Param("id")
+
+	recipe, err := s.recipeService.GetRecipe(c.Request.Context(), id)
+	if err != nil {
+		s.errorResponse(c, http.StatusNotFound, "Recipe not found: "+err.Error())
+		return
+	}
+
+	s.successResponse(c, recipe)
+}
+
+// createRecipe handles POST /api/v1/recipes
+func (s *Server) createRecipe(c *gin.Context) {
+	var req models.CreateRecipeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	recipe := &models.GenerationRecipe{
+		Name:      req.Name,
+		LLMID:     req.LLMID,
+		Language:  req.Language,
+		UserInput: req.UserInput,
+		Count:     req.Count,
+		Tags:      req.Tags,
+	}
+	if recipe.Language == "" {
+		recipe.Language = "EN"
+	}
+	if recipe.Count == 0 {
+		recipe.Count = 20
+	}
+
+	if err := s.recipeService.ValidateRecipe(c.Request.Context(), recipe); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.recipeService.CreateRecipe(c.Request.Context(), recipe); err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to create recipe: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    recipe,
+		Message: "Recipe created successfully",
+	})
+}
+
+// updateRecipe handles PUT /api/v1/recipes/:id
+func (s *Server) updateRecipe(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.UpdateRecipeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	recipe, err := s.recipeService.GetRecipe(c.Request.Context(), id)
+	if err != nil {
+		s.errorResponse(c, http.StatusNotFound, "Recipe not found: "+err.Error())
+		return
+	}
+
+	if req.Name != "" {
+		recipe.Name = req.Name
+	}
+	if req.LLMID != "" {
+		recipe.LLMID = req.LLMID
+	}
+	if req.Language != "" {
+		recipe.Language = req.Language
+	}
+	if req.UserInput != "" {
+		recipe.UserInput = req.UserInput
+	}
+	if req.Count != nil {
+		recipe.Count = *req.Count
+	}
+	if req.Tags != nil {
+		recipe.Tags = req.Tags
+	}
+
+	if err := s.recipeService.ValidateRecipe(c.Request.Context(), recipe); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.recipeService.UpdateRecipe(c.Request.Context(), recipe); err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to update recipe: "+err.Error())
+		return
+	}
+
+	s.successResponse(c, recipe)
+}
+
+// deleteRecipe handles DELETE /api/v1/recipes/:id
+func (s *Server) deleteRecipe(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := s.recipeService.DeleteRecipe(c.Request.Context(), id); err != nil {
+		s.errorResponse(c, http.StatusNotFound, "Recipe not found: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Recipe deleted successfully",
+	})
+}
+
+// runRecipe handles POST /api/v1/recipes/:id/run, generating and saving prompts with the recipe
+func (s *Server) runRecipe(c *gin.Context) {
+	id := c.Param("id")
+
+	if _, err := s.recipeService.GetRecipe(c.Request.Context(), id); err != nil {
+		s.errorResponse(c, http.StatusNotFound, "Recipe not found: "+err.Error())
+		return
+	}
+
+	result, err := s.recipeService.RunRecipe(c.Request.Context(), id, services.NewPromptGenerationService(s.llmRegistry))
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to run recipe: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    result,
+		Message: "Recipe run completed",
+	})
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// recipeDB serves one recipe and its LLM, and records the prompts created
+type recipeDB struct {
+	db.Database
+	recipe  *models.GenerationRecipe
+	llm     *models.LLMConfig
+	prompts []*models.Prompt
+}
+
+func (r *recipeDB) GetRecipe(ctx context.Context, id string) (*models.GenerationRecipe, error) {
+	if id != r.recipe.ID {
+		return nil, fmt.Errorf("recipe not found: %s", id)
+	}
+	copied := *r.recipe
+	return &copied, nil
+}
+
+func (r *recipeDB) UpdateRecipe(ctx context.Context, recipe *models.GenerationRecipe) error {
+	r.recipe = recipe
+	return nil
+}
+
+func (r *recipeDB) GetLLM(ctx context.Context, id string) (*models.LLMConfig, error) {
+	if id != r.llm.ID {
+		return nil, fmt.Errorf("LLM not found: %s", id)
+	}
+	return r.llm, nil
+}
+
+func (r *recipeDB) ListPrompts(ctx context.Context, enabled *bool) ([]*models.Prompt, error) {
+	return r.prompts, nil
+}
+
+func (r *recipeDB) CreatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	r.prompts = append(r.prompts, prompt)
+	return nil
+}
+
+// listProvider answers every prompt with a fixed numbered list
+type listProvider struct{}
+
+func (listProvider) Name() string { return "openai" }
+
+func (listProvider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
+	return &llm.Response{Text: "1. What is the best project management tool?\n2. Which tool do small teams use to plan sprints?"}, nil
+}
+
+func (listProvider) Validate(config map[string]string) error { return nil }
+
+func (listProvider) ListModels(ctx context.Context, apiKey, baseURL string) ([]models.ModelInfo, error) {
+	return nil, nil
+}
+
+func TestRunRecipe(t *testing.T) {
+	registry := llm.NewRegistry()
+	registry.Register(listProvider{})
+
+	tests := []struct {
+		name      string
+		registry  *llm.Registry
+		recipeID  string
+		wantCode  int
+		wantSaved int
+	}{
+		{name: "runs the recipe", registry: registry, recipeID: "recipe-1", wantCode: http.StatusOK, wantSaved: 2},
+		{name: "unknown recipe", registry: registry, recipeID: "missing", wantCode: http.StatusNotFound},
+		{name: "no LLM providers", recipeID: "recipe-1", wantCode: http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := &recipeDB{
+				recipe: &models.GenerationRecipe{ID: "recipe-1", Name: "PM tools", LLMID: "llm-1", Language: "EN", UserInput: "project management tools", Count: 2, Tags: []string{"pm"}},
+				llm:    &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o"},
+			}
+			server := NewServer(database, "*", tt.registry, nil)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/recipes/"+tt.recipeID+"/run", nil)
+			server.router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.wantCode, recorder.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data struct {
+					Saved []*models.Prompt `json:"saved"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if len(body.Data.Saved) != tt.wantSaved || len(database.prompts) != tt.wantSaved {
+				t.Errorf("saved %d prompts (%d stored), want %d", len(body.Data.Saved), len(database.prompts), tt.wantSaved)
+			}
+			if database.recipe.LastRun == nil {
+				t.Error("recipe last run not recorded")
+			}
+		})
+	}
+}
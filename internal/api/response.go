@@ -0,0 +1,173 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// listResponses handles GET /api/v1/responses
+func (s *Server) listResponses(c *gin.Context) {
+	page, limit := s.parsePagination(c)
+
+	filter := shared.ResponseFilter{
+		PromptID:      c.Query("prompt_id"),
+		LLMID:         c.Query("llm_id"),
+		ScheduleID:    c.Query("schedule_id"),
+		RunID:         c.Query("run_id"),
+		Label:         c.Query("label"),
+		ExcludeLabels: shared.NormalizeLabels(c.QueryArray("exclude_label")),
+	}
+
+	if hasError := c.Query("has_error"); hasError != "" {
+		value, err := strconv.ParseBool(hasError)
+		if err != nil {
+			s.errorResponse(c, http.StatusBadRequest, "has_error must be true or false")
+			return
+		}
+		filter.HasError = &value
+	}
+	if grounded := c.Query("grounded"); grounded != "" {
+		value, err := strconv.ParseBool(grounded)
+		if err != nil {
+			s.errorResponse(c, http.StatusBadRequest, "grounded must be true or false")
+			return
+		}
+		filter.Grounded = &value
+	}
+	switch c.Query("order") {
+	case "", "desc":
+	case "asc":
+		filter.SortAsc = true
+	default:
+		s.errorResponse(c, http.StatusBadRequest, "order must be asc or desc")
+		return
+	}
+
+	ctx := s.ownerContext(c)
+	total, err := s.responseService.CountResponses(ctx, filter)
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to count responses: "+err.Error())
+		return
+	}
+
+	filter.Limit = limit
+	filter.Offset = (page - 1) * limit
+	if after := c.Query("after"); after != "" {
+		cursor, err := shared.ParseResponseCursor(after)
+		if err != nil {
+			s.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.After = cursor
+		filter.Offset = 0
+	}
+	responses, err := s.responseService.ListResponses(ctx, filter)
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to list responses: "+err.Error())
+		return
+	}
+	if responses == nil {
+		responses = []*models.Response{}
+	}
+	if c.Query("include_prompt_text") == "true" {
+		if err := services.NewPromptTextResolver(s.db).Resolve(ctx, responses); err != nil {
+			s.errorResponse(c, http.StatusInternalServerError, "Failed to resolve prompt text: "+err.Error())
+			return
+		}
+	}
+
+	pagination := models.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}
+	if len(responses) == limit {
+		last := responses[len(responses)-1]
+		pagination.NextCursor = shared.NewResponseCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Data:       responses,
+		Pagination: pagination,
+	})
+}
+
+// listAnnotations handles GET /api/v1/responses/:id/annotations
+func (s *Server) listAnnotations(c *gin.Context) {
+	annotations, err := s.responseService.ListAnnotations(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, http.StatusNotFound, "Response not found: "+err.Error())
+		return
+	}
+	if annotations == nil {
+		annotations = []models.Annotation{}
+	}
+
+	s.successResponse(c, annotations)
+}
+
+// annotateResponse handles POST /api/v1/responses/:id/annotations
+func (s *Server) annotateResponse(c *gin.Context) {
+	var req models.AnnotateResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	author := req.Author
+	if author == "" {
+		author = s.requestOwner(c, "")
+	}
+
+	annotation, err := services.NewAnnotation(req.Label, req.Note, author)
+	if err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.responseService.AddAnnotation(c.Request.Context(), c.Param("id"), annotation); err != nil {
+		s.errorResponse(c, http.StatusNotFound, "Response not found: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    annotation,
+		Message: "Annotation added",
+	})
+}
+
+// compareResponses handles GET /api/v1/compare/responses
+func (s *Server) compareResponses(c *gin.Context) {
+	promptID := c.Query("prompt")
+	llmIDs := c.QueryArray("llm")
+	if promptID == "" {
+		s.errorResponse(c, http.StatusBadRequest, "prompt is required")
+		return
+	}
+	if len(llmIDs) != 2 || llmIDs[0] == llmIDs[1] {
+		s.errorResponse(c, http.StatusBadRequest, "exactly two different llm parameters are required")
+		return
+	}
+
+	ctx := s.ownerContext(c)
+	if _, err := s.promptService.GetPrompt(ctx, promptID); err != nil {
+		s.errorResponse(c, http.StatusNotFound, "Prompt not found: "+err.Error())
+		return
+	}
+
+	comparison, err := s.responseService.CompareResponses(ctx, promptID, llmIDs[0], llmIDs[1], c.QueryArray("keyword"))
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to compare responses: "+err.Error())
+		return
+	}
+
+	s.successResponse(c, comparison)
+}
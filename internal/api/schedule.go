@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
 	"github.com/AI2HU/gego/internal/shared"
 )
 
@@ -18,7 +21,7 @@ func (s *Server) listSchedules(c *gin.Context) {
 
 	page, limit := s.parsePagination(c)
 
-	schedules, err := s.scheduleService.ListSchedules(c.Request.Context(), enabled)
+	schedules, err := s.scheduleService.ListSchedules(s.ownerContext(c), enabled)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to list schedules: "+err.Error())
 		return
@@ -40,17 +43,29 @@ func (s *Server) listSchedules(c *gin.Context) {
 	responses := make([]models.ScheduleResponse, len(schedules))
 	for i, schedule := range schedules {
 		responses[i] = models.ScheduleResponse{
-			ID:          schedule.ID,
-			Name:        schedule.Name,
-			PromptIDs:   schedule.PromptIDs,
-			LLMIDs:      schedule.LLMIDs,
-			CronExpr:    schedule.CronExpr,
-			Temperature: schedule.Temperature,
-			Enabled:     schedule.Enabled,
-			LastRun:     schedule.LastRun,
-			NextRun:     schedule.NextRun,
-			CreatedAt:   schedule.CreatedAt,
-			UpdatedAt:   schedule.UpdatedAt,
+			ID:            schedule.ID,
+			Name:          schedule.Name,
+			PromptIDs:     schedule.PromptIDs,
+			LLMIDs:        schedule.LLMIDs,
+			AllPrompts:    schedule.AllPrompts,
+			AllLLMs:       schedule.AllLLMs,
+			CronExpr:      schedule.CronExpr,
+			Temperature:   schedule.Temperature,
+			Enabled:       schedule.Enabled,
+			LastRun:       schedule.LastRun,
+			NextRun:       schedule.NextRun,
+			CatchUpPolicy: schedule.CatchUpPolicy,
+			CatchUpMax:    schedule.CatchUpMax,
+			MissedRuns:    schedule.MissedRuns,
+			Seed:          schedule.Seed,
+			Shuffle:       schedule.Shuffle,
+			CheckLanguage: schedule.CheckLanguage,
+			PromptWeights: schedule.PromptWeights,
+			SampleCount:   schedule.SampleCount,
+			Owner:         schedule.Owner,
+			LastError:     schedule.LastError,
+			CreatedAt:     schedule.CreatedAt,
+			UpdatedAt:     schedule.UpdatedAt,
 		}
 	}
 
@@ -78,22 +93,65 @@ func (s *Server) getSchedule(c *gin.Context) {
 	}
 
 	response := models.ScheduleResponse{
-		ID:          schedule.ID,
-		Name:        schedule.Name,
-		PromptIDs:   schedule.PromptIDs,
-		LLMIDs:      schedule.LLMIDs,
-		CronExpr:    schedule.CronExpr,
-		Temperature: schedule.Temperature,
-		Enabled:     schedule.Enabled,
-		LastRun:     schedule.LastRun,
-		NextRun:     schedule.NextRun,
-		CreatedAt:   schedule.CreatedAt,
-		UpdatedAt:   schedule.UpdatedAt,
+		ID:            schedule.ID,
+		Name:          schedule.Name,
+		PromptIDs:     schedule.PromptIDs,
+		LLMIDs:        schedule.LLMIDs,
+		AllPrompts:    schedule.AllPrompts,
+		AllLLMs:       schedule.AllLLMs,
+		CronExpr:      schedule.CronExpr,
+		Temperature:   schedule.Temperature,
+		Enabled:       schedule.Enabled,
+		LastRun:       schedule.LastRun,
+		NextRun:       schedule.NextRun,
+		CatchUpPolicy: schedule.CatchUpPolicy,
+		CatchUpMax:    schedule.CatchUpMax,
+		MissedRuns:    schedule.MissedRuns,
+		Seed:          schedule.Seed,
+		Shuffle:       schedule.Shuffle,
+		CheckLanguage: schedule.CheckLanguage,
+		PromptWeights: schedule.PromptWeights,
+		SampleCount:   schedule.SampleCount,
+		Owner:         schedule.Owner,
+		LastError:     schedule.LastError,
+		CreatedAt:     schedule.CreatedAt,
+		UpdatedAt:     schedule.UpdatedAt,
 	}
 
 	s.successResponse(c, response)
 }
 
+// getScheduleNextRuns handles GET /api/v1/schedules/:id/next
+func (s *Server) getScheduleNextRuns(c *gin.Context) {
+	id := c.Param("id")
+
+	count, err := strconv.Atoi(c.DefaultQuery("count", "5"))
+	if err != nil || count < 1 || count > services.MaxNextRuns {
+		s.errorResponse(c, http.StatusBadRequest, fmt.Sprintf("count must be between 1 and %d", services.MaxNextRuns))
+		return
+	}
+
+	schedule, err := s.scheduleService.GetSchedule(c.Request.Context(), id)
+	if err != nil {
+		s.errorResponse(c, http.StatusNotFound, "Schedule not found: "+err.Error())
+		return
+	}
+
+	nextRuns, err := services.NextRuns(schedule.CronExpr, time.Now().UTC(), count)
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.successResponse(c, models.ScheduleNextRunsResponse{
+		ID:       schedule.ID,
+		CronExpr: schedule.CronExpr,
+		Enabled:  schedule.Enabled,
+		LastRun:  schedule.LastRun,
+		NextRuns: nextRuns,
+	})
+}
+
 // createSchedule handles POST /api/v1/schedules
 func (s *Server) createSchedule(c *gin.Context) {
 	var req models.CreateScheduleRequest
@@ -107,12 +165,12 @@ func (s *Server) createSchedule(c *gin.Context) {
 		return
 	}
 
-	if len(req.PromptIDs) == 0 {
-		s.errorResponse(c, http.StatusBadRequest, "At least one prompt ID is required")
+	if len(req.PromptIDs) == 0 && !req.AllPrompts {
+		s.errorResponse(c, http.StatusBadRequest, "At least one prompt ID is required (or set all_prompts)")
 		return
 	}
-	if len(req.LLMIDs) == 0 {
-		s.errorResponse(c, http.StatusBadRequest, "At least one LLM ID is required")
+	if len(req.LLMIDs) == 0 && !req.AllLLMs {
+		s.errorResponse(c, http.StatusBadRequest, "At least one LLM ID is required (or set all_llms)")
 		return
 	}
 	if len(req.PromptIDs) > 50 {
@@ -128,20 +186,44 @@ func (s *Server) createSchedule(c *gin.Context) {
 		s.errorResponse(c, http.StatusBadRequest, "Cron expression is required")
 		return
 	}
+	cronExpr, err := services.ParseScheduleDescriptor(req.CronExpr)
+	if err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	if err := s.validateScheduleReferences(c.Request.Context(), req.PromptIDs, req.LLMIDs); err != nil {
+	if err := services.ValidateCatchUpPolicy(req.CatchUpPolicy); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := services.ValidatePromptSampling(req.PromptWeights, req.SampleCount); err != nil {
 		s.errorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	schedule := &models.Schedule{
-		ID:          uuid.New().String(),
-		Name:        req.Name,
-		PromptIDs:   req.PromptIDs,
-		LLMIDs:      req.LLMIDs,
-		CronExpr:    req.CronExpr,
-		Temperature: req.Temperature,
-		Enabled:     req.Enabled,
+		ID:            uuid.New().String(),
+		Name:          req.Name,
+		PromptIDs:     req.PromptIDs,
+		LLMIDs:        req.LLMIDs,
+		AllPrompts:    req.AllPrompts,
+		AllLLMs:       req.AllLLMs,
+		CronExpr:      cronExpr,
+		Temperature:   req.Temperature,
+		Enabled:       req.Enabled,
+		CatchUpPolicy: req.CatchUpPolicy,
+		CatchUpMax:    req.CatchUpMax,
+		Seed:          req.Seed,
+		Shuffle:       req.Shuffle,
+		CheckLanguage: req.CheckLanguage,
+		PromptWeights: req.PromptWeights,
+		SampleCount:   req.SampleCount,
+		Owner:         s.requestOwner(c, req.Owner),
+	}
+
+	if err := s.validateScheduleReferences(c.Request.Context(), schedule); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
 	if err := s.scheduleService.CreateSchedule(c.Request.Context(), schedule); err != nil {
@@ -150,18 +232,31 @@ func (s *Server) createSchedule(c *gin.Context) {
 	}
 
 	response := models.ScheduleResponse{
-		ID:          schedule.ID,
-		Name:        schedule.Name,
-		PromptIDs:   schedule.PromptIDs,
-		LLMIDs:      schedule.LLMIDs,
-		CronExpr:    schedule.CronExpr,
-		Temperature: schedule.Temperature,
-		Enabled:     schedule.Enabled,
-		LastRun:     schedule.LastRun,
-		NextRun:     schedule.NextRun,
-		CreatedAt:   schedule.CreatedAt,
-		UpdatedAt:   schedule.UpdatedAt,
+		ID:            schedule.ID,
+		Name:          schedule.Name,
+		PromptIDs:     schedule.PromptIDs,
+		LLMIDs:        schedule.LLMIDs,
+		AllPrompts:    schedule.AllPrompts,
+		AllLLMs:       schedule.AllLLMs,
+		CronExpr:      schedule.CronExpr,
+		Temperature:   schedule.Temperature,
+		Enabled:       schedule.Enabled,
+		LastRun:       schedule.LastRun,
+		NextRun:       schedule.NextRun,
+		CatchUpPolicy: schedule.CatchUpPolicy,
+		CatchUpMax:    schedule.CatchUpMax,
+		MissedRuns:    schedule.MissedRuns,
+		Seed:          schedule.Seed,
+		Shuffle:       schedule.Shuffle,
+		CheckLanguage: schedule.CheckLanguage,
+		PromptWeights: schedule.PromptWeights,
+		SampleCount:   schedule.SampleCount,
+		Owner:         schedule.Owner,
+		LastError:     schedule.LastError,
+		CreatedAt:     schedule.CreatedAt,
+		UpdatedAt:     schedule.UpdatedAt,
 	}
+	response.NextRuns, _ = services.NextRuns(schedule.CronExpr, time.Now().UTC(), 3)
 
 	c.JSON(http.StatusCreated, models.APIResponse{
 		Success: true,
@@ -186,11 +281,22 @@ func (s *Server) updateSchedule(c *gin.Context) {
 		return
 	}
 
-	if req.Name != "" {
-		schedule.Name = req.Name
+	for field, value := range map[string]models.Nullable[string]{"name": req.Name, "cron_expr": req.CronExpr} {
+		if clearsRequired(value) {
+			s.errorResponse(c, http.StatusBadRequest, field+" cannot be cleared")
+			return
+		}
+	}
+
+	req.Name.Apply(&schedule.Name)
+	if req.AllPrompts != nil {
+		schedule.AllPrompts = *req.AllPrompts
+	}
+	if req.AllLLMs != nil {
+		schedule.AllLLMs = *req.AllLLMs
 	}
 	if req.PromptIDs != nil {
-		if len(req.PromptIDs) == 0 {
+		if len(req.PromptIDs) == 0 && !schedule.AllPrompts {
 			s.errorResponse(c, http.StatusBadRequest, "At least one prompt ID is required")
 			return
 		}
@@ -201,7 +307,7 @@ func (s *Server) updateSchedule(c *gin.Context) {
 		schedule.PromptIDs = req.PromptIDs
 	}
 	if req.LLMIDs != nil {
-		if len(req.LLMIDs) == 0 {
+		if len(req.LLMIDs) == 0 && !schedule.AllLLMs {
 			s.errorResponse(c, http.StatusBadRequest, "At least one LLM ID is required")
 			return
 		}
@@ -211,8 +317,14 @@ func (s *Server) updateSchedule(c *gin.Context) {
 		}
 		schedule.LLMIDs = req.LLMIDs
 	}
-	if req.CronExpr != "" {
-		schedule.CronExpr = req.CronExpr
+	if req.CronExpr.Set {
+		cronExpr, err := services.ParseScheduleDescriptor(req.CronExpr.Value)
+		if err != nil {
+			s.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		schedule.CronExpr = cronExpr
+		schedule.LastError = ""
 	}
 	if req.Temperature != nil {
 		if *req.Temperature < 0.0 || *req.Temperature > 1.0 {
@@ -224,9 +336,42 @@ func (s *Server) updateSchedule(c *gin.Context) {
 	if req.Enabled != nil {
 		schedule.Enabled = *req.Enabled
 	}
+	if err := services.ValidateCatchUpPolicy(req.CatchUpPolicy.Value); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.CatchUpPolicy.Apply(&schedule.CatchUpPolicy)
+	if req.CatchUpMax != nil {
+		if *req.CatchUpMax < 0 {
+			s.errorResponse(c, http.StatusBadRequest, "Catch-up max must not be negative")
+			return
+		}
+		schedule.CatchUpMax = *req.CatchUpMax
+	}
+	if req.Seed.Set {
+		schedule.Seed = nil
+		if !req.Seed.Null {
+			schedule.Seed = &req.Seed.Value
+		}
+	}
+	if req.Shuffle != nil {
+		schedule.Shuffle = *req.Shuffle
+	}
+	if req.CheckLanguage != nil {
+		schedule.CheckLanguage = *req.CheckLanguage
+	}
+	req.PromptWeights.Apply(&schedule.PromptWeights)
+	if req.SampleCount != nil {
+		schedule.SampleCount = *req.SampleCount
+	}
+	if err := services.ValidatePromptSampling(schedule.PromptWeights, schedule.SampleCount); err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Owner.Apply(&schedule.Owner)
 
-	if req.PromptIDs != nil || req.LLMIDs != nil {
-		if err := s.validateScheduleReferences(c.Request.Context(), schedule.PromptIDs, schedule.LLMIDs); err != nil {
+	if req.PromptIDs != nil || req.LLMIDs != nil || req.AllPrompts != nil || req.AllLLMs != nil {
+		if err := s.validateScheduleReferences(c.Request.Context(), schedule); err != nil {
 			s.errorResponse(c, http.StatusBadRequest, err.Error())
 			return
 		}
@@ -238,18 +383,31 @@ func (s *Server) updateSchedule(c *gin.Context) {
 	}
 
 	response := models.ScheduleResponse{
-		ID:          schedule.ID,
-		Name:        schedule.Name,
-		PromptIDs:   schedule.PromptIDs,
-		LLMIDs:      schedule.LLMIDs,
-		CronExpr:    schedule.CronExpr,
-		Temperature: schedule.Temperature,
-		Enabled:     schedule.Enabled,
-		LastRun:     schedule.LastRun,
-		NextRun:     schedule.NextRun,
-		CreatedAt:   schedule.CreatedAt,
-		UpdatedAt:   schedule.UpdatedAt,
+		ID:            schedule.ID,
+		Name:          schedule.Name,
+		PromptIDs:     schedule.PromptIDs,
+		LLMIDs:        schedule.LLMIDs,
+		AllPrompts:    schedule.AllPrompts,
+		AllLLMs:       schedule.AllLLMs,
+		CronExpr:      schedule.CronExpr,
+		Temperature:   schedule.Temperature,
+		Enabled:       schedule.Enabled,
+		LastRun:       schedule.LastRun,
+		NextRun:       schedule.NextRun,
+		CatchUpPolicy: schedule.CatchUpPolicy,
+		CatchUpMax:    schedule.CatchUpMax,
+		MissedRuns:    schedule.MissedRuns,
+		Seed:          schedule.Seed,
+		Shuffle:       schedule.Shuffle,
+		CheckLanguage: schedule.CheckLanguage,
+		PromptWeights: schedule.PromptWeights,
+		SampleCount:   schedule.SampleCount,
+		Owner:         schedule.Owner,
+		LastError:     schedule.LastError,
+		CreatedAt:     schedule.CreatedAt,
+		UpdatedAt:     schedule.UpdatedAt,
 	}
+	response.NextRuns, _ = services.NextRuns(schedule.CronExpr, time.Now().UTC(), 3)
 
 	s.successResponse(c, response)
 }
@@ -269,17 +427,28 @@ func (s *Server) deleteSchedule(c *gin.Context) {
 	})
 }
 
-// validateScheduleReferences validates that all referenced prompts and LLMs exist
-func (s *Server) validateScheduleReferences(ctx context.Context, promptIDs, llmIDs []string) error {
-	for _, promptID := range promptIDs {
-		if _, err := s.promptService.GetPrompt(ctx, promptID); err != nil {
-			return fmt.Errorf("prompt not found: %s", promptID)
+// validateScheduleReferences validates that all referenced prompts and LLMs exist,
+// skipping the IDs that all_prompts or all_llms replace
+func (s *Server) validateScheduleReferences(ctx context.Context, schedule *models.Schedule) error {
+	if !schedule.AllPrompts {
+		if len(schedule.PromptIDs) == 0 {
+			return fmt.Errorf("at least one prompt ID is required (or set all_prompts)")
+		}
+		for _, promptID := range schedule.PromptIDs {
+			if _, err := s.promptService.GetPrompt(ctx, promptID); err != nil {
+				return fmt.Errorf("prompt not found: %s", promptID)
+			}
 		}
 	}
 
-	for _, llmID := range llmIDs {
-		if _, err := s.llmService.GetLLM(ctx, llmID); err != nil {
-			return fmt.Errorf("LLM not found: %s", llmID)
+	if !schedule.AllLLMs {
+		if len(schedule.LLMIDs) == 0 {
+			return fmt.Errorf("at least one LLM ID is required (or set all_llms)")
+		}
+		for _, llmID := range schedule.LLMIDs {
+			if _, err := s.llmService.GetLLM(ctx, llmID); err != nil {
+				return fmt.Errorf("LLM not found: %s", llmID)
+			}
 		}
 	}
 
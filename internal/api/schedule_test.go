@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// scheduleDB serves a fixed set of schedules
+type scheduleDB struct {
+	db.Database
+	schedules map[string]*models.Schedule
+}
+
+func (s *scheduleDB) GetSchedule(ctx context.Context, id string) (*models.Schedule, error) {
+	schedule, ok := s.schedules[id]
+	if !ok {
+		return nil, fmt.Errorf("schedule not found: %s", id)
+	}
+	return schedule, nil
+}
+
+func TestGetScheduleNextRuns(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		wantCode  int
+		wantCount int
+	}{
+		{name: "default count", path: "/api/v1/schedules/schedule-1/next", wantCode: http.StatusOK, wantCount: 5},
+		{name: "explicit count", path: "/api/v1/schedules/schedule-1/next?count=3", wantCode: http.StatusOK, wantCount: 3},
+		{name: "count too low", path: "/api/v1/schedules/schedule-1/next?count=0", wantCode: http.StatusBadRequest},
+		{name: "count too high", path: "/api/v1/schedules/schedule-1/next?count=101", wantCode: http.StatusBadRequest},
+		{name: "count not a number", path: "/api/v1/schedules/schedule-1/next?count=all", wantCode: http.StatusBadRequest},
+		{name: "unknown schedule", path: "/api/v1/schedules/missing/next", wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := &scheduleDB{schedules: map[string]*models.Schedule{
+				"schedule-1": {ID: "schedule-1", Name: "Daily", CronExpr: "0 9 * * *", Enabled: true},
+			}}
+			server := NewServer(database, "*", nil, nil)
+
+			before := time.Now().UTC()
+			recorder := httptest.NewRecorder()
+			server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if recorder.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.wantCode, recorder.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data models.ScheduleNextRunsResponse `json:"data"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Data.ID != "schedule-1" || body.Data.CronExpr != "0 9 * * *" || !body.Data.Enabled {
+				t.Errorf("schedule = %+v, want schedule-1 enabled at 0 9 * * *", body.Data)
+			}
+			if len(body.Data.NextRuns) != tt.wantCount {
+				t.Fatalf("got %d next runs, want %d", len(body.Data.NextRuns), tt.wantCount)
+			}
+			previous := before
+			for _, run := range body.Data.NextRuns {
+				if !run.After(previous) || run.Sub(previous) > 24*time.Hour || run.Hour() != 9 || run.Minute() != 0 {
+					t.Errorf("next runs = %v, want consecutive days at 09:00 after %v", body.Data.NextRuns, before)
+					break
+				}
+				previous = run
+			}
+		})
+	}
+}
@@ -2,13 +2,20 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
 	"github.com/AI2HU/gego/internal/shared"
 )
 
+// SetKeywordSearchOptions bounds the responses scanned by keyword searches
+func (s *Server) SetKeywordSearchOptions(opts shared.KeywordSearchOptions) {
+	s.searchService.SetKeywordSearchOptions(opts)
+}
+
 // search handles POST /api/v1/search
 func (s *Server) search(c *gin.Context) {
 	var req models.SearchRequest
@@ -30,36 +37,88 @@ func (s *Server) search(c *gin.Context) {
 		req.Limit = 100
 	}
 
-	keywordStats, err := s.searchService.SearchKeyword(c.Request.Context(), req.Keyword, req.StartTime, req.EndTime)
+	ctx := s.ownerContext(c)
+	if req.Owner != "" {
+		ctx = shared.WithOwner(ctx, req.Owner)
+	}
+	ctx = shared.WithExcludedLabels(ctx, req.ExcludeLabels)
+	ctx = shared.WithErrorResponses(ctx, req.IncludeErrors)
+	if req.MinLength < 0 {
+		s.errorResponse(c, http.StatusBadRequest, "min_length must not be negative")
+		return
+	}
+	ctx, _ = shared.WithMinResponseLength(ctx, req.MinLength)
+	countMode, err := shared.ParseCountMode(req.CountMode)
+	if err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctx = shared.WithCountMode(ctx, countMode)
+	if req.ComparePrevious && req.StartTime == nil {
+		s.errorResponse(c, http.StatusBadRequest, "compare_previous requires start_time")
+		return
+	}
+
+	keywordStats, err := s.searchService.SearchKeyword(ctx, req.Keyword, req.StartTime, req.EndTime)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to search keyword: "+err.Error())
 		return
 	}
 
+	var previousPeriod *models.KeywordPeriodChange
+	if req.ComparePrevious {
+		end := time.Now()
+		if req.EndTime != nil {
+			end = *req.EndTime
+		}
+		previousStart, previousEnd := services.PreviousWindow(*req.StartTime, end)
+		previous, err := s.searchService.SearchKeyword(ctx, req.Keyword, &previousStart, &previousEnd)
+		if err != nil {
+			s.errorResponse(c, http.StatusInternalServerError, "Failed to search previous period: "+err.Error())
+			return
+		}
+		previousPeriod = services.CompareKeywordStats(keywordStats, previous, previousStart, previousEnd)
+	}
+
 	filter := shared.ResponseFilter{
-		Keyword:   req.Keyword,
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
-		Limit:     req.Limit,
+		Keyword:       req.Keyword,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		Limit:         req.Limit,
+		ExcludeLabels: req.ExcludeLabels,
+		HasError:      shared.ErrorFilterFromContext(ctx),
 	}
 
-	responses, err := s.searchService.ListResponses(c.Request.Context(), filter)
+	responses, err := s.searchService.ListResponses(ctx, filter)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to get responses: "+err.Error())
 		return
 	}
+	responses, _ = services.DropShortResponses(responses, req.MinLength)
+	if req.IncludePromptText {
+		if err := services.NewPromptTextResolver(s.db).Resolve(ctx, responses); err != nil {
+			s.errorResponse(c, http.StatusInternalServerError, "Failed to resolve prompt text: "+err.Error())
+			return
+		}
+	}
 
 	response := models.SearchResponse{
-		Keyword:       keywordStats.Keyword,
-		TotalMentions: keywordStats.TotalMentions,
-		UniquePrompts: keywordStats.UniquePrompts,
-		UniqueLLMs:    keywordStats.UniqueLLMs,
-		ByPrompt:      keywordStats.ByPrompt,
-		ByLLM:         keywordStats.ByLLM,
-		ByProvider:    keywordStats.ByProvider,
-		FirstSeen:     keywordStats.FirstSeen,
-		LastSeen:      keywordStats.LastSeen,
-		Responses:     responses,
+		Keyword:        keywordStats.Keyword,
+		TotalMentions:  keywordStats.TotalMentions,
+		UniquePrompts:  keywordStats.UniquePrompts,
+		UniqueLLMs:     keywordStats.UniqueLLMs,
+		ByPrompt:       keywordStats.ByPrompt,
+		ByLLM:          keywordStats.ByLLM,
+		LLMs:           keywordStats.LLMs,
+		ByProvider:     keywordStats.ByProvider,
+		FirstSeen:      keywordStats.FirstSeen,
+		LastSeen:       keywordStats.LastSeen,
+		Scanned:        keywordStats.Scanned,
+		Truncated:      keywordStats.Truncated,
+		ExcludedShort:  keywordStats.ExcludedShort,
+		Analysis:       keywordStats.Analysis,
+		PreviousPeriod: previousPeriod,
+		Responses:      responses,
 	}
 
 	s.successResponse(c, response)
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -8,8 +9,10 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/llm"
 	"github.com/AI2HU/gego/internal/models"
 	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
 )
 
 // Server represents the API server
@@ -20,12 +23,19 @@ type Server struct {
 	scheduleService *services.ScheduleService
 	statsService    *services.StatsService
 	searchService   *services.SearchService
+	recipeService   *services.RecipeService
+	responseService *services.ResponseService
+	llmRegistry     *llm.Registry              // Optional; required by endpoints that call providers
+	scheduler       *services.SchedulerService // Optional; required by endpoints that execute schedules
 	router          *gin.Engine
 	corsOrigin      string
+	createDisabled  bool  // LLMs and prompts created without an enabled field are disabled
+	maxBodySize     int64 // Bytes a request body may have
 }
 
-// NewServer creates a new API server
-func NewServer(database db.Database, corsOrigin string) *Server {
+// NewServer creates a new API server. registry and scheduler may be nil, in which case
+// the endpoints that need them respond with 501 Not Implemented.
+func NewServer(database db.Database, corsOrigin string, registry *llm.Registry, scheduler *services.SchedulerService) *Server {
 	gin.SetMode(gin.ReleaseMode)
 
 	router := gin.Default()
@@ -39,7 +49,7 @@ func NewServer(database db.Database, corsOrigin string) *Server {
 		if allowedOrigin != "" {
 			c.Header("Access-Control-Allow-Origin", allowedOrigin)
 			c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH, HEAD")
-			c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, Accept, Origin")
+			c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, Accept, Origin, X-Gego-Owner")
 			c.Header("Access-Control-Allow-Credentials", "true")
 			c.Header("Access-Control-Max-Age", "86400")
 		}
@@ -59,8 +69,19 @@ func NewServer(database db.Database, corsOrigin string) *Server {
 		scheduleService: services.NewScheduleService(database),
 		statsService:    services.NewStatsService(database),
 		searchService:   services.NewSearchService(database),
+		recipeService:   services.NewRecipeService(database),
+		responseService: services.NewResponseService(database),
+		llmRegistry:     registry,
+		scheduler:       scheduler,
 		router:          router,
 		corsOrigin:      corsOrigin,
+		maxBodySize:     DefaultMaxBodySize,
+	}
+	router.Use(server.limitBodySize)
+
+	if scheduler != nil {
+		server.llmService.Subscribe(scheduler)
+		server.scheduleService.SetRegistrar(scheduler)
 	}
 
 	server.setupRoutes()
@@ -73,23 +94,42 @@ func (s *Server) setupRoutes() {
 
 	api.GET("/llms", s.listLLMs)
 	api.GET("/llms/:id", s.getLLM)
-	// api.POST("/llms", s.createLLM)
-	// api.PUT("/llms/:id", s.updateLLM)
-	// api.DELETE("/llms/:id", s.deleteLLM)
+	api.POST("/llms", s.createLLM)
+	api.PUT("/llms/:id", s.updateLLM)
+	api.DELETE("/llms/:id", s.deleteLLM)
 
 	api.GET("/prompts", s.listPrompts)
 	api.GET("/prompts/:id", s.getPrompt)
-	// api.POST("/prompts", s.createPrompt)
-	// api.PUT("/prompts/:id", s.updatePrompt)
-	// api.DELETE("/prompts/:id", s.deletePrompt)
+	api.GET("/prompts/:id/responses", s.getPromptResponses)
+	api.GET("/prompts/:id/duplicates", s.getPromptDuplicates)
+	api.POST("/prompts", s.createPrompt)
+	api.PUT("/prompts/:id", s.updatePrompt)
+	api.DELETE("/prompts/:id", s.deletePrompt)
 
 	api.GET("/schedules", s.listSchedules)
 	api.GET("/schedules/:id", s.getSchedule)
-	// api.POST("/schedules", s.createSchedule)
-	// api.PUT("/schedules/:id", s.updateSchedule)
-	// api.DELETE("/schedules/:id", s.deleteSchedule)
+	api.GET("/schedules/:id/next", s.getScheduleNextRuns)
+	api.POST("/schedules/:id/run", s.requireLLMRegistry(), s.requireScheduler(), s.runSchedule)
+	api.POST("/schedules", s.createSchedule)
+	api.PUT("/schedules/:id", s.updateSchedule)
+	api.DELETE("/schedules/:id", s.deleteSchedule)
+
+	api.GET("/recipes", s.listRecipes)
+	api.GET("/recipes/:id", s.getRecipe)
+	api.POST("/recipes", s.createRecipe)
+	api.PUT("/recipes/:id", s.updateRecipe)
+	api.DELETE("/recipes/:id", s.deleteRecipe)
+	api.POST("/recipes/:id/run", s.requireLLMRegistry(), s.runRecipe)
 
 	api.GET("/stats", s.getStats)
+	api.GET("/stats/domains", s.getTopDomains)
+	api.GET("/stats/trends", s.getKeywordTrends)
+	api.GET("/keywords/:keyword/score", s.getKeywordScore)
+	api.GET("/responses", s.listResponses)
+	api.DELETE("/responses", s.deleteResponses)
+	api.GET("/responses/:id/annotations", s.listAnnotations)
+	api.POST("/responses/:id/annotations", s.annotateResponse)
+	api.GET("/compare/responses", s.compareResponses)
 
 	api.POST("/search", s.search)
 
@@ -116,6 +156,19 @@ func (s *Server) errorResponse(c *gin.Context, status int, message string) {
 	})
 }
 
+// ownerContext scopes the request context to the owner query parameter, if set
+func (s *Server) ownerContext(c *gin.Context) context.Context {
+	return shared.WithOwner(c.Request.Context(), c.Query("owner"))
+}
+
+// requestOwner returns the owner for a new entry: the body value, else the X-Gego-Owner header
+func (s *Server) requestOwner(c *gin.Context, owner string) string {
+	if owner = strings.TrimSpace(owner); owner != "" {
+		return owner
+	}
+	return strings.TrimSpace(c.GetHeader("X-Gego-Owner"))
+}
+
 func (s *Server) parsePagination(c *gin.Context) (int, int) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
@@ -130,6 +183,11 @@ func (s *Server) parsePagination(c *gin.Context) (int, int) {
 	return page, limit
 }
 
+// clearsRequired reports whether an update request sets a required field to null or empty
+func clearsRequired(field models.Nullable[string]) bool {
+	return field.Set && (field.Null || strings.TrimSpace(field.Value) == "")
+}
+
 func parseAllowedOrigins(corsOrigin string) []string {
 	if corsOrigin == "" || corsOrigin == "*" {
 		return nil
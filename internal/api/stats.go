@@ -1,36 +1,43 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+	"github.com/AI2HU/gego/internal/version"
 )
 
 // getStats handles GET /api/v1/stats
 func (s *Server) getStats(c *gin.Context) {
-	totalResponses, err := s.statsService.GetTotalResponses(c.Request.Context())
+	ctx := shared.WithErrorResponses(shared.WithExcludedLabels(s.ownerContext(c), c.QueryArray("exclude_label")), c.Query("include_errors") == "true")
+
+	totalResponses, err := s.statsService.GetTotalResponses(ctx)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to get total responses: "+err.Error())
 		return
 	}
 
-	totalPrompts, err := s.statsService.GetTotalPrompts(c.Request.Context())
+	totalPrompts, err := s.statsService.GetTotalPrompts(ctx)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to get total prompts: "+err.Error())
 		return
 	}
 
-	totalLLMs, err := s.statsService.GetTotalLLMs(c.Request.Context())
+	totalLLMs, err := s.statsService.GetTotalLLMs(ctx)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to get total LLMs: "+err.Error())
 		return
 	}
 
-	totalSchedules, err := s.statsService.GetTotalSchedules(c.Request.Context())
+	totalSchedules, err := s.statsService.GetTotalSchedules(ctx)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to get total schedules: "+err.Error())
 		return
@@ -42,19 +49,24 @@ func (s *Server) getStats(c *gin.Context) {
 		keywordLimit = 10
 	}
 
-	topKeywords, err := s.statsService.GetTopKeywords(c.Request.Context(), keywordLimit, nil, nil)
+	countMode, err := shared.ParseCountMode(c.Query("count_mode"))
+	if err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	topKeywords, err := s.statsService.GetTopKeywords(shared.WithCountMode(ctx, countMode), keywordLimit, nil, nil)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to get top keywords: "+err.Error())
 		return
 	}
 
-	promptStats, err := s.statsService.GetAllPromptStats(c.Request.Context())
+	promptStats, err := s.statsService.GetAllPromptStats(ctx)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to get prompt stats: "+err.Error())
 		return
 	}
 
-	llmStats, err := s.statsService.GetAllLLMStats(c.Request.Context())
+	llmStats, err := s.statsService.GetAllLLMStats(ctx)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to get LLM stats: "+err.Error())
 		return
@@ -62,7 +74,7 @@ func (s *Server) getStats(c *gin.Context) {
 
 	endTime := time.Now()
 	startTime := endTime.AddDate(0, 0, -30)
-	responseTrends, err := s.statsService.GetResponseTrends(c.Request.Context(), startTime, endTime)
+	responseTrends, err := s.statsService.GetResponseTrends(ctx, startTime, endTime)
 	if err != nil {
 		s.errorResponse(c, http.StatusInternalServerError, "Failed to get response trends: "+err.Error())
 		return
@@ -83,6 +95,143 @@ func (s *Server) getStats(c *gin.Context) {
 	s.successResponse(c, response)
 }
 
+// getTopDomains handles GET /api/v1/stats/domains
+func (s *Server) getTopDomains(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		s.errorResponse(c, http.StatusBadRequest, "limit must be a positive integer")
+		return
+	}
+
+	var startTime *time.Time
+	if daysStr := c.Query("days"); daysStr != "" {
+		days, err := strconv.Atoi(daysStr)
+		if err != nil || days < 1 {
+			s.errorResponse(c, http.StatusBadRequest, "days must be a positive integer")
+			return
+		}
+		start := time.Now().AddDate(0, 0, -days)
+		startTime = &start
+	}
+
+	ctx := shared.WithErrorResponses(shared.WithExcludedLabels(s.ownerContext(c), c.QueryArray("exclude_label")), c.Query("include_errors") == "true")
+	domains, err := s.statsService.GetTopDomains(ctx, c.Query("keyword"), limit, startTime, nil)
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to get domains: "+err.Error())
+		return
+	}
+
+	s.successResponse(c, domains)
+}
+
+// getKeywordTrends handles GET /api/v1/stats/trends
+func (s *Server) getKeywordTrends(c *gin.Context) {
+	var keywords []string
+	for _, value := range c.QueryArray("keyword") {
+		for _, keyword := range strings.Split(value, ",") {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+	}
+	if len(keywords) == 0 {
+		s.errorResponse(c, http.StatusBadRequest, "at least one keyword is required")
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 {
+		s.errorResponse(c, http.StatusBadRequest, "limit must be a positive integer")
+		return
+	}
+
+	var startTime *time.Time
+	if daysStr := c.Query("days"); daysStr != "" {
+		days, err := strconv.Atoi(daysStr)
+		if err != nil || days < 1 {
+			s.errorResponse(c, http.StatusBadRequest, "days must be a positive integer")
+			return
+		}
+		start := time.Now().AddDate(0, 0, -days)
+		startTime = &start
+	}
+
+	snapshots, err := s.statsService.GetSnapshots(s.ownerContext(c), startTime, nil, limit)
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to get stats snapshots: "+err.Error())
+		return
+	}
+
+	s.successResponse(c, services.KeywordTrends(snapshots, keywords))
+}
+
+// SetGEOScoreConfig sets how keyword GEO scores are computed
+func (s *Server) SetGEOScoreConfig(config services.GEOScoreConfig) {
+	s.statsService.SetGEOScoreConfig(config)
+}
+
+// getKeywordScore handles GET /api/v1/keywords/:keyword/score
+func (s *Server) getKeywordScore(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 {
+		s.errorResponse(c, http.StatusBadRequest, "days must be a positive integer")
+		return
+	}
+
+	var group []string
+	for _, value := range c.QueryArray("group") {
+		group = append(group, strings.Split(value, ",")...)
+	}
+
+	ctx := shared.WithErrorResponses(shared.WithExcludedLabels(s.ownerContext(c), c.QueryArray("exclude_label")), c.Query("include_errors") == "true")
+	end := time.Now()
+	start := end.AddDate(0, 0, -days)
+
+	score, err := s.statsService.GetGEOScore(ctx, c.Param("keyword"), group, start, end)
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to compute keyword score: "+err.Error())
+		return
+	}
+
+	s.successResponse(c, score)
+}
+
+// deleteResponses handles DELETE /api/v1/responses
+func (s *Server) deleteResponses(c *gin.Context) {
+	filter, err := services.ResetFilter(c.Query("schedule_id"), c.Query("llm_id"), c.Query("prompt_id"), c.Query("before"))
+	if err != nil {
+		s.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if c.Query("confirm") != "true" {
+		// Report what would be deleted, so that clients can confirm with the exact count
+		matched, err := s.statsService.CountResponses(s.ownerContext(c), filter)
+		if err != nil {
+			s.errorResponse(c, http.StatusInternalServerError, "Failed to count responses: "+err.Error())
+			return
+		}
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Data:    map[string]interface{}{"matched": matched},
+			Error:   fmt.Sprintf("Deleting %d responses requires confirm=true", matched),
+		})
+		return
+	}
+
+	deleted, err := s.statsService.ResetStats(s.ownerContext(c), filter)
+	if err != nil {
+		s.errorResponse(c, http.StatusInternalServerError, "Failed to delete responses: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    map[string]interface{}{"deleted": deleted},
+		Message: fmt.Sprintf("Deleted %d responses", deleted),
+	})
+}
+
 // healthCheck handles GET /api/v1/health
 func (s *Server) healthCheck(c *gin.Context) {
 	if err := s.db.Ping(c.Request.Context()); err != nil {
@@ -93,12 +242,17 @@ func (s *Server) healthCheck(c *gin.Context) {
 		return
 	}
 
+	info := version.Get()
+
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Data: map[string]interface{}{
-			"status":    "healthy",
-			"timestamp": time.Now(),
-			"version":   "1.0.0",
+			"status":     "healthy",
+			"timestamp":  time.Now(),
+			"version":    info.Version,
+			"commit":     info.Commit,
+			"build_date": info.BuildDate,
+			"go_version": info.GoVersion,
 		},
 	})
 }
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/config"
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+var (
+	analyzeScheduleID string
+	analyzeDays       int
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Analyze responses with a judge LLM",
+	Long: `Have the judge LLM configured under analysis in config.yaml report the brands mentioned in
+responses, the sentiment towards each and how strongly it is recommended. Results are stored in
+the response metadata and aggregated into keyword stats.`,
+}
+
+var analyzeRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Analyze the responses of a schedule",
+	Long: `Analyze the responses of a schedule that have not been analyzed yet.
+
+Examples:
+  gego analyze run --schedule 123e4567-e89b-12d3-a456-426614174000
+  gego analyze run --schedule 123e4567-e89b-12d3-a456-426614174000 --days 30`,
+	Args: cobra.NoArgs,
+	RunE: runAnalyze,
+}
+
+func init() {
+	analyzeCmd.AddCommand(analyzeRunCmd)
+
+	analyzeRunCmd.Flags().StringVar(&analyzeScheduleID, "schedule", "", "ID of the schedule whose responses are analyzed")
+	analyzeRunCmd.Flags().IntVar(&analyzeDays, "days", 7, "Number of days of responses to analyze")
+	analyzeRunCmd.MarkFlagRequired("schedule")
+}
+
+func runAnalyze(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+
+	if analyzeDays <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+	if _, err := database.GetSchedule(ctx, analyzeScheduleID); err != nil {
+		return fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	if err := initializeLLMProviders(ctx); err != nil {
+		return fmt.Errorf("failed to initialize LLM providers: %w", err)
+	}
+	analysis, err := newAnalysisService(ctx, database, llmRegistry, cfg)
+	if err != nil {
+		return err
+	}
+
+	since := time.Now().AddDate(0, 0, -analyzeDays)
+	result, err := analysis.AnalyzeSchedule(ctx, analyzeScheduleID, since)
+	if err != nil {
+		return fmt.Errorf("failed to analyze responses: %w", err)
+	}
+
+	fmt.Printf("%s✅ Analyzed %s response(s)%s\n", SuccessStyle, FormatCount(result.Analyzed), Reset)
+	fmt.Printf("%sSkipped: %s\n", LabelStyle, FormatCount(result.Skipped))
+	fmt.Printf("%sFailed: %s\n", LabelStyle, FormatCount(result.Failed))
+	fmt.Printf("%sJudge tokens: %s\n", LabelStyle, FormatCount(result.TokensUsed))
+	if result.CapReached {
+		fmt.Printf("%s⚠️  Stopped at the analysis cap; run again to analyze the remaining responses%s\n", WarningStyle, Reset)
+	}
+	return nil
+}
+
+// newAnalysisService returns an analysis service for the judge LLM configured in cfg
+func newAnalysisService(ctx context.Context, database db.Database, registry *llm.Registry, cfg *config.Config) (*services.AnalysisService, error) {
+	judge, err := services.ResolveJudgeLLM(ctx, database, cfg.Analysis.JudgeLLM)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Analysis.Keywords) == 0 {
+		return nil, fmt.Errorf("no keywords to analyze: set analysis.keywords in config.yaml")
+	}
+
+	return services.NewAnalysisService(database, registry, judge, services.AnalysisOptions{
+		Keywords:     cfg.Analysis.Keywords,
+		Prompt:       cfg.Analysis.Prompt,
+		MaxResponses: cfg.Analysis.MaxResponsesPerRun,
+		MaxTokens:    cfg.Analysis.MaxTokensPerRun,
+	}), nil
+}
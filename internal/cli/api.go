@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"path/filepath"
-	"syscall"
 
 	"github.com/spf13/cobra"
 
@@ -70,6 +68,10 @@ func runAPI(cmd *cobra.Command, args []string) error {
 		}
 		shared.SetExclusionFilePath(exclusionPath)
 	}
+	shared.SetKeywordOptions(cfg.KeywordOptions)
+	if captureRaw {
+		cfg.Storage.CaptureRaw = true
+	}
 
 	selectedCORSOrigin := corsOrigin
 	if selectedCORSOrigin == "" {
@@ -107,11 +109,25 @@ func runAPI(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create hybrid database: %w", err)
 	}
 
-	ctx := context.Background()
+	ctx := cmd.Context()
 	if err := database.Connect(ctx); err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
-	defer database.Disconnect(ctx)
+	defer database.Disconnect(context.Background())
+
+	if cfg.Storage.CompressResponses {
+		database.SetCompressResponses(true)
+	}
+	if cfg.Storage.StorePromptText {
+		database.SetStorePromptText(true)
+	}
+	if cfg.Storage.BatchSize > 1 {
+		batchInterval, err := cfg.Storage.GetBatchInterval()
+		if err != nil {
+			return err
+		}
+		database.SetResponseBatching(cfg.Storage.BatchSize, batchInterval)
+	}
 
 	if err := database.Ping(ctx); err != nil {
 		return fmt.Errorf("database ping failed: %w", err)
@@ -120,20 +136,41 @@ func runAPI(cmd *cobra.Command, args []string) error {
 	fmt.Println("✅ Database connection successful!")
 
 	fmt.Println("\n🔄 Running database migrations...")
-	if err := runAPIMigrations(ctx, database); err != nil {
+	if err := runSQLiteMigrations(ctx, database); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 	fmt.Println("✅ Database migrations completed successfully!")
 
-	server := api.NewServer(database, selectedCORSOrigin)
+	geoScore, err := geoScoreConfig(cfg)
+	if err != nil {
+		return err
+	}
+	searchOpts, err := keywordSearchOptions(cfg)
+	if err != nil {
+		return err
+	}
+
+	registry := newLLMRegistry()
+	if err := registerLLMProviders(ctx, database, registry); err != nil {
+		return fmt.Errorf("failed to initialize LLM providers: %w", err)
+	}
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	scheduler, err := newScheduler(database, registry, cfg)
+	if err != nil {
+		return err
+	}
+
+	server := api.NewServer(database, selectedCORSOrigin, registry, scheduler)
+	server.SetGEOScoreConfig(geoScore)
+	server.SetKeywordSearchOptions(searchOpts)
+	server.SetPreserveTagCase(cfg.Tags.PreserveCase)
+	server.SetCreateDisabled(cfg.CreateDisabled)
+	server.SetMaxBodySize(cfg.APIMaxBodySize)
 
 	go func() {
-		<-c
+		<-ctx.Done()
 		fmt.Println("\n🛑 Shutting down API server...")
-		database.Disconnect(ctx)
+		database.Disconnect(context.Background())
 		os.Exit(0)
 	}()
 
@@ -150,6 +187,8 @@ func runAPI(cmd *cobra.Command, args []string) error {
 	fmt.Println("  Prompts:")
 	fmt.Println("    GET    /api/v1/prompts           - List all prompts")
 	fmt.Println("    GET    /api/v1/prompts/:id       - Get specific prompt")
+	fmt.Println("    GET    /api/v1/prompts/:id/responses - Latest responses per LLM (per_llm, keyword)")
+	fmt.Println("    GET    /api/v1/prompts/:id/duplicates - Identical answers from different LLMs")
 	fmt.Println("    POST   /api/v1/prompts           - Create new prompt")
 	fmt.Println("    PUT    /api/v1/prompts/:id       - Update prompt")
 	fmt.Println("    DELETE /api/v1/prompts/:id       - Delete prompt")
@@ -160,9 +199,25 @@ func runAPI(cmd *cobra.Command, args []string) error {
 	fmt.Println("    POST   /api/v1/schedules         - Create new schedule")
 	fmt.Println("    PUT    /api/v1/schedules/:id     - Update schedule")
 	fmt.Println("    DELETE /api/v1/schedules/:id     - Delete schedule")
+	fmt.Println("    POST   /api/v1/schedules/:id/run - Run schedule now (in the background)")
+	fmt.Println()
+	fmt.Println("  Generation Recipes:")
+	fmt.Println("    GET    /api/v1/recipes           - List all recipes")
+	fmt.Println("    GET    /api/v1/recipes/:id       - Get specific recipe")
+	fmt.Println("    POST   /api/v1/recipes           - Create new recipe")
+	fmt.Println("    PUT    /api/v1/recipes/:id       - Update recipe")
+	fmt.Println("    DELETE /api/v1/recipes/:id       - Delete recipe")
+	fmt.Println("    POST   /api/v1/recipes/:id/run   - Generate and save prompts with recipe")
 	fmt.Println()
 	fmt.Println("  Stats & Search:")
 	fmt.Println("    GET    /api/v1/stats             - Get statistics")
+	fmt.Println("    GET    /api/v1/stats/domains     - Top cited domains (keyword, days, limit)")
+	fmt.Println("    GET    /api/v1/keywords/:keyword/score - Keyword GEO score (days, group)")
+	fmt.Println("    GET    /api/v1/responses         - List responses (run_id, label, exclude_label, has_error filters)")
+	fmt.Println("    DELETE /api/v1/responses         - Delete responses (confirm=true)")
+	fmt.Println("    GET    /api/v1/responses/:id/annotations - List response annotations")
+	fmt.Println("    POST   /api/v1/responses/:id/annotations - Annotate a response")
+	fmt.Println("    GET    /api/v1/compare/responses - Compare two LLMs' latest responses (prompt, llm x2)")
 	fmt.Println("    POST   /api/v1/search            - Search keywords")
 	fmt.Println("    GET    /api/v1/health            - Health check")
 	fmt.Println()
@@ -171,31 +226,3 @@ func runAPI(cmd *cobra.Command, args []string) error {
 	address := fmt.Sprintf("%s:%s", apiHost, apiPort)
 	return server.Run(address)
 }
-
-func runAPIMigrations(ctx context.Context, database db.Database) error {
-	hybridDB, ok := database.(*db.HybridDB)
-	if !ok {
-		return fmt.Errorf("database is not a HybridDB instance")
-	}
-
-	sqliteDB := hybridDB.GetSQLiteDatabase()
-	if sqliteDB == nil {
-		return fmt.Errorf("SQLite database not available")
-	}
-
-	migrationsDir := os.Getenv("GEGO_MIGRATIONS_DIR")
-	if migrationsDir == "" {
-		migrationsDir = "/migrations"
-		if _, err := os.Stat(migrationsDir); os.IsNotExist(err) {
-			workDir, _ := os.Getwd()
-			migrationsDir = filepath.Join(workDir, "internal", "db", "migrations")
-		}
-	}
-
-	sqlDB := sqliteDB.GetDB()
-	if sqlDB == nil {
-		return fmt.Errorf("database connection not available")
-	}
-
-	return db.RunMigrations(ctx, sqlDB, migrationsDir)
-}
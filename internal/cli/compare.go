@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
+)
+
+var (
+	comparePrompt   string
+	compareLLMs     []string
+	compareKeywords []string
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare LLM responses",
+}
+
+var compareResponsesCmd = &cobra.Command{
+	Use:   "responses",
+	Short: "Compare the latest responses of two LLMs to a prompt",
+	Long: `Show the latest responses of two LLMs to the same prompt side by side: the keywords each
+one mentions, and a sentence-level diff of content present in one response but not the other.
+
+Examples:
+  gego compare responses --prompt <prompt-id> --llm <llm-a> --llm <llm-b>
+  gego compare responses --prompt <prompt-id> --llm <llm-a> --llm <llm-b> --keyword Netflix`,
+	Args: cobra.NoArgs,
+	RunE: runCompareResponses,
+}
+
+func init() {
+	compareCmd.AddCommand(compareResponsesCmd)
+
+	compareResponsesCmd.Flags().StringVar(&comparePrompt, "prompt", "", "Prompt ID (required)")
+	compareResponsesCmd.Flags().StringArrayVar(&compareLLMs, "llm", nil, "LLM ID to compare, given twice (required)")
+	compareResponsesCmd.Flags().StringSliceVar(&compareKeywords, "keyword", nil, "Only count these keywords (default: every keyword found)")
+	compareResponsesCmd.MarkFlagRequired("prompt")
+}
+
+func runCompareResponses(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if len(compareLLMs) != 2 {
+		return fmt.Errorf("--llm must be given exactly twice")
+	}
+
+	comparison, err := services.NewResponseService(database).CompareResponses(ctx, comparePrompt, compareLLMs[0], compareLLMs[1], compareKeywords)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s🔍 Response Comparison%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s======================%s\n", DimStyle, Reset)
+	printComparedResponse("-", ErrorStyle, comparison.Left)
+	printComparedResponse("+", SuccessStyle, comparison.Right)
+	fmt.Println()
+
+	if comparison.Left.Response == nil && comparison.Right.Response == nil {
+		fmt.Printf("%sNeither LLM has responded to this prompt yet.%s\n", WarningStyle, Reset)
+		return nil
+	}
+
+	if len(comparison.Keywords) > 0 {
+		fmt.Printf("%sKeyword Hits:%s\n", SuccessStyle, Reset)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "  KEYWORD\t%s\t%s\n", comparison.Left.LLMName, comparison.Right.LLMName)
+		for _, hits := range comparison.Keywords {
+			fmt.Fprintf(w, "  %s\t%d\t%d\n", hits.Keyword, hits.Left, hits.Right)
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	fmt.Printf("%sSentence Diff:%s %s\n", SuccessStyle, Reset, FormatMeta(fmt.Sprintf("(- only in %s, + only in %s)", comparison.Left.LLMName, comparison.Right.LLMName)))
+	for _, op := range comparison.Diff {
+		switch op.Op {
+		case models.DiffLeft:
+			fmt.Printf("%s- %s%s\n", ErrorStyle, op.Text, Reset)
+		case models.DiffRight:
+			fmt.Printf("%s+ %s%s\n", SuccessStyle, op.Text, Reset)
+		default:
+			fmt.Printf("%s  %s%s\n", DimStyle, op.Text, Reset)
+		}
+	}
+	return nil
+}
+
+// printComparedResponse prints one side of a comparison, or notes that the LLM has no response
+func printComparedResponse(marker, style string, compared models.ComparedResponse) {
+	name := compared.LLMName
+	if compared.LLMModel != "" {
+		name += " (" + compared.LLMModel + ")"
+	}
+
+	if compared.Response == nil {
+		fmt.Printf("%s%s %s%s %s\n", style, marker, name, Reset, FormatMeta("no responses to this prompt"))
+		return
+	}
+	fmt.Printf("%s%s %s%s %s\n", style, marker, name, Reset, FormatMeta(fmt.Sprintf("response %s at %s", compared.Response.ID, compared.Response.CreatedAt.Format("2006-01-02 15:04"))))
+}
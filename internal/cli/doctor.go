@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+var (
+	doctorFix        bool
+	doctorPendingAge time.Duration
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the databases for leftovers of interrupted operations",
+	Long: `Look for data left behind by operations spanning SQLite and MongoDB that were interrupted
+halfway, such as prompts saved by 'gego quickstart' whose schedule was never created. These prompts
+carry the gego-pending-creation tag; they are reported once older than --pending-age.
+
+With --fix, leftover prompts no schedule runs are deleted, and the others lose their tag.
+
+Examples:
+  gego doctor
+  gego doctor --fix`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Delete or untag the leftovers found")
+	doctorCmd.Flags().DurationVar(&doctorPendingAge, "pending-age", services.DefaultPendingCreationAge, "Age after which a pending creation counts as interrupted")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+
+	fmt.Printf("%s🩺 Gego Doctor%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s=============%s\n", DimStyle, Reset)
+	fmt.Println()
+
+	doctor := services.NewDoctorService(database)
+	pending, err := doctor.PendingPrompts(ctx, doctorPendingAge)
+	if err != nil {
+		return fmt.Errorf("failed to check pending creations: %w", err)
+	}
+	if len(pending) == 0 {
+		fmt.Printf("%s✅ No leftovers of interrupted creations%s\n", SuccessStyle, Reset)
+		return nil
+	}
+
+	fmt.Printf("%s⚠️  %s prompt(s) left by interrupted creations:%s\n", WarningStyle, FormatCount(len(pending)), Reset)
+	for _, p := range pending {
+		action := "delete (no schedule runs it)"
+		if p.Scheduled {
+			action = "untag (a schedule runs it)"
+		}
+		fmt.Printf("  %s %s %s\n", FormatSecondary(p.Prompt.ID), FormatValue(services.Excerpt(p.Prompt.Template, 60)), FormatMeta("→ "+action))
+	}
+
+	if !doctorFix {
+		fmt.Printf("\n%s💡 Run again with --fix to clean them up%s\n", InfoStyle, Reset)
+		return nil
+	}
+
+	fixed := 0
+	for _, p := range pending {
+		if err := doctor.FixPendingPrompt(ctx, p); err != nil {
+			fmt.Printf("%s❌ Failed to fix prompt %s: %s%s\n", ErrorStyle, p.Prompt.ID, FormatValue(err.Error()), Reset)
+			continue
+		}
+		fixed++
+	}
+	fmt.Printf("\n%s✅ Fixed %s/%s prompt(s)%s\n", SuccessStyle, FormatCount(fixed), FormatCount(len(pending)), Reset)
+	return nil
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+var (
+	exportKeyword string
+	exportOutput  string
+	exportRange   timeRangeFlags
+	exportRunID   string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export collected data",
+	Long:  `Export prompts and responses for use outside of gego.`,
+}
+
+var exportArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Export the responses mentioning a keyword as a static HTML archive",
+	Long: `Export every response mentioning a keyword as a static HTML archive that can be browsed
+offline: an index of prompts, a page per prompt and per LLM, a page per response with the keyword
+highlighted, and a summary of the keyword stats. Pages have no external assets.
+
+File names derive from prompt, LLM and response IDs, so exporting again into the same directory
+rewrites it in place and only changed data shows up in a diff. Pages of responses that no longer
+match are removed.
+
+Examples:
+  gego export archive --keyword Netflix --output archive/
+  gego export archive --keyword Netflix --output archive/ --from 2025-01-01 --to 2025-03-31
+  gego export archive --keyword Netflix --output archive/ --run-id <run-id>`,
+	Args: cobra.NoArgs,
+	RunE: runExportArchive,
+}
+
+func init() {
+	exportCmd.AddCommand(exportArchiveCmd)
+
+	exportArchiveCmd.Flags().StringVar(&exportKeyword, "keyword", "", "Keyword the exported responses mention")
+	exportArchiveCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Directory the archive is written to")
+	addTimeRangeFlags(exportArchiveCmd, &exportRange)
+	exportArchiveCmd.Flags().StringVar(&exportRunID, "run-id", "", "Only export the responses of this schedule run (see gego schedule history)")
+	exportArchiveCmd.MarkFlagRequired("keyword")
+	exportArchiveCmd.MarkFlagRequired("output")
+}
+
+func runExportArchive(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+
+	startTime, endTime, err := exportRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+	searchOpts, err := keywordSearchOptions(cfg)
+	if err != nil {
+		return err
+	}
+	stats, err := statsService.SearchKeyword(ctx, exportKeyword, startTime, endTime, searchOpts)
+	if err != nil {
+		return fmt.Errorf("failed to get keyword stats: %w", err)
+	}
+
+	result, err := services.NewArchiveService(database).Export(ctx, exportOutput, services.ArchiveOptions{
+		Keyword:   exportKeyword,
+		StartTime: startTime,
+		EndTime:   endTime,
+		RunID:     exportRunID,
+		Period:    describeTimeRange(startTime, endTime),
+		Stats:     stats,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export archive: %w", err)
+	}
+
+	fmt.Printf("%s✅ Exported %s responses across %s prompts and %s LLMs%s\n", SuccessStyle, FormatCount(result.Responses), FormatCount(result.Prompts), FormatCount(result.LLMs), Reset)
+	if result.Removed > 0 {
+		fmt.Printf("%sRemoved %s pages of responses no longer in the archive%s\n", DimStyle, FormatCount(result.Removed), Reset)
+	}
+	fmt.Printf("%sOpen: %s\n", LabelStyle, FormatSecondary(filepath.Join(exportOutput, "index.html")))
+	return nil
+}
@@ -5,71 +5,154 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
 
 	"github.com/spf13/cobra"
 
 	"github.com/AI2HU/gego/internal/config"
 	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/i18n"
 	"github.com/AI2HU/gego/internal/models"
 )
 
+var (
+	initNonInteractive     bool
+	initSQLitePath         string
+	initMongoURI           string
+	initMongoDB            string
+	initSkipConnectionTest bool
+	initForce              bool
+)
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize gego configuration",
-	Long:  `Interactive wizard to set up gego configuration including database and brand list.`,
-	RunE:  runInit,
+	Long: `Interactive wizard to set up gego configuration including database and brand list.
+
+With --non-interactive, nothing is prompted: the database settings come from the flags or their
+defaults, which suits CI and container provisioning. In interactive mode the flags only change the
+defaults offered by the wizard.
+
+Examples:
+  gego init
+  gego init --non-interactive --mongo-uri mongodb://mongo:27017 --mongo-db gego_ci
+  gego init --non-interactive --sqlite-path /data/gego.db --skip-connection-test --force`,
+	Args: cobra.NoArgs,
+	RunE: runInit,
+}
+
+func init() {
+	initCmd.Flags().BoolVar(&initNonInteractive, "non-interactive", false, "Configure from the flags without prompting")
+	initCmd.Flags().StringVar(&initSQLitePath, "sqlite-path", "", "SQLite database path (default: under the user config directory)")
+	initCmd.Flags().StringVar(&initMongoURI, "mongo-uri", "mongodb://localhost:27017", "MongoDB connection URI")
+	initCmd.Flags().StringVar(&initMongoDB, "mongo-db", "gego", "MongoDB database name")
+	initCmd.Flags().BoolVar(&initSkipConnectionTest, "skip-connection-test", false, "Save the configuration without connecting to the databases or running migrations")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite an existing configuration file without asking")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
 	reader := bufio.NewReader(os.Stdin)
 
-	fmt.Println("🚀 Welcome to Gego - GEO Tracker Setup")
+	fmt.Println(i18n.T("init.welcome"))
 	fmt.Println("======================================")
 	fmt.Println()
 
-	configPath := config.GetConfigPath()
-	if config.Exists(configPath) {
-		fmt.Printf("Configuration file already exists at: %s\n", configPath)
-		confirmed, err := promptYesNo(reader, "Do you want to overwrite it? (y/N): ")
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.GetConfigPath()
+	}
+	if config.Exists(configPath) && !initForce {
+		fmt.Println(i18n.T("init.config_exists", configPath))
+		if initNonInteractive {
+			return fmt.Errorf("configuration file already exists at %s, use --force to overwrite it", configPath)
+		}
+		confirmed, err := promptYesNo(reader, i18n.T("init.confirm_overwrite"))
 		if err != nil {
 			return err
 		}
 		if !confirmed {
-			fmt.Println("Setup cancelled.")
+			fmt.Println(i18n.T("init.cancelled"))
 			return nil
 		}
 	}
 
 	cfg := config.DefaultConfig()
 
-	fmt.Println("\n📊 Database Configuration")
+	fmt.Println("\n" + i18n.T("init.database_header"))
 	fmt.Println("--------------------------")
-	fmt.Println("Gego uses a hybrid approach:")
-	fmt.Println("  • SQLite for LLMs and Schedules (structured data)")
-	fmt.Println("  • MongoDB for Prompts and Responses (unstructured data)")
+	fmt.Println(i18n.T("init.hybrid_intro"))
+	fmt.Println(i18n.T("init.hybrid_sqlite"))
+	fmt.Println(i18n.T("init.hybrid_mongodb"))
 	fmt.Println()
 
-	fmt.Println("🗄️  SQLite Configuration (for LLMs and Schedules)")
-	sqlitePath, err := promptOptional(reader, "SQLite database path [gego.db]: ", "gego.db")
-	if err != nil {
-		return err
+	sqlitePath := initSQLitePath
+	if sqlitePath == "" {
+		sqlitePath = config.DefaultSQLitePath()
+	}
+	mongoURI := initMongoURI
+
+	if !initNonInteractive {
+		var err error
+		fmt.Println(i18n.T("init.sqlite_header"))
+		sqlitePath, err = promptOptional(reader, i18n.T("init.sqlite_path", sqlitePath), sqlitePath)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("\n" + i18n.T("init.mongodb_header"))
+		mongoURI, err = promptOptional(reader, i18n.T("init.mongodb_uri", mongoURI), mongoURI)
+		if err != nil {
+			return err
+		}
 	}
+
 	cfg.SQLDatabase.Provider = "sqlite"
 	cfg.SQLDatabase.URI = sqlitePath
 	cfg.SQLDatabase.Database = "gego"
-
-	fmt.Println("\n🍃 MongoDB Configuration (for Prompts and Responses)")
-	mongoURI, err2 := promptOptional(reader, "MongoDB URI [mongodb://localhost:27017]: ", "mongodb://localhost:27017")
-	if err2 != nil {
-		return err2
-	}
 	cfg.NoSQLDatabase.Provider = "mongodb"
 	cfg.NoSQLDatabase.URI = mongoURI
-	cfg.NoSQLDatabase.Database = "gego"
+	cfg.NoSQLDatabase.Database = initMongoDB
+
+	if initSkipConnectionTest {
+		fmt.Println("\n" + i18n.T("init.connection_skipped"))
+	} else if err := testInitConnections(cmd.Context(), cfg); err != nil {
+		return err
+	}
+
+	fmt.Println("\n" + i18n.T("init.saving"))
+	if err := cfg.Save(configPath); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println(i18n.T("init.saved", configPath))
+
+	fmt.Println("\n" + i18n.T("init.summary_header"))
+	fmt.Println("========================")
+	fmt.Println(i18n.T("init.summary_sqlite", cfg.SQLDatabase.Provider, cfg.SQLDatabase.URI))
+	fmt.Println(i18n.T("init.summary_nosql", cfg.NoSQLDatabase.Provider, cfg.NoSQLDatabase.URI))
+	fmt.Println(i18n.T("init.summary_database", cfg.NoSQLDatabase.Database))
+	fmt.Println()
+	fmt.Println(i18n.T("init.complete"))
+	fmt.Println()
+	fmt.Println(i18n.T("init.hybrid_summary"))
+	fmt.Println(i18n.T("init.hybrid_summary_sqlite"))
+	fmt.Println(i18n.T("init.hybrid_summary_mongodb"))
+	fmt.Println()
+	fmt.Println(i18n.T("init.next_steps"))
+	fmt.Println(i18n.T("init.next_llm"))
+	fmt.Println(i18n.T("init.next_prompt"))
+	fmt.Println(i18n.T("init.next_schedule"))
+	fmt.Println(i18n.T("init.next_run"))
+	fmt.Println()
+	fmt.Println(i18n.T("init.migration_commands"))
+	fmt.Println(i18n.T("init.migration_up"))
+	fmt.Println(i18n.T("init.migration_status"))
+
+	return nil
+}
 
-	fmt.Println("\n🔌 Testing database connections...")
+// testInitConnections connects to the databases of cfg and applies the SQLite migrations
+func testInitConnections(ctx context.Context, cfg *config.Config) error {
+	fmt.Println("\n" + i18n.T("init.testing_connections"))
 	sqlConfig := &models.Config{
 		Provider: cfg.SQLDatabase.Provider,
 		URI:      cfg.SQLDatabase.URI,
@@ -87,94 +170,26 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create hybrid database: %w", dbErr)
 	}
 
-	ctx := context.Background()
 	if err := testDB.Connect(ctx); err != nil {
-		fmt.Printf("❌ Failed to connect to database: %v\n", err)
-		fmt.Println("\nPlease check your database configuration and try again.")
+		fmt.Println(i18n.T("init.connect_failed", err))
+		fmt.Println("\n" + i18n.T("init.check_config"))
 		return err
 	}
 	defer testDB.Disconnect(ctx)
 
 	if err := testDB.Ping(ctx); err != nil {
-		fmt.Printf("❌ Failed to ping database: %v\n", err)
+		fmt.Println(i18n.T("init.ping_failed", err))
 		return err
 	}
 
-	fmt.Println("✅ Database connection successful!")
+	fmt.Println(i18n.T("init.connection_ok"))
 
-	fmt.Println("\n🔄 Running database migrations...")
-	if err := runMigrations(sqlitePath); err != nil {
-		fmt.Printf("❌ Failed to run migrations: %v\n", err)
-		fmt.Println("You may need to run migrations manually later.")
+	fmt.Println("\n" + i18n.T("init.running_migrations"))
+	if err := runSQLiteMigrations(ctx, testDB); err != nil {
+		fmt.Println(i18n.T("init.migrations_failed", err))
+		fmt.Println(i18n.T("init.migrations_manual"))
 	} else {
-		fmt.Println("✅ Database migrations completed successfully!")
-	}
-
-	fmt.Println("\n💾 Saving configuration...")
-	if err := cfg.Save(configPath); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
-	}
-
-	fmt.Printf("✅ Configuration saved to: %s\n", configPath)
-
-	fmt.Println("\n📋 Configuration Summary")
-	fmt.Println("========================")
-	fmt.Printf("SQLite Database: %s (%s)\n", cfg.SQLDatabase.Provider, cfg.SQLDatabase.URI)
-	fmt.Printf("NoSQL Database: %s (%s)\n", cfg.NoSQLDatabase.Provider, cfg.NoSQLDatabase.URI)
-	fmt.Printf("Database Name: %s\n", cfg.NoSQLDatabase.Database)
-	fmt.Println()
-	fmt.Println("🎉 Setup complete! You can now use gego.")
-	fmt.Println()
-	fmt.Println("ℹ️  Gego uses a hybrid database approach:")
-	fmt.Println("   • SQLite stores LLM configurations and schedules")
-	fmt.Println("   • MongoDB stores prompts and responses for keyword analysis")
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Println("  1. Add LLM providers: gego llm add")
-	fmt.Println("  2. Create prompts: gego prompt add")
-	fmt.Println("  3. Set up schedules: gego schedule add")
-	fmt.Println("  4. Start scheduler: gego run")
-	fmt.Println()
-	fmt.Println("Migration commands:")
-	fmt.Println("  • Run migrations: gego migrate up")
-	fmt.Println("  • Check status: gego migrate status")
-
-	return nil
-}
-
-// runMigrations executes database migrations using gomigrate
-func runMigrations(sqlitePath string) error {
-	if _, err := exec.LookPath("migrate"); err != nil {
-		return fmt.Errorf("migrate command not found. Please install golang-migrate: https://github.com/golang-migrate/migrate")
-	}
-
-	migrationsDir := filepath.Join("internal", "db", "migrations")
-
-	if _, err := os.Stat(migrationsDir); os.IsNotExist(err) {
-		return fmt.Errorf("migrations directory not found: %s", migrationsDir)
-	}
-
-	absSQLitePath, err := filepath.Abs(sqlitePath)
-	if err != nil {
-		return fmt.Errorf("failed to resolve SQLite path: %w", err)
-	}
-
-	dbURL := fmt.Sprintf("sqlite3://%s", absSQLitePath)
-
-	cmd := exec.Command("migrate",
-		"-path", migrationsDir,
-		"-database", dbURL,
-		"up")
-
-	cmd.Dir = "."
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("migration failed: %w\nOutput: %s", err, string(output))
-	}
-
-	if len(output) > 0 {
-		fmt.Printf("Migration output: %s", string(output))
+		fmt.Println(i18n.T("init.migrations_ok"))
 	}
 
 	return nil
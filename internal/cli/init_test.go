@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/config"
+)
+
+func TestRunInitNonInteractive(t *testing.T) {
+	tests := []struct {
+		name         string
+		sqlitePath   string
+		mongoURI     string
+		mongoDB      string
+		existing     bool
+		force        bool
+		wantErr      string
+		wantSQLite   string
+		wantMongoURI string
+		wantMongoDB  string
+	}{
+		{
+			name:       "flags",
+			sqlitePath: "/data/gego.db", mongoURI: "mongodb://mongo:27017", mongoDB: "gego_ci",
+			wantSQLite: "/data/gego.db", wantMongoURI: "mongodb://mongo:27017", wantMongoDB: "gego_ci",
+		},
+		{
+			name:     "defaults",
+			mongoURI: "mongodb://localhost:27017", mongoDB: "gego",
+			wantMongoURI: "mongodb://localhost:27017", wantMongoDB: "gego",
+		},
+		{name: "existing config kept", mongoURI: "mongodb://mongo:27017", mongoDB: "gego_ci", existing: true, wantErr: "use --force to overwrite it"},
+		{
+			name:       "existing config forced",
+			sqlitePath: "/data/gego.db", mongoURI: "mongodb://mongo:27017", mongoDB: "gego_ci", existing: true, force: true,
+			wantSQLite: "/data/gego.db", wantMongoURI: "mongodb://mongo:27017", wantMongoDB: "gego_ci",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			configDir := filepath.Join(dir, "config")
+			t.Setenv("XDG_CONFIG_HOME", configDir)
+			path := filepath.Join(dir, "gego", "config.yaml")
+			const previous = "# previous configuration\n"
+			if tt.existing {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(previous), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfgFile = path
+			initNonInteractive, initSkipConnectionTest, initForce = true, true, tt.force
+			initSQLitePath, initMongoURI, initMongoDB = tt.sqlitePath, tt.mongoURI, tt.mongoDB
+			t.Cleanup(func() {
+				cfgFile = ""
+				initNonInteractive, initSkipConnectionTest, initForce = false, false, false
+				initSQLitePath, initMongoURI, initMongoDB = "", "mongodb://localhost:27017", "gego"
+			})
+
+			output, err := captureStdout(t, func() error { return runInit(initCmd, nil) })
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("runInit() error = %v, want %q", err, tt.wantErr)
+				}
+				if data, _ := os.ReadFile(path); string(data) != previous {
+					t.Errorf("config overwritten without --force:\n%s", data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runInit() error = %v\n%s", err, output)
+			}
+
+			written, err := config.Load(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Without --sqlite-path the database goes under the user config directory
+			wantSQLite := tt.wantSQLite
+			if wantSQLite == "" {
+				wantSQLite = filepath.Join(configDir, "gego", "gego.db")
+			}
+			if written.SQLDatabase.Provider != "sqlite" || written.SQLDatabase.URI != wantSQLite {
+				t.Errorf("SQL database = %s %s, want sqlite %s", written.SQLDatabase.Provider, written.SQLDatabase.URI, wantSQLite)
+			}
+			if written.NoSQLDatabase.Provider != "mongodb" || written.NoSQLDatabase.URI != tt.wantMongoURI || written.NoSQLDatabase.Database != tt.wantMongoDB {
+				t.Errorf("NoSQL database = %s %s %s, want mongodb %s %s", written.NoSQLDatabase.Provider, written.NoSQLDatabase.URI, written.NoSQLDatabase.Database, tt.wantMongoURI, tt.wantMongoDB)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+var keywordTestSample string
+
+var keywordCmd = &cobra.Command{
+	Use:   "keyword",
+	Short: "Tune how keywords are counted",
+	Long:  `Check how the keyword_options of the config count the mentions of a keyword.`,
+}
+
+var keywordTestCmd = &cobra.Command{
+	Use:   "test [keyword]",
+	Short: "Show which mentions of a keyword a response counts",
+	Long: `List the mentions of a keyword in a stored response, as stats and searches count them, and
+show which ones the keyword's exclusion patterns suppress. Use it to tune keyword_options.<keyword>.exclude.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKeywordTest,
+}
+
+func init() {
+	keywordCmd.AddCommand(keywordTestCmd)
+
+	keywordTestCmd.Flags().StringVar(&keywordTestSample, "sample", "", "ID of the response to test (required)")
+	keywordTestCmd.MarkFlagRequired("sample")
+}
+
+func runKeywordTest(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	keyword := strings.TrimSpace(args[0])
+	if keyword == "" {
+		return fmt.Errorf("keyword must not be empty")
+	}
+
+	response, err := database.GetResponse(ctx, keywordTestSample)
+	if err != nil {
+		return fmt.Errorf("failed to get response: %w", err)
+	}
+
+	opts := shared.KeywordOptionsFor(keyword)
+	fmt.Printf("%s🔬 Keyword Test: %s%s\n", FormatHeader(""), keyword, Reset)
+	fmt.Printf("%s%s%s\n", DimStyle, strings.Repeat("=", 16+len(keyword)), Reset)
+	fmt.Printf("%sResponse:%s %s %s\n", LabelStyle, Reset, FormatValue(response.ID), FormatMeta("("+response.LLMName+")"))
+	fmt.Printf("%sCase sensitive:%s %s\n", LabelStyle, Reset, FormatValue(fmt.Sprintf("%t", opts.CaseSensitive)))
+	fmt.Printf("%sExclude URLs:%s %s\n", LabelStyle, Reset, FormatValue(fmt.Sprintf("%t", opts.ExcludeURLs)))
+	if len(opts.Exclude) == 0 {
+		fmt.Printf("%sExclusions:%s %s\n", LabelStyle, Reset, FormatSecondary("none"))
+	} else {
+		fmt.Printf("%sExclusions:%s\n", LabelStyle, Reset)
+		for _, pattern := range opts.Exclude {
+			fmt.Printf("  %s• %s%s\n", DimStyle, pattern, Reset)
+		}
+	}
+	fmt.Println()
+
+	occurrences := shared.KeywordOccurrences(response.ResponseText, keyword)
+	if len(occurrences) == 0 {
+		fmt.Printf("%sNo mention of %s in this response.%s\n", WarningStyle, keyword, Reset)
+		return nil
+	}
+
+	counted := 0
+	for i, occurrence := range occurrences {
+		if occurrence.Excluded {
+			fmt.Printf("  %s%d. ✗ excluded%s %s\n", WarningStyle, i+1, Reset, FormatMeta("by "+occurrence.Pattern))
+		} else {
+			counted++
+			fmt.Printf("  %s%d. ✓ counted%s\n", SuccessStyle, i+1, Reset)
+		}
+		fmt.Printf("     %s%s%s\n", DimStyle, occurrence.Context, Reset)
+	}
+
+	fmt.Println()
+	fmt.Printf("%s📊 %s counted, %s excluded%s\n", InfoStyle, FormatCount(counted), FormatCount(len(occurrences)-counted), Reset)
+	return nil
+}
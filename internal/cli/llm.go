@@ -2,9 +2,14 @@ package cli
 
 import (
 	"bufio"
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -12,8 +17,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
+	"github.com/AI2HU/gego/internal/i18n"
+	"github.com/AI2HU/gego/internal/llm/bedrock"
 	"github.com/AI2HU/gego/internal/models"
 	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
 )
 
 var llmCmd = &cobra.Command{
@@ -22,6 +30,20 @@ var llmCmd = &cobra.Command{
 	Long:  `Add, list, update, and delete LLM provider configurations.`,
 }
 
+var (
+	llmAddForce     bool
+	llmModelsVerify bool
+	llmGetStats     bool
+	llmPromptPrefix string
+	llmPromptSuffix string
+	llmProvider     string
+	llmSet          []string
+	llmUnset        []string
+)
+
+// llmStatsKeywordLimit is the number of top keywords shown by llm get --stats
+const llmStatsKeywordLimit = 5
+
 var llmAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add a new LLM provider",
@@ -52,58 +74,117 @@ var llmDeleteCmd = &cobra.Command{
 var llmEnableCmd = &cobra.Command{
 	Use:   "enable [id]",
 	Short: "Enable an LLM provider",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runLLMEnable,
+	Long: `Enable an LLM, or with --provider every LLM of a provider.
+
+Examples:
+  gego llm enable <id>
+  gego llm enable --provider openai`,
+	Args: llmIDOrProviderArgs,
+	RunE: runLLMEnable,
 }
 
 var llmDisableCmd = &cobra.Command{
 	Use:   "disable [id]",
 	Short: "Disable an LLM provider",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runLLMDisable,
+	Long: `Disable an LLM, or with --provider every LLM of a provider, for example during a provider
+outage or after its API key was revoked.
+
+Examples:
+  gego llm disable <id>
+  gego llm disable --provider anthropic`,
+	Args: llmIDOrProviderArgs,
+	RunE: runLLMDisable,
 }
 
 var llmUpdateCmd = &cobra.Command{
 	Use:   "update [id]",
 	Short: "Update an LLM provider configuration",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runLLMUpdate,
+	Long: `Update an LLM provider configuration. Without --set or --unset, asks for a new API key,
+base URL and enabled state.
+
+--set and --unset change the provider settings in the LLM config without asking anything else.
+Known keys: temperature, top_p, top_k, max_tokens, seed, stream, stop_sequences, response_format,
+reasoning, reasoning_effort and web_search.
+
+Examples:
+  gego llm update <id> --set web_search=true --set seed=42
+  gego llm update <id> --set 'stop_sequences=["###", "END"]'
+  gego llm update <id> --unset seed`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLLMUpdate,
+}
+
+var llmModelsCmd = &cobra.Command{
+	Use:   "models [id]",
+	Short: "Re-list provider models and check configured LLMs against them",
+	Long: `Re-fetch the model list from an LLM's provider and warn if its configured
+model is no longer available, suggesting alternatives.
+
+Examples:
+  gego llm models <id>          # list models available for the LLM's provider
+  gego llm models <id> --verify # only check the configured model
+  gego llm models --verify      # check every configured LLM`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLLMModels,
 }
 
 func init() {
 	llmCmd.AddCommand(llmAddCmd)
+	llmCmd.AddCommand(llmModelsCmd)
 	llmCmd.AddCommand(llmListCmd)
 	llmCmd.AddCommand(llmGetCmd)
 	llmCmd.AddCommand(llmUpdateCmd)
 	llmCmd.AddCommand(llmDeleteCmd)
 	llmCmd.AddCommand(llmEnableCmd)
 	llmCmd.AddCommand(llmDisableCmd)
+
+	llmAddCmd.Flags().BoolVar(&llmAddForce, "force", false, "Add models even if an identical LLM already exists")
+	llmAddCmd.Flags().BoolVar(&createDisabledFlag, "disabled", false, "Add the LLMs disabled, to enable them after review")
+	llmModelsCmd.Flags().BoolVar(&llmModelsVerify, "verify", false, "Only check that the configured model is still available")
+	llmGetCmd.Flags().BoolVar(&llmGetStats, "stats", false, "Show response statistics and top keywords for the LLM")
+	llmUpdateCmd.Flags().StringVar(&llmPromptPrefix, "prompt-prefix", "", "Text sent before every prompt run with the LLM, e.g. \"Answer in French:\" (\"\" clears it)")
+	llmEnableCmd.Flags().StringVar(&llmProvider, "provider", "", "Enable every LLM of this provider instead of one LLM")
+	llmDisableCmd.Flags().StringVar(&llmProvider, "provider", "", "Disable every LLM of this provider instead of one LLM")
+	llmUpdateCmd.Flags().StringVar(&llmPromptSuffix, "prompt-suffix", "", "Text sent after every prompt run with the LLM (\"\" clears it)")
+	llmUpdateCmd.Flags().StringArrayVar(&llmSet, "set", nil, "Set a provider setting in the LLM config, as key=value (repeatable)")
+	llmUpdateCmd.Flags().StringArrayVar(&llmUnset, "unset", nil, "Remove a provider setting from the LLM config (repeatable)")
 }
 
 func runLLMAdd(cmd *cobra.Command, args []string) error {
 	reader := bufio.NewReader(os.Stdin)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
-	fmt.Printf("%s➕ Add New LLM Models%s\n", FormatHeader(""), Reset)
+	llms, err := promptNewLLMs(ctx, reader)
+	if err != nil || len(llms) == 0 {
+		return err
+	}
+	_, err = saveNewLLMs(ctx, reader, llms)
+	return err
+}
+
+// promptNewLLMs asks for a provider, its credentials and models, and returns one LLM per selected
+// model without saving them. It returns no LLM when the provider offers no model.
+func promptNewLLMs(ctx context.Context, reader *bufio.Reader) ([]*models.LLMConfig, error) {
+	fmt.Printf("%s%s%s\n", FormatHeader(""), i18n.T("llm_add.header"), Reset)
 	fmt.Printf("%s====================%s\n", DimStyle, Reset)
 	fmt.Println()
 
-	fmt.Printf("%sAvailable providers:%s\n", LabelStyle, Reset)
+	fmt.Printf("%s%s%s\n", LabelStyle, i18n.T("llm_add.available_providers"), Reset)
 	providers := services.AllProviders()
 	for i, provider := range providers {
 		fmt.Printf("  %s%d. %s%s\n", CountStyle, i+1, Reset, FormatValue(provider.DisplayName()))
 	}
 
-	providerChoice, err := promptWithRetry(reader, "\nSelect provider (1, 2, 3, 4, or 5): ", func(input string) (string, error) {
+	providerChoice, err := promptWithRetry(reader, "\n"+i18n.T("llm_add.select_provider", len(providers)), func(input string) (string, error) {
 		switch input {
-		case "1", "2", "3", "4", "5":
+		case "1", "2", "3", "4", "5", "6", "7", "8":
 			return input, nil
 		default:
-			return "", fmt.Errorf("invalid provider choice: %s (choose 1, 2, 3, 4, or 5)", input)
+			return "", fmt.Errorf("%s", i18n.T("llm_add.invalid_provider", input, len(providers)))
 		}
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var selectedProvider services.Provider
@@ -118,30 +199,36 @@ func runLLMAdd(cmd *cobra.Command, args []string) error {
 		selectedProvider = services.Google
 	case "5":
 		selectedProvider = services.Perplexity
+	case "6":
+		selectedProvider = services.DeepSeek
+	case "7":
+		selectedProvider = services.XAI
+	case "8":
+		selectedProvider = services.Bedrock
 	}
 
 	providerName := selectedProvider.String()
 
 	var apiKey, baseURL string
 
-	if selectedProvider == services.OpenAI || selectedProvider == services.Anthropic || selectedProvider == services.Google || selectedProvider == services.Perplexity {
-		fmt.Printf("\n🔑 %s API Key Required\n", selectedProvider.DisplayName())
-		fmt.Printf("Get your API key from: %s\n", selectedProvider.GetConsoleURL())
+	if selectedProvider.RequiresAPIKey() {
+		fmt.Println("\n" + i18n.T("llm_add.api_key_required", selectedProvider.DisplayName()))
+		fmt.Println(i18n.T("llm_add.api_key_console", selectedProvider.GetConsoleURL()))
 
 		llmService := services.NewLLMService(database)
 		existingKeys, err := llmService.GetExistingAPIKeysForProvider(ctx, providerName)
 		if err != nil {
-			return fmt.Errorf("failed to check existing API keys: %w", err)
+			return nil, fmt.Errorf("failed to check existing API keys: %w", err)
 		}
 
 		if len(existingKeys) > 0 {
-			fmt.Printf("\n%sFound existing API key(s) for %s:%s\n", InfoStyle, selectedProvider.DisplayName(), Reset)
+			fmt.Printf("\n%s%s%s\n", InfoStyle, i18n.T("llm_add.existing_keys", selectedProvider.DisplayName()), Reset)
 			for i, key := range existingKeys {
-				fmt.Printf("  %s%d. %s%s\n", CountStyle, i+1, Reset, services.MaskAPIKey(key))
+				fmt.Printf("  %s%d. %s%s\n", CountStyle, i+1, Reset, shared.MaskAPIKey(key))
 			}
-			fmt.Printf("  %s%d. Add new API key%s\n", CountStyle, len(existingKeys)+1, Reset)
+			fmt.Printf("  %s%d. %s%s\n", CountStyle, len(existingKeys)+1, i18n.T("llm_add.new_key_option"), Reset)
 
-			choice, err := promptWithRetry(reader, fmt.Sprintf("\nSelect API key (1-%d): ", len(existingKeys)+1), func(input string) (string, error) {
+			choice, err := promptWithRetry(reader, "\n"+i18n.T("llm_add.select_key", len(existingKeys)+1), func(input string) (string, error) {
 				var idx int
 				_, err := fmt.Sscanf(input, "%d", &idx)
 				if err != nil || idx < 1 || idx > len(existingKeys)+1 {
@@ -150,7 +237,7 @@ func runLLMAdd(cmd *cobra.Command, args []string) error {
 				return input, nil
 			})
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			var choiceIdx int
@@ -158,27 +245,27 @@ func runLLMAdd(cmd *cobra.Command, args []string) error {
 
 			if choiceIdx <= len(existingKeys) {
 				apiKey = existingKeys[choiceIdx-1]
-				fmt.Printf("%s✅ Using existing API key: %s%s\n", SuccessStyle, services.MaskAPIKey(apiKey), Reset)
+				fmt.Printf("%s%s%s\n", SuccessStyle, i18n.T("llm_add.using_existing_key", shared.MaskAPIKey(apiKey)), Reset)
 			} else {
-				apiKey, err = promptWithRetry(reader, "\nNew API Key: ", func(input string) (string, error) {
+				apiKey, err = promptWithRetry(reader, "\n"+i18n.T("llm_add.new_api_key"), func(input string) (string, error) {
 					if input == "" {
 						return "", fmt.Errorf("API key is required for %s", selectedProvider.DisplayName())
 					}
 					return input, nil
 				})
 				if err != nil {
-					return err
+					return nil, err
 				}
 			}
 		} else {
-			apiKey, err = promptWithRetry(reader, "\nAPI Key: ", func(input string) (string, error) {
+			apiKey, err = promptWithRetry(reader, "\n"+i18n.T("llm_add.api_key"), func(input string) (string, error) {
 				if input == "" {
 					return "", fmt.Errorf("API key is required for %s", selectedProvider.DisplayName())
 				}
 				return input, nil
 			})
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
@@ -187,45 +274,80 @@ func runLLMAdd(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\n🌐 %s Configuration\n", selectedProvider.DisplayName())
 		fmt.Printf("Ollama setup guide: %s\n", selectedProvider.GetConsoleURL())
 
-		baseURL, err = promptWithRetry(reader, "\nBase URL [http://localhost:11434]: ", func(input string) (string, error) {
+		defaultBaseURL := selectedProvider.DefaultBaseURL()
+		baseURL, err = promptWithRetry(reader, fmt.Sprintf("\nBase URL [%s]: ", defaultBaseURL), func(input string) (string, error) {
 			if input == "" {
-				return "http://localhost:11434", nil
+				return defaultBaseURL, nil
+			}
+			return services.NormalizeBaseURL(selectedProvider.String(), input)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if selectedProvider == services.Bedrock {
+		fmt.Printf("\n☁️  %s Configuration\n", selectedProvider.DisplayName())
+		fmt.Println("Bedrock uses your AWS credentials (environment variables or ~/.aws/credentials)")
+		fmt.Printf("Enable model access in: %s\n", selectedProvider.GetConsoleURL())
+
+		defaultRegion := bedrock.EnvironmentRegion()
+		region, err := promptWithRetry(reader, fmt.Sprintf("\nAWS region [%s]: ", defaultRegion), func(input string) (string, error) {
+			if input == "" {
+				return defaultRegion, nil
+			}
+			if !bedrock.ValidRegion(input) {
+				return "", fmt.Errorf("invalid AWS region: %s (e.g. us-east-1)", input)
 			}
 			return input, nil
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
+		baseURL = bedrock.RuntimeURL(region)
 	}
 
-	fmt.Println("\n🔍 Fetching available models...")
+	fmt.Println("\n" + i18n.T("llm_add.fetching_models"))
 
 	provider, ok := llmRegistry.Get(providerName)
 	if !ok {
-		return fmt.Errorf("provider not found in registry: %s", providerName)
+		return nil, fmt.Errorf("provider not found in registry: %s", providerName)
 	}
 
 	availableModels, err := provider.ListModels(ctx, apiKey, baseURL)
 	if err != nil {
-		return fmt.Errorf("failed to list models: %w", err)
+		return nil, fmt.Errorf("failed to list models: %w", err)
 	}
 
 	if len(availableModels) == 0 {
-		fmt.Println("\n⚠️  No models found for this provider")
-		return nil
+		fmt.Println("\n" + i18n.T("llm_add.no_models"))
+		return nil, nil
 	}
 
-	fmt.Println("\nAvailable text-to-text models:")
+	defaultIdx := defaultModelIndex(availableModels, cfg.DefaultModel(providerName))
+
+	fmt.Println("\n" + i18n.T("llm_add.available_models"))
 	fmt.Println("==============================")
 	for i, model := range availableModels {
 		fmt.Printf("%d. %s", i+1, model.Name)
 		if model.Description != "" {
 			fmt.Printf(" - %s", model.Description)
 		}
+		if i == defaultIdx {
+			fmt.Printf(" %s%s%s", SuccessStyle, i18n.T("llm_add.default_marker"), Reset)
+		}
 		fmt.Println()
 	}
 
-	selection, err := promptWithRetry(reader, "\nSelect models (comma-separated numbers, or 'all'): ", func(input string) (string, error) {
+	selectionPrompt := "\n" + i18n.T("llm_add.select_models")
+	if defaultIdx >= 0 {
+		selectionPrompt = "\n" + i18n.T("llm_add.select_models_default", defaultIdx+1)
+	}
+
+	selection, err := promptWithRetry(reader, selectionPrompt, func(input string) (string, error) {
+		if input == "" && defaultIdx >= 0 {
+			return strconv.Itoa(defaultIdx + 1), nil
+		}
 		if strings.ToLower(input) == "all" {
 			return input, nil
 		}
@@ -242,7 +364,7 @@ func runLLMAdd(cmd *cobra.Command, args []string) error {
 		return input, nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var selectedModels []models.ModelInfo
@@ -259,38 +381,69 @@ func runLLMAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Printf("\n%s📝 Adding %s model(s)...%s\n", InfoStyle, FormatCount(len(selectedModels)), Reset)
-
-	addedCount := 0
+	var llms []*models.LLMConfig
 	for _, model := range selectedModels {
-		llm := &models.LLMConfig{
+		llms = append(llms, &models.LLMConfig{
 			ID:        uuid.New().String(),
 			Name:      model.Name,
 			Provider:  providerName,
 			Model:     model.ID,
 			APIKey:    apiKey,
 			BaseURL:   baseURL,
-			Enabled:   true,
+			Enabled:   createEnabled(),
+			Owner:     ownerFlag,
 			Config:    make(map[string]string),
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
-		}
+		})
+	}
+	return llms, nil
+}
+
+// saveNewLLMs saves llms, asking whether to add a duplicate of an existing LLM or reuse it. It
+// returns the LLM to use for each of llms: the saved LLM, the reused existing one, or nil when it
+// failed to save.
+func saveNewLLMs(ctx context.Context, reader *bufio.Reader, llms []*models.LLMConfig) ([]*models.LLMConfig, error) {
+	fmt.Printf("\n%s%s%s\n", InfoStyle, i18n.T("llm_add.adding_models", FormatCount(len(llms))), Reset)
 
-		if err := database.CreateLLM(ctx, llm); err != nil {
-			fmt.Printf("%s⚠️  Failed to add %s: %s%s\n", ErrorStyle, FormatValue(model.Name), FormatValue(err.Error()), Reset)
+	llmService := services.NewLLMService(database)
+
+	saved := make([]*models.LLMConfig, len(llms))
+	addedCount := 0
+	for i, llm := range llms {
+		err := llmService.CreateLLM(ctx, llm, llmAddForce)
+		var duplicate *services.DuplicateLLMError
+		if errors.As(err, &duplicate) {
+			fmt.Printf("%s⚠️  %s already exists as %s (ID: %s)%s\n", WarningStyle, FormatValue(llm.Name), FormatValue(duplicate.ExistingName), FormatSecondary(duplicate.ExistingID), Reset)
+			addAnyway, promptErr := promptYesNo(reader, "Add a duplicate anyway? Otherwise the existing LLM is reused (y/N): ")
+			if promptErr != nil {
+				return saved, promptErr
+			}
+			if !addAnyway {
+				fmt.Printf("%s♻️  Reusing: %s (ID: %s)%s\n", InfoStyle, FormatValue(duplicate.ExistingName), FormatSecondary(duplicate.ExistingID), Reset)
+				if existing, err := llmService.GetLLM(ctx, duplicate.ExistingID); err == nil {
+					saved[i] = existing
+				}
+				continue
+			}
+			err = llmService.CreateLLM(ctx, llm, true)
+		}
+		if err != nil {
+			fmt.Printf("%s⚠️  Failed to add %s: %s%s\n", ErrorStyle, FormatValue(llm.Name), FormatValue(err.Error()), Reset)
 			continue
 		}
 
-		fmt.Printf("%s✅ Added: %s (ID: %s)%s\n", SuccessStyle, FormatValue(model.Name), FormatSecondary(llm.ID), Reset)
+		fmt.Printf("%s%s%s\n", SuccessStyle, i18n.T("llm_add.added", FormatValue(llm.Name), FormatSecondary(llm.ID)), Reset)
+		saved[i] = llm
 		addedCount++
 	}
 
-	fmt.Printf("\n%s🎉 Successfully added %s/%s model(s)!%s\n", SuccessStyle, FormatCount(addedCount), FormatCount(len(selectedModels)), Reset)
-	return nil
+	fmt.Printf("\n%s%s%s\n", SuccessStyle, i18n.T("llm_add.added_summary", FormatCount(addedCount), FormatCount(len(llms))), Reset)
+	return saved, nil
 }
 
 func runLLMList(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
 
 	llmService := services.NewLLMService(database)
 	llms, err := llmService.ListLLMs(ctx, nil)
@@ -312,6 +465,9 @@ func runLLMList(cmd *cobra.Command, args []string) error {
 		if !llm.Enabled {
 			enabled = "No"
 		}
+		if llm.DisabledReason != "" {
+			enabled += " (" + llm.DisabledReason + ")"
+		}
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
 			FormatSecondary(llm.ID),
 			FormatValue(llm.Name),
@@ -328,7 +484,7 @@ func runLLMList(cmd *cobra.Command, args []string) error {
 }
 
 func runLLMGet(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	id := args[0]
 
 	llmService := services.NewLLMService(database)
@@ -343,11 +499,26 @@ func runLLMGet(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%sName: %s\n", LabelStyle, FormatValue(llm.Name))
 	fmt.Printf("%sProvider: %s\n", LabelStyle, FormatSecondary(llm.Provider))
 	fmt.Printf("%sModel: %s\n", LabelStyle, FormatValue(llm.Model))
-	fmt.Printf("%sAPI Key: %s\n", LabelStyle, FormatSecondary(services.MaskAPIKey(llm.APIKey)))
+	fmt.Printf("%sAPI Key: %s\n", LabelStyle, FormatSecondary(cmp.Or(shared.MaskAPIKey(llm.APIKey), shared.APIKeyNotSet)))
 	if llm.BaseURL != "" {
 		fmt.Printf("%sBase URL: %s\n", LabelStyle, FormatSecondary(llm.BaseURL))
 	}
 	fmt.Printf("%sEnabled: %s\n", LabelStyle, FormatValue(fmt.Sprintf("%v", llm.Enabled)))
+	if llm.DisabledReason != "" {
+		fmt.Printf("%sDisabled Reason: %s\n", LabelStyle, FormatValue(llm.DisabledReason))
+	}
+	if llm.AuthFailures > 0 {
+		fmt.Printf("%sAuth Failures: %s\n", LabelStyle, FormatCount(llm.AuthFailures))
+	}
+	if llm.Owner != "" {
+		fmt.Printf("%sOwner: %s\n", LabelStyle, FormatValue(llm.Owner))
+	}
+	if llm.PromptPrefix != "" {
+		fmt.Printf("%sPrompt Prefix: %s\n", LabelStyle, FormatValue(strconv.Quote(llm.PromptPrefix)))
+	}
+	if llm.PromptSuffix != "" {
+		fmt.Printf("%sPrompt Suffix: %s\n", LabelStyle, FormatValue(strconv.Quote(llm.PromptSuffix)))
+	}
 	fmt.Printf("%sCreated: %s\n", LabelStyle, FormatMeta(llm.CreatedAt.Format(time.RFC3339)))
 	fmt.Printf("%sUpdated: %s\n", LabelStyle, FormatMeta(llm.UpdatedAt.Format(time.RFC3339)))
 
@@ -358,11 +529,59 @@ func runLLMGet(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if llmGetStats {
+		return printLLMStats(ctx, llm.ID)
+	}
+
+	return nil
+}
+
+// defaultModelIndex returns the index of the configured default model in available, matched by ID
+// or name, or -1 when there is no default or it is not offered
+func defaultModelIndex(available []models.ModelInfo, defaultModel string) int {
+	if defaultModel == "" {
+		return -1
+	}
+	for i, model := range available {
+		if strings.EqualFold(model.ID, defaultModel) || strings.EqualFold(model.Name, defaultModel) {
+			return i
+		}
+	}
+	return -1
+}
+
+// printLLMStats prints an LLM's response statistics and top keywords
+func printLLMStats(ctx context.Context, llmID string) error {
+	stats, err := statsService.GetLLMStats(ctx, llmID)
+	if err != nil {
+		return fmt.Errorf("failed to get LLM stats: %w", err)
+	}
+
+	fmt.Printf("\n%sStatistics:%s\n", SuccessStyle, Reset)
+	fmt.Printf("%s───────────%s\n", DimStyle, Reset)
+	fmt.Printf("%sTotal Responses: %s\n", LabelStyle, FormatCount(stats.TotalResponses))
+	if stats.TotalResponses == 0 {
+		return nil
+	}
+	fmt.Printf("%sUnique Prompts: %s\n", LabelStyle, FormatCount(stats.UniquePrompts))
+	fmt.Printf("%sAvg Tokens: %s\n", LabelStyle, FormatValue(fmt.Sprintf("%.1f", stats.AvgTokens)))
+
+	keywords, err := statsService.GetLLMTopKeywords(ctx, llmID, llmStatsKeywordLimit)
+	if err != nil {
+		return err
+	}
+	if len(keywords) > 0 {
+		fmt.Printf("%sTop Keywords:%s\n", LabelStyle, Reset)
+		for i, keyword := range keywords {
+			fmt.Printf("  %s%d. %s%s %s\n", CountStyle, i+1, Reset, FormatValue(keyword.Keyword), FormatMeta(fmt.Sprintf("(%d mentions)", keyword.Count)))
+		}
+	}
+
 	return nil
 }
 
 func runLLMDelete(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	reader := bufio.NewReader(os.Stdin)
 
 	fmt.Printf("%s🗑️  Delete LLM Providers%s\n", FormatHeader(""), Reset)
@@ -387,6 +606,9 @@ func runLLMDelete(cmd *cobra.Command, args []string) error {
 		if !llm.Enabled {
 			enabled = "No"
 		}
+		if llm.DisabledReason != "" {
+			enabled += " (" + llm.DisabledReason + ")"
+		}
 		fmt.Printf("%s%d. %s%s\n", CountStyle, i+1, Reset, FormatValue(llm.Name))
 		fmt.Printf("   %sProvider: %s%s\n", DimStyle, FormatSecondary(llm.Provider), Reset)
 		fmt.Printf("   %sModel: %s%s\n", DimStyle, FormatSecondary(llm.Model), Reset)
@@ -461,8 +683,36 @@ func runLLMDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// llmIDOrProviderArgs accepts either one LLM ID or the --provider flag
+func llmIDOrProviderArgs(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("provider") {
+		return cobra.NoArgs(cmd, args)
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// runLLMSetProviderEnabled enables or disables every LLM of the --provider provider
+func runLLMSetProviderEnabled(cmd *cobra.Command, enabled bool) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+	action := "disabled"
+	if enabled {
+		action = "enabled"
+	}
+
+	changed, err := services.NewLLMService(database).SetProviderEnabled(ctx, llmProvider, enabled)
+	if err != nil {
+		return fmt.Errorf("failed to update %s LLMs: %w", llmProvider, err)
+	}
+
+	fmt.Printf("%s✅ %s %s LLM(s) %s%s\n", SuccessStyle, FormatCount(changed), llmProvider, action, Reset)
+	return nil
+}
+
 func runLLMEnable(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	if cmd.Flags().Changed("provider") {
+		return runLLMSetProviderEnabled(cmd, true)
+	}
+	ctx := cmd.Context()
 	id := args[0]
 
 	llmService := services.NewLLMService(database)
@@ -475,7 +725,10 @@ func runLLMEnable(cmd *cobra.Command, args []string) error {
 }
 
 func runLLMDisable(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	if cmd.Flags().Changed("provider") {
+		return runLLMSetProviderEnabled(cmd, false)
+	}
+	ctx := cmd.Context()
 	id := args[0]
 
 	llmService := services.NewLLMService(database)
@@ -488,7 +741,7 @@ func runLLMDisable(cmd *cobra.Command, args []string) error {
 }
 
 func runLLMUpdate(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	id := args[0]
 	reader := bufio.NewReader(os.Stdin)
 
@@ -506,11 +759,38 @@ func runLLMUpdate(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Name: %s\n", llm.Name)
 	fmt.Printf("  Provider: %s\n", llm.Provider)
 	fmt.Printf("  Model: %s\n", llm.Model)
-	fmt.Printf("  API Key: %s\n", services.MaskAPIKey(llm.APIKey))
+	fmt.Printf("  API Key: %s\n", cmp.Or(shared.MaskAPIKey(llm.APIKey), shared.APIKeyNotSet))
 	fmt.Printf("  Base URL: %s\n", llm.BaseURL)
 	fmt.Printf("  Enabled: %t\n", llm.Enabled)
+	if llm.PromptPrefix != "" || llm.PromptSuffix != "" {
+		fmt.Printf("  Prompt Prefix: %q\n", llm.PromptPrefix)
+		fmt.Printf("  Prompt Suffix: %q\n", llm.PromptSuffix)
+	}
+	for _, key := range slices.Sorted(maps.Keys(llm.Config)) {
+		fmt.Printf("  Config %s: %s\n", key, llm.Config[key])
+	}
 	fmt.Println()
 
+	if cmd.Flags().Changed("prompt-prefix") {
+		llm.PromptPrefix = strings.TrimSpace(llmPromptPrefix)
+	}
+	if cmd.Flags().Changed("prompt-suffix") {
+		llm.PromptSuffix = strings.TrimSpace(llmPromptSuffix)
+	}
+
+	if len(llmSet) > 0 || len(llmUnset) > 0 {
+		config, err := applyLLMSettings(llm.Config, llmSet, llmUnset)
+		if err != nil {
+			return err
+		}
+		llm.Config = config
+		if err := llmService.UpdateLLM(ctx, llm); err != nil {
+			return fmt.Errorf("failed to update LLM: %w", err)
+		}
+		fmt.Println("✅ LLM provider updated successfully!")
+		return nil
+	}
+
 	provider := services.FromString(llm.Provider)
 	apiKeyURL := provider.GetConsoleURL()
 	if apiKeyURL != "" {
@@ -523,14 +803,19 @@ func runLLMUpdate(cmd *cobra.Command, args []string) error {
 		llm.APIKey = apiKey
 	}
 
-	if provider == services.Ollama || llm.Provider == "custom" {
-		fmt.Print("Enter new base URL (press Enter to keep current): ")
-		baseURL, _ := reader.ReadString('\n')
-		baseURL = strings.TrimSpace(baseURL)
-		if baseURL != "" {
-			llm.BaseURL = baseURL
+	baseURL, err := promptWithRetry(reader, "Enter new base URL (press Enter to keep current, '-' for the provider default): ", func(input string) (string, error) {
+		switch input {
+		case "":
+			return llm.BaseURL, nil
+		case "-":
+			return "", nil
 		}
+		return services.NormalizeBaseURL(llm.Provider, input)
+	})
+	if err != nil {
+		return err
 	}
+	llm.BaseURL = baseURL
 
 	fmt.Print("Enable this LLM? (y/N): ")
 	enabledStr, _ := reader.ReadString('\n')
@@ -549,3 +834,109 @@ func runLLMUpdate(cmd *cobra.Command, args []string) error {
 	fmt.Println("\n✅ LLM provider updated successfully!")
 	return nil
 }
+
+func runLLMModels(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	llmService := services.NewLLMService(database)
+
+	var llms []*models.LLMConfig
+	if len(args) == 1 {
+		config, err := llmService.GetLLM(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get LLM: %w", err)
+		}
+		llms = append(llms, config)
+	} else {
+		if !llmModelsVerify {
+			return fmt.Errorf("an LLM ID is required unless --verify is set")
+		}
+		all, err := llmService.ListLLMs(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list LLMs: %w", err)
+		}
+		llms = all
+	}
+
+	if len(llms) == 0 {
+		fmt.Printf("%sNo LLM providers configured. Use '%s' to add one.%s\n", WarningStyle, FormatSecondary("gego llm add"), Reset)
+		return nil
+	}
+
+	unavailable := 0
+	for _, config := range llms {
+		provider, ok := llmRegistry.Get(config.Provider)
+		if !ok {
+			fmt.Printf("%s⚠️  %s: provider not found in registry: %s%s\n", WarningStyle, FormatValue(config.Name), config.Provider, Reset)
+			continue
+		}
+
+		check, available, err := llmService.VerifyModel(ctx, provider, config)
+		if err != nil {
+			fmt.Printf("%s❌ %s: %s%s\n", ErrorStyle, FormatValue(config.Name), err.Error(), Reset)
+			continue
+		}
+
+		if !llmModelsVerify {
+			fmt.Printf("%sModels available for %s:%s\n", LabelStyle, FormatSecondary(config.Provider), Reset)
+			for i, model := range available {
+				marker := ""
+				if model.ID == config.Model {
+					marker = " " + SuccessStyle + "(configured)" + Reset
+				}
+				fmt.Printf("  %s%d. %s%s%s\n", CountStyle, i+1, Reset, FormatValue(model.ID), marker)
+			}
+			fmt.Println()
+		}
+
+		if check.Available {
+			fmt.Printf("%s✅ %s: model %s is available%s\n", SuccessStyle, FormatValue(config.Name), FormatValue(config.Model), Reset)
+			continue
+		}
+
+		unavailable++
+		fmt.Printf("%s⚠️  %s (ID: %s): model %s is no longer listed by %s%s\n", WarningStyle, FormatValue(config.Name), FormatSecondary(config.ID), FormatValue(config.Model), config.Provider, Reset)
+		if len(check.Alternatives) > 0 {
+			fmt.Printf("%s   Alternatives:%s\n", InfoStyle, Reset)
+			for _, model := range check.Alternatives {
+				fmt.Printf("     - %s\n", FormatValue(model.ID))
+			}
+		}
+	}
+
+	if unavailable > 0 {
+		fmt.Printf("\n%s%s LLM(s) reference unavailable models. Update or re-add them with '%s'.%s\n", WarningStyle, FormatCount(unavailable), FormatSecondary("gego llm add"), Reset)
+	}
+
+	return nil
+}
+
+// applyLLMSettings returns config with the key=value assignments of set applied and the keys of
+// unset removed. Keys and values are validated; config itself is left unchanged.
+func applyLLMSettings(config map[string]string, set, unset []string) (map[string]string, error) {
+	updated := maps.Clone(config)
+	if updated == nil {
+		updated = make(map[string]string)
+	}
+
+	for _, assignment := range set {
+		key, value, ok := strings.Cut(assignment, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --set %q: expected key=value", assignment)
+		}
+		if err := services.ValidateLLMSetting(key, value); err != nil {
+			return nil, err
+		}
+		updated[key] = value
+	}
+
+	for _, key := range unset {
+		key = strings.TrimSpace(key)
+		if _, ok := updated[key]; !ok {
+			return nil, fmt.Errorf("config key %q is not set", key)
+		}
+		delete(updated, key)
+	}
+
+	return updated, nil
+}
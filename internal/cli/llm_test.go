@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/config"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestApplyLLMSettings(t *testing.T) {
+	current := map[string]string{"seed": "42", "web_search": "true"}
+
+	tests := []struct {
+		name    string
+		set     []string
+		unset   []string
+		want    map[string]string
+		wantErr string
+	}{
+		{name: "set", set: []string{"reasoning_effort=high"}, want: map[string]string{"seed": "42", "web_search": "true", "reasoning_effort": "high"}},
+		{name: "overwrite", set: []string{"seed=7"}, want: map[string]string{"seed": "7", "web_search": "true"}},
+		{name: "value with equals sign", set: []string{`stop_sequences=["a=b"]`}, want: map[string]string{"seed": "42", "web_search": "true", "stop_sequences": `["a=b"]`}},
+		{name: "unset", unset: []string{"seed"}, want: map[string]string{"web_search": "true"}},
+		{name: "missing equals sign", set: []string{"seed"}, wantErr: "expected key=value"},
+		{name: "unknown key", set: []string{"temprature=0.2"}, wantErr: "unknown config key"},
+		{name: "malformed value", set: []string{"web_search=yes please"}, wantErr: "must be true or false"},
+		{name: "bad reasoning effort", set: []string{"reasoning_effort=max"}, wantErr: "must be low, medium or high"},
+		{name: "bad response format", set: []string{"response_format=yaml"}, wantErr: "must be text or json_object"},
+		{name: "unset missing key", unset: []string{"top_p"}, wantErr: "is not set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyLLMSettings(current, tt.set, tt.unset)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("config = %v, want %v", got, tt.want)
+			}
+			if current["seed"] != "42" || len(current) != 2 {
+				t.Errorf("current config modified: %v", current)
+			}
+		})
+	}
+}
+
+func TestDefaultModelIndex(t *testing.T) {
+	available := []models.ModelInfo{
+		{ID: "gpt-4o", Name: "GPT-4o"},
+		{ID: "gpt-4o-mini", Name: "GPT-4o mini"},
+		{ID: "o3-mini", Name: "o3-mini"},
+	}
+	cfg := &config.Config{DefaultModels: map[string]string{"OpenAI": " gpt-4o-mini ", "anthropic": "claude-3-opus"}}
+
+	tests := []struct {
+		name         string
+		defaultModel string
+		want         int
+	}{
+		{name: "configured for the provider", defaultModel: cfg.DefaultModel("openai"), want: 1},
+		{name: "matched by name", defaultModel: "GPT-4o", want: 0},
+		{name: "matched regardless of case", defaultModel: "O3-MINI", want: 2},
+		{name: "not offered", defaultModel: cfg.DefaultModel("anthropic"), want: -1},
+		{name: "none configured", defaultModel: cfg.DefaultModel("ollama"), want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultModelIndex(available, tt.defaultModel); got != tt.want {
+				t.Errorf("defaultModelIndex(%q) = %d, want %d", tt.defaultModel, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/config"
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+var (
+	metricsPort string
+	metricsHost string
+)
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Export keyword metrics",
+}
+
+var metricsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Serve keyword gauges to Prometheus",
+	Long: `Serve keyword gauges on /metrics in the Prometheus text format:
+
+  gego_keyword_mentions_total{keyword,provider,model}  mentions in the responses of the window
+  gego_keyword_mention_rate{keyword,provider}          fraction of a provider's responses mentioning the keyword
+
+Only the keywords registered in keyword_options, geo_score.group and analysis.keywords are
+exported. The gauges are refreshed every metrics.interval (default 5m) over the responses of
+the last metrics.window (default 720h). The config file is read again before each refresh, so
+removed keywords stop being exported without a restart.`,
+	Args: cobra.NoArgs,
+	RunE: runMetricsExport,
+}
+
+func init() {
+	metricsCmd.AddCommand(metricsExportCmd)
+
+	metricsExportCmd.Flags().StringVarP(&metricsPort, "port", "p", "9109", "Port to serve metrics on")
+	metricsExportCmd.Flags().StringVarP(&metricsHost, "host", "H", "0.0.0.0", "Host to bind the metrics server to")
+}
+
+func runMetricsExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	interval, err := cfg.Metrics.GetInterval()
+	if err != nil {
+		return err
+	}
+	window, err := cfg.Metrics.GetWindow()
+	if err != nil {
+		return err
+	}
+
+	metrics := services.NewKeywordMetrics(database)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics)
+	server := &http.Server{Addr: metricsHost + ":" + metricsPort, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	fmt.Printf("%s📈 Keyword Metrics%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s=================%s\n", DimStyle, Reset)
+	fmt.Printf("%sURL:%s %s\n", LabelStyle, Reset, FormatValue(fmt.Sprintf("http://%s/metrics", server.Addr)))
+	fmt.Printf("%sKeywords:%s %s\n", LabelStyle, Reset, FormatCount(len(cfg.RegisteredKeywords())))
+	fmt.Printf("%sRefresh:%s every %s over the last %s\n", LabelStyle, Reset, FormatValue(interval.String()), FormatValue(window.String()))
+	if len(cfg.RegisteredKeywords()) == 0 {
+		fmt.Printf("%s⚠️  No registered keyword: add keywords to keyword_options, geo_score.group or analysis.keywords%s\n", WarningStyle, Reset)
+	}
+	fmt.Println()
+
+	go metrics.Run(ctx, interval, window, registeredKeywords)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("metrics server failed: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// registeredKeywords reads the config file again and returns its registered keywords, keeping the
+// loaded config when the file cannot be read
+func registeredKeywords() []string {
+	reloaded, err := config.Load(cfgFile)
+	if err != nil {
+		logger.Warning("Failed to reload config, keeping the previous keywords: %v", err)
+		return cfg.RegisteredKeywords()
+	}
+	cfg.KeywordOptions = reloaded.KeywordOptions
+	cfg.GEOScore.Group = reloaded.GEOScore.Group
+	cfg.Analysis.Keywords = reloaded.Analysis.Keywords
+	shared.SetKeywordOptions(cfg.KeywordOptions)
+	return cfg.RegisteredKeywords()
+}
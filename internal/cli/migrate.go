@@ -0,0 +1,545 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/db/mongodb"
+)
+
+var (
+	migrateYes         bool
+	compressBatchSize  int
+	migrateMongoStatus bool
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database migrations",
+	Long: `Apply and inspect the SQLite schema migrations.
+
+Migrations are read from $GEGO_MIGRATIONS_DIR, /migrations or ./internal/db/migrations,
+in that order. No external migrate binary is required.`,
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Args:  cobra.NoArgs,
+	RunE:  runMigrateUp,
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the current schema version and pending migrations",
+	Args:  cobra.NoArgs,
+	RunE:  runMigrateStatus,
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [N]",
+	Short: "Roll back the last N migrations (default 1)",
+	Long: `Roll back the last N applied migrations (default 1).
+
+Rolling back drops the tables and columns added by those migrations, along with their data.
+You are asked to confirm unless --yes is given.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMigrateDown,
+}
+
+var migrateGotoCmd = &cobra.Command{
+	Use:   "goto <version>",
+	Short: "Migrate up or down to a specific version",
+	Long: `Migrate the schema up or down to the given version. Version 0 reverts every migration.
+
+Migrating down is destructive and asks for confirmation unless --yes is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMigrateGoto,
+}
+
+var migrateCompressCmd = &cobra.Command{
+	Use:   "compress-responses",
+	Short: "Compress the bodies of existing responses",
+	Long: `Compress the response_text of existing MongoDB responses in batches, keeping a short
+plaintext excerpt for previews. Responses no longer than the excerpt are left as they are.
+
+Run this after enabling storage.compress_responses in the config; new responses are compressed
+as they are stored.`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateCompress,
+}
+
+var migrateDomainsCmd = &cobra.Command{
+	Use:   "extract-domains",
+	Short: "Extract cited domains from existing responses",
+	Long: `Store the domains cited in MongoDB responses stored before domain extraction, in batches,
+so they are included in 'gego stats domains'. New responses get their domains as they are stored.`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateDomains,
+}
+
+var migrateSnapshotsCmd = &cobra.Command{
+	Use:   "backfill-snapshots",
+	Short: "Record LLM names on responses stored without them",
+	Long: `Record the current name, provider and model of each LLM on its MongoDB responses stored
+before these were snapshotted, so stats and search show them without looking up the LLM.
+Fields already recorded are kept, so renaming an LLM does not rewrite its history.`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateSnapshots,
+}
+
+var migrateMongoCmd = &cobra.Command{
+	Use:   "mongo",
+	Short: "Apply pending MongoDB migrations",
+	Long: `Apply the pending MongoDB index and data migrations in order. Applied migrations are
+recorded in the migrations collection, so running the command again only applies new ones.
+
+Use --status to list the migrations without applying them.`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateMongo,
+}
+
+var migrateVersionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the current schema version",
+	Args:  cobra.NoArgs,
+	RunE:  runMigrateVersion,
+}
+
+func init() {
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
+	migrateCmd.AddCommand(migrateGotoCmd)
+	migrateCmd.AddCommand(migrateVersionCmd)
+	migrateCmd.AddCommand(migrateCompressCmd)
+	migrateCmd.AddCommand(migrateDomainsCmd)
+	migrateCmd.AddCommand(migrateSnapshotsCmd)
+	migrateCmd.AddCommand(migrateMongoCmd)
+
+	migrateDownCmd.Flags().BoolVarP(&migrateYes, "yes", "y", false, "Roll back without asking for confirmation")
+	migrateGotoCmd.Flags().BoolVarP(&migrateYes, "yes", "y", false, "Migrate down without asking for confirmation")
+	migrateCompressCmd.Flags().IntVar(&compressBatchSize, "batch-size", mongodb.DefaultCompressBatchSize, "Number of responses compressed per batch")
+	migrateDomainsCmd.Flags().IntVar(&compressBatchSize, "batch-size", mongodb.DefaultCompressBatchSize, "Number of responses processed per batch")
+	migrateMongoCmd.Flags().BoolVar(&migrateMongoStatus, "status", false, "List applied and pending migrations without applying them")
+}
+
+func runMigrateUp(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	fmt.Printf("%s🔄 Running database migrations...%s\n", InfoStyle, Reset)
+	if err := runSQLiteMigrations(ctx, database); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return printMigrationVersion(ctx)
+}
+
+func runMigrateStatus(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	status, err := migrationStatus(ctx, database)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s📋 Migration Status%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s==================%s\n", DimStyle, Reset)
+	fmt.Printf("%sCurrent version: %s\n", LabelStyle, FormatCount(int(status.Version)))
+	fmt.Printf("%sAvailable: %s\n", LabelStyle, FormatSecondary(formatVersions(status.Available)))
+
+	if status.Dirty {
+		fmt.Printf("%s⚠️  Version %d is dirty: a migration failed part-way and the schema needs manual repair.%s\n", ErrorStyle, status.Version, Reset)
+	}
+
+	if len(status.Pending) == 0 {
+		fmt.Printf("%s✅ Database is up to date.%s\n", SuccessStyle, Reset)
+		return nil
+	}
+
+	fmt.Printf("%sPending: %s%s\n", WarningStyle, formatVersions(status.Pending), Reset)
+	fmt.Printf("%sRun '%s' to apply them.%s\n", InfoStyle, FormatSecondary("gego migrate up"), Reset)
+	return nil
+}
+
+func runMigrateDown(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	steps := 1
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid number of migrations: %s (must be a positive integer)", args[0])
+		}
+		steps = n
+	}
+
+	status, err := migrationStatus(ctx, database)
+	if err != nil {
+		return err
+	}
+
+	applied := status.Applied()
+	if len(applied) == 0 {
+		fmt.Printf("%sNo migrations applied, nothing to roll back.%s\n", WarningStyle, Reset)
+		return nil
+	}
+	if steps > len(applied) {
+		return fmt.Errorf("cannot roll back %d migrations: only %d applied", steps, len(applied))
+	}
+
+	confirmed, err := confirmRollback(applied[len(applied)-steps:])
+	if err != nil || !confirmed {
+		return err
+	}
+
+	sqlDB, err := sqliteConnection(database)
+	if err != nil {
+		return err
+	}
+	if err := db.RollbackMigrations(ctx, sqlDB, os.Getenv("GEGO_MIGRATIONS_DIR"), steps); err != nil {
+		return err
+	}
+
+	return printMigrationVersion(ctx)
+}
+
+func runMigrateGoto(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	target, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid version: %s", args[0])
+	}
+	version := uint(target)
+
+	status, err := migrationStatus(ctx, database)
+	if err != nil {
+		return err
+	}
+
+	if version != 0 && !containsVersion(status.Available, version) {
+		return fmt.Errorf("unknown migration version %d (available: %s)", version, formatVersions(status.Available))
+	}
+	if version == status.Version && !status.Dirty {
+		fmt.Printf("%s✅ Database is already at version %d.%s\n", SuccessStyle, version, Reset)
+		return nil
+	}
+
+	if version < status.Version {
+		var reverted []uint
+		for _, v := range status.Applied() {
+			if v > version {
+				reverted = append(reverted, v)
+			}
+		}
+		confirmed, err := confirmRollback(reverted)
+		if err != nil || !confirmed {
+			return err
+		}
+	}
+
+	sqlDB, err := sqliteConnection(database)
+	if err != nil {
+		return err
+	}
+	if err := db.MigrateTo(ctx, sqlDB, os.Getenv("GEGO_MIGRATIONS_DIR"), version); err != nil {
+		return err
+	}
+
+	return printMigrationVersion(ctx)
+}
+
+// confirmRollback lists the migrations about to be reverted and asks for confirmation unless --yes was given
+func confirmRollback(versions []uint) (bool, error) {
+	reversed := make([]uint, len(versions))
+	for i, v := range versions {
+		reversed[len(versions)-1-i] = v
+	}
+
+	fmt.Printf("%s⚠️  Warning: This will roll back migrations %s.%s\n", WarningStyle, formatVersions(reversed), Reset)
+	fmt.Printf("%sTables and columns added by these migrations will be dropped along with their data.%s\n", LabelStyle, Reset)
+
+	if migrateYes {
+		return true, nil
+	}
+
+	confirmed, err := promptYesNo(bufio.NewReader(os.Stdin), fmt.Sprintf("%sAre you sure you want to continue? (y/N): %s", ErrorStyle, Reset))
+	if err != nil {
+		return false, err
+	}
+	if !confirmed {
+		fmt.Printf("%sCancelled.%s\n", WarningStyle, Reset)
+	}
+	return confirmed, nil
+}
+
+// printMigrationVersion prints the schema version after a migration
+func printMigrationVersion(ctx context.Context) error {
+	status, err := migrationStatus(ctx, database)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s✅ Database is at version %s%s\n", SuccessStyle, FormatCount(int(status.Version)), Reset)
+	return nil
+}
+
+// containsVersion reports whether versions includes version
+func containsVersion(versions []uint, version uint) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+func runMigrateVersion(cmd *cobra.Command, args []string) error {
+	status, err := migrationStatus(cmd.Context(), database)
+	if err != nil {
+		return err
+	}
+
+	if status.Dirty {
+		fmt.Printf("%d (dirty)\n", status.Version)
+		return nil
+	}
+	fmt.Println(status.Version)
+	return nil
+}
+
+func runMigrateCompress(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	mongoDB, err := mongoConnection(database)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s🗜️  Compressing stored responses...%s\n", InfoStyle, Reset)
+	compressed, err := mongoDB.CompressResponses(ctx, compressBatchSize, func(scanned, compressed, total int64) {
+		fmt.Printf("\r%sScanned %d/%d responses, compressed %d%s", LabelStyle, scanned, total, compressed, Reset)
+	})
+	fmt.Println()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s✅ Compressed %s responses%s\n", SuccessStyle, FormatCount(int(compressed)), Reset)
+	return nil
+}
+
+func runMigrateDomains(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	mongoDB, err := mongoConnection(database)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s🔗 Extracting cited domains from stored responses...%s\n", InfoStyle, Reset)
+	updated, err := mongoDB.ExtractResponseDomains(ctx, compressBatchSize, func(scanned, updated, total int64) {
+		fmt.Printf("\r%sScanned %d/%d responses%s", LabelStyle, scanned, total, Reset)
+	})
+	fmt.Println()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s✅ Processed %s responses%s\n", SuccessStyle, FormatCount(int(updated)), Reset)
+	return nil
+}
+
+func runMigrateSnapshots(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	mongoDB, err := mongoConnection(database)
+	if err != nil {
+		return err
+	}
+
+	llms, err := database.ListLLMs(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list LLMs: %w", err)
+	}
+
+	fmt.Printf("%s📸 Recording LLM snapshots on stored responses...%s\n", InfoStyle, Reset)
+	updated, missing, err := mongoDB.BackfillLLMSnapshots(ctx, llms)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s✅ Updated %s responses%s\n", SuccessStyle, FormatCount(int(updated)), Reset)
+	if missing > 0 {
+		fmt.Printf("%s⚠️  %d responses belong to deleted LLMs and have no snapshot%s\n", WarningStyle, missing, Reset)
+	}
+	return nil
+}
+
+func runMigrateMongo(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	mongoDB, err := mongoConnection(database)
+	if err != nil {
+		return err
+	}
+
+	if migrateMongoStatus {
+		return printMongoMigrationStatus(ctx, mongoDB)
+	}
+
+	fmt.Printf("%s🔄 Running MongoDB migrations...%s\n", InfoStyle, Reset)
+	applied, err := mongoDB.RunMigrations(ctx, mongodb.Migrations, func(migration mongodb.Migration) {
+		fmt.Printf("%s  %d: %s%s\n", LabelStyle, migration.Version, migration.Description, Reset)
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		fmt.Printf("%s✅ MongoDB is up to date.%s\n", SuccessStyle, Reset)
+		return nil
+	}
+	fmt.Printf("%s✅ Applied %s MongoDB migration(s)%s\n", SuccessStyle, FormatCount(len(applied)), Reset)
+	return nil
+}
+
+// printMongoMigrationStatus lists the applied and pending MongoDB migrations
+func printMongoMigrationStatus(ctx context.Context, mongoDB *mongodb.MongoDB) error {
+	applied, err := mongoDB.AppliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	pending, err := mongoDB.PendingMigrations(ctx, mongodb.Migrations)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s📋 MongoDB Migration Status%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s==========================%s\n", DimStyle, Reset)
+	for _, migration := range applied {
+		fmt.Printf("%s✅ %d: %s %s\n", SuccessStyle, migration.Version, FormatValue(migration.Description),
+			FormatMeta("("+migration.AppliedAt.Local().Format("2006-01-02 15:04")+")"))
+	}
+	for _, migration := range pending {
+		fmt.Printf("%s⏳ %d: %s%s\n", WarningStyle, migration.Version, migration.Description, Reset)
+	}
+
+	if len(pending) == 0 {
+		fmt.Printf("%s✅ MongoDB is up to date.%s\n", SuccessStyle, Reset)
+		return nil
+	}
+	fmt.Printf("%sRun '%s' to apply them.%s\n", InfoStyle, FormatSecondary("gego migrate mongo"), Reset)
+	return nil
+}
+
+// mongoConnection returns the MongoDB side of a hybrid database
+func mongoConnection(database db.Database) (*mongodb.MongoDB, error) {
+	hybridDB, ok := database.(*db.HybridDB)
+	if !ok {
+		return nil, fmt.Errorf("database is not a HybridDB instance")
+	}
+
+	mongoDB := hybridDB.GetNoSQLDatabase()
+	if mongoDB == nil {
+		return nil, fmt.Errorf("MongoDB database not available")
+	}
+	return mongoDB, nil
+}
+
+// runSQLiteMigrations applies pending migrations to the SQLite side of the hybrid database
+func runSQLiteMigrations(ctx context.Context, database db.Database) error {
+	sqlDB, err := sqliteConnection(database)
+	if err != nil {
+		return err
+	}
+
+	return db.RunMigrations(ctx, sqlDB, os.Getenv("GEGO_MIGRATIONS_DIR"))
+}
+
+// migrationStatus reports the migration state of the SQLite side of the hybrid database
+func migrationStatus(ctx context.Context, database db.Database) (*db.MigrationStatus, error) {
+	sqlDB, err := sqliteConnection(database)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := db.GetMigrationStatus(ctx, sqlDB, os.Getenv("GEGO_MIGRATIONS_DIR"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration status: %w", err)
+	}
+	return status, nil
+}
+
+// checkSchemaVersion fails when the SQLite schema is older than this version of gego expects, so
+// that commands do not fail on missing columns
+func checkSchemaVersion(ctx context.Context, database db.Database) error {
+	sqlDB, err := sqliteConnection(database)
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := db.SchemaVersion(ctx, sqlDB)
+	if err != nil {
+		return fmt.Errorf("failed to check the SQLite schema: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("SQLite schema version %d is dirty: a migration failed part-way. Repair the schema, then check it with 'gego migrate status'", version)
+	}
+	if version < db.LatestSchemaVersion {
+		return fmt.Errorf("SQLite schema is at version %d, this version of gego needs version %d. Run 'gego migrate up' first", version, db.LatestSchemaVersion)
+	}
+	return nil
+}
+
+// isMigrateCommand reports whether cmd is gego migrate or one of its subcommands
+func isMigrateCommand(cmd *cobra.Command) bool {
+	for ; cmd != nil; cmd = cmd.Parent() {
+		if cmd == migrateCmd {
+			return true
+		}
+	}
+	return false
+}
+
+// sqliteConnection returns the SQLite connection of a hybrid database
+func sqliteConnection(database db.Database) (*sql.DB, error) {
+	hybridDB, ok := database.(*db.HybridDB)
+	if !ok {
+		return nil, fmt.Errorf("database is not a HybridDB instance")
+	}
+
+	sqliteDB := hybridDB.GetSQLiteDatabase()
+	if sqliteDB == nil {
+		return nil, fmt.Errorf("SQLite database not available")
+	}
+
+	sqlDB := sqliteDB.GetDB()
+	if sqlDB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	return sqlDB, nil
+}
+
+// formatVersions renders migration versions as a comma-separated list
+func formatVersions(versions []uint) string {
+	if len(versions) == 0 {
+		return "none"
+	}
+
+	parts := make([]string, len(versions))
+	for i, v := range versions {
+		parts[i] = strconv.FormatUint(uint64(v), 10)
+	}
+	return strings.Join(parts, ", ")
+}
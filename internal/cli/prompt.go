@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -12,14 +13,21 @@ import (
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
-	"github.com/AI2HU/gego/internal/llm"
-	"github.com/AI2HU/gego/internal/llm/anthropic"
-	"github.com/AI2HU/gego/internal/llm/google"
-	"github.com/AI2HU/gego/internal/llm/ollama"
-	"github.com/AI2HU/gego/internal/llm/openai"
+	"github.com/AI2HU/gego/internal/i18n"
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
 )
 
+var (
+	promptGetStats     bool
+	promptGetResponses int
+	promptImportDryRun bool
+)
+
+// promptStatsKeywordLimit is the number of top keywords shown by prompt get --stats
+const promptStatsKeywordLimit = 5
+
 var promptCmd = &cobra.Command{
 	Use:   "prompt",
 	Short: "Manage prompts for keyword tracking",
@@ -41,11 +49,14 @@ var promptListCmd = &cobra.Command{
 }
 
 var promptGetCmd = &cobra.Command{
-	Use:   "get [id]",
-	Short: "Get details of a prompt template",
-	Long:  `Show detailed information about a specific prompt template used for keyword tracking.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runPromptGet,
+	Use:     "get [id]",
+	Aliases: []string{"show"},
+	Short:   "Get details of a prompt template",
+	Long: `Show detailed information about a specific prompt template used for keyword tracking.
+
+With --responses N, the N latest responses of each LLM are shown side by side with their top keywords.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPromptGet,
 }
 
 var promptDeleteCmd = &cobra.Command{
@@ -72,6 +83,38 @@ var promptDisableCmd = &cobra.Command{
 	RunE:  runPromptDisable,
 }
 
+var promptTokensCmd = &cobra.Command{
+	Use:   "tokens [id]",
+	Short: "Estimate the token footprint of a prompt",
+	Long: `Estimate the prompt tokens for every configured LLM, alongside its max completion tokens,
+and project the monthly token volume of the enabled schedules that use the prompt.
+
+Estimates are computed locally: OpenAI models use a BPE approximation, other providers a
+chars/4 heuristic. No provider is called.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPromptTokens,
+}
+
+var promptImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import a pack of prompts from a YAML or JSON file",
+	Long: `Import the prompts of a YAML or JSON pack file:
+
+  prompts:
+    - template: "What are the best CRM tools for startups?"
+      tags: [crm, startups]
+    - template: "Which project management software do you recommend?"
+      enabled: false
+
+Each prompt requires a template; tags, enabled (true by default, unless --disabled or the
+create_disabled setting is set) and owner are optional.
+The whole file is validated before anything is written: unknown fields, missing templates,
+duplicate templates and invalid tags are all reported and nothing is imported. Prompts whose
+template already exists are skipped. Use --dry-run to only validate the file.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPromptImport,
+}
+
 func init() {
 	promptCmd.AddCommand(promptAddCmd)
 	promptCmd.AddCommand(promptListCmd)
@@ -79,29 +122,37 @@ func init() {
 	promptCmd.AddCommand(promptDeleteCmd)
 	promptCmd.AddCommand(promptEnableCmd)
 	promptCmd.AddCommand(promptDisableCmd)
+	promptCmd.AddCommand(promptTokensCmd)
+	promptCmd.AddCommand(promptImportCmd)
+
+	promptGetCmd.Flags().BoolVar(&promptGetStats, "stats", false, "Show response statistics and top keywords for the prompt")
+	promptGetCmd.Flags().IntVar(&promptGetResponses, "responses", 0, "Show the N latest responses of each LLM")
+	promptAddCmd.Flags().BoolVar(&createDisabledFlag, "disabled", false, "Add the prompts disabled, to enable them after review")
+	promptImportCmd.Flags().BoolVar(&createDisabledFlag, "disabled", false, "Import the prompts that do not set enabled disabled")
+	promptImportCmd.Flags().BoolVar(&promptImportDryRun, "dry-run", false, "Validate the pack without importing it")
 }
 
 func runPromptAdd(cmd *cobra.Command, args []string) error {
 	reader := bufio.NewReader(os.Stdin)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
-	fmt.Printf("%s➕ Add New Prompt Template%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s%s%s\n", FormatHeader(""), i18n.T("prompt_add.header"), Reset)
 	fmt.Printf("%s==========================%s\n", DimStyle, Reset)
 	fmt.Println()
-	fmt.Printf("%sThis prompt will be sent to LLMs to generate text for keyword tracking.%s\n", InfoStyle, Reset)
-	fmt.Printf("%sThe LLM responses will be analyzed to track brand mentions and keywords.%s\n", InfoStyle, Reset)
+	fmt.Printf("%s%s%s\n", InfoStyle, i18n.T("prompt_add.intro_sent"), Reset)
+	fmt.Printf("%s%s%s\n", InfoStyle, i18n.T("prompt_add.intro_analyzed"), Reset)
 	fmt.Println()
 
-	fmt.Printf("%sChoose how to create your prompt:%s\n", LabelStyle, Reset)
-	fmt.Printf("  %s1. Generate prompts using LLM%s\n", CountStyle, Reset)
-	fmt.Printf("  %s2. Add a custom prompt%s\n", CountStyle, Reset)
+	fmt.Printf("%s%s%s\n", LabelStyle, i18n.T("prompt_add.choose_method"), Reset)
+	fmt.Printf("  %s1. %s%s\n", CountStyle, i18n.T("prompt_add.method_generate"), Reset)
+	fmt.Printf("  %s2. %s%s\n", CountStyle, i18n.T("prompt_add.method_custom"), Reset)
 
-	method, err := promptWithRetry(reader, fmt.Sprintf("\n%sSelect method (1 or 2): %s", LabelStyle, Reset), func(input string) (string, error) {
+	method, err := promptWithRetry(reader, fmt.Sprintf("\n%s%s%s", LabelStyle, i18n.T("prompt_add.select_method"), Reset), func(input string) (string, error) {
 		switch input {
 		case "1", "2":
 			return input, nil
 		default:
-			return "", fmt.Errorf("invalid choice: %s (choose 1 or 2)", input)
+			return "", fmt.Errorf("%s", i18n.T("prompt_add.invalid_method", input))
 		}
 	})
 	if err != nil {
@@ -116,7 +167,7 @@ func runPromptAdd(cmd *cobra.Command, args []string) error {
 }
 
 func runPromptList(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
 
 	prompts, err := database.ListPrompts(ctx, nil)
 	if err != nil {
@@ -163,7 +214,7 @@ func runPromptList(cmd *cobra.Command, args []string) error {
 }
 
 func runPromptGet(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	id := args[0]
 
 	prompt, err := database.GetPrompt(ctx, id)
@@ -176,17 +227,185 @@ func runPromptGet(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%sID: %s\n", LabelStyle, FormatSecondary(prompt.ID))
 	fmt.Printf("%sEnabled: %s\n", LabelStyle, FormatValue(fmt.Sprintf("%v", prompt.Enabled)))
 	fmt.Printf("%sTags: %s\n", LabelStyle, FormatSecondary(strings.Join(prompt.Tags, ", ")))
+	if prompt.Owner != "" {
+		fmt.Printf("%sOwner: %s\n", LabelStyle, FormatValue(prompt.Owner))
+	}
 	fmt.Printf("%sCreated: %s\n", LabelStyle, FormatMeta(prompt.CreatedAt.Format(time.RFC3339)))
 	fmt.Printf("%sUpdated: %s\n", LabelStyle, FormatMeta(prompt.UpdatedAt.Format(time.RFC3339)))
 	fmt.Printf("\n%sTemplate:%s\n", SuccessStyle, Reset)
 	fmt.Printf("%s─────────%s\n", DimStyle, Reset)
 	fmt.Printf("%s\n", FormatValue(prompt.Template))
 
+	if promptGetStats {
+		if err := printPromptStats(cmd.Context(), prompt.ID); err != nil {
+			return err
+		}
+	}
+
+	if promptGetResponses > 0 {
+		return printPromptResponses(cmd.Context(), prompt.ID, promptGetResponses)
+	}
+
+	return nil
+}
+
+// printPromptResponses prints the latest responses of each LLM to a prompt
+func printPromptResponses(ctx context.Context, promptID string, perLLM int) error {
+	promptService := services.NewPromptManagementService(database)
+	groups, err := promptService.GetLatestResponsesByLLM(ctx, promptID, perLLM, nil)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%sLatest Responses:%s\n", SuccessStyle, Reset)
+	fmt.Printf("%s─────────────────%s\n", DimStyle, Reset)
+	if len(groups) == 0 {
+		fmt.Printf("%sNo responses yet.%s\n", WarningStyle, Reset)
+		return nil
+	}
+
+	for _, group := range groups {
+		fmt.Printf("\n%s🤖 %s%s %s\n", InfoStyle, FormatValue(group.LLMName), Reset, FormatSecondary(fmt.Sprintf("(%s/%s)", group.LLMProvider, group.LLMModel)))
+		for _, response := range group.Responses {
+			fmt.Printf("  %s%s%s %s\n", DimStyle, response.CreatedAt.Format("2006-01-02 15:04"), Reset, FormatMeta(fmt.Sprintf("temp %.1f · %s", response.Temperature, response.ID)))
+			fmt.Printf("    %s\n", FormatValue(response.Excerpt))
+			if len(response.Mentions) > 0 {
+				fmt.Printf("    %sKeywords: %s\n", LabelStyle, FormatSecondary(formatMentions(response.Mentions)))
+			}
+		}
+	}
+	return nil
+}
+
+// formatMentions renders keyword mention counts, most mentioned first
+func formatMentions(mentions map[string]int) string {
+	keywords := make([]string, 0, len(mentions))
+	for keyword := range mentions {
+		keywords = append(keywords, keyword)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if mentions[keywords[i]] != mentions[keywords[j]] {
+			return mentions[keywords[i]] > mentions[keywords[j]]
+		}
+		return keywords[i] < keywords[j]
+	})
+
+	parts := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		parts[i] = fmt.Sprintf("%s (%d)", keyword, mentions[keyword])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printPromptStats prints a prompt's response statistics and top keywords
+func printPromptStats(ctx context.Context, promptID string) error {
+	stats, err := database.GetPromptStats(ctx, promptID)
+	if err != nil {
+		return fmt.Errorf("failed to get prompt stats: %w", err)
+	}
+
+	fmt.Printf("\n%sStatistics:%s\n", SuccessStyle, Reset)
+	fmt.Printf("%s───────────%s\n", DimStyle, Reset)
+	fmt.Printf("%sTotal Responses: %s\n", LabelStyle, FormatCount(stats.TotalResponses))
+	if stats.TotalResponses == 0 {
+		return nil
+	}
+	fmt.Printf("%sUnique LLMs: %s\n", LabelStyle, FormatCount(stats.UniqueLLMs))
+	fmt.Printf("%sAvg Tokens: %s\n", LabelStyle, FormatValue(fmt.Sprintf("%.1f", stats.AvgTokens)))
+
+	keywords, err := statsService.GetPromptTopKeywords(ctx, promptID, promptStatsKeywordLimit)
+	if err != nil {
+		return err
+	}
+	if len(keywords) > 0 {
+		fmt.Printf("%sTop Keywords:%s\n", LabelStyle, Reset)
+		for i, keyword := range keywords {
+			fmt.Printf("  %s%d. %s%s %s\n", CountStyle, i+1, Reset, FormatValue(keyword.Keyword), FormatMeta(fmt.Sprintf("(%d mentions)", keyword.Count)))
+		}
+	}
+
+	return nil
+}
+
+func runPromptTokens(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	estimate, err := services.NewPromptManagementService(database).EstimatePromptTokens(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s🔢 Token Estimate%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s================%s\n", DimStyle, Reset)
+	fmt.Printf("%sPrompt: %s\n", LabelStyle, FormatSecondary(estimate.Prompt.ID))
+	template := estimate.Prompt.Template
+	if len(template) > 80 {
+		template = template[:77] + "..."
+	}
+	fmt.Printf("%sTemplate: %s\n", LabelStyle, FormatValue(template))
+
+	fmt.Printf("\n%sPer LLM:%s\n", SuccessStyle, Reset)
+	if len(estimate.LLMs) == 0 {
+		fmt.Printf("  %sNo LLMs configured.%s\n", WarningStyle, Reset)
+	}
+	for _, e := range estimate.LLMs {
+		fmt.Printf("  %s%s%s (%s/%s)\n", CountStyle, e.LLM.Name, Reset, e.LLM.Provider, e.LLM.Model)
+		fmt.Printf("    %sPrompt tokens: %s %s\n", LabelStyle, FormatCount(e.PromptTokens), FormatMeta("("+e.Method+")"))
+		fmt.Printf("    %sMax completion tokens: %s\n", LabelStyle, FormatCount(e.MaxCompletionTokens))
+	}
+
+	fmt.Printf("\n%sSchedules (next 30 days):%s\n", SuccessStyle, Reset)
+	if len(estimate.Schedules) == 0 {
+		fmt.Printf("  %sNo enabled schedule uses this prompt.%s\n", WarningStyle, Reset)
+		return nil
+	}
+	for _, e := range estimate.Schedules {
+		fmt.Printf("  %s%s%s (%s)\n", CountStyle, e.Schedule.Name, Reset, e.Schedule.CronExpr)
+		fmt.Printf("    %sRuns: %s, up to %s tokens\n", LabelStyle, FormatCount(e.RunsPerMonth), FormatCount(e.MonthlyTokens))
+	}
+	fmt.Printf("\n%sProjected monthly volume: up to %s tokens%s\n", InfoStyle, FormatCount(estimate.MonthlyTokens), Reset)
+
+	return nil
+}
+
+func runPromptImport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read pack: %w", err)
+	}
+	pack, err := services.ParsePromptPack(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+
+	promptService := services.NewPromptManagementService(database)
+	promptService.SetPreserveTagCase(cfg.Tags.PreserveCase)
+	promptService.SetCreateDisabled(!createEnabled())
+
+	if promptImportDryRun {
+		if err := promptService.ValidatePromptPack(pack); err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+		fmt.Printf("%s✅ %s is valid: %s prompt(s)%s\n", SuccessStyle, args[0], FormatCount(len(pack.Prompts)), Reset)
+		return nil
+	}
+
+	result, err := promptService.ImportPromptPack(ctx, pack, ownerFlag)
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+
+	for _, template := range result.Skipped {
+		fmt.Printf("%s⏭️  Skipped existing prompt: %s%s\n", WarningStyle, services.Excerpt(template, 70), Reset)
+	}
+	fmt.Printf("%s🎉 Imported %s prompt(s), skipped %s existing%s\n", SuccessStyle, FormatCount(len(result.Created)), FormatCount(len(result.Skipped)), Reset)
 	return nil
 }
 
 func runPromptDelete(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	reader := bufio.NewReader(os.Stdin)
 
 	fmt.Printf("%s🗑️  Delete Prompts%s\n", FormatHeader(""), Reset)
@@ -275,7 +494,7 @@ func runPromptDelete(cmd *cobra.Command, args []string) error {
 }
 
 func runPromptEnable(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	id := args[0]
 
 	prompt, err := database.GetPrompt(ctx, id)
@@ -293,7 +512,7 @@ func runPromptEnable(cmd *cobra.Command, args []string) error {
 }
 
 func runPromptDisable(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	id := args[0]
 
 	prompt, err := database.GetPrompt(ctx, id)
@@ -400,6 +619,9 @@ func runPromptGenerate(reader *bufio.Reader, ctx context.Context) error {
 		if count < 1 {
 			return "", fmt.Errorf("count must be at least 1")
 		}
+		if count > 100 {
+			return "", fmt.Errorf("count cannot exceed 100")
+		}
 		return input, nil
 	})
 	if err != nil {
@@ -424,54 +646,18 @@ func runPromptGenerate(reader *bufio.Reader, ctx context.Context) error {
 
 	fmt.Printf("\n%s🔍 Generating prompts...%s\n", InfoStyle, Reset)
 
-	prePrompt := llm.GenerateGEOPromptTemplate(userInput, existingPromptTemplates, languageCode, promptCount)
-
-	var provider llm.Provider
-	switch selectedLLM.Provider {
-	case "openai":
-		provider = openai.New(selectedLLM.APIKey, selectedLLM.BaseURL)
-	case "anthropic":
-		provider = anthropic.New(selectedLLM.APIKey, selectedLLM.BaseURL)
-	case "ollama":
-		provider = ollama.New(selectedLLM.BaseURL)
-	case "google":
-		provider = google.New(selectedLLM.APIKey, selectedLLM.BaseURL)
-	default:
-		return fmt.Errorf("unsupported LLM provider: %s", selectedLLM.Provider)
-	}
-
-	response, err := provider.Generate(ctx, prePrompt, llm.Config{
-		Model:     selectedLLM.Model,
-		MaxTokens: 500,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to generate prompts: %w", err)
-	}
-
-	if response.Error != "" {
-		return fmt.Errorf("LLM error: %s", response.Error)
-	}
-
-	promptLines := strings.Split(strings.TrimSpace(response.Text), "\n")
-	var generatedPrompts []string
-
-	for _, line := range promptLines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		if len(line) > 2 && (line[1] == '.' || line[1] == ')') && (line[0] >= '0' && line[0] <= '9') {
-			line = strings.TrimSpace(line[2:])
-		}
-
-		if line != "" {
-			generatedPrompts = append(generatedPrompts, line)
-		}
+	if err := initializeLLMProviders(ctx); err != nil {
+		return fmt.Errorf("failed to initialize LLM providers: %w", err)
 	}
 
-	if len(generatedPrompts) == 0 {
-		return fmt.Errorf("no valid prompts were generated")
+	generatedPrompts, err := services.NewPromptGenerationService(llmRegistry).GeneratePrompts(ctx, selectedLLM, &services.GenerationConfig{
+		LanguageCode:    languageCode,
+		UserInput:       userInput,
+		PromptCount:     promptCount,
+		ExistingPrompts: existingPromptTemplates,
+	})
+	if err != nil {
+		return err
 	}
 
 	fmt.Printf("\n%s✅ Generated %s prompts:%s\n", SuccessStyle, FormatCount(len(generatedPrompts)), Reset)
@@ -497,8 +683,9 @@ func runPromptGenerate(reader *bufio.Reader, ctx context.Context) error {
 		prompt := &models.Prompt{
 			ID:       uuid.New().String(),
 			Template: promptText,
-			Tags:     []string{"generated", "llm-created", fmt.Sprintf("lang-%s", languageCode)},
-			Enabled:  true,
+			Tags:     shared.NormalizeTags([]string{"generated", "llm-created", fmt.Sprintf("lang-%s", languageCode)}, cfg.Tags.PreserveCase),
+			Enabled:  createEnabled(),
+			Owner:    ownerFlag,
 		}
 
 		if err := database.CreatePrompt(ctx, prompt); err != nil {
@@ -515,17 +702,18 @@ func runPromptGenerate(reader *bufio.Reader, ctx context.Context) error {
 
 // runPromptCustom allows users to add a custom prompt
 func runPromptCustom(reader *bufio.Reader, ctx context.Context) error {
-	fmt.Printf("\n%s✏️  Add Custom Prompt%s\n", FormatHeader(""), Reset)
+	fmt.Printf("\n%s%s%s\n", FormatHeader(""), i18n.T("prompt_add.custom_header"), Reset)
 	fmt.Printf("%s=====================%s\n", DimStyle, Reset)
 
 	prompt := &models.Prompt{
 		ID:      uuid.New().String(),
-		Enabled: true,
+		Enabled: createEnabled(),
+		Owner:   ownerFlag,
 	}
 
-	fmt.Println("\nEnter prompt template (press Ctrl+D when done):")
-	fmt.Println("Example: What are the top streaming services for watching movies?")
-	fmt.Println("Note: This prompt will be used to generate text that will be analyzed for keyword mentions.")
+	fmt.Println("\n" + i18n.T("prompt_add.enter_template"))
+	fmt.Println(i18n.T("prompt_add.template_example"))
+	fmt.Println(i18n.T("prompt_add.template_note"))
 	fmt.Println()
 
 	var templateLines []string
@@ -536,26 +724,21 @@ func runPromptCustom(reader *bufio.Reader, ctx context.Context) error {
 	prompt.Template = strings.Join(templateLines, "\n")
 
 	if prompt.Template == "" {
-		return fmt.Errorf("prompt template cannot be empty")
+		return fmt.Errorf("%s", i18n.T("prompt_add.empty_template"))
 	}
 
-	tags, err := promptOptional(reader, "\nTags (comma-separated, optional): ", "")
+	tags, err := promptOptional(reader, "\n"+i18n.T("prompt_add.tags"), "")
 	if err != nil {
 		return err
 	}
-	if tags != "" {
-		prompt.Tags = strings.Split(tags, ",")
-		for i := range prompt.Tags {
-			prompt.Tags[i] = strings.TrimSpace(prompt.Tags[i])
-		}
-	}
+	prompt.Tags = shared.ParseTags(tags, cfg.Tags.PreserveCase)
 
 	if err := database.CreatePrompt(ctx, prompt); err != nil {
 		return fmt.Errorf("failed to create prompt: %w", err)
 	}
 
-	fmt.Println("\n✅ Prompt added successfully!")
-	fmt.Printf("ID: %s\n", prompt.ID)
+	fmt.Println("\n" + i18n.T("prompt_add.added"))
+	fmt.Println(i18n.T("prompt_add.added_id", prompt.ID))
 
 	return nil
 }
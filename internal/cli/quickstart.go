@@ -0,0 +1,436 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+var (
+	quickstartTopic    string
+	quickstartLanguage string
+	quickstartLLMs     string
+	quickstartCount    int
+	quickstartName     string
+	quickstartCron     string
+	quickstartRunNow   bool
+	quickstartYes      bool
+)
+
+var quickstartCmd = &cobra.Command{
+	Use:   "quickstart",
+	Short: "Create prompts and a daily schedule from a topic in one guided flow",
+	Long: `Guided setup that turns a topic or brand into tracked data:
+
+  1. Ask for a topic/brand and a language
+  2. Pick the configured LLMs to track (or add one)
+  3. Generate prompts with the first selected LLM
+  4. Create a daily schedule wired to the generated prompts
+  5. Optionally run the schedule immediately
+
+Nothing is saved until the final confirmation: an LLM added along the way is
+kept in memory and saved together with the prompts and the schedule. Every
+step can be answered with a flag for non-interactive use.
+
+Examples:
+  gego quickstart
+  gego quickstart --topic "project management tools" --language EN --llms all --count 10 --yes --run-now`,
+	Args: cobra.NoArgs,
+	RunE: runQuickstart,
+}
+
+func init() {
+	quickstartCmd.Flags().StringVar(&quickstartTopic, "topic", "", "Topic or brand to generate prompts about")
+	quickstartCmd.Flags().StringVar(&quickstartLanguage, "language", "", "Language code for generated prompts (e.g., EN, FR)")
+	quickstartCmd.Flags().StringVar(&quickstartLLMs, "llms", "", "Comma-separated LLM IDs or names to use, or 'all'")
+	quickstartCmd.Flags().IntVar(&quickstartCount, "count", 0, "Number of prompts to generate, at most 100 (default 10)")
+	quickstartCmd.Flags().StringVar(&quickstartName, "name", "", "Schedule name (default derived from the topic)")
+	quickstartCmd.Flags().StringVar(&quickstartCron, "cron", "", "Cron expression or phrase such as \"every day at 09:00\" for the schedule (default every day at 9am)")
+	quickstartCmd.Flags().BoolVar(&quickstartRunNow, "run-now", false, "Run the schedule immediately after creating it")
+	quickstartCmd.Flags().BoolVarP(&quickstartYes, "yes", "y", false, "Skip confirmations and save without asking")
+}
+
+func runQuickstart(cmd *cobra.Command, args []string) error {
+	reader := bufio.NewReader(os.Stdin)
+	ctx := cmd.Context()
+
+	if quickstartCount < 0 || quickstartCount > services.MaxGeneratedPromptCount {
+		return fmt.Errorf("invalid --count: %d (must be between 1 and %d, or 0 to be asked)", quickstartCount, services.MaxGeneratedPromptCount)
+	}
+
+	fmt.Printf("%s🚀 Gego Quickstart%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s==================%s\n", DimStyle, Reset)
+	fmt.Println()
+
+	topic := strings.TrimSpace(quickstartTopic)
+	if topic == "" {
+		var err error
+		topic, err = promptWithRetry(reader, fmt.Sprintf("%sWhat topic or brand do you want to track? %s", LabelStyle, Reset), func(input string) (string, error) {
+			if input == "" {
+				return "", fmt.Errorf("topic is required")
+			}
+			return input, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	languageCode := strings.ToUpper(strings.TrimSpace(quickstartLanguage))
+	if languageCode == "" {
+		var err error
+		languageCode, err = promptWithRetry(reader, fmt.Sprintf("%sLanguage code for prompts (e.g., EN, FR, IT) [EN]: %s", LabelStyle, Reset), func(input string) (string, error) {
+			input = strings.ToUpper(input)
+			if input == "" {
+				return "EN", nil
+			}
+			if err := services.ValidateLanguageCode(input); err != nil {
+				return "", err
+			}
+			return input, nil
+		})
+		if err != nil {
+			return err
+		}
+	} else if err := services.ValidateLanguageCode(languageCode); err != nil {
+		return fmt.Errorf("invalid --language: %w", err)
+	}
+
+	selectedLLMs, addedLLMs, err := quickstartSelectLLMs(ctx, reader)
+	if err != nil {
+		return err
+	}
+	if len(selectedLLMs) == 0 {
+		fmt.Printf("%sNo LLMs selected. Add one with %s and run quickstart again.%s\n", WarningStyle, FormatSecondary("gego llm add"), Reset)
+		return nil
+	}
+
+	promptCount := quickstartCount
+	if promptCount <= 0 {
+		countStr, err := promptWithRetry(reader, fmt.Sprintf("%sHow many prompts should be generated? [10]: %s", LabelStyle, Reset), func(input string) (string, error) {
+			if input == "" {
+				return "10", nil
+			}
+			count, err := strconv.Atoi(input)
+			if err != nil || count < 1 || count > services.MaxGeneratedPromptCount {
+				return "", fmt.Errorf("invalid number: %s (enter a number between 1 and %d)", input, services.MaxGeneratedPromptCount)
+			}
+			return input, nil
+		})
+		if err != nil {
+			return err
+		}
+		promptCount, _ = strconv.Atoi(countStr)
+	}
+
+	if err := initializeLLMProviders(ctx); err != nil {
+		return fmt.Errorf("failed to initialize LLM providers: %w", err)
+	}
+	// LLMs added during the quickstart are not stored yet, so their providers are registered here
+	for _, llmConfig := range addedLLMs {
+		provider, err := newProvider(llmConfig.Provider, llmConfig.APIKey, llmConfig.BaseURL)
+		if err != nil {
+			return fmt.Errorf("failed to initialize %s: %w", llmConfig.Name, err)
+		}
+		llmRegistry.Register(provider)
+	}
+
+	existingPrompts, err := database.ListPrompts(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch existing prompts: %w", err)
+	}
+
+	var existingTemplates []string
+	for _, prompt := range existingPrompts {
+		existingTemplates = append(existingTemplates, prompt.Template)
+	}
+
+	generator := selectedLLMs[0]
+	fmt.Printf("\n%s🔍 Generating %d prompts in %s with %s...%s\n", InfoStyle, promptCount, services.GetLanguageName(languageCode), generator.Name, Reset)
+
+	generationService := services.NewPromptGenerationService(llmRegistry)
+	generated, err := generationService.GeneratePrompts(ctx, generator, &services.GenerationConfig{
+		LanguageCode:    languageCode,
+		UserInput:       topic,
+		PromptCount:     promptCount,
+		ExistingPrompts: existingTemplates,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate prompts: %w", err)
+	}
+
+	var prompts []*models.Prompt
+	for _, template := range generated {
+		if err := services.ValidateGeneratedPrompt(template); err != nil {
+			continue
+		}
+		prompt := services.CreatePromptFromGenerated(template, languageCode)
+		prompt.ID = uuid.New().String()
+		prompt.Tags = shared.NormalizeTags(prompt.Tags, cfg.Tags.PreserveCase)
+		prompt.Owner = ownerFlag
+		prompts = append(prompts, prompt)
+	}
+
+	if len(prompts) == 0 {
+		return fmt.Errorf("no valid prompts were generated")
+	}
+
+	scheduleName := quickstartName
+	if scheduleName == "" {
+		scheduleName = fmt.Sprintf("Quickstart: %s", topic)
+	}
+
+	cronExpr := quickstartCron
+	if cronExpr == "" {
+		cronExpr = "0 9 * * *"
+	}
+
+	scheduleService := services.NewScheduleService(database)
+	cronExpr, err = services.ParseScheduleDescriptor(cronExpr)
+	if err != nil {
+		return fmt.Errorf("invalid --cron: %w", err)
+	}
+
+	schedule := &models.Schedule{
+		ID:          uuid.New().String(),
+		Name:        scheduleName,
+		CronExpr:    cronExpr,
+		Temperature: 0.7,
+		Enabled:     true,
+		Owner:       ownerFlag,
+	}
+	for _, prompt := range prompts {
+		schedule.PromptIDs = append(schedule.PromptIDs, prompt.ID)
+	}
+	for _, llmConfig := range selectedLLMs {
+		schedule.LLMIDs = append(schedule.LLMIDs, llmConfig.ID)
+	}
+
+	fmt.Printf("\n%s📋 Generated Prompts:%s\n", LabelStyle, Reset)
+	for i, prompt := range prompts {
+		fmt.Printf("  %s%d. %s%s\n", CountStyle, i+1, Reset, FormatValue(prompt.Template))
+	}
+
+	fmt.Printf("\n%s📅 Schedule:%s\n", LabelStyle, Reset)
+	fmt.Printf("  %sName: %s\n", LabelStyle, FormatValue(schedule.Name))
+	fmt.Printf("  %sCron: %s\n", LabelStyle, FormatSecondary(schedule.CronExpr))
+	fmt.Printf("  %sPrompts: %s\n", LabelStyle, FormatCount(len(schedule.PromptIDs)))
+	fmt.Printf("  %sLLMs: %s\n", LabelStyle, FormatCount(len(schedule.LLMIDs)))
+	for _, llmConfig := range selectedLLMs {
+		fmt.Printf("    %s• %s (%s - %s)%s\n", DimStyle, llmConfig.Name, llmConfig.Provider, llmConfig.Model, Reset)
+	}
+	fmt.Println()
+
+	if !quickstartYes {
+		confirmed, err := promptYesNo(reader, fmt.Sprintf("%sSave these prompts and create the schedule? (y/N): %s", LabelStyle, Reset))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Printf("%sCancelled. Nothing was saved.%s\n", WarningStyle, Reset)
+			return nil
+		}
+	}
+
+	fmt.Printf("\n%s💾 Saving...%s\n", InfoStyle, Reset)
+	created, err := saveQuickstartLLMs(ctx, reader, schedule, addedLLMs)
+	if err != nil {
+		return err
+	}
+	if err := scheduleService.CreateScheduleWithPrompts(ctx, schedule, prompts); err != nil {
+		discardQuickstartLLMs(ctx, created)
+		return err
+	}
+
+	runNow := quickstartRunNow
+	if !runNow && !quickstartYes {
+		runNow, err = promptYesNo(reader, fmt.Sprintf("%sRun the schedule now? (y/N): %s", LabelStyle, Reset))
+		if err != nil {
+			return err
+		}
+	}
+
+	if runNow {
+		fmt.Printf("\n%s⏳ Executing schedule %s...%s\n", InfoStyle, FormatValue(schedule.Name), Reset)
+		if err := sched.ExecuteNow(ctx, schedule.ID); err != nil {
+			fmt.Printf("%s⚠️  Schedule execution failed: %s%s\n", ErrorStyle, FormatValue(err.Error()), Reset)
+		} else {
+			fmt.Printf("%s✅ Schedule execution completed!%s\n", SuccessStyle, Reset)
+		}
+	}
+
+	fmt.Printf("\n%s🎉 Quickstart complete!%s\n", SuccessStyle, Reset)
+	fmt.Printf("%s===================%s\n", DimStyle, Reset)
+	fmt.Printf("%sSchedule ID: %s\n", LabelStyle, FormatSecondary(schedule.ID))
+	fmt.Printf("%sPrompt IDs:%s\n", LabelStyle, Reset)
+	for _, prompt := range prompts {
+		fmt.Printf("  %s\n", FormatSecondary(prompt.ID))
+	}
+	fmt.Printf("%sLLM IDs:%s\n", LabelStyle, Reset)
+	for _, id := range schedule.LLMIDs {
+		fmt.Printf("  %s\n", FormatSecondary(id))
+	}
+	fmt.Printf("\n%sStart the scheduler to run it automatically: %s%s\n", InfoStyle, FormatSecondary("gego scheduler start"), Reset)
+	fmt.Printf("%sView results with: %s%s\n", InfoStyle, FormatSecondary("gego stats keywords"), Reset)
+
+	return nil
+}
+
+// quickstartSelectLLMs resolves the LLMs to use from the --llms flag or an interactive selection.
+// It also returns the LLMs the user added along the way, which are not saved yet.
+func quickstartSelectLLMs(ctx context.Context, reader *bufio.Reader) (selected, added []*models.LLMConfig, err error) {
+	enabled := true
+	llms, err := database.ListLLMs(ctx, &enabled)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list LLMs: %w", err)
+	}
+
+	if len(llms) == 0 {
+		if quickstartYes || quickstartLLMs != "" {
+			return nil, nil, fmt.Errorf("no enabled LLMs configured. Add one first with 'gego llm add'")
+		}
+
+		fmt.Printf("\n%s❌ No enabled LLM providers configured.%s\n", ErrorStyle, Reset)
+		addNow, err := promptYesNo(reader, fmt.Sprintf("%sAdd one now? (y/N): %s", LabelStyle, Reset))
+		if err != nil {
+			return nil, nil, err
+		}
+		if !addNow {
+			return nil, nil, nil
+		}
+
+		fmt.Println()
+		added, err = promptNewLLMs(ctx, reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, l := range added {
+			if l.Enabled {
+				llms = append(llms, l)
+			}
+		}
+		if len(llms) == 0 {
+			return nil, added, nil
+		}
+	}
+
+	selection := strings.TrimSpace(quickstartLLMs)
+	if selection != "" {
+		selected, err := resolveLLMSelection(llms, selection)
+		return selected, added, err
+	}
+
+	fmt.Printf("\n%sAvailable LLMs:%s\n", LabelStyle, Reset)
+	for i, l := range llms {
+		fmt.Printf("  %s%d. %s (%s - %s)%s\n", CountStyle, i+1, FormatValue(l.Name), FormatSecondary(l.Provider), FormatSecondary(l.Model), Reset)
+	}
+
+	_, err = promptWithRetry(reader, fmt.Sprintf("\n%sSelect LLMs (comma-separated numbers or 'all') [all]: %s", LabelStyle, Reset), func(input string) (string, error) {
+		if input == "" || strings.ToLower(input) == "all" {
+			selected = llms
+			return input, nil
+		}
+
+		selected = nil
+		for _, sel := range strings.Split(input, ",") {
+			idx, err := strconv.Atoi(strings.TrimSpace(sel))
+			if err != nil || idx < 1 || idx > len(llms) {
+				return "", fmt.Errorf("invalid selection: %s (choose 1-%d)", sel, len(llms))
+			}
+			selected = append(selected, llms[idx-1])
+		}
+		return input, nil
+	})
+	if err != nil {
+		return nil, added, err
+	}
+
+	return selected, added, nil
+}
+
+// saveQuickstartLLMs saves the LLMs added during a quickstart and points schedule at the LLMs saved
+// or reused in their place. It returns the LLMs it created; when one fails to save, those are
+// deleted again and an error is returned.
+func saveQuickstartLLMs(ctx context.Context, reader *bufio.Reader, schedule *models.Schedule, added []*models.LLMConfig) ([]*models.LLMConfig, error) {
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	saved, err := saveNewLLMs(ctx, reader, added)
+	var created []*models.LLMConfig
+	replacements := make(map[string]string, len(added))
+	for i, l := range added {
+		if saved[i] == nil {
+			if err == nil {
+				err = fmt.Errorf("failed to save LLM %s", l.Name)
+			}
+			continue
+		}
+		if saved[i].ID == l.ID {
+			created = append(created, l)
+		}
+		replacements[l.ID] = saved[i].ID
+	}
+	if err != nil {
+		discardQuickstartLLMs(ctx, created)
+		return nil, err
+	}
+
+	for i, id := range schedule.LLMIDs {
+		if replacement, ok := replacements[id]; ok {
+			schedule.LLMIDs[i] = replacement
+		}
+	}
+	return created, nil
+}
+
+// discardQuickstartLLMs deletes the LLMs a quickstart created before it failed to save the rest
+func discardQuickstartLLMs(ctx context.Context, added []*models.LLMConfig) {
+	ctx = context.WithoutCancel(ctx)
+	for _, l := range added {
+		if err := database.DeleteLLM(ctx, l.ID); err != nil {
+			fmt.Printf("%s⚠️  Failed to remove LLM %s added during the quickstart: %s%s\n", WarningStyle, FormatValue(l.Name), FormatValue(err.Error()), Reset)
+			continue
+		}
+		fmt.Printf("%sRemoved LLM %s added during the quickstart%s\n", DimStyle, l.Name, Reset)
+	}
+}
+
+// resolveLLMSelection matches a comma-separated list of LLM IDs or names against the configured LLMs
+func resolveLLMSelection(llms []*models.LLMConfig, selection string) ([]*models.LLMConfig, error) {
+	if strings.ToLower(selection) == "all" {
+		return llms, nil
+	}
+
+	var selected []*models.LLMConfig
+	for _, ref := range strings.Split(selection, ",") {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+
+		var match *models.LLMConfig
+		for _, l := range llms {
+			if l.ID == ref || strings.EqualFold(l.Name, ref) {
+				match = l
+				break
+			}
+		}
+		if match == nil {
+			return nil, fmt.Errorf("LLM not found or disabled: %s", ref)
+		}
+		selected = append(selected, match)
+	}
+
+	return selected, nil
+}
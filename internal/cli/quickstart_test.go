@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// llmStoreDB stores LLMs in memory and fails to create those named failName
+type llmStoreDB struct {
+	db.Database
+	llms     []*models.LLMConfig
+	failName string
+}
+
+func (l *llmStoreDB) ListLLMs(ctx context.Context, enabled *bool) ([]*models.LLMConfig, error) {
+	return l.llms, nil
+}
+
+func (l *llmStoreDB) GetLLM(ctx context.Context, id string) (*models.LLMConfig, error) {
+	for _, llmConfig := range l.llms {
+		if llmConfig.ID == id {
+			return llmConfig, nil
+		}
+	}
+	return nil, fmt.Errorf("LLM not found: %s", id)
+}
+
+func (l *llmStoreDB) CreateLLM(ctx context.Context, llmConfig *models.LLMConfig) error {
+	if llmConfig.Name == l.failName {
+		return errors.New("disk full")
+	}
+	l.llms = append(l.llms, llmConfig)
+	return nil
+}
+
+func (l *llmStoreDB) DeleteLLM(ctx context.Context, id string) error {
+	l.llms = slices.DeleteFunc(l.llms, func(llmConfig *models.LLMConfig) bool { return llmConfig.ID == id })
+	return nil
+}
+
+func TestSaveQuickstartLLMs(t *testing.T) {
+	existing := &models.LLMConfig{ID: "existing", Name: "GPT-4o", Provider: "openai", Model: "gpt-4o", APIKey: "sk-existing-key-0001"}
+
+	tests := []struct {
+		name        string
+		failName    string
+		input       string
+		wantErr     bool
+		wantStored  []string
+		wantLLMIDs  []string
+		wantCreated int
+	}{
+		{
+			name:        "saves the added LLMs",
+			wantStored:  []string{"existing", "new-1", "new-2"},
+			wantLLMIDs:  []string{"new-1", "new-2"},
+			wantCreated: 2,
+		},
+		{
+			name:        "reuses an existing duplicate",
+			input:       "n\n",
+			wantStored:  []string{"existing", "new-2"},
+			wantLLMIDs:  []string{"existing", "new-2"},
+			wantCreated: 1,
+		},
+		{
+			name:       "failure removes the LLMs saved before it",
+			failName:   "GPT-4o mini",
+			wantErr:    true,
+			wantStored: []string{"existing"},
+			wantLLMIDs: []string{"new-1", "new-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &llmStoreDB{llms: []*models.LLMConfig{existing}, failName: tt.failName}
+			database = store
+			t.Cleanup(func() { database = nil })
+
+			first := &models.LLMConfig{ID: "new-1", Name: "GPT-4o", Provider: "openai", Model: "gpt-4o", APIKey: "sk-other-key-0002"}
+			if tt.input != "" {
+				first.APIKey = existing.APIKey
+			}
+			added := []*models.LLMConfig{
+				first,
+				{ID: "new-2", Name: "GPT-4o mini", Provider: "openai", Model: "gpt-4o-mini", APIKey: "sk-other-key-0002"},
+			}
+			schedule := &models.Schedule{LLMIDs: []string{"new-1", "new-2"}}
+
+			created, err := saveQuickstartLLMs(context.Background(), bufio.NewReader(strings.NewReader(tt.input)), schedule, added)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("saveQuickstartLLMs error = %v, want error %t", err, tt.wantErr)
+			}
+			if len(created) != tt.wantCreated {
+				t.Errorf("created %d LLMs, want %d", len(created), tt.wantCreated)
+			}
+
+			var stored []string
+			for _, llmConfig := range store.llms {
+				stored = append(stored, llmConfig.ID)
+			}
+			if !slices.Equal(stored, tt.wantStored) {
+				t.Errorf("stored LLMs = %v, want %v", stored, tt.wantStored)
+			}
+			if !slices.Equal(schedule.LLMIDs, tt.wantLLMIDs) {
+				t.Errorf("schedule LLMs = %v, want %v", schedule.LLMIDs, tt.wantLLMIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
+)
+
+var (
+	recipeName     string
+	recipeLLM      string
+	recipeLanguage string
+	recipeInput    string
+	recipeCount    int
+	recipeTags     string
+)
+
+var promptRecipeCmd = &cobra.Command{
+	Use:   "recipe",
+	Short: "Manage saved prompt generation recipes",
+	Long: `Save prompt generation parameters (LLM, language, description, count and tags)
+as a recipe and re-run them later. Each run excludes prompts that already exist.`,
+}
+
+var promptRecipeAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Save a new generation recipe",
+	Long: `Save a new generation recipe. Missing values are asked interactively.
+
+Example:
+  gego prompt recipe add --name "PM tools FR" --llm <llm-id> --language FR --input "project management tools" --count 20 --tags pm,monthly`,
+	Args: cobra.NoArgs,
+	RunE: runPromptRecipeAdd,
+}
+
+var promptRecipeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List generation recipes",
+	Args:  cobra.NoArgs,
+	RunE:  runPromptRecipeList,
+}
+
+var promptRecipeRunCmd = &cobra.Command{
+	Use:   "run [id]",
+	Short: "Generate and save prompts from a recipe",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPromptRecipeRun,
+}
+
+var promptRecipeDeleteCmd = &cobra.Command{
+	Use:   "delete [id]",
+	Short: "Delete a generation recipe",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPromptRecipeDelete,
+}
+
+func init() {
+	promptCmd.AddCommand(promptRecipeCmd)
+	promptRecipeCmd.AddCommand(promptRecipeAddCmd)
+	promptRecipeCmd.AddCommand(promptRecipeListCmd)
+	promptRecipeCmd.AddCommand(promptRecipeRunCmd)
+	promptRecipeCmd.AddCommand(promptRecipeDeleteCmd)
+
+	promptRecipeAddCmd.Flags().StringVar(&recipeName, "name", "", "Recipe name")
+	promptRecipeAddCmd.Flags().StringVar(&recipeLLM, "llm", "", "ID or name of the LLM used for generation")
+	promptRecipeAddCmd.Flags().StringVar(&recipeLanguage, "language", "", "Language code (e.g., EN, FR)")
+	promptRecipeAddCmd.Flags().StringVar(&recipeInput, "input", "", "Description of the prompts to generate")
+	promptRecipeAddCmd.Flags().IntVar(&recipeCount, "count", 0, "Number of prompts to generate per run (default 20)")
+	promptRecipeAddCmd.Flags().StringVar(&recipeTags, "tags", "", "Comma-separated tags applied to generated prompts")
+}
+
+func runPromptRecipeAdd(cmd *cobra.Command, args []string) error {
+	reader := bufio.NewReader(os.Stdin)
+	ctx := cmd.Context()
+
+	fmt.Printf("%s➕ Add Generation Recipe%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s========================%s\n", DimStyle, Reset)
+	fmt.Println()
+
+	llms, err := database.ListLLMs(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list LLMs: %w", err)
+	}
+	if len(llms) == 0 {
+		return fmt.Errorf("no LLMs available. Create LLMs first with 'gego llm add'")
+	}
+
+	recipe := &models.GenerationRecipe{
+		Name:      strings.TrimSpace(recipeName),
+		Language:  strings.ToUpper(strings.TrimSpace(recipeLanguage)),
+		UserInput: strings.TrimSpace(recipeInput),
+		Count:     recipeCount,
+	}
+
+	if recipe.Name == "" {
+		recipe.Name, err = promptWithRetry(reader, fmt.Sprintf("%sName: %s", LabelStyle, Reset), func(input string) (string, error) {
+			if input == "" {
+				return "", fmt.Errorf("name is required")
+			}
+			return input, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if recipeLLM != "" {
+		selected, err := resolveLLMSelection(llms, recipeLLM)
+		if err != nil {
+			return err
+		}
+		if len(selected) != 1 {
+			return fmt.Errorf("--llm must reference exactly one LLM")
+		}
+		recipe.LLMID = selected[0].ID
+	} else {
+		fmt.Printf("\n%sAvailable LLMs:%s\n", LabelStyle, Reset)
+		for i, l := range llms {
+			fmt.Printf("  %s%d. %s (%s - %s)%s\n", CountStyle, i+1, FormatValue(l.Name), FormatSecondary(l.Provider), FormatSecondary(l.Model), Reset)
+		}
+		choice, err := promptWithRetry(reader, fmt.Sprintf("\n%sSelect the LLM used for generation (1-%d): %s", LabelStyle, len(llms), Reset), func(input string) (string, error) {
+			idx, err := strconv.Atoi(input)
+			if err != nil || idx < 1 || idx > len(llms) {
+				return "", fmt.Errorf("invalid choice: %s (choose 1-%d)", input, len(llms))
+			}
+			return input, nil
+		})
+		if err != nil {
+			return err
+		}
+		idx, _ := strconv.Atoi(choice)
+		recipe.LLMID = llms[idx-1].ID
+	}
+
+	if recipe.Language == "" {
+		recipe.Language, err = promptWithRetry(reader, fmt.Sprintf("%sLanguage code (e.g., FR, EN, IT) [EN]: %s", LabelStyle, Reset), func(input string) (string, error) {
+			input = strings.ToUpper(input)
+			if input == "" {
+				return "EN", nil
+			}
+			if err := services.ValidateLanguageCode(input); err != nil {
+				return "", err
+			}
+			return input, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if recipe.UserInput == "" {
+		recipe.UserInput, err = promptWithRetry(reader, fmt.Sprintf("%sDescribe the prompts to generate (e.g., 'questions about project management tools'): %s", LabelStyle, Reset), func(input string) (string, error) {
+			if input == "" {
+				return "", fmt.Errorf("description is required")
+			}
+			return input, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if recipe.Count <= 0 {
+		countStr, err := promptWithRetry(reader, fmt.Sprintf("%sPrompts per run [20]: %s", LabelStyle, Reset), func(input string) (string, error) {
+			if input == "" {
+				return "20", nil
+			}
+			count, err := strconv.Atoi(input)
+			if err != nil || count < 1 || count > 100 {
+				return "", fmt.Errorf("invalid number: %s (enter a number between 1 and 100)", input)
+			}
+			return input, nil
+		})
+		if err != nil {
+			return err
+		}
+		recipe.Count, _ = strconv.Atoi(countStr)
+	}
+
+	tags := recipeTags
+	if tags == "" && !cmd.Flags().Changed("tags") {
+		tags, err = promptOptional(reader, fmt.Sprintf("%sTags for generated prompts (comma-separated, optional): %s", LabelStyle, Reset), "")
+		if err != nil {
+			return err
+		}
+	}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			recipe.Tags = append(recipe.Tags, tag)
+		}
+	}
+
+	recipeService := services.NewRecipeService(database)
+	if err := recipeService.CreateRecipe(ctx, recipe); err != nil {
+		return fmt.Errorf("failed to create recipe: %w", err)
+	}
+
+	fmt.Printf("\n%s✅ Recipe saved!%s\n", SuccessStyle, Reset)
+	fmt.Printf("%sID: %s\n", LabelStyle, FormatSecondary(recipe.ID))
+	fmt.Printf("%sRun it with: %s%s\n", InfoStyle, FormatSecondary("gego prompt recipe run "+recipe.ID), Reset)
+
+	return nil
+}
+
+func runPromptRecipeList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	recipes, err := services.NewRecipeService(database).ListRecipes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list recipes: %w", err)
+	}
+
+	if len(recipes) == 0 {
+		fmt.Printf("%sNo recipes saved. Use '%s' to add one.%s\n", WarningStyle, FormatSecondary("gego prompt recipe add"), Reset)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%sID\tNAME\tLANG\tCOUNT\tTAGS\tLAST RUN%s\n", LabelStyle, Reset)
+	fmt.Fprintf(w, "%s──\t────\t────\t─────\t────\t────────%s\n", DimStyle, Reset)
+
+	for _, recipe := range recipes {
+		lastRun := "Never"
+		if recipe.LastRun != nil {
+			lastRun = recipe.LastRun.Format("2006-01-02 15:04")
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			FormatSecondary(recipe.ID),
+			FormatValue(recipe.Name),
+			FormatValue(recipe.Language),
+			FormatCount(recipe.Count),
+			FormatSecondary(strings.Join(recipe.Tags, ",")),
+			FormatMeta(lastRun),
+		)
+	}
+
+	w.Flush()
+	fmt.Printf("\n%sTotal: %s recipes%s\n", InfoStyle, FormatCount(len(recipes)), Reset)
+
+	return nil
+}
+
+func runPromptRecipeRun(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	id := args[0]
+
+	if err := initializeLLMProviders(ctx); err != nil {
+		return fmt.Errorf("failed to initialize LLM providers: %w", err)
+	}
+
+	recipeService := services.NewRecipeService(database)
+	recipe, err := recipeService.GetRecipe(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get recipe: %w", err)
+	}
+
+	fmt.Printf("%s🔍 Running recipe %s: %d %s prompts about %s...%s\n", InfoStyle, FormatValue(recipe.Name), recipe.Count, services.GetLanguageName(recipe.Language), FormatValue(recipe.UserInput), Reset)
+
+	startTime := time.Now()
+	result, err := recipeService.RunRecipe(ctx, id, services.NewPromptGenerationService(llmRegistry))
+	if err != nil {
+		return fmt.Errorf("failed to run recipe: %w", err)
+	}
+
+	fmt.Printf("\n%s✅ Generated %s prompts in %v:%s\n", SuccessStyle, FormatCount(result.Generated), time.Since(startTime).Round(time.Millisecond), Reset)
+	for i, prompt := range result.Saved {
+		fmt.Printf("  %s%d. %s%s\n", CountStyle, i+1, Reset, FormatValue(prompt.Template))
+	}
+
+	fmt.Printf("\n%sSaved: %s%s\n", LabelStyle, FormatCount(len(result.Saved)), Reset)
+	if result.Failed > 0 {
+		fmt.Printf("%sFailed: %s%s\n", WarningStyle, FormatCount(result.Failed), Reset)
+	}
+
+	return nil
+}
+
+func runPromptRecipeDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	id := args[0]
+
+	if err := services.NewRecipeService(database).DeleteRecipe(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete recipe: %w", err)
+	}
+
+	fmt.Printf("%s✅ Recipe deleted!%s\n", SuccessStyle, Reset)
+	return nil
+}
@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/config"
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+var (
+	reportTo       []string
+	reportName     string
+	reportDays     int
+	reportKeywords []string
+	reportDryRun   bool
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Email keyword reports",
+	Long: `Email keyword reports through the SMTP server configured under smtp in config.yaml.
+Reports listed under reports are also sent automatically by the scheduler on their cadence.`,
+}
+
+var reportSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Build a report and email it now",
+	Long: `Build a keyword report and email it now. With --report the recipients, keywords and
+period of a configured report are used; flags override them.
+
+Examples:
+  gego report send --to alice@example.com
+  gego report send --report weekly-brands
+  gego report send --to alice@example.com --keyword Netflix,Hulu --days 30
+  gego report send --report weekly-brands --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runReportSend,
+}
+
+func init() {
+	reportCmd.AddCommand(reportSendCmd)
+
+	reportSendCmd.Flags().StringSliceVar(&reportTo, "to", nil, "Recipient email addresses (default: the configured report's recipients)")
+	reportSendCmd.Flags().StringVar(&reportName, "report", "", "Name of a report configured in config.yaml")
+	reportSendCmd.Flags().IntVar(&reportDays, "days", 0, "Number of days covered (default: the report's period, or 7)")
+	reportSendCmd.Flags().StringSliceVar(&reportKeywords, "keyword", nil, "Keywords to detail (default: the report's keywords)")
+	reportSendCmd.Flags().BoolVar(&reportDryRun, "dry-run", false, "Print the report instead of sending it")
+}
+
+func runReportSend(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+
+	job := services.ReportJob{Name: "Keywords", Window: 7 * 24 * time.Hour}
+	if reportName != "" {
+		jobs, err := reportJobs(cfg)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, configured := range jobs {
+			if configured.Name == reportName {
+				job, found = configured, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no report named %q in config.yaml", reportName)
+		}
+	}
+
+	if len(reportTo) > 0 {
+		job.Recipients = reportTo
+	}
+	if len(reportKeywords) > 0 {
+		job.Keywords = reportKeywords
+	}
+	if reportDays < 0 {
+		return fmt.Errorf("--days must not be negative")
+	}
+	if reportDays > 0 {
+		job.Window = time.Duration(reportDays) * 24 * time.Hour
+	}
+
+	end := time.Now()
+	report, err := services.NewReportService(database).BuildReport(ctx, job.Name, job.Keywords, end.Add(-job.Window), end)
+	if err != nil {
+		return fmt.Errorf("failed to build report: %w", err)
+	}
+
+	if reportDryRun {
+		fmt.Printf("%sSubject: %s\n\n", LabelStyle, FormatValue(report.Subject()))
+		fmt.Print(report.Markdown())
+		return nil
+	}
+
+	if len(job.Recipients) == 0 {
+		return fmt.Errorf("no recipients: use --to or --report")
+	}
+	settings, err := reportSMTPSettings(cfg)
+	if err != nil {
+		return err
+	}
+	if err := services.NewReportMailer(settings).Send(job.Recipients, report.Subject(), report.Markdown()); err != nil {
+		return fmt.Errorf("failed to send report: %w", err)
+	}
+
+	fmt.Printf("%s✅ Sent %s to %s%s\n", SuccessStyle, FormatValue(report.Subject()), FormatSecondary(strings.Join(job.Recipients, ", ")), Reset)
+	return nil
+}
+
+// reportSMTPSettings returns the SMTP settings of cfg with the password resolved
+func reportSMTPSettings(cfg *config.Config) (services.SMTPSettings, error) {
+	if err := cfg.SMTP.Validate(); err != nil {
+		return services.SMTPSettings{}, err
+	}
+	password, err := cfg.SMTP.GetPassword()
+	if err != nil {
+		return services.SMTPSettings{}, fmt.Errorf("invalid smtp.password: %w", err)
+	}
+
+	return services.SMTPSettings{
+		Host:            cfg.SMTP.Host,
+		Port:            cfg.SMTP.GetPort(),
+		Username:        cfg.SMTP.Username,
+		Password:        password,
+		From:            cfg.SMTP.From,
+		DisableStartTLS: cfg.SMTP.DisableStartTLS,
+	}, nil
+}
+
+// reportJobs returns the reports configured in cfg with their cadence translated to cron
+func reportJobs(cfg *config.Config) ([]services.ReportJob, error) {
+	jobs := make([]services.ReportJob, 0, len(cfg.Reports))
+	for i, report := range cfg.Reports {
+		if report.Name == "" {
+			return nil, fmt.Errorf("reports[%d]: name is required", i)
+		}
+		if len(report.Recipients) == 0 {
+			return nil, fmt.Errorf("report %s: at least one recipient is required", report.Name)
+		}
+		if report.Days < 0 {
+			return nil, fmt.Errorf("report %s: days must not be negative", report.Name)
+		}
+
+		cronExpr, err := services.ParseScheduleDescriptor(report.Cadence)
+		if err != nil {
+			return nil, fmt.Errorf("report %s: invalid cadence: %w", report.Name, err)
+		}
+
+		window := time.Duration(report.Days) * 24 * time.Hour
+		if window == 0 {
+			if window, err = services.ReportWindow(cronExpr, time.Now().UTC()); err != nil {
+				return nil, fmt.Errorf("report %s: %w", report.Name, err)
+			}
+		}
+
+		jobs = append(jobs, services.ReportJob{
+			Name:       report.Name,
+			CronExpr:   cronExpr,
+			Window:     window,
+			Recipients: report.Recipients,
+			Keywords:   report.Keywords,
+		})
+	}
+	return jobs, nil
+}
+
+// setSchedulerReports makes scheduler email the reports configured in cfg
+func setSchedulerReports(scheduler *services.SchedulerService, cfg *config.Config) error {
+	jobs, err := reportJobs(cfg)
+	if err != nil {
+		return err
+	}
+	settings, err := reportSMTPSettings(cfg)
+	if err != nil {
+		return err
+	}
+	scheduler.SetReports(jobs, services.NewReportMailer(settings))
+	return nil
+}
@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+var (
+	annotateLabel     string
+	annotateNote      string
+	annotateAuthor    string
+	responseStatsDays int
+)
+
+var responseCmd = &cobra.Command{
+	Use:   "response",
+	Short: "Review stored LLM responses",
+	Long:  `Annotate responses with labels such as hallucination, great_placement or irrelevant to build a labeled dataset.`,
+}
+
+var responseAnnotateCmd = &cobra.Command{
+	Use:   "annotate [id]",
+	Short: "Add a label to a response",
+	Long: fmt.Sprintf(`Add a label and optional note to a response.
+
+Common labels are %s, %s and %s; any other single lowercase word is accepted.
+Keyword stats can skip labeled responses with --exclude-label.`, models.LabelHallucination, models.LabelGreatPlacement, models.LabelIrrelevant),
+	Args: cobra.ExactArgs(1),
+	RunE: runResponseAnnotate,
+}
+
+var responseAnnotationsCmd = &cobra.Command{
+	Use:   "annotations [id]",
+	Short: "List the annotations of a response",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runResponseAnnotations,
+}
+
+var responseDuplicatesCmd = &cobra.Command{
+	Use:   "duplicates [prompt-id]",
+	Short: "Find identical answers to a prompt from different LLMs",
+	Long: `Group the responses to a prompt by content and list the answers returned word for word
+by more than one LLM. Different models rarely agree verbatim, so these usually point to a proxy
+or base URL serving the wrong model, and they skew keyword stats.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runResponseDuplicates,
+}
+
+var responseStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show error rates per provider and model",
+	Long: fmt.Sprintf(`Count successful and failed provider calls per provider and model over a time window.
+Providers and models failing %.0f%% of their calls or more are highlighted. Responses reused
+from the response cache made no provider call and are not counted.`, services.HighErrorRate*100),
+	RunE: runResponseStats,
+}
+
+func init() {
+	responseCmd.AddCommand(responseAnnotateCmd)
+	responseCmd.AddCommand(responseAnnotationsCmd)
+	responseCmd.AddCommand(responseDuplicatesCmd)
+	responseCmd.AddCommand(responseStatsCmd)
+
+	responseStatsCmd.Flags().IntVar(&responseStatsDays, "days", 7, "Number of days to analyze")
+
+	responseAnnotateCmd.Flags().StringVar(&annotateLabel, "label", "", "Label to add (required)")
+	responseAnnotateCmd.Flags().StringVar(&annotateNote, "note", "", "Free-form note")
+	responseAnnotateCmd.Flags().StringVar(&annotateAuthor, "author", "", "Reviewer name (default: $USER)")
+	responseAnnotateCmd.MarkFlagRequired("label")
+}
+
+func runResponseAnnotate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	author := annotateAuthor
+	if author == "" {
+		author = os.Getenv("USER")
+	}
+
+	annotation, err := services.NewResponseService(database).AnnotateResponse(ctx, args[0], annotateLabel, annotateNote, author)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s✅ Labeled response %s as %s%s\n", SuccessStyle, FormatSecondary(args[0]), FormatValue(annotation.Label), Reset)
+	return nil
+}
+
+func runResponseAnnotations(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	annotations, err := services.NewResponseService(database).ListAnnotations(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(annotations) == 0 {
+		fmt.Printf("%sNo annotations on this response.%s\n", WarningStyle, Reset)
+		return nil
+	}
+
+	fmt.Printf("%s🏷️  Annotations%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s==============%s\n", DimStyle, Reset)
+	for _, annotation := range annotations {
+		fmt.Printf("%s%s%s", CountStyle, annotation.Label, Reset)
+		if annotation.Author != "" {
+			fmt.Printf(" %sby %s%s", DimStyle, annotation.Author, Reset)
+		}
+		fmt.Printf(" %s\n", FormatMeta(annotation.CreatedAt.Format(time.RFC3339)))
+		if annotation.Note != "" {
+			fmt.Printf("  %s\n", FormatValue(annotation.Note))
+		}
+	}
+	return nil
+}
+
+func runResponseDuplicates(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+
+	groups, err := services.NewResponseService(database).FindCrossLLMDuplicates(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(groups) == 0 {
+		fmt.Printf("%s✅ No identical answers from different LLMs.%s\n", SuccessStyle, Reset)
+		return nil
+	}
+
+	fmt.Printf("%s⚠️  Cross-LLM Duplicate Answers%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s==============================%s\n", DimStyle, Reset)
+	for i, group := range groups {
+		fmt.Printf("\n%s%d. %s%s %s\n", CountStyle, i+1, Reset,
+			FormatValue(strings.Join(group.LLMNames, ", ")),
+			FormatMeta(fmt.Sprintf("(%d LLMs, %d responses)", len(group.LLMIDs), len(group.ResponseIDs))))
+		fmt.Printf("   %s\n", FormatSecondary(group.Excerpt))
+		fmt.Printf("   %sResponses: %s\n", LabelStyle, FormatMeta(strings.Join(group.ResponseIDs, ", ")))
+	}
+	return nil
+}
+
+func runResponseStats(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+
+	if responseStatsDays < 1 {
+		return fmt.Errorf("--days must be a positive integer")
+	}
+	end := time.Now()
+	start := end.AddDate(0, 0, -responseStatsDays)
+
+	providers, err := statsService.GetErrorRates(ctx, &start, &end)
+	if err != nil {
+		return fmt.Errorf("failed to get error rates: %w", err)
+	}
+
+	fmt.Printf("%s📉 Error Rates%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s==============%s\n", DimStyle, Reset)
+	fmt.Printf("%sPeriod: %s\n", LabelStyle, FormatMeta(formatPeriod(start, end)))
+	fmt.Println()
+
+	if len(providers) == 0 {
+		fmt.Printf("%sNo responses in this period.%s\n", WarningStyle, Reset)
+		return nil
+	}
+
+	printErrorRates(os.Stdout, providers)
+	return nil
+}
+
+// printErrorRates writes a table of provider and model error rates, flagging high ones
+func printErrorRates(out io.Writer, providers []*services.ProviderErrorRates) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%sProvider / Model\tTotal\tSuccess\tErrors\tError Rate%s\n", LabelStyle, Reset)
+	fmt.Fprintf(w, "%s────────────────\t─────\t───────\t──────\t──────────%s\n", DimStyle, Reset)
+
+	for _, provider := range providers {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n", FormatValue(provider.Provider),
+			provider.Total, provider.Success, provider.Errors, formatErrorRate(provider.ErrorRateCounts))
+		for _, model := range provider.Models {
+			fmt.Fprintf(w, "  %s\t%d\t%d\t%d\t%s\n", FormatSecondary(model.Model),
+				model.Total, model.Success, model.Errors, formatErrorRate(model.ErrorRateCounts))
+		}
+	}
+	w.Flush()
+
+	for _, provider := range providers {
+		if provider.QuotaErrors > 0 {
+			fmt.Fprintf(out, "\n%s💳 %s: %d call(s) failed on an exhausted quota or credits. Check the plan and billing of this account; retrying will not help.%s\n",
+				ErrorStyle, provider.Provider, provider.QuotaErrors, Reset)
+		}
+	}
+}
+
+// formatErrorRate formats an error rate as a percentage, highlighted when high
+func formatErrorRate(counts services.ErrorRateCounts) string {
+	rate := fmt.Sprintf("%.1f%%", counts.ErrorRate*100)
+	if counts.High() {
+		return fmt.Sprintf("%s⚠️  %s%s", ErrorStyle, rate, Reset)
+	}
+	return rate
+}
@@ -2,21 +2,28 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
 	"github.com/AI2HU/gego/internal/config"
 	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/i18n"
 	"github.com/AI2HU/gego/internal/llm"
 	"github.com/AI2HU/gego/internal/llm/anthropic"
+	"github.com/AI2HU/gego/internal/llm/bedrock"
+	"github.com/AI2HU/gego/internal/llm/deepseek"
 	"github.com/AI2HU/gego/internal/llm/google"
 	"github.com/AI2HU/gego/internal/llm/ollama"
 	"github.com/AI2HU/gego/internal/llm/openai"
 	"github.com/AI2HU/gego/internal/llm/perplexity"
+	"github.com/AI2HU/gego/internal/llm/xai"
 	"github.com/AI2HU/gego/internal/logger"
 	"github.com/AI2HU/gego/internal/models"
 	"github.com/AI2HU/gego/internal/services"
@@ -27,6 +34,9 @@ var (
 	cfgFile      string
 	logLevel     string
 	logFile      string
+	ownerFlag    string
+	langFlag     string
+	captureRaw   bool
 	cfg          *config.Config
 	database     db.Database
 	llmRegistry  *llm.Registry
@@ -48,7 +58,11 @@ and compare performance across different LLM providers.`,
 			return fmt.Errorf("failed to initialize logging: %w", err)
 		}
 
-		if cmd.Name() == "init" || cmd.Name() == "api" {
+		if err := i18n.SetLanguage(i18n.Detect(langFlag)); err != nil {
+			return err
+		}
+
+		if cmd == initCmd || cmd == apiCmd || cmd == versionCmd {
 			return nil
 		}
 
@@ -74,6 +88,10 @@ and compare performance across different LLM providers.`,
 			}
 			shared.SetExclusionFilePath(exclusionPath)
 		}
+		shared.SetKeywordOptions(cfg.KeywordOptions)
+		if captureRaw {
+			cfg.Storage.CaptureRaw = true
+		}
 
 		sqlConfig := &models.Config{
 			Provider: cfg.SQLDatabase.Provider,
@@ -94,40 +112,87 @@ and compare performance across different LLM providers.`,
 			return fmt.Errorf("failed to create hybrid database: %w", err)
 		}
 
-		if err := database.Connect(context.Background()); err != nil {
+		if err := database.Connect(cmd.Context()); err != nil {
 			return fmt.Errorf("failed to connect to database: %w", err)
 		}
 
-		statsService = services.NewStatsService(database)
+		if !isMigrateCommand(cmd) {
+			if err := checkSchemaVersion(cmd.Context(), database); err != nil {
+				return err
+			}
+		}
 
-		llmRegistry = llm.NewRegistry()
-		llmRegistry.Register(openai.New("", ""))
-		llmRegistry.Register(anthropic.New("", ""))
-		llmRegistry.Register(ollama.New(""))
-		llmRegistry.Register(google.New("", ""))
-		llmRegistry.Register(perplexity.New("", ""))
+		if cfg.Storage.CompressResponses {
+			if hybridDB, ok := database.(*db.HybridDB); ok {
+				hybridDB.SetCompressResponses(true)
+			}
+		}
+		if cfg.Storage.StorePromptText {
+			if hybridDB, ok := database.(*db.HybridDB); ok {
+				hybridDB.SetStorePromptText(true)
+			}
+		}
+		if cfg.Storage.BatchSize > 1 {
+			batchInterval, err := cfg.Storage.GetBatchInterval()
+			if err != nil {
+				return err
+			}
+			if hybridDB, ok := database.(*db.HybridDB); ok {
+				hybridDB.SetResponseBatching(cfg.Storage.BatchSize, batchInterval)
+			}
+		}
 
-		sched = services.NewSchedulerService(database, llmRegistry)
+		statsService = services.NewStatsService(database)
+		geoScore, err := geoScoreConfig(cfg)
+		if err != nil {
+			return err
+		}
+		statsService.SetGEOScoreConfig(geoScore)
 
-		return nil
-	},
-	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
-		if database != nil {
-			return database.Disconnect(context.Background())
+		llmRegistry = newLLMRegistry()
+
+		sched, err = newScheduler(database, llmRegistry, cfg)
+		if err != nil {
+			return err
 		}
+
 		return nil
 	},
 }
 
-// Execute runs the root command
-func Execute() error {
-	return rootCmd.Execute()
+// Execute runs the root command with a context that is cancelled on SIGINT or SIGTERM. The
+// database is disconnected, and batched responses flushed, even when the command fails: cobra
+// skips post-run hooks after an error.
+func Execute() (err error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	defer func() {
+		err = errors.Join(err, closeDatabase())
+	}()
+
+	return rootCmd.ExecuteContext(ctx)
+}
+
+// closeDatabase flushes batched responses and disconnects the database opened by the command, if any
+func closeDatabase() error {
+	if database == nil {
+		return nil
+	}
+	err := database.Disconnect(context.Background())
+	database = nil
+	if err != nil {
+		return fmt.Errorf("failed to disconnect from database: %w", err)
+	}
+	return nil
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.gego/config.yaml)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "INFO", "log level (DEBUG, INFO, WARNING, ERROR)")
 	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "log file path (default: stdout)")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "output language (en, fr; default: $GEGO_LANG or the OS locale)")
+	rootCmd.PersistentFlags().BoolVar(&captureRaw, "capture-raw", false, "store the raw provider requests and responses, secrets redacted, in response metadata (debug)")
+	rootCmd.PersistentFlags().StringVar(&ownerFlag, "owner", "", "team label applied to created LLMs, prompts and schedules, and used to filter lists and stats")
 
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
@@ -139,40 +204,150 @@ func init() {
 	rootCmd.AddCommand(schedulerCmd)
 	rootCmd.AddCommand(statsCmd)
 	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(keywordCmd)
+	rootCmd.AddCommand(metricsCmd)
+	rootCmd.AddCommand(responseCmd)
+	rootCmd.AddCommand(compareCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(analyzeCmd)
+	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(quickstartCmd)
+	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// createDisabledFlag creates the LLMs and prompts added by a command disabled
+var createDisabledFlag bool
+
+// createEnabled returns the enabled state of LLMs and prompts created from the CLI, which are
+// enabled unless --disabled or the create_disabled setting is set
+func createEnabled() bool {
+	return !createDisabledFlag && (cfg == nil || !cfg.CreateDisabled)
+}
+
+// newLLMRegistry creates a registry holding an unconfigured provider of every supported type.
+// A provider that cannot be created from the environment (e.g. Bedrock with an invalid
+// AWS_REGION) is skipped so that the other providers stay usable.
+func newLLMRegistry() *llm.Registry {
+	registry := llm.NewRegistry()
+	for _, providerType := range services.AllProviders() {
+		provider, err := newProvider(providerType.String(), "", "")
+		if err != nil {
+			logger.Warning("Skipping %s provider: %v", providerType, err)
+			continue
+		}
+		registry.Register(provider)
+	}
+	return registry
+}
+
+// newScheduler creates a scheduler service with the storage and response cache options of cfg
+func newScheduler(database db.Database, registry *llm.Registry, cfg *config.Config) (*services.SchedulerService, error) {
+	scheduler := services.NewSchedulerService(database, registry)
+	scheduler.SetProviderFactory(func(llmConfig *models.LLMConfig) (llm.Provider, error) {
+		return newProvider(llmConfig.Provider, llmConfig.APIKey, llmConfig.BaseURL)
+	})
+	scheduler.SetStripReasoning(cfg.Storage.StripReasoning)
+	scheduler.SetCaptureRaw(cfg.Storage.CaptureRaw)
+	scheduler.SetAuthFailureThreshold(cfg.AuthFailureThreshold)
+
+	postProcessors, err := services.NewPostProcessorPipeline(cfg.Storage.PostProcessors)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage.post_processors: %w", err)
+	}
+	scheduler.SetPostProcessors(postProcessors)
+
+	if cfg.ResponseCache.Enabled {
+		cacheTTL, err := cfg.ResponseCache.GetTTL()
+		if err != nil {
+			return nil, err
+		}
+		scheduler.SetResponseCache(cacheTTL)
+		logger.Info("Response cache enabled (TTL: %v)", cacheTTL)
+	}
+
+	if len(cfg.Reports) > 0 {
+		if err := setSchedulerReports(scheduler, cfg); err != nil {
+			logger.Error("Email reports disabled: %v", err)
+		}
+	}
+
+	snapshotJob, err := statsSnapshotJob(cfg)
+	if err != nil {
+		logger.Error("Stats snapshots disabled: %v", err)
+	} else if snapshotJob != nil {
+		scheduler.SetStatsSnapshots(snapshotJob)
+	}
+
+	if cfg.Analysis.AfterRuns {
+		analysis, err := newAnalysisService(context.Background(), database, registry, cfg)
+		if err != nil {
+			logger.Error("Response analysis disabled: %v", err)
+		} else {
+			scheduler.SetAnalysis(analysis)
+		}
+	}
+
+	return scheduler, nil
 }
 
 // Helper function to initialize LLM providers from configs
 func initializeLLMProviders(ctx context.Context) error {
+	return registerLLMProviders(ctx, database, llmRegistry)
+}
+
+// registerLLMProviders registers a provider configured for each stored LLM in registry. LLMs whose
+// provider cannot be created, such as those saved with a base URL that no longer validates, are
+// skipped with a warning so that they can still be fixed with gego llm update.
+func registerLLMProviders(ctx context.Context, database db.Database, registry *llm.Registry) error {
 	llms, err := database.ListLLMs(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to list LLMs: %w", err)
 	}
 
 	for _, llmConfig := range llms {
-		var provider llm.Provider
-
-		switch llmConfig.Provider {
-		case "openai":
-			provider = openai.New(llmConfig.APIKey, llmConfig.BaseURL)
-		case "anthropic":
-			provider = anthropic.New(llmConfig.APIKey, llmConfig.BaseURL)
-		case "ollama":
-			provider = ollama.New(llmConfig.BaseURL)
-		case "google":
-			provider = google.New(llmConfig.APIKey, llmConfig.BaseURL)
-		case "perplexity":
-			provider = perplexity.New(llmConfig.APIKey, llmConfig.BaseURL)
-		default:
+		if services.FromString(llmConfig.Provider) == 0 {
+			continue
+		}
+
+		provider, err := newProvider(llmConfig.Provider, llmConfig.APIKey, llmConfig.BaseURL)
+		if err != nil {
+			logger.Warning("Skipping LLM %s (%s): %v. Fix it with 'gego llm update %s'", llmConfig.Name, llmConfig.ID, err, llmConfig.ID)
 			continue
 		}
 
-		llmRegistry.Register(provider)
+		registry.Register(provider)
 	}
 
 	return nil
 }
 
+// newProvider creates a provider client for the given provider name
+func newProvider(providerName, apiKey, baseURL string) (llm.Provider, error) {
+	switch providerName {
+	case "openai":
+		return openai.New(apiKey, baseURL)
+	case "anthropic":
+		return anthropic.New(apiKey, baseURL)
+	case "ollama":
+		return ollama.New(baseURL)
+	case "google":
+		return google.New(apiKey, baseURL)
+	case "perplexity":
+		return perplexity.New(apiKey, baseURL)
+	case "deepseek":
+		return deepseek.New(apiKey, baseURL)
+	case "xai":
+		return xai.New(apiKey, baseURL)
+	case "bedrock":
+		return bedrock.New(baseURL)
+	default:
+		return nil, fmt.Errorf("unsupported LLM provider: %s", providerName)
+	}
+}
+
 // initializeLogging sets up the logging system based on command line flags
 func initializeLogging() error {
 	level := logger.ParseLogLevel(logLevel)
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
+)
+
+// llmListDB lists a fixed set of LLMs
+type llmListDB struct {
+	db.Database
+	llms []*models.LLMConfig
+}
+
+func (l *llmListDB) ListLLMs(ctx context.Context, enabled *bool) ([]*models.LLMConfig, error) {
+	return l.llms, nil
+}
+
+func TestRegisterLLMProvidersSkipsInvalidBaseURL(t *testing.T) {
+	database := &llmListDB{llms: []*models.LLMConfig{
+		{ID: "llm-1", Name: "Broken", Provider: "openai", APIKey: "sk-test", BaseURL: "api.openai.com/v1"},
+		{ID: "llm-2", Name: "Local", Provider: "ollama", BaseURL: "http://localhost:11434"},
+	}}
+	registry := llm.NewRegistry()
+
+	if err := registerLLMProviders(context.Background(), database, registry); err != nil {
+		t.Fatalf("registerLLMProviders: %v", err)
+	}
+	if _, ok := registry.Get("openai"); ok {
+		t.Error("openai registered despite an invalid base URL")
+	}
+	if _, ok := registry.Get("ollama"); !ok {
+		t.Error("ollama not registered")
+	}
+}
+
+// disconnectDB counts its disconnections, failing them with err when set
+type disconnectDB struct {
+	db.Database
+	disconnects int
+	err         error
+}
+
+func (d *disconnectDB) Disconnect(ctx context.Context) error {
+	d.disconnects++
+	return d.err
+}
+
+func TestCloseDatabase(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "disconnects"},
+		{name: "reports the disconnect error", err: errors.New("flush failed"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &disconnectDB{err: tt.err}
+			database = fake
+			t.Cleanup(func() { database = nil })
+
+			if err := closeDatabase(); (err != nil) != tt.wantErr {
+				t.Fatalf("closeDatabase error = %v, want error %t", err, tt.wantErr)
+			}
+			if err := closeDatabase(); err != nil {
+				t.Errorf("second closeDatabase: %v", err)
+			}
+			if fake.disconnects != 1 {
+				t.Errorf("disconnected %d times, want 1", fake.disconnects)
+			}
+		})
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+
+	for _, providerType := range services.AllProviders() {
+		t.Run(providerType.String(), func(t *testing.T) {
+			provider, err := newProvider(providerType.String(), "test-key", "")
+			if err != nil {
+				t.Fatalf("newProvider: %v", err)
+			}
+			if provider.Name() != providerType.String() {
+				t.Errorf("provider name = %q, want %q", provider.Name(), providerType.String())
+			}
+
+			if _, err := newProvider(providerType.String(), "test-key", "localhost:8080"); err == nil {
+				t.Error("base URL without a scheme accepted")
+			}
+		})
+	}
+
+	if _, err := newProvider("mistral", "test-key", ""); err == nil {
+		t.Error("unsupported provider accepted")
+	}
+}
+
+func TestNewLLMRegistry(t *testing.T) {
+	tests := []struct {
+		name        string
+		region      string
+		wantBedrock bool
+	}{
+		{name: "default region", wantBedrock: true},
+		{name: "invalid AWS_REGION skips bedrock", region: "not a region"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AWS_REGION", tt.region)
+			t.Setenv("AWS_DEFAULT_REGION", "")
+
+			registry := newLLMRegistry()
+			for _, providerType := range services.AllProviders() {
+				_, ok := registry.Get(providerType.String())
+				want := providerType.String() != "bedrock" || tt.wantBedrock
+				if ok != want {
+					t.Errorf("%s provider registered = %v, want %v", providerType, ok, want)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"strings"
@@ -21,23 +22,148 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+var (
+	runStdin bool
+	runLLMs  []string
+)
+
 var runCmd = &cobra.Command{
 	Use:   "run",
 	Short: "Run all prompts with all LLMs once",
-	Long:  `Execute all enabled prompts with all enabled LLMs immediately. Use 'gego scheduler start' for scheduled execution.`,
-	RunE:  runCommand,
+	Long: `Execute all enabled prompts with all enabled LLMs immediately. Use 'gego scheduler start' for scheduled execution.
+
+With --stdin, run the prompts piped to gego instead, one per line, and print the answers. Piped
+prompts and their responses are not stored, for ad-hoc checks:
+
+  echo "What are the best VPNs?" | gego run --stdin --llm <llm-id>`,
+	RunE: runCommand,
+}
+
+func init() {
+	runCmd.Flags().BoolVar(&runStdin, "stdin", false, "Run the prompts piped to stdin, one per line, without storing them")
+	runCmd.Flags().StringSliceVar(&runLLMs, "llm", nil, "ID of an LLM to run piped prompts with, repeatable (default: all enabled LLMs)")
 }
 
 func runCommand(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
+
+	if len(runLLMs) > 0 && !runStdin {
+		return fmt.Errorf("--llm requires --stdin")
+	}
 
 	if err := initializeLLMProviders(ctx); err != nil {
 		return fmt.Errorf("failed to initialize LLM providers: %w", err)
 	}
 
+	if runStdin {
+		prompts, err := readStdinPrompts(os.Stdin)
+		if err != nil {
+			return err
+		}
+		return runStdinMode(ctx, prompts, runLLMs, os.Stdout)
+	}
 	return runOnceMode(ctx)
 }
 
+// readStdinPrompts reads one prompt per non-empty line of a pipe, refusing a terminal so that
+// --stdin does not wait for input silently
+func readStdinPrompts(stdin *os.File) ([]string, error) {
+	if info, err := stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return nil, fmt.Errorf("--stdin expects prompts piped to gego, e.g. echo \"best VPNs?\" | gego run --stdin")
+	}
+	return scanPrompts(stdin)
+}
+
+// scanPrompts returns the non-empty lines of r, trimmed
+func scanPrompts(r io.Reader) ([]string, error) {
+	var prompts []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			prompts = append(prompts, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read prompts from stdin: %w", err)
+	}
+	if len(prompts) == 0 {
+		return nil, fmt.Errorf("no prompt piped to stdin")
+	}
+	return prompts, nil
+}
+
+// runStdinMode runs ad-hoc prompts with the given LLMs, or all enabled LLMs, and writes the answers
+// to out. Neither the prompts nor the responses are stored.
+func runStdinMode(ctx context.Context, templates []string, llmIDs []string, out io.Writer) error {
+	llms, err := stdinLLMs(ctx, llmIDs)
+	if err != nil {
+		return err
+	}
+
+	executionService := services.NewExecutionService(database, llmRegistry)
+	executionService.SetRateLimiters(services.NewRateLimiters())
+	executionService.SetStripReasoning(cfg.Storage.StripReasoning)
+	executionService.SetCaptureRaw(cfg.Storage.CaptureRaw)
+	postProcessors, err := services.NewPostProcessorPipeline(cfg.Storage.PostProcessors)
+	if err != nil {
+		return fmt.Errorf("invalid storage.post_processors: %w", err)
+	}
+	executionService.SetPostProcessors(postProcessors)
+
+	config := services.DefaultExecutionConfig()
+	config.Ephemeral = true
+
+	failed := 0
+	for _, template := range templates {
+		prompt := &models.Prompt{Template: template, Enabled: true}
+		for _, llm := range llms {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			fmt.Fprintf(out, "%s📝 %s%s\n", InfoStyle, FormatValue(template), Reset)
+			fmt.Fprintf(out, "%s🤖 %s (%s)%s\n", InfoStyle, FormatValue(llm.Name), FormatSecondary(llm.Model), Reset)
+			response, err := executionService.ExecutePromptWithLLM(ctx, prompt, llm, config)
+			if err != nil {
+				failed++
+				fmt.Fprintf(out, "%s❌ Failed: %s%s\n\n", ErrorStyle, FormatValue(err.Error()), Reset)
+				continue
+			}
+			fmt.Fprintf(out, "%s\n\n", response.ResponseText)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d execution(s) failed", failed, len(templates)*len(llms))
+	}
+	return nil
+}
+
+// stdinLLMs returns the LLMs of llmIDs, or all enabled LLMs when none is given
+func stdinLLMs(ctx context.Context, llmIDs []string) ([]*models.LLMConfig, error) {
+	if len(llmIDs) == 0 {
+		llms, err := services.NewLLMService(database).GetEnabledLLMs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list LLMs: %w", err)
+		}
+		if len(llms) == 0 {
+			return nil, fmt.Errorf("no enabled LLMs found")
+		}
+		return llms, nil
+	}
+
+	llms := make([]*models.LLMConfig, 0, len(llmIDs))
+	for _, llmID := range llmIDs {
+		llm, err := database.GetLLM(ctx, strings.TrimSpace(llmID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get LLM %s: %w", llmID, err)
+		}
+		llms = append(llms, llm)
+	}
+	return llms, nil
+}
+
 func runOnceMode(ctx context.Context) error {
 	promptService := services.NewPromptManagementService(database)
 	llmService := services.NewLLMService(database)
@@ -98,6 +224,24 @@ func runOnceMode(ctx context.Context) error {
 	totalExecutions := len(prompts) * len(llms)
 	completedExecutions := 0
 
+	rateLimiters := services.NewRateLimiters()
+	executionService := services.NewExecutionService(database, llmRegistry)
+	executionService.SetRateLimiters(rateLimiters)
+	executionService.SetStripReasoning(cfg.Storage.StripReasoning)
+	executionService.SetCaptureRaw(cfg.Storage.CaptureRaw)
+
+	postProcessors, err := services.NewPostProcessorPipeline(cfg.Storage.PostProcessors)
+	if err != nil {
+		return fmt.Errorf("invalid storage.post_processors: %w", err)
+	}
+	executionService.SetPostProcessors(postProcessors)
+
+	remaining := make(map[string]int)
+	for _, llm := range llms {
+		remaining[llm.Provider] += len(prompts)
+	}
+	startTime := time.Now()
+
 	for _, prompt := range prompts {
 		currentTemperature := temperature
 		if temperature == -1.0 { // random was selected
@@ -105,11 +249,19 @@ func runOnceMode(ctx context.Context) error {
 			currentTemperature = rand.Float64()
 		}
 		for _, llm := range llms {
+			if ctx.Err() != nil {
+				fmt.Printf("%s⏹️  Cancelled after %s/%s executions%s\n", WarningStyle, FormatCount(completedExecutions), FormatCount(totalExecutions), Reset)
+				return ctx.Err()
+			}
+
 			fmt.Printf("%s📝 Running prompt: %s%s\n", InfoStyle, FormatValue(prompt.Template), Reset)
 			fmt.Printf("%s🤖 Using LLM: %s (%s)%s\n", InfoStyle, FormatValue(llm.Name), FormatSecondary(llm.Provider), Reset)
 			fmt.Printf("%s🌡️  Using temperature: %s%s\n", InfoStyle, FormatValue(fmt.Sprintf("%.1f", currentTemperature)), Reset)
 
-			executionService := services.NewExecutionService(database, llmRegistry)
+			if delay := rateLimiters.Delay(llm.Provider); delay >= time.Second {
+				fmt.Printf("%s⏳ Waiting %s for the %s rate limit (%d requests/min)...%s\n", DimStyle, delay.Round(time.Second), llm.Provider, services.RequestsPerMinute, Reset)
+			}
+
 			config := &services.ExecutionConfig{
 				Temperature: currentTemperature,
 				MaxRetries:  3,
@@ -124,7 +276,14 @@ func runOnceMode(ctx context.Context) error {
 			}
 
 			completedExecutions++
-			fmt.Printf("%sProgress: %s/%s%s\n", DimStyle, FormatCount(completedExecutions), FormatCount(totalExecutions), Reset)
+			remaining[llm.Provider]--
+
+			paces := make(map[string]services.ProviderPace, len(remaining))
+			for provider, count := range remaining {
+				paces[provider] = rateLimiters.Pace(provider, count)
+			}
+			eta := services.EstimateETA(paces, services.AverageLatency(time.Since(startTime), completedExecutions))
+			fmt.Printf("%sProgress: %s/%s | ETA: ~%s%s\n", DimStyle, FormatCount(completedExecutions), FormatCount(totalExecutions), eta.Round(time.Second), Reset)
 			fmt.Println()
 		}
 	}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/config"
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestScanPrompts(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "one per line", input: "Best VPN?\nBest CRM?\n", want: []string{"Best VPN?", "Best CRM?"}},
+		{name: "blank lines and spaces skipped", input: "\n  Best VPN?  \n\n\t\nBest CRM?", want: []string{"Best VPN?", "Best CRM?"}},
+		{name: "nothing piped", input: " \n\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scanPrompts(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("scanPrompts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("scanPrompts() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// stdinRunDB holds LLMs and fails every write, as piped prompts must not be stored
+type stdinRunDB struct {
+	db.Database
+	llms []*models.LLMConfig
+}
+
+func (s *stdinRunDB) GetLLM(ctx context.Context, id string) (*models.LLMConfig, error) {
+	for _, llmConfig := range s.llms {
+		if llmConfig.ID == id {
+			return llmConfig, nil
+		}
+	}
+	return nil, fmt.Errorf("LLM not found: %s", id)
+}
+
+func (s *stdinRunDB) ListLLMs(ctx context.Context, enabled *bool) ([]*models.LLMConfig, error) {
+	var llms []*models.LLMConfig
+	for _, llmConfig := range s.llms {
+		if enabled == nil || llmConfig.Enabled == *enabled {
+			llms = append(llms, llmConfig)
+		}
+	}
+	return llms, nil
+}
+
+func (s *stdinRunDB) CreatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	return errors.New("piped prompts must not be stored")
+}
+
+func (s *stdinRunDB) CreateResponse(ctx context.Context, response *models.Response) error {
+	return errors.New("responses to piped prompts must not be stored")
+}
+
+// echoProvider answers every prompt with the prompt itself, prefixed by its name
+type echoProvider struct {
+	name string
+}
+
+func (p *echoProvider) Name() string { return p.name }
+
+func (p *echoProvider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
+	return &llm.Response{Text: p.name + " says " + prompt, Provider: p.name, Model: config.Model}, nil
+}
+
+func (p *echoProvider) Capabilities() llm.Capabilities { return llm.Capabilities{} }
+
+func (p *echoProvider) Validate(config map[string]string) error { return nil }
+
+func (p *echoProvider) ListModels(ctx context.Context, apiKey, baseURL string) ([]models.ModelInfo, error) {
+	return nil, nil
+}
+
+func TestRunStdinMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		llmIDs  []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "all enabled LLMs", want: []string{"openai says Best VPN?", "anthropic says Best VPN?"}},
+		{name: "selected LLM", llmIDs: []string{"llm-2"}, want: []string{"anthropic says Best VPN?"}},
+		{name: "unknown LLM", llmIDs: []string{"missing"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := llm.NewRegistry()
+			registry.Register(&echoProvider{name: "openai"})
+			registry.Register(&echoProvider{name: "anthropic"})
+			database = &stdinRunDB{llms: []*models.LLMConfig{
+				{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", Enabled: true},
+				{ID: "llm-2", Name: "Claude", Provider: "anthropic", Model: "claude", Enabled: true},
+				{ID: "llm-3", Name: "Old", Provider: "openai", Model: "gpt-3.5", Enabled: false},
+			}}
+			llmRegistry = registry
+			cfg = &config.Config{}
+			t.Cleanup(func() { database, llmRegistry, cfg = nil, nil, nil })
+
+			// One prompt, so that no provider is called twice and waits for its rate limit
+			var out strings.Builder
+			err := runStdinMode(context.Background(), []string{"Best VPN?"}, tt.llmIDs, &out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runStdinMode() error = %v, wantErr %v\n%s", err, tt.wantErr, out.String())
+			}
+
+			output := out.String()
+			position := 0
+			for _, answer := range tt.want {
+				index := strings.Index(output[position:], answer)
+				if index < 0 {
+					t.Fatalf("output missing %q in order:\n%s", answer, output)
+				}
+				position += index + len(answer)
+			}
+			if strings.Contains(output, "gpt-3.5") {
+				t.Errorf("disabled LLM was run:\n%s", output)
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package cli
 
 import (
 	"bufio"
-	"context"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -13,6 +15,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
 )
 
 var scheduleCmd = &cobra.Command{
@@ -24,6 +28,7 @@ var scheduleCmd = &cobra.Command{
 var scheduleAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add a new schedule",
+	Long:  "Add a new schedule interactively. Its timing is a cron expression or a phrase.\n\n" + services.CronGrammarHelp(),
 	RunE:  runScheduleAdd,
 }
 
@@ -68,6 +73,73 @@ var scheduleRunCmd = &cobra.Command{
 	RunE:  runScheduleRun,
 }
 
+var scheduleCloneCmd = &cobra.Command{
+	Use:   "clone [id]",
+	Short: "Copy a schedule with a new ID",
+	Long: `Copy a schedule, keeping its prompts, LLMs and run options, to create a variant.
+Flags override the copied fields; the copy starts without run history.
+
+Examples:
+  gego schedule clone <id> --name "Hourly variant" --cron "every hour"
+  gego schedule clone <id> --temperature 0.2 --disabled`,
+	Args: cobra.ExactArgs(1),
+	RunE: runScheduleClone,
+}
+
+var scheduleSampleCmd = &cobra.Command{
+	Use:   "sample [id]",
+	Short: "Sample prompts by weight on each run",
+	Long: `Run only --count prompts of a schedule on each fire, drawn at random with each prompt
+favored in proportion to its weight. Prompts without a weight weigh 1.
+
+Examples:
+  gego schedule sample <id> --count 5
+  gego schedule sample <id> --count 5 --weight <prompt-id>=3 --weight <prompt-id>=0.5
+  gego schedule sample <id> --count 0 --clear-weights`,
+	Args: cobra.ExactArgs(1),
+	RunE: runScheduleSample,
+}
+
+var scheduleHistoryCmd = &cobra.Command{
+	Use:   "history [id]",
+	Short: "List the recent runs of a schedule",
+	Long: `List the recent runs of a schedule, newest first, with the run ID recorded on their responses
+and log lines. Pass a run ID to GET /api/v1/responses?run_id=<run-id> or to gego export archive
+--run-id to get exactly the responses of that run.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runScheduleHistory,
+}
+
+var scheduleSimulateCmd = &cobra.Command{
+	Use:   "simulate [id]",
+	Short: "Show what a schedule would do at a given time",
+	Long: `Check whether the cron expression of a schedule fires at --at (UTC, default now) and list the
+prompt x LLM executions a run would make with the current prompts and LLMs. Nothing is executed.
+
+Examples:
+  gego schedule simulate <id> --at "2025-06-02 09:00"
+  gego schedule simulate <id> --at 2025-06-02T11:00:00+02:00`,
+	Args: cobra.ExactArgs(1),
+	RunE: runScheduleSimulate,
+}
+
+var scheduleHistoryLimit int
+
+var scheduleSimulateAt string
+
+var (
+	sampleCount        int
+	sampleWeights      []string
+	sampleClearWeights bool
+)
+
+var (
+	cloneName        string
+	cloneCron        string
+	cloneTemperature float64
+	cloneDisabled    bool
+)
+
 func init() {
 	scheduleCmd.AddCommand(scheduleAddCmd)
 	scheduleCmd.AddCommand(scheduleListCmd)
@@ -76,11 +148,26 @@ func init() {
 	scheduleCmd.AddCommand(scheduleEnableCmd)
 	scheduleCmd.AddCommand(scheduleDisableCmd)
 	scheduleCmd.AddCommand(scheduleRunCmd)
+	scheduleCmd.AddCommand(scheduleCloneCmd)
+	scheduleCmd.AddCommand(scheduleSampleCmd)
+	scheduleCmd.AddCommand(scheduleHistoryCmd)
+	scheduleCmd.AddCommand(scheduleSimulateCmd)
+
+	scheduleCloneCmd.Flags().StringVar(&cloneName, "name", "", "Name of the copy (default: original name with \" (copy)\")")
+	scheduleCloneCmd.Flags().StringVar(&cloneCron, "cron", "", "Cron expression or phrase such as \"every day at 9am\"")
+	scheduleCloneCmd.Flags().Float64Var(&cloneTemperature, "temperature", 0, "Temperature for LLM generation (0.0-1.0)")
+	scheduleCloneCmd.Flags().BoolVar(&cloneDisabled, "disabled", false, "Create the copy disabled")
+
+	scheduleSampleCmd.Flags().IntVar(&sampleCount, "count", 0, "Prompts run per fire (0 runs every prompt)")
+	scheduleSampleCmd.Flags().StringArrayVar(&sampleWeights, "weight", nil, "Prompt weight as <prompt-id>=<weight> (repeatable)")
+	scheduleSampleCmd.Flags().BoolVar(&sampleClearWeights, "clear-weights", false, "Remove all prompt weights")
+	scheduleHistoryCmd.Flags().IntVarP(&scheduleHistoryLimit, "limit", "l", 20, "Number of runs to list")
+	scheduleSimulateCmd.Flags().StringVar(&scheduleSimulateAt, "at", "", "Instant to simulate: \"YYYY-MM-DD HH:MM[:SS]\" in UTC or RFC3339 (default now)")
 }
 
 func runScheduleAdd(cmd *cobra.Command, args []string) error {
 	reader := bufio.NewReader(os.Stdin)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	fmt.Printf("%s➕ Add New Schedule%s\n", FormatHeader(""), Reset)
 	fmt.Printf("%s==================%s\n", DimStyle, Reset)
@@ -89,6 +176,7 @@ func runScheduleAdd(cmd *cobra.Command, args []string) error {
 	schedule := &models.Schedule{
 		ID:      uuid.New().String(),
 		Enabled: true,
+		Owner:   ownerFlag,
 	}
 
 	fmt.Printf("%sName: %s", LabelStyle, Reset)
@@ -109,11 +197,13 @@ func runScheduleAdd(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  %s%d. %s%s\n", CountStyle, i+1, Reset, FormatValue(p.Template))
 	}
 
-	fmt.Printf("\n%sSelect prompts (comma-separated numbers or 'all'): %s", LabelStyle, Reset)
+	fmt.Printf("\n%sSelect prompts (comma-separated numbers, 'all', or 'enabled' to always run every enabled prompt): %s", LabelStyle, Reset)
 	promptSelection, _ := reader.ReadString('\n')
 	promptSelection = strings.TrimSpace(promptSelection)
 
-	if promptSelection == "all" {
+	if promptSelection == "enabled" {
+		schedule.AllPrompts = true
+	} else if promptSelection == "all" {
 		for _, p := range prompts {
 			schedule.PromptIDs = append(schedule.PromptIDs, p.ID)
 		}
@@ -143,11 +233,13 @@ func runScheduleAdd(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  %s%d. %s (%s - %s)%s\n", CountStyle, i+1, FormatValue(l.Name), FormatSecondary(l.Provider), FormatSecondary(l.Model), Reset)
 	}
 
-	fmt.Printf("\n%sSelect LLMs (comma-separated numbers or 'all'): %s", LabelStyle, Reset)
+	fmt.Printf("\n%sSelect LLMs (comma-separated numbers, 'all', or 'enabled' to always run every enabled LLM): %s", LabelStyle, Reset)
 	llmSelection, _ := reader.ReadString('\n')
 	llmSelection = strings.TrimSpace(llmSelection)
 
-	if llmSelection == "all" {
+	if llmSelection == "enabled" {
+		schedule.AllLLMs = true
+	} else if llmSelection == "all" {
 		for _, l := range llms {
 			schedule.LLMIDs = append(schedule.LLMIDs, l.ID)
 		}
@@ -163,60 +255,68 @@ func runScheduleAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Printf("\n%sSchedule Frequency:%s\n", LabelStyle, Reset)
-	fmt.Printf("  %s1. Every day%s\n", CountStyle, Reset)
-	fmt.Printf("  %s2. Every week%s\n", CountStyle, Reset)
-	fmt.Printf("  %s3. Every month%s\n", CountStyle, Reset)
-	fmt.Printf("  %s4. Custom%s\n", CountStyle, Reset)
+	cronExpr, err := promptScheduleCron(reader)
+	if err != nil {
+		return err
+	}
+	schedule.CronExpr = cronExpr
 
-	cronChoice, err := promptWithRetry(reader, fmt.Sprintf("\n%sSelect frequency (1-4): %s", LabelStyle, Reset), func(input string) (string, error) {
+	temperature, err := promptTemperature(reader)
+	if err != nil {
+		return fmt.Errorf("failed to get temperature: %w", err)
+	}
+	schedule.Temperature = temperature
+
+	fmt.Printf("\n%sCatch-up policy for runs missed while the scheduler is down:%s\n", LabelStyle, Reset)
+	fmt.Printf("  %s1. None (only count missed runs)%s\n", CountStyle, Reset)
+	fmt.Printf("  %s2. Run once when the scheduler starts%s\n", CountStyle, Reset)
+	fmt.Printf("  %s3. Backfill every missed run (up to %d)%s\n", CountStyle, models.DefaultCatchUpMax, Reset)
+
+	catchUpChoice, err := promptWithRetry(reader, fmt.Sprintf("\n%sSelect catch-up policy (1-3) [1]: %s", LabelStyle, Reset), func(input string) (string, error) {
 		switch input {
-		case "1", "2", "3", "4":
-			return input, nil
+		case "", "1":
+			return models.CatchUpNone, nil
+		case "2":
+			return models.CatchUpRunOnceOnStart, nil
+		case "3":
+			return models.CatchUpBackfillAll, nil
 		default:
-			return "", fmt.Errorf("invalid choice: %s (choose 1-4)", input)
+			return "", fmt.Errorf("invalid choice: %s (choose 1-3)", input)
 		}
 	})
 	if err != nil {
 		return err
 	}
+	schedule.CatchUpPolicy = catchUpChoice
 
-	var cronExpr string
-	switch cronChoice {
-	case "1":
-		cronExpr = "0 9 * * *"
-		fmt.Printf("%sSelected: Every day%s\n", SuccessStyle, Reset)
-	case "2":
-		cronExpr = "0 9 * * MON"
-		fmt.Printf("%sSelected: Every week%s\n", SuccessStyle, Reset)
-	case "3":
-		cronExpr = "0 9 1 * *"
-		fmt.Printf("%sSelected: Every month%s\n", SuccessStyle, Reset)
-	case "4":
-		fmt.Printf("\n%sCron Expression Examples:%s\n", LabelStyle, Reset)
-		fmt.Printf("  %s*/15 * * * *%s    - Every 15 minutes\n", FormatSecondary(""), Reset)
-		fmt.Printf("  %s0 9 * * *%s       - Every day at 9am\n", FormatSecondary(""), Reset)
-		fmt.Printf("  %s0 9 * * MON%s     - Every Monday at 9am\n", FormatSecondary(""), Reset)
-		fmt.Printf("  %s0 0 1 * *%s       - First day of every month\n", FormatSecondary(""), Reset)
-		customCron, err := promptWithRetry(reader, fmt.Sprintf("\n%sEnter custom cron expression: %s", LabelStyle, Reset), func(input string) (string, error) {
-			if input == "" {
-				return "", fmt.Errorf("cron expression is required")
-			}
-			return input, nil
-		})
-		if err != nil {
-			return err
+	seedStr, err := promptWithRetry(reader, fmt.Sprintf("%sSeed for reproducible outputs (optional, supported by OpenAI, Google and Ollama): %s", LabelStyle, Reset), func(input string) (string, error) {
+		if input == "" {
+			return "", nil
+		}
+		if _, err := strconv.Atoi(input); err != nil {
+			return "", fmt.Errorf("invalid seed: %s (enter an integer or press Enter to skip)", input)
 		}
-		cronExpr = customCron
+		return input, nil
+	})
+	if err != nil {
+		return err
+	}
+	if seedStr != "" {
+		seed, _ := strconv.Atoi(seedStr)
+		schedule.Seed = &seed
 	}
 
-	schedule.CronExpr = cronExpr
+	shuffle, err := promptYesNo(reader, fmt.Sprintf("%sShuffle the prompt x LLM execution order on each run? (y/N): %s", LabelStyle, Reset))
+	if err != nil {
+		return err
+	}
+	schedule.Shuffle = shuffle
 
-	temperature, err := promptTemperature(reader)
+	checkLanguage, err := promptYesNo(reader, fmt.Sprintf("%sFlag responses not in the language of their prompt's lang-XX tag? (y/N): %s", LabelStyle, Reset))
 	if err != nil {
-		return fmt.Errorf("failed to get temperature: %w", err)
+		return err
 	}
-	schedule.Temperature = temperature
+	schedule.CheckLanguage = checkLanguage
 
 	if err := database.CreateSchedule(ctx, schedule); err != nil {
 		return fmt.Errorf("failed to create schedule: %w", err)
@@ -224,8 +324,8 @@ func runScheduleAdd(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("\n%s✅ Schedule added successfully!%s\n", SuccessStyle, Reset)
 	fmt.Printf("%sID: %s\n", LabelStyle, FormatSecondary(schedule.ID))
-	fmt.Printf("%sPrompts: %s\n", LabelStyle, FormatCount(len(schedule.PromptIDs)))
-	fmt.Printf("%sLLMs: %s\n", LabelStyle, FormatCount(len(schedule.LLMIDs)))
+	fmt.Printf("%sPrompts: %s\n", LabelStyle, scheduleTargetCount(schedule.AllPrompts, len(schedule.PromptIDs)))
+	fmt.Printf("%sLLMs: %s\n", LabelStyle, scheduleTargetCount(schedule.AllLLMs, len(schedule.LLMIDs)))
 	fmt.Printf("%sTemperature: %s\n", LabelStyle, FormatValue(fmt.Sprintf("%.1f", schedule.Temperature)))
 	fmt.Printf("\n%sRestart the scheduler to apply changes: %s%s\n", InfoStyle, FormatSecondary("gego scheduler start"), Reset)
 
@@ -233,7 +333,7 @@ func runScheduleAdd(cmd *cobra.Command, args []string) error {
 }
 
 func runScheduleList(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
 
 	schedules, err := database.ListSchedules(ctx, nil)
 	if err != nil {
@@ -246,8 +346,8 @@ func runScheduleList(cmd *cobra.Command, args []string) error {
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "%sID\tNAME\tCRON\tPROMPTS\tLLMs\tTEMP\tLAST RUN\tENABLED%s\n", LabelStyle, Reset)
-	fmt.Fprintf(w, "%s──\t────\t────\t───────\t────\t────\t────────\t───────%s\n", DimStyle, Reset)
+	fmt.Fprintf(w, "%sID\tNAME\tCRON\tPROMPTS\tLLMs\tTEMP\tLAST RUN\tMISSED\tENABLED%s\n", LabelStyle, Reset)
+	fmt.Fprintf(w, "%s──\t────\t────\t───────\t────\t────\t────────\t──────\t───────%s\n", DimStyle, Reset)
 
 	for _, schedule := range schedules {
 		enabled := "Yes"
@@ -260,16 +360,20 @@ func runScheduleList(cmd *cobra.Command, args []string) error {
 			lastRun = schedule.LastRun.Format("01-02 15:04")
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 			FormatSecondary(schedule.ID),
 			FormatValue(schedule.Name),
 			FormatSecondary(schedule.CronExpr),
-			FormatCount(len(schedule.PromptIDs)),
-			FormatCount(len(schedule.LLMIDs)),
+			scheduleTargetCount(schedule.AllPrompts, len(schedule.PromptIDs)),
+			scheduleTargetCount(schedule.AllLLMs, len(schedule.LLMIDs)),
 			FormatValue(fmt.Sprintf("%.1f", schedule.Temperature)),
 			FormatMeta(lastRun),
+			FormatMeta(fmt.Sprintf("missed: %d", schedule.MissedRuns)),
 			FormatValue(enabled),
 		)
+		if schedule.LastError != "" {
+			fmt.Fprintf(w, "\t%s\n", ErrorStyle+"⚠️  not registered: "+schedule.LastError+Reset)
+		}
 	}
 
 	w.Flush()
@@ -279,7 +383,7 @@ func runScheduleList(cmd *cobra.Command, args []string) error {
 }
 
 func runScheduleGet(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	id := args[0]
 
 	schedule, err := database.GetSchedule(ctx, id)
@@ -293,6 +397,9 @@ func runScheduleGet(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%sName: %s\n", LabelStyle, FormatValue(schedule.Name))
 	fmt.Printf("%sCron Expression: %s\n", LabelStyle, FormatSecondary(schedule.CronExpr))
 	fmt.Printf("%sEnabled: %s\n", LabelStyle, FormatValue(fmt.Sprintf("%v", schedule.Enabled)))
+	if schedule.LastError != "" {
+		fmt.Printf("%sLast Error: %s%s%s\n", LabelStyle, ErrorStyle, schedule.LastError, Reset)
+	}
 	fmt.Printf("%sCreated: %s\n", LabelStyle, FormatMeta(schedule.CreatedAt.Format(time.RFC3339)))
 	fmt.Printf("%sUpdated: %s\n", LabelStyle, FormatMeta(schedule.UpdatedAt.Format(time.RFC3339)))
 
@@ -302,8 +409,29 @@ func runScheduleGet(cmd *cobra.Command, args []string) error {
 	if schedule.NextRun != nil {
 		fmt.Printf("%sNext Run: %s\n", LabelStyle, FormatMeta(schedule.NextRun.Format(time.RFC3339)))
 	}
+	fmt.Printf("%sCatch-up Policy: %s\n", LabelStyle, FormatValue(schedule.CatchUpPolicy))
+	fmt.Printf("%sMissed Runs: %s\n", LabelStyle, FormatCount(schedule.MissedRuns))
+	if schedule.Owner != "" {
+		fmt.Printf("%sOwner: %s\n", LabelStyle, FormatValue(schedule.Owner))
+	}
+	if schedule.Seed != nil {
+		fmt.Printf("%sSeed: %s\n", LabelStyle, FormatValue(strconv.Itoa(*schedule.Seed)))
+	}
+	if schedule.Shuffle {
+		fmt.Printf("%sExecution Order: %s\n", LabelStyle, FormatValue("shuffled"))
+	}
+	if schedule.CheckLanguage {
+		fmt.Printf("%sLanguage Check: %s\n", LabelStyle, FormatValue("responses not in the prompt language are flagged"))
+	}
+	if schedule.SampleCount > 0 {
+		fmt.Printf("%sSampling: %s\n", LabelStyle, FormatValue(fmt.Sprintf("%d prompts per run, by weight", schedule.SampleCount)))
+	}
 
-	fmt.Printf("\n%sPrompts (%s):%s\n", SuccessStyle, FormatCount(len(schedule.PromptIDs)), Reset)
+	if schedule.AllPrompts {
+		fmt.Printf("\n%sPrompts:%s %s\n", SuccessStyle, Reset, FormatValue("all enabled prompts, resolved at each run"))
+	} else {
+		fmt.Printf("\n%sPrompts (%s):%s\n", SuccessStyle, FormatCount(len(schedule.PromptIDs)), Reset)
+	}
 	for _, promptID := range schedule.PromptIDs {
 		prompt, err := database.GetPrompt(ctx, promptID)
 		if err != nil {
@@ -317,7 +445,11 @@ func runScheduleGet(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Printf("\n%sLLMs (%s):%s\n", SuccessStyle, FormatCount(len(schedule.LLMIDs)), Reset)
+	if schedule.AllLLMs {
+		fmt.Printf("\n%sLLMs:%s %s\n", SuccessStyle, Reset, FormatValue("all enabled LLMs, resolved at each run"))
+	} else {
+		fmt.Printf("\n%sLLMs (%s):%s\n", SuccessStyle, FormatCount(len(schedule.LLMIDs)), Reset)
+	}
 	for _, llmID := range schedule.LLMIDs {
 		llm, err := database.GetLLM(ctx, llmID)
 		if err != nil {
@@ -327,11 +459,28 @@ func runScheduleGet(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	latency, err := statsService.GetLatencyStats(ctx, schedule.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get latency stats: %w", err)
+	}
+	if len(latency) > 0 {
+		fmt.Printf("\n%sLatency:%s\n", SuccessStyle, Reset)
+		printLatencyStats(os.Stdout, latency)
+	}
+
 	return nil
 }
 
+// scheduleTargetCount formats the number of prompts or LLMs of a schedule, or "all enabled" when resolved at each run
+func scheduleTargetCount(all bool, count int) string {
+	if all {
+		return FormatValue("all enabled")
+	}
+	return FormatCount(count)
+}
+
 func runScheduleDelete(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	reader := bufio.NewReader(os.Stdin)
 
 	if len(args) == 0 {
@@ -389,8 +538,192 @@ func runScheduleDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runScheduleClone(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	overrides := services.ScheduleOverrides{Name: cloneName, CronExpr: cloneCron}
+	if cmd.Flags().Changed("temperature") {
+		overrides.Temperature = &cloneTemperature
+	}
+	if cloneDisabled {
+		enabled := false
+		overrides.Enabled = &enabled
+	}
+
+	schedule, err := services.NewScheduleService(database).CloneSchedule(ctx, args[0], overrides)
+	if err != nil {
+		return fmt.Errorf("failed to clone schedule: %w", err)
+	}
+
+	fmt.Printf("%s✅ Schedule cloned successfully!%s\n", SuccessStyle, Reset)
+	fmt.Printf("%sID: %s\n", LabelStyle, FormatSecondary(schedule.ID))
+	fmt.Printf("%sName: %s\n", LabelStyle, FormatValue(schedule.Name))
+	fmt.Printf("%sCron Expression: %s\n", LabelStyle, FormatSecondary(schedule.CronExpr))
+	fmt.Printf("%sTemperature: %s\n", LabelStyle, FormatValue(fmt.Sprintf("%.1f", schedule.Temperature)))
+	fmt.Printf("%sEnabled: %s\n", LabelStyle, FormatValue(fmt.Sprintf("%v", schedule.Enabled)))
+	fmt.Printf("\n%sRestart the scheduler to apply changes: %s%s\n", InfoStyle, FormatSecondary("gego scheduler start"), Reset)
+	return nil
+}
+
+func runScheduleSample(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	schedule, err := database.GetSchedule(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	if cmd.Flags().Changed("count") {
+		schedule.SampleCount = sampleCount
+	}
+	if sampleClearWeights {
+		schedule.PromptWeights = nil
+	}
+	for _, value := range sampleWeights {
+		promptID, weightStr, ok := strings.Cut(value, "=")
+		weight, err := strconv.ParseFloat(strings.TrimSpace(weightStr), 64)
+		if !ok || err != nil {
+			return fmt.Errorf("invalid --weight %q: use <prompt-id>=<weight>", value)
+		}
+		promptID = strings.TrimSpace(promptID)
+		if !schedule.AllPrompts && !slices.Contains(schedule.PromptIDs, promptID) {
+			return fmt.Errorf("prompt %s is not part of schedule %s", promptID, schedule.Name)
+		}
+		if schedule.PromptWeights == nil {
+			schedule.PromptWeights = make(map[string]float64)
+		}
+		schedule.PromptWeights[promptID] = weight
+	}
+
+	if err := services.ValidatePromptSampling(schedule.PromptWeights, schedule.SampleCount); err != nil {
+		return err
+	}
+	if err := database.UpdateSchedule(ctx, schedule); err != nil {
+		return fmt.Errorf("failed to update schedule: %w", err)
+	}
+
+	fmt.Printf("%s✅ Sampling updated!%s\n", SuccessStyle, Reset)
+	if schedule.SampleCount > 0 {
+		fmt.Printf("%sPrompts per run: %s\n", LabelStyle, FormatCount(schedule.SampleCount))
+	} else {
+		fmt.Printf("%sPrompts per run: %s\n", LabelStyle, FormatValue("all"))
+	}
+	promptIDs := slices.Sorted(maps.Keys(schedule.PromptWeights))
+	for _, promptID := range promptIDs {
+		fmt.Printf("  - %s: %s\n", FormatSecondary(promptID), FormatValue(strconv.FormatFloat(schedule.PromptWeights[promptID], 'g', -1, 64)))
+	}
+	fmt.Printf("\n%sRestart the scheduler to apply changes: %s%s\n", InfoStyle, FormatSecondary("gego scheduler start"), Reset)
+	return nil
+}
+
+func runScheduleHistory(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+
+	schedule, err := database.GetSchedule(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get schedule: %w", err)
+	}
+	runs, err := database.ListScheduleRuns(ctx, schedule.ID, scheduleHistoryLimit)
+	if err != nil {
+		return fmt.Errorf("failed to list schedule runs: %w", err)
+	}
+
+	fmt.Printf("%sRun History: %s%s\n", FormatHeader(""), schedule.Name, Reset)
+	fmt.Printf("%s============%s\n", DimStyle, Reset)
+	if len(runs) == 0 {
+		fmt.Printf("%sNo recorded runs. Runs are recorded from the responses they store.%s\n", WarningStyle, Reset)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%sRUN ID\tSTARTED\tDURATION\tRESPONSES\tERRORS%s\n", LabelStyle, Reset)
+	fmt.Fprintf(w, "%s──────\t───────\t────────\t─────────\t──────%s\n", DimStyle, Reset)
+	for _, run := range runs {
+		errorCount := FormatMeta("0")
+		if run.Errors > 0 {
+			errorCount = ErrorStyle + strconv.Itoa(run.Errors) + Reset
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			FormatSecondary(run.RunID),
+			FormatMeta(run.StartedAt.Format("2006-01-02 15:04:05")),
+			FormatMeta(run.FinishedAt.Sub(run.StartedAt).Round(time.Second).String()),
+			FormatCount(run.Responses),
+			errorCount,
+		)
+	}
+	w.Flush()
+	return nil
+}
+
+func runScheduleSimulate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	at := time.Now().UTC()
+	if scheduleSimulateAt != "" {
+		parsed, err := parseSimulateTime(scheduleSimulateAt)
+		if err != nil {
+			return err
+		}
+		at = parsed
+	}
+
+	schedule, err := database.GetSchedule(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get schedule: %w", err)
+	}
+	simulation, err := services.SimulateSchedule(ctx, database, schedule, at)
+	if err != nil {
+		return fmt.Errorf("failed to simulate schedule: %w", err)
+	}
+
+	fmt.Printf("%sSimulation: %s%s\n", FormatHeader(""), schedule.Name, Reset)
+	fmt.Printf("%s===========%s\n", DimStyle, Reset)
+	fmt.Printf("%sCron: %s\n", LabelStyle, FormatValue(schedule.CronExpr))
+	fmt.Printf("%sAt: %s\n", LabelStyle, FormatValue(simulation.At.Format("2006-01-02 15:04:05 UTC")))
+	if simulation.Fires {
+		fmt.Printf("%s✅ Fires at this time%s\n", SuccessStyle, Reset)
+	} else {
+		fmt.Printf("%s⏸️  Does not fire at this time%s\n", WarningStyle, Reset)
+	}
+	fmt.Printf("%sNext fire: %s\n", LabelStyle, FormatValue(simulation.NextFire.Format("2006-01-02 15:04:05 UTC")))
+
+	for _, warning := range simulation.Warnings {
+		fmt.Printf("%s⚠️  %s%s\n", WarningStyle, warning, Reset)
+	}
+
+	fmt.Printf("\n%sPlanned executions (%s):%s\n", SuccessStyle, FormatCount(len(simulation.Executions)), Reset)
+	if len(simulation.Executions) == 0 {
+		fmt.Printf("  %sNone: the run would make no provider call.%s\n", WarningStyle, Reset)
+		return nil
+	}
+	for i, execution := range simulation.Executions {
+		fmt.Printf("  %s%d.%s %s %s\n", CountStyle, i+1, Reset, FormatValue(execution.LLM.Name), FormatSecondary(services.Excerpt(execution.Prompt.Template, 70)))
+	}
+	if simulation.Sampled {
+		fmt.Printf("%sOnly %d prompt(s) are drawn by weight at run time.%s\n", InfoStyle, schedule.SampleCount, Reset)
+	}
+	if simulation.Shuffled {
+		fmt.Printf("%sThe order is shuffled at run time.%s\n", InfoStyle, Reset)
+	}
+	return nil
+}
+
+// parseSimulateTime parses "YYYY-MM-DD HH:MM[:SS]" in UTC, the timezone of cron expressions, or RFC3339
+func parseSimulateTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --at %q: expected \"YYYY-MM-DD HH:MM[:SS]\" or RFC3339", value)
+}
+
 func runScheduleEnable(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	id := args[0]
 
 	schedule, err := database.GetSchedule(ctx, id)
@@ -409,7 +742,7 @@ func runScheduleEnable(cmd *cobra.Command, args []string) error {
 }
 
 func runScheduleDisable(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	id := args[0]
 
 	schedule, err := database.GetSchedule(ctx, id)
@@ -428,7 +761,7 @@ func runScheduleDisable(cmd *cobra.Command, args []string) error {
 }
 
 func runScheduleRun(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	id := args[0]
 
 	if err := initializeLLMProviders(ctx); err != nil {
@@ -444,3 +777,72 @@ func runScheduleRun(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%s✅ Schedule execution completed!%s\n", SuccessStyle, Reset)
 	return nil
 }
+
+// promptScheduleCron asks for a schedule frequency, echoing the resulting cron expression and
+// next fire times until the user confirms one
+func promptScheduleCron(reader *bufio.Reader) (string, error) {
+	for {
+		fmt.Printf("\n%sSchedule Frequency:%s\n", LabelStyle, Reset)
+		fmt.Printf("  %s1. Every day%s\n", CountStyle, Reset)
+		fmt.Printf("  %s2. Every week%s\n", CountStyle, Reset)
+		fmt.Printf("  %s3. Every month%s\n", CountStyle, Reset)
+		fmt.Printf("  %s4. Custom (cron expression or phrase)%s\n", CountStyle, Reset)
+
+		cronChoice, err := promptWithRetry(reader, fmt.Sprintf("\n%sSelect frequency (1-4): %s", LabelStyle, Reset), func(input string) (string, error) {
+			switch input {
+			case "1", "2", "3", "4":
+				return input, nil
+			default:
+				return "", fmt.Errorf("invalid choice: %s (choose 1-4)", input)
+			}
+		})
+		if err != nil {
+			return "", err
+		}
+
+		var cronExpr string
+		switch cronChoice {
+		case "1":
+			cronExpr = "0 9 * * *"
+			fmt.Printf("%sSelected: Every day%s\n", SuccessStyle, Reset)
+		case "2":
+			cronExpr = "0 9 * * MON"
+			fmt.Printf("%sSelected: Every week%s\n", SuccessStyle, Reset)
+		case "3":
+			cronExpr = "0 9 1 * *"
+			fmt.Printf("%sSelected: Every month%s\n", SuccessStyle, Reset)
+		case "4":
+			fmt.Printf("\n%sCron Expression Examples:%s\n", LabelStyle, Reset)
+			for _, example := range services.CronExpressionExamples {
+				fmt.Printf("  %s%-14s%s - %s\n", FormatSecondary(""), example.Expr, Reset, example.Description)
+			}
+			fmt.Printf("\n%sOr describe it, e.g.:%s\n", LabelStyle, Reset)
+			for _, example := range services.ScheduleDescriptorExamples {
+				fmt.Printf("  %s\n", FormatSecondary(example))
+			}
+			customCron, err := promptWithRetry(reader, fmt.Sprintf("\n%sEnter cron expression or schedule: %s", LabelStyle, Reset), services.ParseScheduleDescriptor)
+			if err != nil {
+				return "", err
+			}
+			cronExpr = customCron
+		}
+
+		nextRuns, err := services.NextRuns(cronExpr, time.Now().UTC(), 3)
+		if err != nil {
+			return "", err
+		}
+		fmt.Printf("\n%sCron: %s\n", LabelStyle, FormatSecondary(cronExpr))
+		fmt.Printf("%sNext runs (UTC):%s\n", LabelStyle, Reset)
+		for _, run := range nextRuns {
+			fmt.Printf("  %s\n", FormatValue(run.Format("Mon 2006-01-02 15:04")))
+		}
+
+		confirmed, err := promptYesNo(reader, fmt.Sprintf("\n%sUse this schedule? (y/N): %s", LabelStyle, Reset))
+		if err != nil {
+			return "", err
+		}
+		if confirmed {
+			return cronExpr, nil
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+var (
+	scheduleExportOutput string
+	scheduleApplyYes     bool
+)
+
+var scheduleExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export schedules as a declarative YAML manifest",
+	Long: `Write every schedule as a YAML manifest that can be reviewed in pull requests and applied
+with 'gego schedule apply'. Prompts are selected by template hash and LLMs by provider and model,
+so the manifest does not depend on database IDs.
+
+Examples:
+  gego schedule export --output schedules.yaml
+  gego schedule export > schedules.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runScheduleExport,
+}
+
+var scheduleApplyCmd = &cobra.Command{
+	Use:   "apply [file]",
+	Short: "Make the schedules match a YAML manifest",
+	Long: `Compare a schedule manifest with the stored schedules and print the plan: schedules to create,
+schedules to update with their changed fields, and schedules missing from the manifest, which are
+disabled rather than deleted. Schedules are matched by name. Nothing changes without --yes.
+
+Prompt selectors pick prompts by tag or by template hash (a prefix of at least 8 characters), LLM
+selectors by provider and optionally model; they are resolved to IDs when the plan is made.
+
+Examples:
+  gego schedule apply schedules.yaml
+  gego schedule apply schedules.yaml --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runScheduleApply,
+}
+
+func init() {
+	scheduleCmd.AddCommand(scheduleExportCmd)
+	scheduleCmd.AddCommand(scheduleApplyCmd)
+
+	scheduleExportCmd.Flags().StringVarP(&scheduleExportOutput, "output", "o", "", "File the manifest is written to (default: stdout)")
+	scheduleApplyCmd.Flags().BoolVarP(&scheduleApplyYes, "yes", "y", false, "Apply the plan instead of only printing it")
+}
+
+func runScheduleExport(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+
+	manifest, err := services.NewScheduleService(database).ExportManifest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to export schedules: %w", err)
+	}
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if scheduleExportOutput == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := os.WriteFile(scheduleExportOutput, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	fmt.Printf("%s✅ Exported %s schedule(s) to %s%s\n", SuccessStyle, FormatCount(len(manifest.Schedules)), scheduleExportOutput, Reset)
+	return nil
+}
+
+func runScheduleApply(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+	manifest, err := services.ParseScheduleManifest(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+
+	scheduleService := services.NewScheduleService(database)
+	plan, err := scheduleService.PlanManifest(ctx, manifest)
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+
+	fmt.Printf("%s📋 Schedule Plan%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s===============%s\n", DimStyle, Reset)
+	fmt.Println()
+
+	counts := make(map[string]int)
+	for _, change := range plan.Changes {
+		counts[change.Action]++
+		switch change.Action {
+		case services.PlanCreate:
+			fmt.Printf("  %s+ create%s %s %s\n", SuccessStyle, Reset, FormatValue(change.Schedule.Name), FormatMeta("("+change.Schedule.CronExpr+")"))
+		case services.PlanUpdate:
+			fmt.Printf("  %s~ update%s %s %s\n", WarningStyle, Reset, FormatValue(change.Schedule.Name), FormatSecondary(change.Schedule.ID))
+		case services.PlanDisable:
+			fmt.Printf("  %s- disable%s %s %s\n", ErrorStyle, Reset, FormatValue(change.Schedule.Name), FormatSecondary(change.Schedule.ID))
+		}
+		for _, line := range change.Diff {
+			fmt.Printf("      %s%s%s\n", DimStyle, line, Reset)
+		}
+	}
+	for _, name := range plan.Unchanged {
+		fmt.Printf("  %s= unchanged %s%s\n", DimStyle, name, Reset)
+	}
+	fmt.Println()
+	fmt.Printf("%sPlan: %d to create, %d to update, %d to disable.%s\n", InfoStyle,
+		counts[services.PlanCreate], counts[services.PlanUpdate], counts[services.PlanDisable], Reset)
+
+	if len(plan.Changes) == 0 {
+		fmt.Printf("%s✅ Schedules already match %s%s\n", SuccessStyle, args[0], Reset)
+		return nil
+	}
+	if !scheduleApplyYes {
+		fmt.Printf("%s💡 Run again with --yes to apply this plan%s\n", InfoStyle, Reset)
+		return nil
+	}
+
+	if err := scheduleService.ApplyPlan(ctx, plan); err != nil {
+		return err
+	}
+	fmt.Printf("%s✅ Applied %s change(s)%s\n", SuccessStyle, FormatCount(len(plan.Changes)), Reset)
+	fmt.Printf("\n%sRestart the scheduler to apply changes: %s%s\n", InfoStyle, FormatSecondary("gego scheduler start"), Reset)
+	return nil
+}
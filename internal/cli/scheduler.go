@@ -1,15 +1,15 @@
 package cli
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 
 	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/services"
 )
 
+var schedulerStatusVerbose bool
+
 var schedulerCmd = &cobra.Command{
 	Use:   "scheduler",
 	Short: "Manage the scheduler",
@@ -22,12 +22,25 @@ var schedulerStartCmd = &cobra.Command{
 	RunE:  runSchedulerStart,
 }
 
+var schedulerStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check that enabled schedules can run",
+	Long: `Check that the prompts and LLMs referenced by each enabled schedule exist, and that each
+schedule has at least one enabled prompt and one enabled LLM. The scheduler runs the same check
+when it registers schedules and before each run. Use --verbose to list the warnings.`,
+	Args: cobra.NoArgs,
+	RunE: runSchedulerStatus,
+}
+
 func init() {
 	schedulerCmd.AddCommand(schedulerStartCmd)
+	schedulerCmd.AddCommand(schedulerStatusCmd)
+
+	schedulerStatusCmd.Flags().BoolVarP(&schedulerStatusVerbose, "verbose", "v", false, "List the validation warnings of each schedule")
 }
 
 func runSchedulerStart(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	fmt.Printf("%s🚀 Start Scheduler%s\n", FormatHeader(""), Reset)
 	fmt.Printf("%s================%s\n", DimStyle, Reset)
@@ -60,18 +73,73 @@ func runSchedulerStart(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("%s✅ All schedules started successfully%s\n", SuccessStyle, Reset)
+	for _, status := range sched.ScheduleStatuses() {
+		if len(status.ValidationWarnings) > 0 {
+			printScheduleStatus(status, true)
+		}
+	}
 	fmt.Printf("%s📅 Running %s schedule(s)%s\n", InfoStyle, FormatCount(len(schedules)), Reset)
 	fmt.Printf("%s🔄 Scheduler is now monitoring schedules%s\n", InfoStyle, Reset)
 	fmt.Printf("%s📝 Press Ctrl+C to stop the scheduler%s\n", InfoStyle, Reset)
 	fmt.Println()
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-
-	<-c
+	<-ctx.Done()
 	fmt.Printf("\n%s⏹️  Stopping scheduler...%s\n", InfoStyle, Reset)
 	sched.Stop()
 	fmt.Printf("%s✅ Scheduler stopped%s\n", SuccessStyle, Reset)
 
 	return nil
 }
+
+func runSchedulerStatus(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	schedules, err := database.ListSchedules(ctx, boolPtr(true))
+	if err != nil {
+		return fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	fmt.Printf("%s🩺 Scheduler Status%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s==================%s\n", DimStyle, Reset)
+	if len(schedules) == 0 {
+		fmt.Printf("%sNo enabled schedules found.%s\n", WarningStyle, Reset)
+		return nil
+	}
+
+	broken := 0
+	for _, schedule := range schedules {
+		status, err := services.CheckSchedule(ctx, database, schedule)
+		if err != nil {
+			return fmt.Errorf("failed to check schedule %s: %w", schedule.Name, err)
+		}
+		if !status.Executable {
+			broken++
+		}
+		printScheduleStatus(status, schedulerStatusVerbose)
+	}
+
+	fmt.Println()
+	if broken > 0 {
+		fmt.Printf("%s🚨 %d of %d enabled schedule(s) cannot run%s\n", ErrorStyle, broken, len(schedules), Reset)
+		return nil
+	}
+	fmt.Printf("%s✅ All %d enabled schedule(s) can run%s\n", SuccessStyle, len(schedules), Reset)
+	return nil
+}
+
+// printScheduleStatus prints whether a schedule can run and, when verbose, its validation warnings
+func printScheduleStatus(status services.ScheduleStatus, verbose bool) {
+	switch {
+	case !status.Executable:
+		fmt.Printf("%s🚨 %s%s %s\n", ErrorStyle, status.Name, Reset, FormatMeta("("+status.ScheduleID+"): cannot run"))
+	case len(status.ValidationWarnings) > 0:
+		fmt.Printf("%s⚠️  %s%s %s\n", WarningStyle, status.Name, Reset, FormatMeta(fmt.Sprintf("(%s): %d warning(s)", status.ScheduleID, len(status.ValidationWarnings))))
+	default:
+		fmt.Printf("%s✅ %s%s %s\n", SuccessStyle, status.Name, Reset, FormatMeta("("+status.ScheduleID+")"))
+	}
+	if verbose {
+		for _, warning := range status.ValidationWarnings {
+			fmt.Printf("     %s• %s%s\n", DimStyle, warning, Reset)
+		}
+	}
+}
@@ -1,15 +1,17 @@
 package cli
 
 import (
-	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/AI2HU/gego/internal/i18n"
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
 	"github.com/AI2HU/gego/internal/shared"
 )
 
@@ -17,6 +19,16 @@ var (
 	searchKeyword       string
 	searchLimit         int
 	searchCaseSensitive bool
+	searchRange         timeRangeFlags
+	searchMinLength     int
+	searchSort          string
+	searchIncludeErrors bool
+)
+
+// Search result orders
+const (
+	searchSortMentions = "mentions" // Responses with the most keyword occurrences first, then newest
+	searchSortRecent   = "recent"   // Newest responses first
 )
 
 var searchCmd = &cobra.Command{
@@ -30,24 +42,49 @@ var searchCmd = &cobra.Command{
 func init() {
 	searchCmd.Flags().IntVarP(&searchLimit, "limit", "l", 50, "Maximum number of results to display")
 	searchCmd.Flags().BoolVarP(&searchCaseSensitive, "case-sensitive", "c", false, "Make search case-sensitive")
+	addTimeRangeFlags(searchCmd, &searchRange)
+	searchCmd.Flags().IntVar(&searchMinLength, "min-length", 0, "Skip responses shorter than this many characters, such as refusals")
+	searchCmd.Flags().BoolVar(&searchIncludeErrors, "include-errors", false, "Also search responses that recorded a provider error")
+	searchCmd.Flags().StringVar(&searchSort, "sort", searchSortMentions, "Result order: mentions (most occurrences first) or recent")
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := shared.WithErrorResponses(cmd.Context(), searchIncludeErrors)
 	keyword := args[0]
 
+	startTime, endTime, err := searchRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+	if searchMinLength < 0 {
+		return fmt.Errorf("--min-length must not be negative")
+	}
+	if searchSort != searchSortMentions && searchSort != searchSortRecent {
+		return fmt.Errorf("invalid --sort %q: use %s or %s", searchSort, searchSortMentions, searchSortRecent)
+	}
+
 	fmt.Printf("%s🔍 Searching for keyword: \"%s\"%s\n", HeaderStyle, CountStyle+keyword+Reset, Reset)
+	fmt.Printf("%s%s %s\n", LabelStyle, i18n.T("stats.window"), FormatMeta(describeTimeRange(startTime, endTime)))
 	fmt.Println()
 
 	filter := shared.ResponseFilter{
-		Keyword: keyword,
-		Limit:   searchLimit * 10,
+		Keyword:   keyword,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Limit:     searchLimit * 10,
+		HasError:  shared.ErrorFilterFromContext(ctx),
 	}
 
 	responses, err := database.ListResponses(ctx, filter)
 	if err != nil {
 		return fmt.Errorf("failed to search responses: %w", err)
 	}
+	responses, excluded := services.DropShortResponses(responses, searchMinLength)
+	printExcludedShort(excluded, searchMinLength)
+	prompts := services.NewPromptTextResolver(database)
+	if err := prompts.Resolve(ctx, responses); err != nil {
+		return err
+	}
 
 	fmt.Printf("%s📊 Found %s responses containing \"%s\"%s\n", InfoStyle, CountStyle+fmt.Sprintf("%d", len(responses))+Reset, CountStyle+keyword+Reset, Reset)
 	fmt.Println()
@@ -64,9 +101,17 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		regex = regexp.MustCompile("(?i)" + regexp.QuoteMeta(keyword))
 	}
 
-	var matches []SearchMatch
+	matchesByResponse := make([][]SearchMatch, 0, len(responses))
 	for _, response := range responses {
-		matches = append(matches, findMatches(response, regex, keyword)...)
+		if responseMatches := findMatches(response, prompts.Template(response.PromptID), regex); len(responseMatches) > 0 {
+			matchesByResponse = append(matchesByResponse, responseMatches)
+		}
+	}
+	rankSearchMatches(matchesByResponse, searchSort)
+
+	var matches []SearchMatch
+	for _, responseMatches := range matchesByResponse {
+		matches = append(matches, responseMatches...)
 	}
 
 	if len(matches) == 0 {
@@ -125,10 +170,25 @@ type SearchMatch struct {
 	CreatedAt   time.Time
 }
 
-func findMatches(response *models.Response, regex *regexp.Regexp, keyword string) []SearchMatch {
+// rankSearchMatches orders the matches of each response by sortBy, keeping the matches of a
+// response together. Under searchSortMentions responses with more matches come first and newer
+// responses break ties; under searchSortRecent newer responses come first.
+func rankSearchMatches(matchesByResponse [][]SearchMatch, sortBy string) {
+	sort.SliceStable(matchesByResponse, func(i, j int) bool {
+		a, b := matchesByResponse[i], matchesByResponse[j]
+		if sortBy == searchSortMentions && len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return a[0].CreatedAt.After(b[0].CreatedAt)
+	})
+}
+
+func findMatches(response *models.Response, promptTemplate string, regex *regexp.Regexp) []SearchMatch {
 	var matches []SearchMatch
 
-	indices := regex.FindAllStringIndex(response.ResponseText, -1)
+	// Match on plain text so formatted mentions such as **Netflix** or [Netflix](url) read cleanly
+	text := shared.StripMarkdown(response.ResponseText)
+	indices := regex.FindAllStringIndex(text, -1)
 
 	for _, index := range indices {
 		start := index[0]
@@ -139,17 +199,17 @@ func findMatches(response *models.Response, regex *regexp.Regexp, keyword string
 			contextStart = 0
 		}
 		contextEnd := end + 100
-		if contextEnd > len(response.ResponseText) {
-			contextEnd = len(response.ResponseText)
+		if contextEnd > len(text) {
+			contextEnd = len(text)
 		}
 
-		contextText := response.ResponseText[contextStart:contextEnd]
+		contextText := text[contextStart:contextEnd]
 
-		highlightedContext := strings.ReplaceAll(contextText, keyword, FormatHighlight(keyword))
+		highlightedContext := regex.ReplaceAllStringFunc(contextText, FormatHighlight)
 
 		promptName := "Unknown Prompt"
-		if prompt, err := database.GetPrompt(context.Background(), response.PromptID); err == nil {
-			promptName = prompt.Template
+		if promptTemplate != "" {
+			promptName = promptTemplate
 		}
 
 		matches = append(matches, SearchMatch{
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRankSearchMatches(t *testing.T) {
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	// responseMatches returns mentions matches of a response created daysAgo days before base
+	responseMatches := func(id string, mentions, daysAgo int) []SearchMatch {
+		matches := make([]SearchMatch, mentions)
+		for i := range matches {
+			matches[i] = SearchMatch{ResponseID: id, CreatedAt: base.AddDate(0, 0, -daysAgo)}
+		}
+		return matches
+	}
+
+	tests := []struct {
+		name   string
+		sortBy string
+		want   []string
+	}{
+		{name: "mentions with recency tiebreaker", sortBy: searchSortMentions, want: []string{"three", "two-new", "two-old", "one-new"}},
+		{name: "recent", sortBy: searchSortRecent, want: []string{"one-new", "two-new", "three", "two-old"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matchesByResponse := [][]SearchMatch{
+				responseMatches("two-old", 2, 5),
+				responseMatches("one-new", 1, 0),
+				responseMatches("three", 3, 3),
+				responseMatches("two-new", 2, 1),
+			}
+
+			rankSearchMatches(matchesByResponse, tt.sortBy)
+
+			var got []string
+			for _, matches := range matchesByResponse {
+				for _, match := range matches[1:] {
+					if match.ResponseID != matches[0].ResponseID {
+						t.Fatalf("matches of %s split up", matches[0].ResponseID)
+					}
+				}
+				got = append(got, matches[0].ResponseID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
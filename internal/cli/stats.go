@@ -2,20 +2,46 @@ package cli
 
 import (
 	"bufio"
-	"context"
 	"fmt"
+	"io"
 	"os"
 	"sort"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/AI2HU/gego/internal/config"
+	"github.com/AI2HU/gego/internal/i18n"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
 	"github.com/AI2HU/gego/internal/shared"
 )
 
 var (
 	statsLimit   int
 	statsKeyword string
+	statsPeriod1 string
+	statsPeriod2 string
+
+	statsExcludeLabels []string
+	statsIncludeErrors bool
+
+	statsScoreDays  int
+	statsScoreGroup []string
+
+	statsRange           timeRangeFlags
+	statsMinLength       int
+	statsComparePrevious bool
+	statsCountMode       string
+
+	statsLLMsSchedule string
+
+	statsResetSchedule string
+	statsResetLLM      string
+	statsResetPrompt   string
+	statsResetBefore   string
 )
 
 var statsCmd = &cobra.Command{
@@ -39,10 +65,18 @@ var statsKeywordCmd = &cobra.Command{
 
 var statsResetCmd = &cobra.Command{
 	Use:   "reset",
-	Short: "Reset all statistics by clearing all responses",
-	Long:  `Reset all statistics by deleting all responses from the database. This will clear all keyword statistics, prompt statistics, and LLM statistics. Prompts and LLMs will remain intact.`,
-	Args:  cobra.NoArgs,
-	RunE:  runStatsReset,
+	Short: "Reset statistics by clearing responses",
+	Long: `Reset statistics by deleting responses from the database. Without flags all responses
+are deleted, clearing all keyword, prompt and LLM statistics. Use the scope flags to only
+delete the responses of one schedule, LLM or prompt, or those created before a date.
+Prompts, LLMs and schedules remain intact.
+
+Examples:
+  gego stats reset
+  gego stats reset --schedule <schedule-id>
+  gego stats reset --llm <llm-id> --before 2025-01-01`,
+	Args: cobra.NoArgs,
+	RunE: runStatsReset,
 }
 
 var statsRefreshCmd = &cobra.Command{
@@ -53,26 +87,134 @@ var statsRefreshCmd = &cobra.Command{
 	RunE:  runStatsRefresh,
 }
 
+var statsCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare keyword mentions between two time periods",
+	Long: `Compare top keywords and share of voice between two time periods.
+
+Periods are given as START..END dates (YYYY-MM-DD, END inclusive).
+By default the last 7 days are compared with the 7 days before them.
+
+Examples:
+  gego stats compare
+  gego stats compare --period1 2025-01-01..2025-01-07 --period2 2025-01-08..2025-01-14`,
+	Args: cobra.NoArgs,
+	RunE: runStatsCompare,
+}
+
+var statsScoreCmd = &cobra.Command{
+	Use:   "score <keyword>",
+	Short: "Compute the GEO score of a keyword",
+	Long: `Compute a single 0-100 visibility score for a keyword over the last --days days,
+combining its mention rate, average list position, share of voice against a keyword group
+and coverage across enabled LLMs. Weights, the keyword group and the minimum number of
+responses are configured under geo_score in config.yaml.
+
+Examples:
+  gego stats score Acme
+  gego stats score Acme --days 7 --group Globex,Initech`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStatsScore,
+}
+
+var statsDomainsCmd = &cobra.Command{
+	Use:   "domains",
+	Short: "View the domains cited most in responses",
+	Long: `Rank the domains of URLs and bare domains (such as example.com) cited in responses by the
+number of responses citing them. With --keyword only responses mentioning the keyword are counted.
+
+Responses stored before domain extraction are included after 'gego migrate extract-domains'.
+
+Examples:
+  gego stats domains
+  gego stats domains --keyword Netflix --days 30
+  gego stats domains --from 2025-01-01 --to 2025-01-31`,
+	Args: cobra.NoArgs,
+	RunE: runStatsDomains,
+}
+
+var statsLLMsCmd = &cobra.Command{
+	Use:   "llms",
+	Short: "View provider latency and queue wait per LLM",
+	Long: `Show how long each LLM's responses took, split into the provider call itself and the
+time spent queued behind the provider rate limiter. A long queue wait with a short latency
+means the rate limit, not the provider, is slowing schedules down.
+
+Examples:
+  gego stats llms
+  gego stats llms --schedule <schedule-id>`,
+	Args: cobra.NoArgs,
+	RunE: runStatsLLMs,
+}
+
 func init() {
 	statsCmd.AddCommand(statsKeywordsCmd)
 	statsCmd.AddCommand(statsKeywordCmd)
 	statsCmd.AddCommand(statsResetCmd)
 	statsCmd.AddCommand(statsRefreshCmd)
+	statsCmd.AddCommand(statsCompareCmd)
+	statsCmd.AddCommand(statsScoreCmd)
+	statsCmd.AddCommand(statsDomainsCmd)
+	statsCmd.AddCommand(statsLLMsCmd)
 
 	statsCmd.PersistentFlags().IntVarP(&statsLimit, "limit", "l", 10, "Limit number of results")
+	for _, cmd := range []*cobra.Command{statsKeywordsCmd, statsKeywordCmd, statsCompareCmd, statsScoreCmd, statsDomainsCmd} {
+		cmd.Flags().StringSliceVar(&statsExcludeLabels, "exclude-label", nil, "Skip responses annotated with these labels (e.g. irrelevant)")
+		cmd.Flags().BoolVar(&statsIncludeErrors, "include-errors", false, "Count responses that recorded a provider error, which are skipped by default")
+	}
 	statsKeywordCmd.Flags().StringVarP(&statsKeyword, "keyword", "k", "", "Keyword name")
+	statsKeywordCmd.Flags().BoolVar(&statsComparePrevious, "compare-previous", false, "Show changes against the window of the same length before --days or --from")
+	statsCompareCmd.Flags().StringVar(&statsPeriod1, "period1", "", "Baseline period as START..END (default: the 7 days before period2)")
+	statsResetCmd.Flags().StringVar(&statsResetSchedule, "schedule", "", "Only delete responses from this schedule ID")
+	statsResetCmd.Flags().StringVar(&statsResetLLM, "llm", "", "Only delete responses from this LLM ID")
+	statsResetCmd.Flags().StringVar(&statsResetPrompt, "prompt", "", "Only delete responses to this prompt ID")
+	statsResetCmd.Flags().StringVar(&statsResetBefore, "before", "", "Only delete responses created before this date (YYYY-MM-DD)")
+	statsDomainsCmd.Flags().StringVarP(&statsKeyword, "keyword", "k", "", "Only count responses mentioning this keyword")
+	for _, cmd := range []*cobra.Command{statsKeywordsCmd, statsKeywordCmd, statsDomainsCmd} {
+		addTimeRangeFlags(cmd, &statsRange)
+	}
+	for _, cmd := range []*cobra.Command{statsKeywordsCmd, statsKeywordCmd} {
+		cmd.Flags().IntVar(&statsMinLength, "min-length", 0, "Skip responses shorter than this many characters, such as refusals")
+	}
+	for _, cmd := range []*cobra.Command{statsKeywordsCmd, statsKeywordCmd, statsCompareCmd} {
+		cmd.Flags().StringVar(&statsCountMode, "count", "occurrences", "Count every mention (occurrences) or the responses mentioning a keyword once each (responses)")
+	}
+	statsScoreCmd.Flags().IntVar(&statsScoreDays, "days", 30, "Number of days to score")
+	statsScoreCmd.Flags().StringSliceVar(&statsScoreGroup, "group", nil, "Keywords to measure share of voice against (default: geo_score.group)")
+	statsCompareCmd.Flags().StringVar(&statsPeriod2, "period2", "", "Period to compare as START..END (default: the last 7 days)")
+	statsLLMsCmd.Flags().StringVar(&statsLLMsSchedule, "schedule", "", "Only count responses from this schedule ID")
 }
 
 func runStatsKeywords(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := shared.WithErrorResponses(shared.WithExcludedLabels(shared.WithOwner(cmd.Context(), ownerFlag), statsExcludeLabels), statsIncludeErrors)
+	if statsMinLength < 0 {
+		return fmt.Errorf("--min-length must not be negative")
+	}
+	ctx, minLength := shared.WithMinResponseLength(ctx, statsMinLength)
+	countMode, err := shared.ParseCountMode(statsCountMode)
+	if err != nil {
+		return err
+	}
+	ctx = shared.WithCountMode(ctx, countMode)
+
+	startTime, endTime, err := statsRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
 
-	keywords, err := database.GetTopKeywords(ctx, statsLimit, nil, nil)
+	keywords, err := database.GetTopKeywords(ctx, statsLimit, startTime, endTime)
 	if err != nil {
 		return fmt.Errorf("failed to get top keywords: %w", err)
 	}
 
+	fmt.Printf("%s%s%s\n", HeaderStyle, i18n.T("stats.top_keywords_header"), Reset)
+	fmt.Printf("%s===========================%s\n", DimStyle, Reset)
+	fmt.Printf("%s%s %s\n", LabelStyle, i18n.T("stats.window"), FormatMeta(describeTimeRange(startTime, endTime)))
+	printExcludedShort(minLength.Excluded(), statsMinLength)
+	fmt.Println()
+
 	if len(keywords) == 0 {
-		fmt.Printf("%sNo keyword statistics available yet. Run some schedules first!%s\n", WarningStyle, Reset)
+		fmt.Printf("%s%s%s\n", WarningStyle, i18n.T("stats.no_keywords"), Reset)
 		return nil
 	}
 
@@ -81,12 +223,12 @@ func runStatsKeywords(cmd *cobra.Command, args []string) error {
 		totalMentions += keyword.Count
 	}
 
-	fmt.Printf("%s📊 Top Keywords by Mentions%s\n", HeaderStyle, Reset)
-	fmt.Printf("%s===========================%s\n", DimStyle, Reset)
-	fmt.Println()
-
 	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "%sRANK\tKEYWORD\tMENTIONS%s\n", LabelStyle, Reset)
+	columns := i18n.T("stats.top_keywords_columns")
+	if countMode == shared.CountModeResponses {
+		columns = i18n.T("stats.top_keywords_columns_responses")
+	}
+	fmt.Fprintf(w, "%s%s%s\n", LabelStyle, columns, Reset)
 	fmt.Fprintf(w, "%s────\t───────\t────────%s\n", DimStyle, Reset)
 
 	for i, keyword := range keywords {
@@ -103,26 +245,79 @@ func runStatsKeywords(cmd *cobra.Command, args []string) error {
 }
 
 func runStatsKeyword(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := shared.WithErrorResponses(shared.WithExcludedLabels(shared.WithOwner(cmd.Context(), ownerFlag), statsExcludeLabels), statsIncludeErrors)
+	if statsMinLength < 0 {
+		return fmt.Errorf("--min-length must not be negative")
+	}
+	ctx, _ = shared.WithMinResponseLength(ctx, statsMinLength)
+	countMode, err := shared.ParseCountMode(statsCountMode)
+	if err != nil {
+		return err
+	}
+	ctx = shared.WithCountMode(ctx, countMode)
 	keywordName := args[0]
 
-	stats, err := database.SearchKeyword(ctx, keywordName, nil, nil)
+	searchOpts, err := keywordSearchOptions(cfg)
+	if err != nil {
+		return err
+	}
+	startTime, endTime, err := statsRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+	if statsComparePrevious && startTime == nil {
+		return fmt.Errorf("--compare-previous requires --days or --from")
+	}
+	stats, err := statsService.SearchKeyword(ctx, keywordName, startTime, endTime, searchOpts)
 	if err != nil {
 		return fmt.Errorf("failed to get keyword stats: %w", err)
 	}
 
-	fmt.Printf("%s📊 Keyword Statistics: %s%s\n", HeaderStyle, CountStyle+keywordName+Reset, Reset)
+	var previous *models.KeywordPeriodChange
+	if statsComparePrevious {
+		end := time.Now()
+		if endTime != nil {
+			end = *endTime
+		}
+		previousStart, previousEnd := services.PreviousWindow(*startTime, end)
+		previousStats, err := statsService.SearchKeyword(ctx, keywordName, &previousStart, &previousEnd, searchOpts)
+		if err != nil {
+			return fmt.Errorf("failed to get keyword stats of the previous window: %w", err)
+		}
+		previous = services.CompareKeywordStats(stats, previousStats, previousStart, previousEnd)
+	}
+
+	fmt.Printf("%s%s%s\n", HeaderStyle, i18n.T("stats.keyword_header", CountStyle+keywordName+Reset), Reset)
 	fmt.Printf("%s========================%s\n", DimStyle, Reset)
+	fmt.Printf("%s%s %s\n", LabelStyle, i18n.T("stats.window"), FormatMeta(describeTimeRange(startTime, endTime)))
+	var compared models.KeywordPeriodChange
+	if previous != nil {
+		compared = *previous
+		fmt.Printf("%s%s %s\n", LabelStyle, i18n.T("stats.previous_window"), FormatMeta(describeTimeRange(&previous.Start, &previous.End)))
+	}
 	fmt.Println()
 
-	fmt.Printf("%sTotal Mentions: %s\n", LabelStyle, FormatCount(stats.TotalMentions))
-	fmt.Printf("%sUnique Prompts: %s\n", LabelStyle, FormatCount(stats.UniquePrompts))
-	fmt.Printf("%sUnique LLMs: %s\n", LabelStyle, FormatCount(stats.UniqueLLMs))
-	fmt.Printf("%sFirst Seen: %s\n", LabelStyle, FormatMeta(stats.FirstSeen.Format("2006-01-02 15:04:05")))
-	fmt.Printf("%sLast Seen: %s\n", LabelStyle, FormatMeta(stats.LastSeen.Format("2006-01-02 15:04:05")))
+	totalLabel := i18n.T("stats.total_mentions")
+	if countMode == shared.CountModeResponses {
+		totalLabel = i18n.T("stats.total_responses_mentioning")
+	}
+	fmt.Printf("%s%s %s%s\n", LabelStyle, totalLabel, FormatCount(stats.TotalMentions), formatCountChange(previous != nil, compared.TotalMentions))
+	fmt.Printf("%s%s %s%s\n", LabelStyle, i18n.T("stats.unique_prompts"), FormatCount(stats.UniquePrompts), formatCountChange(previous != nil, compared.UniquePrompts))
+	fmt.Printf("%s%s %s%s\n", LabelStyle, i18n.T("stats.unique_llms"), FormatCount(stats.UniqueLLMs), formatCountChange(previous != nil, compared.UniqueLLMs))
+	fmt.Printf("%s%s %s\n", LabelStyle, i18n.T("stats.first_seen"), FormatMeta(stats.FirstSeen.Format("2006-01-02 15:04:05")))
+	fmt.Printf("%s%s %s\n", LabelStyle, i18n.T("stats.last_seen"), FormatMeta(stats.LastSeen.Format("2006-01-02 15:04:05")))
+	if stats.Truncated || (previous != nil && previous.Truncated) {
+		fmt.Printf("%s⚠️  %s%s\n", WarningStyle, i18n.T("stats.truncated", stats.Scanned), Reset)
+	}
+	printExcludedShort(stats.ExcludedShort, statsMinLength)
+	if analysis := stats.Analysis; analysis != nil {
+		fmt.Printf("%s%s%s\n", InfoStyle, i18n.T("stats.analysis",
+			analysis.BySentiment[models.SentimentPositive], analysis.BySentiment[models.SentimentNeutral],
+			analysis.BySentiment[models.SentimentNegative], analysis.AvgStrength, analysis.Responses), Reset)
+	}
 	fmt.Println()
 
-	fmt.Printf("%sTop Prompts:%s\n", SuccessStyle, Reset)
+	fmt.Printf("%s%s%s\n", SuccessStyle, i18n.T("stats.top_prompts"), Reset)
 	fmt.Printf("%s────────────%s\n", DimStyle, Reset)
 	type kv struct {
 		Key   string
@@ -136,13 +331,22 @@ func runStatsKeyword(cmd *cobra.Command, args []string) error {
 		return promptList[i].Value > promptList[j].Value
 	})
 
+	if len(promptList) > statsLimit {
+		promptList = promptList[:statsLimit]
+	}
+	promptIDs := make([]string, len(promptList))
 	for i, item := range promptList {
-		if i >= statsLimit {
-			break
-		}
-		prompt, err := database.GetPrompt(ctx, item.Key)
+		promptIDs[i] = item.Key
+	}
+	prompts, err := database.GetPromptsByIDs(ctx, promptIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get prompts: %w", err)
+	}
+
+	for i, item := range promptList {
+		prompt, ok := prompts[item.Key]
 		displayText := item.Key
-		if err == nil {
+		if ok {
 			displayText = prompt.Template
 			if len(displayText) > 80 {
 				start := displayText[:35]
@@ -154,12 +358,12 @@ func runStatsKeyword(cmd *cobra.Command, args []string) error {
 		}
 		percentage := float64(item.Value) / float64(stats.TotalMentions) * 100
 		fmt.Printf("  %s%d. %s%s\n", CountStyle, i+1, Reset, FormatValue(displayText))
-		fmt.Printf("     %s%d mentions (%.1f%%)%s\n", DimStyle, item.Value, percentage, Reset)
+		fmt.Printf("     %s%s%s\n", DimStyle, i18n.T("stats.mentions_share", item.Value, percentage), Reset)
 	}
 
 	fmt.Println()
 
-	fmt.Printf("%sTop LLMs:%s\n", SuccessStyle, Reset)
+	fmt.Printf("%s%s%s\n", SuccessStyle, i18n.T("stats.top_llms"), Reset)
 	fmt.Printf("%s─────────%s\n", DimStyle, Reset)
 	var llmList []kv
 	for k, v := range stats.ByLLM {
@@ -169,25 +373,25 @@ func runStatsKeyword(cmd *cobra.Command, args []string) error {
 		return llmList[i].Value > llmList[j].Value
 	})
 
+	if len(llmList) > statsLimit {
+		llmList = llmList[:statsLimit]
+	}
 	for i, item := range llmList {
-		if i >= statsLimit {
-			break
-		}
-		llm, err := database.GetLLM(ctx, item.Key)
+		snapshot, ok := stats.LLMs[item.Key]
 		displayText := item.Key
-		if err == nil {
-			displayText = fmt.Sprintf("%s (%s)", llm.Model, llm.Provider)
+		if ok {
+			displayText = snapshot.Label()
 		} else {
 			displayText = fmt.Sprintf("[Deleted LLM: %s]", item.Key[:8])
 		}
 		percentage := float64(item.Value) / float64(stats.TotalMentions) * 100
 		fmt.Printf("  %s%d. %s%s\n", CountStyle, i+1, Reset, FormatValue(displayText))
-		fmt.Printf("     %s%d mentions (%.1f%%)%s\n", DimStyle, item.Value, percentage, Reset)
+		fmt.Printf("     %s%s%s\n", DimStyle, i18n.T("stats.mentions_share", item.Value, percentage), Reset)
 	}
 
 	fmt.Println()
 
-	fmt.Printf("%sBy Provider:%s\n", SuccessStyle, Reset)
+	fmt.Printf("%s%s%s\n", SuccessStyle, i18n.T("stats.by_provider"), Reset)
 	fmt.Printf("%s────────────%s\n", DimStyle, Reset)
 	var providerList []kv
 	for k, v := range stats.ByProvider {
@@ -199,7 +403,7 @@ func runStatsKeyword(cmd *cobra.Command, args []string) error {
 
 	for i, item := range providerList {
 		percentage := float64(item.Value) / float64(stats.TotalMentions) * 100
-		fmt.Printf("  %s: %s mentions (%.1f%%)%s\n", FormatValue(item.Key), CountStyle+fmt.Sprintf(" %d", item.Value)+Reset, percentage, Reset)
+		fmt.Printf("  %s: %s%s%s%s\n", FormatValue(item.Key), CountStyle, i18n.T("stats.mentions_share", item.Value, percentage), Reset, formatCountChange(previous != nil, compared.ByProvider[item.Key]))
 		if i >= 10 {
 			break
 		}
@@ -209,26 +413,70 @@ func runStatsKeyword(cmd *cobra.Command, args []string) error {
 }
 
 func runStatsReset(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
 	reader := bufio.NewReader(os.Stdin)
 
-	fmt.Printf("%s🔄 Reset All Statistics%s\n", FormatHeader(""), Reset)
-	fmt.Printf("%s========================%s\n", DimStyle, Reset)
+	filter, err := services.ResetFilter(statsResetSchedule, statsResetLLM, statsResetPrompt, statsResetBefore)
+	if err != nil {
+		return err
+	}
+	scoped := services.IsScopedReset(filter)
+
+	if scoped {
+		fmt.Printf("%s🔄 Reset Statistics%s\n", FormatHeader(""), Reset)
+		fmt.Printf("%s==================%s\n", DimStyle, Reset)
+	} else {
+		fmt.Printf("%s🔄 Reset All Statistics%s\n", FormatHeader(""), Reset)
+		fmt.Printf("%s========================%s\n", DimStyle, Reset)
+	}
 	fmt.Println()
 
-	fmt.Printf("%s⚠️  Warning: This will permanently delete ALL responses from the database.%s\n", WarningStyle, Reset)
-	fmt.Printf("%sThis action will:%s\n", LabelStyle, Reset)
-	fmt.Printf("  %s• Clear all keyword statistics%s\n", DimStyle, Reset)
-	fmt.Printf("  %s• Clear all prompt statistics%s\n", DimStyle, Reset)
-	fmt.Printf("  %s• Clear all LLM statistics%s\n", DimStyle, Reset)
-	fmt.Printf("  %s• Delete all response data%s\n", DimStyle, Reset)
-	fmt.Printf("  %s• Keep prompts and LLMs intact%s\n", DimStyle, Reset)
+	if scoped {
+		fmt.Printf("%s⚠️  Warning: This will permanently delete the responses matching:%s\n", WarningStyle, Reset)
+		if filter.ScheduleID != "" {
+			fmt.Printf("  %s• Schedule: %s%s\n", DimStyle, filter.ScheduleID, Reset)
+		}
+		if filter.LLMID != "" {
+			fmt.Printf("  %s• LLM: %s%s\n", DimStyle, filter.LLMID, Reset)
+		}
+		if filter.PromptID != "" {
+			fmt.Printf("  %s• Prompt: %s%s\n", DimStyle, filter.PromptID, Reset)
+		}
+		if statsResetBefore != "" {
+			fmt.Printf("  %s• Created before: %s%s\n", DimStyle, statsResetBefore, Reset)
+		}
+		fmt.Printf("%sStatistics will be recomputed from the remaining responses. Prompts, LLMs and schedules remain intact.%s\n", LabelStyle, Reset)
+	} else {
+		fmt.Printf("%s⚠️  Warning: This will permanently delete ALL responses from the database.%s\n", WarningStyle, Reset)
+		fmt.Printf("%sThis action will:%s\n", LabelStyle, Reset)
+		fmt.Printf("  %s• Clear all keyword statistics%s\n", DimStyle, Reset)
+		fmt.Printf("  %s• Clear all prompt statistics%s\n", DimStyle, Reset)
+		fmt.Printf("  %s• Clear all LLM statistics%s\n", DimStyle, Reset)
+		fmt.Printf("  %s• Delete all response data%s\n", DimStyle, Reset)
+		fmt.Printf("  %s• Keep prompts and LLMs intact%s\n", DimStyle, Reset)
+	}
 	fmt.Println()
 
+	statsService := services.NewStatsService(database)
+	matched, err := statsService.CountResponses(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to check responses: %w", err)
+	}
+
+	if matched == 0 {
+		fmt.Printf("%sNo responses found to delete.%s\n", WarningStyle, Reset)
+		return nil
+	}
+
+	fmt.Printf("%sMatched responses: %s%s\n", LabelStyle, FormatCount(int(matched)), Reset)
 	fmt.Printf("%sThis action cannot be undone!%s\n", ErrorStyle, Reset)
 	fmt.Println()
 
-	confirmed, err := promptYesNo(reader, fmt.Sprintf("%sAre you sure you want to reset all statistics? (y/N): %s", ErrorStyle, Reset))
+	question := "Are you sure you want to reset all statistics? (y/N): "
+	if scoped {
+		question = fmt.Sprintf("Are you sure you want to delete these %d responses? (y/N): ", matched)
+	}
+	confirmed, err := promptYesNo(reader, fmt.Sprintf("%s%s%s", ErrorStyle, question, Reset))
 	if err != nil {
 		return err
 	}
@@ -238,25 +486,23 @@ func runStatsReset(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Printf("\n%s🗑️  Clearing all responses...%s\n", InfoStyle, Reset)
-
-	responses, err := database.ListResponses(ctx, shared.ResponseFilter{Limit: 1})
-	if err != nil {
-		return fmt.Errorf("failed to check responses: %w", err)
-	}
-
-	if len(responses) == 0 {
-		fmt.Printf("%sNo responses found to delete.%s\n", WarningStyle, Reset)
-		return nil
+	if scoped {
+		fmt.Printf("\n%s🗑️  Clearing matching responses...%s\n", InfoStyle, Reset)
+	} else {
+		fmt.Printf("\n%s🗑️  Clearing all responses...%s\n", InfoStyle, Reset)
 	}
 
-	deletedCount, err := database.DeleteAllResponses(ctx)
+	deletedCount, err := statsService.ResetStats(ctx, filter)
 	if err != nil {
 		return fmt.Errorf("failed to delete responses: %w", err)
 	}
 
 	fmt.Printf("%s✅ Successfully deleted %s responses!%s\n", SuccessStyle, FormatCount(deletedCount), Reset)
-	fmt.Printf("%s🎉 All statistics have been reset.%s\n", SuccessStyle, Reset)
+	if scoped {
+		fmt.Printf("%s🎉 Statistics for the selected scope have been reset.%s\n", SuccessStyle, Reset)
+	} else {
+		fmt.Printf("%s🎉 All statistics have been reset.%s\n", SuccessStyle, Reset)
+	}
 	fmt.Printf("%sYou can now run new prompts to generate fresh statistics.%s\n", InfoStyle, Reset)
 
 	return nil
@@ -310,3 +556,331 @@ func runStatsRefresh(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runStatsDomains(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithErrorResponses(shared.WithExcludedLabels(shared.WithOwner(cmd.Context(), ownerFlag), statsExcludeLabels), statsIncludeErrors)
+
+	startTime, endTime, err := statsRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	domains, err := statsService.GetTopDomains(ctx, statsKeyword, statsLimit, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("failed to get domains: %w", err)
+	}
+
+	title := i18n.T("stats.domains_header")
+	if statsKeyword != "" {
+		title = i18n.T("stats.domains_header_keyword", statsKeyword)
+	}
+	fmt.Printf("%s%s%s\n", HeaderStyle, title, Reset)
+	fmt.Printf("%s%s%s\n", DimStyle, strings.Repeat("=", len([]rune(title))), Reset)
+	fmt.Printf("%s%s %s\n", LabelStyle, i18n.T("stats.window"), FormatMeta(describeTimeRange(startTime, endTime)))
+	fmt.Println()
+
+	if len(domains) == 0 {
+		fmt.Printf("%s%s%s\n", WarningStyle, i18n.T("stats.no_domains"), Reset)
+		return nil
+	}
+
+	for i, domain := range domains {
+		fmt.Printf("%s%d. %s%s %s\n", CountStyle, i+1, Reset, FormatValue(domain.Domain), FormatMeta(i18n.T("stats.domain_responses", domain.Responses)))
+	}
+	return nil
+}
+
+func runStatsLLMs(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+
+	stats, err := statsService.GetLatencyStats(ctx, statsLLMsSchedule)
+	if err != nil {
+		return fmt.Errorf("failed to get latency stats: %w", err)
+	}
+
+	fmt.Printf("%s%s%s\n", HeaderStyle, i18n.T("stats.latency_header"), Reset)
+	fmt.Printf("%s=============================%s\n", DimStyle, Reset)
+	fmt.Println()
+
+	if len(stats) == 0 {
+		fmt.Printf("%s%s%s\n", WarningStyle, i18n.T("stats.no_responses"), Reset)
+		return nil
+	}
+
+	printLatencyStats(cmd.OutOrStdout(), stats)
+	return nil
+}
+
+// printLatencyStats prints a table of the provider latency and queue wait of each LLM
+func printLatencyStats(out io.Writer, stats []*services.LatencyStats) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s%s%s\n", LabelStyle, i18n.T("stats.latency_columns"), Reset)
+	fmt.Fprintf(w, "%s───\t────────\t─────\t───────────\t──────────────\t──────────────%s\n", DimStyle, Reset)
+
+	for _, llmStats := range stats {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n",
+			FormatValue(llmStats.LLMName),
+			FormatSecondary(llmStats.Provider),
+			llmStats.Calls,
+			formatMillis(llmStats.AvgLatencyMs),
+			formatMillis(llmStats.AvgQueueWaitMs),
+			formatMillis(float64(llmStats.MaxQueueWaitMs)),
+		)
+	}
+
+	w.Flush()
+}
+
+// formatMillis formats a duration in milliseconds for display, e.g. 850ms or 1m12.4s
+func formatMillis(ms float64) string {
+	d := time.Duration(ms * float64(time.Millisecond))
+	if d >= time.Second {
+		return d.Round(100 * time.Millisecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+func runStatsScore(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithErrorResponses(shared.WithExcludedLabels(shared.WithOwner(cmd.Context(), ownerFlag), statsExcludeLabels), statsIncludeErrors)
+
+	if statsScoreDays < 1 {
+		return fmt.Errorf("--days must be a positive integer")
+	}
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -statsScoreDays)
+
+	score, err := statsService.GetGEOScore(ctx, args[0], statsScoreGroup, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to compute keyword score: %w", err)
+	}
+
+	fmt.Printf("%s🎯 GEO Score: %s%s\n", HeaderStyle, CountStyle+score.Keyword+Reset, Reset)
+	fmt.Printf("%s=================%s\n", DimStyle, Reset)
+	fmt.Printf("%sPeriod: %s\n", LabelStyle, FormatMeta(fmt.Sprintf("%s to %s", start.Format("2006-01-02"), end.Format("2006-01-02"))))
+	fmt.Printf("%sResponses: %s\n", LabelStyle, FormatCount(score.Responses))
+	fmt.Println()
+
+	if score.InsufficientData {
+		fmt.Printf("%s⚠️  Insufficient data: %d responses in this period, at least %d needed.%s\n", WarningStyle, score.Responses, score.MinResponses, Reset)
+		return nil
+	}
+
+	components := score.Components
+	fmt.Printf("%sScore: %s%.1f%s / 100\n", LabelStyle, CountStyle, *score.Score, Reset)
+	fmt.Println()
+
+	fmt.Printf("%sComponents:%s\n", SuccessStyle, Reset)
+	fmt.Printf("%s───────────%s\n", DimStyle, Reset)
+	weights := components.Weights
+	fmt.Printf("  %sMention rate:   %s %s\n", LabelStyle, FormatValue(fmt.Sprintf("%.1f%%", components.MentionRate*100)),
+		FormatSecondary(fmt.Sprintf("(%d/%d responses, weight %.2f)", components.Mentions, score.Responses, weights.MentionRate)))
+	if components.GroundedMentionRate != nil {
+		fmt.Printf("    %sWith web search: %s, without: %s %s\n", DimStyle, FormatValue(fmt.Sprintf("%.1f%%", *components.GroundedMentionRate*100)),
+			FormatValue(fmt.Sprintf("%.1f%%", *components.UngroundedMentionRate*100)), FormatSecondary(fmt.Sprintf("(%d grounded responses)", components.GroundedResponses)))
+	}
+
+	if components.AvgPosition != nil {
+		fmt.Printf("  %sList position:  %s %s\n", LabelStyle, FormatValue(fmt.Sprintf("#%.1f", *components.AvgPosition)),
+			FormatSecondary(fmt.Sprintf("(score %.2f over %d listings, weight %.2f)", *components.PositionScore, components.ListPlacements, weights.Position)))
+	} else {
+		fmt.Printf("  %sList position:  %s\n", LabelStyle, FormatSecondary("n/a (never listed)"))
+	}
+
+	if components.ShareOfVoice != nil {
+		fmt.Printf("  %sShare of voice: %s %s\n", LabelStyle, FormatValue(fmt.Sprintf("%.1f%%", *components.ShareOfVoice*100)),
+			FormatSecondary(fmt.Sprintf("(vs %s, weight %.2f)", strings.Join(components.Group, ", "), weights.ShareOfVoice)))
+	} else if len(components.Group) == 0 {
+		fmt.Printf("  %sShare of voice: %s\n", LabelStyle, FormatSecondary("n/a (no keyword group, use --group)"))
+	} else {
+		fmt.Printf("  %sShare of voice: %s\n", LabelStyle, FormatSecondary("n/a (no group mentions)"))
+	}
+
+	if components.Coverage != nil {
+		fmt.Printf("  %sCoverage:       %s %s\n", LabelStyle, FormatValue(fmt.Sprintf("%.1f%%", *components.Coverage*100)),
+			FormatSecondary(fmt.Sprintf("(%d/%d enabled LLMs, weight %.2f)", components.MentioningLLMs, components.EnabledLLMs, weights.Coverage)))
+	} else {
+		fmt.Printf("  %sCoverage:       %s\n", LabelStyle, FormatSecondary("n/a (no enabled LLMs)"))
+	}
+
+	fmt.Println()
+	fmt.Printf("%sComponents marked n/a are left out and the remaining weights rescaled.%s\n", DimStyle, Reset)
+	return nil
+}
+
+// geoScoreConfig returns the GEO score settings of cfg, filling in defaults
+func geoScoreConfig(cfg *config.Config) (services.GEOScoreConfig, error) {
+	geoScore := services.DefaultGEOScoreConfig()
+	if err := cfg.GEOScore.Validate(); err != nil {
+		return geoScore, err
+	}
+
+	if weights := cfg.GEOScore.Weights; !weights.IsZero() {
+		geoScore.Weights = models.GEOScoreWeights{
+			MentionRate:  weights.MentionRate,
+			Position:     weights.Position,
+			ShareOfVoice: weights.ShareOfVoice,
+			Coverage:     weights.Coverage,
+		}
+	}
+	if cfg.GEOScore.MinResponses > 0 {
+		geoScore.MinResponses = cfg.GEOScore.MinResponses
+	}
+	geoScore.Group = cfg.GEOScore.Group
+	return geoScore, nil
+}
+
+// printExcludedShort reports how many responses --min-length excluded, if it is set
+func printExcludedShort(excluded, minLength int) {
+	if minLength <= 0 {
+		return
+	}
+	fmt.Printf("%s%s %s\n", LabelStyle, i18n.T("stats.excluded_short", minLength), FormatCount(excluded))
+}
+
+// keywordSearchOptions returns the keyword search limits of cfg
+func keywordSearchOptions(cfg *config.Config) (shared.KeywordSearchOptions, error) {
+	timeout, err := cfg.Search.GetTimeout()
+	if err != nil {
+		return shared.KeywordSearchOptions{}, err
+	}
+	return shared.KeywordSearchOptions{MaxScan: cfg.Search.MaxScan, Timeout: timeout}, nil
+}
+
+func runStatsCompare(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithErrorResponses(shared.WithExcludedLabels(shared.WithOwner(cmd.Context(), ownerFlag), statsExcludeLabels), statsIncludeErrors)
+	countMode, err := shared.ParseCountMode(statsCountMode)
+	if err != nil {
+		return err
+	}
+	ctx = shared.WithCountMode(ctx, countMode)
+
+	now := time.Now().UTC()
+	period2Start, period2End := now.AddDate(0, 0, -7), now
+	if statsPeriod2 != "" {
+		var err error
+		period2Start, period2End, err = parsePeriod(statsPeriod2)
+		if err != nil {
+			return fmt.Errorf("invalid --period2: %w", err)
+		}
+	}
+
+	period1Length := period2End.Sub(period2Start)
+	period1Start, period1End := period2Start.Add(-period1Length), period2Start
+	if statsPeriod1 != "" {
+		var err error
+		period1Start, period1End, err = parsePeriod(statsPeriod1)
+		if err != nil {
+			return fmt.Errorf("invalid --period1: %w", err)
+		}
+	}
+
+	comparison, err := statsService.ComparePeriods(ctx, period1Start, period1End, period2Start, period2End, statsLimit)
+	if err != nil {
+		return fmt.Errorf("failed to compare periods: %w", err)
+	}
+
+	fmt.Printf("%s📊 Keyword Comparison%s\n", HeaderStyle, Reset)
+	fmt.Printf("%s=====================%s\n", DimStyle, Reset)
+	fmt.Println()
+	fmt.Printf("%sPeriod 1: %s %s\n", LabelStyle, FormatMeta(formatPeriod(period1Start, period1End)), FormatSecondary(fmt.Sprintf("(%d mentions)", comparison.Period1Mentions)))
+	fmt.Printf("%sPeriod 2: %s %s\n", LabelStyle, FormatMeta(formatPeriod(period2Start, period2End)), FormatSecondary(fmt.Sprintf("(%d mentions)", comparison.Period2Mentions)))
+	fmt.Println()
+
+	if len(comparison.Keywords) == 0 {
+		fmt.Printf("%sNo keyword mentions found in either period.%s\n", WarningStyle, Reset)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%sKEYWORD\tPERIOD 1\tPERIOD 2\tDELTA\tSOV 1\tSOV 2\tRANK%s\n", LabelStyle, Reset)
+	fmt.Fprintf(w, "%s───────\t────────\t────────\t─────\t─────\t─────\t────%s\n", DimStyle, Reset)
+
+	for _, kw := range comparison.Keywords {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%.1f%%\t%.1f%%\t%s\n",
+			FormatValue(kw.Keyword),
+			kw.Period1Count,
+			kw.Period2Count,
+			formatDelta(kw.Delta),
+			kw.Period1Share,
+			kw.Period2Share,
+			formatRankChange(kw),
+		)
+	}
+
+	w.Flush()
+	return nil
+}
+
+// parsePeriod parses a START..END date range, END being inclusive
+func parsePeriod(value string) (time.Time, time.Time, error) {
+	parts := strings.Split(value, "..")
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("expected START..END, got %q", value)
+	}
+
+	start, err := time.Parse("2006-01-02", strings.TrimSpace(parts[0]))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date: %w", err)
+	}
+
+	end, err := time.Parse("2006-01-02", strings.TrimSpace(parts[1]))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end date: %w", err)
+	}
+
+	// The end date is included: the period ends when the next day starts
+	end = end.AddDate(0, 0, 1)
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end date is before start date")
+	}
+
+	return start, end, nil
+}
+
+func formatPeriod(start, end time.Time) string {
+	return fmt.Sprintf("%s → %s", start.Format("2006-01-02 15:04"), end.Format("2006-01-02 15:04"))
+}
+
+// formatCountChange renders the change of a count against the previous window, such as
+// " ▲ +12.5% (was 40)", or nothing when the previous window is not compared
+func formatCountChange(compared bool, change models.CountChange) string {
+	if !compared {
+		return ""
+	}
+	was := i18n.T("stats.change_was", change.Previous)
+	switch {
+	case change.Delta > 0:
+		return fmt.Sprintf(" %s▲ %s%s %s", SuccessStyle, change.Change, Reset, FormatDim(was))
+	case change.Delta < 0:
+		return fmt.Sprintf(" %s▼ %s%s %s", ErrorStyle, change.Change, Reset, FormatDim(was))
+	default:
+		return " " + FormatDim("= "+was)
+	}
+}
+
+func formatDelta(delta int) string {
+	switch {
+	case delta > 0:
+		return fmt.Sprintf("%s+%d%s", SuccessStyle, delta, Reset)
+	case delta < 0:
+		return fmt.Sprintf("%s%d%s", ErrorStyle, delta, Reset)
+	default:
+		return fmt.Sprintf("%s0%s", DimStyle, Reset)
+	}
+}
+
+func formatRankChange(kw models.KeywordDelta) string {
+	switch {
+	case kw.IsNew:
+		return fmt.Sprintf("%snew (#%d)%s", SuccessStyle, kw.Period2Rank, Reset)
+	case kw.IsDropped:
+		return fmt.Sprintf("%sdropped (was #%d)%s", ErrorStyle, kw.Period1Rank, Reset)
+	case kw.RankChange > 0:
+		return fmt.Sprintf("%s#%d ▲%d%s", SuccessStyle, kw.Period2Rank, kw.RankChange, Reset)
+	case kw.RankChange < 0:
+		return fmt.Sprintf("%s#%d ▼%d%s", ErrorStyle, kw.Period2Rank, -kw.RankChange, Reset)
+	default:
+		return fmt.Sprintf("%s#%d =%s", DimStyle, kw.Period2Rank, Reset)
+	}
+}
@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/config"
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+var (
+	statsSnapshotDays     int
+	statsSnapshotKeywords int
+	statsHistoryRange     timeRangeFlags
+)
+
+var statsSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Store the top keywords of the last days for trends",
+	Long: `Compute the top keywords and their share of voice over the last --days days and store them
+as a snapshot. Snapshots outlive the responses they were computed from, so 'gego stats history'
+can chart keyword trends after responses are reset or archived.
+
+The scheduler takes snapshots by itself when stats_snapshots.cadence is set in config.yaml.
+
+Examples:
+  gego stats snapshot
+  gego stats snapshot --days 7 --keywords 100
+  gego stats snapshot list`,
+	Args: cobra.NoArgs,
+	RunE: runStatsSnapshot,
+}
+
+var statsSnapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stored stats snapshots",
+	Args:  cobra.NoArgs,
+	RunE:  runStatsSnapshotList,
+}
+
+var statsHistoryCmd = &cobra.Command{
+	Use:   "history [keyword...]",
+	Short: "View keyword trends across stats snapshots",
+	Long: `Show the mentions and share of voice of keywords in each stored snapshot, oldest first.
+Without keywords, the top keywords of the latest snapshot are shown. A dash marks a snapshot that
+does not rank the keyword among its top keywords.
+
+Examples:
+  gego stats history Netflix Hulu
+  gego stats history --days 90 --limit 30`,
+	RunE: runStatsHistory,
+}
+
+func init() {
+	statsCmd.AddCommand(statsSnapshotCmd)
+	statsCmd.AddCommand(statsHistoryCmd)
+	statsSnapshotCmd.AddCommand(statsSnapshotListCmd)
+
+	statsSnapshotCmd.Flags().IntVar(&statsSnapshotDays, "days", 1, "Number of days of responses the snapshot covers")
+	statsSnapshotCmd.Flags().IntVar(&statsSnapshotKeywords, "keywords", services.DefaultSnapshotKeywords, "Number of top keywords kept in the snapshot")
+	statsSnapshotCmd.Flags().StringVar(&statsCountMode, "count", "occurrences", "Count every mention (occurrences) or the responses mentioning a keyword once each (responses)")
+	addTimeRangeFlags(statsSnapshotListCmd, &statsHistoryRange)
+	addTimeRangeFlags(statsHistoryCmd, &statsHistoryRange)
+}
+
+func runStatsSnapshot(cmd *cobra.Command, args []string) error {
+	if statsSnapshotDays < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+	if statsSnapshotKeywords < 1 {
+		return fmt.Errorf("--keywords must be at least 1")
+	}
+	countMode, err := shared.ParseCountMode(statsCountMode)
+	if err != nil {
+		return err
+	}
+	ctx := shared.WithCountMode(shared.WithOwner(cmd.Context(), ownerFlag), countMode)
+
+	window := time.Duration(statsSnapshotDays) * 24 * time.Hour
+	snapshot, err := statsService.TakeSnapshot(ctx, window, time.Now(), statsSnapshotKeywords)
+	if err != nil {
+		return fmt.Errorf("failed to take stats snapshot: %w", err)
+	}
+
+	fmt.Printf("%s✅ Stored stats snapshot %s%s\n", SuccessStyle, snapshot.ID, Reset)
+	fmt.Printf("%sPeriod:%s %s\n", LabelStyle, Reset, FormatMeta(formatPeriod(snapshot.PeriodStart, snapshot.PeriodEnd)))
+	fmt.Printf("%sKeywords:%s %s of %s mentions\n", LabelStyle, Reset, FormatCount(len(snapshot.Keywords)), FormatCount(snapshot.TotalMentions))
+	if len(snapshot.Keywords) > 0 {
+		top := snapshot.Keywords[0]
+		fmt.Printf("%sTop keyword:%s %s %s\n", LabelStyle, Reset, FormatValue(top.Keyword), FormatMeta(fmt.Sprintf("(%d, %.1f%%)", top.Count, top.Share)))
+	}
+	return nil
+}
+
+func runStatsSnapshotList(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+	startTime, endTime, err := statsHistoryRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := statsService.GetSnapshots(ctx, startTime, endTime, statsLimit)
+	if err != nil {
+		return fmt.Errorf("failed to list stats snapshots: %w", err)
+	}
+
+	fmt.Printf("%s📸 Stats Snapshots%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s=================%s\n", DimStyle, Reset)
+	fmt.Println()
+
+	if len(snapshots) == 0 {
+		fmt.Printf("%sNo stats snapshots found. Take one with 'gego stats snapshot'.%s\n", WarningStyle, Reset)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%sID\tPERIOD\tCOUNT\tMENTIONS\tTOP KEYWORD%s\n", LabelStyle, Reset)
+	for _, snapshot := range snapshots {
+		top := "-"
+		if len(snapshot.Keywords) > 0 {
+			top = fmt.Sprintf("%s (%.1f%%)", snapshot.Keywords[0].Keyword, snapshot.Keywords[0].Share)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", snapshot.ID, formatPeriod(snapshot.PeriodStart, snapshot.PeriodEnd),
+			snapshot.CountMode, snapshot.TotalMentions, top)
+	}
+	return w.Flush()
+}
+
+func runStatsHistory(cmd *cobra.Command, args []string) error {
+	ctx := shared.WithOwner(cmd.Context(), ownerFlag)
+	startTime, endTime, err := statsHistoryRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := statsService.GetSnapshots(ctx, startTime, endTime, statsLimit)
+	if err != nil {
+		return fmt.Errorf("failed to list stats snapshots: %w", err)
+	}
+
+	fmt.Printf("%s📈 Keyword History%s\n", FormatHeader(""), Reset)
+	fmt.Printf("%s=================%s\n", DimStyle, Reset)
+	fmt.Println()
+
+	if len(snapshots) == 0 {
+		fmt.Printf("%sNo stats snapshots found. Take one with 'gego stats snapshot'.%s\n", WarningStyle, Reset)
+		return nil
+	}
+
+	keywords := args
+	if len(keywords) == 0 {
+		for i, share := range snapshots[len(snapshots)-1].Keywords {
+			if i == 5 {
+				break
+			}
+			keywords = append(keywords, share.Keyword)
+		}
+	}
+	trends := services.KeywordTrends(snapshots, keywords)
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%sSNAPSHOT\t%s%s\n", LabelStyle, strings.ToUpper(strings.Join(keywords, "\t")), Reset)
+	for i, snapshot := range snapshots {
+		cells := make([]string, 0, len(trends))
+		for _, trend := range trends {
+			point := trend.Points[i]
+			if point.Rank == 0 {
+				cells = append(cells, "-")
+				continue
+			}
+			cells = append(cells, fmt.Sprintf("%d (%.1f%%)", point.Count, point.Share))
+		}
+		fmt.Fprintf(w, "%s\t%s\n", snapshot.PeriodEnd.Local().Format("2006-01-02 15:04"), strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+// statsSnapshotJob returns the stats snapshot job configured in cfg, or nil when no cadence is set
+func statsSnapshotJob(cfg *config.Config) (*services.StatsSnapshotJob, error) {
+	settings := cfg.StatsSnapshots
+	if settings.Cadence == "" {
+		return nil, nil
+	}
+	if settings.Keywords < 0 {
+		return nil, fmt.Errorf("stats_snapshots.keywords must not be negative")
+	}
+
+	cronExpr, err := services.ParseScheduleDescriptor(settings.Cadence)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stats_snapshots.cadence: %w", err)
+	}
+
+	var window time.Duration
+	if settings.Window != "" {
+		if window, err = time.ParseDuration(settings.Window); err != nil || window <= 0 {
+			return nil, fmt.Errorf("invalid stats_snapshots.window %q: must be a positive duration", settings.Window)
+		}
+	} else if window, err = services.ReportWindow(cronExpr, time.Now().UTC()); err != nil {
+		return nil, fmt.Errorf("stats_snapshots: %w", err)
+	}
+
+	return &services.StatsSnapshotJob{CronExpr: cronExpr, Window: window, Limit: settings.Keywords}, nil
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/services"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "end date included up to the next day",
+			value:     "2026-03-01..2026-03-07",
+			wantStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "single day",
+			value:     "2026-03-01..2026-03-01",
+			wantStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{name: "end before start", value: "2026-03-02..2026-03-01", wantErr: true},
+		{name: "missing end", value: "2026-03-01", wantErr: true},
+		{name: "invalid date", value: "2026-03-01..March", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := parsePeriod(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePeriod(%q) error = %v, want error %t", tt.value, err, tt.wantErr)
+			}
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("parsePeriod(%q) = %s..%s, want %s..%s", tt.value, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+// statsDB holds one prompt, one LLM and the responses of either
+type statsDB struct {
+	db.Database
+	responses []*models.Response
+}
+
+func (s *statsDB) GetPrompt(ctx context.Context, id string) (*models.Prompt, error) {
+	if id != "prompt-1" {
+		return nil, fmt.Errorf("prompt not found: %s", id)
+	}
+	return &models.Prompt{ID: id, Template: "What is the best CRM?", Enabled: true}, nil
+}
+
+func (s *statsDB) GetLLM(ctx context.Context, id string) (*models.LLMConfig, error) {
+	if id != "llm-1" {
+		return nil, fmt.Errorf("LLM not found: %s", id)
+	}
+	return &models.LLMConfig{ID: id, Name: "GPT", Provider: "openai", Model: "gpt-4o", Enabled: true}, nil
+}
+
+func (s *statsDB) GetPromptStats(ctx context.Context, promptID string) (*models.PromptStats, error) {
+	responses, _ := s.ListResponses(ctx, shared.ResponseFilter{PromptID: promptID})
+	return &models.PromptStats{PromptID: promptID, TotalResponses: len(responses), UniqueLLMs: 1, AvgTokens: 42}, nil
+}
+
+func (s *statsDB) GetLLMStats(ctx context.Context, llmID string) (*models.LLMStats, error) {
+	responses, _ := s.ListResponses(ctx, shared.ResponseFilter{LLMID: llmID})
+	return &models.LLMStats{LLMID: llmID, TotalResponses: len(responses), UniquePrompts: 1, AvgTokens: 42}, nil
+}
+
+func (s *statsDB) ListResponses(ctx context.Context, filter shared.ResponseFilter) ([]*models.Response, error) {
+	var result []*models.Response
+	for _, response := range s.responses {
+		if (filter.PromptID == "" || response.PromptID == filter.PromptID) && (filter.LLMID == "" || response.LLMID == filter.LLMID) {
+			result = append(result, response)
+		}
+	}
+	return result, nil
+}
+
+// captureStdout returns what fn prints to standard output
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- string(data)
+	}()
+
+	fnErr := fn()
+	os.Stdout = stdout
+	writer.Close()
+	return <-output, fnErr
+}
+
+func TestGetStats(t *testing.T) {
+	responses := []*models.Response{
+		{ID: "response-1", PromptID: "prompt-1", LLMID: "llm-1", ResponseText: "Acme leads, ahead of Globex."},
+		{ID: "response-2", PromptID: "prompt-1", LLMID: "llm-1", ResponseText: "Most teams pick Acme."},
+	}
+
+	tests := []struct {
+		name         string
+		run          func(cmd *cobra.Command, args []string) error
+		id           string
+		responses    []*models.Response
+		want         []string
+		wantKeywords bool
+	}{
+		{
+			name:         "prompt get",
+			run:          runPromptGet,
+			id:           "prompt-1",
+			responses:    responses,
+			want:         []string{"Total Responses: ", "Unique LLMs: ", "Acme", "(2 mentions)", "Globex", "(1 mentions)"},
+			wantKeywords: true,
+		},
+		{name: "prompt get without responses", run: runPromptGet, id: "prompt-1", want: []string{"Total Responses: "}},
+		{
+			name:         "llm get",
+			run:          runLLMGet,
+			id:           "llm-1",
+			responses:    responses,
+			want:         []string{"Total Responses: ", "Unique Prompts: ", "Acme", "(2 mentions)"},
+			wantKeywords: true,
+		},
+		{name: "llm get without responses", run: runLLMGet, id: "llm-1", want: []string{"Total Responses: "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &statsDB{responses: tt.responses}
+			database = store
+			statsService = services.NewStatsService(store)
+			promptGetStats, llmGetStats = true, true
+			t.Cleanup(func() {
+				database, statsService = nil, nil
+				promptGetStats, llmGetStats = false, false
+			})
+
+			cmd := &cobra.Command{}
+			cmd.SetContext(context.Background())
+			output, err := captureStdout(t, func() error { return tt.run(cmd, []string{tt.id}) })
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("output does not contain %q:\n%s", want, output)
+				}
+			}
+			if got := strings.Contains(output, "Top Keywords:"); got != tt.wantKeywords {
+				t.Errorf("top keywords shown = %t, want %t:\n%s", got, tt.wantKeywords, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/i18n"
+)
+
+// timeRangeFlags holds the --days, --from and --to flags restricting a command to a time window
+type timeRangeFlags struct {
+	days int
+	from string
+	to   string
+}
+
+// addTimeRangeFlags registers the time window flags of cmd
+func addTimeRangeFlags(cmd *cobra.Command, flags *timeRangeFlags) {
+	cmd.Flags().IntVar(&flags.days, "days", 0, "Only count responses from the last N days, or the N days before --to (default: all time)")
+	cmd.Flags().StringVar(&flags.from, "from", "", "Only count responses created at or after this time (YYYY-MM-DD or RFC3339, local time)")
+	cmd.Flags().StringVar(&flags.to, "to", "", "Only count responses created at or before this time (YYYY-MM-DD inclusive or RFC3339, local time)")
+}
+
+// resolve returns the window selected by the flags; a nil bound is open
+func (f *timeRangeFlags) resolve(now time.Time) (*time.Time, *time.Time, error) {
+	if f.days < 0 {
+		return nil, nil, fmt.Errorf("--days must not be negative")
+	}
+	if f.days > 0 && f.from != "" {
+		return nil, nil, fmt.Errorf("--days and --from cannot be used together")
+	}
+
+	var start, end *time.Time
+	if f.to != "" {
+		to, err := parseTimeFlag(f.to, true)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --to: %w", err)
+		}
+		end = &to
+	}
+	if f.from != "" {
+		from, err := parseTimeFlag(f.from, false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --from: %w", err)
+		}
+		start = &from
+	}
+	if f.days > 0 {
+		reference := now
+		if end != nil {
+			reference = *end
+		}
+		from := reference.AddDate(0, 0, -f.days)
+		start = &from
+	}
+
+	if start != nil && end != nil && !start.Before(*end) {
+		return nil, nil, fmt.Errorf("the start of the window (%s) must be before its end (%s)",
+			start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	return start, end, nil
+}
+
+// parseTimeFlag parses an RFC3339 time or a YYYY-MM-DD date in the local timezone. With
+// endOfDay a date stands for its last instant, so that --to includes the whole day.
+func parseTimeFlag(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC3339, got %q", value)
+	}
+	if endOfDay {
+		date = date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return date, nil
+}
+
+// describeTimeRange describes a window for output headers, e.g. "2025-01-01 00:00 → now"
+func describeTimeRange(start, end *time.Time) string {
+	if start == nil && end == nil {
+		return i18n.T("time_range.all_time")
+	}
+
+	from := i18n.T("time_range.beginning")
+	if start != nil {
+		from = start.Local().Format("2006-01-02 15:04")
+	}
+	to := i18n.T("time_range.now")
+	if end != nil {
+		to = end.Local().Format("2006-01-02 15:04")
+	}
+	return fmt.Sprintf("%s → %s (%s)", from, to, time.Now().Format("MST"))
+}
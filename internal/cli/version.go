@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AI2HU/gego/internal/version"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version and build information",
+	Args:  cobra.NoArgs,
+	RunE:  runVersion,
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	info := version.Get()
+
+	fmt.Printf("%sVersion: %s\n", LabelStyle, FormatValue(info.Version))
+	fmt.Printf("%sCommit: %s\n", LabelStyle, FormatSecondary(info.Commit))
+	fmt.Printf("%sBuild Date: %s\n", LabelStyle, FormatMeta(info.BuildDate))
+	fmt.Printf("%sGo Version: %s\n", LabelStyle, FormatMeta(info.GoVersion))
+
+	return nil
+}
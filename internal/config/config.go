@@ -4,16 +4,327 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration
 type Config struct {
-	SQLDatabase           DatabaseConfig `yaml:"sql_database"`                      // SQLite for LLMs and Schedules
-	NoSQLDatabase         DatabaseConfig `yaml:"nosql_database"`                    // MongoDB for Prompts and Responses
-	CORSOrigin            string         `yaml:"cors_origin,omitempty"`             // CORS origin for API server
-	KeywordsExclusionPath string         `yaml:"keywords_exclusion_path,omitempty"` // Path to keywords exclusion file
+	SQLDatabase           DatabaseConfig            `yaml:"sql_database"`                      // SQLite for LLMs and Schedules
+	NoSQLDatabase         DatabaseConfig            `yaml:"nosql_database"`                    // MongoDB for Prompts and Responses
+	CORSOrigin            string                    `yaml:"cors_origin,omitempty"`             // CORS origin for API server
+	APIMaxBodySize        int64                     `yaml:"api_max_body_size,omitempty"`       // Bytes an API request body may have (default 1MB)
+	KeywordsExclusionPath string                    `yaml:"keywords_exclusion_path,omitempty"` // Path to keywords exclusion file
+	ResponseCache         ResponseCacheConfig       `yaml:"response_cache,omitempty"`          // Opt-in reuse of recent identical responses
+	Storage               StorageConfig             `yaml:"storage,omitempty"`                 // Response storage options
+	GEOScore              GEOScoreConfig            `yaml:"geo_score,omitempty"`               // Weights and thresholds of keyword GEO scores
+	KeywordOptions        map[string]KeywordOptions `yaml:"keyword_options,omitempty"`         // Per-keyword counting options
+	DefaultModels         map[string]string         `yaml:"default_models,omitempty"`          // Model preselected by llm add, keyed by provider
+	Search                SearchConfig              `yaml:"search,omitempty"`                  // Limits of keyword searches
+	SMTP                  SMTPConfig                `yaml:"smtp,omitempty"`                    // Mail server used to send reports
+	Reports               []ReportConfig            `yaml:"reports,omitempty"`                 // Reports emailed by the scheduler
+	Tags                  TagsConfig                `yaml:"tags,omitempty"`                    // Normalization of prompt tags
+	Analysis              AnalysisConfig            `yaml:"analysis,omitempty"`                // Judge LLM analysis of responses
+	CreateDisabled        bool                      `yaml:"create_disabled,omitempty"`         // Create new LLMs and prompts disabled, to review them first
+	Metrics               MetricsConfig             `yaml:"metrics,omitempty"`                 // Prometheus keyword gauges served by gego metrics export
+	StatsSnapshots        StatsSnapshotConfig       `yaml:"stats_snapshots,omitempty"`         // Top keywords stored by the scheduler for trends
+	AuthFailureThreshold  int                       `yaml:"auth_failure_threshold,omitempty"`  // Consecutive auth failures before an LLM is disabled (default 10, -1 never)
+}
+
+// RegisteredKeywords returns the keywords configured in keyword_options, geo_score.group and
+// analysis.keywords, sorted
+func (c *Config) RegisteredKeywords() []string {
+	seen := make(map[string]bool)
+	var keywords []string
+	add := func(keyword string) {
+		keyword = strings.TrimSpace(keyword)
+		if keyword != "" && !seen[strings.ToLower(keyword)] {
+			seen[strings.ToLower(keyword)] = true
+			keywords = append(keywords, keyword)
+		}
+	}
+	for keyword := range c.KeywordOptions {
+		add(keyword)
+	}
+	for _, keyword := range c.GEOScore.Group {
+		add(keyword)
+	}
+	for _, keyword := range c.Analysis.Keywords {
+		add(keyword)
+	}
+	sort.Strings(keywords)
+	return keywords
+}
+
+// AnalysisConfig represents the analysis of responses by a judge LLM
+type AnalysisConfig struct {
+	JudgeLLM           string   `yaml:"judge_llm,omitempty"`             // ID or name of the LLM judging responses
+	Keywords           []string `yaml:"keywords,omitempty"`              // Tracked keywords the judge looks for
+	Prompt             string   `yaml:"prompt,omitempty"`                // Judge prompt template with {{keywords}} and {{response}} placeholders
+	AfterRuns          bool     `yaml:"after_runs,omitempty"`            // Analyze new responses after each scheduled run
+	MaxResponsesPerRun int      `yaml:"max_responses_per_run,omitempty"` // Responses analyzed per run at most (0 for no limit)
+	MaxTokensPerRun    int      `yaml:"max_tokens_per_run,omitempty"`    // Judge tokens spent per run at most (0 for no limit)
+}
+
+// TagsConfig represents how prompt tags are normalized. Tags are always trimmed and deduplicated.
+type TagsConfig struct {
+	PreserveCase bool `yaml:"preserve_case,omitempty"` // Keep the case of tags instead of lowercasing them
+}
+
+// DefaultModel returns the configured default model for a provider, if any
+func (c *Config) DefaultModel(provider string) string {
+	for name, model := range c.DefaultModels {
+		if strings.EqualFold(name, provider) {
+			return strings.TrimSpace(model)
+		}
+	}
+	return ""
+}
+
+// KeywordOptions represents how mentions of a keyword are counted
+type KeywordOptions struct {
+	CaseSensitive bool     `yaml:"case_sensitive,omitempty"` // Only count mentions with the keyword's exact case
+	ExcludeURLs   bool     `yaml:"exclude_urls,omitempty"`   // Do not count mentions inside URLs and domains such as netflix.com
+	Exclude       []string `yaml:"exclude,omitempty"`        // Regexes or phrases; mentions inside a match are not counted, e.g. "apple pie"
+}
+
+// Validate checks that the exclusion patterns compile
+func (o KeywordOptions) Validate() error {
+	for _, pattern := range o.Exclude {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("exclusion patterns must not be empty")
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid exclusion pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// validateKeywordOptions checks the options of every keyword
+func (c *Config) validateKeywordOptions() error {
+	for keyword, opts := range c.KeywordOptions {
+		if err := opts.Validate(); err != nil {
+			return fmt.Errorf("keyword_options.%s: %w", keyword, err)
+		}
+	}
+	return nil
+}
+
+// GEOScoreConfig represents the GEO score configuration. Weights left at zero use the defaults.
+type GEOScoreConfig struct {
+	Weights      GEOScoreWeights `yaml:"weights,omitempty"`
+	MinResponses int             `yaml:"min_responses,omitempty"` // Periods with fewer responses report insufficient data
+	Group        []string        `yaml:"group,omitempty"`         // Keywords share of voice is measured against, e.g. competitors
+}
+
+// GEOScoreWeights represents the relative weights of the GEO score components
+type GEOScoreWeights struct {
+	MentionRate  float64 `yaml:"mention_rate,omitempty"`
+	Position     float64 `yaml:"position,omitempty"`
+	ShareOfVoice float64 `yaml:"share_of_voice,omitempty"`
+	Coverage     float64 `yaml:"coverage,omitempty"`
+}
+
+// IsZero reports whether no weight is set
+func (w GEOScoreWeights) IsZero() bool {
+	return w.MentionRate == 0 && w.Position == 0 && w.ShareOfVoice == 0 && w.Coverage == 0
+}
+
+// Validate checks that the GEO score settings are usable
+func (c GEOScoreConfig) Validate() error {
+	w := c.Weights
+	if w.MentionRate < 0 || w.Position < 0 || w.ShareOfVoice < 0 || w.Coverage < 0 {
+		return fmt.Errorf("geo_score.weights must not be negative")
+	}
+	if c.MinResponses < 0 {
+		return fmt.Errorf("geo_score.min_responses must not be negative, got %d", c.MinResponses)
+	}
+	return nil
+}
+
+// StorageConfig represents response storage options
+type StorageConfig struct {
+	CompressResponses bool     `yaml:"compress_responses,omitempty"` // Store response bodies gzip-compressed with a plaintext excerpt
+	StripReasoning    bool     `yaml:"strip_reasoning,omitempty"`    // Remove <think>-style reasoning sections from response bodies
+	StorePromptText   bool     `yaml:"store_prompt_text,omitempty"`  // Store the full prompt on every response instead of only its hash
+	PostProcessors    []string `yaml:"post_processors,omitempty"`    // Ordered transforms applied to response bodies before storage, e.g. [collapse_whitespace, lowercase]
+	CaptureRaw        bool     `yaml:"capture_raw,omitempty"`        // Debug: store the raw provider requests and responses, secrets redacted, in response metadata
+	BatchSize         int      `yaml:"batch_size,omitempty"`         // Insert new responses in batches of this size (0 or 1 inserts each response immediately)
+	BatchInterval     string   `yaml:"batch_interval,omitempty"`     // Duration such as "2s" after which a partial batch is inserted (default 2s)
+}
+
+// GetBatchInterval returns the parsed response batch interval, or the default when unset
+func (c StorageConfig) GetBatchInterval() (time.Duration, error) {
+	return parsePositiveDuration("storage.batch_interval", c.BatchInterval, DefaultResponseBatchInterval)
+}
+
+// SearchConfig represents the limits of keyword searches
+type SearchConfig struct {
+	MaxScan int    `yaml:"max_scan,omitempty"` // Maximum responses scanned per keyword search (default 100000)
+	Timeout string `yaml:"timeout,omitempty"`  // Duration such as "30s" after which a search returns partial results (default 30s)
+}
+
+// GetTimeout returns the parsed search timeout, or 0 when unset
+func (c SearchConfig) GetTimeout() (time.Duration, error) {
+	if c.Timeout == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid search.timeout %q: %w", c.Timeout, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("search.timeout must be positive, got %s", c.Timeout)
+	}
+	return timeout, nil
+}
+
+// SMTPConfig represents the mail server reports are sent through. Connections are upgraded with
+// STARTTLS unless disabled for a local relay.
+type SMTPConfig struct {
+	Host            string `yaml:"host,omitempty"`
+	Port            int    `yaml:"port,omitempty"` // Default 587
+	Username        string `yaml:"username,omitempty"`
+	Password        string `yaml:"password,omitempty"` // Supports ${ENV_VAR} references
+	From            string `yaml:"from,omitempty"`
+	DisableStartTLS bool   `yaml:"disable_starttls,omitempty"` // Send in plaintext, e.g. to a local relay
+}
+
+// DefaultSMTPPort is the mail submission port used when smtp.port is not set
+const DefaultSMTPPort = 587
+
+// Validate checks that the SMTP settings can send mail
+func (c SMTPConfig) Validate() error {
+	if c.Host == "" {
+		return fmt.Errorf("smtp.host is required to send reports")
+	}
+	if c.From == "" {
+		return fmt.Errorf("smtp.from is required to send reports")
+	}
+	if c.Port < 0 || c.Port > 65535 {
+		return fmt.Errorf("smtp.port must be between 1 and 65535, got %d", c.Port)
+	}
+	return nil
+}
+
+// GetPort returns the SMTP port, falling back to DefaultSMTPPort
+func (c SMTPConfig) GetPort() int {
+	if c.Port == 0 {
+		return DefaultSMTPPort
+	}
+	return c.Port
+}
+
+// GetPassword returns the SMTP password with environment references resolved
+func (c SMTPConfig) GetPassword() (string, error) {
+	return ResolveEnv(c.Password)
+}
+
+// ReportConfig represents a report emailed on a cadence
+type ReportConfig struct {
+	Name       string   `yaml:"name"`
+	Recipients []string `yaml:"recipients"`
+	Cadence    string   `yaml:"cadence"`            // weekly, monthly, a phrase such as "every monday at 08:00" or a cron expression
+	Keywords   []string `yaml:"keywords,omitempty"` // Keywords detailed in the report
+	Days       int      `yaml:"days,omitempty"`     // Days covered by each report (default: the time since the previous send)
+}
+
+// ResolveEnv resolves a value of the form ${NAME} to the NAME environment variable, so that
+// secrets can stay out of config.yaml. Other values are returned unchanged.
+func ResolveEnv(value string) (string, error) {
+	if !strings.HasPrefix(value, "${") || !strings.HasSuffix(value, "}") {
+		return value, nil
+	}
+
+	name := value[2 : len(value)-1]
+	resolved, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return resolved, nil
+}
+
+// ResponseCacheConfig represents the response cache configuration
+type ResponseCacheConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	TTL     string `yaml:"ttl,omitempty"` // Duration such as "30m" or "6h" (default 1h)
+}
+
+// DefaultResponseCacheTTL is used when the response cache is enabled without a TTL
+const DefaultResponseCacheTTL = time.Hour
+
+// GetTTL returns the parsed cache TTL, falling back to DefaultResponseCacheTTL
+func (c ResponseCacheConfig) GetTTL() (time.Duration, error) {
+	if c.TTL == "" {
+		return DefaultResponseCacheTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid response_cache.ttl %q: %w", c.TTL, err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("response_cache.ttl must be positive, got %s", c.TTL)
+	}
+
+	return ttl, nil
+}
+
+// DefaultResponseBatchInterval is how long new responses are buffered by default when batching is enabled
+const DefaultResponseBatchInterval = 2 * time.Second
+
+const (
+	// DefaultMetricsInterval is how often keyword gauges are refreshed by default
+	DefaultMetricsInterval = 5 * time.Minute
+	// DefaultMetricsWindow is the period of responses keyword gauges are computed over by default
+	DefaultMetricsWindow = 30 * 24 * time.Hour
+)
+
+// MetricsConfig represents the keyword gauges exported for Prometheus. Only registered keywords
+// are exported, to bound the number of series.
+type MetricsConfig struct {
+	Interval string `yaml:"interval,omitempty"` // Duration such as "5m" between refreshes (default 5m)
+	Window   string `yaml:"window,omitempty"`   // Duration such as "168h" of responses the gauges cover (default 720h)
+}
+
+// GetInterval returns the parsed refresh interval, or the default when unset
+func (c MetricsConfig) GetInterval() (time.Duration, error) {
+	return parsePositiveDuration("metrics.interval", c.Interval, DefaultMetricsInterval)
+}
+
+// GetWindow returns the parsed response window, or the default when unset
+func (c MetricsConfig) GetWindow() (time.Duration, error) {
+	return parsePositiveDuration("metrics.window", c.Window, DefaultMetricsWindow)
+}
+
+// StatsSnapshotConfig represents the stats snapshots taken by the scheduler. Snapshots are only
+// taken when a cadence is set.
+type StatsSnapshotConfig struct {
+	Cadence  string `yaml:"cadence,omitempty"`  // daily, weekly, a phrase such as "every day at 23:55" or a cron expression
+	Window   string `yaml:"window,omitempty"`   // Duration such as "24h" of responses each snapshot covers (default: the time since the previous snapshot)
+	Keywords int    `yaml:"keywords,omitempty"` // Top keywords kept in each snapshot (default 50)
+}
+
+// parsePositiveDuration parses the duration of a setting, returning fallback when it is unset
+func parsePositiveDuration(name, value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("%s must be positive, got %s", name, value)
+	}
+	return duration, nil
 }
 
 // DatabaseConfig represents database configuration
@@ -31,7 +342,7 @@ func DefaultConfig() *Config {
 	return &Config{
 		SQLDatabase: DatabaseConfig{
 			Provider: "sqlite",
-			URI:      "gego.db",
+			URI:      DefaultSQLitePath(),
 			Database: "gego",
 		},
 		NoSQLDatabase: DatabaseConfig{
@@ -55,12 +366,19 @@ func Load(path string) (*Config, error) {
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	if err := config.validateKeywordOptions(); err != nil {
+		return nil, err
+	}
 
 	return &config, nil
 }
 
 // Save saves configuration to file
 func (c *Config) Save(path string) error {
+	if err := c.validateKeywordOptions(); err != nil {
+		return err
+	}
+
 	data, err := yaml.Marshal(c)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
@@ -78,6 +396,15 @@ func (c *Config) Save(path string) error {
 	return nil
 }
 
+// DefaultSQLitePath returns the default SQLite database path under the user config directory
+func DefaultSQLitePath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "gego.db"
+	}
+	return filepath.Join(configDir, "gego", "gego.db")
+}
+
 // GetConfigPath returns the default config file path
 func GetConfigPath() string {
 	home, err := os.UserHomeDir()
@@ -100,6 +100,10 @@ func (h *HybridDB) GetLLM(ctx context.Context, id string) (*models.LLMConfig, er
 	return h.sqlDB.GetLLM(ctx, id)
 }
 
+func (h *HybridDB) GetLLMsByIDs(ctx context.Context, ids []string) (map[string]*models.LLMConfig, error) {
+	return h.sqlDB.GetLLMsByIDs(ctx, ids)
+}
+
 func (h *HybridDB) ListLLMs(ctx context.Context, enabled *bool) ([]*models.LLMConfig, error) {
 	return h.sqlDB.ListLLMs(ctx, enabled)
 }
@@ -108,6 +112,10 @@ func (h *HybridDB) UpdateLLM(ctx context.Context, llm *models.LLMConfig) error {
 	return h.sqlDB.UpdateLLM(ctx, llm)
 }
 
+func (h *HybridDB) SetLLMAuthFailures(ctx context.Context, id string, failures int) error {
+	return h.sqlDB.SetLLMAuthFailures(ctx, id, failures)
+}
+
 func (h *HybridDB) DeleteLLM(ctx context.Context, id string) error {
 	return h.sqlDB.DeleteLLM(ctx, id)
 }
@@ -140,6 +148,30 @@ func (h *HybridDB) DeleteAllSchedules(ctx context.Context) (int, error) {
 	return h.sqlDB.DeleteAllSchedules(ctx)
 }
 
+func (h *HybridDB) SetScheduleError(ctx context.Context, id, message string) error {
+	return h.sqlDB.SetScheduleError(ctx, id, message)
+}
+
+func (h *HybridDB) CreateRecipe(ctx context.Context, recipe *models.GenerationRecipe) error {
+	return h.sqlDB.CreateRecipe(ctx, recipe)
+}
+
+func (h *HybridDB) GetRecipe(ctx context.Context, id string) (*models.GenerationRecipe, error) {
+	return h.sqlDB.GetRecipe(ctx, id)
+}
+
+func (h *HybridDB) ListRecipes(ctx context.Context) ([]*models.GenerationRecipe, error) {
+	return h.sqlDB.ListRecipes(ctx)
+}
+
+func (h *HybridDB) UpdateRecipe(ctx context.Context, recipe *models.GenerationRecipe) error {
+	return h.sqlDB.UpdateRecipe(ctx, recipe)
+}
+
+func (h *HybridDB) DeleteRecipe(ctx context.Context, id string) error {
+	return h.sqlDB.DeleteRecipe(ctx, id)
+}
+
 // Prompt operations - Use NoSQL
 func (h *HybridDB) CreatePrompt(ctx context.Context, prompt *models.Prompt) error {
 	return h.nosqlDB.CreatePrompt(ctx, prompt)
@@ -149,6 +181,10 @@ func (h *HybridDB) GetPrompt(ctx context.Context, id string) (*models.Prompt, er
 	return h.nosqlDB.GetPrompt(ctx, id)
 }
 
+func (h *HybridDB) GetPromptsByIDs(ctx context.Context, ids []string) (map[string]*models.Prompt, error) {
+	return h.nosqlDB.GetPromptsByIDs(ctx, ids)
+}
+
 func (h *HybridDB) ListPrompts(ctx context.Context, enabled *bool) ([]*models.Prompt, error) {
 	return h.nosqlDB.ListPrompts(ctx, enabled)
 }
@@ -181,18 +217,54 @@ func (h *HybridDB) CountResponses(ctx context.Context, filter shared.ResponseFil
 	return h.nosqlDB.CountResponses(ctx, filter)
 }
 
+func (h *HybridDB) DeleteResponses(ctx context.Context, filter shared.ResponseFilter) (int, error) {
+	return h.nosqlDB.DeleteResponses(ctx, filter)
+}
+
 func (h *HybridDB) DeleteAllResponses(ctx context.Context) (int, error) {
 	return h.nosqlDB.DeleteAllResponses(ctx)
 }
 
-func (h *HybridDB) SearchKeyword(ctx context.Context, keyword string, startTime, endTime *time.Time) (*models.KeywordStats, error) {
-	return h.nosqlDB.SearchKeyword(ctx, keyword, startTime, endTime)
+func (h *HybridDB) GetLatestResponsesByLLM(ctx context.Context, promptID string, perLLM int) ([]models.LLMResponses, error) {
+	return h.nosqlDB.GetLatestResponsesByLLM(ctx, promptID, perLLM)
+}
+
+func (h *HybridDB) SetResponseMetadata(ctx context.Context, responseID, key string, value interface{}) error {
+	return h.nosqlDB.SetResponseMetadata(ctx, responseID, key, value)
+}
+
+func (h *HybridDB) ListScheduleRuns(ctx context.Context, scheduleID string, limit int) ([]models.ScheduleRun, error) {
+	return h.nosqlDB.ListScheduleRuns(ctx, scheduleID, limit)
+}
+
+func (h *HybridDB) AddAnnotation(ctx context.Context, responseID string, annotation models.Annotation) error {
+	return h.nosqlDB.AddAnnotation(ctx, responseID, annotation)
+}
+
+func (h *HybridDB) ListAnnotations(ctx context.Context, responseID string) ([]models.Annotation, error) {
+	return h.nosqlDB.ListAnnotations(ctx, responseID)
+}
+
+func (h *HybridDB) SearchKeyword(ctx context.Context, keyword string, startTime, endTime *time.Time, opts shared.KeywordSearchOptions) (*models.KeywordStats, error) {
+	return h.nosqlDB.SearchKeyword(ctx, keyword, startTime, endTime, opts)
 }
 
 func (h *HybridDB) GetTopKeywords(ctx context.Context, limit int, startTime, endTime *time.Time) ([]models.KeywordCount, error) {
 	return h.nosqlDB.GetTopKeywords(ctx, limit, startTime, endTime)
 }
 
+func (h *HybridDB) GetTopDomains(ctx context.Context, keyword string, limit int, startTime, endTime *time.Time) ([]models.DomainCount, error) {
+	return h.nosqlDB.GetTopDomains(ctx, keyword, limit, startTime, endTime)
+}
+
+func (h *HybridDB) CreateStatsSnapshot(ctx context.Context, snapshot *models.StatsSnapshot) error {
+	return h.nosqlDB.CreateStatsSnapshot(ctx, snapshot)
+}
+
+func (h *HybridDB) ListStatsSnapshots(ctx context.Context, startTime, endTime *time.Time, limit int) ([]*models.StatsSnapshot, error) {
+	return h.nosqlDB.ListStatsSnapshots(ctx, startTime, endTime, limit)
+}
+
 func (h *HybridDB) GetPromptStats(ctx context.Context, promptID string) (*models.PromptStats, error) {
 	return h.nosqlDB.GetPromptStats(ctx, promptID)
 }
@@ -201,6 +273,43 @@ func (h *HybridDB) GetLLMStats(ctx context.Context, llmID string) (*models.LLMSt
 	return h.nosqlDB.GetLLMStats(ctx, llmID)
 }
 
+// SetCompressResponses enables compression of stored response bodies
+func (h *HybridDB) SetCompressResponses(enabled bool) {
+	if mongoDB := h.GetNoSQLDatabase(); mongoDB != nil {
+		mongoDB.SetCompressResponses(enabled)
+	}
+}
+
+// SetStorePromptText enables storing the full prompt text on every response
+func (h *HybridDB) SetStorePromptText(enabled bool) {
+	if mongoDB := h.GetNoSQLDatabase(); mongoDB != nil {
+		mongoDB.SetStorePromptText(enabled)
+	}
+}
+
+// SetResponseBatching buffers new responses and inserts them in batches of size, or every interval
+func (h *HybridDB) SetResponseBatching(size int, interval time.Duration) {
+	if mongoDB := h.GetNoSQLDatabase(); mongoDB != nil {
+		mongoDB.SetResponseBatching(size, interval)
+	}
+}
+
+// FlushResponses writes the responses buffered by response batching
+func (h *HybridDB) FlushResponses(ctx context.Context) error {
+	if mongoDB := h.GetNoSQLDatabase(); mongoDB != nil {
+		return mongoDB.FlushResponses(ctx)
+	}
+	return nil
+}
+
+// PendingResponses returns the responses buffered by response batching and not written yet
+func (h *HybridDB) PendingResponses() []*models.Response {
+	if mongoDB := h.GetNoSQLDatabase(); mongoDB != nil {
+		return mongoDB.PendingResponses()
+	}
+	return nil
+}
+
 func (h *HybridDB) GetNoSQLDatabase() *mongodb.MongoDB {
 	if mongoDB, ok := h.nosqlDB.(*mongodb.MongoDB); ok {
 		return mongoDB
@@ -1,8 +1,26 @@
 package db
 
+import (
+	"context"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
 // Database defines the combined interface for both SQL and NoSQL database operations
 // This interface combines SQLDatabase and NoSQLDatabase for backward compatibility
 type Database interface {
 	SQLDatabase
 	NoSQLDatabase
 }
+
+// ResponseFlusher is implemented by databases that can buffer new responses, to write them before
+// they are read back
+type ResponseFlusher interface {
+	FlushResponses(ctx context.Context) error
+}
+
+// PendingResponseLister is implemented by databases that can buffer new responses, to read those
+// not written yet
+type PendingResponseLister interface {
+	PendingResponses() []*models.Response
+}
@@ -5,16 +5,173 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
+// LatestSchemaVersion is the version of the newest SQLite migration in internal/db/migrations
+const LatestSchemaVersion = 12
+
+// SchemaVersion returns the applied SQLite schema version (0 if no migration was applied) and
+// whether a migration failed part-way. Unlike GetMigrationStatus, it does not need the migrations
+// directory.
+func SchemaVersion(ctx context.Context, db *sql.DB) (uint, bool, error) {
+	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create sqlite driver: %w", err)
+	}
+
+	version, dirty, err := driver.Version()
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	if version == database.NilVersion {
+		return 0, dirty, nil
+	}
+	return uint(version), dirty, nil
+}
+
+// RunMigrations applies all pending migrations from migrationsDir to the SQLite database
 func RunMigrations(ctx context.Context, db *sql.DB, migrationsDir string) error {
+	m, err := newMigrate(db, migrationsDir)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Up(); err != nil {
+		if errors.Is(err, migrate.ErrNoChange) {
+			return nil
+		}
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return nil
+}
+
+// RollbackMigrations reverts the last steps applied migrations
+func RollbackMigrations(ctx context.Context, db *sql.DB, migrationsDir string, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1")
+	}
+
+	m, err := newMigrate(db, migrationsDir)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Steps(-steps); err != nil {
+		return fmt.Errorf("failed to roll back migrations: %w", err)
+	}
+
+	return nil
+}
+
+// MigrateTo migrates the schema up or down to the given version; version 0 reverts every migration
+func MigrateTo(ctx context.Context, db *sql.DB, migrationsDir string, version uint) error {
+	m, err := newMigrate(db, migrationsDir)
+	if err != nil {
+		return err
+	}
+
+	if version == 0 {
+		err = m.Down()
+	} else {
+		err = m.Migrate(version)
+	}
+	if err != nil {
+		if errors.Is(err, migrate.ErrNoChange) {
+			return nil
+		}
+		return fmt.Errorf("failed to migrate to version %d: %w", version, err)
+	}
+
+	return nil
+}
+
+// MigrationStatus describes the migration state of a SQLite database
+type MigrationStatus struct {
+	Version   uint   // Current schema version (0 if no migration was applied)
+	Dirty     bool   // A migration failed part-way and the schema needs manual repair
+	Available []uint // Versions found in the migrations directory, in ascending order
+	Pending   []uint // Available versions above the current version
+}
+
+// Applied returns the available versions at or below the current version, in ascending order
+func (s *MigrationStatus) Applied() []uint {
+	var applied []uint
+	for _, v := range s.Available {
+		if v <= s.Version {
+			applied = append(applied, v)
+		}
+	}
+	return applied
+}
+
+// GetMigrationStatus reports the current schema version and pending migrations
+func GetMigrationStatus(ctx context.Context, db *sql.DB, migrationsDir string) (*MigrationStatus, error) {
+	absPath, err := resolveMigrationsDir(migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := newMigrate(db, absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MigrationStatus{}
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	status.Version = version
+	status.Dirty = dirty
+
+	status.Available, err = availableMigrations(absPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range status.Available {
+		if v > status.Version {
+			status.Pending = append(status.Pending, v)
+		}
+	}
+
+	return status, nil
+}
+
+// newMigrate creates a golang-migrate instance for the SQLite database and migrations directory
+func newMigrate(db *sql.DB, migrationsDir string) (*migrate.Migrate, error) {
+	absPath, err := resolveMigrationsDir(migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sqlite driver: %w", err)
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(MigrationsSourceURL(absPath), "sqlite3", driver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
+	}
+
+	return m, nil
+}
+
+// resolveMigrationsDir returns the absolute migrations directory, defaulting to /migrations
+// or ./internal/db/migrations when migrationsDir is empty
+func resolveMigrationsDir(migrationsDir string) (string, error) {
 	if migrationsDir == "" {
 		migrationsDir = "/migrations"
 		if _, err := os.Stat(migrationsDir); os.IsNotExist(err) {
@@ -25,34 +182,76 @@ func RunMigrations(ctx context.Context, db *sql.DB, migrationsDir string) error
 
 	absPath, err := filepath.Abs(migrationsDir)
 	if err != nil {
-		return fmt.Errorf("failed to resolve migrations path: %w", err)
+		return "", fmt.Errorf("failed to resolve migrations path: %w", err)
 	}
 
 	if _, err := os.Stat(absPath); os.IsNotExist(err) {
-		return fmt.Errorf("migrations directory not found: %s", absPath)
+		return "", fmt.Errorf("migrations directory not found: %s", absPath)
 	}
 
-	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	return absPath, nil
+}
+
+// availableMigrations lists the versions of the up migrations in dir, in ascending order
+func availableMigrations(dir string) ([]uint, error) {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return fmt.Errorf("failed to create sqlite driver: %w", err)
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
 	}
 
-	sourceURL := fmt.Sprintf("file://%s", absPath)
-	if !strings.HasPrefix(absPath, "/") {
-		sourceURL = fmt.Sprintf("file:///%s", absPath)
+	var versions []uint
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, uint(version))
 	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
 
-	m, err := migrate.NewWithDatabaseInstance(sourceURL, "sqlite3", driver)
-	if err != nil {
-		return fmt.Errorf("failed to create migrate instance: %w", err)
-	}
+	return versions, nil
+}
 
-	if err := m.Up(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			return nil
-		}
-		return fmt.Errorf("failed to run migrations: %w", err)
+// MigrationsSourceURL returns the golang-migrate file source URL for an absolute migrations directory
+func MigrationsSourceURL(absPath string) string {
+	return pathURL("file", absPath)
+}
+
+// SQLiteDatabaseURL returns the golang-migrate sqlite3 database URL for an absolute database path
+func SQLiteDatabaseURL(absPath string) string {
+	return pathURL("sqlite3", absPath)
+}
+
+// pathURL builds a URL for a filesystem path using forward slashes. Windows drive letters
+// (C:\dir) go in the host position, as golang-migrate joins host and path to recover the
+// filesystem path; UNC paths (\\server\share) keep their leading double slash.
+func pathURL(scheme, path string) string {
+	u := url.URL{Scheme: scheme}
+
+	switch {
+	case isWindowsDrivePath(path):
+		path = strings.ReplaceAll(path, `\`, "/")
+		u.Host = path[:2]
+		u.Path = path[2:]
+	case strings.HasPrefix(path, `\\`):
+		u.Path = strings.ReplaceAll(path, `\`, "/")
+	default:
+		u.Path = filepath.ToSlash(path)
 	}
 
-	return nil
+	return u.String()
+}
+
+// isWindowsDrivePath reports whether path starts with a drive letter such as C:
+func isWindowsDrivePath(path string) bool {
+	if len(path) < 2 || path[1] != ':' {
+		return false
+	}
+	c := path[0]
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
 }
@@ -0,0 +1,155 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const testMigrationsDir = "migrations"
+
+// openTestSQLite opens an empty SQLite database in a temporary directory
+func openTestSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	sqlDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "gego.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return sqlDB
+}
+
+func TestRunMigrations(t *testing.T) {
+	ctx := context.Background()
+	sqlDB := openTestSQLite(t)
+
+	status, err := GetMigrationStatus(ctx, sqlDB, testMigrationsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Version != 0 || len(status.Pending) != len(status.Available) || len(status.Applied()) != 0 {
+		t.Errorf("fresh database: version %d, %d pending of %d", status.Version, len(status.Pending), len(status.Available))
+	}
+	if last := status.Available[len(status.Available)-1]; last != LatestSchemaVersion {
+		t.Errorf("newest migration is %d, LatestSchemaVersion is %d", last, LatestSchemaVersion)
+	}
+
+	if err := RunMigrations(ctx, sqlDB, testMigrationsDir); err != nil {
+		t.Fatal(err)
+	}
+	// Running them again is a no-op
+	if err := RunMigrations(ctx, sqlDB, testMigrationsDir); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+
+	status, err = GetMigrationStatus(ctx, sqlDB, testMigrationsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Version != LatestSchemaVersion || status.Dirty || len(status.Pending) != 0 {
+		t.Errorf("migrated database: version %d dirty %t pending %v", status.Version, status.Dirty, status.Pending)
+	}
+	if !slices.Equal(status.Applied(), status.Available) {
+		t.Errorf("applied %v, want %v", status.Applied(), status.Available)
+	}
+
+	for _, table := range []string{"llms", "schedules", "generation_recipes"} {
+		var name string
+		if err := sqlDB.QueryRowContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&name); err != nil {
+			t.Errorf("table %s: %v", table, err)
+		}
+	}
+}
+
+func TestRunMigrationsMissingDir(t *testing.T) {
+	if err := RunMigrations(context.Background(), openTestSQLite(t), filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("RunMigrations succeeded without a migrations directory")
+	}
+}
+
+func TestPathURL(t *testing.T) {
+	tests := []struct {
+		scheme string
+		path   string
+		want   string
+	}{
+		{scheme: "file", path: "/app/internal/db/migrations", want: "file:///app/internal/db/migrations"},
+		{scheme: "sqlite3", path: "/home/me/.gego/gego.db", want: "sqlite3:///home/me/.gego/gego.db"},
+		{scheme: "file", path: "/path with spaces/migrations", want: "file:///path%20with%20spaces/migrations"},
+		{scheme: "file", path: `C:\gego\migrations`, want: "file://C:/gego/migrations"},
+		{scheme: "file", path: `\\server\share\migrations`, want: "file:////server/share/migrations"},
+	}
+
+	for _, tt := range tests {
+		if got := pathURL(tt.scheme, tt.path); got != tt.want {
+			t.Errorf("pathURL(%q, %q) = %q, want %q", tt.scheme, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRollbackMigrations(t *testing.T) {
+	ctx := context.Background()
+	sqlDB := openTestSQLite(t)
+	if err := RunMigrations(ctx, sqlDB, testMigrationsDir); err != nil {
+		t.Fatal(err)
+	}
+
+	version := func() uint {
+		t.Helper()
+		v, dirty, err := SchemaVersion(ctx, sqlDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dirty {
+			t.Fatalf("schema version %d is dirty", v)
+		}
+		return v
+	}
+
+	if err := RollbackMigrations(ctx, sqlDB, testMigrationsDir, 0); err == nil {
+		t.Error("rolling back 0 steps succeeded")
+	}
+
+	if err := RollbackMigrations(ctx, sqlDB, testMigrationsDir, 2); err != nil {
+		t.Fatal(err)
+	}
+	if got := version(); got != LatestSchemaVersion-2 {
+		t.Errorf("version after rolling back 2 = %d, want %d", got, LatestSchemaVersion-2)
+	}
+
+	if err := MigrateTo(ctx, sqlDB, testMigrationsDir, 3); err != nil {
+		t.Fatal(err)
+	}
+	if got := version(); got != 3 {
+		t.Errorf("version after goto 3 = %d, want 3", got)
+	}
+
+	// Every down migration runs, and the schema can be rebuilt afterwards
+	if err := MigrateTo(ctx, sqlDB, testMigrationsDir, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := version(); got != 0 {
+		t.Errorf("version after goto 0 = %d, want 0", got)
+	}
+	var tables int
+	if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'llms'").Scan(&tables); err != nil {
+		t.Fatal(err)
+	}
+	if tables != 0 {
+		t.Error("llms table left after reverting every migration")
+	}
+
+	if err := MigrateTo(ctx, sqlDB, testMigrationsDir, LatestSchemaVersion); err != nil {
+		t.Fatal(err)
+	}
+	if got := version(); got != LatestSchemaVersion {
+		t.Errorf("version after goto %d = %d", LatestSchemaVersion, got)
+	}
+
+	// Migrating to the current version is a no-op
+	if err := MigrateTo(ctx, sqlDB, testMigrationsDir, LatestSchemaVersion); err != nil {
+		t.Errorf("goto the current version: %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+-- Migration: 002_generation_recipes.down.sql
+-- Description: Rollback saved prompt generation recipes
+-- Author: AI2HU
+
+-- Drop triggers
+DROP TRIGGER IF EXISTS trigger_generation_recipes_updated_at;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_generation_recipes_created_at;
+DROP INDEX IF EXISTS idx_generation_recipes_llm_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS generation_recipes;
@@ -0,0 +1,27 @@
+-- Migration: 002_generation_recipes.sql
+-- Description: Saved prompt generation recipes
+-- Author: AI2HU
+
+-- Create generation recipes table for storing reusable prompt generation parameters
+CREATE TABLE IF NOT EXISTS generation_recipes (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    llm_id TEXT NOT NULL,
+    language TEXT NOT NULL DEFAULT 'EN',
+    user_input TEXT NOT NULL,
+    prompt_count INTEGER NOT NULL DEFAULT 20 CHECK (prompt_count >= 1 AND prompt_count <= 100),
+    tags TEXT NOT NULL DEFAULT '[]', -- JSON array of tags applied to generated prompts
+    last_run DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_generation_recipes_llm_id ON generation_recipes(llm_id);
+CREATE INDEX IF NOT EXISTS idx_generation_recipes_created_at ON generation_recipes(created_at);
+
+CREATE TRIGGER IF NOT EXISTS trigger_generation_recipes_updated_at 
+    AFTER UPDATE ON generation_recipes
+    FOR EACH ROW
+    BEGIN
+        UPDATE generation_recipes SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+    END;
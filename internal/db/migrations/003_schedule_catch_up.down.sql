@@ -0,0 +1,7 @@
+-- Migration: 003_schedule_catch_up.down.sql
+-- Description: Rollback schedule catch-up policy and missed run tracking
+-- Author: AI2HU
+
+ALTER TABLE schedules DROP COLUMN missed_runs;
+ALTER TABLE schedules DROP COLUMN catch_up_max;
+ALTER TABLE schedules DROP COLUMN catch_up_policy;
@@ -0,0 +1,12 @@
+-- Migration: 003_schedule_catch_up.sql
+-- Description: Catch-up policy and missed run tracking for schedules
+-- Author: AI2HU
+
+-- Policy applied on scheduler start for fire times missed while it was down
+ALTER TABLE schedules ADD COLUMN catch_up_policy TEXT NOT NULL DEFAULT 'none' CHECK (catch_up_policy IN ('none', 'run_once_on_start', 'backfill_all'));
+
+-- Maximum number of catch-up runs for the backfill_all policy (0 uses the default)
+ALTER TABLE schedules ADD COLUMN catch_up_max INTEGER NOT NULL DEFAULT 0 CHECK (catch_up_max >= 0);
+
+-- Total number of fire times missed while the scheduler was not running
+ALTER TABLE schedules ADD COLUMN missed_runs INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- Migration: 004_schedule_seed.down.sql
+-- Description: Rollback optional sampling seed for schedules
+-- Author: AI2HU
+
+ALTER TABLE schedules DROP COLUMN seed;
@@ -0,0 +1,6 @@
+-- Migration: 004_schedule_seed.sql
+-- Description: Optional sampling seed for reproducible schedule runs
+-- Author: AI2HU
+
+-- Seed passed to providers that support it (NULL uses the LLM's own configuration)
+ALTER TABLE schedules ADD COLUMN seed INTEGER;
@@ -0,0 +1,9 @@
+-- Migration: 005_owner.down.sql
+-- Description: Rollback owner labels on LLMs and schedules
+-- Author: AI2HU
+
+DROP INDEX IF EXISTS idx_schedules_owner;
+DROP INDEX IF EXISTS idx_llms_owner;
+
+ALTER TABLE schedules DROP COLUMN owner;
+ALTER TABLE llms DROP COLUMN owner;
@@ -0,0 +1,10 @@
+-- Migration: 005_owner.sql
+-- Description: Owner labels for attributing LLMs and schedules to a team
+-- Author: AI2HU
+
+-- Team or API token name the entry belongs to (empty = unowned)
+ALTER TABLE llms ADD COLUMN owner TEXT NOT NULL DEFAULT '';
+ALTER TABLE schedules ADD COLUMN owner TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_llms_owner ON llms(owner);
+CREATE INDEX IF NOT EXISTS idx_schedules_owner ON schedules(owner);
@@ -0,0 +1,5 @@
+-- Migration: 006_schedule_shuffle.down.sql
+-- Description: Rollback shuffled execution order for schedules
+-- Author: AI2HU
+
+ALTER TABLE schedules DROP COLUMN shuffle;
@@ -0,0 +1,6 @@
+-- Migration: 006_schedule_shuffle.sql
+-- Description: Optional shuffled prompt x LLM execution order for schedules
+-- Author: AI2HU
+
+-- Shuffle the execution order of each run (0 = prompt order, then LLM order)
+ALTER TABLE schedules ADD COLUMN shuffle INTEGER NOT NULL DEFAULT 0;
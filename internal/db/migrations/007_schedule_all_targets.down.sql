@@ -0,0 +1,6 @@
+-- Migration: 007_schedule_all_targets.down.sql
+-- Description: Rollback schedules that run every enabled prompt or LLM
+-- Author: AI2HU
+
+ALTER TABLE schedules DROP COLUMN all_llms;
+ALTER TABLE schedules DROP COLUMN all_prompts;
@@ -0,0 +1,9 @@
+-- Migration: 007_schedule_all_targets.sql
+-- Description: Schedules that run every enabled prompt or LLM, resolved at fire time
+-- Author: AI2HU
+
+-- Run every enabled prompt instead of prompt_ids (0 = use prompt_ids)
+ALTER TABLE schedules ADD COLUMN all_prompts INTEGER NOT NULL DEFAULT 0;
+
+-- Run every enabled LLM instead of llm_ids (0 = use llm_ids)
+ALTER TABLE schedules ADD COLUMN all_llms INTEGER NOT NULL DEFAULT 0;
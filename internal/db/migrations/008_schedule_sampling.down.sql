@@ -0,0 +1,6 @@
+-- Migration: 008_schedule_sampling.down.sql
+-- Description: Rollback weighted sampling of schedule prompts
+-- Author: AI2HU
+
+ALTER TABLE schedules DROP COLUMN sample_count;
+ALTER TABLE schedules DROP COLUMN prompt_weights;
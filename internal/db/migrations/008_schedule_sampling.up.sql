@@ -0,0 +1,9 @@
+-- Migration: 008_schedule_sampling.sql
+-- Description: Weighted sampling of the prompts run by each schedule fire
+-- Author: AI2HU
+
+-- Relative prompt weights as a JSON object keyed by prompt ID (missing prompts weigh 1)
+ALTER TABLE schedules ADD COLUMN prompt_weights TEXT NOT NULL DEFAULT '{}';
+
+-- Prompts sampled per fire according to their weights (0 = run every prompt)
+ALTER TABLE schedules ADD COLUMN sample_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- Migration: 009_schedule_last_error.down.sql
+-- Description: Rollback schedule registration errors
+-- Author: AI2HU
+
+ALTER TABLE schedules DROP COLUMN last_error;
@@ -0,0 +1,6 @@
+-- Migration: 009_schedule_last_error.sql
+-- Description: Record why the scheduler could not register a schedule
+-- Author: AI2HU
+
+-- Error of the last failed registration with cron ('' = registered fine)
+ALTER TABLE schedules ADD COLUMN last_error TEXT NOT NULL DEFAULT '';
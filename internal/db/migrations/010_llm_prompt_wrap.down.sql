@@ -0,0 +1,6 @@
+-- Migration: 010_llm_prompt_wrap.down.sql
+-- Description: Rollback per-LLM prompt prefix and suffix
+-- Author: AI2HU
+
+ALTER TABLE llms DROP COLUMN prompt_suffix;
+ALTER TABLE llms DROP COLUMN prompt_prefix;
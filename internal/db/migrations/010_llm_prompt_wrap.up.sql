@@ -0,0 +1,9 @@
+-- Migration: 010_llm_prompt_wrap.sql
+-- Description: Per-LLM text wrapped around every prompt
+-- Author: AI2HU
+
+-- Text sent before every prompt run with the LLM ('' = none)
+ALTER TABLE llms ADD COLUMN prompt_prefix TEXT NOT NULL DEFAULT '';
+
+-- Text sent after every prompt run with the LLM ('' = none)
+ALTER TABLE llms ADD COLUMN prompt_suffix TEXT NOT NULL DEFAULT '';
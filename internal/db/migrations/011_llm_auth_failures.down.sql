@@ -0,0 +1,6 @@
+-- Migration: 011_llm_auth_failures.down.sql
+-- Description: Rollback automatic disable of LLMs after authentication failures
+-- Author: AI2HU
+
+ALTER TABLE llms DROP COLUMN disabled_reason;
+ALTER TABLE llms DROP COLUMN auth_failures;
@@ -0,0 +1,9 @@
+-- Migration: 011_llm_auth_failures.sql
+-- Description: Automatic disable of LLMs after repeated authentication failures
+-- Author: AI2HU
+
+-- Consecutive calls rejected for authentication, reset by a successful call or a manual re-enable
+ALTER TABLE llms ADD COLUMN auth_failures INTEGER NOT NULL DEFAULT 0;
+
+-- Why the LLM was disabled automatically ('' = it was not)
+ALTER TABLE llms ADD COLUMN disabled_reason TEXT NOT NULL DEFAULT '';
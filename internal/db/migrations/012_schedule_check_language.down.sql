@@ -0,0 +1,5 @@
+-- Migration: 012_schedule_check_language.down.sql
+-- Description: Rollback the response language check of schedules
+-- Author: AI2HU
+
+ALTER TABLE schedules DROP COLUMN check_language;
@@ -0,0 +1,6 @@
+-- Migration: 012_schedule_check_language.sql
+-- Description: Optional check that responses are in the language of their prompt
+-- Author: AI2HU
+
+-- Flag responses not in the language of the lang-XX tag of their prompt (0 = no check)
+ALTER TABLE schedules ADD COLUMN check_language INTEGER NOT NULL DEFAULT 0;
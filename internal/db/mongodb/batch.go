@@ -0,0 +1,144 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// defaultResponseBatchInterval is how long new responses wait in a batch by default before it is written
+const defaultResponseBatchInterval = 2 * time.Second
+
+// responseBatcher buffers new response documents and writes them with one InsertMany when the
+// buffer is full or when its interval elapses
+type responseBatcher struct {
+	insert func(ctx context.Context, docs []interface{}) error
+	size   int
+
+	mu        sync.Mutex
+	pending   []interface{}
+	responses []*models.Response // The responses of the pending documents, in the same order
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newResponseBatcher starts a batcher writing with insert every size documents or every interval
+func newResponseBatcher(insert func(ctx context.Context, docs []interface{}) error, size int, interval time.Duration) *responseBatcher {
+	b := &responseBatcher{
+		insert: insert,
+		size:   size,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go b.run(interval)
+	return b
+}
+
+// run flushes the buffer every interval until the batcher is closed
+func (b *responseBatcher) run(interval time.Duration) {
+	defer close(b.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+			if err := b.flush(context.Background()); err != nil {
+				logger.Error("Failed to write batched responses: %v", err)
+			}
+		}
+	}
+}
+
+// add buffers doc, the document of response, writing the batch when it reaches its size
+func (b *responseBatcher) add(ctx context.Context, doc interface{}, response *models.Response) error {
+	b.mu.Lock()
+	b.pending = append(b.pending, doc)
+	b.responses = append(b.responses, response)
+	full := len(b.pending) >= b.size
+	b.mu.Unlock()
+
+	if full {
+		return b.flush(ctx)
+	}
+	return nil
+}
+
+// flush writes the buffered documents
+func (b *responseBatcher) flush(ctx context.Context) error {
+	b.mu.Lock()
+	docs := b.pending
+	b.pending = nil
+	b.responses = nil
+	b.mu.Unlock()
+
+	if len(docs) == 0 {
+		return nil
+	}
+	if err := b.insert(ctx, docs); err != nil {
+		return fmt.Errorf("failed to insert %d batched responses: %w", len(docs), err)
+	}
+	return nil
+}
+
+// pendingResponses returns the responses buffered and not written yet
+func (b *responseBatcher) pendingResponses() []*models.Response {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*models.Response(nil), b.responses...)
+}
+
+// close stops the periodic flush and writes the documents still buffered
+func (b *responseBatcher) close(ctx context.Context) error {
+	close(b.stop)
+	<-b.done
+	return b.flush(ctx)
+}
+
+// SetResponseBatching buffers the responses written by CreateResponse and inserts them together
+// once size of them are buffered or interval elapses, whichever comes first. Buffered responses
+// are written by FlushResponses and Disconnect. A size below 2 writes every response immediately.
+func (m *MongoDB) SetResponseBatching(size int, interval time.Duration) {
+	if m.batcher != nil {
+		if err := m.batcher.close(context.Background()); err != nil {
+			logger.Error("Failed to write batched responses: %v", err)
+		}
+		m.batcher = nil
+	}
+	if size < 2 {
+		return
+	}
+	if interval <= 0 {
+		interval = defaultResponseBatchInterval
+	}
+
+	m.batcher = newResponseBatcher(func(ctx context.Context, docs []interface{}) error {
+		_, err := m.database.Collection(collResponses).InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+		return err
+	}, size, interval)
+}
+
+// FlushResponses writes the responses buffered by response batching, if any
+func (m *MongoDB) FlushResponses(ctx context.Context) error {
+	if m.batcher == nil {
+		return nil
+	}
+	return m.batcher.flush(ctx)
+}
+
+// PendingResponses returns the responses buffered by response batching and not written yet
+func (m *MongoDB) PendingResponses() []*models.Response {
+	if m.batcher == nil {
+		return nil
+	}
+	return m.batcher.pendingResponses()
+}
@@ -0,0 +1,108 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// recordingInsert records the batches it is asked to insert, failing them all with err when set
+type recordingInsert struct {
+	mu      sync.Mutex
+	batches [][]interface{}
+	err     error
+}
+
+func (r *recordingInsert) insert(ctx context.Context, docs []interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, docs)
+	return r.err
+}
+
+func (r *recordingInsert) sizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sizes []int
+	for _, batch := range r.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
+func TestResponseBatcher(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		adds      int
+		wantAdded []int // Batches written while adding
+		wantAll   []int // Batches written once closed
+	}{
+		{name: "close flushes a partial batch", size: 10, adds: 3, wantAll: []int{3}},
+		{name: "full batch written on add", size: 2, adds: 5, wantAdded: []int{2, 2}, wantAll: []int{2, 2, 1}},
+		{name: "close with nothing buffered", size: 2, adds: 2, wantAdded: []int{2}, wantAll: []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &recordingInsert{}
+			batcher := newResponseBatcher(recorder.insert, tt.size, time.Hour)
+
+			for i := 0; i < tt.adds; i++ {
+				if err := batcher.add(context.Background(), i, &models.Response{}); err != nil {
+					t.Fatalf("add: %v", err)
+				}
+			}
+			if got := recorder.sizes(); !slices.Equal(got, tt.wantAdded) {
+				t.Errorf("batches written while adding = %v, want %v", got, tt.wantAdded)
+			}
+			if pending := len(batcher.pendingResponses()); pending != tt.adds%tt.size {
+				t.Errorf("%d responses pending, want %d", pending, tt.adds%tt.size)
+			}
+
+			if err := batcher.close(context.Background()); err != nil {
+				t.Fatalf("close: %v", err)
+			}
+			if got := recorder.sizes(); !slices.Equal(got, tt.wantAll) {
+				t.Errorf("batches written = %v, want %v", got, tt.wantAll)
+			}
+		})
+	}
+}
+
+func TestResponseBatcherFlushesOnInterval(t *testing.T) {
+	recorder := &recordingInsert{}
+	batcher := newResponseBatcher(recorder.insert, 10, 10*time.Millisecond)
+	defer batcher.close(context.Background())
+
+	batcher.add(context.Background(), "doc", &models.Response{})
+	deadline := time.Now().Add(time.Second)
+	for len(recorder.sizes()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("batch not written after its interval")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDisconnectReportsFlushError(t *testing.T) {
+	recorder := &recordingInsert{err: errors.New("write concern error")}
+	m := &MongoDB{batcher: newResponseBatcher(recorder.insert, 10, time.Hour)}
+	m.batcher.add(context.Background(), "doc", &models.Response{})
+
+	err := m.Disconnect(context.Background())
+	if err == nil || !errors.Is(err, recorder.err) {
+		t.Fatalf("Disconnect error = %v, want the insert error", err)
+	}
+	if m.batcher != nil {
+		t.Error("batcher kept after Disconnect")
+	}
+	if err := m.Disconnect(context.Background()); err != nil {
+		t.Errorf("second Disconnect: %v", err)
+	}
+}
@@ -0,0 +1,289 @@
+package mongodb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+const (
+	// fieldCompressedText holds the gzip-compressed response body; response_text then only keeps an excerpt
+	fieldCompressedText = "response_text_compressed"
+
+	// responseExcerptLength is the number of characters kept in plaintext for previews
+	responseExcerptLength = 200
+
+	// DefaultCompressBatchSize is the number of documents compressed per batch by CompressResponses
+	DefaultCompressBatchSize = 500
+)
+
+// responseDoc is a stored response, whose body may be compressed
+type responseDoc struct {
+	models.Response `bson:",inline"`
+	CompressedText  []byte `bson:"response_text_compressed,omitempty"`
+}
+
+// toResponse returns the response with its full body restored
+func (d *responseDoc) toResponse() (*models.Response, error) {
+	if len(d.CompressedText) > 0 {
+		text, err := decompressText(d.CompressedText)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress response %s: %w", d.ID, err)
+		}
+		d.ResponseText = text
+	}
+	return &d.Response, nil
+}
+
+// SetCompressResponses enables gzip compression of response bodies written by CreateResponse
+func (m *MongoDB) SetCompressResponses(enabled bool) {
+	m.compressResponses = enabled
+}
+
+// SetStorePromptText stores the full prompt text on responses written by CreateResponse;
+// otherwise only its hash is stored
+func (m *MongoDB) SetStorePromptText(enabled bool) {
+	m.storePromptText = enabled
+}
+
+// setResponseText stores text in doc, compressed with a plaintext excerpt when compression is enabled
+// and the text is longer than the excerpt
+func (m *MongoDB) setResponseText(doc bson.M, text string) error {
+	excerpt, truncated := responseExcerpt(text)
+	if !m.compressResponses || !truncated {
+		doc["response_text"] = text
+		return nil
+	}
+
+	compressed, err := compressText(text)
+	if err != nil {
+		return fmt.Errorf("failed to compress response text: %w", err)
+	}
+	doc["response_text"] = excerpt
+	doc[fieldCompressedText] = compressed
+	return nil
+}
+
+// CompressResponses compresses the bodies of existing uncompressed responses in batches of batchSize,
+// calling progress after each batch. Responses no longer than the excerpt are left as they are.
+func (m *MongoDB) CompressResponses(ctx context.Context, batchSize int, progress func(scanned, compressed, total int64)) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultCompressBatchSize
+	}
+
+	coll := m.database.Collection(collResponses)
+	query := bson.M{fieldCompressedText: bson.M{"$exists": false}}
+
+	total, err := coll.CountDocuments(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count responses: %w", err)
+	}
+
+	var scanned, compressed int64
+	lastID := ""
+	for {
+		batchQuery := bson.M{fieldCompressedText: bson.M{"$exists": false}}
+		if lastID != "" {
+			batchQuery["_id"] = bson.M{"$gt": lastID}
+		}
+
+		opts := options.Find().
+			SetSort(bson.D{{Key: "_id", Value: 1}}).
+			SetLimit(int64(batchSize)).
+			SetProjection(bson.M{"_id": 1, "response_text": 1})
+
+		cursor, err := coll.Find(ctx, batchQuery, opts)
+		if err != nil {
+			return compressed, fmt.Errorf("failed to read responses: %w", err)
+		}
+
+		var batch []struct {
+			ID           string `bson:"_id"`
+			ResponseText string `bson:"response_text"`
+		}
+		if err := cursor.All(ctx, &batch); err != nil {
+			return compressed, fmt.Errorf("failed to decode responses: %w", err)
+		}
+		if len(batch) == 0 {
+			return compressed, nil
+		}
+
+		var writes []mongo.WriteModel
+		for _, doc := range batch {
+			excerpt, truncated := responseExcerpt(doc.ResponseText)
+			if !truncated {
+				continue
+			}
+
+			data, err := compressText(doc.ResponseText)
+			if err != nil {
+				return compressed, fmt.Errorf("failed to compress response %s: %w", doc.ID, err)
+			}
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": doc.ID}).
+				SetUpdate(bson.M{"$set": bson.M{"response_text": excerpt, fieldCompressedText: data}}))
+		}
+
+		if len(writes) > 0 {
+			result, err := coll.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+			if err != nil {
+				return compressed, fmt.Errorf("failed to write compressed responses: %w", err)
+			}
+			compressed += result.ModifiedCount
+		}
+
+		scanned += int64(len(batch))
+		lastID = batch[len(batch)-1].ID
+		if progress != nil {
+			progress(scanned, compressed, total)
+		}
+	}
+}
+
+// findKeywordResponses returns the responses matching filter in its listing order, with the keyword
+// matched after decompression for compressed bodies. The scan stops once offset+limit responses
+// matched, but offset and limit are left for the caller to apply.
+func (m *MongoDB) findKeywordResponses(ctx context.Context, filter shared.ResponseFilter) ([]*models.Response, error) {
+	opts := options.Find().SetSort(responseSort(filter))
+
+	cursor, err := m.database.Collection(collResponses).Find(ctx, responseQuery(ctx, filter), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	regex := keywordRegexp(filter.Keyword)
+
+	var responses []*models.Response
+	for cursor.Next(ctx) {
+		var doc responseDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		wasCompressed := len(doc.CompressedText) > 0
+
+		response, err := doc.toResponse()
+		if err != nil {
+			return nil, err
+		}
+		if wasCompressed && !regex.MatchString(response.ResponseText) {
+			continue
+		}
+		responses = append(responses, response)
+		// Later matches fall outside the requested page
+		if filter.Limit > 0 && len(responses) >= filter.Offset+filter.Limit {
+			break
+		}
+	}
+
+	return responses, cursor.Err()
+}
+
+// keywordResponseIDs streams the responses matching the filter keyword and returns only their IDs,
+// projecting away every field the match does not need so that large scopes stay cheap to count and delete
+func (m *MongoDB) keywordResponseIDs(ctx context.Context, filter shared.ResponseFilter) ([]string, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "response_text": 1, fieldCompressedText: 1})
+
+	cursor, err := m.database.Collection(collResponses).Find(ctx, responseQuery(ctx, filter), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	regex := keywordRegexp(filter.Keyword)
+
+	var ids []string
+	for cursor.Next(ctx) {
+		var doc responseDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		if len(doc.CompressedText) > 0 {
+			response, err := doc.toResponse()
+			if err != nil {
+				return nil, err
+			}
+			if !regex.MatchString(response.ResponseText) {
+				continue
+			}
+		}
+		ids = append(ids, doc.ID)
+	}
+
+	return ids, cursor.Err()
+}
+
+// keywordClause matches the keyword against plaintext bodies and lets every compressed body through,
+// to be matched once decompressed
+func keywordClause(pattern string) bson.A {
+	return bson.A{
+		bson.M{"response_text": bson.M{"$regex": pattern, "$options": "i"}},
+		bson.M{fieldCompressedText: bson.M{"$exists": true}},
+	}
+}
+
+// keywordRegexp compiles a case-insensitive keyword pattern, matching it literally if it is not a valid regex
+func keywordRegexp(pattern string) *regexp.Regexp {
+	regex, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return regexp.MustCompile("(?i)" + regexp.QuoteMeta(pattern))
+	}
+	return regex
+}
+
+// responseExcerpt returns the first responseExcerptLength characters of text and whether it was truncated
+func responseExcerpt(text string) (string, bool) {
+	runes := []rune(text)
+	if len(runes) <= responseExcerptLength {
+		return text, false
+	}
+	return string(runes[:responseExcerptLength]), true
+}
+
+// compressedBytes returns the compressed body stored in a raw response document, if any
+func compressedBytes(doc bson.M) []byte {
+	switch val := doc[fieldCompressedText].(type) {
+	case primitive.Binary:
+		return val.Data
+	case []byte:
+		return val
+	}
+	return nil
+}
+
+func compressText(text string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(text)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressText(data []byte) (string, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	text, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	return string(text), nil
+}
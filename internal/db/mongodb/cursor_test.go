@@ -0,0 +1,152 @@
+package mongodb
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// cursorDoc is the part of a stored response that cursor paging reads
+type cursorDoc struct {
+	ID        string
+	CreatedAt time.Time
+}
+
+// field returns the value of a cursor query field of doc
+func (d cursorDoc) field(t *testing.T, name string) interface{} {
+	switch name {
+	case "_id":
+		return d.ID
+	case "created_at":
+		return d.CreatedAt
+	}
+	t.Fatalf("unexpected query field %q", name)
+	return nil
+}
+
+// compareValues orders two strings or two times
+func compareValues(a, b interface{}) int {
+	if at, ok := a.(time.Time); ok {
+		return at.Compare(b.(time.Time))
+	}
+	return strings.Compare(a.(string), b.(string))
+}
+
+// matchesQuery evaluates the subset of MongoDB queries built for cursor paging against doc
+func matchesQuery(t *testing.T, doc cursorDoc, query bson.M) bool {
+	t.Helper()
+	for key, value := range query {
+		switch key {
+		case "$and", "$or":
+			matched := 0
+			for _, clause := range value.(bson.A) {
+				if matchesQuery(t, doc, clause.(bson.M)) {
+					matched++
+				}
+			}
+			if key == "$and" && matched != len(value.(bson.A)) || key == "$or" && matched == 0 {
+				return false
+			}
+		default:
+			got := doc.field(t, key)
+			operators, ok := value.(bson.M)
+			if !ok {
+				operators = bson.M{"$eq": value}
+			}
+			for operator, operand := range operators {
+				cmp := compareValues(got, operand)
+				if ok := map[string]bool{"$eq": cmp == 0, "$lt": cmp < 0, "$gt": cmp > 0, "$lte": cmp <= 0, "$gte": cmp >= 0}[operator]; !ok {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// sortDocs sorts docs in the order of responseSort
+func sortDocs(docs []cursorDoc, order bson.D, t *testing.T) {
+	sort.SliceStable(docs, func(i, j int) bool {
+		for _, key := range order {
+			if cmp := compareValues(docs[i].field(t, key.Key), docs[j].field(t, key.Key)); cmp != 0 {
+				return cmp*key.Value.(int) < 0
+			}
+		}
+		return false
+	})
+}
+
+func TestResponseCursorPaging(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	// Several responses share a created_at, as a scheduled run stores them in the same millisecond
+	offsets := map[string]time.Duration{
+		"r07": 0, "r02": 0, "r09": 0,
+		"r01": time.Second, "r05": time.Second,
+		"r03": 2 * time.Second,
+		"r10": 3 * time.Second, "r04": 3 * time.Second, "r08": 3 * time.Second, "r06": 3 * time.Second,
+	}
+	var docs []cursorDoc
+	for id, offset := range offsets {
+		docs = append(docs, cursorDoc{ID: id, CreatedAt: base.Add(offset)})
+	}
+
+	for _, asc := range []bool{false, true} {
+		for _, limit := range []int{1, 2, 3, 4, 10} {
+			filter := shared.ResponseFilter{SortAsc: asc}
+			want := slices.Clone(docs)
+			sortDocs(want, responseSort(filter), t)
+
+			var got []cursorDoc
+			for page := 0; page <= len(docs); page++ {
+				var matched []cursorDoc
+				for _, doc := range docs {
+					if matchesQuery(t, doc, responseQuery(context.Background(), filter)) {
+						matched = append(matched, doc)
+					}
+				}
+				sortDocs(matched, responseSort(filter), t)
+				if len(matched) > limit {
+					matched = matched[:limit]
+				}
+				got = append(got, matched...)
+				if len(matched) < limit {
+					break
+				}
+
+				// Round-trip the cursor as the API does
+				last := matched[len(matched)-1]
+				cursor, err := shared.ParseResponseCursor(shared.NewResponseCursor(last.CreatedAt, last.ID).Encode())
+				if err != nil {
+					t.Fatal(err)
+				}
+				filter.After = cursor
+			}
+
+			if !slices.Equal(got, want) {
+				t.Errorf("asc=%t limit=%d: paged %v, want %v without duplicates or gaps", asc, limit, got, want)
+			}
+		}
+	}
+}
+
+func TestAfterCursorKeepsExistingAnd(t *testing.T) {
+	existing := bson.M{"llm_id": "llm-1"}
+	query := bson.M{"$and": bson.A{existing}, "$or": bson.A{bson.M{"response_text": "Acme"}}}
+
+	query = afterCursor(query, shared.NewResponseCursor(time.Now(), "r1"), false)
+
+	clauses := query["$and"].(bson.A)
+	if len(clauses) != 2 || clauses[0].(bson.M)["llm_id"] != "llm-1" {
+		t.Errorf("$and = %v, want the existing clause followed by the cursor clause", clauses)
+	}
+	if _, ok := query["$or"]; !ok {
+		t.Error("keyword $or was dropped")
+	}
+}
@@ -0,0 +1,184 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// GetTopDomains ranks the domains cited in responses by the number of responses citing them.
+// With a keyword, only responses mentioning it are counted.
+func (m *MongoDB) GetTopDomains(ctx context.Context, keyword string, limit int, startTime, endTime *time.Time) ([]models.DomainCount, error) {
+	query := labelScope(ownerScope(ctx, bson.M{"domains.0": bson.M{"$exists": true}}), shared.ExcludedLabelsFromContext(ctx))
+	errorScope(query, shared.ErrorFilterFromContext(ctx))
+	if startTime != nil || endTime != nil {
+		timeQuery := bson.M{}
+		if startTime != nil {
+			timeQuery["$gte"] = *startTime
+		}
+		if endTime != nil {
+			timeQuery["$lte"] = *endTime
+		}
+		query["created_at"] = timeQuery
+	}
+
+	if keyword != "" {
+		query["$or"] = keywordClause(shared.KeywordPattern(keyword))
+		return m.countKeywordDomains(ctx, query, keyword, limit)
+	}
+
+	pipeline := []bson.M{
+		{"$match": query},
+		{"$unwind": "$domains"},
+		{"$group": bson.M{"_id": "$domains", "responses": bson.M{"$sum": 1}}},
+		{"$sort": bson.D{{Key: "responses", Value: -1}, {Key: "_id", Value: 1}}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": limit})
+	}
+
+	cursor, err := m.database.Collection(collResponses).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate domains: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []models.DomainCount
+	for cursor.Next(ctx) {
+		var row struct {
+			Domain    string `bson:"_id"`
+			Responses int    `bson:"responses"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("failed to decode domain count: %w", err)
+		}
+		results = append(results, models.DomainCount{Domain: row.Domain, Responses: row.Responses})
+	}
+
+	return results, cursor.Err()
+}
+
+// countKeywordDomains counts the domains of the responses matching query that mention keyword.
+// Bodies are checked with CountKeyword, decompressing them as needed, so the count matches keyword stats.
+func (m *MongoDB) countKeywordDomains(ctx context.Context, query bson.M, keyword string, limit int) ([]models.DomainCount, error) {
+	opts := options.Find().SetProjection(bson.M{"response_text": 1, fieldCompressedText: 1, "domains": 1})
+
+	cursor, err := m.database.Collection(collResponses).Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read responses: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	counts := make(map[string]int)
+	for cursor.Next(ctx) {
+		var doc responseDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		response, err := doc.toResponse()
+		if err != nil {
+			return nil, err
+		}
+		if shared.CountKeyword(response.ResponseText, keyword) == 0 {
+			continue
+		}
+		for _, domain := range response.Domains {
+			counts[domain]++
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.DomainCount, 0, len(counts))
+	for domain, responses := range counts {
+		results = append(results, models.DomainCount{Domain: domain, Responses: responses})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Responses != results[j].Responses {
+			return results[i].Responses > results[j].Responses
+		}
+		return results[i].Domain < results[j].Domain
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// ExtractResponseDomains stores the cited domains of responses stored before domain extraction,
+// in batches of batchSize, calling progress after each batch
+func (m *MongoDB) ExtractResponseDomains(ctx context.Context, batchSize int, progress func(scanned, updated, total int64)) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultCompressBatchSize
+	}
+
+	coll := m.database.Collection(collResponses)
+	total, err := coll.CountDocuments(ctx, bson.M{"domains": bson.M{"$exists": false}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count responses: %w", err)
+	}
+
+	var scanned, updated int64
+	lastID := ""
+	for {
+		batchQuery := bson.M{"domains": bson.M{"$exists": false}}
+		if lastID != "" {
+			batchQuery["_id"] = bson.M{"$gt": lastID}
+		}
+
+		opts := options.Find().
+			SetSort(bson.D{{Key: "_id", Value: 1}}).
+			SetLimit(int64(batchSize)).
+			SetProjection(bson.M{"_id": 1, "response_text": 1, fieldCompressedText: 1})
+
+		cursor, err := coll.Find(ctx, batchQuery, opts)
+		if err != nil {
+			return updated, fmt.Errorf("failed to read responses: %w", err)
+		}
+
+		var batch []responseDoc
+		if err := cursor.All(ctx, &batch); err != nil {
+			return updated, fmt.Errorf("failed to decode responses: %w", err)
+		}
+		if len(batch) == 0 {
+			return updated, nil
+		}
+
+		var writes []mongo.WriteModel
+		for i := range batch {
+			response, err := batch[i].toResponse()
+			if err != nil {
+				return updated, err
+			}
+			domains := shared.ExtractDomains(response.ResponseText)
+			if domains == nil {
+				domains = []string{} // Marks the response as processed
+			}
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": response.ID}).
+				SetUpdate(bson.M{"$set": bson.M{"domains": domains}}))
+		}
+
+		result, err := coll.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return updated, fmt.Errorf("failed to write response domains: %w", err)
+		}
+		updated += result.ModifiedCount
+
+		scanned += int64(len(batch))
+		lastID = batch[len(batch)-1].ID
+		if progress != nil {
+			progress(scanned, updated, total)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collMigrations records the applied MongoDB migrations
+const collMigrations = "migrations"
+
+// Migration is a versioned change to the MongoDB schema or data. Up must be safe to run again
+// after a partial failure, since a migration is only recorded once it succeeds.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, m *MongoDB) error
+}
+
+// AppliedMigration is a migration recorded in the migrations collection
+type AppliedMigration struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// Migrations lists the MongoDB migrations in version order. Append new migrations with the next
+// version; never renumber or remove applied ones.
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "Create response and prompt indexes",
+		Up: func(ctx context.Context, m *MongoDB) error {
+			return m.createIndexes(ctx)
+		},
+	},
+	{
+		Version:     2,
+		Description: "Extract cited domains of responses stored before domain extraction",
+		Up: func(ctx context.Context, m *MongoDB) error {
+			_, err := m.ExtractResponseDomains(ctx, DefaultCompressBatchSize, nil)
+			return err
+		},
+	},
+}
+
+// migrationStore reads and records the applied migrations
+type migrationStore interface {
+	appliedMigrations(ctx context.Context) ([]AppliedMigration, error)
+	recordMigration(ctx context.Context, migration AppliedMigration) error
+}
+
+// AppliedMigrations returns the migrations recorded as applied, in version order
+func (m *MongoDB) AppliedMigrations(ctx context.Context) ([]AppliedMigration, error) {
+	return m.appliedMigrations(ctx)
+}
+
+func (m *MongoDB) appliedMigrations(ctx context.Context) ([]AppliedMigration, error) {
+	cursor, err := m.database.Collection(collMigrations).Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applied migrations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var applied []AppliedMigration
+	if err := cursor.All(ctx, &applied); err != nil {
+		return nil, fmt.Errorf("failed to decode applied migrations: %w", err)
+	}
+	return applied, nil
+}
+
+func (m *MongoDB) recordMigration(ctx context.Context, migration AppliedMigration) error {
+	_, err := m.database.Collection(collMigrations).ReplaceOne(ctx, bson.M{"_id": migration.Version}, migration, options.Replace().SetUpsert(true))
+	return err
+}
+
+// PendingMigrations returns the migrations of migrations not applied yet, in version order
+func (m *MongoDB) PendingMigrations(ctx context.Context, migrations []Migration) ([]Migration, error) {
+	return pendingMigrations(ctx, m, migrations)
+}
+
+func pendingMigrations(ctx context.Context, store migrationStore, migrations []Migration) ([]Migration, error) {
+	applied, err := store.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	done := make(map[int]bool, len(applied))
+	for _, migration := range applied {
+		done[migration.Version] = true
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if !done[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// RunMigrations applies the pending migrations of migrations in version order, recording each one
+// as it succeeds, and calls progress before each. It stops at the first failure; migrations already
+// applied stay recorded, so running it again resumes from the failed one.
+func (m *MongoDB) RunMigrations(ctx context.Context, migrations []Migration, progress func(Migration)) ([]Migration, error) {
+	return runMigrations(ctx, m, m, migrations, progress)
+}
+
+// runMigrations applies the pending migrations of store to m
+func runMigrations(ctx context.Context, store migrationStore, m *MongoDB, migrations []Migration, progress func(Migration)) ([]Migration, error) {
+	pending, err := pendingMigrations(ctx, store, migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, migration := range pending {
+		if progress != nil {
+			progress(migration)
+		}
+		if err := migration.Up(ctx, m); err != nil {
+			return applied, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+
+		record := AppliedMigration{Version: migration.Version, Description: migration.Description, AppliedAt: time.Now()}
+		if err := store.recordMigration(ctx, record); err != nil {
+			return applied, fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+		applied = append(applied, migration)
+	}
+	return applied, nil
+}
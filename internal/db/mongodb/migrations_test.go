@@ -0,0 +1,97 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// memoryMigrationStore records applied migrations in memory
+type memoryMigrationStore struct {
+	applied []AppliedMigration
+}
+
+func (s *memoryMigrationStore) appliedMigrations(ctx context.Context) ([]AppliedMigration, error) {
+	return s.applied, nil
+}
+
+func (s *memoryMigrationStore) recordMigration(ctx context.Context, migration AppliedMigration) error {
+	s.applied = append(s.applied, migration)
+	return nil
+}
+
+// versions returns the versions of migrations
+func versions(migrations []Migration) []int {
+	var result []int
+	for _, migration := range migrations {
+		result = append(result, migration.Version)
+	}
+	return result
+}
+
+func TestRunMigrationsIdempotent(t *testing.T) {
+	calls := make(map[int]int)
+	failing := errors.New("index build interrupted")
+	var failVersion2 error = failing
+
+	migration := func(version int) Migration {
+		return Migration{Version: version, Description: "test", Up: func(ctx context.Context, m *MongoDB) error {
+			calls[version]++
+			if version == 2 {
+				return failVersion2
+			}
+			return nil
+		}}
+	}
+	migrations := []Migration{migration(1), migration(2), migration(3)}
+	store := &memoryMigrationStore{}
+	ctx := context.Background()
+
+	// A failure stops the run and keeps the migrations applied before it recorded
+	applied, err := runMigrations(ctx, store, nil, migrations, nil)
+	if !errors.Is(err, failing) {
+		t.Fatalf("first run error = %v, want the migration failure", err)
+	}
+	if got := versions(applied); !slices.Equal(got, []int{1}) {
+		t.Errorf("first run applied %v, want [1]", got)
+	}
+
+	// Running again resumes from the failed migration
+	failVersion2 = nil
+	applied, err = runMigrations(ctx, store, nil, migrations, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := versions(applied); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("second run applied %v, want [2 3]", got)
+	}
+
+	// Once everything is applied, running again is a no-op
+	var progressed []int
+	applied, err = runMigrations(ctx, store, nil, migrations, func(migration Migration) {
+		progressed = append(progressed, migration.Version)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 0 || len(progressed) != 0 {
+		t.Errorf("third run applied %v with progress %v, want nothing", versions(applied), progressed)
+	}
+	pending, err := pendingMigrations(ctx, store, migrations)
+	if err != nil || len(pending) != 0 {
+		t.Errorf("pending = %v (%v), want none", versions(pending), err)
+	}
+
+	if calls[1] != 1 || calls[2] != 2 || calls[3] != 1 {
+		t.Errorf("Up calls = %v, want each migration run once after it succeeds", calls)
+	}
+}
+
+func TestMigrationVersionsIncrease(t *testing.T) {
+	for i, migration := range Migrations {
+		if migration.Version != i+1 || migration.Description == "" || migration.Up == nil {
+			t.Errorf("migration %d = version %d %q, want version %d with a description and Up", i, migration.Version, migration.Description, i+1)
+		}
+	}
+}
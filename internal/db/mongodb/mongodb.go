@@ -2,6 +2,7 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -19,6 +20,10 @@ type MongoDB struct {
 	client   *mongo.Client
 	database *mongo.Database
 	config   *models.Config
+
+	compressResponses bool             // Store response bodies gzip-compressed
+	storePromptText   bool             // Store the full prompt text on every response, not just its hash
+	batcher           *responseBatcher // Buffers new responses when response batching is enabled
 }
 
 const (
@@ -56,12 +61,22 @@ func (m *MongoDB) Connect(ctx context.Context) error {
 	return nil
 }
 
-// Disconnect closes the MongoDB connection
+// Disconnect writes the batched responses and closes the MongoDB connection. The connection is
+// closed even when the batched responses fail to be written.
 func (m *MongoDB) Disconnect(ctx context.Context) error {
+	var errs []error
+	if m.batcher != nil {
+		if err := m.batcher.close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+		m.batcher = nil
+	}
 	if m.client != nil {
-		return m.client.Disconnect(ctx)
+		if err := m.client.Disconnect(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to disconnect from MongoDB: %w", err))
+		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 // Ping checks the database connection
@@ -84,6 +99,25 @@ func (m *MongoDB) createIndexes(ctx context.Context) error {
 		{
 			Keys: bson.D{
 				{Key: "created_at", Value: -1},
+				{Key: "_id", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "owner", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "domains", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "schedule_id", Value: 1},
+				{Key: "run_id", Value: 1},
 			},
 		},
 	}
@@ -93,6 +127,28 @@ func (m *MongoDB) createIndexes(ctx context.Context) error {
 		return fmt.Errorf("failed to create response indexes: %w", err)
 	}
 
+	promptIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "created_at", Value: -1},
+			{Key: "_id", Value: 1},
+		},
+	}
+
+	if _, err := m.database.Collection(collPrompts).Indexes().CreateOne(ctx, promptIndex); err != nil {
+		return fmt.Errorf("failed to create prompt indexes: %w", err)
+	}
+
+	snapshotIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "owner", Value: 1},
+			{Key: "period_end", Value: -1},
+		},
+	}
+
+	if _, err := m.database.Collection(collStatsSnapshots).Indexes().CreateOne(ctx, snapshotIndex); err != nil {
+		return fmt.Errorf("failed to create stats snapshot indexes: %w", err)
+	}
+
 	return nil
 }
 
@@ -106,6 +162,7 @@ func (m *MongoDB) CreatePrompt(ctx context.Context, prompt *models.Prompt) error
 		"template":   prompt.Template,
 		"tags":       prompt.Tags,
 		"enabled":    prompt.Enabled,
+		"owner":      prompt.Owner,
 		"created_at": prompt.CreatedAt,
 		"updated_at": prompt.UpdatedAt,
 	}
@@ -125,6 +182,41 @@ func (m *MongoDB) GetPrompt(ctx context.Context, id string) (*models.Prompt, err
 		return nil, err
 	}
 
+	return promptFromDoc(doc)
+}
+
+// GetPromptsByIDs retrieves the prompts with the given IDs in one query, keyed by ID.
+// IDs without a prompt are left out of the result.
+func (m *MongoDB) GetPromptsByIDs(ctx context.Context, ids []string) (map[string]*models.Prompt, error) {
+	prompts := make(map[string]*models.Prompt, len(ids))
+	if len(ids) == 0 {
+		return prompts, nil
+	}
+
+	cursor, err := m.database.Collection(collPrompts).Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+
+		prompt, err := promptFromDoc(doc)
+		if err != nil {
+			return nil, err
+		}
+		prompts[prompt.ID] = prompt
+	}
+
+	return prompts, cursor.Err()
+}
+
+// promptFromDoc converts a BSON prompt document to a Prompt
+func promptFromDoc(doc bson.M) (*models.Prompt, error) {
 	var promptID string
 	if id, ok := doc["_id"].(string); ok {
 		promptID = id
@@ -138,6 +230,7 @@ func (m *MongoDB) GetPrompt(ctx context.Context, id string) (*models.Prompt, err
 		ID:        promptID,
 		Template:  getString(doc, "template"),
 		Enabled:   getBool(doc, "enabled"),
+		Owner:     getString(doc, "owner"),
 		CreatedAt: getTime(doc, "created_at"),
 		UpdatedAt: getTime(doc, "updated_at"),
 	}
@@ -155,12 +248,15 @@ func (m *MongoDB) GetPrompt(ctx context.Context, id string) (*models.Prompt, err
 
 // ListPrompts lists all prompts, optionally filtered by enabled status
 func (m *MongoDB) ListPrompts(ctx context.Context, enabled *bool) ([]*models.Prompt, error) {
-	filter := bson.M{}
+	filter := ownerScope(ctx, bson.M{})
 	if enabled != nil {
 		filter["enabled"] = *enabled
 	}
 
-	cursor, err := m.database.Collection(collPrompts).Find(ctx, filter)
+	// Newest first, with _id as a tiebreaker so pagination is stable
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}})
+
+	cursor, err := m.database.Collection(collPrompts).Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -173,31 +269,9 @@ func (m *MongoDB) ListPrompts(ctx context.Context, enabled *bool) ([]*models.Pro
 			return nil, err
 		}
 
-		// Convert BSON document to Prompt struct
-		var promptID string
-		if id, ok := doc["_id"].(string); ok {
-			promptID = id
-		} else if objectID, ok := doc["_id"].(primitive.ObjectID); ok {
-			promptID = objectID.Hex()
-		} else {
-			return nil, fmt.Errorf("invalid _id type in document")
-		}
-
-		prompt := &models.Prompt{
-			ID:        promptID,
-			Template:  getString(doc, "template"),
-			Enabled:   getBool(doc, "enabled"),
-			CreatedAt: getTime(doc, "created_at"),
-			UpdatedAt: getTime(doc, "updated_at"),
-		}
-
-		// Handle optional fields
-		if tags, ok := doc["tags"].([]interface{}); ok {
-			for _, t := range tags {
-				if str, ok := t.(string); ok {
-					prompt.Tags = append(prompt.Tags, str)
-				}
-			}
+		prompt, err := promptFromDoc(doc)
+		if err != nil {
+			return nil, err
 		}
 
 		prompts = append(prompts, prompt)
@@ -216,6 +290,7 @@ func (m *MongoDB) UpdatePrompt(ctx context.Context, prompt *models.Prompt) error
 		"template":   prompt.Template,
 		"tags":       prompt.Tags,
 		"enabled":    prompt.Enabled,
+		"owner":      prompt.Owner,
 		"created_at": prompt.CreatedAt,
 		"updated_at": prompt.UpdatedAt,
 	}
@@ -269,72 +344,112 @@ func (m *MongoDB) DeleteAllPrompts(ctx context.Context) (int, error) {
 
 // CreateResponse creates a new response
 func (m *MongoDB) CreateResponse(ctx context.Context, response *models.Response) error {
+	doc, err := m.responseDocument(response)
+	if err != nil {
+		return err
+	}
+	if m.batcher != nil {
+		return m.batcher.add(ctx, doc, response)
+	}
+
+	_, err = m.database.Collection(collResponses).InsertOne(ctx, doc)
+	return err
+}
+
+// responseDocument builds the stored document of a new response, setting its creation time
+func (m *MongoDB) responseDocument(response *models.Response) (bson.M, error) {
 	response.CreatedAt = time.Now()
 
 	doc := bson.M{
-		"_id":           response.ID,
-		"prompt_id":     response.PromptID,
-		"prompt_text":   response.PromptText,
-		"llm_id":        response.LLMID,
-		"llm_name":      response.LLMName,
-		"llm_provider":  response.LLMProvider,
-		"llm_model":     response.LLMModel,
-		"response_text": response.ResponseText,
-		"schedule_id":   response.ScheduleID,
-		"tokens_used":   response.TokensUsed,
-		"temperature":   response.Temperature,
-		"created_at":    response.CreatedAt,
+		"_id":          response.ID,
+		"prompt_id":    response.PromptID,
+		"llm_id":       response.LLMID,
+		"llm_name":     response.LLMName,
+		"llm_provider": response.LLMProvider,
+		"llm_model":    response.LLMModel,
+		"schedule_id":  response.ScheduleID,
+		"tokens_used":  response.TokensUsed,
+		"temperature":  response.Temperature,
+		"created_at":   response.CreatedAt,
 	}
 
+	if response.PromptHash == "" && response.PromptText != "" {
+		response.PromptHash = shared.PromptHash(response.PromptText)
+	}
+	if response.PromptHash != "" {
+		doc["prompt_hash"] = response.PromptHash
+	}
+	if m.storePromptText && response.PromptText != "" {
+		doc["prompt_text"] = response.PromptText
+	}
 	if response.Metadata != nil {
 		doc["metadata"] = response.Metadata
 	}
+	if response.Owner != "" {
+		doc["owner"] = response.Owner
+	}
+	if response.LatencyMs > 0 {
+		doc["latency_ms"] = response.LatencyMs
+	}
+	if response.QueueWaitMs > 0 {
+		doc["queue_wait_ms"] = response.QueueWaitMs
+	}
+	if response.Error != "" {
+		doc["error"] = response.Error
+	}
+	if response.ErrorClass != "" {
+		doc["error_class"] = response.ErrorClass
+	}
+	if response.RunID != "" {
+		doc["run_id"] = response.RunID
+	}
+	if response.Grounded {
+		doc["grounded"] = true
+	}
+	if response.Domains == nil {
+		response.Domains = shared.ExtractDomains(response.ResponseText)
+	}
+	if len(response.Domains) > 0 {
+		doc["domains"] = response.Domains
+	}
+	if err := m.setResponseText(doc, response.ResponseText); err != nil {
+		return nil, err
+	}
 
-	_, err := m.database.Collection(collResponses).InsertOne(ctx, doc)
-	return err
+	return doc, nil
 }
 
 // GetResponse retrieves a response by ID
 func (m *MongoDB) GetResponse(ctx context.Context, id string) (*models.Response, error) {
-	var response models.Response
-	err := m.database.Collection(collResponses).FindOne(ctx, bson.M{"_id": id}).Decode(&response)
+	// The response may still be buffered when it was just created
+	if err := m.FlushResponses(ctx); err != nil {
+		return nil, err
+	}
+
+	var doc responseDoc
+	err := m.database.Collection(collResponses).FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
 	if err == mongo.ErrNoDocuments {
 		return nil, fmt.Errorf("response not found: %s", id)
 	}
-	return &response, err
+	if err != nil {
+		return nil, err
+	}
+	return doc.toResponse()
 }
 
 // ListResponses lists responses with filtering
 func (m *MongoDB) ListResponses(ctx context.Context, filter shared.ResponseFilter) ([]*models.Response, error) {
-	query := bson.M{}
-
-	if filter.PromptID != "" {
-		query["prompt_id"] = filter.PromptID
-	}
-	if filter.LLMID != "" {
-		query["llm_id"] = filter.LLMID
-	}
-	if filter.ScheduleID != "" {
-		query["schedule_id"] = filter.ScheduleID
-	}
 	if filter.Keyword != "" {
-		query["response_text"] = bson.M{
-			"$regex":   filter.Keyword,
-			"$options": "i",
-		}
-	}
-	if filter.StartTime != nil || filter.EndTime != nil {
-		timeQuery := bson.M{}
-		if filter.StartTime != nil {
-			timeQuery["$gte"] = *filter.StartTime
-		}
-		if filter.EndTime != nil {
-			timeQuery["$lte"] = *filter.EndTime
+		responses, err := m.findKeywordResponses(ctx, filter)
+		if err != nil {
+			return nil, err
 		}
-		query["created_at"] = timeQuery
+		return paginate(responses, filter.Offset, filter.Limit), nil
 	}
 
-	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	query := responseQuery(ctx, filter)
+
+	opts := options.Find().SetSort(responseSort(filter))
 
 	if filter.Limit > 0 {
 		opts.SetLimit(int64(filter.Limit))
@@ -349,44 +464,49 @@ func (m *MongoDB) ListResponses(ctx context.Context, filter shared.ResponseFilte
 	}
 	defer cursor.Close(ctx)
 
-	var responses []*models.Response
-	if err := cursor.All(ctx, &responses); err != nil {
+	var docs []responseDoc
+	if err := cursor.All(ctx, &docs); err != nil {
 		return nil, err
 	}
 
+	responses := make([]*models.Response, 0, len(docs))
+	for i := range docs {
+		response, err := docs[i].toResponse()
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+
 	return responses, nil
 }
 
-// CountResponses counts responses matching the filter without fetching all documents
-func (m *MongoDB) CountResponses(ctx context.Context, filter shared.ResponseFilter) (int64, error) {
-	query := bson.M{}
-
-	if filter.PromptID != "" {
-		query["prompt_id"] = filter.PromptID
+// paginate applies offset and limit to an already filtered list of responses
+func paginate(responses []*models.Response, offset, limit int) []*models.Response {
+	if offset >= len(responses) {
+		return nil
 	}
-	if filter.LLMID != "" {
-		query["llm_id"] = filter.LLMID
+	if offset > 0 {
+		responses = responses[offset:]
 	}
-	if filter.ScheduleID != "" {
-		query["schedule_id"] = filter.ScheduleID
+	if limit > 0 && limit < len(responses) {
+		responses = responses[:limit]
 	}
+	return responses
+}
+
+// CountResponses counts responses matching the filter without fetching all documents
+func (m *MongoDB) CountResponses(ctx context.Context, filter shared.ResponseFilter) (int64, error) {
 	if filter.Keyword != "" {
-		query["response_text"] = bson.M{
-			"$regex":   filter.Keyword,
-			"$options": "i",
-		}
-	}
-	if filter.StartTime != nil || filter.EndTime != nil {
-		timeQuery := bson.M{}
-		if filter.StartTime != nil {
-			timeQuery["$gte"] = *filter.StartTime
+		ids, err := m.keywordResponseIDs(ctx, filter)
+		if err != nil {
+			return 0, err
 		}
-		if filter.EndTime != nil {
-			timeQuery["$lte"] = *filter.EndTime
-		}
-		query["created_at"] = timeQuery
+		return int64(len(ids)), nil
 	}
 
+	query := responseQuery(ctx, filter)
+
 	count, err := m.database.Collection(collResponses).CountDocuments(ctx, query)
 	return count, err
 }
@@ -433,6 +553,199 @@ func getTime(doc bson.M, key string) time.Time {
 	return time.Time{}
 }
 
+// DeleteResponses deletes responses matching the filter and returns the deleted count
+func (m *MongoDB) DeleteResponses(ctx context.Context, filter shared.ResponseFilter) (int, error) {
+	query := responseQuery(ctx, filter)
+	if filter.Keyword != "" {
+		ids, err := m.keywordResponseIDs(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+		query = bson.M{"_id": bson.M{"$in": ids}}
+	}
+
+	result, err := m.database.Collection(collResponses).DeleteMany(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
+
+// responseQuery builds the MongoDB query for a response filter, scoped to the context owner
+func responseQuery(ctx context.Context, filter shared.ResponseFilter) bson.M {
+	query := ownerScope(ctx, bson.M{})
+
+	if filter.PromptID != "" {
+		query["prompt_id"] = filter.PromptID
+	}
+	if filter.LLMID != "" {
+		query["llm_id"] = filter.LLMID
+	}
+	if filter.Model != "" {
+		query["llm_model"] = filter.Model
+	}
+	if filter.ScheduleID != "" {
+		query["schedule_id"] = filter.ScheduleID
+	}
+	if filter.RunID != "" {
+		query["run_id"] = filter.RunID
+	}
+	if filter.Owner != "" {
+		query["owner"] = filter.Owner
+	}
+	if label := shared.NormalizeLabel(filter.Label); label != "" {
+		query["annotations.label"] = label
+	}
+	labelScope(query, shared.NormalizeLabels(filter.ExcludeLabels))
+	errorScope(query, filter.HasError)
+	if filter.Grounded != nil {
+		if *filter.Grounded {
+			query["grounded"] = true
+		} else {
+			query["grounded"] = bson.M{"$ne": true}
+		}
+	}
+	if filter.CacheKey != "" {
+		query["metadata.cache_key"] = filter.CacheKey
+	}
+	if filter.ExcludeCached {
+		query["metadata.from_cache"] = bson.M{"$ne": true}
+	}
+	if filter.Keyword != "" {
+		query["$or"] = keywordClause(filter.Keyword)
+	}
+	if filter.StartTime != nil || filter.EndTime != nil {
+		timeQuery := bson.M{}
+		if filter.StartTime != nil {
+			timeQuery["$gte"] = *filter.StartTime
+		}
+		if filter.EndTime != nil {
+			timeQuery["$lte"] = *filter.EndTime
+		}
+		query["created_at"] = timeQuery
+	}
+	if filter.After != nil {
+		query = afterCursor(query, filter.After, filter.SortAsc)
+	}
+
+	return query
+}
+
+// responseSort returns the listing order of filter: newest first (created_at descending, then _id
+// ascending), or its exact reverse when SortAsc is set, so that both can walk the same indexes
+func responseSort(filter shared.ResponseFilter) bson.D {
+	if filter.SortAsc {
+		return bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: -1}}
+	}
+	return bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}
+}
+
+// afterCursor restricts query to responses listed after cursor in the newest-first order, or in
+// the oldest-first order when asc is set
+func afterCursor(query bson.M, cursor *shared.ResponseCursor, asc bool) bson.M {
+	createdAt, id := "$lt", "$gt"
+	if asc {
+		createdAt, id = "$gt", "$lt"
+	}
+	// Added to $and so it neither replaces the keyword $or nor other $and clauses
+	clauses, _ := query["$and"].(bson.A)
+	query["$and"] = append(clauses, bson.M{"$or": bson.A{
+		bson.M{"created_at": bson.M{createdAt: cursor.CreatedAt}},
+		bson.M{"created_at": cursor.CreatedAt, "_id": bson.M{id: cursor.ID}},
+	}})
+	return query
+}
+
+// ownerScope restricts query to the owner the context is scoped to, if any
+func ownerScope(ctx context.Context, query bson.M) bson.M {
+	if owner := shared.OwnerFromContext(ctx); owner != "" {
+		query["owner"] = owner
+	}
+	return query
+}
+
+// errorScope restricts query to responses that recorded a provider error, or that did not, when
+// hasError is set
+func errorScope(query bson.M, hasError *bool) bson.M {
+	if hasError == nil {
+		return query
+	}
+	if *hasError {
+		query["error"] = bson.M{"$nin": bson.A{nil, ""}}
+	} else {
+		query["error"] = bson.M{"$in": bson.A{nil, ""}}
+	}
+	return query
+}
+
+// labelScope excludes responses annotated with any of labels from query
+func labelScope(query bson.M, labels []string) bson.M {
+	if len(labels) == 0 {
+		return query
+	}
+
+	condition, ok := query["annotations.label"].(bson.M)
+	if !ok {
+		condition = bson.M{}
+		if label, isString := query["annotations.label"].(string); isString {
+			condition["$eq"] = label
+		}
+	}
+	condition["$nin"] = labels
+	query["annotations.label"] = condition
+	return query
+}
+
+// AddAnnotation appends an annotation to a response
+func (m *MongoDB) AddAnnotation(ctx context.Context, responseID string, annotation models.Annotation) error {
+	result, err := m.database.Collection(collResponses).UpdateOne(ctx,
+		bson.M{"_id": responseID},
+		bson.M{"$push": bson.M{"annotations": annotation}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("response not found: %s", responseID)
+	}
+	return nil
+}
+
+// SetResponseMetadata sets one metadata entry of a response, leaving the others untouched
+func (m *MongoDB) SetResponseMetadata(ctx context.Context, responseID, key string, value interface{}) error {
+	if err := m.FlushResponses(ctx); err != nil {
+		return err
+	}
+	result, err := m.database.Collection(collResponses).UpdateOne(ctx,
+		bson.M{"_id": responseID},
+		bson.M{"$set": bson.M{"metadata." + key: value}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("response not found: %s", responseID)
+	}
+	return nil
+}
+
+// ListAnnotations returns the annotations of a response, oldest first
+func (m *MongoDB) ListAnnotations(ctx context.Context, responseID string) ([]models.Annotation, error) {
+	var doc struct {
+		Annotations []models.Annotation `bson:"annotations"`
+	}
+
+	opts := options.FindOne().SetProjection(bson.M{"annotations": 1})
+	err := m.database.Collection(collResponses).FindOne(ctx, bson.M{"_id": responseID}, opts).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("response not found: %s", responseID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.Annotations, nil
+}
+
 // DeleteAllResponses deletes all responses from the database
 func (m *MongoDB) DeleteAllResponses(ctx context.Context) (int, error) {
 	result, err := m.database.Collection(collResponses).DeleteMany(ctx, bson.M{})
@@ -442,13 +755,79 @@ func (m *MongoDB) DeleteAllResponses(ctx context.Context) (int, error) {
 	return int(result.DeletedCount), nil
 }
 
+// GetLatestResponsesByLLM returns the perLLM most recent responses to a prompt for each LLM,
+// in a single aggregation
+func (m *MongoDB) GetLatestResponsesByLLM(ctx context.Context, promptID string, perLLM int) ([]models.LLMResponses, error) {
+	query := labelScope(ownerScope(ctx, bson.M{"prompt_id": promptID}), shared.ExcludedLabelsFromContext(ctx))
+
+	pipeline := []bson.M{
+		{"$match": query},
+		{"$sort": bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}},
+		{
+			"$group": bson.M{
+				"_id":          "$llm_id",
+				"llm_name":     bson.M{"$first": "$llm_name"},
+				"llm_model":    bson.M{"$first": "$llm_model"},
+				"llm_provider": bson.M{"$first": "$llm_provider"},
+				"responses":    bson.M{"$push": "$$ROOT"},
+			},
+		},
+		{
+			"$project": bson.M{
+				"llm_name":     1,
+				"llm_model":    1,
+				"llm_provider": 1,
+				"responses":    bson.M{"$slice": bson.A{"$responses", perLLM}},
+			},
+		},
+		{"$sort": bson.D{{Key: "llm_name", Value: 1}, {Key: "_id", Value: 1}}},
+	}
+
+	cursor, err := m.database.Collection(collResponses).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate responses: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groups []models.LLMResponses
+	for cursor.Next(ctx) {
+		var row struct {
+			LLMID       string        `bson:"_id"`
+			LLMName     string        `bson:"llm_name"`
+			LLMModel    string        `bson:"llm_model"`
+			LLMProvider string        `bson:"llm_provider"`
+			Responses   []responseDoc `bson:"responses"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("failed to decode responses: %w", err)
+		}
+
+		group := models.LLMResponses{
+			LLMID:       row.LLMID,
+			LLMName:     row.LLMName,
+			LLMModel:    row.LLMModel,
+			LLMProvider: row.LLMProvider,
+		}
+		for i := range row.Responses {
+			response, err := row.Responses[i].toResponse()
+			if err != nil {
+				return nil, err
+			}
+			group.Responses = append(group.Responses, response)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, cursor.Err()
+}
+
 // GetPromptStats calculates prompt statistics on-demand from responses
 func (m *MongoDB) GetPromptStats(ctx context.Context, promptID string) (*models.PromptStats, error) {
 	pipeline := []bson.M{
 		{
-			"$match": bson.M{
+			"$match": ownerScope(ctx, bson.M{
 				"prompt_id": promptID,
-			},
+			}),
 		},
 		{
 			"$group": bson.M{
@@ -506,9 +885,9 @@ func (m *MongoDB) GetPromptStats(ctx context.Context, promptID string) (*models.
 func (m *MongoDB) GetLLMStats(ctx context.Context, llmID string) (*models.LLMStats, error) {
 	pipeline := []bson.M{
 		{
-			"$match": bson.M{
+			"$match": ownerScope(ctx, bson.M{
 				"llm_id": llmID,
-			},
+			}),
 		},
 		{
 			"$group": bson.M{
@@ -566,9 +945,9 @@ func (m *MongoDB) GetLLMStats(ctx context.Context, llmID string) (*models.LLMSta
 func (m *MongoDB) getLLMCountsForPrompt(ctx context.Context, promptID string) (map[string]int, error) {
 	pipeline := []bson.M{
 		{
-			"$match": bson.M{
+			"$match": ownerScope(ctx, bson.M{
 				"prompt_id": promptID,
-			},
+			}),
 		},
 		{
 			"$group": bson.M{
@@ -603,9 +982,9 @@ func (m *MongoDB) getLLMCountsForPrompt(ctx context.Context, promptID string) (m
 func (m *MongoDB) getPromptCountsForLLM(ctx context.Context, llmID string) (map[string]int, error) {
 	pipeline := []bson.M{
 		{
-			"$match": bson.M{
+			"$match": ownerScope(ctx, bson.M{
 				"llm_id": llmID,
-			},
+			}),
 		},
 		{
 			"$group": bson.M{
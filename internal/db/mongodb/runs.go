@@ -0,0 +1,43 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// ListScheduleRuns summarizes the runs of a schedule from the responses they produced, newest first.
+// Responses stored before run IDs were recorded are left out.
+func (m *MongoDB) ListScheduleRuns(ctx context.Context, scheduleID string, limit int) ([]models.ScheduleRun, error) {
+	pipeline := []bson.M{
+		{"$match": ownerScope(ctx, bson.M{"schedule_id": scheduleID, "run_id": bson.M{"$exists": true}})},
+		{"$group": bson.M{
+			"_id":         "$run_id",
+			"started_at":  bson.M{"$min": "$created_at"},
+			"finished_at": bson.M{"$max": "$created_at"},
+			"responses":   bson.M{"$sum": 1},
+			"errors": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{bson.M{"$strLenCP": bson.M{"$ifNull": bson.A{"$error", ""}}}, 0}}, 1, 0,
+			}}},
+		}},
+		{"$sort": bson.D{{Key: "started_at", Value: -1}, {Key: "_id", Value: 1}}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": limit})
+	}
+
+	cursor, err := m.database.Collection(collResponses).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate schedule runs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var runs []models.ScheduleRun
+	if err := cursor.All(ctx, &runs); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule runs: %w", err)
+	}
+	return runs, nil
+}
@@ -2,23 +2,26 @@ package mongodb
 
 import (
 	"context"
-	"regexp"
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/AI2HU/gego/internal/models"
 	"github.com/AI2HU/gego/internal/shared"
 )
 
-// SearchKeyword searches for a keyword in all responses and calculates stats on-the-fly
-func (m *MongoDB) SearchKeyword(ctx context.Context, keyword string, startTime, endTime *time.Time) (*models.KeywordStats, error) {
-	pattern := regexp.QuoteMeta(keyword)
-	regex := bson.M{"$regex": pattern, "$options": "i"}
-
-	query := bson.M{
-		"response_text": regex,
-	}
+// SearchKeyword searches for a keyword in all responses and calculates stats on-the-fly. Newest
+// responses are scanned first; a scan reaching opts.MaxScan or opts.Timeout returns partial stats
+// flagged as truncated.
+func (m *MongoDB) SearchKeyword(ctx context.Context, keyword string, startTime, endTime *time.Time, opts shared.KeywordSearchOptions) (*models.KeywordStats, error) {
+	opts = opts.WithDefaults()
+	query := ownerScope(ctx, bson.M{
+		"$or": keywordClause(shared.KeywordPattern(keyword)),
+	})
+	labelScope(query, shared.ExcludedLabelsFromContext(ctx))
+	errorScope(query, shared.ErrorFilterFromContext(ctx))
 
 	if startTime != nil || endTime != nil {
 		timeQuery := bson.M{}
@@ -31,35 +34,71 @@ func (m *MongoDB) SearchKeyword(ctx context.Context, keyword string, startTime,
 		query["created_at"] = timeQuery
 	}
 
-	cursor, err := m.database.Collection(collResponses).Find(ctx, query)
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
 	stats := &models.KeywordStats{
 		Keyword:    keyword,
+		CountMode:  string(shared.CountModeFromContext(ctx)),
 		ByPrompt:   make(map[string]int),
 		ByLLM:      make(map[string]int),
 		ByProvider: make(map[string]int),
+		LLMs:       make(map[string]models.LLMSnapshot),
+	}
+
+	scanCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	// One document past the limit tells whether the scan is complete
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(opts.MaxScan) + 1)
+	cursor, err := m.database.Collection(collResponses).Find(scanCtx, query, findOpts)
+	if err != nil {
+		if scanDeadlineExceeded(ctx, scanCtx) {
+			stats.Truncated = true
+			return stats, nil
+		}
+		return nil, err
 	}
+	defer cursor.Close(context.Background())
 
 	promptsSeen := make(map[string]bool)
 	llmsSeen := make(map[string]bool)
+	minLength := shared.MinLengthFilterFromContext(ctx)
+	countMode := shared.CountModeFromContext(ctx)
+
+	for cursor.Next(scanCtx) {
+		if stats.Scanned == opts.MaxScan {
+			stats.Truncated = true
+			break
+		}
+		stats.Scanned++
 
-	for cursor.Next(ctx) {
 		var doc bson.M
 		if err := cursor.Decode(&doc); err != nil {
 			continue
 		}
 
 		responseText := getString(doc, "response_text")
+		compressed := compressedBytes(doc)
+		if compressed != nil {
+			text, err := decompressText(compressed)
+			if err != nil {
+				continue
+			}
+			responseText = text
+		}
+		if minLength.Exclude(responseText) {
+			stats.ExcludedShort++
+			continue
+		}
 		promptID := getString(doc, "prompt_id")
 		llmID := getString(doc, "llm_id")
 		llmProvider := getString(doc, "llm_provider")
 		createdAt := getTime(doc, "created_at")
 
-		count := shared.CountOccurrences(responseText, keyword)
+		count := countMode.Count(shared.CountKeyword(responseText, keyword))
+		if count == 0 {
+			continue
+		}
 		stats.TotalMentions += count
 
 		stats.ByPrompt[promptID] += count
@@ -67,9 +106,27 @@ func (m *MongoDB) SearchKeyword(ctx context.Context, keyword string, startTime,
 
 		stats.ByLLM[llmID] += count
 		llmsSeen[llmID] = true
+		// Responses are scanned newest first, so the most recent snapshot wins
+		if _, ok := stats.LLMs[llmID]; !ok {
+			snapshot := models.LLMSnapshot{Name: getString(doc, "llm_name"), Provider: llmProvider, Model: getString(doc, "llm_model")}
+			if !snapshot.Empty() {
+				stats.LLMs[llmID] = snapshot
+			}
+		}
 
 		stats.ByProvider[llmProvider] += count
 
+		if metadata, ok := doc["metadata"].(bson.M); ok {
+			if analysis, ok := models.AnalysisFromMetadata(metadata); ok {
+				if brand, ok := analysis.Brand(keyword); ok {
+					if stats.Analysis == nil {
+						stats.Analysis = &models.KeywordAnalysis{}
+					}
+					stats.Analysis.Add(brand)
+				}
+			}
+		}
+
 		if stats.FirstSeen.IsZero() || createdAt.Before(stats.FirstSeen) {
 			stats.FirstSeen = createdAt
 		}
@@ -78,21 +135,37 @@ func (m *MongoDB) SearchKeyword(ctx context.Context, keyword string, startTime,
 		}
 	}
 
+	if err := cursor.Err(); err != nil {
+		if !scanDeadlineExceeded(ctx, scanCtx) {
+			return nil, err
+		}
+		stats.Truncated = true
+	}
+
 	stats.UniquePrompts = len(promptsSeen)
 	stats.UniqueLLMs = len(llmsSeen)
 
 	return stats, nil
 }
 
-// GetTopKeywords returns the most common keywords across all responses
+// scanDeadlineExceeded reports whether scanCtx expired while its parent ctx is still live
+func scanDeadlineExceeded(ctx, scanCtx context.Context) bool {
+	return ctx.Err() == nil && errors.Is(scanCtx.Err(), context.DeadlineExceeded)
+}
+
+// GetTopKeywords returns the most common keywords across all responses, counting their mentions or
+// the responses mentioning them as the count mode of ctx says
 func (m *MongoDB) GetTopKeywords(ctx context.Context, limit int, startTime, endTime *time.Time) ([]models.KeywordCount, error) {
-	query := bson.M{}
+	query := labelScope(ownerScope(ctx, bson.M{}), shared.ExcludedLabelsFromContext(ctx))
+	errorScope(query, shared.ErrorFilterFromContext(ctx))
 	if startTime != nil || endTime != nil {
 		timeQuery := bson.M{}
 		if startTime != nil {
 			timeQuery["$gte"] = *startTime
 		}
-		if endTime != nil {
+		if endTime != nil && shared.ExclusiveEndFromContext(ctx) {
+			timeQuery["$lt"] = *endTime
+		} else if endTime != nil {
 			timeQuery["$lte"] = *endTime
 		}
 		query["created_at"] = timeQuery
@@ -105,16 +178,23 @@ func (m *MongoDB) GetTopKeywords(ctx context.Context, limit int, startTime, endT
 	defer cursor.Close(ctx)
 
 	wordCounts := make(map[string]int)
+	minLength := shared.MinLengthFilterFromContext(ctx)
+	countMode := shared.CountModeFromContext(ctx)
 	for cursor.Next(ctx) {
-		var response models.Response
-		if err := cursor.Decode(&response); err != nil {
+		var doc responseDoc
+		if err := cursor.Decode(&doc); err != nil {
 			continue
 		}
-
-		words := shared.ExtractCapitalizedWords(response.ResponseText)
-		for _, word := range words {
-			wordCounts[word]++
+		response, err := doc.toResponse()
+		if err != nil {
+			continue
 		}
+		// Failed calls have no body to count, short or not
+		if response.Error == "" && minLength.Exclude(response.ResponseText) {
+			continue
+		}
+
+		countMode.AddWords(wordCounts, shared.ExtractCapitalizedWords(response.ResponseText))
 	}
 
 	type kv struct {
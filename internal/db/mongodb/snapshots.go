@@ -0,0 +1,58 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// BackfillLLMSnapshots records the name, provider and model of llms on their responses stored
+// without them, leaving fields that are already set untouched. It returns the number of responses
+// updated and the number still missing a snapshot, such as responses of deleted LLMs.
+func (m *MongoDB) BackfillLLMSnapshots(ctx context.Context, llms []*models.LLMConfig) (int64, int64, error) {
+	coll := m.database.Collection(collResponses)
+
+	var updated int64
+	for _, llm := range llms {
+		// An update pipeline fills each empty field in place, counting every response once
+		set := bson.M{}
+		for field, value := range map[string]string{
+			"llm_name":     llm.Name,
+			"llm_provider": llm.Provider,
+			"llm_model":    llm.Model,
+		} {
+			set[field] = bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$" + field, ""}}, ""}},
+				value,
+				"$" + field,
+			}}
+		}
+
+		result, err := coll.UpdateMany(ctx,
+			bson.M{"llm_id": llm.ID, "$or": bson.A{
+				bson.M{"llm_name": bson.M{"$in": bson.A{"", nil}}},
+				bson.M{"llm_provider": bson.M{"$in": bson.A{"", nil}}},
+				bson.M{"llm_model": bson.M{"$in": bson.A{"", nil}}},
+			}},
+			mongo.Pipeline{{{Key: "$set", Value: set}}})
+		if err != nil {
+			return updated, 0, fmt.Errorf("failed to backfill responses of LLM %s: %w", llm.ID, err)
+		}
+		updated += result.ModifiedCount
+	}
+
+	missing, err := coll.CountDocuments(ctx, bson.M{
+		"llm_name":     bson.M{"$in": bson.A{"", nil}},
+		"llm_provider": bson.M{"$in": bson.A{"", nil}},
+		"llm_model":    bson.M{"$in": bson.A{"", nil}},
+	})
+	if err != nil {
+		return updated, 0, fmt.Errorf("failed to count responses without snapshot: %w", err)
+	}
+
+	return updated, missing, nil
+}
@@ -0,0 +1,66 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+const collStatsSnapshots = "stats_snapshots"
+
+// CreateStatsSnapshot stores a stats snapshot, recording the owner of the context on it
+func (m *MongoDB) CreateStatsSnapshot(ctx context.Context, snapshot *models.StatsSnapshot) error {
+	if snapshot.CreatedAt.IsZero() {
+		snapshot.CreatedAt = time.Now()
+	}
+	if owner := shared.OwnerFromContext(ctx); owner != "" {
+		snapshot.Owner = owner
+	}
+
+	if _, err := m.database.Collection(collStatsSnapshots).InsertOne(ctx, snapshot); err != nil {
+		return fmt.Errorf("failed to insert stats snapshot: %w", err)
+	}
+	return nil
+}
+
+// ListStatsSnapshots returns the snapshots whose period ends between startTime and endTime, oldest
+// first. A positive limit keeps the most recent ones.
+func (m *MongoDB) ListStatsSnapshots(ctx context.Context, startTime, endTime *time.Time, limit int) ([]*models.StatsSnapshot, error) {
+	query := ownerScope(ctx, bson.M{})
+	if startTime != nil || endTime != nil {
+		periodEnd := bson.M{}
+		if startTime != nil {
+			periodEnd["$gte"] = *startTime
+		}
+		if endTime != nil {
+			periodEnd["$lte"] = *endTime
+		}
+		query["period_end"] = periodEnd
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "period_end", Value: -1}, {Key: "_id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := m.database.Collection(collStatsSnapshots).Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stats snapshots: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var snapshots []*models.StatsSnapshot
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to decode stats snapshots: %w", err)
+	}
+	for i, j := 0, len(snapshots)-1; i < j; i, j = i+1, j-1 {
+		snapshots[i], snapshots[j] = snapshots[j], snapshots[i]
+	}
+	return snapshots, nil
+}
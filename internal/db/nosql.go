@@ -18,6 +18,7 @@ type NoSQLDatabase interface {
 	// Prompt operations
 	CreatePrompt(ctx context.Context, prompt *models.Prompt) error
 	GetPrompt(ctx context.Context, id string) (*models.Prompt, error)
+	GetPromptsByIDs(ctx context.Context, ids []string) (map[string]*models.Prompt, error)
 	ListPrompts(ctx context.Context, enabled *bool) ([]*models.Prompt, error)
 	UpdatePrompt(ctx context.Context, prompt *models.Prompt) error
 	DeletePrompt(ctx context.Context, id string) error
@@ -28,11 +29,24 @@ type NoSQLDatabase interface {
 	GetResponse(ctx context.Context, id string) (*models.Response, error)
 	ListResponses(ctx context.Context, filter shared.ResponseFilter) ([]*models.Response, error)
 	CountResponses(ctx context.Context, filter shared.ResponseFilter) (int64, error)
+	DeleteResponses(ctx context.Context, filter shared.ResponseFilter) (int, error)
 	DeleteAllResponses(ctx context.Context) (int, error)
+	GetLatestResponsesByLLM(ctx context.Context, promptID string, perLLM int) ([]models.LLMResponses, error)
+	SetResponseMetadata(ctx context.Context, responseID, key string, value interface{}) error
+	ListScheduleRuns(ctx context.Context, scheduleID string, limit int) ([]models.ScheduleRun, error)
+
+	// Response annotations
+	AddAnnotation(ctx context.Context, responseID string, annotation models.Annotation) error
+	ListAnnotations(ctx context.Context, responseID string) ([]models.Annotation, error)
 
 	// Keyword search (on-demand, searches through response_text)
-	SearchKeyword(ctx context.Context, keyword string, startTime, endTime *time.Time) (*models.KeywordStats, error)
+	SearchKeyword(ctx context.Context, keyword string, startTime, endTime *time.Time, opts shared.KeywordSearchOptions) (*models.KeywordStats, error)
 	GetTopKeywords(ctx context.Context, limit int, startTime, endTime *time.Time) ([]models.KeywordCount, error)
+	GetTopDomains(ctx context.Context, keyword string, limit int, startTime, endTime *time.Time) ([]models.DomainCount, error)
+
+	// Stats snapshots
+	CreateStatsSnapshot(ctx context.Context, snapshot *models.StatsSnapshot) error
+	ListStatsSnapshots(ctx context.Context, startTime, endTime *time.Time, limit int) ([]*models.StatsSnapshot, error)
 
 	// Statistics operations
 	GetPromptStats(ctx context.Context, promptID string) (*models.PromptStats, error)
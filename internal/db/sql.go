@@ -6,7 +6,7 @@ import (
 	"github.com/AI2HU/gego/internal/models"
 )
 
-// SQLDatabase defines the interface for SQL database operations (LLMs and Schedules)
+// SQLDatabase defines the interface for SQL database operations (LLMs, Schedules and generation recipes)
 type SQLDatabase interface {
 	// Connection management
 	Connect(ctx context.Context) error
@@ -16,8 +16,10 @@ type SQLDatabase interface {
 	// LLM operations
 	CreateLLM(ctx context.Context, llm *models.LLMConfig) error
 	GetLLM(ctx context.Context, id string) (*models.LLMConfig, error)
+	GetLLMsByIDs(ctx context.Context, ids []string) (map[string]*models.LLMConfig, error)
 	ListLLMs(ctx context.Context, enabled *bool) ([]*models.LLMConfig, error)
 	UpdateLLM(ctx context.Context, llm *models.LLMConfig) error
+	SetLLMAuthFailures(ctx context.Context, id string, failures int) error
 	DeleteLLM(ctx context.Context, id string) error
 	DeleteAllLLMs(ctx context.Context) (int, error)
 
@@ -28,4 +30,12 @@ type SQLDatabase interface {
 	UpdateSchedule(ctx context.Context, schedule *models.Schedule) error
 	DeleteSchedule(ctx context.Context, id string) error
 	DeleteAllSchedules(ctx context.Context) (int, error)
+	SetScheduleError(ctx context.Context, id, message string) error
+
+	// Generation recipe operations
+	CreateRecipe(ctx context.Context, recipe *models.GenerationRecipe) error
+	GetRecipe(ctx context.Context, id string) (*models.GenerationRecipe, error)
+	ListRecipes(ctx context.Context) ([]*models.GenerationRecipe, error)
+	UpdateRecipe(ctx context.Context, recipe *models.GenerationRecipe) error
+	DeleteRecipe(ctx context.Context, id string) error
 }
@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// CreateRecipe creates a new generation recipe
+func (s *SQLite) CreateRecipe(ctx context.Context, recipe *models.GenerationRecipe) error {
+	recipe.CreatedAt = time.Now()
+	recipe.UpdatedAt = time.Now()
+
+	query := `
+		INSERT INTO generation_recipes (id, name, llm_id, language, user_input, prompt_count, tags, last_run, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := s.db.ExecContext(ctx, query,
+		recipe.ID,
+		recipe.Name,
+		recipe.LLMID,
+		recipe.Language,
+		recipe.UserInput,
+		recipe.Count,
+		sliceToJSON(recipe.Tags),
+		recipe.LastRun,
+		recipe.CreatedAt,
+		recipe.UpdatedAt,
+	)
+
+	return err
+}
+
+// GetRecipe retrieves a generation recipe by ID
+func (s *SQLite) GetRecipe(ctx context.Context, id string) (*models.GenerationRecipe, error) {
+	query := `
+		SELECT id, name, llm_id, language, user_input, prompt_count, tags, last_run, created_at, updated_at
+		FROM generation_recipes WHERE id = ?`
+
+	var recipe models.GenerationRecipe
+	var tagsJSON string
+
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&recipe.ID,
+		&recipe.Name,
+		&recipe.LLMID,
+		&recipe.Language,
+		&recipe.UserInput,
+		&recipe.Count,
+		&tagsJSON,
+		&recipe.LastRun,
+		&recipe.CreatedAt,
+		&recipe.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("recipe not found: %s", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	recipe.Tags = jsonToSlice(tagsJSON)
+	return &recipe, nil
+}
+
+// ListRecipes lists all generation recipes
+func (s *SQLite) ListRecipes(ctx context.Context) ([]*models.GenerationRecipe, error) {
+	query := `
+		SELECT id, name, llm_id, language, user_input, prompt_count, tags, last_run, created_at, updated_at
+		FROM generation_recipes ORDER BY created_at DESC, id`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipes []*models.GenerationRecipe
+	for rows.Next() {
+		var recipe models.GenerationRecipe
+		var tagsJSON string
+
+		err := rows.Scan(
+			&recipe.ID,
+			&recipe.Name,
+			&recipe.LLMID,
+			&recipe.Language,
+			&recipe.UserInput,
+			&recipe.Count,
+			&tagsJSON,
+			&recipe.LastRun,
+			&recipe.CreatedAt,
+			&recipe.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		recipe.Tags = jsonToSlice(tagsJSON)
+		recipes = append(recipes, &recipe)
+	}
+
+	return recipes, nil
+}
+
+// UpdateRecipe updates an existing generation recipe
+func (s *SQLite) UpdateRecipe(ctx context.Context, recipe *models.GenerationRecipe) error {
+	recipe.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE generation_recipes
+		SET name = ?, llm_id = ?, language = ?, user_input = ?, prompt_count = ?, tags = ?, last_run = ?, updated_at = ?
+		WHERE id = ?`
+
+	result, err := s.db.ExecContext(ctx, query,
+		recipe.Name,
+		recipe.LLMID,
+		recipe.Language,
+		recipe.UserInput,
+		recipe.Count,
+		sliceToJSON(recipe.Tags),
+		recipe.LastRun,
+		recipe.UpdatedAt,
+		recipe.ID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("recipe not found: %s", recipe.ID)
+	}
+
+	return nil
+}
+
+// DeleteRecipe deletes a generation recipe
+func (s *SQLite) DeleteRecipe(ctx context.Context, id string) error {
+	query := "DELETE FROM generation_recipes WHERE id = ?"
+	result, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("recipe not found: %s", id)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
 )
 
 // SQLite implements the Database interface for SQLite
@@ -27,26 +29,41 @@ func New(config *models.Config) (*SQLite, error) {
 	}, nil
 }
 
-// Connect establishes connection to SQLite
-func (s *SQLite) Connect(ctx context.Context) error {
-	dbPath := s.config.URI
-	if strings.HasPrefix(dbPath, "~") {
+// ResolvePath expands a leading ~ and returns the absolute, cleaned database path
+func ResolvePath(uri string) (string, error) {
+	dbPath := uri
+	if dbPath == "~" || strings.HasPrefix(dbPath, "~/") || strings.HasPrefix(dbPath, `~\`) {
 		home, err := os.UserHomeDir()
 		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
+			return "", fmt.Errorf("failed to get home directory: %w", err)
 		}
-		dbPath = filepath.Join(home, dbPath[1:])
-	} else if !filepath.IsAbs(dbPath) {
+		dbPath = filepath.Join(home, filepath.FromSlash(dbPath[1:]))
+	}
+
+	if !filepath.IsAbs(dbPath) {
 		absPath, err := filepath.Abs(dbPath)
 		if err != nil {
-			return fmt.Errorf("failed to resolve absolute path: %w", err)
+			return "", fmt.Errorf("failed to resolve absolute path: %w", err)
 		}
 		dbPath = absPath
 	}
 
+	return filepath.Clean(dbPath), nil
+}
+
+// Connect establishes connection to SQLite
+func (s *SQLite) Connect(ctx context.Context) error {
+	dbPath, err := ResolvePath(s.config.URI)
+	if err != nil {
+		return err
+	}
+
+	// Skip volume roots such as C:\ or \\server\share\, which cannot be created
 	dir := filepath.Dir(dbPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create database directory: %w", err)
+	if dir != filepath.VolumeName(dir)+string(filepath.Separator) {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create database directory: %w", err)
+		}
 	}
 
 	db, err := sql.Open("sqlite3", dbPath)
@@ -84,71 +101,98 @@ func (s *SQLite) GetDB() *sql.DB {
 	return s.db
 }
 
+// mapToJSON encodes an LLM config map for the config column
 func mapToJSON(m map[string]string) string {
 	if len(m) == 0 {
 		return "{}"
 	}
-	result := "{"
-	first := true
-	for k, v := range m {
-		if !first {
-			result += ","
-		}
-		result += fmt.Sprintf(`"%s":"%s"`, k, v)
-		first = false
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "{}"
 	}
-	result += "}"
-	return result
+	return string(data)
 }
 
+// jsonToMap decodes the config column, ignoring malformed values
 func jsonToMap(jsonStr string) map[string]string {
-	if jsonStr == "" || jsonStr == "{}" {
+	m := make(map[string]string)
+	if jsonStr == "" {
+		return m
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &m); err != nil {
 		return make(map[string]string)
 	}
-	return make(map[string]string)
+	return m
+}
+
+// weightsToJSON encodes prompt weights for the prompt_weights column
+func weightsToJSON(weights map[string]float64) string {
+	if len(weights) == 0 {
+		return "{}"
+	}
+	data, err := json.Marshal(weights)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
 }
 
+// jsonToWeights decodes the prompt_weights column, ignoring malformed values
+func jsonToWeights(jsonStr string) map[string]float64 {
+	var weights map[string]float64
+	if err := json.Unmarshal([]byte(jsonStr), &weights); err != nil || len(weights) == 0 {
+		return nil
+	}
+	return weights
+}
+
+func catchUpPolicyOrDefault(policy string) string {
+	if policy == "" {
+		return models.CatchUpNone
+	}
+	return policy
+}
+
+// sliceToJSON encodes a string list for a JSON column
 func sliceToJSON(slice []string) string {
 	if len(slice) == 0 {
 		return "[]"
 	}
-	result := "["
-	for i, s := range slice {
-		if i > 0 {
-			result += ","
-		}
-		result += fmt.Sprintf(`"%s"`, s)
+	data, err := json.Marshal(slice)
+	if err != nil {
+		return "[]"
 	}
-	result += "]"
-	return result
+	return string(data)
 }
 
+// jsonToSlice decodes a JSON string list column, ignoring malformed values
 func jsonToSlice(jsonStr string) []string {
-	if jsonStr == "" || jsonStr == "[]" {
+	var result []string
+	if err := json.Unmarshal([]byte(jsonStr), &result); err != nil || result == nil {
 		return []string{}
 	}
+	return result
+}
 
-	jsonStr = strings.TrimSpace(jsonStr)
-	if !strings.HasPrefix(jsonStr, "[") || !strings.HasSuffix(jsonStr, "]") {
-		return []string{}
-	}
+// listConditions builds the WHERE clause shared by the LLM and schedule listings
+// from the enabled filter and the owner the context is scoped to
+func listConditions(ctx context.Context, enabled *bool) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
 
-	jsonStr = jsonStr[1 : len(jsonStr)-1]
-	if jsonStr == "" {
-		return []string{}
+	if enabled != nil {
+		conditions = append(conditions, "enabled = ?")
+		args = append(args, *enabled)
 	}
-
-	parts := strings.Split(jsonStr, ",")
-	var result []string
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if strings.HasPrefix(part, `"`) && strings.HasSuffix(part, `"`) {
-			part = part[1 : len(part)-1]
-		}
-		result = append(result, part)
+	if owner := shared.OwnerFromContext(ctx); owner != "" {
+		conditions = append(conditions, "owner = ?")
+		args = append(args, owner)
 	}
 
-	return result
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
 }
 
 // CreateLLM creates a new LLM configuration
@@ -157,8 +201,8 @@ func (s *SQLite) CreateLLM(ctx context.Context, llm *models.LLMConfig) error {
 	llm.UpdatedAt = time.Now()
 
 	query := `
-		INSERT INTO llms (id, name, provider, model, api_key, base_url, config, enabled, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		INSERT INTO llms (id, name, provider, model, api_key, base_url, config, enabled, owner, prompt_prefix, prompt_suffix, auth_failures, disabled_reason, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err := s.db.ExecContext(ctx, query,
 		llm.ID,
@@ -169,6 +213,11 @@ func (s *SQLite) CreateLLM(ctx context.Context, llm *models.LLMConfig) error {
 		llm.BaseURL,
 		mapToJSON(llm.Config),
 		llm.Enabled,
+		llm.Owner,
+		llm.PromptPrefix,
+		llm.PromptSuffix,
+		llm.AuthFailures,
+		llm.DisabledReason,
 		llm.CreatedAt,
 		llm.UpdatedAt,
 	)
@@ -179,7 +228,7 @@ func (s *SQLite) CreateLLM(ctx context.Context, llm *models.LLMConfig) error {
 // GetLLM retrieves an LLM configuration by ID
 func (s *SQLite) GetLLM(ctx context.Context, id string) (*models.LLMConfig, error) {
 	query := `
-		SELECT id, name, provider, model, api_key, base_url, config, enabled, created_at, updated_at
+		SELECT id, name, provider, model, api_key, base_url, config, enabled, owner, prompt_prefix, prompt_suffix, auth_failures, disabled_reason, created_at, updated_at
 		FROM llms WHERE id = ?`
 
 	var llm models.LLMConfig
@@ -194,6 +243,11 @@ func (s *SQLite) GetLLM(ctx context.Context, id string) (*models.LLMConfig, erro
 		&llm.BaseURL,
 		&configJSON,
 		&llm.Enabled,
+		&llm.Owner,
+		&llm.PromptPrefix,
+		&llm.PromptSuffix,
+		&llm.AuthFailures,
+		&llm.DisabledReason,
 		&llm.CreatedAt,
 		&llm.UpdatedAt,
 	)
@@ -209,19 +263,80 @@ func (s *SQLite) GetLLM(ctx context.Context, id string) (*models.LLMConfig, erro
 	return &llm, nil
 }
 
+// llmIDBatchSize bounds the number of IDs bound in one GetLLMsByIDs query
+const llmIDBatchSize = 500
+
+// GetLLMsByIDs retrieves the LLM configurations with the given IDs, keyed by ID.
+// IDs without an LLM are left out of the result.
+func (s *SQLite) GetLLMsByIDs(ctx context.Context, ids []string) (map[string]*models.LLMConfig, error) {
+	llms := make(map[string]*models.LLMConfig, len(ids))
+
+	for start := 0; start < len(ids); start += llmIDBatchSize {
+		batch := ids[start:min(start+llmIDBatchSize, len(ids))]
+
+		args := make([]interface{}, len(batch))
+		for i, id := range batch {
+			args[i] = id
+		}
+
+		query := `
+		SELECT id, name, provider, model, api_key, base_url, config, enabled, owner, prompt_prefix, prompt_suffix, auth_failures, disabled_reason, created_at, updated_at
+		FROM llms WHERE id IN (?` + strings.Repeat(", ?", len(batch)-1) + `)`
+
+		rows, err := s.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var llm models.LLMConfig
+			var configJSON string
+
+			err := rows.Scan(
+				&llm.ID,
+				&llm.Name,
+				&llm.Provider,
+				&llm.Model,
+				&llm.APIKey,
+				&llm.BaseURL,
+				&configJSON,
+				&llm.Enabled,
+				&llm.Owner,
+				&llm.PromptPrefix,
+				&llm.PromptSuffix,
+				&llm.AuthFailures,
+				&llm.DisabledReason,
+				&llm.CreatedAt,
+				&llm.UpdatedAt,
+			)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+
+			llm.Config = jsonToMap(configJSON)
+			llms[llm.ID] = &llm
+		}
+
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return llms, nil
+}
+
 // ListLLMs lists all LLM configurations, optionally filtered by enabled status
 func (s *SQLite) ListLLMs(ctx context.Context, enabled *bool) ([]*models.LLMConfig, error) {
 	query := `
-		SELECT id, name, provider, model, api_key, base_url, config, enabled, created_at, updated_at
+		SELECT id, name, provider, model, api_key, base_url, config, enabled, owner, prompt_prefix, prompt_suffix, auth_failures, disabled_reason, created_at, updated_at
 		FROM llms`
-	args := []interface{}{}
+	where, args := listConditions(ctx, enabled)
+	query += where
 
-	if enabled != nil {
-		query += " WHERE enabled = ?"
-		args = append(args, *enabled)
-	}
-
-	query += " ORDER BY created_at DESC"
+	query += " ORDER BY created_at DESC, id"
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -243,6 +358,11 @@ func (s *SQLite) ListLLMs(ctx context.Context, enabled *bool) ([]*models.LLMConf
 			&llm.BaseURL,
 			&configJSON,
 			&llm.Enabled,
+			&llm.Owner,
+			&llm.PromptPrefix,
+			&llm.PromptSuffix,
+			&llm.AuthFailures,
+			&llm.DisabledReason,
 			&llm.CreatedAt,
 			&llm.UpdatedAt,
 		)
@@ -263,7 +383,7 @@ func (s *SQLite) UpdateLLM(ctx context.Context, llm *models.LLMConfig) error {
 
 	query := `
 		UPDATE llms 
-		SET name = ?, provider = ?, model = ?, api_key = ?, base_url = ?, config = ?, enabled = ?, updated_at = ?
+		SET name = ?, provider = ?, model = ?, api_key = ?, base_url = ?, config = ?, enabled = ?, owner = ?, prompt_prefix = ?, prompt_suffix = ?, auth_failures = ?, disabled_reason = ?, updated_at = ?
 		WHERE id = ?`
 
 	result, err := s.db.ExecContext(ctx, query,
@@ -274,6 +394,11 @@ func (s *SQLite) UpdateLLM(ctx context.Context, llm *models.LLMConfig) error {
 		llm.BaseURL,
 		mapToJSON(llm.Config),
 		llm.Enabled,
+		llm.Owner,
+		llm.PromptPrefix,
+		llm.PromptSuffix,
+		llm.AuthFailures,
+		llm.DisabledReason,
 		llm.UpdatedAt,
 		llm.ID,
 	)
@@ -294,6 +419,26 @@ func (s *SQLite) UpdateLLM(ctx context.Context, llm *models.LLMConfig) error {
 	return nil
 }
 
+// SetLLMAuthFailures records the consecutive authentication failures of an LLM without touching
+// updated_at
+func (s *SQLite) SetLLMAuthFailures(ctx context.Context, id string, failures int) error {
+	result, err := s.db.ExecContext(ctx, "UPDATE llms SET auth_failures = ? WHERE id = ?", failures, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("LLM not found: %s", id)
+	}
+
+	return nil
+}
+
 // DeleteLLM deletes an LLM configuration
 func (s *SQLite) DeleteLLM(ctx context.Context, id string) error {
 	query := "DELETE FROM llms WHERE id = ?"
@@ -336,19 +481,31 @@ func (s *SQLite) CreateSchedule(ctx context.Context, schedule *models.Schedule)
 	schedule.UpdatedAt = time.Now()
 
 	query := `
-		INSERT INTO schedules (id, name, prompt_ids, llm_ids, cron_expr, temperature, enabled, last_run, next_run, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		INSERT INTO schedules (id, name, prompt_ids, llm_ids, all_prompts, all_llms, cron_expr, temperature, enabled, last_run, next_run, catch_up_policy, catch_up_max, missed_runs, seed, shuffle, check_language, prompt_weights, sample_count, owner, last_error, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err := s.db.ExecContext(ctx, query,
 		schedule.ID,
 		schedule.Name,
 		sliceToJSON(schedule.PromptIDs),
 		sliceToJSON(schedule.LLMIDs),
+		schedule.AllPrompts,
+		schedule.AllLLMs,
 		schedule.CronExpr,
 		schedule.Temperature,
 		schedule.Enabled,
 		schedule.LastRun,
 		schedule.NextRun,
+		catchUpPolicyOrDefault(schedule.CatchUpPolicy),
+		schedule.CatchUpMax,
+		schedule.MissedRuns,
+		schedule.Seed,
+		schedule.Shuffle,
+		schedule.CheckLanguage,
+		weightsToJSON(schedule.PromptWeights),
+		schedule.SampleCount,
+		schedule.Owner,
+		schedule.LastError,
 		schedule.CreatedAt,
 		schedule.UpdatedAt,
 	)
@@ -359,22 +516,34 @@ func (s *SQLite) CreateSchedule(ctx context.Context, schedule *models.Schedule)
 // GetSchedule retrieves a schedule by ID
 func (s *SQLite) GetSchedule(ctx context.Context, id string) (*models.Schedule, error) {
 	query := `
-		SELECT id, name, prompt_ids, llm_ids, cron_expr, temperature, enabled, last_run, next_run, created_at, updated_at
+		SELECT id, name, prompt_ids, llm_ids, all_prompts, all_llms, cron_expr, temperature, enabled, last_run, next_run, catch_up_policy, catch_up_max, missed_runs, seed, shuffle, check_language, prompt_weights, sample_count, owner, last_error, created_at, updated_at
 		FROM schedules WHERE id = ?`
 
 	var schedule models.Schedule
-	var promptIDsJSON, llmIDsJSON string
+	var promptIDsJSON, llmIDsJSON, promptWeightsJSON string
 
 	err := s.db.QueryRowContext(ctx, query, id).Scan(
 		&schedule.ID,
 		&schedule.Name,
 		&promptIDsJSON,
 		&llmIDsJSON,
+		&schedule.AllPrompts,
+		&schedule.AllLLMs,
 		&schedule.CronExpr,
 		&schedule.Temperature,
 		&schedule.Enabled,
 		&schedule.LastRun,
 		&schedule.NextRun,
+		&schedule.CatchUpPolicy,
+		&schedule.CatchUpMax,
+		&schedule.MissedRuns,
+		&schedule.Seed,
+		&schedule.Shuffle,
+		&schedule.CheckLanguage,
+		&promptWeightsJSON,
+		&schedule.SampleCount,
+		&schedule.Owner,
+		&schedule.LastError,
 		&schedule.CreatedAt,
 		&schedule.UpdatedAt,
 	)
@@ -388,22 +557,19 @@ func (s *SQLite) GetSchedule(ctx context.Context, id string) (*models.Schedule,
 
 	schedule.PromptIDs = jsonToSlice(promptIDsJSON)
 	schedule.LLMIDs = jsonToSlice(llmIDsJSON)
+	schedule.PromptWeights = jsonToWeights(promptWeightsJSON)
 	return &schedule, nil
 }
 
 // ListSchedules lists all schedules, optionally filtered by enabled status
 func (s *SQLite) ListSchedules(ctx context.Context, enabled *bool) ([]*models.Schedule, error) {
 	query := `
-		SELECT id, name, prompt_ids, llm_ids, cron_expr, temperature, enabled, last_run, next_run, created_at, updated_at
+		SELECT id, name, prompt_ids, llm_ids, all_prompts, all_llms, cron_expr, temperature, enabled, last_run, next_run, catch_up_policy, catch_up_max, missed_runs, seed, shuffle, check_language, prompt_weights, sample_count, owner, last_error, created_at, updated_at
 		FROM schedules`
-	args := []interface{}{}
+	where, args := listConditions(ctx, enabled)
+	query += where
 
-	if enabled != nil {
-		query += " WHERE enabled = ?"
-		args = append(args, *enabled)
-	}
-
-	query += " ORDER BY created_at DESC"
+	query += " ORDER BY created_at DESC, id"
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -414,18 +580,30 @@ func (s *SQLite) ListSchedules(ctx context.Context, enabled *bool) ([]*models.Sc
 	var schedules []*models.Schedule
 	for rows.Next() {
 		var schedule models.Schedule
-		var promptIDsJSON, llmIDsJSON string
+		var promptIDsJSON, llmIDsJSON, promptWeightsJSON string
 
 		err := rows.Scan(
 			&schedule.ID,
 			&schedule.Name,
 			&promptIDsJSON,
 			&llmIDsJSON,
+			&schedule.AllPrompts,
+			&schedule.AllLLMs,
 			&schedule.CronExpr,
 			&schedule.Temperature,
 			&schedule.Enabled,
 			&schedule.LastRun,
 			&schedule.NextRun,
+			&schedule.CatchUpPolicy,
+			&schedule.CatchUpMax,
+			&schedule.MissedRuns,
+			&schedule.Seed,
+			&schedule.Shuffle,
+			&schedule.CheckLanguage,
+			&promptWeightsJSON,
+			&schedule.SampleCount,
+			&schedule.Owner,
+			&schedule.LastError,
 			&schedule.CreatedAt,
 			&schedule.UpdatedAt,
 		)
@@ -435,6 +613,7 @@ func (s *SQLite) ListSchedules(ctx context.Context, enabled *bool) ([]*models.Sc
 
 		schedule.PromptIDs = jsonToSlice(promptIDsJSON)
 		schedule.LLMIDs = jsonToSlice(llmIDsJSON)
+		schedule.PromptWeights = jsonToWeights(promptWeightsJSON)
 		schedules = append(schedules, &schedule)
 	}
 
@@ -447,18 +626,30 @@ func (s *SQLite) UpdateSchedule(ctx context.Context, schedule *models.Schedule)
 
 	query := `
 		UPDATE schedules 
-		SET name = ?, prompt_ids = ?, llm_ids = ?, cron_expr = ?, temperature = ?, enabled = ?, last_run = ?, next_run = ?, updated_at = ?
+		SET name = ?, prompt_ids = ?, llm_ids = ?, all_prompts = ?, all_llms = ?, cron_expr = ?, temperature = ?, enabled = ?, last_run = ?, next_run = ?, catch_up_policy = ?, catch_up_max = ?, missed_runs = ?, seed = ?, shuffle = ?, check_language = ?, prompt_weights = ?, sample_count = ?, owner = ?, last_error = ?, updated_at = ?
 		WHERE id = ?`
 
 	result, err := s.db.ExecContext(ctx, query,
 		schedule.Name,
 		sliceToJSON(schedule.PromptIDs),
 		sliceToJSON(schedule.LLMIDs),
+		schedule.AllPrompts,
+		schedule.AllLLMs,
 		schedule.CronExpr,
 		schedule.Temperature,
 		schedule.Enabled,
 		schedule.LastRun,
 		schedule.NextRun,
+		catchUpPolicyOrDefault(schedule.CatchUpPolicy),
+		schedule.CatchUpMax,
+		schedule.MissedRuns,
+		schedule.Seed,
+		schedule.Shuffle,
+		schedule.CheckLanguage,
+		weightsToJSON(schedule.PromptWeights),
+		schedule.SampleCount,
+		schedule.Owner,
+		schedule.LastError,
 		schedule.UpdatedAt,
 		schedule.ID,
 	)
@@ -499,6 +690,26 @@ func (s *SQLite) DeleteSchedule(ctx context.Context, id string) error {
 	return nil
 }
 
+// SetScheduleError records the registration error of a schedule without touching updated_at,
+// which catch-up counts missed runs from
+func (s *SQLite) SetScheduleError(ctx context.Context, id, message string) error {
+	result, err := s.db.ExecContext(ctx, "UPDATE schedules SET last_error = ? WHERE id = ?", message, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("schedule not found: %s", id)
+	}
+
+	return nil
+}
+
 // DeleteAllSchedules deletes all schedules
 func (s *SQLite) DeleteAllSchedules(ctx context.Context) (int, error) {
 	query := "DELETE FROM schedules"
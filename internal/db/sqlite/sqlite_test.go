@@ -0,0 +1,109 @@
+package sqlite_test
+
+import (
+	"context"
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/db/sqlite"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// newTestSQLite returns a migrated SQLite database in a temporary directory
+func newTestSQLite(t *testing.T) *sqlite.SQLite {
+	t.Helper()
+	ctx := context.Background()
+
+	s, err := sqlite.New(&models.Config{URI: filepath.Join(t.TempDir(), "gego.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Disconnect(context.Background()) })
+
+	if err := db.RunMigrations(ctx, s.GetDB(), "../migrations"); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestLLMConfigRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+
+	config := map[string]string{
+		"web_search":       "true",
+		"seed":             "42",
+		"reasoning_effort": "high",
+		"response_format":  "json_object",
+		"stop_sequences":   `["###", "END"]`,
+		"note":             "quote \" backslash \\ newline \n unicode é",
+	}
+	if err := s.CreateLLM(ctx, &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", Config: config, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.GetLLM(ctx, "llm-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !maps.Equal(got.Config, config) {
+		t.Errorf("GetLLM config = %v, want %v", got.Config, config)
+	}
+
+	listed, err := s.ListLLMs(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || !maps.Equal(listed[0].Config, config) {
+		t.Errorf("ListLLMs config = %v, want %v", listed, config)
+	}
+
+	got.Config = map[string]string{"seed": "7"}
+	if err := s.UpdateLLM(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := s.GetLLM(ctx, "llm-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !maps.Equal(updated.Config, got.Config) {
+		t.Errorf("updated config = %v, want %v", updated.Config, got.Config)
+	}
+}
+
+func TestScheduleIDsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+
+	promptIDs := []string{"prompt,1", `prompt "2"`, "prompt-3"}
+	schedule := &models.Schedule{ID: "schedule-1", Name: "Daily", PromptIDs: promptIDs, LLMIDs: []string{"llm-1"}, CronExpr: "0 9 * * *", Enabled: true}
+	if err := s.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.GetSchedule(ctx, "schedule-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.PromptIDs, promptIDs) || !slices.Equal(got.LLMIDs, schedule.LLMIDs) {
+		t.Errorf("IDs = %q %q, want %q %q", got.PromptIDs, got.LLMIDs, promptIDs, schedule.LLMIDs)
+	}
+}
+
+func TestSchemaVersionIsLatest(t *testing.T) {
+	s := newTestSQLite(t)
+
+	version, dirty, err := db.SchemaVersion(context.Background(), s.GetDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != db.LatestSchemaVersion || dirty {
+		t.Errorf("schema version = %d (dirty %t) after migrating, want LatestSchemaVersion %d", version, dirty, db.LatestSchemaVersion)
+	}
+}
@@ -0,0 +1,150 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage is the language of the reference catalog every message key must exist in
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var (
+	mu       sync.RWMutex
+	language = DefaultLanguage
+	catalogs = mustLoadCatalogs()
+)
+
+// mustLoadCatalogs parses the embedded locales/<lang>.json catalogs, keyed by language
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: failed to read catalogs: %v", err))
+	}
+
+	loaded := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: failed to read %s: %v", entry.Name(), err))
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog %s: %v", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+	return loaded
+}
+
+// Languages returns the languages with a catalog, sorted
+func Languages() []string {
+	languages := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// SetLanguage selects the language of translated messages
+func SetLanguage(lang string) error {
+	lang = normalize(lang)
+	if _, ok := catalogs[lang]; !ok {
+		return fmt.Errorf("unsupported language %q (supported: %s)", lang, strings.Join(Languages(), ", "))
+	}
+
+	mu.Lock()
+	language = lang
+	mu.Unlock()
+	return nil
+}
+
+// Language returns the selected language
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return language
+}
+
+// Detect returns the language to use: flag if set, then GEGO_LANG, then the OS locale
+// (LC_ALL, LC_MESSAGES, LANG). Locales without a catalog fall back to DefaultLanguage.
+func Detect(flag string) string {
+	if flag != "" {
+		return normalize(flag)
+	}
+	if lang := os.Getenv("GEGO_LANG"); lang != "" {
+		return normalize(lang)
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if lang := normalize(value); catalogs[lang] != nil {
+			return lang
+		}
+		return DefaultLanguage
+	}
+	return DefaultLanguage
+}
+
+// normalize reduces a locale such as fr_FR.UTF-8 or fr-CA to its language code
+func normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "_-.@"); i >= 0 {
+		locale = locale[:i]
+	}
+	if locale == "c" || locale == "posix" {
+		return DefaultLanguage
+	}
+	return locale
+}
+
+// T returns the message for key in the selected language, formatted with args like fmt.Sprintf.
+// Keys missing from the selected catalog fall back to the default one, and unknown keys are
+// returned as is so they stand out.
+func T(key string, args ...interface{}) string {
+	message, ok := catalogs[Language()][key]
+	if !ok {
+		message, ok = catalogs[DefaultLanguage][key]
+	}
+	if !ok {
+		return key
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// MissingKeys returns the keys of the default catalog that lang does not translate, sorted
+func MissingKeys(lang string) []string {
+	catalog := catalogs[normalize(lang)]
+
+	var missing []string
+	for key := range catalogs[DefaultLanguage] {
+		if _, ok := catalog[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// HasKey reports whether key exists in the default catalog
+func HasKey(key string) bool {
+	_, ok := catalogs[DefaultLanguage][key]
+	return ok
+}
@@ -0,0 +1,120 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// formatVerbPattern matches the fmt verbs of a message, such as %s, %d or %.1f
+var formatVerbPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	if len(catalogs[DefaultLanguage]) == 0 {
+		t.Fatalf("default catalog %s is empty", DefaultLanguage)
+	}
+
+	for _, lang := range Languages() {
+		if missing := MissingKeys(lang); len(missing) > 0 {
+			t.Errorf("%s is missing %q", lang, missing)
+		}
+		for key := range catalogs[lang] {
+			if !HasKey(key) {
+				t.Errorf("%s has %q, which is not in the %s catalog", lang, key, DefaultLanguage)
+			}
+		}
+	}
+}
+
+func TestCatalogsHaveTheSameFormatVerbs(t *testing.T) {
+	for key, message := range catalogs[DefaultLanguage] {
+		want := formatVerbPattern.FindAllString(message, -1)
+		for _, lang := range Languages() {
+			translated, ok := catalogs[lang][key]
+			if !ok {
+				continue
+			}
+			if got := formatVerbPattern.FindAllString(translated, -1); !slices.Equal(got, want) {
+				t.Errorf("%s %q has verbs %q, want %q like %s", lang, key, got, want, DefaultLanguage)
+			}
+		}
+	}
+}
+
+// usedKeyPattern matches the keys passed to T in the Go sources
+var usedKeyPattern = regexp.MustCompile(`i18n\.T\("([a-z0-9_.]+)"`)
+
+func TestUsedKeysExist(t *testing.T) {
+	used := 0
+	err := filepath.WalkDir("..", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(path, ".go") {
+			return err
+		}
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range usedKeyPattern.FindAllStringSubmatch(string(source), -1) {
+			used++
+			if !HasKey(match[1]) {
+				t.Errorf("%s uses %q, which is not in the %s catalog", path, match[1], DefaultLanguage)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used == 0 {
+		t.Error("found no i18n.T calls")
+	}
+}
+
+func TestT(t *testing.T) {
+	defer SetLanguage(Language())
+
+	if err := SetLanguage("fr_FR.UTF-8"); err != nil {
+		t.Fatal(err)
+	}
+	if Language() != "fr" {
+		t.Errorf("language = %s, want fr", Language())
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key = %q, want the key itself", got)
+	}
+	if err := SetLanguage("xx"); err == nil {
+		t.Error("SetLanguage accepted a language without a catalog")
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		flag string
+		env  map[string]string
+		want string
+	}{
+		{name: "flag", flag: "FR", env: map[string]string{"GEGO_LANG": "en"}, want: "fr"},
+		{name: "GEGO_LANG", env: map[string]string{"GEGO_LANG": "fr-CA", "LANG": "en_US.UTF-8"}, want: "fr"},
+		{name: "LANG", env: map[string]string{"LANG": "fr_FR.UTF-8"}, want: "fr"},
+		{name: "LC_ALL before LANG", env: map[string]string{"LC_ALL": "en_GB.UTF-8", "LANG": "fr_FR.UTF-8"}, want: "en"},
+		{name: "POSIX locale", env: map[string]string{"LANG": "C"}, want: DefaultLanguage},
+		{name: "locale without a catalog", env: map[string]string{"LANG": "de_DE.UTF-8"}, want: DefaultLanguage},
+		{name: "nothing set", want: DefaultLanguage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"GEGO_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+				t.Setenv(name, tt.env[name])
+			}
+			if got := Detect(tt.flag); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.flag, got, tt.want)
+			}
+		})
+	}
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/AI2HU/gego/internal/models"
 )
 
+// structuredToolName is the tool Anthropic is forced to call to return structured output
+const structuredToolName = "structured_response"
+
 // Provider implements the LLM Provider interface for Anthropic
 type Provider struct {
 	apiKey  string
@@ -21,7 +24,11 @@ type Provider struct {
 }
 
 // New creates a new Anthropic provider
-func New(apiKey, baseURL string) *Provider {
+func New(apiKey, baseURL string) (*Provider, error) {
+	baseURL, err := llm.ValidateBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
 	if baseURL == "" {
 		baseURL = "https://api.anthropic.com/v1"
 	}
@@ -29,10 +36,8 @@ func New(apiKey, baseURL string) *Provider {
 	return &Provider{
 		apiKey:  apiKey,
 		baseURL: baseURL,
-		client: &http.Client{
-			Timeout: 60 * time.Second,
-		},
-	}
+		client:  llm.NewHTTPClient(60 * time.Second),
+	}, nil
 }
 
 // Name returns the provider name
@@ -40,6 +45,11 @@ func (p *Provider) Name() string {
 	return "anthropic"
 }
 
+// Capabilities returns the optional features supported by Anthropic
+func (p *Provider) Capabilities() llm.Capabilities {
+	return llm.Capabilities{StructuredOutput: true, MaxStopSequences: llm.UnlimitedStopSequences}
+}
+
 // Validate validates the provider configuration
 func (p *Provider) Validate(config map[string]string) error {
 	if config["api_key"] == "" {
@@ -51,6 +61,7 @@ func (p *Provider) Validate(config map[string]string) error {
 // Generate sends a prompt to Anthropic and returns the response
 func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
 	startTime := time.Now()
+	ctx, requestID := llm.EnsureRequestID(ctx)
 
 	model := "claude-3-7-sonnet-20250219"
 	if config.Model != "" {
@@ -71,6 +82,19 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 		"temperature": temperature,
 		"max_tokens":  maxTokens,
 	}
+	if len(config.StopSequences) > 0 {
+		requestBody["stop_sequences"] = config.StopSequences
+	}
+	if len(config.ResponseSchema) > 0 {
+		// Anthropic has no JSON output mode: forcing a tool call makes the model answer with
+		// the tool input, which follows the tool's input schema
+		requestBody["tools"] = []map[string]interface{}{{
+			"name":         structuredToolName,
+			"description":  "Respond with the requested structured data.",
+			"input_schema": config.ResponseSchema,
+		}}
+		requestBody["tool_choice"] = map[string]string{"type": "tool", "name": structuredToolName}
+	}
 
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
@@ -103,7 +127,9 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 
 	var anthropicResp struct {
 		Content []struct {
-			Text string `json:"text"`
+			Type  string          `json:"type"`
+			Text  string          `json:"text"`
+			Input json.RawMessage `json:"input"`
 		} `json:"content"`
 		Usage struct {
 			InputTokens  int `json:"input_tokens"`
@@ -122,12 +148,21 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 
 	totalTokens := anthropicResp.Usage.InputTokens + anthropicResp.Usage.OutputTokens
 
+	text := anthropicResp.Content[0].Text
+	for _, content := range anthropicResp.Content {
+		if content.Type == "tool_use" {
+			text = string(content.Input)
+			break
+		}
+	}
+
 	return &llm.Response{
-		Text:       anthropicResp.Content[0].Text,
+		Text:       text,
 		TokensUsed: totalTokens,
 		LatencyMs:  time.Since(startTime).Milliseconds(),
 		Model:      anthropicResp.Model,
 		Provider:   "anthropic",
+		Metadata:   map[string]interface{}{llm.MetadataRequestID: requestID},
 	}, nil
 }
 
@@ -140,9 +175,7 @@ func (p *Provider) ListModels(ctx context.Context, apiKey, baseURL string) ([]mo
 		baseURL = p.baseURL
 	}
 
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+	client := llm.NewHTTPClient(30 * time.Second)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+"/models", nil)
 	if err != nil {
@@ -0,0 +1,60 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/llm"
+)
+
+func TestGenerateStopSequences(t *testing.T) {
+	tests := []struct {
+		name  string
+		stops []string
+	}{
+		{name: "no stop sequences"},
+		{name: "stop sequences", stops: []string{"###", "END"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body struct {
+				StopSequences []string `json:"stop_sequences"`
+			}
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				data, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(data, &body); err != nil {
+					t.Errorf("request body: %v", err)
+				}
+				io.WriteString(w, `{"content":[{"type":"text","text":"Acme"}],"usage":{"input_tokens":5,"output_tokens":1},"model":"claude-sonnet-4-5"}`)
+			}))
+			defer server.Close()
+
+			provider, err := New("sk-ant-test", server.URL+"/v1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			response, err := provider.Generate(context.Background(), "What is the best tool?", llm.Config{Model: "claude-sonnet-4-5", StopSequences: tt.stops})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if path != "/v1/messages" {
+				t.Errorf("path = %s, want /v1/messages", path)
+			}
+			if response.Text != "Acme" || response.TokensUsed != 6 {
+				t.Errorf("response = %q (%d tokens), want Acme (6 tokens)", response.Text, response.TokensUsed)
+			}
+			if !slices.Equal(body.StopSequences, tt.stops) {
+				t.Errorf("stop_sequences = %q, want %q", body.StopSequences, tt.stops)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package llm
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// ValidateBaseURL validates a provider base URL and returns it without trailing slashes. Paths are
+// kept, as gateways often mount providers under a prefix such as /v1.
+// An empty value is allowed and means the provider default is used.
+func ValidateBaseURL(baseURL string) (string, error) {
+	baseURL = strings.TrimSpace(baseURL)
+	if baseURL == "" {
+		return "", nil
+	}
+
+	if strings.IndexFunc(baseURL, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("invalid base URL %q: must not contain whitespace", baseURL)
+	}
+	if !strings.Contains(baseURL, "://") {
+		return "", fmt.Errorf("invalid base URL %q: missing scheme (did you mean http://%s?)", baseURL, baseURL)
+	}
+
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+	if parsed.Hostname() == "" {
+		return "", fmt.Errorf("invalid base URL %q: missing host, e.g. http://localhost:11434 or http://[::1]:11434", baseURL)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("invalid base URL %q: must not contain a query or fragment", baseURL)
+	}
+
+	return strings.TrimRight(baseURL, "/"), nil
+}
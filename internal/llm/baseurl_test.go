@@ -0,0 +1,47 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+		wantErr string
+	}{
+		{name: "empty uses the provider default", baseURL: "", want: ""},
+		{name: "blank uses the provider default", baseURL: "   ", want: ""},
+		{name: "https", baseURL: "https://api.openai.com/v1", want: "https://api.openai.com/v1"},
+		{name: "trailing slashes removed", baseURL: "http://localhost:11434//", want: "http://localhost:11434"},
+		{name: "surrounding whitespace trimmed", baseURL: " https://gateway.example.com/openai/v1 ", want: "https://gateway.example.com/openai/v1"},
+		{name: "IPv6 host", baseURL: "http://[::1]:11434", want: "http://[::1]:11434"},
+		{name: "missing scheme", baseURL: "localhost:11434", wantErr: "missing scheme"},
+		{name: "unsupported scheme", baseURL: "ftp://example.com", wantErr: "scheme must be http or https"},
+		{name: "missing host", baseURL: "http://", wantErr: "missing host"},
+		{name: "missing host with port", baseURL: "http://:11434", wantErr: "missing host"},
+		{name: "inner whitespace", baseURL: "http://local host:11434", wantErr: "must not contain whitespace"},
+		{name: "query", baseURL: "https://example.com/v1?key=abc", wantErr: "query or fragment"},
+		{name: "fragment", baseURL: "https://example.com/v1#top", wantErr: "query or fragment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateBaseURL(tt.baseURL)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ValidateBaseURL(%q) error = %v, want it to contain %q", tt.baseURL, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateBaseURL(%q): %v", tt.baseURL, err)
+			}
+			if got != tt.want {
+				t.Errorf("ValidateBaseURL(%q) = %q, want %q", tt.baseURL, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,480 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrock"
+	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrock/types"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// DefaultRegion is used when neither the base URL nor AWS_REGION / AWS_DEFAULT_REGION name a region
+const DefaultRegion = "us-east-1"
+
+// DefaultModel is used when the LLM config does not name a model
+const DefaultModel = "anthropic.claude-3-haiku-20240307-v1:0"
+
+// Model families with a distinct Bedrock request schema
+const (
+	FamilyAnthropic = "anthropic"
+	FamilyTitan     = "titan"
+	FamilyLlama     = "llama"
+	FamilyMistral   = "mistral"
+	FamilyCohere    = "cohere"
+)
+
+// regionPattern matches AWS region names such as us-east-1 or ap-southeast-2
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+
+// runtimeHostPattern captures the region of a Bedrock runtime endpoint host
+var runtimeHostPattern = regexp.MustCompile(`^bedrock-runtime(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com$`)
+
+// Config is the Bedrock endpoint configuration of a provider
+type Config struct {
+	// Region is the AWS region hosting the models
+	Region string
+	// BaseURL overrides the runtime endpoint (e.g. a VPC endpoint); empty means the public endpoint of Region
+	BaseURL string
+	// ControlURL overrides the control plane endpoint listing models; empty means the public endpoint of the region
+	ControlURL string
+}
+
+// Provider implements the LLM Provider interface for AWS Bedrock with the AWS SDK, authenticating
+// with the standard AWS credential chain: environment variables, shared config and credentials
+// files (profiles, SSO), and container or instance roles
+type Provider struct {
+	config Config
+
+	awsConfigMu sync.Mutex
+	awsConfig   *aws.Config // Loaded on first use
+}
+
+// New creates a new Bedrock provider. baseURL is the runtime endpoint of a region, e.g.
+// https://bedrock-runtime.eu-west-1.amazonaws.com; empty uses the region of the environment.
+func New(baseURL string) (*Provider, error) {
+	baseURL, err := llm.ValidateBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithConfig(Config{Region: RegionFromBaseURL(baseURL), BaseURL: baseURL})
+}
+
+// NewWithConfig creates a new Bedrock provider from an explicit endpoint configuration
+func NewWithConfig(config Config) (*Provider, error) {
+	if config.Region == "" {
+		config.Region = EnvironmentRegion()
+	}
+	if !ValidRegion(config.Region) {
+		return nil, fmt.Errorf("invalid AWS region: %s", config.Region)
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = RuntimeURL(config.Region)
+	}
+
+	return &Provider{config: config}, nil
+}
+
+// ValidRegion reports whether region looks like an AWS region name
+func ValidRegion(region string) bool {
+	return regionPattern.MatchString(region)
+}
+
+// EnvironmentRegion returns the region of AWS_REGION or AWS_DEFAULT_REGION, or DefaultRegion
+func EnvironmentRegion() string {
+	for _, name := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if region := strings.TrimSpace(os.Getenv(name)); region != "" {
+			return region
+		}
+	}
+	return DefaultRegion
+}
+
+// RuntimeURL returns the public Bedrock runtime endpoint of region
+func RuntimeURL(region string) string {
+	return fmt.Sprintf("https://bedrock-runtime.%s.amazonaws.com", region)
+}
+
+// loadAWSConfig resolves the shared AWS configuration once; credentials are retrieved, and
+// refreshed, by the SDK when requests are signed. Requests go through the gego provider
+// transport on top of the SDK one, which keeps settings such as AWS_CA_BUNDLE.
+func (p *Provider) loadAWSConfig(ctx context.Context) (aws.Config, error) {
+	p.awsConfigMu.Lock()
+	defer p.awsConfigMu.Unlock()
+
+	if p.awsConfig == nil {
+		cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(p.config.Region))
+		if err != nil {
+			return aws.Config{}, fmt.Errorf("failed to load AWS configuration: %w", err)
+		}
+		var base http.RoundTripper
+		if buildable, ok := cfg.HTTPClient.(*awshttp.BuildableClient); ok {
+			base = buildable.GetTransport()
+		}
+		cfg.HTTPClient = &http.Client{
+			Timeout:   120 * time.Second,
+			Transport: &llm.Transport{Base: base},
+		}
+		p.awsConfig = &cfg
+	}
+	return *p.awsConfig, nil
+}
+
+// apiError adds the HTTP status of a failed AWS call to err, as llm.ClassifyError reads it
+func apiError(err error) error {
+	var responseErr *awshttp.ResponseError
+	if errors.As(err, &responseErr) {
+		return fmt.Errorf("Bedrock API error (HTTP %d): %w", responseErr.HTTPStatusCode(), err)
+	}
+	return fmt.Errorf("Bedrock API error: %w", err)
+}
+
+// RegionFromBaseURL returns the region of a public Bedrock runtime endpoint, or "" for any other URL
+func RegionFromBaseURL(baseURL string) string {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	if match := runtimeHostPattern.FindStringSubmatch(parsed.Hostname()); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "bedrock"
+}
+
+// Capabilities returns the optional features supported by Bedrock
+func (p *Provider) Capabilities() llm.Capabilities {
+	return llm.Capabilities{MaxStopSequences: llm.UnlimitedStopSequences}
+}
+
+// Validate validates the provider configuration
+func (p *Provider) Validate(config map[string]string) error {
+	// Bedrock authenticates with AWS credentials rather than an API key
+	if region := config["region"]; region != "" && !ValidRegion(region) {
+		return fmt.Errorf("invalid AWS region: %s", region)
+	}
+	return nil
+}
+
+// ModelFamily returns the request schema family of a Bedrock model ID, accepting
+// cross-region inference profile IDs such as us.anthropic.claude-3-5-sonnet-20240620-v1:0
+func ModelFamily(modelID string) (string, error) {
+	id := strings.ToLower(modelID)
+	for _, prefix := range []string{"us.", "eu.", "apac.", "us-gov.", "global."} {
+		id = strings.TrimPrefix(id, prefix)
+	}
+
+	switch {
+	case strings.HasPrefix(id, "anthropic."):
+		return FamilyAnthropic, nil
+	case strings.HasPrefix(id, "amazon.titan-text"):
+		return FamilyTitan, nil
+	case strings.HasPrefix(id, "meta.llama"):
+		return FamilyLlama, nil
+	case strings.HasPrefix(id, "mistral."):
+		return FamilyMistral, nil
+	case strings.HasPrefix(id, "cohere.command-r"):
+		return FamilyCohere, nil
+	default:
+		return "", fmt.Errorf("unsupported Bedrock model: %s (supported: Anthropic Claude, Amazon Titan Text, Meta Llama, Mistral, Cohere Command R)", modelID)
+	}
+}
+
+// BuildRequestBody returns the InvokeModel request body for a prompt in the schema of the model's family
+func BuildRequestBody(modelID, prompt string, config llm.Config) ([]byte, error) {
+	family, err := ModelFamily(modelID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxTokens := config.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = 1000
+	}
+
+	var body map[string]interface{}
+	switch family {
+	case FamilyAnthropic:
+		body = map[string]interface{}{
+			"anthropic_version": "bedrock-2023-05-31",
+			"max_tokens":        maxTokens,
+			"messages": []map[string]string{
+				{"role": "user", "content": prompt},
+			},
+			"temperature": config.Temperature,
+		}
+		if len(config.StopSequences) > 0 {
+			body["stop_sequences"] = config.StopSequences
+		}
+	case FamilyTitan:
+		generationConfig := map[string]interface{}{
+			"maxTokenCount": maxTokens,
+			"temperature":   config.Temperature,
+		}
+		if len(config.StopSequences) > 0 {
+			generationConfig["stopSequences"] = config.StopSequences
+		}
+		body = map[string]interface{}{
+			"inputText":            prompt,
+			"textGenerationConfig": generationConfig,
+		}
+	case FamilyLlama:
+		if len(config.StopSequences) > 0 {
+			return nil, fmt.Errorf("stop sequences are not supported by Meta Llama models on Bedrock")
+		}
+		body = map[string]interface{}{
+			"prompt":      llamaPrompt(modelID, prompt),
+			"max_gen_len": maxTokens,
+			"temperature": config.Temperature,
+		}
+	case FamilyMistral:
+		body = map[string]interface{}{
+			"prompt":      fmt.Sprintf("<s>[INST] %s [/INST]", prompt),
+			"max_tokens":  maxTokens,
+			"temperature": config.Temperature,
+		}
+		if len(config.StopSequences) > 0 {
+			body["stop"] = config.StopSequences
+		}
+	case FamilyCohere:
+		body = map[string]interface{}{
+			"message":     prompt,
+			"max_tokens":  maxTokens,
+			"temperature": config.Temperature,
+		}
+		if len(config.StopSequences) > 0 {
+			body["stop_sequences"] = config.StopSequences
+		}
+	}
+
+	return json.Marshal(body)
+}
+
+// llamaPrompt wraps a prompt in the chat template the Llama model generation expects
+func llamaPrompt(modelID, prompt string) string {
+	if strings.Contains(strings.ToLower(modelID), "llama2") {
+		return fmt.Sprintf("<s>[INST] %s [/INST]", prompt)
+	}
+	return fmt.Sprintf("<|begin_of_text|><|start_header_id|>user<|end_header_id|>\n\n%s<|eot_id|><|start_header_id|>assistant<|end_header_id|>\n\n", prompt)
+}
+
+// ParseResponseBody extracts the generated text and token usage from an InvokeModel response body.
+// Mistral and Cohere bodies carry no token usage, which is then 0.
+func ParseResponseBody(modelID string, body []byte) (string, int, error) {
+	family, err := ModelFamily(modelID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	switch family {
+	case FamilyAnthropic:
+		var resp struct {
+			Content []struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"content"`
+			Usage struct {
+				InputTokens  int `json:"input_tokens"`
+				OutputTokens int `json:"output_tokens"`
+			} `json:"usage"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return "", 0, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+
+		var text strings.Builder
+		for _, block := range resp.Content {
+			if block.Type == "text" {
+				text.WriteString(block.Text)
+			}
+		}
+		return text.String(), resp.Usage.InputTokens + resp.Usage.OutputTokens, nil
+	case FamilyTitan:
+		var resp struct {
+			InputTextTokenCount int `json:"inputTextTokenCount"`
+			Results             []struct {
+				TokenCount int    `json:"tokenCount"`
+				OutputText string `json:"outputText"`
+			} `json:"results"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return "", 0, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		if len(resp.Results) == 0 {
+			return "", 0, fmt.Errorf("no results returned from API")
+		}
+		return resp.Results[0].OutputText, resp.InputTextTokenCount + resp.Results[0].TokenCount, nil
+	case FamilyMistral:
+		var resp struct {
+			Outputs []struct {
+				Text string `json:"text"`
+			} `json:"outputs"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return "", 0, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		if len(resp.Outputs) == 0 {
+			return "", 0, fmt.Errorf("no outputs returned from API")
+		}
+		return resp.Outputs[0].Text, 0, nil
+	case FamilyCohere:
+		var resp struct {
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return "", 0, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		return resp.Text, 0, nil
+	default:
+		var resp struct {
+			Generation           string `json:"generation"`
+			PromptTokenCount     int    `json:"prompt_token_count"`
+			GenerationTokenCount int    `json:"generation_token_count"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return "", 0, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		return resp.Generation, resp.PromptTokenCount + resp.GenerationTokenCount, nil
+	}
+}
+
+// Generate sends a prompt to a Bedrock model with InvokeModel and returns the response
+func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
+	startTime := time.Now()
+	ctx, requestID := llm.EnsureRequestID(ctx)
+
+	model := DefaultModel
+	if config.Model != "" {
+		model = config.Model
+	}
+
+	jsonBody, err := BuildRequestBody(model, prompt, config)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := p.loadAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := bedrockruntime.NewFromConfig(cfg, func(o *bedrockruntime.Options) {
+		if p.config.BaseURL != RuntimeURL(p.config.Region) {
+			o.BaseEndpoint = aws.String(p.config.BaseURL)
+		}
+	})
+
+	output, err := client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		ModelId:     aws.String(model),
+		Body:        jsonBody,
+		ContentType: aws.String("application/json"),
+		Accept:      aws.String("application/json"),
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	text, tokensUsed, err := ParseResponseBody(model, output.Body)
+	if err != nil {
+		return nil, err
+	}
+	if tokensUsed == 0 {
+		tokensUsed = headerTokenCount(output.ResultMetadata)
+	}
+
+	metadata := map[string]interface{}{llm.MetadataRequestID: requestID}
+	if awsRequestID, ok := awsmiddleware.GetRequestIDMetadata(output.ResultMetadata); ok {
+		metadata["aws_request_id"] = awsRequestID
+	}
+
+	return &llm.Response{
+		Text:       text,
+		TokensUsed: tokensUsed,
+		LatencyMs:  time.Since(startTime).Milliseconds(),
+		Model:      model,
+		Provider:   "bedrock",
+		Metadata:   metadata,
+	}, nil
+}
+
+// headerTokenCount returns the input plus output token counts Bedrock reports in the headers of
+// an InvokeModel response, or 0 when they are missing
+func headerTokenCount(metadata middleware.Metadata) int {
+	response, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response)
+	if !ok {
+		return 0
+	}
+	input, _ := strconv.Atoi(response.Header.Get("X-Amzn-Bedrock-Input-Token-Count"))
+	output, _ := strconv.Atoi(response.Header.Get("X-Amzn-Bedrock-Output-Token-Count"))
+	return input + output
+}
+
+// ListModels lists the active on-demand text models of the supported families with
+// ListFoundationModels. apiKey is unused; baseURL selects the region.
+func (p *Provider) ListModels(ctx context.Context, apiKey, baseURL string) ([]models.ModelInfo, error) {
+	region := p.config.Region
+	if baseURL != "" {
+		if baseRegion := RegionFromBaseURL(baseURL); baseRegion != "" {
+			region = baseRegion
+		}
+	}
+
+	cfg, err := p.loadAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := bedrock.NewFromConfig(cfg, func(o *bedrock.Options) {
+		o.Region = region
+		if p.config.ControlURL != "" {
+			o.BaseEndpoint = aws.String(p.config.ControlURL)
+		}
+	})
+
+	output, err := client.ListFoundationModels(ctx, &bedrock.ListFoundationModelsInput{
+		ByInferenceType:  bedrocktypes.InferenceTypeOnDemand,
+		ByOutputModality: bedrocktypes.ModelModalityText,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", apiError(err))
+	}
+
+	var textModels []models.ModelInfo
+	for _, model := range output.ModelSummaries {
+		if model.ModelLifecycle != nil && model.ModelLifecycle.Status != bedrocktypes.FoundationModelLifecycleStatusActive {
+			continue
+		}
+		modelID := aws.ToString(model.ModelId)
+		if _, err := ModelFamily(modelID); err != nil {
+			continue
+		}
+		textModels = append(textModels, models.ModelInfo{
+			ID:          modelID,
+			Name:        modelID,
+			Description: fmt.Sprintf("%s %s (%s)", aws.ToString(model.ProviderName), aws.ToString(model.ModelName), region),
+		})
+	}
+
+	return textModels, nil
+}
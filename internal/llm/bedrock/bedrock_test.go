@@ -0,0 +1,432 @@
+package bedrock
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/AI2HU/gego/internal/llm"
+)
+
+// Credentials of the AWS Signature Version 4 test suite
+const (
+	testAccessKeyID     = "AKIDEXAMPLE"
+	testSecretAccessKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+)
+
+// setTestCredentials points the AWS credential chain at the test credentials only
+func setTestCredentials(t *testing.T) {
+	t.Helper()
+	missing := filepath.Join(t.TempDir(), "missing")
+	t.Setenv("AWS_ACCESS_KEY_ID", testAccessKeyID)
+	t.Setenv("AWS_SECRET_ACCESS_KEY", testSecretAccessKey)
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_CONFIG_FILE", missing)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", missing)
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+}
+
+// assertJSONEqual fails unless got and want encode the same JSON value
+func assertJSONEqual(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("invalid expected JSON %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestModelFamily(t *testing.T) {
+	tests := []struct {
+		modelID string
+		want    string
+		wantErr bool
+	}{
+		{modelID: "anthropic.claude-3-haiku-20240307-v1:0", want: FamilyAnthropic},
+		{modelID: "us.anthropic.claude-3-5-sonnet-20241022-v2:0", want: FamilyAnthropic},
+		{modelID: "amazon.titan-text-express-v1", want: FamilyTitan},
+		{modelID: "meta.llama3-8b-instruct-v1:0", want: FamilyLlama},
+		{modelID: "mistral.mistral-large-2402-v1:0", want: FamilyMistral},
+		{modelID: "cohere.command-r-plus-v1:0", want: FamilyCohere},
+		{modelID: "cohere.embed-english-v3", wantErr: true},
+		{modelID: "ai21.j2-ultra-v1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.modelID, func(t *testing.T) {
+			got, err := ModelFamily(tt.modelID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ModelFamily(%q) error = %v, wantErr %v", tt.modelID, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ModelFamily(%q) = %q, want %q", tt.modelID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildRequestBody(t *testing.T) {
+	config := llm.Config{Temperature: 0.5, MaxTokens: 200, StopSequences: []string{"END"}}
+
+	tests := []struct {
+		name    string
+		modelID string
+		config  llm.Config
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "anthropic",
+			modelID: "anthropic.claude-3-haiku-20240307-v1:0",
+			config:  config,
+			want: `{"anthropic_version":"bedrock-2023-05-31","max_tokens":200,"temperature":0.5,
+"messages":[{"role":"user","content":"Best tool?"}],"stop_sequences":["END"]}`,
+		},
+		{
+			name:    "titan",
+			modelID: "amazon.titan-text-express-v1",
+			config:  config,
+			want: `{"inputText":"Best tool?",
+"textGenerationConfig":{"maxTokenCount":200,"temperature":0.5,"stopSequences":["END"]}}`,
+		},
+		{
+			name:    "llama 3 with the default max tokens",
+			modelID: "meta.llama3-8b-instruct-v1:0",
+			config:  llm.Config{Temperature: 0.5},
+			want: `{"prompt":"<|begin_of_text|><|start_header_id|>user<|end_header_id|>\n\nBest tool?<|eot_id|><|start_header_id|>assistant<|end_header_id|>\n\n",
+"max_gen_len":1000,"temperature":0.5}`,
+		},
+		{
+			name:    "llama 2",
+			modelID: "meta.llama2-13b-chat-v1",
+			config:  llm.Config{Temperature: 0.5, MaxTokens: 200},
+			want:    `{"prompt":"<s>[INST] Best tool? [/INST]","max_gen_len":200,"temperature":0.5}`,
+		},
+		{
+			name:    "llama rejects stop sequences",
+			modelID: "meta.llama3-8b-instruct-v1:0",
+			config:  config,
+			wantErr: true,
+		},
+		{
+			name:    "mistral",
+			modelID: "mistral.mistral-large-2402-v1:0",
+			config:  config,
+			want:    `{"prompt":"<s>[INST] Best tool? [/INST]","max_tokens":200,"temperature":0.5,"stop":["END"]}`,
+		},
+		{
+			name:    "cohere",
+			modelID: "cohere.command-r-v1:0",
+			config:  config,
+			want:    `{"message":"Best tool?","max_tokens":200,"temperature":0.5,"stop_sequences":["END"]}`,
+		},
+		{
+			name:    "unsupported model",
+			modelID: "ai21.j2-ultra-v1",
+			config:  config,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildRequestBody(tt.modelID, "Best tool?", tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildRequestBody() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				assertJSONEqual(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseResponseBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		modelID    string
+		body       string
+		wantText   string
+		wantTokens int
+		wantErr    bool
+	}{
+		{
+			name:    "anthropic keeps text blocks",
+			modelID: "anthropic.claude-3-haiku-20240307-v1:0",
+			body: `{"id":"msg_1","type":"message","role":"assistant","content":[{"type":"text","text":"Acme "},
+{"type":"tool_use","id":"t1","name":"search","input":{}},{"type":"text","text":"wins"}],
+"stop_reason":"end_turn","usage":{"input_tokens":12,"output_tokens":3}}`,
+			wantText:   "Acme wins",
+			wantTokens: 15,
+		},
+		{
+			name:       "titan",
+			modelID:    "amazon.titan-text-express-v1",
+			body:       `{"inputTextTokenCount":4,"results":[{"tokenCount":2,"outputText":"Acme","completionReason":"FINISH"}]}`,
+			wantText:   "Acme",
+			wantTokens: 6,
+		},
+		{
+			name:    "titan without results",
+			modelID: "amazon.titan-text-express-v1",
+			body:    `{"inputTextTokenCount":4,"results":[]}`,
+			wantErr: true,
+		},
+		{
+			name:       "llama",
+			modelID:    "meta.llama3-8b-instruct-v1:0",
+			body:       `{"generation":"Acme","prompt_token_count":9,"generation_token_count":1,"stop_reason":"stop"}`,
+			wantText:   "Acme",
+			wantTokens: 10,
+		},
+		{
+			name:     "mistral",
+			modelID:  "mistral.mistral-large-2402-v1:0",
+			body:     `{"outputs":[{"text":"Acme","stop_reason":"stop"}]}`,
+			wantText: "Acme",
+		},
+		{
+			name:    "mistral without outputs",
+			modelID: "mistral.mistral-large-2402-v1:0",
+			body:    `{"outputs":[]}`,
+			wantErr: true,
+		},
+		{
+			name:     "cohere",
+			modelID:  "cohere.command-r-v1:0",
+			body:     `{"response_id":"r1","text":"Acme","generation_id":"g1","finish_reason":"COMPLETE"}`,
+			wantText: "Acme",
+		},
+		{
+			name:    "malformed body",
+			modelID: "cohere.command-r-v1:0",
+			body:    `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, tokens, err := ParseResponseBody(tt.modelID, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseResponseBody() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if text != tt.wantText || tokens != tt.wantTokens {
+				t.Errorf("ParseResponseBody() = %q, %d, want %q, %d", text, tokens, tt.wantText, tt.wantTokens)
+			}
+		})
+	}
+}
+
+// TestSignerKnownVector checks the signer used for Bedrock requests against the get-vanilla
+// case of the AWS Signature Version 4 test suite
+func TestSignerKnownVector(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingTime := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	emptyPayloadHash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	credentials := aws.Credentials{AccessKeyID: testAccessKeyID, SecretAccessKey: testSecretAccessKey}
+
+	if err := v4.NewSigner().SignHTTP(context.Background(), credentials, req, emptyPayloadHash, "service", "us-east-1", signingTime); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+}
+
+// verifySignature re-signs the signed headers of a received request with the test credentials
+// and fails unless the signature matches
+func verifySignature(t *testing.T, r *http.Request, region string) {
+	t.Helper()
+	authorization := r.Header.Get("Authorization")
+	signingTime, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		t.Fatalf("X-Amz-Date: %v", err)
+	}
+	wantScope := "Credential=" + testAccessKeyID + "/" + signingTime.Format("20060102") + "/" + region + "/bedrock/aws4_request"
+	if !strings.Contains(authorization, wantScope) {
+		t.Fatalf("Authorization = %q, want scope %q", authorization, wantScope)
+	}
+
+	_, signedHeaders, _ := strings.Cut(authorization, "SignedHeaders=")
+	signedHeaders, _, _ = strings.Cut(signedHeaders, ",")
+
+	resigned, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resigned.ContentLength = r.ContentLength
+	for _, name := range strings.Split(signedHeaders, ";") {
+		if name != "host" {
+			resigned.Header[http.CanonicalHeaderKey(name)] = r.Header.Values(name)
+		}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	payloadHash := sha256.Sum256(body)
+
+	credentials := aws.Credentials{AccessKeyID: testAccessKeyID, SecretAccessKey: testSecretAccessKey}
+	if err := v4.NewSigner().SignHTTP(context.Background(), credentials, resigned, hex.EncodeToString(payloadHash[:]), "bedrock", region, signingTime); err != nil {
+		t.Fatal(err)
+	}
+	if got := resigned.Header.Get("Authorization"); got != authorization {
+		t.Errorf("Authorization = %q, want %q", authorization, got)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name       string
+		model      string
+		header     map[string]string
+		response   string
+		wantText   string
+		wantTokens int
+	}{
+		{
+			name:       "usage from the body",
+			model:      "anthropic.claude-3-haiku-20240307-v1:0",
+			response:   `{"content":[{"type":"text","text":"Acme"}],"usage":{"input_tokens":12,"output_tokens":3}}`,
+			wantText:   "Acme",
+			wantTokens: 15,
+		},
+		{
+			name:  "usage from the headers",
+			model: "mistral.mistral-large-2402-v1:0",
+			header: map[string]string{
+				"X-Amzn-Bedrock-Input-Token-Count":  "9",
+				"X-Amzn-Bedrock-Output-Token-Count": "2",
+			},
+			response:   `{"outputs":[{"text":"Acme","stop_reason":"stop"}]}`,
+			wantText:   "Acme",
+			wantTokens: 11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestCredentials(t)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if want := "/model/" + tt.model + "/invoke"; r.Method != http.MethodPost || r.URL.Path != want {
+					t.Errorf("request = %s %s, want POST %s", r.Method, r.URL.Path, want)
+				}
+				verifySignature(t, r, "eu-west-1")
+				data, _ := io.ReadAll(r.Body)
+				want, _ := BuildRequestBody(tt.model, "Best tool?", llm.Config{Model: tt.model, Temperature: 0.2})
+				assertJSONEqual(t, data, string(want))
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("X-Amzn-RequestId", "aws-request-1")
+				for name, value := range tt.header {
+					w.Header().Set(name, value)
+				}
+				io.WriteString(w, tt.response)
+			}))
+			defer server.Close()
+
+			provider, err := NewWithConfig(Config{Region: "eu-west-1", BaseURL: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := provider.Generate(context.Background(), "Best tool?", llm.Config{Model: tt.model, Temperature: 0.2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Text != tt.wantText || resp.TokensUsed != tt.wantTokens || resp.Model != tt.model {
+				t.Errorf("response = %q, %d tokens, model %q, want %q, %d tokens, model %q",
+					resp.Text, resp.TokensUsed, resp.Model, tt.wantText, tt.wantTokens, tt.model)
+			}
+			if got := resp.Metadata["aws_request_id"]; got != "aws-request-1" {
+				t.Errorf("aws_request_id = %v, want aws-request-1", got)
+			}
+		})
+	}
+}
+
+func TestGenerateHTTPError(t *testing.T) {
+	setTestCredentials(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Amzn-ErrorType", "AccessDeniedException")
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"message":"You don't have access to the model with the specified model ID."}`)
+	}))
+	defer server.Close()
+
+	provider, err := NewWithConfig(Config{Region: "us-east-1", BaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = provider.Generate(context.Background(), "Best tool?", llm.Config{Model: DefaultModel})
+	if err == nil || !strings.Contains(err.Error(), "HTTP 403") {
+		t.Errorf("error = %v, want the HTTP status", err)
+	}
+}
+
+func TestListModels(t *testing.T) {
+	setTestCredentials(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/foundation-models" {
+			t.Errorf("path = %s, want /foundation-models", r.URL.Path)
+		}
+		if got := r.URL.Query(); got.Get("byInferenceType") != "ON_DEMAND" || got.Get("byOutputModality") != "TEXT" {
+			t.Errorf("query = %s, want on-demand text models", r.URL.RawQuery)
+		}
+		verifySignature(t, r, "eu-west-1")
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"modelSummaries":[
+{"modelArn":"arn:1","modelId":"anthropic.claude-3-haiku-20240307-v1:0","modelName":"Claude 3 Haiku","providerName":"Anthropic","modelLifecycle":{"status":"ACTIVE"}},
+{"modelArn":"arn:2","modelId":"anthropic.claude-v2","modelName":"Claude","providerName":"Anthropic","modelLifecycle":{"status":"LEGACY"}},
+{"modelArn":"arn:3","modelId":"ai21.j2-ultra-v1","modelName":"Jurassic-2 Ultra","providerName":"AI21 Labs","modelLifecycle":{"status":"ACTIVE"}},
+{"modelArn":"arn:4","modelId":"mistral.mistral-large-2402-v1:0","modelName":"Mistral Large","providerName":"Mistral AI","modelLifecycle":{"status":"ACTIVE"}}]}`)
+	}))
+	defer server.Close()
+
+	provider, err := NewWithConfig(Config{Region: "us-east-1", ControlURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := provider.ListModels(context.Background(), "", RuntimeURL("eu-west-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, model := range list {
+		ids = append(ids, model.ID)
+	}
+	want := []string{"anthropic.claude-3-haiku-20240307-v1:0", "mistral.mistral-large-2402-v1:0"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("models = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,167 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// MetadataRawCapture is the Response.Metadata key holding the raw provider exchanges of a response
+const MetadataRawCapture = "raw_capture"
+
+// maxRawCaptureBytes is the number of bytes of each request and response body captured
+const maxRawCaptureBytes = 256 * 1024
+
+// redacted replaces secrets in captured bodies and URLs
+const redacted = "[REDACTED]"
+
+var (
+	// secretFieldPattern matches JSON string fields whose name suggests a secret
+	secretFieldPattern = regexp.MustCompile(`("(?i:[a-z_-]*api[_-]?key|key|[a-z_-]*token|secret|password|authorization)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// secretQueryParams are the URL query parameters redacted from captured URLs
+	secretQueryParams = []string{"key", "api_key", "apikey", "token", "access_token"}
+	// secretHeaderPattern matches the names of headers carrying credentials
+	secretHeaderPattern = regexp.MustCompile(`(?i)^(authorization|proxy-authorization|cookie|.*api-?key.*|.*token.*|.*secret.*)$`)
+)
+
+// RawExchange is a provider HTTP request and its response, as sent and received, with secrets redacted
+type RawExchange struct {
+	Method   string            `json:"method" bson:"method"`
+	URL      string            `json:"url" bson:"url"`
+	Headers  map[string]string `json:"headers,omitempty" bson:"headers,omitempty"`
+	Status   int               `json:"status,omitempty" bson:"status,omitempty"`
+	Request  string            `json:"request,omitempty" bson:"request,omitempty"`
+	Response string            `json:"response,omitempty" bson:"response,omitempty"`
+	Error    string            `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+// RawCapture collects the raw exchanges of the provider calls made with its context
+type RawCapture struct {
+	secrets   []string
+	mu        sync.Mutex
+	exchanges []RawExchange
+}
+
+type rawCaptureKey struct{}
+
+// WithRawCapture returns a context whose provider calls are captured, with secrets such as the
+// API key of the LLM redacted from the captured bodies
+func WithRawCapture(ctx context.Context, secrets ...string) (context.Context, *RawCapture) {
+	capture := &RawCapture{}
+	for _, secret := range secrets {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			capture.secrets = append(capture.secrets, secret)
+		}
+	}
+	return context.WithValue(ctx, rawCaptureKey{}, capture), capture
+}
+
+// Exchanges returns the exchanges captured so far, in call order
+func (c *RawCapture) Exchanges() []RawExchange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]RawExchange(nil), c.exchanges...)
+}
+
+// Redact removes the capture's secrets and the values of secret-looking JSON fields from text
+func (c *RawCapture) Redact(text string) string {
+	for _, secret := range c.secrets {
+		text = strings.ReplaceAll(text, secret, redacted)
+	}
+	return secretFieldPattern.ReplaceAllString(text, `$1"`+redacted+`"`)
+}
+
+// redactURL removes the capture's secrets and secret query parameters from a URL
+func (c *RawCapture) redactURL(u *url.URL) string {
+	clean := *u
+	clean.User = nil
+	query := clean.Query()
+	for _, param := range secretQueryParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+		}
+	}
+	clean.RawQuery = strings.ReplaceAll(query.Encode(), url.QueryEscape(redacted), redacted)
+	return c.Redact(clean.String())
+}
+
+// redactHeaders returns the request headers, with credential headers and the capture's secrets redacted
+func (c *RawCapture) redactHeaders(header http.Header) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if secretHeaderPattern.MatchString(name) {
+			headers[name] = redacted
+			continue
+		}
+		headers[name] = c.Redact(strings.Join(values, ", "))
+	}
+	return headers
+}
+
+// captureRequest reads the body of req, leaving it readable for the transport
+func (c *RawCapture) captureRequest(req *http.Request) RawExchange {
+	exchange := RawExchange{Method: req.Method, URL: c.redactURL(req.URL), Headers: c.redactHeaders(req.Header)}
+	if req.Body == nil || req.Body == http.NoBody {
+		return exchange
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		exchange.Error = err.Error()
+		return exchange
+	}
+	exchange.Request = c.Redact(truncateCapture(body))
+	return exchange
+}
+
+// captureResponse records the status and the first maxRawCaptureBytes of the body of resp,
+// leaving the whole body readable for the provider client
+func (c *RawCapture) captureResponse(exchange RawExchange, resp *http.Response, err error) {
+	if err != nil {
+		exchange.Error = err.Error()
+	} else {
+		exchange.Status = resp.StatusCode
+		if resp.Body != nil {
+			head, readErr := io.ReadAll(io.LimitReader(resp.Body, maxRawCaptureBytes+1))
+			resp.Body = readCloser{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+			if readErr != nil {
+				exchange.Error = readErr.Error()
+			}
+			exchange.Response = c.Redact(truncateCapture(head))
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.exchanges = append(c.exchanges, exchange)
+}
+
+// truncateCapture returns body as text, cut to maxRawCaptureBytes
+func truncateCapture(body []byte) string {
+	if len(body) > maxRawCaptureBytes {
+		return string(body[:maxRawCaptureBytes]) + "…[truncated]"
+	}
+	return string(body)
+}
+
+// rawCaptureFromContext returns the capture of ctx, if any
+func rawCaptureFromContext(ctx context.Context) *RawCapture {
+	capture, _ := ctx.Value(rawCaptureKey{}).(*RawCapture)
+	return capture
+}
+
+// readCloser reads from a reader and closes an underlying body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,133 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testAPIKey = "sk-test-0123456789abcdef"
+
+// newEchoServer answers every request with a body quoting the API key it was sent
+func newEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"echo":` + string(body) + `,"note":"called with ` + testAPIKey + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// post sends body to url through the provider transport, with the API key in the given header
+func post(t *testing.T, ctx context.Context, url, header, value, body string) string {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	resp, err := NewHTTPClient(5 * time.Second).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	received, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(received)
+}
+
+func TestRawCaptureOnlyWhenEnabled(t *testing.T) {
+	server := newEchoServer(t)
+	body := `{"model":"gpt-4o"}`
+
+	if capture := rawCaptureFromContext(context.Background()); capture != nil {
+		t.Fatalf("capture without WithRawCapture = %v, want nil", capture)
+	}
+
+	ctx, capture := WithRawCapture(context.Background(), testAPIKey)
+	received := post(t, ctx, server.URL, "", "", body)
+	post(t, ctx, server.URL+"/second", "", "", body)
+
+	// The provider client still reads the whole, unredacted response
+	if !strings.Contains(received, testAPIKey) {
+		t.Errorf("provider read %q, want the response untouched", received)
+	}
+	exchanges := capture.Exchanges()
+	if len(exchanges) != 2 {
+		t.Fatalf("captured %d exchanges, want the 2 made with the capture context", len(exchanges))
+	}
+	if exchanges[0].Method != http.MethodPost || exchanges[0].URL != server.URL || exchanges[0].Status != http.StatusOK || exchanges[0].Request != body {
+		t.Errorf("first exchange = %+v", exchanges[0])
+	}
+	if exchanges[1].URL != server.URL+"/second" {
+		t.Errorf("second exchange URL = %q, want call order kept", exchanges[1].URL)
+	}
+}
+
+func TestRawCaptureRedactsAPIKeys(t *testing.T) {
+	server := newEchoServer(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		header  string
+		value   string
+		body    string
+		wantURL string
+	}{
+		{name: "OpenAI bearer token", header: "Authorization", value: "Bearer " + testAPIKey, body: `{"model":"gpt-4o"}`},
+		{name: "Anthropic x-api-key", header: "x-api-key", value: testAPIKey, body: `{"model":"claude"}`},
+		{name: "Google key in header", header: "x-goog-api-key", value: testAPIKey, body: `{"contents":[]}`},
+		{
+			name:    "Google key in query",
+			path:    "/v1beta/models/gemini:generateContent?alt=json&key=" + testAPIKey,
+			body:    `{"contents":[]}`,
+			wantURL: "/v1beta/models/gemini:generateContent?alt=json&key=[REDACTED]",
+		},
+		{name: "secret JSON field", body: `{"model":"gpt-4o","api_key":"other-secret","access_token":"other-token"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, capture := WithRawCapture(context.Background(), testAPIKey)
+			post(t, ctx, server.URL+tt.path, tt.header, tt.value, tt.body)
+
+			exchanges := capture.Exchanges()
+			if len(exchanges) != 1 {
+				t.Fatalf("captured %d exchanges, want 1", len(exchanges))
+			}
+			exchange := exchanges[0]
+			if tt.wantURL != "" && exchange.URL != server.URL+tt.wantURL {
+				t.Errorf("URL = %q, want %q", exchange.URL, server.URL+tt.wantURL)
+			}
+			if tt.header != "" && exchange.Headers[http.CanonicalHeaderKey(tt.header)] != redacted {
+				t.Errorf("%s header = %q, want %q", tt.header, exchange.Headers[http.CanonicalHeaderKey(tt.header)], redacted)
+			}
+			if exchange.Headers["Content-Type"] != "application/json" {
+				t.Errorf("Content-Type header = %q, want other headers kept", exchange.Headers["Content-Type"])
+			}
+
+			captured, err := json.Marshal(exchange)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, secret := range []string{testAPIKey, "other-secret", "other-token"} {
+				if strings.Contains(string(captured), secret) {
+					t.Errorf("capture leaks %q: %s", secret, captured)
+				}
+			}
+		})
+	}
+}
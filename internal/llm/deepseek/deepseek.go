@@ -0,0 +1,218 @@
+package deepseek
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// DefaultBaseURL is the DeepSeek API endpoint
+const DefaultBaseURL = "https://api.deepseek.com"
+
+// MetadataReasoningContent is the response metadata key holding deepseek-reasoner's chain of thought
+const MetadataReasoningContent = "reasoning_content"
+
+// Provider implements the LLM Provider interface for DeepSeek's OpenAI-compatible API
+type Provider struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// New creates a new DeepSeek provider
+func New(apiKey, baseURL string) (*Provider, error) {
+	baseURL, err := llm.ValidateBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Provider{
+		apiKey:  apiKey,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  llm.NewHTTPClient(120 * time.Second),
+	}, nil
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "deepseek"
+}
+
+// Capabilities returns the optional features supported by DeepSeek
+func (p *Provider) Capabilities() llm.Capabilities {
+	return llm.Capabilities{JSONMode: true, MaxStopSequences: 16}
+}
+
+// Validate validates the provider configuration
+func (p *Provider) Validate(config map[string]string) error {
+	if config["api_key"] == "" {
+		return fmt.Errorf("api_key is required")
+	}
+	return nil
+}
+
+// Generate sends a prompt to DeepSeek and returns the response. The reasoning of
+// deepseek-reasoner is kept out of the text and stored in the response metadata.
+func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
+	startTime := time.Now()
+	ctx, requestID := llm.EnsureRequestID(ctx)
+
+	model := "deepseek-chat"
+	if config.Model != "" {
+		model = config.Model
+	}
+
+	maxTokens := config.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = 1000
+	}
+
+	requestBody := map[string]interface{}{
+		"model": model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+		"temperature": config.Temperature,
+		"max_tokens":  maxTokens,
+		"stream":      false,
+	}
+	if len(config.StopSequences) > 0 {
+		requestBody["stop"] = config.StopSequences
+	}
+	if config.ResponseFormat == llm.ResponseFormatJSONObject {
+		requestBody["response_format"] = map[string]string{"type": "json_object"}
+	}
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/chat/completions", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DeepSeek API error (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	var chatResp struct {
+		Model   string `json:"model"`
+		Choices []struct {
+			Message struct {
+				Content          string `json:"content"`
+				ReasoningContent string `json:"reasoning_content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			TotalTokens int `json:"total_tokens"`
+		} `json:"usage"`
+	}
+
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if len(chatResp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices returned from API")
+	}
+
+	message := chatResp.Choices[0].Message
+	metadata := map[string]interface{}{llm.MetadataRequestID: requestID}
+	if message.ReasoningContent != "" {
+		metadata[MetadataReasoningContent] = message.ReasoningContent
+	}
+
+	if chatResp.Model != "" {
+		model = chatResp.Model
+	}
+
+	return &llm.Response{
+		Text:       message.Content,
+		TokensUsed: chatResp.Usage.TotalTokens,
+		LatencyMs:  time.Since(startTime).Milliseconds(),
+		Model:      model,
+		Provider:   "deepseek",
+		Metadata:   metadata,
+	}, nil
+}
+
+// modelDescriptions describes the models returned by DeepSeek's models endpoint
+var modelDescriptions = map[string]string{
+	"deepseek-chat":     "General chat model (DeepSeek-V3)",
+	"deepseek-reasoner": "Reasoning model (DeepSeek-R1), returns its chain of thought separately",
+}
+
+// ListModels lists available models from DeepSeek
+func (p *Provider) ListModels(ctx context.Context, apiKey, baseURL string) ([]models.ModelInfo, error) {
+	if apiKey == "" {
+		apiKey = p.apiKey
+	}
+	if baseURL == "" {
+		baseURL = p.baseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimSuffix(baseURL, "/")+"/models", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	resp, err := llm.NewHTTPClient(30 * time.Second).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DeepSeek API error (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	var listResp struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &listResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	modelList := make([]models.ModelInfo, 0, len(listResp.Data))
+	for _, model := range listResp.Data {
+		modelList = append(modelList, models.ModelInfo{
+			ID:          model.ID,
+			Name:        model.ID,
+			Description: modelDescriptions[model.ID],
+		})
+	}
+	return modelList, nil
+}
@@ -0,0 +1,120 @@
+package deepseek
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/llm"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        llm.Config
+		response      string
+		wantText      string
+		wantReasoning string
+		wantFormat    bool
+	}{
+		{
+			name:     "chat model",
+			config:   llm.Config{Temperature: 0.7},
+			response: `{"model":"deepseek-chat","choices":[{"message":{"role":"assistant","content":"Acme"}}],"usage":{"total_tokens":12}}`,
+			wantText: "Acme",
+		},
+		{
+			name:   "reasoner keeps its reasoning out of the text",
+			config: llm.Config{Model: "deepseek-reasoner", Temperature: 0.7, StopSequences: []string{"END"}, ResponseFormat: llm.ResponseFormatJSONObject},
+			response: `{"model":"deepseek-reasoner","choices":[{"message":{"role":"assistant",
+"content":"{\"brand\":\"Acme\"}","reasoning_content":"Acme and Globex both fit; Acme is cheaper."}}],"usage":{"total_tokens":80}}`,
+			wantText:      `{"brand":"Acme"}`,
+			wantReasoning: "Acme and Globex both fit; Acme is cheaper.",
+			wantFormat:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body struct {
+				Model          string              `json:"model"`
+				Messages       []map[string]string `json:"messages"`
+				Temperature    float64             `json:"temperature"`
+				MaxTokens      int                 `json:"max_tokens"`
+				Stream         bool                `json:"stream"`
+				Stop           []string            `json:"stop"`
+				ResponseFormat map[string]string   `json:"response_format"`
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/chat/completions" {
+					t.Errorf("path = %s, want /chat/completions", r.URL.Path)
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer sk-deepseek-test" {
+					t.Errorf("Authorization = %q, want the bearer API key", got)
+				}
+				data, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(data, &body); err != nil {
+					t.Errorf("request body: %v", err)
+				}
+				io.WriteString(w, tt.response)
+			}))
+			defer server.Close()
+
+			provider, err := New("sk-deepseek-test", server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response, err := provider.Generate(context.Background(), "What is the best tool?", tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wantModel := tt.config.Model
+			if wantModel == "" {
+				wantModel = "deepseek-chat"
+			}
+			if body.Model != wantModel || len(body.Messages) != 1 || body.Messages[0]["role"] != "user" || body.Messages[0]["content"] != "What is the best tool?" {
+				t.Errorf("request model and messages = %s %v", body.Model, body.Messages)
+			}
+			if body.Temperature != tt.config.Temperature || body.MaxTokens != 1000 || body.Stream {
+				t.Errorf("request temperature %v, max_tokens %d, stream %t", body.Temperature, body.MaxTokens, body.Stream)
+			}
+			if len(body.Stop) != len(tt.config.StopSequences) {
+				t.Errorf("stop = %v, want %v", body.Stop, tt.config.StopSequences)
+			}
+			if gotFormat := body.ResponseFormat["type"] == "json_object"; gotFormat != tt.wantFormat {
+				t.Errorf("response_format = %v, want JSON object %t", body.ResponseFormat, tt.wantFormat)
+			}
+
+			if response.Text != tt.wantText {
+				t.Errorf("text = %q, want %q", response.Text, tt.wantText)
+			}
+			reasoning, _ := response.Metadata[MetadataReasoningContent].(string)
+			if reasoning != tt.wantReasoning {
+				t.Errorf("reasoning = %q, want %q", reasoning, tt.wantReasoning)
+			}
+			if response.Model != wantModel || response.Provider != "deepseek" {
+				t.Errorf("model %s from %s, want %s from deepseek", response.Model, response.Provider, wantModel)
+			}
+		})
+	}
+}
+
+func TestGenerateHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		io.WriteString(w, `{"error":{"message":"Insufficient Balance"}}`)
+	}))
+	defer server.Close()
+
+	provider, err := New("sk-deepseek-test", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.Generate(context.Background(), "What is the best tool?", llm.Config{}); err == nil {
+		t.Error("Generate succeeded on HTTP 402")
+	}
+}
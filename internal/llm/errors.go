@@ -0,0 +1,150 @@
+package llm
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Classes of provider errors, stored on error responses
+const (
+	// ErrorClassRateLimit means too many requests were sent; retrying later succeeds
+	ErrorClassRateLimit = "rate_limit"
+	// ErrorClassQuota means the quota or credits of the account are exhausted; retrying does not
+	// help until the billing is sorted out
+	ErrorClassQuota = "quota"
+	// ErrorClassAuth means the API key was rejected, for example because it was revoked; retrying
+	// does not help until the key is replaced
+	ErrorClassAuth = "auth"
+)
+
+// Error codes and message fragments of quota and billing errors. OpenAI answers 429 with type
+// insufficient_quota, Anthropic 400 with "credit balance is too low" or type billing_error,
+// DeepSeek 402 "Insufficient Balance" and xAI 429 with "used all available credits".
+var quotaMarkers = []string{
+	"insufficient_quota",
+	"billing_error",
+	"billing_not_active",
+	"exceeded your current quota",
+	"check your plan and billing",
+	"credit balance is too low",
+	"insufficient balance",
+	"insufficient credits",
+	"available credits",
+	"spending limit",
+	"payment required",
+}
+
+// Error codes and message fragments of rate limit errors
+var rateLimitMarkers = []string{
+	"rate_limit",
+	"rate limit",
+	"ratelimit",
+	"too many requests",
+	"resource_exhausted",
+	"throttling",
+	"overloaded",
+}
+
+// Error codes and message fragments of authentication errors
+var authMarkers = []string{
+	"invalid_api_key",
+	"incorrect api key",
+	"invalid api key",
+	"invalid x-api-key",
+	"api key not valid",
+	"authentication_error",
+	"permission_denied",
+	"unauthorized",
+}
+
+// statusPattern matches the HTTP status of provider error messages, as in "HTTP 429" or
+// `POST "https://...": 429 Too Many Requests`
+var statusPattern = regexp.MustCompile(`(?:HTTP |": )(\d{3})\b`)
+
+// providerErrorBody is the JSON error body shape shared by most providers
+type providerErrorBody struct {
+	Type    string `json:"type"`
+	Code    any    `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Error   *struct {
+		Type    string `json:"type"`
+		Code    any    `json:"code"`
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ClassifyError classifies a provider error message as ErrorClassQuota, ErrorClassRateLimit or
+// ErrorClassAuth from the error body it carries, or returns "" for other errors
+func ClassifyError(message string) string {
+	if message == "" {
+		return ""
+	}
+
+	text := strings.ToLower(message)
+	if fields := errorBodyFields(message); fields != "" {
+		text = fields + " " + text
+	}
+	for _, marker := range quotaMarkers {
+		if strings.Contains(text, marker) {
+			return ErrorClassQuota
+		}
+	}
+	for _, marker := range rateLimitMarkers {
+		if strings.Contains(text, marker) {
+			return ErrorClassRateLimit
+		}
+	}
+	for _, marker := range authMarkers {
+		if strings.Contains(text, marker) {
+			return ErrorClassAuth
+		}
+	}
+
+	if match := statusPattern.FindStringSubmatch(message); match != nil {
+		switch status, _ := strconv.Atoi(match[1]); status {
+		case 402:
+			return ErrorClassQuota
+		case 429:
+			return ErrorClassRateLimit
+		case 401, 403:
+			return ErrorClassAuth
+		}
+	}
+	return ""
+}
+
+// errorBodyFields returns the lower-cased type, code, status and message of the JSON error body
+// embedded in message, or "" when it has none
+func errorBodyFields(message string) string {
+	start := strings.Index(message, "{")
+	end := strings.LastIndex(message, "}")
+	if start < 0 || end < start {
+		return ""
+	}
+
+	var body providerErrorBody
+	if err := json.Unmarshal([]byte(message[start:end+1]), &body); err != nil {
+		return ""
+	}
+	fields := []string{body.Type, codeString(body.Code), body.Status, body.Message}
+	if body.Error != nil {
+		fields = append(fields, body.Error.Type, codeString(body.Error.Code), body.Error.Status, body.Error.Message)
+	}
+	return strings.ToLower(strings.Join(fields, " "))
+}
+
+// codeString renders an error code, which providers send as a string or a number
+func codeString(code any) string {
+	switch code := code.(type) {
+	case string:
+		return code
+	case float64:
+		return strconv.Itoa(int(code))
+	default:
+		return ""
+	}
+}
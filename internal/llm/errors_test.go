@@ -0,0 +1,70 @@
+package llm
+
+import "testing"
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name: "OpenAI insufficient quota",
+			message: `OpenAI API error: POST "https://api.openai.com/v1/chat/completions": 429 Too Many Requests {
+    "message": "You exceeded your current quota, please check your plan and billing details. For more information on this error, read the docs: https://platform.openai.com/docs/guides/error-codes/api-errors.",
+    "type": "insufficient_quota",
+    "param": null,
+    "code": "insufficient_quota"
+}`,
+			want: ErrorClassQuota,
+		},
+		{
+			name: "OpenAI rate limit",
+			message: `OpenAI API error: POST "https://api.openai.com/v1/chat/completions": 429 Too Many Requests {
+    "message": "Rate limit reached for gpt-4o in organization org-abc123 on tokens per min (TPM): Limit 30000, Used 29877, Requested 512. Please try again in 778ms.",
+    "type": "tokens",
+    "param": null,
+    "code": "rate_limit_exceeded"
+}`,
+			want: ErrorClassRateLimit,
+		},
+		{
+			name:    "Anthropic rate limit",
+			message: `Anthropic API error (rate_limit_error): This request would exceed the rate limit for your organization (8a9b0c1d) of 50 requests per minute. For details, refer to: https://docs.anthropic.com/en/api/rate-limits.`,
+			want:    ErrorClassRateLimit,
+		},
+		{
+			name:    "Anthropic credit balance",
+			message: `Anthropic API error (invalid_request_error): Your credit balance is too low to access the Anthropic API. Please go to Plans & Billing to upgrade or purchase credits.`,
+			want:    ErrorClassQuota,
+		},
+		{
+			name:    "Anthropic raw credit balance body",
+			message: `HTTP 400: {"type":"error","error":{"type":"invalid_request_error","message":"Your credit balance is too low to access the Anthropic API. Please go to Plans & Billing to upgrade or purchase credits."}}`,
+			want:    ErrorClassQuota,
+		},
+		{
+			name:    "Anthropic invalid key",
+			message: `Anthropic API error (authentication_error): invalid x-api-key`,
+			want:    ErrorClassAuth,
+		},
+		{
+			name:    "status only",
+			message: `Bedrock API error (HTTP 429): operation error Bedrock Runtime: InvokeModel, https response error StatusCode: 429`,
+			want:    ErrorClassRateLimit,
+		},
+		{
+			name:    "server error",
+			message: `HTTP 500: {"type":"error","error":{"type":"api_error","message":"Internal server error"}}`,
+		},
+		{name: "no error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.message); got != tt.want {
+				t.Errorf("ClassifyError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -20,10 +20,16 @@ type Provider struct {
 }
 
 // New creates a new Google provider
-func New(apiKey, baseURL string) *Provider {
+func New(apiKey, baseURL string) (*Provider, error) {
+	baseURL, err := llm.ValidateBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
 	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
-		APIKey:  apiKey,
-		Backend: genai.BackendGeminiAPI,
+		APIKey:     apiKey,
+		Backend:    genai.BackendGeminiAPI,
+		HTTPClient: llm.NewHTTPClient(0),
 	})
 	if err != nil {
 		client = nil
@@ -33,7 +39,7 @@ func New(apiKey, baseURL string) *Provider {
 		apiKey:  apiKey,
 		baseURL: baseURL,
 		client:  client,
-	}
+	}, nil
 }
 
 // Name returns the provider name
@@ -41,6 +47,11 @@ func (p *Provider) Name() string {
 	return "google"
 }
 
+// Capabilities returns the optional features supported by Google AI
+func (p *Provider) Capabilities() llm.Capabilities {
+	return llm.Capabilities{JSONMode: true, StructuredOutput: true, Seed: true, WebSearch: true}
+}
+
 // Validate validates the provider configuration
 func (p *Provider) Validate(config map[string]string) error {
 	if config["api_key"] == "" {
@@ -52,6 +63,7 @@ func (p *Provider) Validate(config map[string]string) error {
 // Generate sends a prompt to Google AI and returns the response
 func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
 	startTime := time.Now()
+	ctx, requestID := llm.EnsureRequestID(ctx)
 
 	model := "gemini-1.5-flash"
 	if config.Model != "" {
@@ -62,8 +74,9 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 	if client == nil {
 		var err error
 		client, err = genai.NewClient(ctx, &genai.ClientConfig{
-			APIKey:  p.apiKey,
-			Backend: genai.BackendGeminiAPI,
+			APIKey:     p.apiKey,
+			Backend:    genai.BackendGeminiAPI,
+			HTTPClient: llm.NewHTTPClient(0),
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Google client: %w", err)
@@ -83,6 +96,20 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 		TopP:        float32Ptr(float32(config.TopP)),
 		TopK:        float32Ptr(float32(config.TopK)),
 	}
+	if config.ResponseFormat == llm.ResponseFormatJSONObject {
+		generationConfig.ResponseMIMEType = "application/json"
+	}
+	if len(config.ResponseSchema) > 0 {
+		generationConfig.ResponseMIMEType = "application/json"
+		generationConfig.ResponseJsonSchema = config.ResponseSchema
+	}
+	if config.Seed != nil {
+		seed := int32(*config.Seed)
+		generationConfig.Seed = &seed
+	}
+	if config.WebSearch {
+		generationConfig.Tools = []*genai.Tool{{GoogleSearch: &genai.GoogleSearch{}}}
+	}
 
 	result, err := client.Models.GenerateContent(ctx, model, content, generationConfig)
 	if err != nil {
@@ -101,20 +128,36 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 		tokensUsed = int(result.UsageMetadata.TotalTokenCount)
 	}
 
+	metadata := map[string]interface{}{llm.MetadataRequestID: requestID}
+	if config.WebSearch && len(result.Candidates) > 0 && result.Candidates[0].GroundingMetadata != nil {
+		var urls []string
+		for _, chunk := range result.Candidates[0].GroundingMetadata.GroundingChunks {
+			if chunk != nil && chunk.Web != nil {
+				urls = append(urls, chunk.Web.URI)
+			}
+		}
+		if citations := llm.Citations(urls); len(citations) > 0 {
+			metadata[llm.MetadataCitations] = citations
+		}
+	}
+
 	return &llm.Response{
 		Text:       generatedText,
 		TokensUsed: tokensUsed,
 		LatencyMs:  time.Since(startTime).Milliseconds(),
 		Model:      model,
 		Provider:   "google",
+		Metadata:   metadata,
+		Grounded:   config.WebSearch,
 	}, nil
 }
 
 // ListModels lists available Google AI models
 func (p *Provider) ListModels(ctx context.Context, apiKey, baseURL string) ([]models.ModelInfo, error) {
 	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey:  apiKey,
-		Backend: genai.BackendGeminiAPI,
+		APIKey:     apiKey,
+		Backend:    genai.BackendGeminiAPI,
+		HTTPClient: llm.NewHTTPClient(0),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Google client: %w", err)
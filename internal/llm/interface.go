@@ -2,8 +2,10 @@ package llm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/AI2HU/gego/internal/models"
@@ -17,6 +19,123 @@ type Config struct {
 	TopP        float64 `json:"top_p"`
 	TopK        int     `json:"top_k"`
 	Stream      bool    `json:"stream"`
+	// ResponseFormat is ResponseFormatText (default) or ResponseFormatJSONObject
+	ResponseFormat string `json:"response_format,omitempty"`
+	// Seed requests near-deterministic sampling on providers that support it
+	Seed *int `json:"seed,omitempty"`
+	// StopSequences ends generation when any of the sequences is produced
+	StopSequences []string `json:"stop_sequences,omitempty"`
+	// ResponseSchema is a JSON schema the output must match, honored by providers reporting
+	// StructuredOutput; use GenerateStructured to also cover the others
+	ResponseSchema json.RawMessage `json:"response_schema,omitempty"`
+	// Reasoning marks reasoning models, which take max_completion_tokens and no temperature. OpenAI
+	// detects its o-series models without it.
+	Reasoning bool `json:"reasoning,omitempty"`
+	// ReasoningEffort is the low, medium or high reasoning effort of reasoning models
+	ReasoningEffort string `json:"reasoning_effort,omitempty"`
+	// WebSearch lets providers reporting WebSearch search the web before answering, as their
+	// consumer apps do
+	WebSearch bool `json:"web_search,omitempty"`
+}
+
+// Response formats for Config.ResponseFormat
+const (
+	ResponseFormatText       = "text"
+	ResponseFormatJSONObject = "json_object"
+)
+
+// Capabilities describes optional features supported by a provider
+type Capabilities struct {
+	// JSONMode means the provider can force JSON output (ResponseFormatJSONObject)
+	JSONMode bool
+	// StructuredOutput means the provider constrains output to Config.ResponseSchema
+	StructuredOutput bool
+	// Seed means the provider honors Config.Seed
+	Seed bool
+	// MaxStopSequences is the number of stop sequences the provider accepts
+	// (0 = unsupported, UnlimitedStopSequences = no limit)
+	MaxStopSequences int
+	// WebSearch means the provider can ground answers with a web search (Config.WebSearch)
+	WebSearch bool
+}
+
+// UnlimitedStopSequences marks providers without a stop sequence count limit
+const UnlimitedStopSequences = -1
+
+// CapabilityReporter is implemented by providers that support optional features
+type CapabilityReporter interface {
+	Capabilities() Capabilities
+}
+
+// GetCapabilities returns the provider's capabilities, or none if it does not report any
+func GetCapabilities(provider Provider) Capabilities {
+	if reporter, ok := provider.(CapabilityReporter); ok {
+		return reporter.Capabilities()
+	}
+	return Capabilities{}
+}
+
+// ValidateResponseFormat checks that format is known and supported by the provider
+func ValidateResponseFormat(provider Provider, format string) error {
+	switch format {
+	case "", ResponseFormatText:
+		return nil
+	case ResponseFormatJSONObject:
+		if !GetCapabilities(provider).JSONMode {
+			return fmt.Errorf("provider %s does not support response_format %s", provider.Name(), format)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid response_format: %s (must be %s or %s)", format, ResponseFormatText, ResponseFormatJSONObject)
+	}
+}
+
+// ParseStopSequences parses stop sequences from an LLM config value: either a JSON
+// array of strings (e.g. ["\n\n", "END"]) or a single literal sequence
+func ParseStopSequences(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(strings.TrimSpace(value), "[") {
+		return []string{value}, nil
+	}
+
+	var stops []string
+	if err := json.Unmarshal([]byte(value), &stops); err != nil {
+		return nil, fmt.Errorf("invalid stop_sequences: %w", err)
+	}
+	return stops, nil
+}
+
+// ValidateStopSequences checks that the provider accepts the given stop sequences
+func ValidateStopSequences(provider Provider, stops []string) error {
+	if len(stops) == 0 {
+		return nil
+	}
+	for _, stop := range stops {
+		if stop == "" {
+			return fmt.Errorf("stop sequences must not be empty")
+		}
+	}
+
+	max := GetCapabilities(provider).MaxStopSequences
+	switch {
+	case max == 0:
+		return fmt.Errorf("provider %s does not support stop sequences", provider.Name())
+	case max != UnlimitedStopSequences && len(stops) > max:
+		return fmt.Errorf("provider %s accepts at most %d stop sequences, got %d", provider.Name(), max, len(stops))
+	}
+	return nil
+}
+
+// ParseReasoningEffort validates a reasoning_effort LLM config value
+func ParseReasoningEffort(value string) (string, error) {
+	switch effort := strings.ToLower(strings.TrimSpace(value)); effort {
+	case "low", "medium", "high":
+		return effort, nil
+	default:
+		return "", fmt.Errorf("invalid reasoning_effort: %s (must be low, medium or high)", value)
+	}
 }
 
 // DefaultConfig returns a config with sensible defaults
@@ -55,11 +174,16 @@ type Response struct {
 	Model      string
 	Provider   string
 	Error      string
+	Metadata   map[string]interface{}
+	// Grounded means the answer was generated with a web search; its sources are in
+	// Metadata[MetadataCitations]
+	Grounded bool
 }
 
 // Registry manages LLM providers
 type Registry struct {
 	providers map[string]Provider
+	mu        sync.RWMutex
 }
 
 // NewRegistry creates a new provider registry
@@ -71,17 +195,30 @@ func NewRegistry() *Registry {
 
 // Register registers a provider
 func (r *Registry) Register(provider Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.providers[provider.Name()] = provider
 }
 
+// Unregister removes the provider registered under name, if any
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.providers, name)
+}
+
 // Get retrieves a provider by name
 func (r *Registry) Get(name string) (Provider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	provider, ok := r.providers[name]
 	return provider, ok
 }
 
 // List returns all registered provider names
 func (r *Registry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	names := make([]string, 0, len(r.providers))
 	for name := range r.providers {
 		names = append(names, name)
@@ -0,0 +1,29 @@
+package llm
+
+import "testing"
+
+func TestParseReasoningEffort(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "low", want: "low"},
+		{value: " Medium ", want: "medium"},
+		{value: "HIGH", want: "high"},
+		{value: "", wantErr: true},
+		{value: "max", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseReasoningEffort(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReasoningEffort(%q) error = %v, want error %t", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseReasoningEffort(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -20,18 +20,23 @@ type Provider struct {
 	client  *http.Client
 }
 
+// DefaultBaseURL is the address of a local Ollama server
+const DefaultBaseURL = "http://localhost:11434"
+
 // New creates a new Ollama provider
-func New(baseURL string) *Provider {
+func New(baseURL string) (*Provider, error) {
+	baseURL, err := llm.ValidateBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
 	if baseURL == "" {
-		baseURL = "http://localhost:11434"
+		baseURL = DefaultBaseURL
 	}
 
 	return &Provider{
 		baseURL: baseURL,
-		client: &http.Client{
-			Timeout: 120 * time.Second,
-		},
-	}
+		client:  llm.NewHTTPClient(120 * time.Second),
+	}, nil
 }
 
 // Name returns the provider name
@@ -39,6 +44,11 @@ func (p *Provider) Name() string {
 	return "ollama"
 }
 
+// Capabilities returns the optional features supported by Ollama
+func (p *Provider) Capabilities() llm.Capabilities {
+	return llm.Capabilities{JSONMode: true, Seed: true, MaxStopSequences: llm.UnlimitedStopSequences}
+}
+
 // Validate validates the provider configuration
 func (p *Provider) Validate(config map[string]string) error {
 	// Ollama doesn't require API key, just a reachable endpoint
@@ -48,6 +58,7 @@ func (p *Provider) Validate(config map[string]string) error {
 // Generate sends a prompt to Ollama and returns the response
 func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
 	startTime := time.Now()
+	ctx, requestID := llm.EnsureRequestID(ctx)
 
 	model := "llama2"
 	if config.Model != "" {
@@ -56,13 +67,24 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 
 	temperature := config.Temperature
 
+	options := map[string]interface{}{
+		"temperature": temperature,
+	}
+	if config.Seed != nil {
+		options["seed"] = *config.Seed
+	}
+	if len(config.StopSequences) > 0 {
+		options["stop"] = config.StopSequences
+	}
+
 	requestBody := map[string]interface{}{
-		"model":  model,
-		"prompt": prompt,
-		"stream": false,
-		"options": map[string]interface{}{
-			"temperature": temperature,
-		},
+		"model":   model,
+		"prompt":  prompt,
+		"stream":  false,
+		"options": options,
+	}
+	if config.ResponseFormat == llm.ResponseFormatJSONObject {
+		requestBody["format"] = "json"
 	}
 
 	jsonBody, err := json.Marshal(requestBody)
@@ -76,7 +98,6 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-
 	resp, err := p.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -112,13 +133,44 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 		LatencyMs:  time.Since(startTime).Milliseconds(),
 		Model:      ollamaResp.Model,
 		Provider:   "ollama",
+		Metadata:   map[string]interface{}{llm.MetadataRequestID: requestID},
 	}, nil
 }
 
+// reachabilityTimeout bounds the version check made before listing models
+const reachabilityTimeout = 5 * time.Second
+
+// CheckReachable pings the Ollama version endpoint at baseURL, returning a friendly error
+// when the daemon cannot be reached
+func CheckReachable(ctx context.Context, baseURL string) error {
+	ctx, cancel := context.WithTimeout(ctx, reachabilityTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+"/api/version", nil)
+	if err != nil {
+		return fmt.Errorf("invalid Ollama base URL %s: %w", baseURL, err)
+	}
+
+	resp, err := llm.NewHTTPClient(reachabilityTimeout).Do(req)
+	if err != nil {
+		return fmt.Errorf("Ollama not reachable at %s — is it running? (%w)", baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Ollama not reachable at %s — is it running? (unexpected status %d from /api/version)", baseURL, resp.StatusCode)
+	}
+	return nil
+}
+
 // ListModels lists available models from Ollama
 func (p *Provider) ListModels(ctx context.Context, apiKey, baseURL string) ([]models.ModelInfo, error) {
 	if baseURL == "" {
-		baseURL = "http://localhost:11434"
+		baseURL = DefaultBaseURL
+	}
+
+	if err := CheckReachable(ctx, baseURL); err != nil {
+		return nil, err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+"/api/tags", nil)
@@ -126,7 +178,7 @@ func (p *Provider) ListModels(ctx context.Context, apiKey, baseURL string) ([]mo
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := llm.NewHTTPClient(30 * time.Second)
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list models: %w", err)
@@ -0,0 +1,139 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/llm"
+)
+
+func TestGenerateOptions(t *testing.T) {
+	seed := 42
+
+	tests := []struct {
+		name       string
+		config     llm.Config
+		wantStops  []string
+		wantSeed   *int
+		wantFormat string
+	}{
+		{name: "defaults", config: llm.Config{Model: "llama3.2", Temperature: 0.7}},
+		{name: "stop sequences", config: llm.Config{Model: "llama3.2", StopSequences: []string{"###", "END"}}, wantStops: []string{"###", "END"}},
+		{name: "seed", config: llm.Config{Model: "llama3.2", Seed: &seed}, wantSeed: &seed},
+		{name: "JSON", config: llm.Config{Model: "llama3.2", ResponseFormat: llm.ResponseFormatJSONObject}, wantFormat: "json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body struct {
+				Format  string `json:"format"`
+				Options struct {
+					Stop []string `json:"stop"`
+					Seed *int     `json:"seed"`
+				} `json:"options"`
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/generate" {
+					t.Errorf("path = %s, want /api/generate", r.URL.Path)
+				}
+				data, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(data, &body); err != nil {
+					t.Errorf("request body: %v", err)
+				}
+				io.WriteString(w, `{"model":"llama3.2","response":"Acme","done":true}`)
+			}))
+			defer server.Close()
+
+			provider, err := New(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response, err := provider.Generate(context.Background(), "What is the best tool?", tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if response.Text != "Acme" {
+				t.Errorf("text = %q, want Acme", response.Text)
+			}
+			if !slices.Equal(body.Options.Stop, tt.wantStops) {
+				t.Errorf("stop = %q, want %q", body.Options.Stop, tt.wantStops)
+			}
+			if (body.Options.Seed == nil) != (tt.wantSeed == nil) || (tt.wantSeed != nil && *body.Options.Seed != *tt.wantSeed) {
+				t.Errorf("seed = %v, want %v", body.Options.Seed, tt.wantSeed)
+			}
+			if body.Format != tt.wantFormat {
+				t.Errorf("format = %q, want %q", body.Format, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestListModelsReachability(t *testing.T) {
+	stopped := httptest.NewServer(http.NotFoundHandler())
+	stoppedURL := stopped.URL
+	stopped.Close()
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantErr    string
+		wantModels int
+	}{
+		{name: "not running", wantErr: "Ollama not reachable at " + stoppedURL + " — is it running?"},
+		{
+			name:    "not an Ollama server",
+			handler: func(w http.ResponseWriter, r *http.Request) { http.NotFound(w, r) },
+			wantErr: "is it running? (unexpected status 404 from /api/version)",
+		},
+		{
+			name: "running",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/version":
+					io.WriteString(w, `{"version":"0.5.7"}`)
+				case "/api/tags":
+					io.WriteString(w, `{"models":[{"name":"llama3.2"},{"name":"nomic-embed-text"}]}`)
+				default:
+					http.NotFound(w, r)
+				}
+			},
+			wantModels: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL := stoppedURL
+			if tt.handler != nil {
+				server := httptest.NewServer(tt.handler)
+				defer server.Close()
+				baseURL = server.URL
+			}
+
+			provider, err := New(baseURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			available, err := provider.ListModels(context.Background(), "", baseURL)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(available) != tt.wantModels {
+				t.Errorf("listed %d models, want %d", len(available), tt.wantModels)
+			}
+		})
+	}
+}
@@ -8,12 +8,36 @@ import (
 
 	"github.com/openai/openai-go/v3"
 	"github.com/openai/openai-go/v3/option"
+	"github.com/openai/openai-go/v3/responses"
 	"github.com/openai/openai-go/v3/shared"
 
 	"github.com/AI2HU/gego/internal/llm"
 	"github.com/AI2HU/gego/internal/models"
 )
 
+// Response metadata keys of the completion token split
+const (
+	// MetadataCompletionTokens holds the completion tokens, reasoning tokens included
+	MetadataCompletionTokens = "completion_tokens"
+	// MetadataReasoningTokens holds the completion tokens a reasoning model spent thinking
+	MetadataReasoningTokens = "reasoning_tokens"
+)
+
+// reasoningModelPrefixes are the prefixes of the o-series reasoning models
+var reasoningModelPrefixes = []string{"o1", "o3", "o4"}
+
+// IsReasoningModel reports whether model is an o-series reasoning model, which rejects temperature
+// and max_tokens
+func IsReasoningModel(model string) bool {
+	model = strings.ToLower(model)
+	for _, prefix := range reasoningModelPrefixes {
+		if model == prefix || strings.HasPrefix(model, prefix+"-") {
+			return true
+		}
+	}
+	return false
+}
+
 // Provider implements the LLM Provider interface for OpenAI
 type Provider struct {
 	apiKey  string
@@ -22,14 +46,21 @@ type Provider struct {
 }
 
 // New creates a new OpenAI provider
-func New(apiKey, baseURL string) *Provider {
+func New(apiKey, baseURL string) (*Provider, error) {
+	baseURL, err := llm.ValidateBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
 	client := openai.NewClient(
 		option.WithAPIKey(apiKey),
+		option.WithHTTPClient(llm.NewHTTPClient(0)),
 	)
 
 	if baseURL != "" && baseURL != "https://api.openai.com/v1" {
 		client = openai.NewClient(
 			option.WithAPIKey(apiKey),
+			option.WithHTTPClient(llm.NewHTTPClient(0)),
 			option.WithBaseURL(baseURL),
 		)
 	}
@@ -38,7 +69,7 @@ func New(apiKey, baseURL string) *Provider {
 		apiKey:  apiKey,
 		baseURL: baseURL,
 		client:  client,
-	}
+	}, nil
 }
 
 // Name returns the provider name
@@ -46,6 +77,11 @@ func (p *Provider) Name() string {
 	return "openai"
 }
 
+// Capabilities returns the optional features supported by OpenAI
+func (p *Provider) Capabilities() llm.Capabilities {
+	return llm.Capabilities{JSONMode: true, StructuredOutput: true, Seed: true, MaxStopSequences: 4, WebSearch: true}
+}
+
 // Validate validates the provider configuration
 func (p *Provider) Validate(config map[string]string) error {
 	if config["api_key"] == "" {
@@ -57,6 +93,7 @@ func (p *Provider) Validate(config map[string]string) error {
 // Generate sends a prompt to OpenAI and returns the response
 func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
 	startTime := time.Now()
+	ctx, requestID := llm.EnsureRequestID(ctx)
 
 	model := shared.ChatModelGPT3_5Turbo
 	if config.Model != "" {
@@ -69,23 +106,55 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 		maxTokens = 1000
 	}
 
-	chatCompletion, err := p.client.Chat.Completions.New(
-		ctx,
-		openai.ChatCompletionNewParams{
-			Model: model,
-			Messages: []openai.ChatCompletionMessageParamUnion{
-				{
-					OfUser: &openai.ChatCompletionUserMessageParam{
-						Content: openai.ChatCompletionUserMessageParamContentUnion{
-							OfString: openai.String(prompt),
-						},
+	if config.WebSearch {
+		return p.generateWithWebSearch(ctx, prompt, string(model), config, maxTokens, startTime, requestID)
+	}
+
+	params := openai.ChatCompletionNewParams{
+		Model: model,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			{
+				OfUser: &openai.ChatCompletionUserMessageParam{
+					Content: openai.ChatCompletionUserMessageParamContentUnion{
+						OfString: openai.String(prompt),
 					},
 				},
 			},
-			Temperature: openai.Float(temperature),
-			MaxTokens:   openai.Int(int64(maxTokens)),
 		},
-	)
+	}
+	if config.Reasoning || IsReasoningModel(string(model)) {
+		params.MaxCompletionTokens = openai.Int(int64(maxTokens))
+		if config.ReasoningEffort != "" {
+			params.ReasoningEffort = shared.ReasoningEffort(config.ReasoningEffort)
+		}
+	} else {
+		params.Temperature = openai.Float(temperature)
+		params.MaxTokens = openai.Int(int64(maxTokens))
+	}
+
+	if config.Seed != nil {
+		params.Seed = openai.Int(int64(*config.Seed))
+	}
+	if len(config.StopSequences) > 0 {
+		params.Stop = openai.ChatCompletionNewParamsStopUnion{OfStringArray: config.StopSequences}
+	}
+	if config.ResponseFormat == llm.ResponseFormatJSONObject {
+		params.ResponseFormat = openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONObject: &shared.ResponseFormatJSONObjectParam{},
+		}
+	}
+	if len(config.ResponseSchema) > 0 {
+		params.ResponseFormat = openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONSchema: &shared.ResponseFormatJSONSchemaParam{
+				JSONSchema: shared.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:   "response",
+					Schema: config.ResponseSchema,
+				},
+			},
+		}
+	}
+
+	chatCompletion, err := p.client.Chat.Completions.New(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("OpenAI API error: %w", err)
 	}
@@ -100,12 +169,76 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 		tokensUsed = int(chatCompletion.Usage.TotalTokens)
 	}
 
+	metadata := map[string]interface{}{llm.MetadataRequestID: requestID}
+	if completionTokens := chatCompletion.Usage.CompletionTokens; completionTokens != 0 {
+		metadata[MetadataCompletionTokens] = int(completionTokens)
+	}
+	if reasoningTokens := chatCompletion.Usage.CompletionTokensDetails.ReasoningTokens; reasoningTokens != 0 {
+		metadata[MetadataReasoningTokens] = int(reasoningTokens)
+	}
+
 	return &llm.Response{
 		Text:       generatedText,
 		TokensUsed: tokensUsed,
 		LatencyMs:  time.Since(startTime).Milliseconds(),
 		Model:      string(model),
 		Provider:   "openai",
+		Metadata:   metadata,
+	}, nil
+}
+
+// generateWithWebSearch answers through the Responses API with the web search tool, which the Chat
+// Completions API does not offer, and records the cited URLs
+func (p *Provider) generateWithWebSearch(ctx context.Context, prompt, model string, config llm.Config, maxTokens int, startTime time.Time, requestID string) (*llm.Response, error) {
+	params := responses.ResponseNewParams{
+		Model:           model,
+		Input:           responses.ResponseNewParamsInputUnion{OfString: openai.String(prompt)},
+		Tools:           []responses.ToolUnionParam{{OfWebSearch: &responses.WebSearchToolParam{Type: responses.WebSearchToolTypeWebSearch}}},
+		MaxOutputTokens: openai.Int(int64(maxTokens)),
+	}
+	if config.Reasoning || IsReasoningModel(model) {
+		if config.ReasoningEffort != "" {
+			params.Reasoning = shared.ReasoningParam{Effort: shared.ReasoningEffort(config.ReasoningEffort)}
+		}
+	} else {
+		params.Temperature = openai.Float(config.Temperature)
+	}
+
+	response, err := p.client.Responses.New(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI API error: %w", err)
+	}
+
+	var urls []string
+	for _, item := range response.Output {
+		for _, content := range item.Content {
+			for _, annotation := range content.Annotations {
+				if annotation.Type == "url_citation" {
+					urls = append(urls, annotation.URL)
+				}
+			}
+		}
+	}
+
+	metadata := map[string]interface{}{llm.MetadataRequestID: requestID}
+	if citations := llm.Citations(urls); len(citations) > 0 {
+		metadata[llm.MetadataCitations] = citations
+	}
+	if outputTokens := response.Usage.OutputTokens; outputTokens != 0 {
+		metadata[MetadataCompletionTokens] = int(outputTokens)
+	}
+	if reasoningTokens := response.Usage.OutputTokensDetails.ReasoningTokens; reasoningTokens != 0 {
+		metadata[MetadataReasoningTokens] = int(reasoningTokens)
+	}
+
+	return &llm.Response{
+		Text:       response.OutputText(),
+		TokensUsed: int(response.Usage.TotalTokens),
+		LatencyMs:  time.Since(startTime).Milliseconds(),
+		Model:      model,
+		Provider:   "openai",
+		Metadata:   metadata,
+		Grounded:   true,
 	}, nil
 }
 
@@ -115,10 +248,12 @@ func (p *Provider) ListModels(ctx context.Context, apiKey, baseURL string) ([]mo
 	if apiKey != "" && apiKey != p.apiKey {
 		client = openai.NewClient(
 			option.WithAPIKey(apiKey),
+			option.WithHTTPClient(llm.NewHTTPClient(0)),
 		)
 		if baseURL != "" && baseURL != "https://api.openai.com/v1" {
 			client = openai.NewClient(
 				option.WithAPIKey(apiKey),
+				option.WithHTTPClient(llm.NewHTTPClient(0)),
 				option.WithBaseURL(baseURL),
 			)
 		}
@@ -133,7 +268,7 @@ func (p *Provider) ListModels(ctx context.Context, apiKey, baseURL string) ([]mo
 	for _, model := range modelList.Data {
 		modelID := string(model.ID)
 
-		if strings.HasPrefix(strings.ToLower(modelID), "gpt") {
+		if strings.HasPrefix(strings.ToLower(modelID), "gpt") || IsReasoningModel(modelID) {
 			if strings.Contains(modelID, ":") {
 				continue
 			}
@@ -0,0 +1,200 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/llm"
+)
+
+const chatCompletionJSON = `{"id":"chatcmpl-1","object":"chat.completion","created":0,"model":"gpt-4o",
+"choices":[{"index":0,"message":{"role":"assistant","content":"Acme"},"finish_reason":"stop"}],
+"usage":{"prompt_tokens":5,"completion_tokens":1,"total_tokens":6}}`
+
+// request is an API request received by the test server
+type request struct {
+	path string
+	body map[string]interface{}
+}
+
+// newTestProvider returns a provider calling a test server that answers every request with
+// response, and the requests the server received
+func newTestProvider(t *testing.T, response string) (*Provider, *[]request) {
+	t.Helper()
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var body map[string]interface{}
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("request body: %v", err)
+		}
+		requests = append(requests, request{path: r.URL.Path, body: body})
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, response)
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := New("sk-test", server.URL+"/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider, &requests
+}
+
+func TestGenerateStopSequences(t *testing.T) {
+	tests := []struct {
+		name  string
+		stops []string
+		want  []interface{}
+	}{
+		{name: "no stop sequences"},
+		{name: "stop sequences", stops: []string{"###", "END"}, want: []interface{}{"###", "END"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, requests := newTestProvider(t, chatCompletionJSON)
+			response, err := provider.Generate(context.Background(), "What is the best tool?", llm.Config{Model: "gpt-4o", Temperature: 0.7, StopSequences: tt.stops})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.Text != "Acme" {
+				t.Errorf("text = %q, want Acme", response.Text)
+			}
+
+			body := (*requests)[0].body
+			stop, ok := body["stop"].([]interface{})
+			if tt.want == nil {
+				if _, present := body["stop"]; present {
+					t.Errorf("stop = %v, want absent", body["stop"])
+				}
+				return
+			}
+			if !ok || len(stop) != len(tt.want) || stop[0] != tt.want[0] || stop[1] != tt.want[1] {
+				t.Errorf("stop = %v, want %v", body["stop"], tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateResponseFormat(t *testing.T) {
+	schema := json.RawMessage(`{"type":"object","properties":{"brand":{"type":"string"}}}`)
+
+	tests := []struct {
+		name       string
+		format     string
+		schema     json.RawMessage
+		wantType   string
+		wantSchema bool
+	}{
+		{name: "text"},
+		{name: "explicit text", format: llm.ResponseFormatText},
+		{name: "JSON object", format: llm.ResponseFormatJSONObject, wantType: "json_object"},
+		{name: "JSON schema", schema: schema, wantType: "json_schema", wantSchema: true},
+		{name: "schema wins over JSON object", format: llm.ResponseFormatJSONObject, schema: schema, wantType: "json_schema", wantSchema: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, requests := newTestProvider(t, chatCompletionJSON)
+			config := llm.Config{Model: "gpt-4o", Temperature: 0.7, ResponseFormat: tt.format, ResponseSchema: tt.schema}
+			if _, err := provider.Generate(context.Background(), "What is the best tool?", config); err != nil {
+				t.Fatal(err)
+			}
+
+			body := (*requests)[0].body
+			format, ok := body["response_format"].(map[string]interface{})
+			if tt.wantType == "" {
+				if _, present := body["response_format"]; present {
+					t.Errorf("response_format = %v, want absent", body["response_format"])
+				}
+				return
+			}
+			if !ok || format["type"] != tt.wantType {
+				t.Fatalf("response_format = %v, want type %s", body["response_format"], tt.wantType)
+			}
+			jsonSchema, _ := format["json_schema"].(map[string]interface{})
+			if tt.wantSchema && (jsonSchema["name"] != "response" || jsonSchema["schema"] == nil) {
+				t.Errorf("json_schema = %v, want the configured schema", format["json_schema"])
+			}
+		})
+	}
+}
+
+func TestIsReasoningModel(t *testing.T) {
+	tests := []struct {
+		model string
+		want  bool
+	}{
+		{model: "o1", want: true},
+		{model: "o3-mini", want: true},
+		{model: "O4-mini-2025-04-16", want: true},
+		{model: "gpt-4o"},
+		{model: "o10"},
+		{model: "omni-moderation-latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			if got := IsReasoningModel(tt.model); got != tt.want {
+				t.Errorf("IsReasoningModel(%q) = %t, want %t", tt.model, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateReasoning(t *testing.T) {
+	const reasoningCompletionJSON = `{"id":"chatcmpl-1","object":"chat.completion","created":0,"model":"o3-mini",
+"choices":[{"index":0,"message":{"role":"assistant","content":"Acme"},"finish_reason":"stop"}],
+"usage":{"prompt_tokens":5,"completion_tokens":120,"total_tokens":125,"completion_tokens_details":{"reasoning_tokens":100}}}`
+
+	tests := []struct {
+		name          string
+		config        llm.Config
+		wantReasoning bool
+		wantEffort    string
+	}{
+		{name: "chat model", config: llm.Config{Model: "gpt-4o", Temperature: 0.7, MaxTokens: 500}},
+		{name: "o-series model", config: llm.Config{Model: "o3-mini", Temperature: 0.7, MaxTokens: 500}, wantReasoning: true},
+		{name: "o-series model with effort", config: llm.Config{Model: "o3-mini", MaxTokens: 500, ReasoningEffort: "high"}, wantReasoning: true, wantEffort: "high"},
+		{name: "model marked reasoning", config: llm.Config{Model: "gateway-thinker", MaxTokens: 500, Reasoning: true, ReasoningEffort: "low"}, wantReasoning: true, wantEffort: "low"},
+		{name: "effort ignored on chat model", config: llm.Config{Model: "gpt-4o", MaxTokens: 500, ReasoningEffort: "high"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, requests := newTestProvider(t, reasoningCompletionJSON)
+			response, err := provider.Generate(context.Background(), "What is the best tool?", tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body := (*requests)[0].body
+			_, hasTemperature := body["temperature"]
+			_, hasMaxTokens := body["max_tokens"]
+			if tt.wantReasoning {
+				if body["max_completion_tokens"] != float64(500) || hasMaxTokens || hasTemperature {
+					t.Errorf("max_completion_tokens %v, max_tokens present %t, temperature present %t", body["max_completion_tokens"], hasMaxTokens, hasTemperature)
+				}
+			} else {
+				if body["max_tokens"] != float64(500) || !hasTemperature {
+					t.Errorf("max_tokens %v, temperature present %t", body["max_tokens"], hasTemperature)
+				}
+				if _, present := body["max_completion_tokens"]; present {
+					t.Error("max_completion_tokens sent to a chat model")
+				}
+			}
+			if effort, _ := body["reasoning_effort"].(string); effort != tt.wantEffort {
+				t.Errorf("reasoning_effort = %q, want %q", effort, tt.wantEffort)
+			}
+
+			if response.Metadata[MetadataCompletionTokens] != 120 || response.Metadata[MetadataReasoningTokens] != 100 {
+				t.Errorf("metadata = %v, want 120 completion and 100 reasoning tokens", response.Metadata)
+			}
+		})
+	}
+}
@@ -19,18 +19,23 @@ type Provider struct {
 }
 
 // New creates a new Perplexity provider
-func New(apiKey, baseURL string) *Provider {
+func New(apiKey, baseURL string) (*Provider, error) {
+	baseURL, err := llm.ValidateBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
 	if baseURL == "" {
 		baseURL = "https://api.perplexity.ai"
 	}
 
 	client := pplx.NewClient(apiKey)
+	client.SetHTTPClient(llm.NewHTTPClient(pplx.DefaultTimeout))
 
 	return &Provider{
 		apiKey:  apiKey,
 		baseURL: baseURL,
 		client:  client,
-	}
+	}, nil
 }
 
 // Name returns the provider name
@@ -38,6 +43,12 @@ func (p *Provider) Name() string {
 	return "perplexity"
 }
 
+// Capabilities returns the optional features supported by Perplexity, whose models always search
+// the web
+func (p *Provider) Capabilities() llm.Capabilities {
+	return llm.Capabilities{WebSearch: true}
+}
+
 // Validate validates the provider configuration
 func (p *Provider) Validate(config map[string]string) error {
 	if config["api_key"] == "" {
@@ -49,6 +60,7 @@ func (p *Provider) Validate(config map[string]string) error {
 // Generate sends a prompt to Perplexity and returns the response
 func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
 	startTime := time.Now()
+	ctx, requestID := llm.EnsureRequestID(ctx)
 
 	model := "sonar"
 	if config.Model != "" {
@@ -80,7 +92,7 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 		return nil, fmt.Errorf("request validation failed: %w", err)
 	}
 
-	resp, err := p.client.SendCompletionRequest(req)
+	resp, err := p.client.SendCompletionRequestWithContext(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -92,12 +104,23 @@ func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Confi
 
 	tokensUsed := resp.Usage.TotalTokens
 
+	urls := resp.GetCitations()
+	for _, result := range resp.GetSearchResults() {
+		urls = append(urls, result.URL)
+	}
+	metadata := map[string]interface{}{llm.MetadataRequestID: requestID}
+	if citations := llm.Citations(urls); len(citations) > 0 {
+		metadata[llm.MetadataCitations] = citations
+	}
+
 	return &llm.Response{
 		Text:       content,
 		TokensUsed: tokensUsed,
 		LatencyMs:  time.Since(startTime).Milliseconds(),
 		Model:      model,
 		Provider:   "perplexity",
+		Metadata:   metadata,
+		Grounded:   true,
 	}, nil
 }
 
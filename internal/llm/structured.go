@@ -0,0 +1,171 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// MetadataStructuredMode records how structured output was obtained: StructuredModeNative or
+// StructuredModePrompt
+const MetadataStructuredMode = "structured_mode"
+
+// Structured output modes
+const (
+	StructuredModeNative = "native" // The provider constrained the output to the schema
+	StructuredModePrompt = "prompt" // The schema was appended to the prompt
+)
+
+// structuredAttempts is the number of generations tried before invalid JSON is reported
+const structuredAttempts = 2
+
+// jsonFencePattern matches a markdown code fence around a JSON answer
+var jsonFencePattern = regexp.MustCompile("(?s)^```[a-zA-Z]*\\s*\\n(.*?)\\n?```$")
+
+// GenerateStructured asks provider for a JSON answer matching schema and returns it as the
+// response text. Providers reporting StructuredOutput constrain the output natively; others get
+// the schema appended to the prompt, with JSON mode when available. An answer that is not valid
+// JSON or does not match the schema is regenerated once before an error is returned.
+func GenerateStructured(ctx context.Context, provider Provider, prompt string, schema json.RawMessage, config Config) (*Response, error) {
+	var schemaObject map[string]interface{}
+	if err := json.Unmarshal(schema, &schemaObject); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	mode := StructuredModePrompt
+	capabilities := GetCapabilities(provider)
+	if capabilities.StructuredOutput {
+		mode = StructuredModeNative
+		config.ResponseSchema = schema
+	} else {
+		prompt = StructuredPrompt(prompt, schema)
+		if capabilities.JSONMode {
+			config.ResponseFormat = ResponseFormatJSONObject
+		}
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= structuredAttempts; attempt++ {
+		response, err := provider.Generate(ctx, prompt, config)
+		if err != nil {
+			return nil, err
+		}
+
+		text := ExtractJSON(response.Text)
+		if lastErr = ValidateJSON([]byte(text), schemaObject); lastErr == nil {
+			response.Text = text
+			if response.Metadata == nil {
+				response.Metadata = make(map[string]interface{})
+			}
+			response.Metadata[MetadataStructuredMode] = mode
+			return response, nil
+		}
+	}
+
+	return nil, fmt.Errorf("provider %s returned invalid structured output after %d attempts: %w", provider.Name(), structuredAttempts, lastErr)
+}
+
+// StructuredPrompt appends an instruction to answer with JSON matching schema to prompt, for
+// providers without native structured output
+func StructuredPrompt(prompt string, schema json.RawMessage) string {
+	return fmt.Sprintf("%s\n\nRespond with only JSON matching this JSON schema, without any other text or code fences:\n%s", prompt, schema)
+}
+
+// ExtractJSON returns text with surrounding whitespace and a markdown code fence removed
+func ExtractJSON(text string) string {
+	text = strings.TrimSpace(text)
+	if match := jsonFencePattern.FindStringSubmatch(text); match != nil {
+		return strings.TrimSpace(match[1])
+	}
+	return text
+}
+
+// ValidateJSON checks that data is JSON matching schema. The type, properties, required, items
+// and enum keywords are checked; other keywords are ignored.
+func ValidateJSON(data []byte, schema map[string]interface{}) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return validateValue(value, schema, "$")
+}
+
+// validateValue checks value against schema, reporting mismatches at path
+func validateValue(value interface{}, schema map[string]interface{}, path string) error {
+	if schemaType, ok := schema["type"].(string); ok && !matchesType(value, schemaType) {
+		return fmt.Errorf("%s: expected %s", path, schemaType)
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if fmt.Sprint(allowed) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, value, enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, present := v[fmt.Sprint(name)]; !present {
+					return fmt.Errorf("%s: missing required property %v", path, name)
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, propertyValue := range v {
+			if propertySchema, ok := properties[name].(map[string]interface{}); ok {
+				if err := validateValue(propertyValue, propertySchema, path+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				if err := validateValue(item, items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// matchesType reports whether a decoded JSON value has the given JSON schema type
+func matchesType(value interface{}, schemaType string) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return true
+	}
+}
@@ -0,0 +1,92 @@
+package llm
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/version"
+)
+
+// RequestIDHeader is the header carrying the gego request ID to providers
+const RequestIDHeader = "X-Request-ID"
+
+// MetadataRequestID is the Response.Metadata key holding the request ID
+const MetadataRequestID = "request_id"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the given request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in the context, if any
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// EnsureRequestID returns a context carrying a request ID, generating one when missing
+func EnsureRequestID(ctx context.Context) (context.Context, string) {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return ctx, requestID
+	}
+	requestID := uuid.New().String()
+	return WithRequestID(ctx, requestID), requestID
+}
+
+// Transport is an http.RoundTripper that tags outbound provider calls
+// with the gego User-Agent and an X-Request-ID header
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewHTTPClient creates an HTTP client using the gego provider transport
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &Transport{},
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	requestID := RequestIDFromContext(req.Context())
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", version.UserAgent())
+	req.Header.Set(RequestIDHeader, requestID)
+
+	capture := rawCaptureFromContext(req.Context())
+	var exchange RawExchange
+	if capture != nil {
+		exchange = capture.captureRequest(req)
+	}
+
+	startTime := time.Now()
+	resp, err := base.RoundTrip(req)
+	latency := time.Since(startTime)
+	if capture != nil {
+		capture.captureResponse(exchange, resp, err)
+	}
+
+	if err != nil {
+		logger.Debug("Provider request %s %s %s failed after %v: %v", requestID, req.Method, req.URL.Host, latency, err)
+		return nil, err
+	}
+
+	logger.Debug("Provider request %s %s %s returned HTTP %d in %v", requestID, req.Method, req.URL.Host, resp.StatusCode, latency)
+	return resp, nil
+}
@@ -0,0 +1,22 @@
+package llm
+
+import "strings"
+
+// MetadataCitations is the Response.Metadata key holding the source URLs a web-search-grounded
+// answer cites, deduplicated in citation order
+const MetadataCitations = "citations"
+
+// Citations deduplicates source URLs in order, dropping empty ones
+func Citations(urls []string) []string {
+	seen := make(map[string]bool, len(urls))
+	var citations []string
+	for _, url := range urls {
+		url = strings.TrimSpace(url)
+		if url == "" || seen[url] {
+			continue
+		}
+		seen[url] = true
+		citations = append(citations, url)
+	}
+	return citations
+}
@@ -0,0 +1,173 @@
+package xai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/openai/openai-go/v3/shared"
+
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// DefaultBaseURL is the xAI API endpoint
+const DefaultBaseURL = "https://api.x.ai/v1"
+
+// Provider implements the LLM Provider interface for xAI's OpenAI-compatible Grok API
+type Provider struct {
+	apiKey  string
+	baseURL string
+	client  openai.Client
+}
+
+// New creates a new xAI provider
+func New(apiKey, baseURL string) (*Provider, error) {
+	baseURL, err := llm.ValidateBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Provider{
+		apiKey:  apiKey,
+		baseURL: baseURL,
+		client:  newClient(apiKey, baseURL),
+	}, nil
+}
+
+// newClient creates an OpenAI SDK client pointed at the xAI API
+func newClient(apiKey, baseURL string) openai.Client {
+	return openai.NewClient(
+		option.WithAPIKey(apiKey),
+		option.WithHTTPClient(llm.NewHTTPClient(0)),
+		option.WithBaseURL(baseURL),
+	)
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "xai"
+}
+
+// Capabilities returns the optional features supported by xAI
+func (p *Provider) Capabilities() llm.Capabilities {
+	return llm.Capabilities{JSONMode: true, Seed: true, MaxStopSequences: 4}
+}
+
+// Validate validates the provider configuration
+func (p *Provider) Validate(config map[string]string) error {
+	if config["api_key"] == "" {
+		return fmt.Errorf("api_key is required")
+	}
+	return nil
+}
+
+// Generate sends a prompt to Grok and returns the response
+func (p *Provider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
+	startTime := time.Now()
+	ctx, requestID := llm.EnsureRequestID(ctx)
+
+	model := "grok-3"
+	if config.Model != "" {
+		model = config.Model
+	}
+
+	maxTokens := config.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = 1000
+	}
+
+	params := openai.ChatCompletionNewParams{
+		Model: shared.ChatModel(model),
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			{
+				OfUser: &openai.ChatCompletionUserMessageParam{
+					Content: openai.ChatCompletionUserMessageParamContentUnion{
+						OfString: openai.String(prompt),
+					},
+				},
+			},
+		},
+		Temperature: openai.Float(config.Temperature),
+		MaxTokens:   openai.Int(int64(maxTokens)),
+	}
+
+	if config.Seed != nil {
+		params.Seed = openai.Int(int64(*config.Seed))
+	}
+	if len(config.StopSequences) > 0 {
+		params.Stop = openai.ChatCompletionNewParamsStopUnion{OfStringArray: config.StopSequences}
+	}
+	if config.ResponseFormat == llm.ResponseFormatJSONObject {
+		params.ResponseFormat = openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONObject: &shared.ResponseFormatJSONObjectParam{},
+		}
+	}
+
+	chatCompletion, err := p.client.Chat.Completions.New(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("xAI API error: %w", err)
+	}
+
+	if len(chatCompletion.Choices) == 0 {
+		return nil, fmt.Errorf("no choices returned from API")
+	}
+
+	return &llm.Response{
+		Text:       chatCompletion.Choices[0].Message.Content,
+		TokensUsed: int(chatCompletion.Usage.TotalTokens),
+		LatencyMs:  time.Since(startTime).Milliseconds(),
+		Model:      model,
+		Provider:   "xai",
+		Metadata:   map[string]interface{}{llm.MetadataRequestID: requestID},
+	}, nil
+}
+
+// ListModels lists available text-to-text Grok models from xAI
+func (p *Provider) ListModels(ctx context.Context, apiKey, baseURL string) ([]models.ModelInfo, error) {
+	client := p.client
+	if (apiKey != "" && apiKey != p.apiKey) || (baseURL != "" && baseURL != p.baseURL) {
+		if apiKey == "" {
+			apiKey = p.apiKey
+		}
+		if baseURL == "" {
+			baseURL = p.baseURL
+		}
+		client = newClient(apiKey, baseURL)
+	}
+
+	modelList, err := client.Models.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+
+	var textModels []models.ModelInfo
+	for _, model := range modelList.Data {
+		if !IsTextModel(model.ID) {
+			continue
+		}
+		textModels = append(textModels, models.ModelInfo{
+			ID:          model.ID,
+			Name:        model.ID,
+			Description: fmt.Sprintf("xAI %s", model.ID),
+		})
+	}
+
+	return textModels, nil
+}
+
+// IsTextModel reports whether an xAI model generates text, excluding image generation
+// and embedding models
+func IsTextModel(modelID string) bool {
+	id := strings.ToLower(modelID)
+	if !strings.HasPrefix(id, "grok") {
+		return false
+	}
+	return !strings.Contains(id, "image") && !strings.Contains(id, "embed")
+}
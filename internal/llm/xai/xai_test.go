@@ -0,0 +1,130 @@
+package xai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/llm"
+)
+
+// newTestServer returns a server answering chat completions and the model list, and the bodies
+// of the chat requests it received
+func newTestServer(t *testing.T) (*httptest.Server, *[]map[string]interface{}) {
+	t.Helper()
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer xai-test-key" {
+			t.Errorf("Authorization = %q, want the bearer API key", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/chat/completions":
+			data, _ := io.ReadAll(r.Body)
+			var body map[string]interface{}
+			if err := json.Unmarshal(data, &body); err != nil {
+				t.Errorf("request body: %v", err)
+			}
+			bodies = append(bodies, body)
+			io.WriteString(w, `{"id":"chatcmpl-1","object":"chat.completion","created":0,"model":"grok-3",
+"choices":[{"index":0,"message":{"role":"assistant","content":"Acme"},"finish_reason":"stop"}],
+"usage":{"prompt_tokens":5,"completion_tokens":1,"total_tokens":6}}`)
+		case "/v1/models":
+			io.WriteString(w, `{"object":"list","data":[
+{"id":"grok-3","object":"model","created":0,"owned_by":"xai"},
+{"id":"grok-3-mini","object":"model","created":0,"owned_by":"xai"},
+{"id":"grok-2-image-1212","object":"model","created":0,"owned_by":"xai"},
+{"id":"grok-embed-1","object":"model","created":0,"owned_by":"xai"},
+{"id":"whisper-1","object":"model","created":0,"owned_by":"xai"}]}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies
+}
+
+func TestGenerate(t *testing.T) {
+	seed := 42
+
+	tests := []struct {
+		name      string
+		config    llm.Config
+		wantModel string
+		wantSeed  bool
+		wantStop  bool
+		wantJSON  bool
+	}{
+		{name: "defaults", config: llm.Config{Temperature: 0.7}, wantModel: "grok-3"},
+		{
+			name:      "options",
+			config:    llm.Config{Model: "grok-3-mini", Temperature: 0.2, Seed: &seed, StopSequences: []string{"END"}, ResponseFormat: llm.ResponseFormatJSONObject},
+			wantModel: "grok-3-mini",
+			wantSeed:  true,
+			wantStop:  true,
+			wantJSON:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, bodies := newTestServer(t)
+			provider, err := New("xai-test-key", server.URL+"/v1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			response, err := provider.Generate(context.Background(), "What is the best tool?", tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.Text != "Acme" || response.TokensUsed != 6 || response.Provider != "xai" {
+				t.Errorf("response = %+v", response)
+			}
+
+			body := (*bodies)[0]
+			messages, _ := body["messages"].([]interface{})
+			message, _ := messages[0].(map[string]interface{})
+			if len(messages) != 1 || message["role"] != "user" || message["content"] != "What is the best tool?" {
+				t.Errorf("messages = %v, want the prompt as the only user message", body["messages"])
+			}
+			if body["model"] != tt.wantModel || body["temperature"] != tt.config.Temperature || body["max_tokens"] != float64(1000) {
+				t.Errorf("model %v, temperature %v, max_tokens %v", body["model"], body["temperature"], body["max_tokens"])
+			}
+			if _, ok := body["seed"]; ok != tt.wantSeed {
+				t.Errorf("seed = %v, want present %t", body["seed"], tt.wantSeed)
+			}
+			if _, ok := body["stop"]; ok != tt.wantStop {
+				t.Errorf("stop = %v, want present %t", body["stop"], tt.wantStop)
+			}
+			format, _ := body["response_format"].(map[string]interface{})
+			if gotJSON := format["type"] == "json_object"; gotJSON != tt.wantJSON {
+				t.Errorf("response_format = %v, want JSON object %t", body["response_format"], tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestListModelsKeepsChatModels(t *testing.T) {
+	server, _ := newTestServer(t)
+	provider, err := New("xai-test-key", server.URL+"/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	available, err := provider.ListModels(context.Background(), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, model := range available {
+		ids = append(ids, model.ID)
+	}
+	if want := []string{"grok-3", "grok-3-mini"}; !slices.Equal(ids, want) {
+		t.Errorf("models = %v, want %v", ids, want)
+	}
+}
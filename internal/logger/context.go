@@ -0,0 +1,40 @@
+package logger
+
+import "context"
+
+type runIDKey struct{}
+
+// WithRunID returns a context whose log lines, written with the Context functions, carry runID
+func WithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+// RunIDFromContext returns the run ID of ctx, or "" outside of a run
+func RunIDFromContext(ctx context.Context) string {
+	runID, _ := ctx.Value(runIDKey{}).(string)
+	return runID
+}
+
+// withRunPrefix prefixes format with the run ID of ctx, if any
+func withRunPrefix(ctx context.Context, format string) string {
+	if runID := RunIDFromContext(ctx); runID != "" {
+		return "[run " + runID + "] " + format
+	}
+	return format
+}
+
+func DebugContext(ctx context.Context, format string, v ...interface{}) {
+	GetLogger().Debug(withRunPrefix(ctx, format), v...)
+}
+
+func InfoContext(ctx context.Context, format string, v ...interface{}) {
+	GetLogger().Info(withRunPrefix(ctx, format), v...)
+}
+
+func WarningContext(ctx context.Context, format string, v ...interface{}) {
+	GetLogger().Warning(withRunPrefix(ctx, format), v...)
+}
+
+func ErrorContext(ctx context.Context, format string, v ...interface{}) {
+	GetLogger().Error(withRunPrefix(ctx, format), v...)
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"bytes"
+	"encoding/json"
 	"time"
 )
 
@@ -20,10 +22,43 @@ type PaginatedResponse struct {
 
 // Pagination represents pagination metadata
 type Pagination struct {
-	Page       int   `json:"page"`
-	Limit      int   `json:"limit"`
-	Total      int64 `json:"total"`
-	TotalPages int   `json:"total_pages"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Total      int64  `json:"total"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"` // Pass as after to fetch the next page
+}
+
+// Nullable is an update request field distinguishing an absent field, which leaves the stored
+// value unchanged, from an explicit null, which clears it
+type Nullable[T any] struct {
+	Set   bool // The field was present, possibly null
+	Null  bool // The field was null
+	Value T
+}
+
+// UnmarshalJSON records that the field was present and whether it was null
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		n.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &n.Value)
+}
+
+// Apply stores the field in target: its value, or the zero value when null. Absent fields leave
+// target unchanged.
+func (n Nullable[T]) Apply(target *T) {
+	if !n.Set {
+		return
+	}
+	if n.Null {
+		var zero T
+		*target = zero
+		return
+	}
+	*target = n.Value
 }
 
 // CreateLLMRequest represents the request to create a new LLM
@@ -34,46 +69,64 @@ type CreateLLMRequest struct {
 	APIKey   string            `json:"api_key,omitempty"`
 	BaseURL  string            `json:"base_url,omitempty"`
 	Config   map[string]string `json:"config,omitempty"`
-	Enabled  bool              `json:"enabled"`
+	Enabled  *bool             `json:"enabled,omitempty"` // Absent follows the create_disabled setting
+	Owner    string            `json:"owner,omitempty"`
+	// Text sent before and after every prompt run with the LLM
+	PromptPrefix string `json:"prompt_prefix,omitempty"`
+	PromptSuffix string `json:"prompt_suffix,omitempty"`
 }
 
-// UpdateLLMRequest represents the request to update an existing LLM
+// UpdateLLMRequest represents the request to update an existing LLM. Absent fields are left
+// unchanged; null clears api_key, base_url, config, owner, prompt_prefix and prompt_suffix.
 type UpdateLLMRequest struct {
-	Name     string            `json:"name,omitempty"`
-	Provider string            `json:"provider,omitempty"`
-	Model    string            `json:"model,omitempty"`
-	APIKey   string            `json:"api_key,omitempty"`
-	BaseURL  string            `json:"base_url,omitempty"`
-	Config   map[string]string `json:"config,omitempty"`
-	Enabled  *bool             `json:"enabled,omitempty"`
+	Name     Nullable[string]            `json:"name"`
+	Provider Nullable[string]            `json:"provider"`
+	Model    Nullable[string]            `json:"model"`
+	APIKey   Nullable[string]            `json:"api_key"`
+	BaseURL  Nullable[string]            `json:"base_url"`
+	Config   Nullable[map[string]string] `json:"config"`
+	Enabled  *bool                       `json:"enabled,omitempty"`
+	Owner    Nullable[string]            `json:"owner"`
+	// Text sent before and after every prompt run with the LLM
+	PromptPrefix Nullable[string] `json:"prompt_prefix"`
+	PromptSuffix Nullable[string] `json:"prompt_suffix"`
 }
 
 // LLMResponse represents the response for LLM operations
 type LLMResponse struct {
-	ID        string            `json:"id"`
-	Name      string            `json:"name"`
-	Provider  string            `json:"provider"`
-	Model     string            `json:"model"`
-	APIKey    string            `json:"api_key,omitempty"`
-	BaseURL   string            `json:"base_url,omitempty"`
-	Config    map[string]string `json:"config,omitempty"`
-	Enabled   bool              `json:"enabled"`
-	CreatedAt time.Time         `json:"created_at"`
-	UpdatedAt time.Time         `json:"updated_at"`
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Provider string            `json:"provider"`
+	Model    string            `json:"model"`
+	APIKey   string            `json:"api_key,omitempty"`
+	BaseURL  string            `json:"base_url,omitempty"`
+	Config   map[string]string `json:"config,omitempty"`
+	Enabled  bool              `json:"enabled"`
+	Owner    string            `json:"owner,omitempty"`
+	// Text sent before and after every prompt run with the LLM
+	PromptPrefix   string    `json:"prompt_prefix,omitempty"`
+	PromptSuffix   string    `json:"prompt_suffix,omitempty"`
+	AuthFailures   int       `json:"auth_failures,omitempty"`
+	DisabledReason string    `json:"disabled_reason,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 // CreatePromptRequest represents the request to create a new prompt
 type CreatePromptRequest struct {
 	Template string   `json:"template" binding:"required"`
 	Tags     []string `json:"tags,omitempty"`
-	Enabled  bool     `json:"enabled"`
+	Enabled  *bool    `json:"enabled,omitempty"` // Absent follows the create_disabled setting
+	Owner    string   `json:"owner,omitempty"`
 }
 
-// UpdatePromptRequest represents the request to update an existing prompt
+// UpdatePromptRequest represents the request to update an existing prompt. Absent fields are
+// left unchanged; null clears tags and owner.
 type UpdatePromptRequest struct {
-	Template string   `json:"template,omitempty"`
-	Tags     []string `json:"tags,omitempty"`
-	Enabled  *bool    `json:"enabled,omitempty"`
+	Template Nullable[string]   `json:"template"`
+	Tags     Nullable[[]string] `json:"tags"`
+	Enabled  *bool              `json:"enabled,omitempty"`
+	Owner    Nullable[string]   `json:"owner"`
 }
 
 // PromptResponse represents the response for prompt operations
@@ -82,43 +135,107 @@ type PromptResponse struct {
 	Template  string    `json:"template"`
 	Tags      []string  `json:"tags,omitempty"`
 	Enabled   bool      `json:"enabled"`
+	Owner     string    `json:"owner,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // CreateScheduleRequest represents the request to create a new schedule
 type CreateScheduleRequest struct {
-	Name        string   `json:"name" binding:"required"`
-	PromptIDs   []string `json:"prompt_ids" binding:"required"`
-	LLMIDs      []string `json:"llm_ids" binding:"required"`
-	CronExpr    string   `json:"cron_expr" binding:"required"`
-	Temperature float64  `json:"temperature,omitempty"`
-	Enabled     bool     `json:"enabled"`
+	Name          string             `json:"name" binding:"required"`
+	PromptIDs     []string           `json:"prompt_ids"` // Required unless all_prompts is set
+	LLMIDs        []string           `json:"llm_ids"`    // Required unless all_llms is set
+	AllPrompts    bool               `json:"all_prompts,omitempty"`
+	AllLLMs       bool               `json:"all_llms,omitempty"`
+	CronExpr      string             `json:"cron_expr" binding:"required"` // Cron expression or phrase such as "every day at 09:00"
+	Temperature   float64            `json:"temperature,omitempty"`
+	Enabled       bool               `json:"enabled"`
+	CatchUpPolicy string             `json:"catch_up_policy,omitempty"`
+	CatchUpMax    int                `json:"catch_up_max,omitempty"`
+	Seed          *int               `json:"seed,omitempty"`
+	Shuffle       bool               `json:"shuffle,omitempty"`
+	CheckLanguage bool               `json:"check_language,omitempty"` // Flag responses not in the language of their prompt's lang-XX tag
+	PromptWeights map[string]float64 `json:"prompt_weights,omitempty"` // Relative sampling weights by prompt ID (default 1)
+	SampleCount   int                `json:"sample_count,omitempty"`   // Prompts sampled by weight per run (0 = all)
+	Owner         string             `json:"owner,omitempty"`
 }
 
-// UpdateScheduleRequest represents the request to update an existing schedule
+// UpdateScheduleRequest represents the request to update an existing schedule. Absent fields are
+// left unchanged; null clears catch_up_policy, seed, prompt_weights and owner.
 type UpdateScheduleRequest struct {
-	Name        string   `json:"name,omitempty"`
-	PromptIDs   []string `json:"prompt_ids,omitempty"`
-	LLMIDs      []string `json:"llm_ids,omitempty"`
-	CronExpr    string   `json:"cron_expr,omitempty"`
-	Temperature *float64 `json:"temperature,omitempty"`
-	Enabled     *bool    `json:"enabled,omitempty"`
+	Name          Nullable[string]             `json:"name"`
+	PromptIDs     []string                     `json:"prompt_ids,omitempty"`
+	LLMIDs        []string                     `json:"llm_ids,omitempty"`
+	AllPrompts    *bool                        `json:"all_prompts,omitempty"`
+	AllLLMs       *bool                        `json:"all_llms,omitempty"`
+	CronExpr      Nullable[string]             `json:"cron_expr"`
+	Temperature   *float64                     `json:"temperature,omitempty"`
+	Enabled       *bool                        `json:"enabled,omitempty"`
+	CatchUpPolicy Nullable[string]             `json:"catch_up_policy"`
+	CatchUpMax    *int                         `json:"catch_up_max,omitempty"`
+	Seed          Nullable[int]                `json:"seed"`
+	Shuffle       *bool                        `json:"shuffle,omitempty"`
+	CheckLanguage *bool                        `json:"check_language,omitempty"`
+	PromptWeights Nullable[map[string]float64] `json:"prompt_weights"`
+	SampleCount   *int                         `json:"sample_count,omitempty"`
+	Owner         Nullable[string]             `json:"owner"`
 }
 
 // ScheduleResponse represents the response for schedule operations
 type ScheduleResponse struct {
-	ID          string     `json:"id"`
-	Name        string     `json:"name"`
-	PromptIDs   []string   `json:"prompt_ids"`
-	LLMIDs      []string   `json:"llm_ids"`
-	CronExpr    string     `json:"cron_expr"`
-	Temperature float64    `json:"temperature"`
-	Enabled     bool       `json:"enabled"`
-	LastRun     *time.Time `json:"last_run,omitempty"`
-	NextRun     *time.Time `json:"next_run,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID            string             `json:"id"`
+	Name          string             `json:"name"`
+	PromptIDs     []string           `json:"prompt_ids"`
+	LLMIDs        []string           `json:"llm_ids"`
+	AllPrompts    bool               `json:"all_prompts"`
+	AllLLMs       bool               `json:"all_llms"`
+	CronExpr      string             `json:"cron_expr"`
+	Temperature   float64            `json:"temperature"`
+	Enabled       bool               `json:"enabled"`
+	LastRun       *time.Time         `json:"last_run,omitempty"`
+	NextRun       *time.Time         `json:"next_run,omitempty"`
+	NextRuns      []time.Time        `json:"next_runs,omitempty"` // Next fire times, echoed on create and update
+	CatchUpPolicy string             `json:"catch_up_policy"`
+	CatchUpMax    int                `json:"catch_up_max"`
+	MissedRuns    int                `json:"missed_runs"`
+	Seed          *int               `json:"seed,omitempty"`
+	Shuffle       bool               `json:"shuffle"`
+	CheckLanguage bool               `json:"check_language"`
+	PromptWeights map[string]float64 `json:"prompt_weights,omitempty"`
+	SampleCount   int                `json:"sample_count,omitempty"`
+	Owner         string             `json:"owner,omitempty"`
+	LastError     string             `json:"last_error,omitempty"` // Why the scheduler could not register the schedule
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// ScheduleNextRunsResponse represents the upcoming fire times of a schedule
+type ScheduleNextRunsResponse struct {
+	ID       string      `json:"id"`
+	CronExpr string      `json:"cron_expr"`
+	Enabled  bool        `json:"enabled"`
+	LastRun  *time.Time  `json:"last_run,omitempty"`
+	NextRuns []time.Time `json:"next_runs"`
+}
+
+// CreateRecipeRequest represents the request to create a new generation recipe
+type CreateRecipeRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	LLMID     string   `json:"llm_id" binding:"required"`
+	Language  string   `json:"language,omitempty"`
+	UserInput string   `json:"user_input" binding:"required"`
+	Count     int      `json:"count,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// UpdateRecipeRequest represents the request to update an existing generation recipe
+type UpdateRecipeRequest struct {
+	Name      string   `json:"name,omitempty"`
+	LLMID     string   `json:"llm_id,omitempty"`
+	Language  string   `json:"language,omitempty"`
+	UserInput string   `json:"user_input,omitempty"`
+	Count     *int     `json:"count,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
 }
 
 // StatsResponse represents the response for statistics
@@ -136,22 +253,111 @@ type StatsResponse struct {
 
 // SearchRequest represents the request to search responses
 type SearchRequest struct {
-	Keyword   string     `json:"keyword" binding:"required"`
-	StartTime *time.Time `json:"start_time,omitempty"`
-	EndTime   *time.Time `json:"end_time,omitempty"`
-	Limit     int        `json:"limit,omitempty"`
+	Keyword       string     `json:"keyword" binding:"required"`
+	StartTime     *time.Time `json:"start_time,omitempty"`
+	EndTime       *time.Time `json:"end_time,omitempty"`
+	Limit         int        `json:"limit,omitempty"`
+	Owner         string     `json:"owner,omitempty"`
+	ExcludeLabels []string   `json:"exclude_labels,omitempty"` // Skip responses annotated with these labels
+	MinLength     int        `json:"min_length,omitempty"`     // Skip responses shorter than this many characters
+	IncludeErrors bool       `json:"include_errors,omitempty"` // Also count responses that recorded a provider error
+	// IncludePromptText joins the prompt text into responses stored with only a prompt hash
+	IncludePromptText bool `json:"include_prompt_text,omitempty"`
+	// ComparePrevious adds the stats of the window of the same length before start_time
+	ComparePrevious bool `json:"compare_previous,omitempty"`
+	// CountMode is occurrences (default), counting every mention, or responses, counting the
+	// responses mentioning the keyword once each
+	CountMode string `json:"count_mode,omitempty"`
+}
+
+// AnnotateResponseRequest represents the request to annotate a response
+type AnnotateResponseRequest struct {
+	Label  string `json:"label" binding:"required"`
+	Note   string `json:"note,omitempty"`
+	Author string `json:"author,omitempty"` // Defaults to the X-Gego-Owner header
 }
 
 // SearchResponse represents the response for search operations
 type SearchResponse struct {
-	Keyword       string         `json:"keyword"`
-	TotalMentions int            `json:"total_mentions"`
-	UniquePrompts int            `json:"unique_prompts"`
-	UniqueLLMs    int            `json:"unique_llms"`
-	ByPrompt      map[string]int `json:"by_prompt"`
-	ByLLM         map[string]int `json:"by_llm"`
-	ByProvider    map[string]int `json:"by_provider"`
-	FirstSeen     time.Time      `json:"first_seen"`
-	LastSeen      time.Time      `json:"last_seen"`
-	Responses     []*Response    `json:"responses,omitempty"`
+	Keyword        string                 `json:"keyword"`
+	TotalMentions  int                    `json:"total_mentions"`
+	UniquePrompts  int                    `json:"unique_prompts"`
+	UniqueLLMs     int                    `json:"unique_llms"`
+	ByPrompt       map[string]int         `json:"by_prompt"`
+	ByLLM          map[string]int         `json:"by_llm"`
+	LLMs           map[string]LLMSnapshot `json:"llms,omitempty"` // llm_id -> LLM details recorded on its responses
+	ByProvider     map[string]int         `json:"by_provider"`
+	FirstSeen      time.Time              `json:"first_seen"`
+	LastSeen       time.Time              `json:"last_seen"`
+	Scanned        int                    `json:"scanned"`
+	Truncated      bool                   `json:"truncated,omitempty"`
+	ExcludedShort  int                    `json:"excluded_short,omitempty"`
+	Analysis       *KeywordAnalysis       `json:"analysis,omitempty"`
+	PreviousPeriod *KeywordPeriodChange   `json:"previous_period,omitempty"`
+	Responses      []*Response            `json:"responses,omitempty"`
+}
+
+// PromptLLMResponses represents the latest responses of one LLM to a prompt
+type PromptLLMResponses struct {
+	LLMID       string            `json:"llm_id"`
+	LLMName     string            `json:"llm_name"`
+	LLMModel    string            `json:"llm_model"`
+	LLMProvider string            `json:"llm_provider"`
+	Responses   []ResponseSummary `json:"responses"`
+}
+
+// ResponseSummary represents a response excerpt with its keyword mentions
+type ResponseSummary struct {
+	ID          string         `json:"id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	Temperature float64        `json:"temperature"`
+	Excerpt     string         `json:"excerpt"`
+	Mentions    map[string]int `json:"mentions"`
+}
+
+// ResponseComparison represents the latest responses of two LLMs to the same prompt, side by side
+type ResponseComparison struct {
+	PromptID string           `json:"prompt_id"`
+	Left     ComparedResponse `json:"left"`
+	Right    ComparedResponse `json:"right"`
+	Keywords []KeywordHits    `json:"keywords"`
+	Diff     []SentenceDiffOp `json:"diff"`
+}
+
+// DuplicateGroup represents a response text returned identically to the same prompt by several LLMs
+type DuplicateGroup struct {
+	ContentHash string   `json:"content_hash"`
+	Excerpt     string   `json:"excerpt"`
+	LLMIDs      []string `json:"llm_ids"`
+	LLMNames    []string `json:"llm_names"`
+	ResponseIDs []string `json:"response_ids"`
+}
+
+// ComparedResponse represents one side of a response comparison; Response is nil when
+// the LLM has no response to the prompt
+type ComparedResponse struct {
+	LLMID    string    `json:"llm_id"`
+	LLMName  string    `json:"llm_name"`
+	LLMModel string    `json:"llm_model,omitempty"`
+	Response *Response `json:"response,omitempty"`
+}
+
+// KeywordHits represents the mentions of a keyword in each compared response
+type KeywordHits struct {
+	Keyword string `json:"keyword"`
+	Left    int    `json:"left"`
+	Right   int    `json:"right"`
+}
+
+// Sentence diff operations
+const (
+	DiffEqual = "equal" // Sentence in both responses
+	DiffLeft  = "left"  // Sentence only in the left response
+	DiffRight = "right" // Sentence only in the right response
+)
+
+// SentenceDiffOp represents one sentence of a sentence-level diff
+type SentenceDiffOp struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
 }
@@ -1,6 +1,11 @@
+// Package models holds the shared data types. Each type has a single definition: domain
+// entities in core.go, statistics in stats.go, API payloads in api.go and database
+// connection settings in config.go.
 package models
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -8,16 +13,21 @@ import (
 
 // LLMConfig represents an LLM provider configuration
 type LLMConfig struct {
-	ID        string            `json:"id"`
-	Name      string            `json:"name"`
-	Provider  string            `json:"provider"` // openai, anthropic, ollama, custom
-	Model     string            `json:"model"`
-	APIKey    string            `json:"api_key,omitempty"`
-	BaseURL   string            `json:"base_url,omitempty"`
-	Config    map[string]string `json:"config,omitempty"` // Additional provider-specific config
-	Enabled   bool              `json:"enabled"`
-	CreatedAt time.Time         `json:"created_at"`
-	UpdatedAt time.Time         `json:"updated_at"`
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	Provider       string            `json:"provider"` // openai, anthropic, ollama, custom
+	Model          string            `json:"model"`
+	APIKey         string            `json:"api_key,omitempty"`
+	BaseURL        string            `json:"base_url,omitempty"`
+	Config         map[string]string `json:"config,omitempty"` // Additional provider-specific config
+	Enabled        bool              `json:"enabled"`
+	Owner          string            `json:"owner,omitempty"`           // Team or API token the LLM is attributed to
+	PromptPrefix   string            `json:"prompt_prefix,omitempty"`   // Sent before every prompt run with this LLM, e.g. "Answer in French:"
+	PromptSuffix   string            `json:"prompt_suffix,omitempty"`   // Sent after every prompt run with this LLM
+	AuthFailures   int               `json:"auth_failures,omitempty"`   // Consecutive calls rejected for authentication
+	DisabledReason string            `json:"disabled_reason,omitempty"` // Why gego disabled the LLM by itself; cleared on re-enable
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
 }
 
 // Prompt represents a prompt template
@@ -26,30 +36,68 @@ type Prompt struct {
 	Template  string    `json:"template"`
 	Tags      []string  `json:"tags,omitempty"`
 	Enabled   bool      `json:"enabled"`
+	Owner     string    `json:"owner,omitempty"` // Team or API token the prompt is attributed to
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // Schedule represents a scheduler configuration
 type Schedule struct {
-	ID          string     `json:"id"`
-	Name        string     `json:"name"`
-	PromptIDs   []string   `json:"prompt_ids"`
-	LLMIDs      []string   `json:"llm_ids"`
-	CronExpr    string     `json:"cron_expr"`             // Cron expression for scheduling
-	Temperature float64    `json:"temperature,omitempty"` // Temperature for LLM generation (0-1, default 0.7)
-	Enabled     bool       `json:"enabled"`
-	LastRun     *time.Time `json:"last_run,omitempty"`
-	NextRun     *time.Time `json:"next_run,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID            string             `json:"id"`
+	Name          string             `json:"name"`
+	PromptIDs     []string           `json:"prompt_ids"`
+	LLMIDs        []string           `json:"llm_ids"`
+	AllPrompts    bool               `json:"all_prompts"`           // Run every enabled prompt at fire time instead of PromptIDs
+	AllLLMs       bool               `json:"all_llms"`              // Run every enabled LLM at fire time instead of LLMIDs
+	CronExpr      string             `json:"cron_expr"`             // Cron expression for scheduling
+	Temperature   float64            `json:"temperature,omitempty"` // Temperature for LLM generation (0-1, default 0.7)
+	Enabled       bool               `json:"enabled"`
+	LastRun       *time.Time         `json:"last_run,omitempty"`
+	NextRun       *time.Time         `json:"next_run,omitempty"`
+	CatchUpPolicy string             `json:"catch_up_policy,omitempty"` // none, run_once_on_start or backfill_all
+	CatchUpMax    int                `json:"catch_up_max,omitempty"`    // Cap on backfill_all catch-up runs (0 = default)
+	MissedRuns    int                `json:"missed_runs"`               // Fire times missed while the scheduler was down
+	Seed          *int               `json:"seed,omitempty"`            // Sampling seed for providers that support it
+	Shuffle       bool               `json:"shuffle"`                   // Shuffle the prompt x LLM execution order of each run
+	CheckLanguage bool               `json:"check_language"`            // Flag responses not in the language of their prompt's lang-XX tag
+	PromptWeights map[string]float64 `json:"prompt_weights,omitempty"`  // Relative sampling weights by prompt ID; missing prompts weigh 1
+	SampleCount   int                `json:"sample_count,omitempty"`    // Prompts sampled by weight on each run (0 = run every prompt)
+	Owner         string             `json:"owner,omitempty"`           // Team or API token the schedule is attributed to
+	LastError     string             `json:"last_error,omitempty"`      // Why the scheduler could not register the schedule
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// Catch-up policies for missed schedule runs
+const (
+	CatchUpNone           = "none"
+	CatchUpRunOnceOnStart = "run_once_on_start"
+	CatchUpBackfillAll    = "backfill_all"
+)
+
+// DefaultCatchUpMax caps backfill_all catch-up runs when no maximum is configured
+const DefaultCatchUpMax = 5
+
+// GenerationRecipe represents saved parameters for regenerating a batch of prompts
+type GenerationRecipe struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	LLMID     string     `json:"llm_id"`     // LLM used to generate the prompts
+	Language  string     `json:"language"`   // Language code (e.g., EN, FR)
+	UserInput string     `json:"user_input"` // Description of the prompts to generate
+	Count     int        `json:"count"`      // Number of prompts to generate per run
+	Tags      []string   `json:"tags,omitempty"`
+	LastRun   *time.Time `json:"last_run,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
 }
 
 // Response represents an LLM response to a prompt
 type Response struct {
 	ID           string                 `json:"id" bson:"_id"`
 	PromptID     string                 `json:"prompt_id" bson:"prompt_id"`
-	PromptText   string                 `json:"prompt_text" bson:"prompt_text"` // Actual prompt sent
+	PromptText   string                 `json:"prompt_text,omitempty" bson:"prompt_text,omitempty"` // Actual prompt sent, stored only when enabled
+	PromptHash   string                 `json:"prompt_hash,omitempty" bson:"prompt_hash,omitempty"` // SHA-256 of the prompt sent
 	LLMID        string                 `json:"llm_id" bson:"llm_id"`
 	LLMName      string                 `json:"llm_name" bson:"llm_name"`
 	LLMProvider  string                 `json:"llm_provider" bson:"llm_provider"`
@@ -58,12 +106,128 @@ type Response struct {
 	Temperature  float64                `json:"temperature,omitempty" bson:"temperature,omitempty"` // Temperature used for generation
 	Metadata     map[string]interface{} `json:"metadata,omitempty" bson:"metadata,omitempty"`       // Additional metadata
 	ScheduleID   string                 `json:"schedule_id,omitempty" bson:"schedule_id,omitempty"`
+	RunID        string                 `json:"run_id,omitempty" bson:"run_id,omitempty"` // Schedule execution that produced it
 	TokensUsed   int                    `json:"tokens_used,omitempty" bson:"tokens_used,omitempty"`
-	LatencyMs    int64                  `json:"latency_ms,omitempty" bson:"latency_ms,omitempty"`
+	LatencyMs    int64                  `json:"latency_ms,omitempty" bson:"latency_ms,omitempty"`       // Duration of the provider call
+	QueueWaitMs  int64                  `json:"queue_wait_ms,omitempty" bson:"queue_wait_ms,omitempty"` // Time spent waiting for the provider rate limiter
 	Error        string                 `json:"error,omitempty" bson:"error,omitempty"`
+	ErrorClass   string                 `json:"error_class,omitempty" bson:"error_class,omitempty"` // rate_limit or quota, when the provider error was recognized
+	Owner        string                 `json:"owner,omitempty" bson:"owner,omitempty"`             // Owner of the schedule, prompt or LLM that produced it
+	Annotations  []Annotation           `json:"annotations,omitempty" bson:"annotations,omitempty"` // Reviewer labels
+	Domains      []string               `json:"domains,omitempty" bson:"domains,omitempty"`         // Distinct domains cited in the response
+	Grounded     bool                   `json:"grounded,omitempty" bson:"grounded,omitempty"`       // Generated with a web search
 	CreatedAt    time.Time              `json:"created_at" bson:"created_at"`
 }
 
+// LLMSnapshot is the LLM name, provider and model recorded on a response when it was generated
+type LLMSnapshot struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+}
+
+// LLMSnapshot returns the LLM details recorded on the response
+func (r *Response) LLMSnapshot() LLMSnapshot {
+	return LLMSnapshot{Name: r.LLMName, Provider: r.LLMProvider, Model: r.LLMModel}
+}
+
+// Empty reports whether nothing was recorded, as on responses stored before snapshots
+func (s LLMSnapshot) Empty() bool {
+	return s.Name == "" && s.Provider == "" && s.Model == ""
+}
+
+// Label returns "model (provider)", falling back to the name when no model was recorded
+func (s LLMSnapshot) Label() string {
+	model := s.Model
+	if model == "" {
+		model = s.Name
+	}
+	if s.Provider == "" {
+		return model
+	}
+	return model + " (" + s.Provider + ")"
+}
+
+// LLMResponses groups the latest responses of one LLM to a prompt, newest first
+type LLMResponses struct {
+	LLMID       string
+	LLMName     string
+	LLMModel    string
+	LLMProvider string
+	Responses   []*Response
+}
+
+// MetadataAnalysis is the response metadata key holding the judge LLM's ResponseAnalysis
+const MetadataAnalysis = "analysis"
+
+// Brand sentiments reported by the judge LLM
+const (
+	SentimentPositive = "positive"
+	SentimentNeutral  = "neutral"
+	SentimentNegative = "negative"
+)
+
+// MaxRecommendationStrength is the strongest recommendation a judge LLM can report
+const MaxRecommendationStrength = 5
+
+// ResponseAnalysis is a judge LLM's assessment of the brands mentioned in a response
+type ResponseAnalysis struct {
+	JudgeLLMID string          `json:"judge_llm_id" bson:"judge_llm_id"`
+	JudgeModel string          `json:"judge_model" bson:"judge_model"`
+	Brands     []BrandAnalysis `json:"brands" bson:"brands"`
+	AnalyzedAt time.Time       `json:"analyzed_at" bson:"analyzed_at"`
+}
+
+// BrandAnalysis is the sentiment towards a brand in a response and how strongly it is recommended
+type BrandAnalysis struct {
+	Brand     string `json:"brand" bson:"brand"`
+	Sentiment string `json:"sentiment" bson:"sentiment"` // positive, neutral or negative
+	Strength  int    `json:"strength" bson:"strength"`   // Recommendation strength, 0 to MaxRecommendationStrength
+}
+
+// AnalysisFromMetadata returns the judge analysis stored in response metadata, if any
+func AnalysisFromMetadata(metadata map[string]interface{}) (*ResponseAnalysis, bool) {
+	value, ok := metadata[MetadataAnalysis]
+	if !ok || value == nil {
+		return nil, false
+	}
+	// Stored analyses are read back as generic maps; a JSON round trip restores the struct
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	var analysis ResponseAnalysis
+	if err := json.Unmarshal(data, &analysis); err != nil {
+		return nil, false
+	}
+	return &analysis, true
+}
+
+// Brand returns the analysis of brand, matched case-insensitively, if the judge reported it
+func (a *ResponseAnalysis) Brand(brand string) (BrandAnalysis, bool) {
+	for _, analyzed := range a.Brands {
+		if strings.EqualFold(strings.TrimSpace(analyzed.Brand), strings.TrimSpace(brand)) {
+			return analyzed, true
+		}
+	}
+	return BrandAnalysis{}, false
+}
+
+// Annotation is a reviewer's label on a response, used to build labeled datasets
+type Annotation struct {
+	Label     string    `json:"label" bson:"label"`
+	Note      string    `json:"note,omitempty" bson:"note,omitempty"`
+	Author    string    `json:"author,omitempty" bson:"author,omitempty"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// Common annotation labels; any other lowercase label is accepted too
+const (
+	LabelHallucination  = "hallucination"
+	LabelGreatPlacement = "great_placement"
+	LabelIrrelevant     = "irrelevant"
+)
+
 // ModelInfo represents information about an available model from a provider
 type ModelInfo struct {
 	ID          string `json:"id"`
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -16,6 +17,21 @@ type KeywordCount struct {
 	Count   int    `json:"count"`
 }
 
+// DomainCount represents the number of responses citing a domain
+type DomainCount struct {
+	Domain    string `json:"domain"`
+	Responses int    `json:"responses"`
+}
+
+// ScheduleRun summarizes the responses of one schedule execution
+type ScheduleRun struct {
+	RunID      string    `json:"run_id" bson:"_id"`
+	StartedAt  time.Time `json:"started_at" bson:"started_at"`   // First response of the run
+	FinishedAt time.Time `json:"finished_at" bson:"finished_at"` // Last response of the run
+	Responses  int       `json:"responses" bson:"responses"`
+	Errors     int       `json:"errors" bson:"errors"`
+}
+
 // PromptStats represents aggregated statistics for a prompt
 type PromptStats struct {
 	PromptID       string         `json:"prompt_id"`
@@ -38,13 +54,177 @@ type LLMStats struct {
 
 // KeywordStats represents on-demand calculated statistics for a keyword search
 type KeywordStats struct {
-	Keyword       string         `json:"keyword"`
-	TotalMentions int            `json:"total_mentions"`
-	UniquePrompts int            `json:"unique_prompts"`
-	UniqueLLMs    int            `json:"unique_llms"`
-	ByPrompt      map[string]int `json:"by_prompt"`   // prompt_id -> count
-	ByLLM         map[string]int `json:"by_llm"`      // llm_id -> count
-	ByProvider    map[string]int `json:"by_provider"` // provider -> count
-	FirstSeen     time.Time      `json:"first_seen"`
-	LastSeen      time.Time      `json:"last_seen"`
+	Keyword       string                 `json:"keyword"`
+	TotalMentions int                    `json:"total_mentions"`       // Mentions, or responses mentioning the keyword when CountMode is responses
+	CountMode     string                 `json:"count_mode,omitempty"` // occurrences or responses
+	UniquePrompts int                    `json:"unique_prompts"`
+	UniqueLLMs    int                    `json:"unique_llms"`
+	ByPrompt      map[string]int         `json:"by_prompt"`      // prompt_id -> count
+	ByLLM         map[string]int         `json:"by_llm"`         // llm_id -> count
+	LLMs          map[string]LLMSnapshot `json:"llms,omitempty"` // llm_id -> LLM details recorded on its responses
+	ByProvider    map[string]int         `json:"by_provider"`    // provider -> count
+	FirstSeen     time.Time              `json:"first_seen"`
+	LastSeen      time.Time              `json:"last_seen"`
+	Scanned       int                    `json:"scanned"`                  // Responses scanned
+	Truncated     bool                   `json:"truncated,omitempty"`      // The scan stopped at its limit or deadline; counts are partial
+	ExcludedShort int                    `json:"excluded_short,omitempty"` // Matching responses skipped for being shorter than the minimum length
+	Analysis      *KeywordAnalysis       `json:"analysis,omitempty"`       // Judge LLM assessments of the keyword, when responses were analyzed
+}
+
+// KeywordAnalysis aggregates the judge LLM assessments of a keyword across responses
+type KeywordAnalysis struct {
+	Responses   int            `json:"responses"`    // Analyzed responses in which the judge reported the keyword
+	BySentiment map[string]int `json:"by_sentiment"` // sentiment -> responses
+	AvgStrength float64        `json:"avg_strength"` // Average recommendation strength, 0 to 5
+}
+
+// Add counts one judge assessment of the keyword
+func (a *KeywordAnalysis) Add(brand BrandAnalysis) {
+	if a.BySentiment == nil {
+		a.BySentiment = make(map[string]int)
+	}
+	a.AvgStrength = (a.AvgStrength*float64(a.Responses) + float64(brand.Strength)) / float64(a.Responses+1)
+	a.Responses++
+	a.BySentiment[brand.Sentiment]++
+}
+
+// KeywordPeriodChange compares keyword stats with those of the window of the same length before them
+type KeywordPeriodChange struct {
+	Start         time.Time              `json:"start"`
+	End           time.Time              `json:"end"`
+	TotalMentions CountChange            `json:"total_mentions"`
+	UniquePrompts CountChange            `json:"unique_prompts"`
+	UniqueLLMs    CountChange            `json:"unique_llms"`
+	ByProvider    map[string]CountChange `json:"by_provider"`
+	Truncated     bool                   `json:"truncated,omitempty"` // The scan of the previous window stopped at its limit or deadline
+}
+
+// CountChange is a count of the previous window and how it changed in the current one
+type CountChange struct {
+	Previous int      `json:"previous"`
+	Current  int      `json:"current"`
+	Delta    int      `json:"delta"`
+	Percent  *float64 `json:"percent,omitempty"` // Unset when the previous count is 0
+	Change   string   `json:"change"`            // Signed percentage such as "+12.5%" or "-3.0%", or "new"
+}
+
+// NewCountChange computes the change from previous to current
+func NewCountChange(previous, current int) CountChange {
+	change := CountChange{Previous: previous, Current: current, Delta: current - previous}
+	switch {
+	case previous == 0 && current == 0:
+		change.Change = "0%"
+	case previous == 0:
+		change.Change = "new"
+	default:
+		percent := float64(change.Delta) / float64(previous) * 100
+		change.Percent = &percent
+		change.Change = fmt.Sprintf("%+.1f%%", percent)
+	}
+	return change
+}
+
+// KeywordDelta represents the change of a keyword between two periods
+type KeywordDelta struct {
+	Keyword      string  `json:"keyword"`
+	Period1Count int     `json:"period1_count"`
+	Period2Count int     `json:"period2_count"`
+	Delta        int     `json:"delta"`
+	Period1Share float64 `json:"period1_share"` // share of voice in percent
+	Period2Share float64 `json:"period2_share"` // share of voice in percent
+	Period1Rank  int     `json:"period1_rank,omitempty"`
+	Period2Rank  int     `json:"period2_rank,omitempty"`
+	RankChange   int     `json:"rank_change"` // positive means the keyword moved up
+	IsNew        bool    `json:"is_new"`
+	IsDropped    bool    `json:"is_dropped"`
+}
+
+// PeriodComparison represents keyword statistics compared across two time periods
+type PeriodComparison struct {
+	Period1Start    time.Time      `json:"period1_start"`
+	Period1End      time.Time      `json:"period1_end"`
+	Period2Start    time.Time      `json:"period2_start"`
+	Period2End      time.Time      `json:"period2_end"`
+	Period1Mentions int            `json:"period1_mentions"`
+	Period2Mentions int            `json:"period2_mentions"`
+	Keywords        []KeywordDelta `json:"keywords"`
+}
+
+// GEOScore is the composite visibility score of a keyword over a period.
+// Score and Components are nil when the period has too few responses to be meaningful.
+type GEOScore struct {
+	Keyword          string              `json:"keyword"`
+	PeriodStart      time.Time           `json:"period_start"`
+	PeriodEnd        time.Time           `json:"period_end"`
+	Responses        int                 `json:"responses"`
+	MinResponses     int                 `json:"min_responses"`
+	InsufficientData bool                `json:"insufficient_data"`
+	Score            *float64            `json:"score,omitempty"` // 0-100
+	Components       *GEOScoreComponents `json:"components,omitempty"`
+}
+
+// GEOScoreComponents is the breakdown of a GEO score. Components that cannot be measured
+// (no list placements, no keyword group, no enabled LLMs) are nil and left out of the score.
+type GEOScoreComponents struct {
+	MentionRate    float64         `json:"mention_rate"`             // Fraction of responses mentioning the keyword
+	AvgPosition    *float64        `json:"avg_position,omitempty"`   // Average 1-based list position where the keyword is listed
+	PositionScore  *float64        `json:"position_score,omitempty"` // Average reciprocal list position, 1 for always first
+	ShareOfVoice   *float64        `json:"share_of_voice,omitempty"` // Keyword mentions over mentions of the whole keyword group
+	Coverage       *float64        `json:"coverage,omitempty"`       // Fraction of enabled LLMs mentioning the keyword at least once
+	Mentions       int             `json:"mentions"`                 // Responses mentioning the keyword
+	ListPlacements int             `json:"list_placements"`          // Responses listing the keyword in a numbered or bulleted list
+	GroupMentions  int             `json:"group_mentions,omitempty"` // Mentions of the keyword and its group
+	Group          []string        `json:"group,omitempty"`          // Keywords share of voice is measured against
+	MentioningLLMs int             `json:"mentioning_llms"`          // Enabled LLMs mentioning the keyword
+	EnabledLLMs    int             `json:"enabled_llms"`             // Enabled LLMs
+	Weights        GEOScoreWeights `json:"weights"`
+	// Mention rates of the responses generated with and without a web search, set when the period
+	// has both. They are informational and not part of the score.
+	GroundedResponses     int      `json:"grounded_responses,omitempty"`
+	GroundedMentionRate   *float64 `json:"grounded_mention_rate,omitempty"`
+	UngroundedMentionRate *float64 `json:"ungrounded_mention_rate,omitempty"`
+}
+
+// GEOScoreWeights are the relative weights of the GEO score components
+type GEOScoreWeights struct {
+	MentionRate  float64 `json:"mention_rate"`
+	Position     float64 `json:"position"`
+	ShareOfVoice float64 `json:"share_of_voice"`
+	Coverage     float64 `json:"coverage"`
+}
+
+// StatsSnapshot is the keyword ranking of a period computed at a point in time. Snapshots are
+// stored so that trends can be charted after the responses they were computed from are deleted.
+type StatsSnapshot struct {
+	ID            string         `json:"id" bson:"_id"`
+	Owner         string         `json:"owner,omitempty" bson:"owner,omitempty"`
+	CreatedAt     time.Time      `json:"created_at" bson:"created_at"`
+	PeriodStart   time.Time      `json:"period_start" bson:"period_start"`
+	PeriodEnd     time.Time      `json:"period_end" bson:"period_end"`
+	CountMode     string         `json:"count_mode" bson:"count_mode"`         // occurrences or responses
+	TotalMentions int            `json:"total_mentions" bson:"total_mentions"` // Mentions of every keyword of the period, the share of voice base
+	Keywords      []KeywordShare `json:"keywords" bson:"keywords"`             // Top keywords, most mentioned first
+}
+
+// KeywordShare is the mentions and share of voice of a keyword in a snapshot
+type KeywordShare struct {
+	Keyword string  `json:"keyword" bson:"keyword"`
+	Count   int     `json:"count" bson:"count"`
+	Share   float64 `json:"share" bson:"share"` // share of voice in percent
+}
+
+// KeywordTrend is the mentions of a keyword across snapshots, oldest first
+type KeywordTrend struct {
+	Keyword string              `json:"keyword"`
+	Points  []KeywordTrendPoint `json:"points"`
+}
+
+// KeywordTrendPoint is a keyword in one snapshot. Rank is 0 when the keyword is not among the
+// top keywords of the snapshot, whose count and share are then 0.
+type KeywordTrendPoint struct {
+	SnapshotID string    `json:"snapshot_id"`
+	Timestamp  time.Time `json:"timestamp"` // End of the snapshot period
+	Count      int       `json:"count"`
+	Share      float64   `json:"share"`
+	Rank       int       `json:"rank,omitempty"`
 }
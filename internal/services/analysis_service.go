@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// DefaultAnalysisPrompt is the judge prompt used when none is configured. {{keywords}} is replaced
+// by the tracked keywords and {{response}} by the analyzed response.
+const DefaultAnalysisPrompt = `You are reviewing an answer given by an AI assistant.
+Tracked brands: {{keywords}}
+
+For each tracked brand mentioned in the answer, report the sentiment of the answer towards it
+(positive, neutral or negative) and how strongly the answer recommends it, from 0 (not
+recommended) to 5 (top recommendation). Leave out brands the answer does not mention.
+
+Answer:
+{{response}}`
+
+// analysisTemperature keeps judge assessments as repeatable as the provider allows
+const analysisTemperature = 0.0
+
+// analysisSchema is the JSON schema of the judge LLM's answer
+var analysisSchema = json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "brands": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "brand": {"type": "string"},
+          "sentiment": {"type": "string", "enum": ["positive", "neutral", "negative"]},
+          "strength": {"type": "integer", "minimum": 0, "maximum": 5}
+        },
+        "required": ["brand", "sentiment", "strength"]
+      }
+    }
+  },
+  "required": ["brands"]
+}`)
+
+// AnalysisOptions configures the judge prompt and the caps of an analysis run
+type AnalysisOptions struct {
+	Keywords     []string // Tracked keywords the judge looks for
+	Prompt       string   // Judge prompt template (default: DefaultAnalysisPrompt)
+	MaxResponses int      // Responses analyzed per run at most (0 for no limit)
+	MaxTokens    int      // Judge tokens spent per run at most (0 for no limit)
+}
+
+// AnalysisResult summarizes an analysis run
+type AnalysisResult struct {
+	Analyzed   int  // Responses analyzed and updated
+	Skipped    int  // Errored or already analyzed responses
+	Failed     int  // Responses the judge could not analyze
+	TokensUsed int  // Tokens spent by the judge
+	CapReached bool // The run stopped at its response or token cap
+}
+
+// AnalysisService has a judge LLM assess the brands mentioned in responses
+type AnalysisService struct {
+	db        db.Database
+	execution *ExecutionService
+	judge     *models.LLMConfig
+	options   AnalysisOptions
+}
+
+// NewAnalysisService creates a new analysis service judging responses with judge
+func NewAnalysisService(database db.Database, registry *llm.Registry, judge *models.LLMConfig, options AnalysisOptions) *AnalysisService {
+	if options.Prompt == "" {
+		options.Prompt = DefaultAnalysisPrompt
+	}
+	return &AnalysisService{
+		db:        database,
+		execution: NewExecutionService(database, registry),
+		judge:     judge,
+		options:   options,
+	}
+}
+
+// SetRateLimiters paces judge calls with the given per-provider rate limiters
+func (s *AnalysisService) SetRateLimiters(rateLimiters *RateLimiters) {
+	s.execution.SetRateLimiters(rateLimiters)
+}
+
+// ResolveJudgeLLM returns the LLM with the given ID, or else the one with that name
+func ResolveJudgeLLM(ctx context.Context, database db.Database, idOrName string) (*models.LLMConfig, error) {
+	if idOrName == "" {
+		return nil, fmt.Errorf("no judge LLM configured: set analysis.judge_llm in config.yaml")
+	}
+	if judge, err := database.GetLLM(ctx, idOrName); err == nil {
+		return judge, nil
+	}
+
+	llms, err := database.ListLLMs(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LLMs: %w", err)
+	}
+	for _, candidate := range llms {
+		if strings.EqualFold(candidate.Name, idOrName) {
+			return candidate, nil
+		}
+	}
+	return nil, fmt.Errorf("judge LLM not found: %s", idOrName)
+}
+
+// AnalyzeSchedule analyzes the responses of a schedule created since the given time
+func (s *AnalysisService) AnalyzeSchedule(ctx context.Context, scheduleID string, since time.Time) (*AnalysisResult, error) {
+	responses, err := s.db.ListResponses(ctx, shared.ResponseFilter{ScheduleID: scheduleID, StartTime: &since})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list responses: %w", err)
+	}
+	return s.AnalyzeResponses(ctx, responses), nil
+}
+
+// AnalyzeResponses stores the judge's analysis in the metadata of each response not analyzed yet.
+// A response the judge fails on is logged and counted, leaving the response itself untouched.
+func (s *AnalysisService) AnalyzeResponses(ctx context.Context, responses []*models.Response) *AnalysisResult {
+	result := &AnalysisResult{}
+	for _, response := range responses {
+		if response.Error != "" || response.ResponseText == "" {
+			result.Skipped++
+			continue
+		}
+		if _, analyzed := models.AnalysisFromMetadata(response.Metadata); analyzed {
+			result.Skipped++
+			continue
+		}
+		if s.capReached(result) {
+			result.CapReached = true
+			break
+		}
+
+		tokens, err := s.analyzeResponse(ctx, response)
+		result.TokensUsed += tokens
+		if err != nil {
+			logger.Warning("Failed to analyze response %s: %v", response.ID, err)
+			result.Failed++
+			continue
+		}
+		result.Analyzed++
+	}
+	return result
+}
+
+// capReached reports whether the run has reached its response or token cap
+func (s *AnalysisService) capReached(result *AnalysisResult) bool {
+	if s.options.MaxResponses > 0 && result.Analyzed+result.Failed >= s.options.MaxResponses {
+		return true
+	}
+	return s.options.MaxTokens > 0 && result.TokensUsed >= s.options.MaxTokens
+}
+
+// analyzeResponse has the judge assess one response and stores the result, returning the tokens spent
+func (s *AnalysisService) analyzeResponse(ctx context.Context, response *models.Response) (int, error) {
+	prompt := strings.NewReplacer(
+		"{{keywords}}", strings.Join(s.options.Keywords, ", "),
+		"{{response}}", response.ResponseText,
+	).Replace(s.options.Prompt)
+
+	judged, err := s.execution.GenerateStructured(ctx, s.judge, prompt, analysisSchema, analysisTemperature)
+	if err != nil {
+		return 0, err
+	}
+
+	var output struct {
+		Brands []models.BrandAnalysis `json:"brands"`
+	}
+	if err := json.Unmarshal([]byte(judged.Text), &output); err != nil {
+		return judged.TokensUsed, fmt.Errorf("failed to parse judge output: %w", err)
+	}
+
+	analysis := models.ResponseAnalysis{
+		JudgeLLMID: s.judge.ID,
+		JudgeModel: s.judge.Model,
+		Brands:     make([]models.BrandAnalysis, 0, len(output.Brands)),
+		AnalyzedAt: time.Now(),
+	}
+	for _, brand := range output.Brands {
+		brand.Brand = strings.TrimSpace(brand.Brand)
+		if brand.Brand == "" {
+			continue
+		}
+		brand.Sentiment = strings.ToLower(brand.Sentiment)
+		brand.Strength = max(0, min(brand.Strength, models.MaxRecommendationStrength))
+		analysis.Brands = append(analysis.Brands, brand)
+	}
+
+	if err := s.db.SetResponseMetadata(ctx, response.ID, models.MetadataAnalysis, analysis); err != nil {
+		return judged.TokensUsed, fmt.Errorf("failed to store analysis: %w", err)
+	}
+	return judged.TokensUsed, nil
+}
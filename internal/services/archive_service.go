@@ -0,0 +1,330 @@
+package services
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"html"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+//go:embed templates/archive.html
+var archiveTemplateFS embed.FS
+
+var archiveTemplates = template.Must(template.ParseFS(archiveTemplateFS, "templates/archive.html"))
+
+// archivePageSize is the number of responses read per page while exporting an archive
+const archivePageSize = 500
+
+// archiveTimeFormat is the format of dates in archive pages
+const archiveTimeFormat = "2006-01-02 15:04:05 MST"
+
+// Archive subdirectories, rewritten by every export
+var archiveDirs = []string{"prompts", "llms", "responses"}
+
+// ArchiveOptions selects the responses of an archive
+type ArchiveOptions struct {
+	Keyword   string
+	StartTime *time.Time
+	EndTime   *time.Time
+	RunID     string               // Only export the responses of this schedule run
+	Period    string               // Description of the time window, shown on the summary page
+	Stats     *models.KeywordStats // Keyword stats of the window, shown on the summary page
+}
+
+// ArchiveResult summarizes an exported archive
+type ArchiveResult struct {
+	Responses int
+	Prompts   int
+	LLMs      int
+	Removed   int // Pages of earlier exports that no longer have a response
+}
+
+// ArchiveService exports the responses mentioning a keyword as a static HTML archive
+type ArchiveService struct {
+	db db.Database
+}
+
+// NewArchiveService creates a new archive service
+func NewArchiveService(database db.Database) *ArchiveService {
+	return &ArchiveService{db: database}
+}
+
+// archiveEntry is what prompt and LLM pages keep of a response once its page is written
+type archiveEntry struct {
+	File      string
+	CreatedAt string
+	Label     string
+	Mentions  int
+}
+
+// archiveGroup collects the entries of one prompt or LLM
+type archiveGroup struct {
+	ID        string
+	File      string
+	Name      string
+	Template  string
+	Detail    string
+	Responses int
+	Mentions  int
+	entries   []archiveEntry
+}
+
+// archiveResponse is the data of a response page
+type archiveResponse struct {
+	LLM        string
+	CreatedAt  string
+	Mentions   int
+	Prompt     string
+	PromptFile string
+	Body       template.HTML
+}
+
+// archivePage is the data of every archive page
+type archivePage struct {
+	Title       string
+	Keyword     string
+	Period      string
+	Root        string
+	Prompts     []*archiveGroup
+	LLMs        []*archiveGroup
+	Description string
+	Column      string
+	Entries     []archiveEntry
+	Response    archiveResponse
+	Stats       *models.KeywordStats
+	Responses   int
+}
+
+// Export writes the archive of the responses mentioning opts.Keyword to dir: index.html lists the
+// prompts, llms.html the LLMs and summary.html the keyword stats, with one page per prompt, LLM and
+// response below. Responses are read and written a page at a time, and file names derive from IDs
+// so that repeated exports of the same data are identical.
+func (s *ArchiveService) Export(ctx context.Context, dir string, opts ArchiveOptions) (*ArchiveResult, error) {
+	if strings.TrimSpace(opts.Keyword) == "" {
+		return nil, fmt.Errorf("keyword is required")
+	}
+	for _, sub := range archiveDirs {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create archive directory: %w", err)
+		}
+	}
+
+	regex := regexp.MustCompile("(?i)" + regexp.QuoteMeta(opts.Keyword))
+	page := archivePage{Keyword: opts.Keyword, Root: "../"}
+	prompts := NewPromptTextResolver(s.db)
+	promptGroups := make(map[string]*archiveGroup)
+	llmGroups := make(map[string]*archiveGroup)
+	written := make(map[string]bool)
+	result := &ArchiveResult{}
+
+	filter := shared.ResponseFilter{
+		Keyword:   regexp.QuoteMeta(opts.Keyword),
+		StartTime: opts.StartTime,
+		EndTime:   opts.EndTime,
+		RunID:     opts.RunID,
+		Limit:     archivePageSize,
+	}
+	for {
+		responses, err := s.db.ListResponses(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list responses: %w", err)
+		}
+		if err := prompts.Resolve(ctx, responses); err != nil {
+			return nil, err
+		}
+
+		for _, response := range responses {
+			mentions := len(regex.FindAllStringIndex(response.ResponseText, -1))
+			promptGroup := archiveGroupFor(promptGroups, response.PromptID)
+			llmGroup := archiveGroupFor(llmGroups, response.LLMID)
+
+			promptText := prompts.Template(response.PromptID)
+			if promptText == "" {
+				promptText = response.PromptText
+			}
+			if promptGroup.Template == "" {
+				promptGroup.Template = orDefault(promptText, "Deleted prompt "+response.PromptID)
+			}
+			snapshot := response.LLMSnapshot()
+			if llmGroup.Name == "" {
+				llmGroup.Name = orDefault(snapshot.Name, response.LLMID)
+				llmGroup.Detail = snapshot.Label()
+			}
+
+			file := archiveFileName(response.ID)
+			createdAt := response.CreatedAt.UTC().Format(archiveTimeFormat)
+			page.Title = llmGroup.Name + " · " + createdAt
+			page.Response = archiveResponse{
+				LLM:        orDefault(snapshot.Label(), llmGroup.Name),
+				CreatedAt:  createdAt,
+				Mentions:   mentions,
+				Prompt:     promptGroup.Template,
+				PromptFile: promptGroup.File,
+				Body:       highlightHTML(response.ResponseText, regex),
+			}
+			if err := writeArchivePage(dir, "responses", file, "response", page, written); err != nil {
+				return nil, err
+			}
+
+			promptGroup.add(archiveEntry{File: file, CreatedAt: createdAt, Label: llmGroup.Name, Mentions: mentions})
+			llmGroup.add(archiveEntry{File: file, CreatedAt: createdAt, Label: Excerpt(promptGroup.Template, 80), Mentions: mentions})
+			result.Responses++
+		}
+
+		if len(responses) < archivePageSize {
+			break
+		}
+		last := responses[len(responses)-1]
+		filter.After = shared.NewResponseCursor(last.CreatedAt, last.ID)
+	}
+
+	promptList := sortedArchiveGroups(promptGroups, func(g *archiveGroup) string { return g.Template })
+	for _, group := range promptList {
+		page.Title, page.Description, page.Column, page.Entries = "Prompt", group.Template, "LLM", group.entries
+		if err := writeArchivePage(dir, "prompts", group.File, "group", page, written); err != nil {
+			return nil, err
+		}
+	}
+	llmList := sortedArchiveGroups(llmGroups, func(g *archiveGroup) string { return g.Name })
+	for _, group := range llmList {
+		page.Title, page.Description, page.Column, page.Entries = group.Name, group.Detail, "Prompt", group.entries
+		if err := writeArchivePage(dir, "llms", group.File, "group", page, written); err != nil {
+			return nil, err
+		}
+	}
+
+	page = archivePage{Keyword: opts.Keyword, Period: opts.Period, Prompts: promptList, LLMs: llmList, Stats: opts.Stats, Responses: result.Responses}
+	for _, root := range []struct{ file, title, name string }{
+		{"index.html", "Prompts", "index"},
+		{"llms.html", "LLMs", "llms"},
+		{"summary.html", "Summary", "summary"},
+	} {
+		page.Title = root.title
+		if err := writeArchivePage(dir, "", root.file, root.name, page, written); err != nil {
+			return nil, err
+		}
+	}
+
+	removed, err := removeStaleArchivePages(dir, written)
+	if err != nil {
+		return nil, err
+	}
+	result.Prompts, result.LLMs, result.Removed = len(promptList), len(llmList), removed
+	return result, nil
+}
+
+// add records a response of the group
+func (g *archiveGroup) add(entry archiveEntry) {
+	g.entries = append(g.entries, entry)
+	g.Responses++
+	g.Mentions += entry.Mentions
+}
+
+// archiveGroupFor returns the group of id, creating it on first use
+func archiveGroupFor(groups map[string]*archiveGroup, id string) *archiveGroup {
+	group, ok := groups[id]
+	if !ok {
+		group = &archiveGroup{ID: id, File: archiveFileName(id)}
+		groups[id] = group
+	}
+	return group
+}
+
+// sortedArchiveGroups returns groups ordered by name, then ID, so that pages list them stably
+func sortedArchiveGroups(groups map[string]*archiveGroup, name func(*archiveGroup) string) []*archiveGroup {
+	list := make([]*archiveGroup, 0, len(groups))
+	for _, group := range groups {
+		list = append(list, group)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if a, b := strings.ToLower(name(list[i])), strings.ToLower(name(list[j])); a != b {
+			return a < b
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// archiveFilePattern matches the characters not kept in archive file names
+var archiveFilePattern = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// archiveFileName returns the stable page file name of an ID
+func archiveFileName(id string) string {
+	if id == "" {
+		id = "unknown"
+	}
+	return archiveFilePattern.ReplaceAllString(id, "_") + ".html"
+}
+
+// writeArchivePage renders the named template with page into dir/sub/file and records it as written
+func writeArchivePage(dir, sub, file, name string, page archivePage, written map[string]bool) error {
+	path := filepath.Join(dir, sub, file)
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := archiveTemplates.ExecuteTemplate(out, name, page); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to render %s: %w", path, err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	written[path] = true
+	return nil
+}
+
+// removeStaleArchivePages deletes the pages of earlier exports that this export did not write
+func removeStaleArchivePages(dir string, written map[string]bool) (int, error) {
+	removed := 0
+	for _, sub := range archiveDirs {
+		paths, err := filepath.Glob(filepath.Join(dir, sub, "*.html"))
+		if err != nil {
+			return removed, err
+		}
+		for _, path := range paths {
+			if written[path] {
+				continue
+			}
+			if err := os.Remove(path); err != nil {
+				return removed, fmt.Errorf("failed to remove stale page %s: %w", path, err)
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// highlightHTML escapes text for HTML, wrapping the matches of regex in <mark>
+func highlightHTML(text string, regex *regexp.Regexp) template.HTML {
+	var b strings.Builder
+	last := 0
+	for _, match := range regex.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:match[0]]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[match[0]:match[1]]))
+		b.WriteString("</mark>")
+		last = match[1]
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return template.HTML(b.String())
+}
+
+// orDefault returns value, or fallback when value is empty
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// DefaultAuthFailureThreshold is the number of consecutive authentication failures, counted across
+// runs, after which the scheduler disables an LLM
+const DefaultAuthFailureThreshold = 10
+
+// errLLMAutoDisabled skips the executions of an LLM disabled for authentication failures earlier in the run
+var errLLMAutoDisabled = errors.New("LLM disabled after repeated authentication failures")
+
+// authFailureTracker counts the consecutive authentication failures of the LLMs of a schedule run,
+// continuing from the counts stored with them
+type authFailureTracker struct {
+	mu       sync.Mutex
+	failures map[string]int // LLM ID -> consecutive authentication failures
+	disabled map[string]bool
+}
+
+type authFailureTrackerKey struct{}
+
+// withAuthFailureTracker returns a context whose executions share tracker
+func withAuthFailureTracker(ctx context.Context, tracker *authFailureTracker) context.Context {
+	return context.WithValue(ctx, authFailureTrackerKey{}, tracker)
+}
+
+// newAuthFailureTracker creates an empty tracker
+func newAuthFailureTracker() *authFailureTracker {
+	return &authFailureTracker{
+		failures: make(map[string]int),
+		disabled: make(map[string]bool),
+	}
+}
+
+// llmAutoDisabled reports whether llmConfig was disabled for authentication failures earlier in the run of ctx
+func llmAutoDisabled(ctx context.Context, llmConfig *models.LLMConfig) bool {
+	tracker, ok := ctx.Value(authFailureTrackerKey{}).(*authFailureTracker)
+	if !ok {
+		return false
+	}
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	return tracker.disabled[llmConfig.ID]
+}
+
+// SetAuthFailureThreshold disables an LLM once threshold consecutive calls were rejected for
+// authentication; 0 restores the default and a negative threshold never disables LLMs
+func (s *SchedulerService) SetAuthFailureThreshold(threshold int) {
+	if threshold == 0 {
+		threshold = DefaultAuthFailureThreshold
+	}
+	s.authFailureThreshold = threshold
+}
+
+// recordAuthResult updates the consecutive authentication failures of llmConfig with the outcome
+// of response: authentication errors count, a successful call resets the count and other errors,
+// such as rate limits and server errors, leave it as is. The LLM is disabled when the count
+// reaches the threshold.
+func (s *SchedulerService) recordAuthResult(ctx context.Context, llmConfig *models.LLMConfig, response *models.Response) {
+	tracker, ok := ctx.Value(authFailureTrackerKey{}).(*authFailureTracker)
+	if !ok {
+		return
+	}
+	failed := response.ErrorClass == llm.ErrorClassAuth
+	if !failed && response.Error != "" {
+		return
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	failures, seen := tracker.failures[llmConfig.ID]
+	if !seen {
+		failures = llmConfig.AuthFailures
+	}
+	if !failed {
+		tracker.failures[llmConfig.ID] = 0
+		if failures > 0 {
+			if err := s.db.SetLLMAuthFailures(ctx, llmConfig.ID, 0); err != nil {
+				logger.WarningContext(ctx, "Failed to reset the authentication failures of LLM %s: %v", llmConfig.ID, err)
+			}
+		}
+		return
+	}
+
+	failures++
+	tracker.failures[llmConfig.ID] = failures
+	if tracker.disabled[llmConfig.ID] {
+		return
+	}
+	if err := s.db.SetLLMAuthFailures(ctx, llmConfig.ID, failures); err != nil {
+		logger.WarningContext(ctx, "Failed to record the authentication failures of LLM %s: %v", llmConfig.ID, err)
+	}
+	if s.authFailureThreshold < 0 || failures < s.authFailureThreshold {
+		return
+	}
+
+	reason := fmt.Sprintf("%d consecutive authentication failures", failures)
+	if err := s.disableLLM(ctx, llmConfig.ID, failures, reason); err != nil {
+		logger.ErrorContext(ctx, "Failed to disable LLM %s after %d authentication failures: %v", llmConfig.Name, failures, err)
+		return
+	}
+	tracker.disabled[llmConfig.ID] = true
+	logger.ErrorContext(ctx, "🚨 llm_disabled: [%s] %s rejected %d consecutive calls for authentication and was disabled. Replace the API key and re-enable it with 'gego llm enable %s'. Provider error: %s",
+		llmConfig.Name, llmConfig.Provider, failures, llmConfig.ID, response.Error)
+}
+
+// disableLLM disables the LLM with the given ID, recording why
+func (s *SchedulerService) disableLLM(ctx context.Context, id string, failures int, reason string) error {
+	llmConfig, err := s.db.GetLLM(ctx, id)
+	if err != nil {
+		return err
+	}
+	llmConfig.Enabled = false
+	llmConfig.AuthFailures = failures
+	llmConfig.DisabledReason = reason
+	return s.db.UpdateLLM(ctx, llmConfig)
+}
+
+// clearAutoDisable resets the authentication failures and disable reason of an enabled LLM, so
+// that re-enabling an LLM starts counting afresh
+func clearAutoDisable(llmConfig *models.LLMConfig) {
+	if llmConfig.Enabled {
+		llmConfig.AuthFailures = 0
+		llmConfig.DisabledReason = ""
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+)
+
+// CronParser parses the cron expressions of schedules, both when they are validated and when the
+// scheduler registers them: 5 fields, an optional leading seconds field, or a descriptor such as
+// @hourly or @every 2h
+var CronParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// CronExample is a cron expression accepted by CronParser and what it means
+type CronExample struct {
+	Expr        string
+	Description string
+}
+
+// CronExpressionExamples show each form of the cron grammar
+var CronExpressionExamples = []CronExample{
+	{"*/15 * * * *", "every 15 minutes"},
+	{"0 9 * * *", "every day at 09:00"},
+	{"0 9 * * MON", "every Monday at 09:00"},
+	{"0 0 1 * *", "first day of every month"},
+	{"30 0 9 * * *", "every day at 09:00:30 (leading seconds field)"},
+	{"@daily", "every day at midnight (also @hourly, @weekly, @monthly, @yearly)"},
+	{"@every 2h", "every 2 hours from when the scheduler starts (also 30m, 1h30m)"},
+}
+
+// ParseCron parses a cron expression with CronParser
+func ParseCron(cronExpr string) (cron.Schedule, error) {
+	schedule, err := CronParser.Parse(cronExpr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", cronExpr, err)
+	}
+	return schedule, nil
+}
+
+// CronGrammarHelp describes the accepted cron expressions and phrases, one example per line
+func CronGrammarHelp() string {
+	var b strings.Builder
+	b.WriteString("Cron expressions (UTC):\n")
+	for _, example := range CronExpressionExamples {
+		fmt.Fprintf(&b, "  %-14s %s\n", example.Expr, example.Description)
+	}
+	b.WriteString("Phrases:\n")
+	for _, example := range ScheduleDescriptorExamples {
+		fmt.Fprintf(&b, "  %s\n", example)
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// DefaultPendingCreationAge is how old a prompt tagged PendingCreationTag must be before gego
+// doctor reports it, so that creations still in progress are left alone
+const DefaultPendingCreationAge = time.Hour
+
+// PendingPrompt is a prompt left behind with PendingCreationTag by an interrupted creation
+type PendingPrompt struct {
+	Prompt    *models.Prompt
+	Scheduled bool // A schedule runs the prompt, so only its tag is stale
+}
+
+// DoctorService finds inconsistencies between the SQL and NoSQL stores
+type DoctorService struct {
+	db db.Database
+}
+
+// NewDoctorService creates a new doctor service
+func NewDoctorService(database db.Database) *DoctorService {
+	return &DoctorService{db: database}
+}
+
+// PendingPrompts returns the prompts tagged PendingCreationTag more than olderThan ago
+func (s *DoctorService) PendingPrompts(ctx context.Context, olderThan time.Duration) ([]PendingPrompt, error) {
+	prompts, err := s.db.ListPrompts(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	var candidates []*models.Prompt
+	for _, prompt := range prompts {
+		if slices.Contains(prompt.Tags, PendingCreationTag) && prompt.CreatedAt.Before(cutoff) {
+			candidates = append(candidates, prompt)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	schedules, err := s.db.ListSchedules(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+	scheduled := make(map[string]bool)
+	for _, schedule := range schedules {
+		for _, id := range schedule.PromptIDs {
+			scheduled[id] = true
+		}
+	}
+
+	pending := make([]PendingPrompt, 0, len(candidates))
+	for _, prompt := range candidates {
+		pending = append(pending, PendingPrompt{Prompt: prompt, Scheduled: scheduled[prompt.ID]})
+	}
+	return pending, nil
+}
+
+// FixPendingPrompt completes the interrupted creation of a pending prompt: a prompt a schedule runs
+// loses its tag, any other is deleted
+func (s *DoctorService) FixPendingPrompt(ctx context.Context, pending PendingPrompt) error {
+	if !pending.Scheduled {
+		return s.db.DeletePrompt(ctx, pending.Prompt.ID)
+	}
+	prompt := pending.Prompt
+	prompt.Tags = slices.DeleteFunc(prompt.Tags, func(tag string) bool { return tag == PendingCreationTag })
+	return s.db.UpdatePrompt(ctx, prompt)
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestDoctorPendingPrompts(t *testing.T) {
+	old := time.Now().Add(-2 * time.Hour)
+	database := newMemoryDB()
+	database.prompts["prompt-1"] = &models.Prompt{ID: "prompt-1", Template: "Best CRM?", Tags: []string{"crm", PendingCreationTag}, CreatedAt: old}
+	database.prompts["prompt-2"] = &models.Prompt{ID: "prompt-2", Template: "Best ERP?", Tags: []string{PendingCreationTag}, CreatedAt: old}
+	database.prompts["prompt-3"] = &models.Prompt{ID: "prompt-3", Template: "Best HRIS?", Tags: []string{PendingCreationTag}, CreatedAt: time.Now()}
+	database.prompts["prompt-4"] = &models.Prompt{ID: "prompt-4", Template: "Best VPN?", CreatedAt: old}
+	database.schedules["schedule-1"] = &models.Schedule{ID: "schedule-1", Name: "Daily", PromptIDs: []string{"prompt-1"}}
+	doctor := NewDoctorService(database)
+	ctx := context.Background()
+
+	// A creation still in progress is left alone
+	pending, err := doctor.PendingPrompts(ctx, DefaultPendingCreationAge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0].Prompt.ID != "prompt-1" || !pending[0].Scheduled || pending[1].Prompt.ID != "prompt-2" || pending[1].Scheduled {
+		t.Fatalf("pending = %+v, want scheduled prompt-1 and unscheduled prompt-2", pending)
+	}
+
+	for _, prompt := range pending {
+		if err := doctor.FixPendingPrompt(ctx, prompt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if prompt := database.prompts["prompt-1"]; prompt == nil || !slices.Equal(prompt.Tags, []string{"crm"}) {
+		t.Errorf("scheduled prompt-1 = %+v, want it kept without the pending tag", prompt)
+	}
+	if _, ok := database.prompts["prompt-2"]; ok {
+		t.Error("unscheduled prompt-2 kept, want it deleted")
+	}
+
+	pending, err = doctor.PendingPrompts(ctx, DefaultPendingCreationAge)
+	if err != nil || len(pending) != 0 {
+		t.Errorf("pending after fix = %+v (%v), want none", pending, err)
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// HighErrorRate is the error rate from which a provider or model is flagged as unreliable
+const HighErrorRate = 0.2
+
+// ErrorRateCounts counts successful and failed provider calls
+type ErrorRateCounts struct {
+	Total     int     `json:"total"`
+	Success   int     `json:"success"`
+	Errors    int     `json:"errors"`
+	ErrorRate float64 `json:"error_rate"` // Errors / Total, between 0 and 1
+	// QuotaErrors counts the errors reporting an exhausted quota or credits, which need billing action
+	QuotaErrors int `json:"quota_errors"`
+}
+
+// High reports whether the error rate reaches HighErrorRate
+func (c ErrorRateCounts) High() bool {
+	return c.Total > 0 && c.ErrorRate >= HighErrorRate
+}
+
+func (c *ErrorRateCounts) add(response *models.Response) {
+	c.Total++
+	if response.Error != "" {
+		c.Errors++
+		if response.ErrorClass == llm.ErrorClassQuota {
+			c.QuotaErrors++
+		}
+	} else {
+		c.Success++
+	}
+	c.ErrorRate = float64(c.Errors) / float64(c.Total)
+}
+
+// ProviderErrorRates represents the error rate of a provider and of each of its models
+type ProviderErrorRates struct {
+	Provider string `json:"provider"`
+	ErrorRateCounts
+	Models []*ModelErrorRates `json:"models"`
+}
+
+// ModelErrorRates represents the error rate of one model of a provider
+type ModelErrorRates struct {
+	Model string `json:"model"`
+	ErrorRateCounts
+}
+
+// GetErrorRates returns the success and error counts of each provider and model for the responses
+// created between startTime and endTime (either may be nil), highest error rate first
+func (s *StatsService) GetErrorRates(ctx context.Context, startTime, endTime *time.Time) ([]*ProviderErrorRates, error) {
+	responses, err := s.db.ListResponses(ctx, shared.ResponseFilter{StartTime: startTime, EndTime: endTime, Limit: 10000})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get responses: %w", err)
+	}
+	return errorRates(responses), nil
+}
+
+// errorRates groups responses by provider and model, skipping responses reused from the response
+// cache as they made no provider call
+func errorRates(responses []*models.Response) []*ProviderErrorRates {
+	byProvider := make(map[string]*ProviderErrorRates)
+	byModel := make(map[string]*ModelErrorRates)
+	var results []*ProviderErrorRates
+
+	for _, response := range responses {
+		if fromCache, _ := response.Metadata["from_cache"].(bool); fromCache {
+			continue
+		}
+
+		provider := byProvider[response.LLMProvider]
+		if provider == nil {
+			provider = &ProviderErrorRates{Provider: response.LLMProvider}
+			byProvider[response.LLMProvider] = provider
+			results = append(results, provider)
+		}
+		provider.add(response)
+
+		modelKey := response.LLMProvider + "\x00" + response.LLMModel
+		model := byModel[modelKey]
+		if model == nil {
+			model = &ModelErrorRates{Model: response.LLMModel}
+			byModel[modelKey] = model
+			provider.Models = append(provider.Models, model)
+		}
+		model.add(response)
+	}
+
+	for _, provider := range results {
+		sort.SliceStable(provider.Models, func(i, j int) bool {
+			return provider.Models[i].ErrorRate > provider.Models[j].ErrorRate
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].ErrorRate != results[j].ErrorRate {
+			return results[i].ErrorRate > results[j].ErrorRate
+		}
+		return results[i].Provider < results[j].Provider
+	})
+
+	return results
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestGetErrorRates(t *testing.T) {
+	response := func(provider, model, errMessage, errClass string) *models.Response {
+		return &models.Response{LLMProvider: provider, LLMModel: model, Error: errMessage, ErrorClass: errClass}
+	}
+
+	database := newMemoryDB()
+	database.responses = []*models.Response{
+		response("openai", "gpt-4o", "", ""),
+		response("openai", "gpt-4o", "", ""),
+		response("openai", "gpt-4o", "", ""),
+		response("openai", "gpt-4o-mini", "HTTP 429: insufficient_quota", llm.ErrorClassQuota),
+		response("anthropic", "claude-3-haiku", "", ""),
+		response("anthropic", "claude-3-haiku", "HTTP 500", ""),
+		{LLMProvider: "google", LLMModel: "gemini", Error: "HTTP 500", Metadata: map[string]interface{}{"from_cache": true}},
+	}
+
+	rates, err := NewStatsService(database).GetErrorRates(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		provider    string
+		total       int
+		errors      int
+		quotaErrors int
+		rate        float64
+		high        bool
+	}{
+		{provider: "anthropic", total: 2, errors: 1, rate: 0.5, high: true},
+		{provider: "openai", total: 4, errors: 1, quotaErrors: 1, rate: 0.25, high: true},
+	}
+	if len(rates) != len(want) {
+		t.Fatalf("got %d providers, want %d (cached responses skipped)", len(rates), len(want))
+	}
+	for i, w := range want {
+		got := rates[i]
+		if got.Provider != w.provider || got.Total != w.total || got.Errors != w.errors ||
+			got.QuotaErrors != w.quotaErrors || got.ErrorRate != w.rate || got.High() != w.high {
+			t.Errorf("provider %d = %s total %d errors %d quota %d rate %v high %t, want %+v",
+				i, got.Provider, got.Total, got.Errors, got.QuotaErrors, got.ErrorRate, got.High(), w)
+		}
+	}
+
+	openaiModels := rates[1].Models
+	if len(openaiModels) != 2 || openaiModels[0].Model != "gpt-4o-mini" || openaiModels[0].ErrorRate != 1 ||
+		openaiModels[1].Model != "gpt-4o" || openaiModels[1].ErrorRate != 0 {
+		t.Errorf("openai models = %+v, want gpt-4o-mini (rate 1) then gpt-4o (rate 0)", openaiModels)
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -9,14 +10,22 @@ import (
 
 	"github.com/AI2HU/gego/internal/db"
 	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/logger"
 	"github.com/AI2HU/gego/internal/models"
 	"github.com/AI2HU/gego/internal/shared"
 )
 
 // ExecutionService provides business logic for prompt execution
 type ExecutionService struct {
-	db          db.Database
-	llmRegistry *llm.Registry
+	db           db.Database
+	llmRegistry  *llm.Registry
+	rateLimiters *RateLimiters // Optional per-provider pacing
+	// Remove reasoning sections from stored response bodies
+	stripReasoning bool
+	// Ordered transforms applied to response bodies before storage
+	postProcessors *PostProcessorPipeline
+	// Store the raw provider requests and responses in response metadata
+	captureRaw bool
 }
 
 // NewExecutionService creates a new execution service
@@ -27,11 +36,32 @@ func NewExecutionService(database db.Database, registry *llm.Registry) *Executio
 	}
 }
 
+// SetRateLimiters paces provider calls with the given per-provider rate limiters
+func (s *ExecutionService) SetRateLimiters(rateLimiters *RateLimiters) {
+	s.rateLimiters = rateLimiters
+}
+
+// SetStripReasoning removes delimited reasoning sections from stored response bodies, keeping the raw body in metadata
+func (s *ExecutionService) SetStripReasoning(enabled bool) {
+	s.stripReasoning = enabled
+}
+
+// SetPostProcessors applies pipeline to stored response bodies, keeping the raw body in metadata
+func (s *ExecutionService) SetPostProcessors(pipeline *PostProcessorPipeline) {
+	s.postProcessors = pipeline
+}
+
+// SetCaptureRaw stores the raw provider requests and responses, secrets redacted, in response metadata
+func (s *ExecutionService) SetCaptureRaw(enabled bool) {
+	s.captureRaw = enabled
+}
+
 // ExecutionConfig represents configuration for prompt execution
 type ExecutionConfig struct {
 	Temperature float64       `json:"temperature"`
 	MaxRetries  int           `json:"max_retries"`
 	RetryDelay  time.Duration `json:"retry_delay"`
+	Ephemeral   bool          `json:"ephemeral"` // Return the response without storing it, as for ad-hoc prompts read from stdin
 }
 
 // DefaultExecutionConfig returns default execution configuration
@@ -54,9 +84,20 @@ func (s *ExecutionService) ExecutePromptWithLLM(ctx context.Context, prompt *mod
 		return nil, fmt.Errorf("LLM provider %s not found", llmConfig.Provider)
 	}
 
+	promptText := RenderPrompt(prompt.Template, llmConfig)
 	var lastErr error
+	var queueWait time.Duration
 	for attempt := 1; attempt <= config.MaxRetries; attempt++ {
-		response, err := provider.Generate(ctx, prompt.Template, llm.Config{
+		if s.rateLimiters != nil {
+			queueStart := time.Now()
+			if err := s.rateLimiters.Wait(ctx, llmConfig.Provider); err != nil {
+				return nil, fmt.Errorf("rate limiter wait failed: %w", err)
+			}
+			queueWait += time.Since(queueStart)
+		}
+
+		captureCtx, capture := startRawCapture(ctx, s.captureRaw, llmConfig)
+		response, err := provider.Generate(captureCtx, promptText, llm.Config{
 			Model:       llmConfig.Model,
 			Temperature: config.Temperature,
 			MaxTokens:   1000,
@@ -64,8 +105,13 @@ func (s *ExecutionService) ExecutePromptWithLLM(ctx context.Context, prompt *mod
 
 		if err != nil {
 			lastErr = fmt.Errorf("failed to generate response: %w", err)
+			if llm.ClassifyError(err.Error()) == llm.ErrorClassQuota {
+				return nil, fmt.Errorf("not retrying, provider quota exhausted: %w", lastErr)
+			}
 			if attempt < config.MaxRetries {
-				time.Sleep(config.RetryDelay)
+				if err := sleepContext(ctx, config.RetryDelay); err != nil {
+					return nil, err
+				}
 				continue
 			}
 			return nil, lastErr
@@ -73,8 +119,13 @@ func (s *ExecutionService) ExecutePromptWithLLM(ctx context.Context, prompt *mod
 
 		if response.Error != "" {
 			lastErr = fmt.Errorf("LLM error: %s", response.Error)
+			if llm.ClassifyError(response.Error) == llm.ErrorClassQuota {
+				return nil, fmt.Errorf("not retrying, provider quota exhausted: %w", lastErr)
+			}
 			if attempt < config.MaxRetries {
-				time.Sleep(config.RetryDelay)
+				if err := sleepContext(ctx, config.RetryDelay); err != nil {
+					return nil, err
+				}
 				continue
 			}
 			return nil, lastErr
@@ -84,16 +135,28 @@ func (s *ExecutionService) ExecutePromptWithLLM(ctx context.Context, prompt *mod
 			ID:           uuid.New().String(),
 			PromptID:     prompt.ID,
 			LLMID:        llmConfig.ID,
-			PromptText:   prompt.Template,
+			PromptText:   promptText,
 			ResponseText: response.Text,
 			LLMName:      llmConfig.Name,
 			LLMProvider:  llmConfig.Provider,
 			LLMModel:     llmConfig.Model,
 			Temperature:  config.Temperature,
+			Metadata:     promptWrapMetadata(rawCaptureMetadata(response.Metadata, capture), llmConfig),
+			Owner:        responseOwner(ctx, prompt, llmConfig),
+			RunID:        logger.RunIDFromContext(ctx),
 			TokensUsed:   response.TokensUsed,
 			LatencyMs:    response.LatencyMs,
+			QueueWaitMs:  queueWait.Milliseconds(),
+			Grounded:     response.Grounded,
 			CreatedAt:    time.Now(),
 		}
+		if s.stripReasoning {
+			stripResponseReasoning(responseModel)
+		}
+		postProcessResponse(responseModel, s.postProcessors)
+		if config.Ephemeral {
+			return responseModel, nil
+		}
 
 		if err := s.db.CreateResponse(ctx, responseModel); err != nil {
 			return nil, fmt.Errorf("failed to save response: %w", err)
@@ -105,8 +168,35 @@ func (s *ExecutionService) ExecutePromptWithLLM(ctx context.Context, prompt *mod
 	return nil, fmt.Errorf("all %d attempts failed. Last error: %w", config.MaxRetries, lastErr)
 }
 
+// GenerateStructured asks an LLM for a JSON answer matching schema, for analysis jobs such as
+// classifying stored responses. The answer is validated against the schema and returned without
+// being stored.
+func (s *ExecutionService) GenerateStructured(ctx context.Context, llmConfig *models.LLMConfig, prompt string, schema json.RawMessage, temperature float64) (*llm.Response, error) {
+	provider, ok := s.llmRegistry.Get(llmConfig.Provider)
+	if !ok {
+		return nil, fmt.Errorf("LLM provider %s not found", llmConfig.Provider)
+	}
+
+	if s.rateLimiters != nil {
+		if err := s.rateLimiters.Wait(ctx, llmConfig.Provider); err != nil {
+			return nil, fmt.Errorf("rate limiter wait failed: %w", err)
+		}
+	}
+
+	response, err := llm.GenerateStructured(ctx, provider, prompt, schema, llm.Config{
+		Model:       llmConfig.Model,
+		Temperature: temperature,
+		MaxTokens:   1000,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate structured response: %w", err)
+	}
+	return response, nil
+}
+
 // ExecuteSchedule executes all prompts in a schedule with all LLMs
 func (s *ExecutionService) ExecuteSchedule(ctx context.Context, scheduleID string, config *ExecutionConfig) (*ExecutionResult, error) {
+	ctx = logger.WithRunID(ctx, uuid.New().String())
 	scheduleService := NewScheduleService(s.db)
 	plan, err := scheduleService.GetScheduleExecutionPlan(ctx, scheduleID)
 	if err != nil {
@@ -268,3 +358,16 @@ func (s *ExecutionService) DeleteResponse(ctx context.Context, id string) error
 func (s *ExecutionService) DeleteAllResponses(ctx context.Context) (int, error) {
 	return s.db.DeleteAllResponses(ctx)
 }
+
+// sleepContext waits for d, returning early with the context error if ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// newTestExecution returns an execution service over database calling provider
+func newTestExecution(database *memoryDB, provider llm.Provider) *ExecutionService {
+	registry := llm.NewRegistry()
+	registry.Register(provider)
+	return NewExecutionService(database, registry)
+}
+
+// hangingProvider signals started on its first call, then fails with err, or with the context
+// error once the context is cancelled when err is nil
+type hangingProvider struct {
+	*recordingProvider
+	started chan struct{}
+	err     error
+}
+
+func (p *hangingProvider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
+	select {
+	case <-p.started:
+	default:
+		close(p.started)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestExecutePromptWithLLMCancelled(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "during the provider call"},
+		{name: "during the retry delay", err: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &hangingProvider{recordingProvider: &recordingProvider{name: "openai"}, started: make(chan struct{}), err: tt.err}
+			service := newTestExecution(newMemoryDB(), provider)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			done := make(chan error, 1)
+			go func() {
+				prompt := &models.Prompt{ID: "prompt-1", Template: "What is the best tool?"}
+				llmConfig := &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o"}
+				_, err := service.ExecutePromptWithLLM(ctx, prompt, llmConfig, &ExecutionConfig{Temperature: 0.7, MaxRetries: 3, RetryDelay: time.Hour})
+				done <- err
+			}()
+
+			<-provider.started
+			cancel()
+			select {
+			case err := <-done:
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("error = %v, want context.Canceled", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("execution did not stop after cancellation")
+			}
+		})
+	}
+}
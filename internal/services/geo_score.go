@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// DefaultGEOScoreMinResponses is the number of responses a period needs before it is scored
+const DefaultGEOScoreMinResponses = 20
+
+// GEOScoreConfig configures how GEO scores are computed
+type GEOScoreConfig struct {
+	Weights      models.GEOScoreWeights
+	MinResponses int      // Periods with fewer responses report insufficient data
+	Group        []string // Default keywords share of voice is measured against
+}
+
+// DefaultGEOScoreConfig returns the default GEO score configuration
+func DefaultGEOScoreConfig() GEOScoreConfig {
+	return GEOScoreConfig{
+		Weights: models.GEOScoreWeights{
+			MentionRate:  0.4,
+			Position:     0.2,
+			ShareOfVoice: 0.2,
+			Coverage:     0.2,
+		},
+		MinResponses: DefaultGEOScoreMinResponses,
+	}
+}
+
+// SetGEOScoreConfig sets the weights, minimum responses and default keyword group of GEO scores
+func (s *StatsService) SetGEOScoreConfig(config GEOScoreConfig) {
+	s.geoScore = config
+}
+
+// GetGEOScore computes the GEO score of a keyword over the responses between start and end. Share of voice is measured
+// against group, or the configured group when empty.
+func (s *StatsService) GetGEOScore(ctx context.Context, keyword string, group []string, start, end time.Time) (*models.GEOScore, error) {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return nil, fmt.Errorf("keyword is required")
+	}
+	if len(group) == 0 {
+		group = s.geoScore.Group
+	}
+
+	responses, err := s.db.ListResponses(ctx, shared.ResponseFilter{
+		StartTime:     &start,
+		EndTime:       &end,
+		ExcludeLabels: shared.ExcludedLabelsFromContext(ctx),
+		HasError:      shared.ErrorFilterFromContext(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list responses: %w", err)
+	}
+
+	score := &models.GEOScore{
+		Keyword:      keyword,
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		Responses:    len(responses),
+		MinResponses: s.geoScore.MinResponses,
+	}
+	if len(responses) == 0 || len(responses) < s.geoScore.MinResponses {
+		score.InsufficientData = true
+		return score, nil
+	}
+
+	enabled := true
+	llms, err := s.db.ListLLMs(ctx, &enabled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LLMs: %w", err)
+	}
+
+	components := &models.GEOScoreComponents{
+		Group:       otherKeywords(group, keyword),
+		EnabledLLMs: len(llms),
+		Weights:     s.geoScore.Weights,
+	}
+
+	mentioning := make(map[string]bool)
+	var groundedMentions int
+	var keywordOccurrences, groupOccurrences int
+	var positionSum, reciprocalSum float64
+	for _, response := range responses {
+		if response.Grounded {
+			components.GroundedResponses++
+		}
+		occurrences := shared.CountKeyword(response.ResponseText, keyword)
+		keywordOccurrences += occurrences
+		for _, other := range components.Group {
+			groupOccurrences += shared.CountKeyword(response.ResponseText, other)
+		}
+		if occurrences == 0 {
+			continue
+		}
+
+		components.Mentions++
+		if response.Grounded {
+			groundedMentions++
+		}
+		mentioning[response.LLMID] = true
+		if position := ListPosition(response.ResponseText, keyword); position > 0 {
+			components.ListPlacements++
+			positionSum += float64(position)
+			reciprocalSum += 1 / float64(position)
+		}
+	}
+
+	components.MentionRate = float64(components.Mentions) / float64(len(responses))
+	if ungrounded := len(responses) - components.GroundedResponses; components.GroundedResponses > 0 && ungrounded > 0 {
+		groundedRate := float64(groundedMentions) / float64(components.GroundedResponses)
+		ungroundedRate := float64(components.Mentions-groundedMentions) / float64(ungrounded)
+		components.GroundedMentionRate = &groundedRate
+		components.UngroundedMentionRate = &ungroundedRate
+	}
+
+	if components.ListPlacements > 0 {
+		avgPosition := positionSum / float64(components.ListPlacements)
+		positionScore := reciprocalSum / float64(components.ListPlacements)
+		components.AvgPosition = &avgPosition
+		components.PositionScore = &positionScore
+	}
+
+	if len(components.Group) > 0 {
+		components.GroupMentions = keywordOccurrences + groupOccurrences
+		if components.GroupMentions > 0 {
+			shareOfVoice := float64(keywordOccurrences) / float64(components.GroupMentions)
+			components.ShareOfVoice = &shareOfVoice
+		}
+	}
+
+	if len(llms) > 0 {
+		for _, llmConfig := range llms {
+			if mentioning[llmConfig.ID] {
+				components.MentioningLLMs++
+			}
+		}
+		coverage := float64(components.MentioningLLMs) / float64(len(llms))
+		components.Coverage = &coverage
+	}
+
+	composite := compositeGEOScore(components, s.geoScore.Weights)
+	score.Score = &composite
+	score.Components = components
+	return score, nil
+}
+
+// ListPosition returns the 1-based position of the first numbered or bulleted list item
+// mentioning keyword in text, or 0 if no list item mentions it
+func ListPosition(text, keyword string) int {
+	position := 0
+	for _, line := range strings.Split(text, "\n") {
+		line = markdownEmphasisPattern.ReplaceAllString(line, "$2")
+		if !enumeratorPattern.MatchString(line) {
+			continue
+		}
+
+		position++
+		if shared.CountKeyword(line, keyword) > 0 {
+			return position
+		}
+	}
+	return 0
+}
+
+// compositeGEOScore weighs the measured components into a 0-100 score, renormalizing
+// the weights over the components that could be measured
+func compositeGEOScore(components *models.GEOScoreComponents, weights models.GEOScoreWeights) float64 {
+	total := weights.MentionRate * components.MentionRate
+	weightSum := weights.MentionRate
+
+	for _, part := range []struct {
+		value  *float64
+		weight float64
+	}{
+		{components.PositionScore, weights.Position},
+		{components.ShareOfVoice, weights.ShareOfVoice},
+		{components.Coverage, weights.Coverage},
+	} {
+		if part.value == nil {
+			continue
+		}
+		total += part.weight * *part.value
+		weightSum += part.weight
+	}
+
+	if weightSum == 0 {
+		return 0
+	}
+	return total / weightSum * 100
+}
+
+// otherKeywords returns the trimmed, de-duplicated keywords of group other than keyword
+func otherKeywords(group []string, keyword string) []string {
+	seen := map[string]bool{strings.ToLower(keyword): true}
+	var others []string
+	for _, other := range group {
+		other = strings.TrimSpace(other)
+		if other == "" || seen[strings.ToLower(other)] {
+			continue
+		}
+		seen[strings.ToLower(other)] = true
+		others = append(others, other)
+	}
+	return others
+}
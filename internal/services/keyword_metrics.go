@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// KeywordMetrics serves keyword gauges in the Prometheus text format. Each refresh replaces every
+// series, so the series of keywords no longer registered are dropped.
+type KeywordMetrics struct {
+	db db.Database
+
+	mu          sync.RWMutex
+	mentions    map[keywordModelSeries]int
+	rates       map[keywordProviderSeries]float64
+	refreshedAt time.Time
+}
+
+// keywordModelSeries labels a gego_keyword_mentions_total series
+type keywordModelSeries struct {
+	keyword, provider, model string
+}
+
+// keywordProviderSeries labels a gego_keyword_mention_rate series
+type keywordProviderSeries struct {
+	keyword, provider string
+}
+
+// NewKeywordMetrics creates keyword gauges with no series until the first refresh
+func NewKeywordMetrics(database db.Database) *KeywordMetrics {
+	return &KeywordMetrics{db: database}
+}
+
+// Refresh recomputes the gauges of keywords over the responses of the last window
+func (m *KeywordMetrics) Refresh(ctx context.Context, keywords []string, window time.Duration) error {
+	start := time.Now().Add(-window)
+	responses, err := m.db.ListResponses(ctx, shared.ResponseFilter{StartTime: &start})
+	if err != nil {
+		return fmt.Errorf("failed to list responses: %w", err)
+	}
+
+	mentions := make(map[keywordModelSeries]int)
+	mentioning := make(map[keywordProviderSeries]int)
+	byProvider := make(map[string]int)
+	for _, response := range responses {
+		if response.Error != "" {
+			continue
+		}
+		byProvider[response.LLMProvider]++
+		for _, keyword := range keywords {
+			count := shared.CountKeyword(response.ResponseText, keyword)
+			if count == 0 {
+				continue
+			}
+			mentions[keywordModelSeries{keyword, response.LLMProvider, response.LLMModel}] += count
+			mentioning[keywordProviderSeries{keyword, response.LLMProvider}]++
+		}
+	}
+
+	rates := make(map[keywordProviderSeries]float64)
+	for _, keyword := range keywords {
+		for provider, total := range byProvider {
+			series := keywordProviderSeries{keyword, provider}
+			rates[series] = float64(mentioning[series]) / float64(total)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mentions = mentions
+	m.rates = rates
+	m.refreshedAt = time.Now()
+	return nil
+}
+
+// Run refreshes the gauges every interval until ctx is done, reading the registered keywords
+// again before each refresh so that removed keywords stop being exported
+func (m *KeywordMetrics) Run(ctx context.Context, interval, window time.Duration, keywords func() []string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Refresh(ctx, keywords(), window); err != nil {
+			logger.Warning("Failed to refresh keyword metrics: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// WriteTo writes the gauges in the Prometheus text exposition format
+func (m *KeywordMetrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var b strings.Builder
+	b.WriteString("# HELP gego_keyword_mentions_total Mentions of a registered keyword in the responses of the metrics window.\n")
+	b.WriteString("# TYPE gego_keyword_mentions_total gauge\n")
+	mentionSeries := make([]keywordModelSeries, 0, len(m.mentions))
+	for series := range m.mentions {
+		mentionSeries = append(mentionSeries, series)
+	}
+	sort.Slice(mentionSeries, func(i, j int) bool {
+		a, c := mentionSeries[i], mentionSeries[j]
+		if a.keyword != c.keyword {
+			return a.keyword < c.keyword
+		}
+		if a.provider != c.provider {
+			return a.provider < c.provider
+		}
+		return a.model < c.model
+	})
+	for _, series := range mentionSeries {
+		fmt.Fprintf(&b, "gego_keyword_mentions_total{keyword=\"%s\",provider=\"%s\",model=\"%s\"} %d\n",
+			escapeLabel(series.keyword), escapeLabel(series.provider), escapeLabel(series.model), m.mentions[series])
+	}
+
+	b.WriteString("# HELP gego_keyword_mention_rate Fraction of the responses of a provider mentioning a registered keyword in the metrics window.\n")
+	b.WriteString("# TYPE gego_keyword_mention_rate gauge\n")
+	rateSeries := make([]keywordProviderSeries, 0, len(m.rates))
+	for series := range m.rates {
+		rateSeries = append(rateSeries, series)
+	}
+	sort.Slice(rateSeries, func(i, j int) bool {
+		if rateSeries[i].keyword != rateSeries[j].keyword {
+			return rateSeries[i].keyword < rateSeries[j].keyword
+		}
+		return rateSeries[i].provider < rateSeries[j].provider
+	})
+	for _, series := range rateSeries {
+		fmt.Fprintf(&b, "gego_keyword_mention_rate{keyword=\"%s\",provider=\"%s\"} %g\n",
+			escapeLabel(series.keyword), escapeLabel(series.provider), m.rates[series])
+	}
+
+	if !m.refreshedAt.IsZero() {
+		b.WriteString("# HELP gego_keyword_metrics_refreshed_timestamp_seconds Time of the last refresh of the keyword gauges.\n")
+		b.WriteString("# TYPE gego_keyword_metrics_refreshed_timestamp_seconds gauge\n")
+		fmt.Fprintf(&b, "gego_keyword_metrics_refreshed_timestamp_seconds %d\n", m.refreshedAt.Unix())
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the gauges to a Prometheus scrape
+func (m *KeywordMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if _, err := m.WriteTo(w); err != nil {
+		logger.Warning("Failed to write keyword metrics: %v", err)
+	}
+}
+
+// labelEscaper escapes Prometheus label values
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel escapes a Prometheus label value
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
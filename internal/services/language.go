@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// Metadata keys recording the language check of a response
+const (
+	metadataLanguage         = "language"
+	metadataExpectedLanguage = "expected_language"
+	metadataLanguageMismatch = "language_mismatch"
+)
+
+// languageTagPrefix starts the prompt tags declaring the language of a prompt, as in lang-FR
+const languageTagPrefix = "lang-"
+
+// languageMinLetters is the number of letters below which the language of a text is not detected
+const languageMinLetters = 40
+
+// languageMinStopwords is the number of stopwords a Latin-script text needs for its language to be detected
+const languageMinStopwords = 4
+
+// languageStopwords holds frequent words of the Latin-script languages the detector tells apart
+var languageStopwords = map[string][]string{
+	"EN": {"the", "and", "is", "are", "of", "to", "in", "that", "it", "for", "with", "this", "you", "be", "on", "not", "or", "which", "have", "from"},
+	"FR": {"le", "la", "les", "et", "est", "des", "une", "un", "du", "que", "qui", "pour", "dans", "pas", "sont", "avec", "ce", "sur", "au", "vous"},
+	"ES": {"el", "los", "las", "y", "es", "que", "del", "una", "por", "con", "para", "son", "como", "pero", "más", "su", "se", "al", "lo", "muy"},
+	"IT": {"il", "che", "è", "della", "per", "una", "sono", "con", "non", "del", "gli", "di", "più", "anche", "come", "questo", "alla", "nel", "ma", "essere"},
+	"DE": {"der", "die", "und", "das", "ist", "nicht", "ein", "eine", "mit", "zu", "den", "von", "sich", "auch", "für", "auf", "dem", "sind", "es", "werden"},
+	"PT": {"os", "as", "é", "não", "uma", "um", "do", "da", "que", "para", "com", "são", "mais", "em", "por", "dos", "das", "também", "você", "ou"},
+	"NL": {"de", "het", "een", "en", "is", "van", "niet", "dat", "zijn", "met", "voor", "op", "ook", "te", "maar", "je", "dit", "wordt", "naar", "bij"},
+	"SV": {"och", "är", "att", "det", "som", "en", "på", "för", "med", "inte", "av", "till", "den", "har", "om", "ett", "kan", "eller", "du", "vara"},
+	"DA": {"og", "er", "at", "det", "som", "en", "på", "for", "med", "ikke", "af", "til", "den", "har", "om", "et", "kan", "eller", "du", "være"},
+	"NO": {"og", "er", "at", "det", "som", "en", "på", "for", "med", "ikke", "av", "til", "den", "har", "om", "et", "kan", "eller", "du", "være"},
+	"PL": {"i", "w", "nie", "się", "na", "jest", "to", "że", "do", "z", "jak", "co", "ale", "są", "dla", "oraz", "być", "przez", "od", "jego"},
+}
+
+// languageStopwordSets holds languageStopwords as sets
+var languageStopwordSets = func() map[string]map[string]bool {
+	sets := make(map[string]map[string]bool, len(languageStopwords))
+	for code, stopwords := range languageStopwords {
+		sets[code] = make(map[string]bool, len(stopwords))
+		for _, word := range stopwords {
+			sets[code][word] = true
+		}
+	}
+	return sets
+}()
+
+// Scripts the detector tells apart; Japanese is kanji mixed with kana
+const (
+	scriptLatin      = "latin"
+	scriptCyrillic   = "cyrillic"
+	scriptGreek      = "greek"
+	scriptArabic     = "arabic"
+	scriptHebrew     = "hebrew"
+	scriptDevanagari = "devanagari"
+	scriptThai       = "thai"
+	scriptHangul     = "hangul"
+	scriptHan        = "han"
+	scriptJapanese   = "japanese"
+)
+
+// scriptTables maps the scripts recognized letter by letter to their Unicode table
+var scriptTables = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{scriptLatin, unicode.Latin},
+	{scriptCyrillic, unicode.Cyrillic},
+	{scriptGreek, unicode.Greek},
+	{scriptArabic, unicode.Arabic},
+	{scriptHebrew, unicode.Hebrew},
+	{scriptDevanagari, unicode.Devanagari},
+	{scriptThai, unicode.Thai},
+	{scriptHangul, unicode.Hangul},
+	{scriptHan, unicode.Han},
+}
+
+// languageScripts maps the languages written in a script of their own to it
+var languageScripts = map[string]string{
+	"RU": scriptCyrillic,
+	"BG": scriptCyrillic,
+	"EL": scriptGreek,
+	"AR": scriptArabic,
+	"HE": scriptHebrew,
+	"HI": scriptDevanagari,
+	"TH": scriptThai,
+	"KO": scriptHangul,
+	"ZH": scriptHan,
+	"JA": scriptJapanese,
+}
+
+// scriptLanguages maps the scripts other than Latin to the language detected for them
+var scriptLanguages = map[string]string{
+	scriptCyrillic:   "RU",
+	scriptGreek:      "EL",
+	scriptArabic:     "AR",
+	scriptHebrew:     "HE",
+	scriptDevanagari: "HI",
+	scriptThai:       "TH",
+	scriptHangul:     "KO",
+	scriptHan:        "ZH",
+	scriptJapanese:   "JA",
+}
+
+// PromptLanguage returns the upper-case language code of the first lang-XX tag of prompt, or ""
+func PromptLanguage(prompt *models.Prompt) string {
+	for _, tag := range prompt.Tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if code, ok := strings.CutPrefix(tag, languageTagPrefix); ok && code != "" {
+			code, _, _ = strings.Cut(code, "-")
+			return strings.ToUpper(code)
+		}
+	}
+	return ""
+}
+
+// DetectLanguage returns the language code of text, or "" when it is too short or ambiguous.
+// Languages with a script of their own are told apart by script, Latin-script languages by their
+// most frequent words.
+func DetectLanguage(text string) string {
+	script, letters := dominantScript(text)
+	if letters < languageMinLetters {
+		return ""
+	}
+	if script != scriptLatin {
+		return scriptLanguages[script]
+	}
+
+	best, bestScore := "", 0
+	for code, score := range stopwordScores(text) {
+		if score > bestScore || (score == bestScore && code < best) {
+			best, bestScore = code, score
+		}
+	}
+	if bestScore < languageMinStopwords {
+		return ""
+	}
+	return best
+}
+
+// LanguageMismatch reports whether text is clearly not in the language with code expected. Close
+// languages get the benefit of the doubt: a Latin-script text only mismatches when the expected
+// language scores less than half of the detected one. Texts whose language cannot be told are
+// never flagged.
+func LanguageMismatch(text, expected string) (detected string, mismatch bool) {
+	expected = strings.ToUpper(expected)
+	detected = DetectLanguage(text)
+	if detected == "" || detected == expected {
+		return detected, false
+	}
+
+	script, _ := dominantScript(text)
+	if expectedScript, ok := languageScripts[expected]; ok {
+		return detected, script != expectedScript
+	}
+	if script != scriptLatin {
+		return detected, true
+	}
+	if _, ok := languageStopwords[expected]; !ok {
+		// A Latin-script language the detector has no stopwords for cannot be told from others
+		return detected, false
+	}
+
+	scores := stopwordScores(text)
+	return detected, scores[expected]*2 < scores[detected]
+}
+
+// dominantScript returns the script most letters of text are written in and the number of letters
+func dominantScript(text string) (string, int) {
+	counts := make(map[string]int)
+	kana, letters := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana) {
+			kana++
+			counts[scriptHan]++
+			continue
+		}
+		for _, script := range scriptTables {
+			if unicode.Is(script.table, r) {
+				counts[script.name]++
+				break
+			}
+		}
+	}
+
+	dominant := ""
+	for script, count := range counts {
+		if count > counts[dominant] || (count == counts[dominant] && script < dominant) {
+			dominant = script
+		}
+	}
+	// Japanese mixes kanji with kana, Chinese has none
+	if dominant == scriptHan && kana*10 >= counts[scriptHan] {
+		dominant = scriptJapanese
+	}
+	return dominant, letters
+}
+
+// stopwordScores counts the words of text that are stopwords of each Latin-script language
+func stopwordScores(text string) map[string]int {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	scores := make(map[string]int, len(languageStopwordSets))
+	for code, set := range languageStopwordSets {
+		for _, word := range words {
+			if set[word] {
+				scores[code]++
+			}
+		}
+	}
+	return scores
+}
+
+// checkResponseLanguage records in the metadata of response the language it was detected in and,
+// when it is clearly not the language of the lang-XX tag of prompt, flags it as a mismatch
+func checkResponseLanguage(ctx context.Context, prompt *models.Prompt, response *models.Response) {
+	expected := PromptLanguage(prompt)
+	if expected == "" || response.Error != "" || response.ResponseText == "" {
+		return
+	}
+
+	detected, mismatch := LanguageMismatch(response.ResponseText, expected)
+	if response.Metadata == nil {
+		response.Metadata = make(map[string]interface{})
+	}
+	response.Metadata[metadataExpectedLanguage] = expected
+	if detected != "" {
+		response.Metadata[metadataLanguage] = detected
+	}
+	if mismatch {
+		response.Metadata[metadataLanguageMismatch] = true
+		logger.WarningContext(ctx, "🌐 [%s] Response to prompt %s detected as %s, expected %s from its lang-%s tag",
+			response.LLMName, prompt.ID, detected, expected, strings.ToLower(expected))
+	}
+}
+
+// languageCheckKey marks the context of schedule runs checking the language of responses
+type languageCheckKey struct{}
+
+// withLanguageCheck returns a context whose executions check the language of their responses
+func withLanguageCheck(ctx context.Context) context.Context {
+	return context.WithValue(ctx, languageCheckKey{}, true)
+}
+
+// languageCheckEnabled reports whether the executions of ctx check the language of their responses
+func languageCheckEnabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(languageCheckKey{}).(bool)
+	return enabled
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+const (
+	englishAnswer = "The best CRM for a small business is HubSpot, which is free to start and easy to use. Salesforce is more powerful, but it is also more expensive and it takes time to set up."
+	frenchAnswer  = "Le meilleur CRM pour une petite entreprise est HubSpot, qui est gratuit pour commencer et facile à prendre en main. Salesforce est plus complet, mais il est aussi plus cher et long à configurer."
+)
+
+func TestPromptLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want string
+	}{
+		{name: "language tag", tags: []string{"crm", "lang-fr"}, want: "FR"},
+		{name: "region ignored", tags: []string{"Lang-FR-ca"}, want: "FR"},
+		{name: "first tag wins", tags: []string{"lang-de", "lang-fr"}, want: "DE"},
+		{name: "no language tag", tags: []string{"crm", "lang-"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PromptLanguage(&models.Prompt{Tags: tt.tags}); got != tt.want {
+				t.Errorf("PromptLanguage(%v) = %q, want %q", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLanguageMismatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		expected     string
+		wantDetected string
+		wantMismatch bool
+	}{
+		{name: "English answer to a French prompt", text: englishAnswer, expected: "FR", wantDetected: "EN", wantMismatch: true},
+		{name: "French answer to a French prompt", text: frenchAnswer, expected: "fr", wantDetected: "FR"},
+		{name: "French answer to an English prompt", text: frenchAnswer, expected: "EN", wantDetected: "FR", wantMismatch: true},
+		{name: "Russian answer to a French prompt", text: "Лучшая CRM для малого бизнеса — это HubSpot, она бесплатна и проста в использовании.", expected: "FR", wantDetected: "RU", wantMismatch: true},
+		{name: "Latin answer to a Japanese prompt", text: englishAnswer, expected: "JA", wantDetected: "EN", wantMismatch: true},
+		{name: "too short to tell", text: "HubSpot, Salesforce.", expected: "FR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detected, mismatch := LanguageMismatch(tt.text, tt.expected)
+			if detected != tt.wantDetected || mismatch != tt.wantMismatch {
+				t.Errorf("LanguageMismatch() = %q, %t, want %q, %t", detected, mismatch, tt.wantDetected, tt.wantMismatch)
+			}
+		})
+	}
+}
+
+func TestScheduleFlagsLanguageMismatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		checkLanguage bool
+		answer        string
+		wantMetadata  map[string]interface{}
+	}{
+		{
+			name: "English answer flagged", checkLanguage: true, answer: englishAnswer,
+			wantMetadata: map[string]interface{}{metadataExpectedLanguage: "FR", metadataLanguage: "EN", metadataLanguageMismatch: true},
+		},
+		{
+			name: "French answer passes", checkLanguage: true, answer: frenchAnswer,
+			wantMetadata: map[string]interface{}{metadataExpectedLanguage: "FR", metadataLanguage: "FR"},
+		},
+		{name: "check off", answer: englishAnswer, wantMetadata: map[string]interface{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := newMemoryDB()
+			scheduler := newTestScheduler(database, &recordingProvider{name: "openai", text: tt.answer})
+			ctx := context.Background()
+			if tt.checkLanguage {
+				ctx = withLanguageCheck(ctx)
+			}
+
+			prompt := &models.Prompt{ID: "prompt-1", Template: "Quel est le meilleur CRM ?", Tags: []string{"crm", "lang-fr"}}
+			llmConfig := &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o"}
+			if err := scheduler.executePromptWithLLM(ctx, "schedule-1", prompt, llmConfig, 0.7, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			responses := database.allResponses()
+			if len(responses) != 1 {
+				t.Fatalf("stored %d responses, want 1", len(responses))
+			}
+			metadata := responses[0].Metadata
+			for _, key := range []string{metadataExpectedLanguage, metadataLanguage, metadataLanguageMismatch} {
+				if metadata[key] != tt.wantMetadata[key] {
+					t.Errorf("metadata[%s] = %v, want %v", key, metadata[key], tt.wantMetadata[key])
+				}
+			}
+		})
+	}
+}
@@ -3,14 +3,29 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/llm/deepseek"
+	"github.com/AI2HU/gego/internal/llm/ollama"
+	"github.com/AI2HU/gego/internal/llm/xai"
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
 )
 
 // LLMService provides business logic for LLM management
 type LLMService struct {
-	db db.Database
+	db        db.Database
+	listeners []LLMListener
+}
+
+// LLMListener is notified after an LLM is updated, enabled, disabled or deleted, so that in-process state built from
+// its configuration, such as provider clients and rate limiters, can be dropped
+type LLMListener interface {
+	InvalidateLLM(ctx context.Context, llm *models.LLMConfig)
 }
 
 // NewLLMService creates a new LLM service
@@ -18,6 +33,18 @@ func NewLLMService(database db.Database) *LLMService {
 	return &LLMService{db: database}
 }
 
+// Subscribe notifies listener of the LLM changes made through this service
+func (s *LLMService) Subscribe(listener LLMListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+// invalidate notifies the listeners that llm changed
+func (s *LLMService) invalidate(ctx context.Context, llm *models.LLMConfig) {
+	for _, listener := range s.listeners {
+		listener.InvalidateLLM(ctx, llm)
+	}
+}
+
 // Provider represents available LLM providers
 type Provider int
 
@@ -27,6 +54,9 @@ const (
 	Ollama
 	Google
 	Perplexity
+	DeepSeek
+	XAI
+	Bedrock
 )
 
 // String returns the string representation of the provider
@@ -42,6 +72,12 @@ func (p Provider) String() string {
 		return "google"
 	case Perplexity:
 		return "perplexity"
+	case DeepSeek:
+		return "deepseek"
+	case XAI:
+		return "xai"
+	case Bedrock:
+		return "bedrock"
 	default:
 		return "unknown"
 	}
@@ -60,6 +96,12 @@ func FromString(s string) Provider {
 		return Google
 	case "perplexity":
 		return Perplexity
+	case "deepseek":
+		return DeepSeek
+	case "xai":
+		return XAI
+	case "bedrock":
+		return Bedrock
 	default:
 		return 0 // Unknown provider
 	}
@@ -78,6 +120,12 @@ func (p Provider) DisplayName() string {
 		return "Google (Gemini)"
 	case Perplexity:
 		return "Perplexity (Sonar)"
+	case DeepSeek:
+		return "DeepSeek (Chat, Reasoner)"
+	case XAI:
+		return "xAI (Grok)"
+	case Bedrock:
+		return "AWS Bedrock (Claude, Titan, Llama)"
 	default:
 		return "Unknown"
 	}
@@ -85,7 +133,7 @@ func (p Provider) DisplayName() string {
 
 // AllProviders returns a slice of all available providers
 func AllProviders() []Provider {
-	return []Provider{OpenAI, Anthropic, Ollama, Google, Perplexity}
+	return []Provider{OpenAI, Anthropic, Ollama, Google, Perplexity, DeepSeek, XAI, Bedrock}
 }
 
 // GetConsoleURL returns the console URL where API keys can be generated for the provider
@@ -99,6 +147,12 @@ func (p Provider) GetConsoleURL() string {
 		return "https://makersuite.google.com/app/apikey"
 	case Perplexity:
 		return "https://www.perplexity.ai/settings/api"
+	case DeepSeek:
+		return "https://platform.deepseek.com/api_keys"
+	case XAI:
+		return "https://console.x.ai"
+	case Bedrock:
+		return "https://console.aws.amazon.com/bedrock/home#/modelaccess" // Bedrock uses AWS credentials, this is where model access is granted
 	case Ollama:
 		return "https://ollama.ai/" // Ollama doesn't need API keys, but provides setup info
 	default:
@@ -106,15 +160,38 @@ func (p Provider) GetConsoleURL() string {
 	}
 }
 
-// MaskAPIKey masks the API key for display (shows first 4 and last 4 characters)
-func MaskAPIKey(apiKey string) string {
-	if apiKey == "" {
-		return "(not set)"
+// DefaultBaseURL returns the base URL the provider uses when an LLM sets none, or "" when it is
+// the endpoint built into the provider's client
+func (p Provider) DefaultBaseURL() string {
+	switch p {
+	case Ollama:
+		return ollama.DefaultBaseURL
+	case DeepSeek:
+		return deepseek.DefaultBaseURL
+	case XAI:
+		return xai.DefaultBaseURL
+	default:
+		return ""
 	}
-	if len(apiKey) <= 8 {
-		return "***"
+}
+
+// NormalizeBaseURL validates the base URL of an LLM of provider and returns it without trailing
+// slashes. An empty value means the provider default and is returned as is.
+func NormalizeBaseURL(provider, baseURL string) (string, error) {
+	normalized, err := llm.ValidateBaseURL(baseURL)
+	if err != nil {
+		if p := FromString(provider); p != 0 && p.DefaultBaseURL() != "" {
+			return "", fmt.Errorf("%w (the %s default is %s)", err, p.String(), p.DefaultBaseURL())
+		}
+		return "", err
 	}
-	return apiKey[:4] + "..." + apiKey[len(apiKey)-4:]
+	return normalized, nil
+}
+
+// RequiresAPIKey reports whether LLMs of the provider need an API key. Ollama needs none and
+// Bedrock authenticates with the AWS credentials of the environment.
+func (p Provider) RequiresAPIKey() bool {
+	return p != Ollama && p != Bedrock
 }
 
 // GetExistingAPIKeysForProvider returns existing API keys for a given provider
@@ -156,27 +233,218 @@ func (s *LLMService) ValidateLLMConfig(config *models.LLMConfig) error {
 		return fmt.Errorf("unknown provider: %s", config.Provider)
 	}
 
-	if provider != Ollama && config.APIKey == "" {
+	if provider.RequiresAPIKey() && config.APIKey == "" {
 		return fmt.Errorf("API key is required for %s", provider.DisplayName())
 	}
 
+	baseURL, err := NormalizeBaseURL(config.Provider, config.BaseURL)
+	if err != nil {
+		return err
+	}
+	config.BaseURL = baseURL
+
+	return ValidateLLMOptions(config.Config)
+}
+
+const (
+	// MaxLLMConfigKeys is the number of provider-specific settings an LLM may have
+	MaxLLMConfigKeys = 50
+	// MaxLLMConfigKeyLength is the number of bytes of a setting name
+	MaxLLMConfigKeyLength = 100
+	// MaxLLMConfigValueLength is the number of bytes of a setting value
+	MaxLLMConfigValueLength = 4096
+)
+
+// ValidateLLMOptions checks the size of the provider-specific settings of an LLM
+func ValidateLLMOptions(options map[string]string) error {
+	if len(options) > MaxLLMConfigKeys {
+		return fmt.Errorf("config has %d keys, the maximum is %d", len(options), MaxLLMConfigKeys)
+	}
+	for key, value := range options {
+		if len(key) > MaxLLMConfigKeyLength {
+			return fmt.Errorf("config key %q exceeds %d bytes", Excerpt(key, 30), MaxLLMConfigKeyLength)
+		}
+		if len(value) > MaxLLMConfigValueLength {
+			return fmt.Errorf("config value of %q exceeds %d bytes", key, MaxLLMConfigValueLength)
+		}
+	}
 	return nil
 }
 
-// CreateLLM creates a new LLM configuration
-func (s *LLMService) CreateLLM(ctx context.Context, config *models.LLMConfig) error {
+// llmSettings validates the value of each setting gego reads from an LLM config
+var llmSettings = map[string]func(value string) error{
+	"temperature": parseFloatSetting,
+	"top_p":       parseFloatSetting,
+	"top_k":       parseIntSetting,
+	"max_tokens":  parseIntSetting,
+	"seed":        parseIntSetting,
+	"stream":      parseBoolSetting,
+	"reasoning":   parseBoolSetting,
+	"web_search":  parseBoolSetting,
+	"reasoning_effort": func(value string) error {
+		if _, err := llm.ParseReasoningEffort(value); err != nil {
+			return fmt.Errorf("must be low, medium or high")
+		}
+		return nil
+	},
+	"stop_sequences": func(value string) error {
+		if _, err := llm.ParseStopSequences(value); err != nil {
+			return fmt.Errorf("must be a sequence or a JSON array of strings")
+		}
+		return nil
+	},
+	"response_format": func(value string) error {
+		if value != llm.ResponseFormatText && value != llm.ResponseFormatJSONObject {
+			return fmt.Errorf("must be %s or %s", llm.ResponseFormatText, llm.ResponseFormatJSONObject)
+		}
+		return nil
+	},
+}
+
+// LLMSettingKeys returns the LLM config keys gego reads, sorted
+func LLMSettingKeys() []string {
+	keys := make([]string, 0, len(llmSettings))
+	for key := range llmSettings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValidateLLMSetting checks that key is a setting gego reads and that value parses for it.
+// Whether the provider supports the setting is only checked when prompts run.
+func ValidateLLMSetting(key, value string) error {
+	validate, ok := llmSettings[key]
+	if !ok {
+		return fmt.Errorf("unknown config key %q (known keys: %s)", key, strings.Join(LLMSettingKeys(), ", "))
+	}
+	if err := validate(value); err != nil {
+		return fmt.Errorf("invalid %s %q: %w", key, value, err)
+	}
+	return nil
+}
+
+func parseFloatSetting(value string) error {
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return fmt.Errorf("must be a number")
+	}
+	return nil
+}
+
+func parseIntSetting(value string) error {
+	if _, err := strconv.Atoi(value); err != nil {
+		return fmt.Errorf("must be an integer")
+	}
+	return nil
+}
+
+func parseBoolSetting(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("must be true or false")
+	}
+	return nil
+}
+
+// DuplicateLLMError is returned when creating an LLM that matches an existing one
+type DuplicateLLMError struct {
+	ExistingID   string
+	ExistingName string
+}
+
+func (e *DuplicateLLMError) Error() string {
+	return fmt.Sprintf("LLM already exists: %s (ID: %s)", e.ExistingName, e.ExistingID)
+}
+
+// InvalidLLMError is returned when an LLM configuration fails validation
+type InvalidLLMError struct {
+	Err error
+}
+
+func (e *InvalidLLMError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidLLMError) Unwrap() error {
+	return e.Err
+}
+
+// FindDuplicateLLM returns an existing LLM with the same provider and model, and the same
+// API key (or the same base URL for Ollama and Bedrock), or nil if there is none. API keys are
+// compared by fingerprint.
+func (s *LLMService) FindDuplicateLLM(ctx context.Context, config *models.LLMConfig) (*models.LLMConfig, error) {
+	llms, err := s.db.ListLLMs(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint := shared.APIKeyFingerprint(config.APIKey)
+
+	for _, existing := range llms {
+		if existing.ID == config.ID || existing.Provider != config.Provider || existing.Model != config.Model {
+			continue
+		}
+
+		if FromString(config.Provider) == Ollama {
+			if ollamaBaseURL(existing.BaseURL) == ollamaBaseURL(config.BaseURL) {
+				return existing, nil
+			}
+			continue
+		}
+		if FromString(config.Provider) == Bedrock {
+			if existing.BaseURL == config.BaseURL {
+				return existing, nil
+			}
+			continue
+		}
+
+		if shared.APIKeyFingerprint(existing.APIKey) == fingerprint {
+			return existing, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ollamaBaseURL returns the base URL an Ollama provider actually uses
+func ollamaBaseURL(baseURL string) string {
+	if baseURL == "" {
+		return Ollama.DefaultBaseURL()
+	}
+	return strings.TrimRight(baseURL, "/")
+}
+
+// CreateLLM creates a new LLM configuration. It returns an *InvalidLLMError when the
+// configuration is invalid and, unless force is set, a *DuplicateLLMError when an equivalent
+// LLM already exists.
+func (s *LLMService) CreateLLM(ctx context.Context, config *models.LLMConfig, force bool) error {
 	if err := s.ValidateLLMConfig(config); err != nil {
-		return err
+		return &InvalidLLMError{Err: err}
+	}
+
+	if !force {
+		existing, err := s.FindDuplicateLLM(ctx, config)
+		if err != nil {
+			return fmt.Errorf("failed to check for duplicate LLMs: %w", err)
+		}
+		if existing != nil {
+			return &DuplicateLLMError{ExistingID: existing.ID, ExistingName: existing.Name}
+		}
 	}
+
 	return s.db.CreateLLM(ctx, config)
 }
 
-// UpdateLLM updates an existing LLM configuration
+// UpdateLLM updates an existing LLM configuration. It returns an *InvalidLLMError when the
+// configuration is invalid.
 func (s *LLMService) UpdateLLM(ctx context.Context, config *models.LLMConfig) error {
 	if err := s.ValidateLLMConfig(config); err != nil {
+		return &InvalidLLMError{Err: err}
+	}
+	clearAutoDisable(config)
+	if err := s.db.UpdateLLM(ctx, config); err != nil {
 		return err
 	}
-	return s.db.UpdateLLM(ctx, config)
+	s.invalidate(ctx, config)
+	return nil
 }
 
 // GetLLM retrieves an LLM configuration by ID
@@ -191,7 +459,15 @@ func (s *LLMService) ListLLMs(ctx context.Context, enabled *bool) ([]*models.LLM
 
 // DeleteLLM deletes an LLM configuration
 func (s *LLMService) DeleteLLM(ctx context.Context, id string) error {
-	return s.db.DeleteLLM(ctx, id)
+	llm, err := s.db.GetLLM(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.db.DeleteLLM(ctx, id); err != nil {
+		return err
+	}
+	s.invalidate(ctx, llm)
+	return nil
 }
 
 // EnableLLM enables an LLM configuration
@@ -201,7 +477,12 @@ func (s *LLMService) EnableLLM(ctx context.Context, id string) error {
 		return err
 	}
 	llm.Enabled = true
-	return s.db.UpdateLLM(ctx, llm)
+	clearAutoDisable(llm)
+	if err := s.db.UpdateLLM(ctx, llm); err != nil {
+		return err
+	}
+	s.invalidate(ctx, llm)
+	return nil
 }
 
 // DisableLLM disables an LLM configuration
@@ -211,7 +492,39 @@ func (s *LLMService) DisableLLM(ctx context.Context, id string) error {
 		return err
 	}
 	llm.Enabled = false
-	return s.db.UpdateLLM(ctx, llm)
+	if err := s.db.UpdateLLM(ctx, llm); err != nil {
+		return err
+	}
+	s.invalidate(ctx, llm)
+	return nil
+}
+
+// SetProviderEnabled enables or disables every LLM of provider, returning how many changed
+func (s *LLMService) SetProviderEnabled(ctx context.Context, provider string, enabled bool) (int, error) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if FromString(provider) == 0 {
+		return 0, fmt.Errorf("unknown provider: %s", provider)
+	}
+
+	llms, err := s.db.ListLLMs(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, llm := range llms {
+		if llm.Provider != provider || llm.Enabled == enabled {
+			continue
+		}
+		llm.Enabled = enabled
+		clearAutoDisable(llm)
+		if err := s.db.UpdateLLM(ctx, llm); err != nil {
+			return changed, fmt.Errorf("failed to update LLM %s: %w", llm.ID, err)
+		}
+		s.invalidate(ctx, llm)
+		changed++
+	}
+	return changed, nil
 }
 
 // GetEnabledLLMs returns only enabled LLM configurations
@@ -235,3 +548,64 @@ func (s *LLMService) ValidateProviderModel(provider string, model string) error
 
 	return nil
 }
+
+// ModelCheck reports whether an LLM's configured model is still offered by its provider
+type ModelCheck struct {
+	LLM          *models.LLMConfig
+	Available    bool
+	Alternatives []models.ModelInfo
+}
+
+// VerifyModel re-fetches the provider's model list and checks the LLM's configured model against it
+func (s *LLMService) VerifyModel(ctx context.Context, provider llm.Provider, config *models.LLMConfig) (*ModelCheck, []models.ModelInfo, error) {
+	available, err := provider.ListModels(ctx, config.APIKey, config.BaseURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list models for %s: %w", config.Provider, err)
+	}
+	return CheckModelAvailability(config, available), available, nil
+}
+
+// CheckModelAvailability checks whether the configured model is in the available list and,
+// if not, suggests up to three models sharing the longest name prefix
+func CheckModelAvailability(config *models.LLMConfig, available []models.ModelInfo) *ModelCheck {
+	check := &ModelCheck{LLM: config}
+
+	for _, model := range available {
+		if model.ID == config.Model {
+			check.Available = true
+			return check
+		}
+	}
+
+	type candidate struct {
+		model  models.ModelInfo
+		prefix int
+	}
+
+	var candidates []candidate
+	for _, model := range available {
+		prefix := commonPrefixLength(strings.ToLower(model.ID), strings.ToLower(config.Model))
+		if prefix >= 3 {
+			candidates = append(candidates, candidate{model: model, prefix: prefix})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].prefix > candidates[j].prefix
+	})
+
+	for i := 0; i < len(candidates) && i < 3; i++ {
+		check.Alternatives = append(check.Alternatives, candidates[i].model)
+	}
+
+	return check
+}
+
+// commonPrefixLength returns the number of leading bytes shared by a and b
+func commonPrefixLength(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestFindDuplicateLLM(t *testing.T) {
+	existing := []*models.LLMConfig{
+		{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", APIKey: "sk-first-key-0001"},
+		{ID: "llm-2", Name: "Local", Provider: "ollama", Model: "llama3.2"},
+		{ID: "llm-3", Name: "Bedrock", Provider: "bedrock", Model: "claude", BaseURL: "us-east-1"},
+	}
+
+	tests := []struct {
+		name   string
+		config *models.LLMConfig
+		want   string
+	}{
+		{name: "same API key", config: &models.LLMConfig{Provider: "openai", Model: "gpt-4o", APIKey: "sk-first-key-0001"}, want: "llm-1"},
+		{name: "different API key", config: &models.LLMConfig{Provider: "openai", Model: "gpt-4o", APIKey: "sk-other-key-0002"}},
+		{name: "different model", config: &models.LLMConfig{Provider: "openai", Model: "gpt-4o-mini", APIKey: "sk-first-key-0001"}},
+		{name: "itself", config: &models.LLMConfig{ID: "llm-1", Provider: "openai", Model: "gpt-4o", APIKey: "sk-first-key-0001"}},
+		{name: "Ollama default base URL", config: &models.LLMConfig{Provider: "ollama", Model: "llama3.2", BaseURL: "http://localhost:11434/"}, want: "llm-2"},
+		{name: "Ollama other host", config: &models.LLMConfig{Provider: "ollama", Model: "llama3.2", BaseURL: "http://gpu-box:11434"}},
+		{name: "Bedrock same region", config: &models.LLMConfig{Provider: "bedrock", Model: "claude", BaseURL: "us-east-1"}, want: "llm-3"},
+		{name: "Bedrock other region", config: &models.LLMConfig{Provider: "bedrock", Model: "claude", BaseURL: "eu-west-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := newMemoryDB()
+			for _, llmConfig := range existing {
+				database.llms[llmConfig.ID] = llmConfig
+			}
+
+			duplicate, err := NewLLMService(database).FindDuplicateLLM(context.Background(), tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ""
+			if duplicate != nil {
+				got = duplicate.ID
+			}
+			if got != tt.want {
+				t.Errorf("duplicate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// modelListProvider offers models, or fails to list them with err when set
+type modelListProvider struct {
+	*recordingProvider
+	models []models.ModelInfo
+	err    error
+}
+
+func (p *modelListProvider) ListModels(ctx context.Context, apiKey, baseURL string) ([]models.ModelInfo, error) {
+	return p.models, p.err
+}
+
+func TestVerifyModel(t *testing.T) {
+	offered := []models.ModelInfo{{ID: "gpt-4o"}, {ID: "gpt-4o-mini"}, {ID: "gpt-4.1"}, {ID: "o3"}}
+
+	tests := []struct {
+		name             string
+		model            string
+		listErr          error
+		wantErr          bool
+		wantAvailable    bool
+		wantAlternatives []string
+	}{
+		{name: "offered", model: "gpt-4o", wantAvailable: true},
+		{name: "retired model suggests alternatives", model: "gpt-4-turbo", wantAlternatives: []string{"gpt-4o", "gpt-4o-mini", "gpt-4.1"}},
+		{name: "no close alternative", model: "claude-3-opus"},
+		{name: "listing fails", model: "gpt-4o", listErr: errors.New("invalid API key"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &modelListProvider{recordingProvider: &recordingProvider{name: "openai"}, models: offered, err: tt.listErr}
+			config := &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: tt.model}
+
+			check, _, err := NewLLMService(newMemoryDB()).VerifyModel(context.Background(), provider, config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyModel error = %v, want error %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if check.Available != tt.wantAvailable {
+				t.Errorf("available = %t, want %t", check.Available, tt.wantAvailable)
+			}
+			var alternatives []string
+			for _, model := range check.Alternatives {
+				alternatives = append(alternatives, model.ID)
+			}
+			if !slices.Equal(alternatives, tt.wantAlternatives) {
+				t.Errorf("alternatives = %v, want %v", alternatives, tt.wantAlternatives)
+			}
+		})
+	}
+}
+
+// recordingListener records the IDs of the LLMs it is notified of
+type recordingListener struct {
+	ids []string
+}
+
+func (l *recordingListener) InvalidateLLM(ctx context.Context, llm *models.LLMConfig) {
+	l.ids = append(l.ids, llm.ID)
+}
+
+func TestLLMChangesNotifyListeners(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, service *LLMService) error
+		want   []string
+	}{
+		{
+			name: "update",
+			change: func(ctx context.Context, service *LLMService) error {
+				return service.UpdateLLM(ctx, &models.LLMConfig{ID: "llm-1", Name: "GPT 4o", Provider: "openai", Model: "gpt-4o", APIKey: "sk-test"})
+			},
+			want: []string{"llm-1"},
+		},
+		{name: "delete", change: func(ctx context.Context, service *LLMService) error { return service.DeleteLLM(ctx, "llm-1") }, want: []string{"llm-1"}},
+		{name: "enable", change: func(ctx context.Context, service *LLMService) error { return service.EnableLLM(ctx, "llm-2") }, want: []string{"llm-2"}},
+		{name: "disable", change: func(ctx context.Context, service *LLMService) error { return service.DisableLLM(ctx, "llm-1") }, want: []string{"llm-1"}},
+		{
+			name: "provider toggle",
+			change: func(ctx context.Context, service *LLMService) error {
+				_, err := service.SetProviderEnabled(ctx, "openai", false)
+				return err
+			},
+			want: []string{"llm-1"},
+		},
+		{name: "failed change", change: func(ctx context.Context, service *LLMService) error { return service.EnableLLM(ctx, "missing") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := newMemoryDB()
+			database.llms["llm-1"] = &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", APIKey: "sk-test", Enabled: true}
+			database.llms["llm-2"] = &models.LLMConfig{ID: "llm-2", Name: "Local", Provider: "ollama", Model: "llama3.2"}
+			listener := &recordingListener{}
+			service := NewLLMService(database)
+			service.Subscribe(listener)
+
+			err := tt.change(context.Background(), service)
+			if (err != nil) != (tt.want == nil) {
+				t.Fatalf("change error = %v", err)
+			}
+			if !slices.Equal(listener.ids, tt.want) {
+				t.Errorf("notified %v, want %v", listener.ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteLLMDropsRateLimiter(t *testing.T) {
+	database := newMemoryDB()
+	database.llms["llm-1"] = &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", APIKey: "sk-test", Enabled: true}
+	scheduler := newTestScheduler(database, &recordingProvider{name: "openai", text: "ok"})
+	scheduler.RateLimiters().Get("openai")
+
+	service := NewLLMService(database)
+	service.Subscribe(scheduler)
+	if err := service.DeleteLLM(context.Background(), "llm-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if providers := scheduler.RateLimiters().Providers(); len(providers) != 0 {
+		t.Errorf("rate limiters = %v, want none after deleting the last openai LLM", providers)
+	}
+}
+
+func TestSetProviderEnabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		provider    string
+		enabled     bool
+		wantChanged int
+		wantEnabled map[string]bool
+		wantErr     bool
+	}{
+		{name: "enable provider", provider: "openai", enabled: true, wantChanged: 1, wantEnabled: map[string]bool{"llm-1": true, "llm-2": true, "llm-3": true, "llm-4": false}},
+		{name: "disable provider", provider: "openai", wantChanged: 1, wantEnabled: map[string]bool{"llm-1": false, "llm-2": false, "llm-3": true, "llm-4": false}},
+		{name: "provider name normalized", provider: " Anthropic ", wantChanged: 1, wantEnabled: map[string]bool{"llm-1": true, "llm-2": false, "llm-3": false, "llm-4": false}},
+		{name: "nothing to change", provider: "ollama", wantChanged: 0, wantEnabled: map[string]bool{"llm-1": true, "llm-2": false, "llm-3": true, "llm-4": false}},
+		{name: "unknown provider", provider: "acme", enabled: true, wantErr: true, wantEnabled: map[string]bool{"llm-1": true, "llm-2": false, "llm-3": true, "llm-4": false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := newMemoryDB()
+			database.llms["llm-1"] = &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", Enabled: true}
+			database.llms["llm-2"] = &models.LLMConfig{ID: "llm-2", Name: "GPT mini", Provider: "openai", Model: "gpt-4o-mini", AuthFailures: 3, DisabledReason: "invalid API key"}
+			database.llms["llm-3"] = &models.LLMConfig{ID: "llm-3", Name: "Claude", Provider: "anthropic", Model: "claude", Enabled: true}
+			database.llms["llm-4"] = &models.LLMConfig{ID: "llm-4", Name: "Local", Provider: "ollama", Model: "llama3.2"}
+
+			changed, err := NewLLMService(database).SetProviderEnabled(context.Background(), tt.provider, tt.enabled)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetProviderEnabled() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("SetProviderEnabled() changed %d, want %d", changed, tt.wantChanged)
+			}
+			for id, want := range tt.wantEnabled {
+				if got := database.llms[id].Enabled; got != want {
+					t.Errorf("%s enabled = %t, want %t", id, got, want)
+				}
+			}
+			// Enabling an LLM by hand clears its automatic disabling
+			if llm2 := database.llms["llm-2"]; llm2.Enabled && (llm2.AuthFailures != 0 || llm2.DisabledReason != "") {
+				t.Errorf("re-enabled llm-2 kept auth failures %d, reason %q", llm2.AuthFailures, llm2.DisabledReason)
+			}
+		})
+	}
+}
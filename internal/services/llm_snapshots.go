@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// ResolveLLMSnapshots completes snapshots, the LLM details recorded on responses keyed by LLM ID,
+// for each of llmIDs. Only IDs without a recorded snapshot, from responses stored before snapshots,
+// are looked up in the live configuration; deleted LLMs among them are left out.
+func ResolveLLMSnapshots(ctx context.Context, database db.Database, llmIDs []string, snapshots map[string]models.LLMSnapshot) error {
+	var missing []string
+	for _, id := range llmIDs {
+		if snapshot, ok := snapshots[id]; !ok || snapshot.Empty() {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	llms, err := database.GetLLMsByIDs(ctx, missing)
+	if err != nil {
+		return fmt.Errorf("failed to get LLMs: %w", err)
+	}
+	for id, llm := range llms {
+		snapshots[id] = models.LLMSnapshot{Name: llm.Name, Provider: llm.Provider, Model: llm.Model}
+	}
+	return nil
+}
+
+// resolveKeywordLLMs completes the LLM snapshots of keyword stats for every LLM it counts
+func resolveKeywordLLMs(ctx context.Context, database db.Database, stats *models.KeywordStats) error {
+	if stats.LLMs == nil {
+		stats.LLMs = make(map[string]models.LLMSnapshot)
+	}
+	llmIDs := make([]string, 0, len(stats.ByLLM))
+	for id := range stats.ByLLM {
+		llmIDs = append(llmIDs, id)
+	}
+	return ResolveLLMSnapshots(ctx, database, llmIDs, stats.LLMs)
+}
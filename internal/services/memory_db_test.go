@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// memoryDB keeps LLMs, schedules, prompts and responses in memory. Operations the tests do not
+// need panic through the nil embedded Database.
+type memoryDB struct {
+	db.Database
+
+	mu        sync.Mutex
+	llms      map[string]*models.LLMConfig
+	schedules map[string]*models.Schedule
+	prompts   map[string]*models.Prompt
+	responses []*models.Response
+	batch     []*models.Response // Responses created while batching, not written yet
+	batching  bool
+}
+
+func newMemoryDB() *memoryDB {
+	return &memoryDB{
+		llms:      make(map[string]*models.LLMConfig),
+		schedules: make(map[string]*models.Schedule),
+		prompts:   make(map[string]*models.Prompt),
+	}
+}
+
+func (m *memoryDB) CreateLLM(ctx context.Context, llmConfig *models.LLMConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *llmConfig
+	m.llms[llmConfig.ID] = &copied
+	return nil
+}
+
+func (m *memoryDB) GetLLM(ctx context.Context, id string) (*models.LLMConfig, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	llmConfig, ok := m.llms[id]
+	if !ok {
+		return nil, fmt.Errorf("LLM not found: %s", id)
+	}
+	copied := *llmConfig
+	return &copied, nil
+}
+
+// GetLLMsByIDs returns the stored LLMs among ids, keyed by ID
+func (m *memoryDB) GetLLMsByIDs(ctx context.Context, ids []string) (map[string]*models.LLMConfig, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make(map[string]*models.LLMConfig)
+	for _, id := range ids {
+		if llmConfig, ok := m.llms[id]; ok {
+			copied := *llmConfig
+			result[id] = &copied
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryDB) UpdateLLM(ctx context.Context, llmConfig *models.LLMConfig) error {
+	return m.CreateLLM(ctx, llmConfig)
+}
+
+func (m *memoryDB) DeleteLLM(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.llms[id]; !ok {
+		return fmt.Errorf("LLM not found: %s", id)
+	}
+	delete(m.llms, id)
+	return nil
+}
+
+func (m *memoryDB) ListLLMs(ctx context.Context, enabled *bool) ([]*models.LLMConfig, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*models.LLMConfig
+	for _, llmConfig := range m.llms {
+		if enabled == nil || llmConfig.Enabled == *enabled {
+			copied := *llmConfig
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (m *memoryDB) CreateSchedule(ctx context.Context, schedule *models.Schedule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *schedule
+	m.schedules[schedule.ID] = &copied
+	return nil
+}
+
+func (m *memoryDB) UpdateSchedule(ctx context.Context, schedule *models.Schedule) error {
+	return m.CreateSchedule(ctx, schedule)
+}
+
+func (m *memoryDB) GetSchedule(ctx context.Context, id string) (*models.Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	schedule, ok := m.schedules[id]
+	if !ok {
+		return nil, fmt.Errorf("schedule not found: %s", id)
+	}
+	copied := *schedule
+	return &copied, nil
+}
+
+func (m *memoryDB) ListSchedules(ctx context.Context, enabled *bool) ([]*models.Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*models.Schedule
+	for _, schedule := range m.schedules {
+		if enabled == nil || schedule.Enabled == *enabled {
+			copied := *schedule
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (m *memoryDB) DeleteSchedule(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.schedules[id]; !ok {
+		return fmt.Errorf("schedule not found: %s", id)
+	}
+	delete(m.schedules, id)
+	return nil
+}
+
+func (m *memoryDB) SetScheduleError(ctx context.Context, id, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if schedule, ok := m.schedules[id]; ok {
+		schedule.LastError = message
+	}
+	return nil
+}
+
+func (m *memoryDB) CreatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *prompt
+	copied.Tags = slices.Clone(prompt.Tags)
+	m.prompts[prompt.ID] = &copied
+	return nil
+}
+
+func (m *memoryDB) UpdatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	m.mu.Lock()
+	if _, ok := m.prompts[prompt.ID]; !ok {
+		m.mu.Unlock()
+		return fmt.Errorf("prompt not found: %s", prompt.ID)
+	}
+	m.mu.Unlock()
+	return m.CreatePrompt(ctx, prompt)
+}
+
+func (m *memoryDB) GetPrompt(ctx context.Context, id string) (*models.Prompt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	prompt, ok := m.prompts[id]
+	if !ok {
+		return nil, fmt.Errorf("prompt not found: %s", id)
+	}
+	copied := *prompt
+	return &copied, nil
+}
+
+// GetPromptsByIDs returns the stored prompts among ids, keyed by ID
+func (m *memoryDB) GetPromptsByIDs(ctx context.Context, ids []string) (map[string]*models.Prompt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make(map[string]*models.Prompt)
+	for _, id := range ids {
+		if prompt, ok := m.prompts[id]; ok {
+			copied := *prompt
+			result[id] = &copied
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryDB) ListPrompts(ctx context.Context, enabled *bool) ([]*models.Prompt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*models.Prompt
+	for _, prompt := range m.prompts {
+		if enabled == nil || prompt.Enabled == *enabled {
+			copied := *prompt
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (m *memoryDB) DeletePrompt(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.prompts[id]; !ok {
+		return fmt.Errorf("prompt not found: %s", id)
+	}
+	delete(m.prompts, id)
+	return nil
+}
+
+func (m *memoryDB) CreateResponse(ctx context.Context, response *models.Response) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.batching {
+		m.batch = append(m.batch, response)
+		return nil
+	}
+	m.responses = append(m.responses, response)
+	return nil
+}
+
+// ListResponses honors the prompt, schedule, start time and grounded filters, newest first
+func (m *memoryDB) ListResponses(ctx context.Context, filter shared.ResponseFilter) ([]*models.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*models.Response
+	for i := len(m.responses) - 1; i >= 0; i-- {
+		response := m.responses[i]
+		if filter.PromptID != "" && response.PromptID != filter.PromptID {
+			continue
+		}
+		if filter.LLMID != "" && response.LLMID != filter.LLMID {
+			continue
+		}
+		if filter.Model != "" && response.LLMModel != filter.Model {
+			continue
+		}
+		if filter.ScheduleID != "" && response.ScheduleID != filter.ScheduleID {
+			continue
+		}
+		if filter.StartTime != nil && response.CreatedAt.Before(*filter.StartTime) {
+			continue
+		}
+		if filter.Grounded != nil && response.Grounded != *filter.Grounded {
+			continue
+		}
+		if filter.HasError != nil && (response.Error != "") != *filter.HasError {
+			continue
+		}
+		if filter.CacheKey != "" && response.Metadata["cache_key"] != filter.CacheKey {
+			continue
+		}
+		if fromCache, _ := response.Metadata["from_cache"].(bool); filter.ExcludeCached && fromCache {
+			continue
+		}
+		result = append(result, response)
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// PendingResponses returns the responses created while batching
+func (m *memoryDB) PendingResponses() []*models.Response {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*models.Response(nil), m.batch...)
+}
+
+// FlushResponses writes the responses created while batching
+func (m *memoryDB) FlushResponses(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses = append(m.responses, m.batch...)
+	m.batch = nil
+	return nil
+}
+
+// allResponses returns the written responses, oldest first
+func (m *memoryDB) allResponses() []*models.Response {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*models.Response(nil), m.responses...)
+}
+
+// recordingProvider answers every prompt with text and records the prompts and configs it was
+// called with. It reports every capability.
+type recordingProvider struct {
+	name string
+	text string
+
+	mu      sync.Mutex
+	prompts []string
+	configs []llm.Config
+}
+
+func (p *recordingProvider) Name() string { return p.name }
+
+func (p *recordingProvider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prompts = append(p.prompts, prompt)
+	p.configs = append(p.configs, config)
+	return &llm.Response{Text: p.text, Provider: p.name, Model: config.Model, Grounded: config.WebSearch}, nil
+}
+
+func (p *recordingProvider) Capabilities() llm.Capabilities {
+	return llm.Capabilities{JSONMode: true, Seed: true, MaxStopSequences: llm.UnlimitedStopSequences, WebSearch: true}
+}
+
+func (p *recordingProvider) Validate(config map[string]string) error { return nil }
+
+func (p *recordingProvider) ListModels(ctx context.Context, apiKey, baseURL string) ([]models.ModelInfo, error) {
+	return nil, nil
+}
+
+// calls returns the configs the provider was called with
+func (p *recordingProvider) calls() []llm.Config {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]llm.Config(nil), p.configs...)
+}
+
+// sentPrompts returns the prompts the provider was called with
+func (p *recordingProvider) sentPrompts() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.prompts...)
+}
+
+// newTestScheduler returns a scheduler over database calling provider without rate limit
+func newTestScheduler(database db.Database, provider llm.Provider) *SchedulerService {
+	registry := llm.NewRegistry()
+	registry.Register(provider)
+	scheduler := NewSchedulerService(database, registry)
+	scheduler.rateLimiters.limiters[provider.Name()] = rate.NewLimiter(rate.Inf, 1)
+	return scheduler
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// MetadataPostProcessors lists the post-processors applied to a stored response body
+const MetadataPostProcessors = "post_processors"
+
+// PostProcessor transforms a response body before it is stored
+type PostProcessor func(text string) string
+
+// postProcessors are the named transforms available to storage.post_processors
+var postProcessors = map[string]PostProcessor{
+	"lowercase":           strings.ToLower,
+	"trim":                strings.TrimSpace,
+	"collapse_whitespace": collapseWhitespace,
+	"strip_markdown":      shared.StripMarkdown,
+}
+
+// PostProcessorNames returns the names of the available post-processors, sorted
+func PostProcessorNames() []string {
+	names := make([]string, 0, len(postProcessors))
+	for name := range postProcessors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PostProcessorPipeline applies named post-processors to response bodies in order
+type PostProcessorPipeline struct {
+	names []string
+	steps []PostProcessor
+}
+
+// NewPostProcessorPipeline builds a pipeline running the named post-processors in the given order
+func NewPostProcessorPipeline(names []string) (*PostProcessorPipeline, error) {
+	pipeline := &PostProcessorPipeline{}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		step, ok := postProcessors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown post-processor %q (available: %s)", name, strings.Join(PostProcessorNames(), ", "))
+		}
+		pipeline.names = append(pipeline.names, name)
+		pipeline.steps = append(pipeline.steps, step)
+	}
+	return pipeline, nil
+}
+
+// Names returns the post-processors of the pipeline in order
+func (p *PostProcessorPipeline) Names() []string {
+	return p.names
+}
+
+// Apply runs text through every post-processor of the pipeline
+func (p *PostProcessorPipeline) Apply(text string) string {
+	for _, step := range p.steps {
+		text = step(text)
+	}
+	return text
+}
+
+// postProcessResponse applies pipeline to a response body, keeping the raw body in its metadata
+func postProcessResponse(response *models.Response, pipeline *PostProcessorPipeline) {
+	if pipeline == nil || len(pipeline.steps) == 0 {
+		return
+	}
+
+	processed := pipeline.Apply(response.ResponseText)
+	if processed == response.ResponseText {
+		return
+	}
+
+	if response.Metadata == nil {
+		response.Metadata = make(map[string]interface{})
+	}
+	// Reasoning stripping may already have saved the raw body
+	if _, ok := response.Metadata[MetadataRawResponseText]; !ok {
+		response.Metadata[MetadataRawResponseText] = response.ResponseText
+	}
+	response.Metadata[MetadataPostProcessors] = pipeline.Names()
+	response.ResponseText = processed
+}
+
+// collapseWhitespace replaces runs of spaces and tabs with a single space and runs of blank lines
+// with a single blank line, trimming trailing spaces from each line
+func collapseWhitespace(text string) string {
+	lines := strings.Split(text, "\n")
+	collapsed := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+		collapsed = append(collapsed, line)
+	}
+	return strings.Join(collapsed, "\n")
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestPostProcessorPipeline(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "strip markdown then lowercase",
+			names: []string{"strip_markdown", "lowercase"},
+			text:  "## Top picks\n\n**Acme** and [Globex](https://globex.example)",
+			want:  "top picks\n\nacme and globex",
+		},
+		{
+			name:  "collapse whitespace then trim",
+			names: []string{" Collapse_Whitespace ", "trim"},
+			text:  "  Acme   is\t\tfirst  \n\n\n\nGlobex  second \n",
+			want:  "Acme is first\n\nGlobex second",
+		},
+		{
+			name: "empty pipeline",
+			text: " **Acme** ",
+			want: " **Acme** ",
+		},
+		{
+			name:    "unknown post-processor",
+			names:   []string{"trim", "uppercase"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline, err := NewPostProcessorPipeline(tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPostProcessorPipeline(%v) error = %v, wantErr %v", tt.names, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := pipeline.Apply(tt.text); got != tt.want {
+				t.Errorf("Apply(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostProcessResponseKeepsRawText(t *testing.T) {
+	pipeline, err := NewPostProcessorPipeline([]string{"strip_markdown", "lowercase"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := &models.Response{ResponseText: "**Acme** wins"}
+
+	postProcessResponse(response, pipeline)
+
+	if response.ResponseText != "acme wins" {
+		t.Errorf("ResponseText = %q, want %q", response.ResponseText, "acme wins")
+	}
+	if got := response.Metadata[MetadataRawResponseText]; got != "**Acme** wins" {
+		t.Errorf("raw text = %v, want the original body", got)
+	}
+	if got, _ := response.Metadata[MetadataPostProcessors].([]string); !slices.Equal(got, []string{"strip_markdown", "lowercase"}) {
+		t.Errorf("post-processors = %v, want [strip_markdown lowercase]", got)
+	}
+}
@@ -3,12 +3,19 @@ package services
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/AI2HU/gego/internal/llm"
 	"github.com/AI2HU/gego/internal/models"
 )
 
+// GenerationTimeout bounds a single prompt generation call
+const GenerationTimeout = 2 * time.Minute
+
 // PromptGenerationService provides business logic for LLM-based prompt generation
 type PromptGenerationService struct {
 	llmRegistry *llm.Registry
@@ -43,8 +50,8 @@ func (s *PromptGenerationService) ValidateGenerationConfig(config *GenerationCon
 	if config.PromptCount < 1 {
 		return fmt.Errorf("prompt count must be at least 1")
 	}
-	if config.PromptCount > 100 {
-		return fmt.Errorf("prompt count cannot exceed 100")
+	if config.PromptCount > MaxGeneratedPromptCount {
+		return fmt.Errorf("prompt count cannot exceed %d", MaxGeneratedPromptCount)
 	}
 	return nil
 }
@@ -67,10 +74,10 @@ func (s *PromptGenerationService) GeneratePrompts(ctx context.Context, llmConfig
 		config.PromptCount,
 	)
 
-	response, err := provider.Generate(ctx, prePrompt, llm.Config{
-		Model:     llmConfig.Model,
-		MaxTokens: 1000,
-	})
+	ctx, cancel := context.WithTimeout(ctx, GenerationTimeout)
+	defer cancel()
+
+	response, err := provider.Generate(ctx, prePrompt, generationLLMConfig(llmConfig))
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate prompts: %w", err)
 	}
@@ -79,29 +86,75 @@ func (s *PromptGenerationService) GeneratePrompts(ctx context.Context, llmConfig
 		return nil, fmt.Errorf("LLM error: %s", response.Error)
 	}
 
-	promptLines := strings.Split(strings.TrimSpace(response.Text), "\n")
-	var generatedPrompts []string
+	generatedPrompts := ParseGeneratedPrompts(response.Text)
+	if len(generatedPrompts) == 0 {
+		return nil, fmt.Errorf("no valid prompts were generated")
+	}
+
+	return generatedPrompts, nil
+}
+
+// generationLLMConfig builds the generation config from the LLM's configured temperature and max_tokens
+func generationLLMConfig(llmConfig *models.LLMConfig) llm.Config {
+	config := llm.DefaultConfig()
+	config.Model = llmConfig.Model
+	config.MaxTokens = MaxCompletionTokens(llmConfig)
+
+	if value, ok := llmConfig.Config["temperature"]; ok {
+		if temperature, err := strconv.ParseFloat(value, 64); err == nil {
+			config.Temperature = temperature
+		}
+	}
+
+	return config
+}
+
+// MaxGeneratedPromptCount is the most prompts a single generation may ask for
+const MaxGeneratedPromptCount = 100
 
-	for _, line := range promptLines {
-		line = strings.TrimSpace(line)
+// MaxGeneratedPromptLength is the longest line ParseGeneratedPrompts accepts as a prompt
+const MaxGeneratedPromptLength = 1000
+
+var (
+	// enumeratorPattern matches a leading list marker such as "12.", "3)", "-", "*" or "•"
+	enumeratorPattern = regexp.MustCompile(`^\s*(\d+[.)]|[-*•])\s*`)
+	// markdownEmphasisPattern matches bold or italic markers around text
+	markdownEmphasisPattern = regexp.MustCompile(`(\*\*|__)(.+?)(\*\*|__)`)
+)
+
+// ParseGeneratedPrompts extracts the prompts from an LLM's list answer. List markers and markdown
+// emphasis are stripped; when the answer contains a list, lines around it (such as an introductory
+// sentence) are dropped. Lines longer than MaxGeneratedPromptLength are ignored.
+func ParseGeneratedPrompts(text string) []string {
+	var listed, plain []string
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(markdownEmphasisPattern.ReplaceAllString(line, "$2"))
 		if line == "" {
 			continue
 		}
 
-		if len(line) > 2 && (line[1] == '.' || line[1] == ')') && (line[0] >= '0' && line[0] <= '9') {
-			line = strings.TrimSpace(line[2:])
+		isListItem := false
+		if marker := enumeratorPattern.FindString(line); marker != "" {
+			isListItem = true
+			line = strings.TrimSpace(line[len(marker):])
 		}
 
-		if line != "" {
-			generatedPrompts = append(generatedPrompts, line)
+		if line == "" || utf8.RuneCountInString(line) > MaxGeneratedPromptLength {
+			continue
 		}
-	}
 
-	if len(generatedPrompts) == 0 {
-		return nil, fmt.Errorf("no valid prompts were generated")
+		if isListItem {
+			listed = append(listed, line)
+		} else {
+			plain = append(plain, line)
+		}
 	}
 
-	return generatedPrompts, nil
+	if len(listed) > 0 {
+		return listed
+	}
+	return plain
 }
 
 // GetLanguageName returns the display name for a language code
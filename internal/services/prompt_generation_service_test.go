@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestGenerationLLMConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          map[string]string
+		wantTemperature float64
+		wantMaxTokens   int
+	}{
+		{name: "defaults", wantTemperature: 0.7, wantMaxTokens: DefaultMaxTokens},
+		{name: "configured", config: map[string]string{"temperature": "0.2", "max_tokens": "2000"}, wantTemperature: 0.2, wantMaxTokens: 2000},
+		{name: "zero temperature", config: map[string]string{"temperature": "0"}, wantTemperature: 0, wantMaxTokens: DefaultMaxTokens},
+		{name: "malformed temperature", config: map[string]string{"temperature": "warm"}, wantTemperature: 0.7, wantMaxTokens: DefaultMaxTokens},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := generationLLMConfig(&models.LLMConfig{Model: "gpt-4o", Config: tt.config})
+			if got.Model != "gpt-4o" || got.Temperature != tt.wantTemperature || got.MaxTokens != tt.wantMaxTokens {
+				t.Errorf("config = model %s temperature %v max tokens %d, want gpt-4o %v %d",
+					got.Model, got.Temperature, got.MaxTokens, tt.wantTemperature, tt.wantMaxTokens)
+			}
+		})
+	}
+}
+
+// deadlineProvider records whether Generate was called with a deadline
+type deadlineProvider struct {
+	recordingProvider
+	deadline time.Time
+}
+
+func (p *deadlineProvider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
+	p.deadline, _ = ctx.Deadline()
+	return p.recordingProvider.Generate(ctx, prompt, config)
+}
+
+func TestGeneratePrompts(t *testing.T) {
+	provider := &deadlineProvider{recordingProvider: recordingProvider{name: "openai", text: "1. What is the best CRM?\n2. Which CRM do startups use?"}}
+	registry := llm.NewRegistry()
+	registry.Register(provider)
+	service := NewPromptGenerationService(registry)
+
+	llmConfig := &models.LLMConfig{Provider: "openai", Model: "gpt-4o", Config: map[string]string{"temperature": "0.3"}}
+	prompts, err := service.GeneratePrompts(context.Background(), llmConfig, &GenerationConfig{LanguageCode: "EN", UserInput: "CRM software", PromptCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(prompts) != 2 || prompts[0] != "What is the best CRM?" {
+		t.Errorf("prompts = %q", prompts)
+	}
+	if calls := provider.calls(); len(calls) != 1 || calls[0].Temperature != 0.3 {
+		t.Errorf("calls = %+v, want one at temperature 0.3", calls)
+	}
+	if remaining := time.Until(provider.deadline); remaining <= 0 || remaining > GenerationTimeout {
+		t.Errorf("deadline in %v, want within %v", remaining, GenerationTimeout)
+	}
+
+	_, err = service.GeneratePrompts(context.Background(), &models.LLMConfig{Provider: "anthropic", Model: "claude"}, &GenerationConfig{LanguageCode: "EN", UserInput: "CRM software", PromptCount: 2})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("unregistered provider error = %v", err)
+	}
+}
+
+func TestParseGeneratedPrompts(t *testing.T) {
+	var twelve []string
+	var twelveWant []string
+	for i := 1; i <= 12; i++ {
+		prompt := "Question number " + strconv.Itoa(i) + "?"
+		twelve = append(twelve, strconv.Itoa(i)+". "+prompt)
+		twelveWant = append(twelveWant, prompt)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "numbered", text: "1. What is the best CRM?\n2. Which CRM is cheapest?", want: []string{"What is the best CRM?", "Which CRM is cheapest?"}},
+		{name: "ten or more items", text: strings.Join(twelve, "\n"), want: twelveWant},
+		{name: "parenthesis markers", text: "1) First?\n10) Tenth?", want: []string{"First?", "Tenth?"}},
+		{name: "bullets", text: "- Dash?\n* Star?\n• Bullet?", want: []string{"Dash?", "Star?", "Bullet?"}},
+		{name: "markdown emphasis", text: "1. **Best CRM** for startups?\n2. __Cheapest__ CRM?", want: []string{"Best CRM for startups?", "Cheapest CRM?"}},
+		{name: "bold markers", text: "**1.** Bold number?", want: []string{"Bold number?"}},
+		{name: "introduction dropped", text: "Here are your prompts:\n\n1. First?\n2. Second?\n\nHope this helps!", want: []string{"First?", "Second?"}},
+		{name: "plain lines", text: "First?\n\n  Second?  ", want: []string{"First?", "Second?"}},
+		{name: "empty items skipped", text: "1.\n2. Second?\n-", want: []string{"Second?"}},
+		{name: "numbers inside text kept", text: "1. Top 10 CRMs in 2025?", want: []string{"Top 10 CRMs in 2025?"}},
+		{name: "overlong line ignored", text: "1. " + strings.Repeat("a", MaxGeneratedPromptLength+1) + "\n2. Short?", want: []string{"Short?"}},
+		{name: "empty", text: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseGeneratedPrompts(tt.text)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseGeneratedPrompts = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
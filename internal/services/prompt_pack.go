@@ -0,0 +1,171 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+const (
+	// MaxPromptPackSize is the number of prompts a pack may hold
+	MaxPromptPackSize = 1000
+	// maxTagLength is the number of characters of a prompt tag, as checked by ValidatePromptTags
+	maxTagLength = 50
+)
+
+// PromptPack is a YAML or JSON file of prompts imported together
+type PromptPack struct {
+	Prompts []PromptPackEntry `yaml:"prompts" json:"prompts"`
+}
+
+// PromptPackEntry is a prompt of a pack
+type PromptPackEntry struct {
+	Template string   `yaml:"template" json:"template"`
+	Tags     []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Enabled  *bool    `yaml:"enabled,omitempty" json:"enabled,omitempty"` // Defaults to true unless prompts are created disabled
+	Owner    string   `yaml:"owner,omitempty" json:"owner,omitempty"`
+}
+
+// PackValidationError lists every problem found in an imported pack, each prefixed with the path
+// of the offending field, such as "prompts[2].template: is required"
+type PackValidationError struct {
+	Problems []string
+}
+
+func (e *PackValidationError) Error() string {
+	return fmt.Sprintf("invalid pack, nothing was imported (%d problem(s)):\n  - %s", len(e.Problems), strings.Join(e.Problems, "\n  - "))
+}
+
+// PromptPackResult summarizes a pack import
+type PromptPackResult struct {
+	Created []*models.Prompt
+	Skipped []string // Templates of pack prompts that already exist
+}
+
+// ParsePromptPack decodes a pack from YAML or JSON, rejecting fields the pack format does not define
+func ParsePromptPack(data []byte) (*PromptPack, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var pack PromptPack
+	if err := decoder.Decode(&pack); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, &PackValidationError{Problems: []string{"file is empty"}}
+		}
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			return nil, &PackValidationError{Problems: typeErr.Errors}
+		}
+		return nil, &PackValidationError{Problems: []string{strings.TrimPrefix(err.Error(), "yaml: ")}}
+	}
+	return &pack, nil
+}
+
+// ValidatePromptPack checks the required fields and value ranges of every prompt of pack, returning
+// a PackValidationError listing all problems
+func (s *PromptManagementService) ValidatePromptPack(pack *PromptPack) error {
+	var problems []string
+	switch {
+	case len(pack.Prompts) == 0:
+		problems = append(problems, "prompts: at least one prompt is required")
+	case len(pack.Prompts) > MaxPromptPackSize:
+		problems = append(problems, fmt.Sprintf("prompts: %d prompts exceed the maximum of %d per pack", len(pack.Prompts), MaxPromptPackSize))
+	}
+
+	seen := make(map[string]int)
+	for i, entry := range pack.Prompts {
+		path := fmt.Sprintf("prompts[%d]", i)
+		template := strings.TrimSpace(SanitizeTemplate(entry.Template))
+		if template == "" {
+			problems = append(problems, path+".template: is required")
+		} else if first, ok := seen[template]; ok {
+			problems = append(problems, fmt.Sprintf("%s.template: duplicates prompts[%d]", path, first))
+		} else {
+			seen[template] = i
+		}
+		for j, tag := range entry.Tags {
+			tag = strings.TrimSpace(tag)
+			if tag == "" {
+				problems = append(problems, fmt.Sprintf("%s.tags[%d]: must not be empty", path, j))
+			} else if len(tag) > maxTagLength {
+				problems = append(problems, fmt.Sprintf("%s.tags[%d]: %q exceeds %d characters", path, j, tag, maxTagLength))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &PackValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// ImportPromptPack validates pack and creates its prompts, skipping those whose template already
+// exists. The import is all or nothing: an invalid pack creates no prompt, and prompts created
+// before a failed write are deleted again.
+func (s *PromptManagementService) ImportPromptPack(ctx context.Context, pack *PromptPack, owner string) (*PromptPackResult, error) {
+	if err := s.ValidatePromptPack(pack); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.db.ListPrompts(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list existing prompts: %w", err)
+	}
+	existingTemplates := make(map[string]bool, len(existing))
+	for _, prompt := range existing {
+		existingTemplates[strings.TrimSpace(prompt.Template)] = true
+	}
+
+	result := &PromptPackResult{}
+	var prompts []*models.Prompt
+	for _, entry := range pack.Prompts {
+		template := strings.TrimSpace(SanitizeTemplate(entry.Template))
+		if existingTemplates[template] {
+			result.Skipped = append(result.Skipped, template)
+			continue
+		}
+
+		enabled := !s.createDisabled
+		if entry.Enabled != nil {
+			enabled = *entry.Enabled
+		}
+		prompt := &models.Prompt{
+			ID:       uuid.New().String(),
+			Template: template,
+			Tags:     s.NormalizeTags(entry.Tags),
+			Enabled:  enabled,
+			Owner:    strings.TrimSpace(entry.Owner),
+		}
+		if prompt.Owner == "" {
+			prompt.Owner = owner
+		}
+		prompts = append(prompts, prompt)
+	}
+
+	for _, prompt := range prompts {
+		if err := s.db.CreatePrompt(ctx, prompt); err != nil {
+			s.rollbackPrompts(ctx, result.Created)
+			return nil, fmt.Errorf("failed to create prompt %q, import rolled back: %w", Excerpt(prompt.Template, 50), err)
+		}
+		result.Created = append(result.Created, prompt)
+	}
+	return result, nil
+}
+
+// rollbackPrompts deletes the prompts created by a failed import
+func (s *PromptManagementService) rollbackPrompts(ctx context.Context, prompts []*models.Prompt) {
+	for _, prompt := range prompts {
+		if err := s.db.DeletePrompt(ctx, prompt.ID); err != nil {
+			logger.Error("Failed to roll back imported prompt %s: %v", prompt.ID, err)
+		}
+	}
+}
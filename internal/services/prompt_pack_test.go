@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// failingPromptDB fails the nth prompt it is asked to create
+type failingPromptDB struct {
+	*memoryDB
+	failAt  int
+	created int
+}
+
+func (f *failingPromptDB) CreatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	f.created++
+	if f.created == f.failAt {
+		return errors.New("connection reset")
+	}
+	return f.memoryDB.CreatePrompt(ctx, prompt)
+}
+
+func TestImportPromptPack(t *testing.T) {
+	pack := &PromptPack{Prompts: []PromptPackEntry{
+		{Template: "What is the best CRM?", Tags: []string{"CRM"}},
+		{Template: "Which CRM do startups use?"},
+		{Template: "Which CRM integrates with email?"},
+	}}
+
+	tests := []struct {
+		name        string
+		pack        *PromptPack
+		failAt      int
+		wantErr     bool
+		wantPrompts int
+	}{
+		{name: "imports every prompt", pack: pack, wantPrompts: 4},
+		{name: "write failing partway inserts nothing", pack: pack, failAt: 3, wantErr: true, wantPrompts: 1},
+		{name: "write failing first inserts nothing", pack: pack, failAt: 1, wantErr: true, wantPrompts: 1},
+		{
+			name: "invalid pack inserts nothing",
+			pack: &PromptPack{Prompts: []PromptPackEntry{
+				{Template: "What is the best CRM?"},
+				{Template: " "},
+			}},
+			wantErr:     true,
+			wantPrompts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := newMemoryDB()
+			memory.CreatePrompt(context.Background(), &models.Prompt{ID: "existing", Template: "Which CRM is cheapest?"})
+			database := &failingPromptDB{memoryDB: memory, failAt: tt.failAt}
+
+			_, err := NewPromptManagementService(database).ImportPromptPack(context.Background(), tt.pack, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ImportPromptPack error = %v, want error %t", err, tt.wantErr)
+			}
+
+			prompts, _ := memory.ListPrompts(context.Background(), nil)
+			if len(prompts) != tt.wantPrompts {
+				t.Errorf("stored %d prompts, want %d", len(prompts), tt.wantPrompts)
+			}
+		})
+	}
+}
+
+func TestImportPromptPackSkipsExisting(t *testing.T) {
+	memory := newMemoryDB()
+	memory.CreatePrompt(context.Background(), &models.Prompt{ID: "existing", Template: "What is the best CRM?"})
+
+	result, err := NewPromptManagementService(memory).ImportPromptPack(context.Background(), &PromptPack{Prompts: []PromptPackEntry{
+		{Template: "  What is the best CRM?  "},
+		{Template: "Which CRM do startups use?"},
+	}}, "sales")
+	if err != nil {
+		t.Fatalf("ImportPromptPack: %v", err)
+	}
+	if len(result.Created) != 1 || len(result.Skipped) != 1 {
+		t.Fatalf("created %d, skipped %d, want 1 and 1", len(result.Created), len(result.Skipped))
+	}
+	if result.Created[0].Owner != "sales" {
+		t.Errorf("owner = %q, want sales", result.Created[0].Owner)
+	}
+}
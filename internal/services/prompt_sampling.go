@@ -0,0 +1,59 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// DefaultPromptWeight is the sampling weight of prompts without a configured weight
+const DefaultPromptWeight = 1.0
+
+// ValidatePromptSampling validates the prompt weights and sample count of a schedule
+func ValidatePromptSampling(weights map[string]float64, sampleCount int) error {
+	if sampleCount < 0 {
+		return fmt.Errorf("sample count must not be negative, got: %d", sampleCount)
+	}
+	for promptID, weight := range weights {
+		if weight <= 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+			return fmt.Errorf("weight of prompt %s must be a positive number, got: %v", promptID, weight)
+		}
+	}
+	return nil
+}
+
+// samplePrompts picks count prompts without replacement, each draw favoring prompts in proportion
+// to their weight. With count 0, or at least as many as there are prompts, all prompts are kept.
+// The sampled prompts keep their original order.
+func samplePrompts(prompts []*models.Prompt, weights map[string]float64, count int, rng *rand.Rand) []*models.Prompt {
+	if count <= 0 || count >= len(prompts) {
+		return prompts
+	}
+
+	// Weighted reservoir sampling (Efraimidis-Spirakis): keep the count largest u^(1/w) keys
+	type keyed struct {
+		index int
+		key   float64
+	}
+	keys := make([]keyed, len(prompts))
+	for i, prompt := range prompts {
+		weight, ok := weights[prompt.ID]
+		if !ok {
+			weight = DefaultPromptWeight
+		}
+		keys[i] = keyed{index: i, key: math.Pow(rng.Float64(), 1/weight)}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].key > keys[j].key })
+
+	selected := keys[:count]
+	sort.Slice(selected, func(i, j int) bool { return selected[i].index < selected[j].index })
+
+	sampled := make([]*models.Prompt, count)
+	for i, k := range selected {
+		sampled[i] = prompts[k.index]
+	}
+	return sampled
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestSamplePromptsFrequency(t *testing.T) {
+	prompts := []*models.Prompt{{ID: "heavy"}, {ID: "light-1"}, {ID: "light-2"}}
+	weights := map[string]float64{"heavy": 3}
+	rng := rand.New(rand.NewSource(42))
+
+	const draws = 20000
+	counts := make(map[string]int)
+	for i := 0; i < draws; i++ {
+		sampled := samplePrompts(prompts, weights, 1, rng)
+		if len(sampled) != 1 {
+			t.Fatalf("sampled %d prompts, want 1", len(sampled))
+		}
+		counts[sampled[0].ID]++
+	}
+
+	// A single draw picks each prompt in proportion to its weight: 3/5, 1/5 and 1/5
+	want := map[string]float64{"heavy": 0.6, "light-1": 0.2, "light-2": 0.2}
+	for id, frequency := range want {
+		if got := float64(counts[id]) / draws; math.Abs(got-frequency) > 0.02 {
+			t.Errorf("prompt %s sampled %.3f of the time, want %.2f", id, got, frequency)
+		}
+	}
+}
+
+func TestSamplePromptsKeepsOrder(t *testing.T) {
+	prompts := []*models.Prompt{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+	rng := rand.New(rand.NewSource(1))
+
+	tests := []struct {
+		name  string
+		count int
+		want  int
+	}{
+		{name: "no sampling", count: 0, want: 4},
+		{name: "more than available", count: 9, want: 4},
+		{name: "sampled", count: 2, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampled := samplePrompts(prompts, nil, tt.count, rng)
+			if len(sampled) != tt.want {
+				t.Fatalf("sampled %d prompts, want %d", len(sampled), tt.want)
+			}
+			for i := 1; i < len(sampled); i++ {
+				if sampled[i-1].ID >= sampled[i].ID {
+					t.Errorf("sampled %s before %s, want the original order", sampled[i-1].ID, sampled[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestValidatePromptSampling(t *testing.T) {
+	tests := []struct {
+		name        string
+		weights     map[string]float64
+		sampleCount int
+		wantErr     bool
+	}{
+		{name: "valid", weights: map[string]float64{"a": 2.5}, sampleCount: 3},
+		{name: "negative count", sampleCount: -1, wantErr: true},
+		{name: "zero weight", weights: map[string]float64{"a": 0}, wantErr: true},
+		{name: "infinite weight", weights: map[string]float64{"a": math.Inf(1)}, wantErr: true},
+		{name: "NaN weight", weights: map[string]float64{"a": math.NaN()}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePromptSampling(tt.weights, tt.sampleCount); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePromptSampling() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
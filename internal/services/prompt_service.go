@@ -3,15 +3,30 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/AI2HU/gego/internal/db"
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+const (
+	// MaxResponsesPerLLM caps the responses per LLM returned by GetLatestResponsesByLLM
+	MaxResponsesPerLLM = 20
+	// ResponseExcerptLength is the number of characters of a response shown in summaries
+	ResponseExcerptLength = 200
+	// responseMentionLimit is the number of extracted keywords counted per response summary
+	responseMentionLimit = 5
 )
 
 // PromptManagementService provides business logic for prompt management
 type PromptManagementService struct {
 	db db.Database
+	// Keep the case of tags instead of lowercasing them
+	preserveTagCase bool
+	// Create imported prompts without an enabled field disabled
+	createDisabled bool
 }
 
 // NewPromptManagementService creates a new prompt management service
@@ -19,6 +34,27 @@ func NewPromptManagementService(database db.Database) *PromptManagementService {
 	return &PromptManagementService{db: database}
 }
 
+// SetPreserveTagCase keeps the case of prompt tags, which are otherwise lowercased
+func (s *PromptManagementService) SetPreserveTagCase(enabled bool) {
+	s.preserveTagCase = enabled
+}
+
+// SetCreateDisabled imports the prompts of packs that do not set enabled disabled
+func (s *PromptManagementService) SetCreateDisabled(disabled bool) {
+	s.createDisabled = disabled
+}
+
+// NormalizeTags trims, deduplicates and, unless the case is preserved, lowercases tags
+func (s *PromptManagementService) NormalizeTags(tags []string) []string {
+	return shared.NormalizeTags(tags, s.preserveTagCase)
+}
+
+// SanitizeTemplate removes NUL bytes and invalid UTF-8 from a prompt template. MongoDB stores them,
+// but SQLite and exports fail on them later.
+func SanitizeTemplate(template string) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(template, ""), "\x00", "")
+}
+
 // ValidatePrompt validates prompt configuration
 func (s *PromptManagementService) ValidatePrompt(prompt *models.Prompt) error {
 	if prompt.Template == "" {
@@ -30,16 +66,20 @@ func (s *PromptManagementService) ValidatePrompt(prompt *models.Prompt) error {
 	return nil
 }
 
-// CreatePrompt creates a new prompt
+// CreatePrompt creates a new prompt with normalized tags and a sanitized template
 func (s *PromptManagementService) CreatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	prompt.Tags = s.NormalizeTags(prompt.Tags)
+	prompt.Template = SanitizeTemplate(prompt.Template)
 	if err := s.ValidatePrompt(prompt); err != nil {
 		return err
 	}
 	return s.db.CreatePrompt(ctx, prompt)
 }
 
-// UpdatePrompt updates an existing prompt
+// UpdatePrompt updates an existing prompt with normalized tags and a sanitized template
 func (s *PromptManagementService) UpdatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	prompt.Tags = s.NormalizeTags(prompt.Tags)
+	prompt.Template = SanitizeTemplate(prompt.Template)
 	if err := s.ValidatePrompt(prompt); err != nil {
 		return err
 	}
@@ -81,6 +121,35 @@ func (s *PromptManagementService) DisablePrompt(ctx context.Context, id string)
 	return s.db.UpdatePrompt(ctx, prompt)
 }
 
+// Prompt list orders accepted by SortPrompts
+const (
+	PromptSortCreatedAt = "created_at" // Newest first (default)
+	PromptSortTemplate  = "template"   // Template text, alphabetically
+)
+
+// SortPrompts orders prompts in place; ties are broken by ID so pagination is stable
+func SortPrompts(prompts []*models.Prompt, order string) error {
+	switch order {
+	case "", PromptSortCreatedAt:
+		sort.SliceStable(prompts, func(i, j int) bool {
+			if !prompts[i].CreatedAt.Equal(prompts[j].CreatedAt) {
+				return prompts[i].CreatedAt.After(prompts[j].CreatedAt)
+			}
+			return prompts[i].ID < prompts[j].ID
+		})
+	case PromptSortTemplate:
+		sort.SliceStable(prompts, func(i, j int) bool {
+			if prompts[i].Template != prompts[j].Template {
+				return prompts[i].Template < prompts[j].Template
+			}
+			return prompts[i].ID < prompts[j].ID
+		})
+	default:
+		return fmt.Errorf("invalid sort: %s (must be %s or %s)", order, PromptSortCreatedAt, PromptSortTemplate)
+	}
+	return nil
+}
+
 // GetEnabledPrompts returns only enabled prompts
 func (s *PromptManagementService) GetEnabledPrompts(ctx context.Context) ([]*models.Prompt, error) {
 	enabled := true
@@ -141,3 +210,82 @@ func (s *PromptManagementService) GetPromptsByTags(ctx context.Context, tags []s
 
 	return results, nil
 }
+
+// GetLatestResponsesByLLM returns the perLLM most recent responses to a prompt for each LLM, with an
+// excerpt and the mention counts of keywords, or of the top extracted keywords when none are given
+func (s *PromptManagementService) GetLatestResponsesByLLM(ctx context.Context, promptID string, perLLM int, keywords []string) ([]models.PromptLLMResponses, error) {
+	if perLLM < 1 || perLLM > MaxResponsesPerLLM {
+		return nil, fmt.Errorf("responses per LLM must be between 1 and %d", MaxResponsesPerLLM)
+	}
+
+	groups, err := s.db.GetLatestResponsesByLLM(ctx, promptID, perLLM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get responses: %w", err)
+	}
+
+	result := make([]models.PromptLLMResponses, 0, len(groups))
+	for _, group := range groups {
+		llmResponses := models.PromptLLMResponses{
+			LLMID:       group.LLMID,
+			LLMName:     group.LLMName,
+			LLMModel:    group.LLMModel,
+			LLMProvider: group.LLMProvider,
+		}
+		for _, response := range group.Responses {
+			llmResponses.Responses = append(llmResponses.Responses, models.ResponseSummary{
+				ID:          response.ID,
+				CreatedAt:   response.CreatedAt,
+				Temperature: response.Temperature,
+				Excerpt:     Excerpt(response.ResponseText, ResponseExcerptLength),
+				Mentions:    keywordMentions(response.ResponseText, keywords),
+			})
+		}
+		result = append(result, llmResponses)
+	}
+	return result, nil
+}
+
+// keywordMentions counts the mentions of keywords in text, or of its responseMentionLimit most
+// frequent extracted keywords when keywords is empty
+func keywordMentions(text string, keywords []string) map[string]int {
+	mentions := make(map[string]int)
+	if len(keywords) > 0 {
+		for _, keyword := range keywords {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				mentions[keyword] = shared.CountKeyword(text, keyword)
+			}
+		}
+		return mentions
+	}
+
+	counts := make(map[string]int)
+	for _, word := range shared.ExtractCapitalizedWords(text) {
+		counts[word]++
+	}
+	words := make([]string, 0, len(counts))
+	for word := range counts {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if counts[words[i]] != counts[words[j]] {
+			return counts[words[i]] > counts[words[j]]
+		}
+		return words[i] < words[j]
+	})
+	for i, word := range words {
+		if i >= responseMentionLimit {
+			break
+		}
+		mentions[word] = counts[word]
+	}
+	return mentions
+}
+
+// Excerpt returns the first length characters of text, with whitespace collapsed
+func Excerpt(text string, length int) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) <= length {
+		return string(runes)
+	}
+	return strings.TrimSpace(string(runes[:length])) + "..."
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// PromptTextResolver joins prompt templates back into responses stored with only a prompt hash,
+// loading the prompts of a batch of responses in one query and caching them for the lifetime of the resolver
+type PromptTextResolver struct {
+	db        db.Database
+	templates map[string]string // Keyed by prompt ID; empty for prompts that no longer exist
+}
+
+// NewPromptTextResolver creates a resolver with an empty prompt cache
+func NewPromptTextResolver(database db.Database) *PromptTextResolver {
+	return &PromptTextResolver{db: database, templates: make(map[string]string)}
+}
+
+// Load fetches the prompts referenced by responses that are not cached yet
+func (r *PromptTextResolver) Load(ctx context.Context, responses []*models.Response) error {
+	var missing []string
+	queued := make(map[string]bool)
+	for _, response := range responses {
+		if _, ok := r.templates[response.PromptID]; !ok && !queued[response.PromptID] {
+			queued[response.PromptID] = true
+			missing = append(missing, response.PromptID)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	prompts, err := r.db.GetPromptsByIDs(ctx, missing)
+	if err != nil {
+		return fmt.Errorf("failed to get prompts: %w", err)
+	}
+	for _, id := range missing {
+		r.templates[id] = ""
+		if prompt, ok := prompts[id]; ok {
+			r.templates[id] = prompt.Template
+		}
+	}
+	return nil
+}
+
+// Template returns the current template of a loaded prompt, or "" if it no longer exists
+func (r *PromptTextResolver) Template(promptID string) string {
+	return r.templates[promptID]
+}
+
+// Resolve fills in PromptText on responses that lack it, when their prompt still has the template
+// they were generated from, wrapped with the LLM prompt prefix and suffix recorded on the response.
+// Stored prompt text is always kept as is.
+func (r *PromptTextResolver) Resolve(ctx context.Context, responses []*models.Response) error {
+	if err := r.Load(ctx, responses); err != nil {
+		return err
+	}
+
+	for _, response := range responses {
+		if response.PromptText != "" {
+			continue
+		}
+
+		template := r.Template(response.PromptID)
+		if template == "" {
+			continue
+		}
+		prompt := responsePrompt(response, template)
+		if response.PromptHash == "" || response.PromptHash == shared.PromptHash(prompt) {
+			response.PromptText = prompt
+		}
+	}
+	return nil
+}
+
+// matchesPrompt reports whether a response was generated from template, comparing the stored
+// prompt text when present and the prompt hash otherwise
+func matchesPrompt(response *models.Response, template string) bool {
+	if response.PromptText != "" {
+		return response.PromptText == template
+	}
+	return response.PromptHash == shared.PromptHash(template)
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// Metadata keys recording the prefix and suffix a prompt was wrapped with
+const (
+	metadataPromptPrefix = "prompt_prefix"
+	metadataPromptSuffix = "prompt_suffix"
+)
+
+// RenderPrompt wraps a prompt template with the prompt prefix and suffix of an LLM, each separated
+// from the template by a blank line. The template is returned as is when the LLM has neither.
+func RenderPrompt(template string, llmConfig *models.LLMConfig) string {
+	return wrapPrompt(llmConfig.PromptPrefix, template, llmConfig.PromptSuffix)
+}
+
+// wrapPrompt joins the non-empty prefix, template and suffix with blank lines
+func wrapPrompt(prefix, template, suffix string) string {
+	prefix = strings.TrimSpace(prefix)
+	suffix = strings.TrimSpace(suffix)
+	if prefix == "" && suffix == "" {
+		return template
+	}
+
+	parts := make([]string, 0, 3)
+	if prefix != "" {
+		parts = append(parts, prefix)
+	}
+	parts = append(parts, template)
+	if suffix != "" {
+		parts = append(parts, suffix)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// promptWrapMetadata records the prompt prefix and suffix of llmConfig in metadata, so that the
+// prompt sent can be rebuilt from the template of responses stored without their prompt text
+func promptWrapMetadata(metadata map[string]interface{}, llmConfig *models.LLMConfig) map[string]interface{} {
+	prefix := strings.TrimSpace(llmConfig.PromptPrefix)
+	suffix := strings.TrimSpace(llmConfig.PromptSuffix)
+	if prefix == "" && suffix == "" {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	if prefix != "" {
+		metadata[metadataPromptPrefix] = prefix
+	}
+	if suffix != "" {
+		metadata[metadataPromptSuffix] = suffix
+	}
+	return metadata
+}
+
+// responsePrompt rebuilds the prompt a response was generated from out of its prompt template and
+// the prefix and suffix recorded in its metadata
+func responsePrompt(response *models.Response, template string) string {
+	prefix, _ := response.Metadata[metadataPromptPrefix].(string)
+	suffix, _ := response.Metadata[metadataPromptSuffix].(string)
+	return wrapPrompt(prefix, template, suffix)
+}
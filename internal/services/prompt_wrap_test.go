@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		suffix string
+		want   string
+	}{
+		{name: "neither", want: "Best CRM?"},
+		{name: "prefix", prefix: "Answer in French:", want: "Answer in French:\n\nBest CRM?"},
+		{name: "suffix", suffix: "Answer in one line.", want: "Best CRM?\n\nAnswer in one line."},
+		{name: "both trimmed", prefix: "  Answer in French: \n", suffix: "\tCite sources. ", want: "Answer in French:\n\nBest CRM?\n\nCite sources."},
+		{name: "blank", prefix: "   ", suffix: "\n", want: "Best CRM?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			llmConfig := &models.LLMConfig{PromptPrefix: tt.prefix, PromptSuffix: tt.suffix}
+			if got := RenderPrompt("Best CRM?", llmConfig); got != tt.want {
+				t.Errorf("RenderPrompt() = %q, want %q", got, tt.want)
+			}
+
+			// The stored metadata rebuilds the prompt that was sent
+			response := &models.Response{Metadata: promptWrapMetadata(nil, llmConfig)}
+			if got := responsePrompt(response, "Best CRM?"); got != tt.want {
+				t.Errorf("responsePrompt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutePromptWithLLMWrapsPrompt(t *testing.T) {
+	provider := &recordingProvider{name: "openai", text: "HubSpot"}
+	service := newTestExecution(newMemoryDB(), provider)
+
+	prompt := &models.Prompt{ID: "prompt-1", Template: "Best CRM?"}
+	llmConfig := &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", PromptPrefix: "Answer in French:", PromptSuffix: "Be brief."}
+	response, err := service.ExecutePromptWithLLM(context.Background(), prompt, llmConfig, DefaultExecutionConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Answer in French:\n\nBest CRM?\n\nBe brief."
+	if got := provider.sentPrompts(); !slices.Equal(got, []string{want}) {
+		t.Errorf("sent prompts = %q, want [%q]", got, want)
+	}
+	if response.Metadata[metadataPromptPrefix] != "Answer in French:" || response.Metadata[metadataPromptSuffix] != "Be brief." {
+		t.Errorf("metadata = %v, want the prefix and suffix recorded", response.Metadata)
+	}
+}
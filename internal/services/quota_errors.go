@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// errQuotaExhausted skips the executions of an LLM whose account ran out of quota earlier in the run
+var errQuotaExhausted = errors.New("provider quota exhausted earlier in this run")
+
+// quotaTracker records the provider accounts that reported an exhausted quota during a schedule
+// run, so that their remaining executions are skipped instead of failing one by one
+type quotaTracker struct {
+	exhausted sync.Map // provider and API key -> LLM name that hit the quota
+}
+
+type quotaTrackerKey struct{}
+
+// withQuotaTracker returns a context whose executions share tracker
+func withQuotaTracker(ctx context.Context, tracker *quotaTracker) context.Context {
+	return context.WithValue(ctx, quotaTrackerKey{}, tracker)
+}
+
+// quotaAccount identifies the account behind an LLM, which its quota belongs to
+func quotaAccount(llmConfig *models.LLMConfig) string {
+	return llmConfig.Provider + "\x00" + llmConfig.APIKey
+}
+
+// quotaExhausted reports whether the account of llmConfig ran out of quota earlier in the run of ctx
+func quotaExhausted(ctx context.Context, llmConfig *models.LLMConfig) bool {
+	tracker, ok := ctx.Value(quotaTrackerKey{}).(*quotaTracker)
+	if !ok {
+		return false
+	}
+	_, exhausted := tracker.exhausted.Load(quotaAccount(llmConfig))
+	return exhausted
+}
+
+// reportQuotaExhausted logs a quota error of llmConfig prominently, once per account and run, and
+// records it so that the remaining executions of the run skip the account
+func reportQuotaExhausted(ctx context.Context, llmConfig *models.LLMConfig, message string) {
+	if tracker, ok := ctx.Value(quotaTrackerKey{}).(*quotaTracker); ok {
+		if _, seen := tracker.exhausted.LoadOrStore(quotaAccount(llmConfig), llmConfig.Name); seen {
+			return
+		}
+	}
+	logger.ErrorContext(ctx, "💳 [%s] %s quota exhausted or out of credits: check the plan and billing of this account. Remaining calls of this run are skipped. Provider error: %s",
+		llmConfig.Name, llmConfig.Provider, message)
+}
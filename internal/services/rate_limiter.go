@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiters paces requests per LLM provider
+type RateLimiters struct {
+	limiters map[string]*rate.Limiter // Keyed by provider name
+	mu       sync.RWMutex
+}
+
+// NewRateLimiters creates per-provider rate limiters allowing RequestsPerMinute with RateLimitBurst
+func NewRateLimiters() *RateLimiters {
+	return &RateLimiters{limiters: make(map[string]*rate.Limiter)}
+}
+
+// Get gets or creates the rate limiter for the given provider
+func (r *RateLimiters) Get(provider string) *rate.Limiter {
+	r.mu.RLock()
+	limiter, exists := r.limiters[provider]
+	r.mu.RUnlock()
+
+	if exists {
+		return limiter
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if limiter, exists := r.limiters[provider]; exists {
+		return limiter
+	}
+
+	limiter = rate.NewLimiter(rate.Every(RateLimitInterval), RateLimitBurst)
+	r.limiters[provider] = limiter
+	return limiter
+}
+
+// Remove drops the rate limiter of provider; the next request creates a fresh one
+func (r *RateLimiters) Remove(provider string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.limiters, provider)
+}
+
+// Providers returns the providers with a rate limiter
+func (r *RateLimiters) Providers() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	providers := make([]string, 0, len(r.limiters))
+	for provider := range r.limiters {
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
+// Wait blocks until the provider's rate limit allows a request or ctx is cancelled
+func (r *RateLimiters) Wait(ctx context.Context, provider string) error {
+	return r.Get(provider).Wait(ctx)
+}
+
+// Delay returns how long the next request to provider would wait for the rate limit
+func (r *RateLimiters) Delay(provider string) time.Duration {
+	tokens := r.Get(provider).Tokens()
+	if tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - tokens) * float64(RateLimitInterval))
+}
+
+// Pace returns the rate limit state of provider for remaining pending requests
+func (r *RateLimiters) Pace(provider string, remaining int) ProviderPace {
+	return ProviderPace{
+		Remaining: remaining,
+		Available: r.Get(provider).Tokens(),
+		Interval:  RateLimitInterval,
+	}
+}
+
+// ProviderPace describes the pending requests of a provider and how fast its rate limit lets them through
+type ProviderPace struct {
+	Remaining int           // Requests left to send
+	Available float64       // Requests the limiter allows right away
+	Interval  time.Duration // Time between requests once Available is used up
+}
+
+// AverageLatency returns the mean time an execution took, or zero before any completed
+func AverageLatency(elapsed time.Duration, completed int) time.Duration {
+	if completed <= 0 {
+		return 0
+	}
+	return elapsed / time.Duration(completed)
+}
+
+// EstimateETA estimates the time left for sequential executions that each take about avgLatency,
+// where no provider can go faster than its rate limit allows
+func EstimateETA(paces map[string]ProviderPace, avgLatency time.Duration) time.Duration {
+	total := 0
+	var rateBound time.Duration
+	for _, pace := range paces {
+		total += pace.Remaining
+
+		waiting := pace.Remaining - int(math.Floor(math.Max(pace.Available, 0)))
+		if waiting > 0 {
+			if bound := time.Duration(waiting) * pace.Interval; bound > rateBound {
+				rateBound = bound
+			}
+		}
+	}
+
+	latencyBound := time.Duration(total) * avgLatency
+	if rateBound > latencyBound {
+		return rateBound
+	}
+	return latencyBound
+}
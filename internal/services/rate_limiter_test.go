@@ -0,0 +1,60 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateETA(t *testing.T) {
+	tests := []struct {
+		name      string
+		paces     map[string]ProviderPace
+		elapsed   time.Duration
+		completed int
+		want      time.Duration
+	}{
+		{
+			name:  "none completed waits only on rate limits",
+			paces: map[string]ProviderPace{"openai": {Remaining: 10, Available: 4, Interval: time.Second}},
+			want:  6 * time.Second,
+		},
+		{
+			name:  "none completed within the burst",
+			paces: map[string]ProviderPace{"openai": {Remaining: 3, Available: 4, Interval: time.Second}},
+		},
+		{
+			name:      "latency bound",
+			paces:     map[string]ProviderPace{"openai": {Remaining: 4, Available: 4, Interval: time.Second}, "anthropic": {Remaining: 2, Available: 2, Interval: time.Second}},
+			elapsed:   20 * time.Second,
+			completed: 10,
+			want:      12 * time.Second,
+		},
+		{
+			name:      "slowest rate limit bound",
+			paces:     map[string]ProviderPace{"openai": {Remaining: 2, Available: 2, Interval: time.Second}, "anthropic": {Remaining: 5, Interval: 6 * time.Second}},
+			elapsed:   time.Second,
+			completed: 1,
+			want:      30 * time.Second,
+		},
+		{
+			name:      "negative tokens count as none available",
+			paces:     map[string]ProviderPace{"openai": {Remaining: 2, Available: -0.5, Interval: time.Second}},
+			completed: 1,
+			want:      2 * time.Second,
+		},
+		{
+			name:      "all completed",
+			paces:     map[string]ProviderPace{"openai": {Remaining: 0, Interval: time.Second}},
+			elapsed:   time.Minute,
+			completed: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateETA(tt.paces, AverageLatency(tt.elapsed, tt.completed)); got != tt.want {
+				t.Errorf("ETA = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// startRawCapture returns a context capturing the provider calls made for llmConfig when enabled
+func startRawCapture(ctx context.Context, enabled bool, llmConfig *models.LLMConfig) (context.Context, *llm.RawCapture) {
+	if !enabled {
+		return ctx, nil
+	}
+	return llm.WithRawCapture(ctx, llmConfig.APIKey)
+}
+
+// rawCaptureMetadata records the exchanges of capture in metadata, when any were captured
+func rawCaptureMetadata(metadata map[string]interface{}, capture *llm.RawCapture) map[string]interface{} {
+	if capture == nil {
+		return metadata
+	}
+	exchanges := capture.Exchanges()
+	if len(exchanges) == 0 {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[llm.MetadataRawCapture] = exchanges
+	return metadata
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// httpProvider posts every prompt to url through the provider transport, with its API key
+type httpProvider struct {
+	*recordingProvider
+	url    string
+	apiKey string
+}
+
+func (p *httpProvider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, strings.NewReader(`{"prompt":"`+prompt+`"}`))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	resp, err := llm.NewHTTPClient(5 * time.Second).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &llm.Response{Text: string(body), Provider: p.name, Model: config.Model}, nil
+}
+
+func TestExecutePromptWithLLMCapturesRawOnlyWhenEnabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`HubSpot`))
+	}))
+	defer server.Close()
+
+	const apiKey = "sk-test-0123456789abcdef"
+	prompt := &models.Prompt{ID: "prompt-1", Template: "Best CRM?"}
+	llmConfig := &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", APIKey: apiKey}
+
+	for _, enabled := range []bool{false, true} {
+		provider := &httpProvider{recordingProvider: &recordingProvider{name: "openai"}, url: server.URL, apiKey: apiKey}
+		service := newTestExecution(newMemoryDB(), provider)
+		service.SetCaptureRaw(enabled)
+
+		response, err := service.ExecutePromptWithLLM(context.Background(), prompt, llmConfig, DefaultExecutionConfig())
+		if err != nil {
+			t.Fatal(err)
+		}
+		exchanges, captured := response.Metadata[llm.MetadataRawCapture].([]llm.RawExchange)
+		if captured != enabled {
+			t.Fatalf("capture enabled %t: metadata = %v", enabled, response.Metadata)
+		}
+		if !enabled {
+			continue
+		}
+		if len(exchanges) != 1 || exchanges[0].Request != `{"prompt":"Best CRM?"}` || exchanges[0].Response != "HubSpot" {
+			t.Errorf("exchanges = %+v, want the one provider call", exchanges)
+		}
+		if got := exchanges[0].Headers["Authorization"]; got != "[REDACTED]" {
+			t.Errorf("Authorization header = %q, want it redacted", got)
+		}
+	}
+}
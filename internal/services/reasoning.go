@@ -0,0 +1,66 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// MetadataRawResponseText holds the unstripped response body when reasoning sections were removed
+const MetadataRawResponseText = "raw_response_text"
+
+// reasoningMarkers are the opening and closing markers of reasoning sections emitted by reasoning models
+var reasoningMarkers = []struct{ open, close string }{
+	{"<think>", "</think>"},
+	{"<thinking>", "</thinking>"},
+	{"<reasoning>", "</reasoning>"},
+	{"<|begin_of_thought|>", "<|end_of_thought|>"},
+	{"◁think▷", "◁/think▷"},
+}
+
+// reasoningPatterns match a complete reasoning section for each marker pair
+var reasoningPatterns = func() []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, 0, len(reasoningMarkers))
+	for _, marker := range reasoningMarkers {
+		patterns = append(patterns, regexp.MustCompile(`(?s)`+regexp.QuoteMeta(marker.open)+`.*?`+regexp.QuoteMeta(marker.close)))
+	}
+	return patterns
+}()
+
+// StripReasoning removes delimited reasoning sections from text and reports whether any were found.
+// A closing marker without its opening one drops everything before it, and an opening marker
+// that is never closed drops everything after it, as some chat
+// templates prefill the opening marker and truncated output never closes it.
+func StripReasoning(text string) (string, bool) {
+	stripped := text
+	for i, marker := range reasoningMarkers {
+		stripped = reasoningPatterns[i].ReplaceAllString(stripped, "")
+
+		if idx := strings.Index(stripped, marker.close); idx >= 0 {
+			stripped = stripped[idx+len(marker.close):]
+		}
+		if idx := strings.Index(stripped, marker.open); idx >= 0 {
+			stripped = stripped[:idx]
+		}
+	}
+
+	if stripped == text {
+		return text, false
+	}
+	return strings.TrimSpace(stripped), true
+}
+
+// stripResponseReasoning removes reasoning sections from a response body, keeping the raw body in its metadata
+func stripResponseReasoning(response *models.Response) {
+	stripped, found := StripReasoning(response.ResponseText)
+	if !found {
+		return
+	}
+
+	if response.Metadata == nil {
+		response.Metadata = make(map[string]interface{})
+	}
+	response.Metadata[MetadataRawResponseText] = response.ResponseText
+	response.ResponseText = stripped
+}
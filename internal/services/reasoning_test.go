@@ -0,0 +1,43 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestStripResponseReasoning(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		want     string
+		wantRaw  bool
+		metadata map[string]interface{}
+	}{
+		{name: "no reasoning", text: "Acme is the best tool.", want: "Acme is the best tool."},
+		{name: "think section", text: "<think>\nThe user wants a tool.\n</think>\n\nAcme is the best tool.", want: "Acme is the best tool.", wantRaw: true},
+		{name: "several sections", text: "<thinking>a</thinking>Acme<reasoning>b</reasoning> leads.", want: "Acme leads.", wantRaw: true},
+		{name: "thought markers", text: "<|begin_of_thought|>hmm<|end_of_thought|>Acme", want: "Acme", wantRaw: true},
+		{name: "prefilled opening marker", text: "Weighing Acme and Globex.</think>Acme", want: "Acme", wantRaw: true},
+		{name: "truncated reasoning", text: "Acme<think>Should I also mention Globex", want: "Acme", wantRaw: true},
+		{name: "existing metadata kept", text: "<think>x</think>Acme", want: "Acme", wantRaw: true, metadata: map[string]interface{}{"seed": 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &models.Response{ResponseText: tt.text, Metadata: tt.metadata}
+			stripResponseReasoning(response)
+
+			if response.ResponseText != tt.want {
+				t.Errorf("text = %q, want %q", response.ResponseText, tt.want)
+			}
+			raw, ok := response.Metadata[MetadataRawResponseText]
+			if ok != tt.wantRaw || (ok && raw != tt.text) {
+				t.Errorf("raw text = %v, want %q kept: %t", raw, tt.text, tt.wantRaw)
+			}
+			if tt.metadata != nil && response.Metadata["seed"] != 7 {
+				t.Errorf("metadata = %v, want seed kept", response.Metadata)
+			}
+		})
+	}
+}
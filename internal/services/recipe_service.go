@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// RecipeService provides business logic for saved prompt generation recipes
+type RecipeService struct {
+	db db.Database
+}
+
+// NewRecipeService creates a new recipe service
+func NewRecipeService(database db.Database) *RecipeService {
+	return &RecipeService{db: database}
+}
+
+// RecipeRunResult represents the outcome of running a generation recipe
+type RecipeRunResult struct {
+	RecipeID  string           `json:"recipe_id"`
+	Generated int              `json:"generated"`
+	Saved     []*models.Prompt `json:"saved"`
+	Failed    int              `json:"failed"`
+}
+
+// ValidateRecipe validates recipe configuration
+func (s *RecipeService) ValidateRecipe(ctx context.Context, recipe *models.GenerationRecipe) error {
+	if strings.TrimSpace(recipe.Name) == "" {
+		return fmt.Errorf("recipe name is required")
+	}
+	if strings.TrimSpace(recipe.UserInput) == "" {
+		return fmt.Errorf("user input is required")
+	}
+	if err := ValidateLanguageCode(recipe.Language); err != nil {
+		return err
+	}
+	if recipe.Count < 1 || recipe.Count > 100 {
+		return fmt.Errorf("prompt count must be between 1 and 100, got: %d", recipe.Count)
+	}
+	if recipe.LLMID == "" {
+		return fmt.Errorf("LLM ID is required")
+	}
+	if _, err := s.db.GetLLM(ctx, recipe.LLMID); err != nil {
+		return fmt.Errorf("LLM %s not found: %w", recipe.LLMID, err)
+	}
+	return nil
+}
+
+// CreateRecipe creates a new generation recipe
+func (s *RecipeService) CreateRecipe(ctx context.Context, recipe *models.GenerationRecipe) error {
+	if recipe.ID == "" {
+		recipe.ID = uuid.New().String()
+	}
+	recipe.Language = strings.ToUpper(strings.TrimSpace(recipe.Language))
+	if err := s.ValidateRecipe(ctx, recipe); err != nil {
+		return err
+	}
+	return s.db.CreateRecipe(ctx, recipe)
+}
+
+// UpdateRecipe updates an existing generation recipe
+func (s *RecipeService) UpdateRecipe(ctx context.Context, recipe *models.GenerationRecipe) error {
+	recipe.Language = strings.ToUpper(strings.TrimSpace(recipe.Language))
+	if err := s.ValidateRecipe(ctx, recipe); err != nil {
+		return err
+	}
+	return s.db.UpdateRecipe(ctx, recipe)
+}
+
+// GetRecipe retrieves a generation recipe by ID
+func (s *RecipeService) GetRecipe(ctx context.Context, id string) (*models.GenerationRecipe, error) {
+	return s.db.GetRecipe(ctx, id)
+}
+
+// ListRecipes lists all generation recipes
+func (s *RecipeService) ListRecipes(ctx context.Context) ([]*models.GenerationRecipe, error) {
+	return s.db.ListRecipes(ctx)
+}
+
+// DeleteRecipe deletes a generation recipe
+func (s *RecipeService) DeleteRecipe(ctx context.Context, id string) error {
+	return s.db.DeleteRecipe(ctx, id)
+}
+
+// RunRecipe generates prompts with the recipe's stored parameters, excluding existing prompts,
+// and saves them with the recipe's tags
+func (s *RecipeService) RunRecipe(ctx context.Context, id string, generator *PromptGenerationService) (*RecipeRunResult, error) {
+	recipe, err := s.db.GetRecipe(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	llmConfig, err := s.db.GetLLM(ctx, recipe.LLMID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM for recipe: %w", err)
+	}
+
+	existingPrompts, err := s.db.ListPrompts(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch existing prompts: %w", err)
+	}
+
+	existingTemplates := make([]string, 0, len(existingPrompts))
+	for _, prompt := range existingPrompts {
+		existingTemplates = append(existingTemplates, prompt.Template)
+	}
+
+	generated, err := generator.GeneratePrompts(ctx, llmConfig, &GenerationConfig{
+		LanguageCode:    recipe.Language,
+		UserInput:       recipe.UserInput,
+		PromptCount:     recipe.Count,
+		ExistingPrompts: existingTemplates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RecipeRunResult{
+		RecipeID:  recipe.ID,
+		Generated: len(generated),
+	}
+
+	for _, template := range generated {
+		if err := ValidateGeneratedPrompt(template); err != nil {
+			result.Failed++
+			continue
+		}
+
+		prompt := CreatePromptFromGenerated(template, recipe.Language)
+		prompt.ID = uuid.New().String()
+		if len(recipe.Tags) > 0 {
+			prompt.Tags = append([]string{}, recipe.Tags...)
+		}
+
+		if err := s.db.CreatePrompt(ctx, prompt); err != nil {
+			result.Failed++
+			continue
+		}
+		result.Saved = append(result.Saved, prompt)
+	}
+
+	now := time.Now()
+	recipe.LastRun = &now
+	if err := s.db.UpdateRecipe(ctx, recipe); err != nil {
+		return result, fmt.Errorf("failed to update recipe last run: %w", err)
+	}
+
+	return result, nil
+}
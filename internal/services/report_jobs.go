@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AI2HU/gego/internal/logger"
+)
+
+// ReportJob is a report the scheduler builds and emails on a cron schedule
+type ReportJob struct {
+	Name       string
+	CronExpr   string
+	Window     time.Duration // Period covered by each report
+	Recipients []string
+	Keywords   []string
+}
+
+// ReportWindow returns the time between two consecutive fire times of cronExpr, the period a
+// report sent on that schedule covers by default
+func ReportWindow(cronExpr string, now time.Time) (time.Duration, error) {
+	runs, err := NextRuns(cronExpr, now, 2)
+	if err != nil {
+		return 0, err
+	}
+	if len(runs) < 2 {
+		return 0, fmt.Errorf("cron expression %q does not repeat", cronExpr)
+	}
+	return runs[1].Sub(runs[0]), nil
+}
+
+// SetReports makes the scheduler email jobs through mailer while it runs
+func (s *SchedulerService) SetReports(jobs []ReportJob, mailer *ReportMailer) {
+	s.reportJobs = jobs
+	s.reportMailer = mailer
+}
+
+// registerReports adds the report jobs to cron
+func (s *SchedulerService) registerReports() {
+	for _, job := range s.reportJobs {
+		job := job
+		entryID, err := s.cron.AddFunc(job.CronExpr, func() {
+			if err := s.SendReport(context.Background(), job, time.Now()); err != nil {
+				logger.Error("Failed to send report %s: %v", job.Name, err)
+			}
+		})
+		if err != nil {
+			logger.Error("Failed to register report %s: %v", job.Name, err)
+			continue
+		}
+		s.reportEntries = append(s.reportEntries, entryID)
+		logger.Info("Registered report %s with cron expression: %s (%d recipient(s))", job.Name, job.CronExpr, len(job.Recipients))
+	}
+}
+
+// SendReport builds the report of job for the window ending at end and emails it
+func (s *SchedulerService) SendReport(ctx context.Context, job ReportJob, end time.Time) error {
+	if s.reportMailer == nil {
+		return fmt.Errorf("no SMTP server configured")
+	}
+
+	report, err := NewReportService(s.db).BuildReport(ctx, job.Name, job.Keywords, end.Add(-job.Window), end)
+	if err != nil {
+		return err
+	}
+	if err := s.reportMailer.Send(job.Recipients, report.Subject(), report.Markdown()); err != nil {
+		return err
+	}
+
+	logger.Info("Sent report %s to %d recipient(s)", job.Name, len(job.Recipients))
+	return nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPSettings represents the mail server reports are sent through
+type SMTPSettings struct {
+	Host            string
+	Port            int
+	Username        string
+	Password        string
+	From            string
+	DisableStartTLS bool // Send in plaintext, e.g. to a local relay
+}
+
+// ReportMailer emails rendered reports over SMTP
+type ReportMailer struct {
+	settings SMTPSettings
+}
+
+// NewReportMailer creates a mailer sending through the given SMTP server
+func NewReportMailer(settings SMTPSettings) *ReportMailer {
+	return &ReportMailer{settings: settings}
+}
+
+// Send emails markdown to recipients as a plain text and HTML message. The connection is upgraded
+// with STARTTLS, and sending fails if the server does not offer it unless DisableStartTLS is set.
+func (m *ReportMailer) Send(recipients []string, subject, markdown string) error {
+	if len(recipients) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+
+	message, err := buildReportMessage(m.settings.From, recipients, subject, markdown, time.Now())
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.settings.Host, strconv.Itoa(m.settings.Port))
+	client, err := smtp.Dial(addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+	defer client.Close()
+
+	if !m.settings.DisableStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("SMTP server %s does not support STARTTLS", addr)
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: m.settings.Host}); err != nil {
+			return fmt.Errorf("STARTTLS with %s failed: %w", addr, err)
+		}
+	}
+
+	if m.settings.Username != "" {
+		auth := smtp.PlainAuth("", m.settings.Username, m.settings.Password, m.settings.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(m.settings.From); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM %s rejected: %w", m.settings.From, err)
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("SMTP RCPT TO %s rejected: %w", recipient, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := writer.Write(message); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// buildReportMessage builds a multipart/alternative message with the markdown as plain text and
+// its HTML rendering
+func buildReportMessage(from string, recipients []string, subject, markdown string, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, alternative := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", markdown},
+		{"text/html; charset=utf-8", MarkdownToHTML(markdown)},
+	} {
+		part, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {alternative.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build message: %w", err)
+		}
+		encoder := quotedprintable.NewWriter(part)
+		if _, err := encoder.Write([]byte(alternative.content)); err != nil {
+			return nil, fmt.Errorf("failed to build message: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, fmt.Errorf("failed to build message: %w", err)
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build message: %w", err)
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", from)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
+
+var (
+	// markdownBoldPattern matches **bold** text in escaped HTML
+	markdownBoldPattern = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	// markdownOrderedItemPattern matches the marker of a numbered list item
+	markdownOrderedItemPattern = regexp.MustCompile(`^\d+\. `)
+)
+
+// MarkdownToHTML renders the Markdown subset used by reports (headings, bulleted and numbered
+// lists, bold text and paragraphs) as an HTML document
+func MarkdownToHTML(markdown string) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><body style=\"font-family: sans-serif\">\n")
+
+	openList := ""
+	closeList := func() {
+		if openList != "" {
+			fmt.Fprintf(&b, "</%s>\n", openList)
+			openList = ""
+		}
+	}
+	startList := func(tag string) {
+		if openList != tag {
+			closeList()
+			fmt.Fprintf(&b, "<%s>\n", tag)
+			openList = tag
+		}
+	}
+	inline := func(text string) string {
+		return markdownBoldPattern.ReplaceAllString(html.EscapeString(text), "<strong>$1</strong>")
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		switch {
+		case line == "":
+			closeList()
+		case strings.HasPrefix(line, "### "):
+			closeList()
+			fmt.Fprintf(&b, "<h3>%s</h3>\n", inline(line[4:]))
+		case strings.HasPrefix(line, "## "):
+			closeList()
+			fmt.Fprintf(&b, "<h2>%s</h2>\n", inline(line[3:]))
+		case strings.HasPrefix(line, "# "):
+			closeList()
+			fmt.Fprintf(&b, "<h1>%s</h1>\n", inline(line[2:]))
+		case strings.HasPrefix(line, "- "):
+			startList("ul")
+			fmt.Fprintf(&b, "<li>%s</li>\n", inline(line[2:]))
+		case markdownOrderedItemPattern.MatchString(line):
+			startList("ol")
+			fmt.Fprintf(&b, "<li>%s</li>\n", inline(markdownOrderedItemPattern.ReplaceAllString(line, "")))
+		default:
+			closeList()
+			fmt.Fprintf(&b, "<p>%s</p>\n", inline(line))
+		}
+	}
+	closeList()
+
+	b.WriteString("</body></html>\n")
+	return b.String()
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// reportTopKeywords is the number of top keywords listed in a report
+const reportTopKeywords = 10
+
+// ReportService builds keyword reports over a period
+type ReportService struct {
+	db db.Database
+}
+
+// NewReportService creates a new report service
+func NewReportService(database db.Database) *ReportService {
+	return &ReportService{db: database}
+}
+
+// Report summarizes keyword mentions over a period
+type Report struct {
+	Name        string
+	Start       time.Time
+	End         time.Time
+	Responses   int64
+	TopKeywords []models.KeywordCount
+	Keywords    []*models.KeywordStats // Tracked keywords, in configuration order
+}
+
+// BuildReport gathers the responses, top keywords and tracked keyword stats of [start, end]
+func (s *ReportService) BuildReport(ctx context.Context, name string, keywords []string, start, end time.Time) (*Report, error) {
+	report := &Report{Name: name, Start: start, End: end}
+
+	filter := shared.ResponseFilter{StartTime: &start, EndTime: &end}
+	responses, err := s.db.CountResponses(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count responses: %w", err)
+	}
+	report.Responses = responses
+
+	topKeywords, err := s.db.GetTopKeywords(ctx, reportTopKeywords, &start, &end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top keywords: %w", err)
+	}
+	report.TopKeywords = topKeywords
+
+	for _, keyword := range keywords {
+		stats, err := s.db.SearchKeyword(ctx, keyword, &start, &end, shared.KeywordSearchOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stats of keyword %s: %w", keyword, err)
+		}
+		report.Keywords = append(report.Keywords, stats)
+	}
+
+	return report, nil
+}
+
+// Subject returns the email subject of the report
+func (r *Report) Subject() string {
+	return fmt.Sprintf("Gego report: %s (%s to %s)", r.Name, r.Start.Format("2006-01-02"), r.End.Format("2006-01-02"))
+}
+
+// Markdown renders the report as Markdown
+func (r *Report) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Gego Report: %s\n\n", r.Name)
+	fmt.Fprintf(&b, "**Period:** %s to %s (UTC)\n\n", r.Start.UTC().Format("2006-01-02 15:04"), r.End.UTC().Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "**Responses:** %d\n\n", r.Responses)
+
+	b.WriteString("## Top Keywords\n\n")
+	if len(r.TopKeywords) == 0 {
+		b.WriteString("No keywords mentioned in this period.\n\n")
+	}
+	for i, keyword := range r.TopKeywords {
+		fmt.Fprintf(&b, "%d. **%s**: %d mentions\n", i+1, keyword.Keyword, keyword.Count)
+	}
+	if len(r.TopKeywords) > 0 {
+		b.WriteString("\n")
+	}
+
+	if len(r.Keywords) > 0 {
+		b.WriteString("## Tracked Keywords\n\n")
+	}
+	for _, stats := range r.Keywords {
+		fmt.Fprintf(&b, "### %s\n\n", stats.Keyword)
+		fmt.Fprintf(&b, "- Mentions: %d\n", stats.TotalMentions)
+		fmt.Fprintf(&b, "- Prompts: %d\n", stats.UniquePrompts)
+		fmt.Fprintf(&b, "- LLMs: %d\n", stats.UniqueLLMs)
+		if len(stats.ByProvider) > 0 {
+			fmt.Fprintf(&b, "- By provider: %s\n", formatCounts(stats.ByProvider))
+		}
+		if stats.Truncated {
+			fmt.Fprintf(&b, "- Partial: scan stopped after %d responses\n", stats.Scanned)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// formatCounts formats counts as "name count" pairs, highest first
+func formatCounts(counts map[string]int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s %d", name, counts[name])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// CompareResponses compares the latest responses of two LLMs to a prompt: the mentions of keywords,
+// or of every keyword extracted from either response when none are given, and a sentence-level diff
+func (s *ResponseService) CompareResponses(ctx context.Context, promptID, leftLLMID, rightLLMID string, keywords []string) (*models.ResponseComparison, error) {
+	if promptID == "" {
+		return nil, fmt.Errorf("prompt ID is required")
+	}
+	if leftLLMID == "" || rightLLMID == "" {
+		return nil, fmt.Errorf("two LLM IDs are required")
+	}
+	if leftLLMID == rightLLMID {
+		return nil, fmt.Errorf("LLMs to compare must be different")
+	}
+
+	if _, err := s.db.GetPrompt(ctx, promptID); err != nil {
+		return nil, fmt.Errorf("failed to get prompt: %w", err)
+	}
+
+	left, err := s.latestResponse(ctx, promptID, leftLLMID)
+	if err != nil {
+		return nil, err
+	}
+	right, err := s.latestResponse(ctx, promptID, rightLLMID)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &models.ResponseComparison{
+		PromptID: promptID,
+		Left:     left,
+		Right:    right,
+	}
+
+	leftText, rightText := responseText(left.Response), responseText(right.Response)
+	if len(keywords) == 0 {
+		keywords = unionKeywords(leftText, rightText)
+	}
+	for _, keyword := range keywords {
+		if keyword = strings.TrimSpace(keyword); keyword == "" {
+			continue
+		}
+		comparison.Keywords = append(comparison.Keywords, models.KeywordHits{
+			Keyword: keyword,
+			Left:    shared.CountKeyword(leftText, keyword),
+			Right:   shared.CountKeyword(rightText, keyword),
+		})
+	}
+	sort.SliceStable(comparison.Keywords, func(i, j int) bool {
+		return hitsGap(comparison.Keywords[i]) > hitsGap(comparison.Keywords[j])
+	})
+
+	comparison.Diff = DiffSentences(SplitSentences(leftText), SplitSentences(rightText))
+	return comparison, nil
+}
+
+// latestResponse returns an LLM's most recent response to a prompt, with a nil Response if it has none
+func (s *ResponseService) latestResponse(ctx context.Context, promptID, llmID string) (models.ComparedResponse, error) {
+	compared := models.ComparedResponse{LLMID: llmID, LLMName: llmID}
+
+	responses, err := s.db.ListResponses(ctx, shared.ResponseFilter{PromptID: promptID, LLMID: llmID, Limit: 1})
+	if err != nil {
+		return compared, fmt.Errorf("failed to get responses of LLM %s: %w", llmID, err)
+	}
+	snapshots := make(map[string]models.LLMSnapshot)
+	if len(responses) > 0 {
+		compared.Response = responses[0]
+		snapshots[llmID] = responses[0].LLMSnapshot()
+	}
+	if err := ResolveLLMSnapshots(ctx, s.db, []string{llmID}, snapshots); err != nil {
+		return compared, err
+	}
+	if snapshot, ok := snapshots[llmID]; ok && !snapshot.Empty() {
+		compared.LLMName = snapshot.Name
+		compared.LLMModel = snapshot.Model
+	}
+	return compared, nil
+}
+
+// SplitSentences splits text into trimmed sentences, ending them at ., ! or ? followed by
+// whitespace and at line breaks
+func SplitSentences(text string) []string {
+	var sentences []string
+	var current strings.Builder
+	flush := func() {
+		if sentence := strings.TrimSpace(current.String()); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		current.Reset()
+	}
+
+	runes := []rune(text)
+	for i, r := range runes {
+		if r == '\n' {
+			flush()
+			continue
+		}
+		current.WriteRune(r)
+		if (r == '.' || r == '!' || r == '?') && (i+1 == len(runes) || unicode.IsSpace(runes[i+1])) && !isListNumber(current.String()) {
+			flush()
+		}
+	}
+	flush()
+	return sentences
+}
+
+// isListNumber reports whether s is a list number such as "12." that does not end a sentence
+func isListNumber(s string) bool {
+	s = strings.TrimSuffix(strings.TrimSpace(s), ".")
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// DiffSentences computes a sentence-level diff of left and right from their longest common
+// subsequence. Sentences are matched ignoring case, markdown and whitespace.
+func DiffSentences(left, right []string) []models.SentenceDiffOp {
+	leftKeys, rightKeys := sentenceKeys(left), sentenceKeys(right)
+
+	// lcs[i][j] is the length of the longest common subsequence of left[i:] and right[j:]
+	lcs := make([][]int, len(left)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(right)+1)
+	}
+	for i := len(left) - 1; i >= 0; i-- {
+		for j := len(right) - 1; j >= 0; j-- {
+			if leftKeys[i] == rightKeys[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diff []models.SentenceDiffOp
+	i, j := 0, 0
+	for i < len(left) && j < len(right) {
+		switch {
+		case leftKeys[i] == rightKeys[j]:
+			diff = append(diff, models.SentenceDiffOp{Op: models.DiffEqual, Text: left[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, models.SentenceDiffOp{Op: models.DiffLeft, Text: left[i]})
+			i++
+		default:
+			diff = append(diff, models.SentenceDiffOp{Op: models.DiffRight, Text: right[j]})
+			j++
+		}
+	}
+	for ; i < len(left); i++ {
+		diff = append(diff, models.SentenceDiffOp{Op: models.DiffLeft, Text: left[i]})
+	}
+	for ; j < len(right); j++ {
+		diff = append(diff, models.SentenceDiffOp{Op: models.DiffRight, Text: right[j]})
+	}
+	return diff
+}
+
+// sentenceKeys returns the comparison keys of sentences
+func sentenceKeys(sentences []string) []string {
+	keys := make([]string, len(sentences))
+	for i, sentence := range sentences {
+		keys[i] = strings.ToLower(strings.Join(strings.Fields(shared.NormalizeText(sentence)), " "))
+	}
+	return keys
+}
+
+// unionKeywords returns the keywords extracted from either text, in alphabetical order
+func unionKeywords(texts ...string) []string {
+	seen := make(map[string]bool)
+	var keywords []string
+	for _, text := range texts {
+		for _, word := range shared.ExtractCapitalizedWords(text) {
+			if !seen[word] {
+				seen[word] = true
+				keywords = append(keywords, word)
+			}
+		}
+	}
+	sort.Strings(keywords)
+	return keywords
+}
+
+// hitsGap is how much more one response mentions a keyword than the other
+func hitsGap(hits models.KeywordHits) int {
+	if hits.Left > hits.Right {
+		return hits.Left - hits.Right
+	}
+	return hits.Right - hits.Left
+}
+
+func responseText(response *models.Response) string {
+	if response == nil {
+		return ""
+	}
+	return response.ResponseText
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// duplicateExcerptLength caps the response text shown for a duplicate group
+const duplicateExcerptLength = 200
+
+// FindCrossLLMDuplicates groups the responses to a prompt by content hash and returns the groups
+// answered identically by more than one LLM, most LLMs first. Identical answers from different
+// models usually point to a proxy or base URL serving the wrong model and skew keyword stats.
+func (s *ResponseService) FindCrossLLMDuplicates(ctx context.Context, promptID string) ([]*models.DuplicateGroup, error) {
+	if promptID == "" {
+		return nil, fmt.Errorf("prompt ID is required")
+	}
+	if _, err := s.db.GetPrompt(ctx, promptID); err != nil {
+		return nil, fmt.Errorf("failed to get prompt: %w", err)
+	}
+
+	responses, err := s.db.ListResponses(ctx, shared.ResponseFilter{PromptID: promptID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list responses: %w", err)
+	}
+
+	return crossLLMDuplicates(responses), nil
+}
+
+// crossLLMDuplicates returns the groups of identical response texts produced by more than one LLM
+func crossLLMDuplicates(responses []*models.Response) []*models.DuplicateGroup {
+	groups := make(map[string]*models.DuplicateGroup)
+	llmsByHash := make(map[string]map[string]bool)
+	var order []string
+
+	for _, response := range responses {
+		if response.Error != "" || response.ResponseText == "" {
+			continue
+		}
+
+		hash := shared.ContentHash(response.ResponseText)
+		group := groups[hash]
+		if group == nil {
+			group = &models.DuplicateGroup{
+				ContentHash: hash,
+				Excerpt:     Excerpt(response.ResponseText, duplicateExcerptLength),
+			}
+			groups[hash] = group
+			llmsByHash[hash] = make(map[string]bool)
+			order = append(order, hash)
+		}
+
+		group.ResponseIDs = append(group.ResponseIDs, response.ID)
+		if !llmsByHash[hash][response.LLMID] {
+			llmsByHash[hash][response.LLMID] = true
+			group.LLMIDs = append(group.LLMIDs, response.LLMID)
+			group.LLMNames = append(group.LLMNames, response.LLMName)
+		}
+	}
+
+	var duplicates []*models.DuplicateGroup
+	for _, hash := range order {
+		if group := groups[hash]; len(group.LLMIDs) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		if len(duplicates[i].LLMIDs) != len(duplicates[j].LLMIDs) {
+			return len(duplicates[i].LLMIDs) > len(duplicates[j].LLMIDs)
+		}
+		return len(duplicates[i].ResponseIDs) > len(duplicates[j].ResponseIDs)
+	})
+
+	return duplicates
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestFindCrossLLMDuplicates(t *testing.T) {
+	response := func(id, llmID, text string) *models.Response {
+		return &models.Response{ID: id, PromptID: "prompt-1", LLMID: llmID, LLMName: llmID + " name", ResponseText: text}
+	}
+
+	// The memory database lists responses newest first, i.e. in reverse order of the slice
+	tests := []struct {
+		name      string
+		responses []*models.Response
+		wantLLMs  [][]string
+		wantIDs   [][]string
+	}{
+		{
+			name: "same text from two LLMs",
+			responses: []*models.Response{
+				response("r1", "gpt", "Acme is the best tool."),
+				response("r2", "claude", "Acme is the best tool.\n"),
+				response("r3", "gemini", "Globex is the best tool."),
+			},
+			wantLLMs: [][]string{{"claude", "gpt"}},
+			wantIDs:  [][]string{{"r2", "r1"}},
+		},
+		{
+			name: "same text repeated by one LLM",
+			responses: []*models.Response{
+				response("r1", "gpt", "Acme is the best tool."),
+				response("r2", "gpt", "Acme is the best tool."),
+				response("r3", "claude", "Globex is the best tool."),
+			},
+		},
+		{
+			name: "groups with more LLMs first",
+			responses: []*models.Response{
+				response("r1", "gpt", "Globex"),
+				response("r2", "claude", "Globex"),
+				response("r3", "gpt", "Acme"),
+				response("r4", "claude", "Acme"),
+				response("r5", "gemini", "Acme"),
+			},
+			wantLLMs: [][]string{{"gemini", "claude", "gpt"}, {"claude", "gpt"}},
+			wantIDs:  [][]string{{"r5", "r4", "r3"}, {"r2", "r1"}},
+		},
+		{
+			name: "errors and empty answers are ignored",
+			responses: []*models.Response{
+				response("r1", "gpt", ""),
+				response("r2", "claude", ""),
+				{ID: "r3", PromptID: "prompt-1", LLMID: "gemini", ResponseText: "", Error: "timeout"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := newMemoryDB()
+			database.prompts["prompt-1"] = &models.Prompt{ID: "prompt-1", Template: "What is the best tool?"}
+			database.responses = tt.responses
+
+			groups, err := NewResponseService(database).FindCrossLLMDuplicates(context.Background(), "prompt-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(groups) != len(tt.wantLLMs) {
+				t.Fatalf("got %d duplicate groups, want %d", len(groups), len(tt.wantLLMs))
+			}
+			for i, group := range groups {
+				if !slices.Equal(group.LLMIDs, tt.wantLLMs[i]) || !slices.Equal(group.ResponseIDs, tt.wantIDs[i]) {
+					t.Errorf("group %d = LLMs %v responses %v, want LLMs %v responses %v",
+						i, group.LLMIDs, group.ResponseIDs, tt.wantLLMs[i], tt.wantIDs[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// MaxAnnotationNoteLength caps the note attached to an annotation
+const MaxAnnotationNoteLength = 2000
+
+// ResponseService provides business logic for browsing and reviewing responses
+type ResponseService struct {
+	db db.Database
+}
+
+// NewResponseService creates a new response service
+func NewResponseService(database db.Database) *ResponseService {
+	return &ResponseService{db: database}
+}
+
+// ListResponses lists responses matching the filter
+func (s *ResponseService) ListResponses(ctx context.Context, filter shared.ResponseFilter) ([]*models.Response, error) {
+	return s.db.ListResponses(ctx, filter)
+}
+
+// CountResponses counts responses matching the filter
+func (s *ResponseService) CountResponses(ctx context.Context, filter shared.ResponseFilter) (int64, error) {
+	return s.db.CountResponses(ctx, filter)
+}
+
+// AnnotateResponse labels a response, returning the stored annotation
+func (s *ResponseService) AnnotateResponse(ctx context.Context, responseID, label, note, author string) (*models.Annotation, error) {
+	annotation, err := NewAnnotation(label, note, author)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.AddAnnotation(ctx, responseID, annotation); err != nil {
+		return nil, err
+	}
+	return annotation, nil
+}
+
+// AddAnnotation appends a validated annotation to a response
+func (s *ResponseService) AddAnnotation(ctx context.Context, responseID string, annotation *models.Annotation) error {
+	if err := s.db.AddAnnotation(ctx, responseID, *annotation); err != nil {
+		return fmt.Errorf("failed to annotate response: %w", err)
+	}
+	return nil
+}
+
+// ListAnnotations returns the annotations of a response
+func (s *ResponseService) ListAnnotations(ctx context.Context, responseID string) ([]models.Annotation, error) {
+	annotations, err := s.db.ListAnnotations(ctx, responseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list annotations: %w", err)
+	}
+	return annotations, nil
+}
+
+// NewAnnotation builds a normalized, validated annotation timestamped now
+func NewAnnotation(label, note, author string) (*models.Annotation, error) {
+	annotation := &models.Annotation{
+		Label:     shared.NormalizeLabel(label),
+		Note:      strings.TrimSpace(note),
+		Author:    strings.TrimSpace(author),
+		CreatedAt: time.Now(),
+	}
+
+	if err := ValidateAnnotation(annotation); err != nil {
+		return nil, err
+	}
+	return annotation, nil
+}
+
+// ValidateAnnotation validates an annotation's label and note
+func ValidateAnnotation(annotation *models.Annotation) error {
+	if annotation.Label == "" {
+		return fmt.Errorf("label is required (e.g. %s, %s, %s)", models.LabelHallucination, models.LabelGreatPlacement, models.LabelIrrelevant)
+	}
+	if len(annotation.Label) > 50 {
+		return fmt.Errorf("label must be no more than 50 characters long")
+	}
+	if strings.ContainsAny(annotation.Label, ", ") {
+		return fmt.Errorf("label must not contain spaces or commas: %s", annotation.Label)
+	}
+	if len(annotation.Note) > MaxAnnotationNoteLength {
+		return fmt.Errorf("note must be no more than %d characters long", MaxAnnotationNoteLength)
+	}
+	return nil
+}
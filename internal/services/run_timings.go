@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// runTimings accumulates where the provider calls of one schedule run spent their time
+type runTimings struct {
+	calls     atomic.Int64
+	queueWait atomic.Int64 // nanoseconds waiting for provider rate limiters
+	latency   atomic.Int64 // nanoseconds in provider calls
+}
+
+type runTimingsKey struct{}
+
+// withRunTimings returns a context whose provider calls are recorded in timings
+func withRunTimings(ctx context.Context, timings *runTimings) context.Context {
+	return context.WithValue(ctx, runTimingsKey{}, timings)
+}
+
+// recordRunTimings adds a provider call to the run timings of ctx, if any
+func recordRunTimings(ctx context.Context, queueWait, latency time.Duration) {
+	timings, ok := ctx.Value(runTimingsKey{}).(*runTimings)
+	if !ok {
+		return
+	}
+	timings.calls.Add(1)
+	timings.queueWait.Add(int64(queueWait))
+	timings.latency.Add(int64(latency))
+}
+
+// averages returns the mean queue wait and provider latency of the recorded calls
+func (t *runTimings) averages() (queueWait, latency time.Duration) {
+	calls := t.calls.Load()
+	if calls == 0 {
+		return 0, 0
+	}
+	return time.Duration(t.queueWait.Load() / calls), time.Duration(t.latency.Load() / calls)
+}
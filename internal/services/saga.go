@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AI2HU/gego/internal/logger"
+)
+
+// PendingCreationTag marks the prompts of a creation spanning both stores until it completes, so
+// that prompts left behind by an interrupted creation can be found by gego doctor
+const PendingCreationTag = "gego-pending-creation"
+
+// compensation undoes one completed step of a saga
+type compensation struct {
+	description string
+	undo        func(ctx context.Context) error
+}
+
+// Saga tracks the completed steps of an operation spanning the SQL and NoSQL stores, which share
+// no transaction, so that they can be undone when a later step fails
+type Saga struct {
+	name          string
+	compensations []compensation
+}
+
+// NewSaga creates a saga for the operation described by name
+func NewSaga(name string) *Saga {
+	return &Saga{name: name}
+}
+
+// OnRollback registers how to undo the step that just completed
+func (s *Saga) OnRollback(description string, undo func(ctx context.Context) error) {
+	s.compensations = append(s.compensations, compensation{description: description, undo: undo})
+}
+
+// Rollback undoes the completed steps, newest first, and returns cause. A compensation that fails
+// is logged and joined to the returned error, and does not stop the others. Compensations run even
+// when ctx was cancelled, as cancelling is a common reason for the failure.
+func (s *Saga) Rollback(ctx context.Context, cause error) error {
+	ctx = context.WithoutCancel(ctx)
+
+	var failures []error
+	for i := len(s.compensations) - 1; i >= 0; i-- {
+		step := s.compensations[i]
+		if err := step.undo(ctx); err != nil {
+			logger.Error("Failed to %s while rolling back %s: %v", step.description, s.name, err)
+			failures = append(failures, fmt.Errorf("failed to %s: %w", step.description, err))
+		}
+	}
+	s.compensations = nil
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%w (rollback incomplete, see gego doctor: %w)", cause, errors.Join(failures...))
+	}
+	return cause
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSagaRollback(t *testing.T) {
+	cause := errors.New("database is locked")
+	var undone []string
+	step := func(name string, err error) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			undone = append(undone, name)
+			return err
+		}
+	}
+
+	saga := NewSaga("the test")
+	saga.OnRollback("undo first", step("first", nil))
+	saga.OnRollback("undo second", step("second", errors.New("connection reset")))
+	saga.OnRollback("undo third", step("third", nil))
+
+	// Compensations run newest first, even once the context is cancelled, and a failing one does
+	// not stop the others
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := saga.Rollback(ctx, cause)
+
+	if !slices.Equal(undone, []string{"third", "second", "first"}) {
+		t.Errorf("undone %v, want newest first", undone)
+	}
+	if !errors.Is(err, cause) || !strings.Contains(err.Error(), "failed to undo second: connection reset") {
+		t.Errorf("Rollback() = %v, want the cause and the failed compensation", err)
+	}
+
+	// Compensations run once
+	undone = nil
+	if err := saga.Rollback(context.Background(), cause); err != cause || len(undone) != 0 {
+		t.Errorf("second Rollback() = %v, undone %v, want only the cause", err, undone)
+	}
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScheduleDescriptorExamples are phrases accepted in place of a cron expression
+var ScheduleDescriptorExamples = []string{
+	"hourly",
+	"every 6 hours",
+	"every 15 minutes",
+	"every day at 09:00",
+	"every monday",
+	"every weekday at 8:30am",
+	"every month at noon",
+}
+
+// defaultDescriptorTime is the time of day of day, week and month phrases without "at"
+const defaultDescriptorTime = "0 9"
+
+var descriptorWeekdays = map[string]string{
+	"sunday":    "SUN",
+	"monday":    "MON",
+	"tuesday":   "TUE",
+	"wednesday": "WED",
+	"thursday":  "THU",
+	"friday":    "FRI",
+	"saturday":  "SAT",
+	"weekday":   "MON-FRI",
+	"weekend":   "SAT,SUN",
+}
+
+// ParseScheduleDescriptor turns a cron expression or a human-friendly phrase such as
+// "every day at 09:00" into a normalized cron expression accepted by CronParser
+func ParseScheduleDescriptor(input string) (string, error) {
+	fields := strings.Fields(strings.ToLower(input))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("cron expression is required")
+	}
+
+	if looksLikeCron(fields) {
+		cronExpr := strings.Join(strings.Fields(input), " ")
+		if _, err := ParseCron(cronExpr); err != nil {
+			return "", err
+		}
+		return cronExpr, nil
+	}
+
+	cronExpr, err := parseDescriptorPhrase(fields)
+	if err != nil {
+		return "", fmt.Errorf("%w; use a cron expression such as \"0 9 * * *\" or a phrase such as %s",
+			err, quoteExamples(ScheduleDescriptorExamples))
+	}
+	return cronExpr, nil
+}
+
+// looksLikeCron reports whether fields are meant as a cron expression rather than a phrase
+func looksLikeCron(fields []string) bool {
+	first := fields[0][0]
+	return first == '*' || first == '?' || first == '@' || (first >= '0' && first <= '9')
+}
+
+// parseDescriptorPhrase translates the lowercased words of a schedule phrase into cron
+func parseDescriptorPhrase(fields []string) (string, error) {
+	phrase := strings.Join(fields, " ")
+
+	switch phrase {
+	case "hourly", "every hour":
+		return "0 * * * *", nil
+	case "every minute":
+		return "* * * * *", nil
+	}
+
+	// Split off an optional "at <time>" suffix
+	timeOfDay := defaultDescriptorTime
+	hasTime := false
+	for i, field := range fields {
+		if field == "at" {
+			if i == len(fields)-1 {
+				return "", fmt.Errorf("missing time after \"at\" in %q", phrase)
+			}
+			minuteHour, err := parseDescriptorTime(strings.Join(fields[i+1:], ""))
+			if err != nil {
+				return "", err
+			}
+			timeOfDay = minuteHour
+			hasTime = true
+			fields = fields[:i]
+			break
+		}
+	}
+
+	switch strings.Join(fields, " ") {
+	case "daily", "every day":
+		return timeOfDay + " * * *", nil
+	case "weekly", "every week":
+		return timeOfDay + " * * MON", nil
+	case "monthly", "every month":
+		return timeOfDay + " 1 * *", nil
+	}
+
+	if len(fields) == 2 && fields[0] == "every" {
+		if weekday, ok := descriptorWeekdays[strings.TrimSuffix(fields[1], "s")]; ok {
+			return timeOfDay + " * * " + weekday, nil
+		}
+	}
+
+	if len(fields) == 3 && fields[0] == "every" {
+		interval, err := strconv.Atoi(fields[1])
+		if err != nil || interval < 1 {
+			return "", fmt.Errorf("unrecognized schedule %q", phrase)
+		}
+		if hasTime {
+			return "", fmt.Errorf("%q is ambiguous: an interval cannot also run at a time of day", phrase)
+		}
+		switch strings.TrimSuffix(fields[2], "s") {
+		case "minute":
+			if 60%interval != 0 {
+				return "", fmt.Errorf("every %d minutes is ambiguous: the interval must divide an hour evenly (e.g. 5, 10, 15, 30)", interval)
+			}
+			if interval == 60 {
+				return "0 * * * *", nil
+			}
+			return fmt.Sprintf("*/%d * * * *", interval), nil
+		case "hour":
+			if 24%interval != 0 {
+				return "", fmt.Errorf("every %d hours is ambiguous: the interval must divide a day evenly (e.g. 2, 4, 6, 12)", interval)
+			}
+			if interval == 24 {
+				return "0 0 * * *", nil
+			}
+			return fmt.Sprintf("0 */%d * * *", interval), nil
+		}
+	}
+
+	return "", fmt.Errorf("unrecognized schedule %q", phrase)
+}
+
+// parseDescriptorTime parses a time of day such as 09:00, 9am, 9:30pm, noon or midnight into
+// the cron "minute hour" fields. A bare hour is rejected as ambiguous between am and pm.
+func parseDescriptorTime(value string) (string, error) {
+	switch value {
+	case "noon":
+		return "0 12", nil
+	case "midnight":
+		return "0 0", nil
+	}
+
+	meridiem := ""
+	if strings.HasSuffix(value, "am") || strings.HasSuffix(value, "pm") {
+		meridiem = value[len(value)-2:]
+		value = value[:len(value)-2]
+	}
+
+	hourText, minuteText, hasMinutes := strings.Cut(value, ":")
+	if !hasMinutes && meridiem == "" {
+		return "", fmt.Errorf("time %q is ambiguous: use 24-hour HH:MM (e.g. 09:00) or am/pm (e.g. 9am)", value)
+	}
+
+	hour, err := strconv.Atoi(hourText)
+	if err != nil {
+		return "", fmt.Errorf("invalid time %q", value+meridiem)
+	}
+	minute := 0
+	if hasMinutes {
+		if len(minuteText) != 2 {
+			return "", fmt.Errorf("invalid time %q", value+meridiem)
+		}
+		if minute, err = strconv.Atoi(minuteText); err != nil || minute < 0 || minute > 59 {
+			return "", fmt.Errorf("invalid time %q", value+meridiem)
+		}
+	}
+
+	if meridiem != "" {
+		if hour < 1 || hour > 12 {
+			return "", fmt.Errorf("invalid time %q", value+meridiem)
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	} else if hour < 0 || hour > 23 {
+		return "", fmt.Errorf("invalid time %q", value)
+	}
+
+	return fmt.Sprintf("%d %d", minute, hour), nil
+}
+
+// quoteExamples formats examples as a quoted, comma-separated list
+func quoteExamples(examples []string) string {
+	quoted := make([]string, len(examples))
+	for i, example := range examples {
+		quoted[i] = strconv.Quote(example)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// MaxNextRuns is the number of upcoming fire times that can be listed at once
+const MaxNextRuns = 100
+
+// NextRuns returns the next n fire times of a cron expression after from
+func NextRuns(cronExpr string, from time.Time, n int) ([]time.Time, error) {
+	cronSchedule, err := ParseCron(cronExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]time.Time, 0, n)
+	for next := cronSchedule.Next(from); len(runs) < n && !next.IsZero(); next = cronSchedule.Next(next) {
+		runs = append(runs, next)
+	}
+	return runs, nil
+}
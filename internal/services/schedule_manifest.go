@@ -0,0 +1,461 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+const (
+	// DefaultManifestTemperature is the temperature of manifest schedules that do not set one
+	DefaultManifestTemperature = 0.7
+	// manifestHashLength is the length of the template hashes written by ExportManifest
+	manifestHashLength = 16
+	// minManifestHashLength is the shortest template hash prefix a prompt selector accepts
+	minManifestHashLength = 8
+)
+
+// ScheduleManifest is the declarative form of schedules, kept in a YAML file under version control
+// and applied with gego schedule apply. Prompts and LLMs are selected by content rather than by ID,
+// so a manifest can be applied to any database holding the same prompts and models.
+type ScheduleManifest struct {
+	Schedules []ScheduleSpec `yaml:"schedules"`
+}
+
+// ScheduleSpec is a schedule of a manifest, matched to stored schedules by name
+type ScheduleSpec struct {
+	Name          string         `yaml:"name"`
+	Cron          string         `yaml:"cron"`                  // Cron expression or phrase such as "every day at 09:00"
+	Temperature   *float64       `yaml:"temperature,omitempty"` // Defaults to 0.7
+	Enabled       *bool          `yaml:"enabled,omitempty"`     // Defaults to true
+	Prompts       PromptSelector `yaml:"prompts"`
+	LLMs          LLMSelector    `yaml:"llms"`
+	CatchUpPolicy string         `yaml:"catch_up_policy,omitempty"`
+	Shuffle       bool           `yaml:"shuffle,omitempty"`
+	CheckLanguage bool           `yaml:"check_language,omitempty"`
+	Seed          *int           `yaml:"seed,omitempty"`
+	SampleCount   int            `yaml:"sample_count,omitempty"`
+}
+
+// PromptSelector selects the prompts of a manifest schedule. Tags and hashes add up.
+type PromptSelector struct {
+	All    bool     `yaml:"all,omitempty"`    // Every enabled prompt at fire time
+	Tags   []string `yaml:"tags,omitempty"`   // Prompts carrying any of these tags
+	Hashes []string `yaml:"hashes,omitempty"` // SHA-256 of prompt templates, or prefixes of at least 8 characters
+}
+
+// LLMSelector selects the LLMs of a manifest schedule
+type LLMSelector struct {
+	All   bool       `yaml:"all,omitempty"` // Every enabled LLM at fire time
+	Match []LLMMatch `yaml:"match,omitempty"`
+}
+
+// LLMMatch selects the LLMs of a provider, optionally only those of one model
+type LLMMatch struct {
+	Provider string `yaml:"provider"`
+	Model    string `yaml:"model,omitempty"`
+}
+
+// Actions of a schedule plan
+const (
+	PlanCreate  = "create"
+	PlanUpdate  = "update"
+	PlanDisable = "disable"
+)
+
+// ScheduleChange is what applying a manifest does to one schedule
+type ScheduleChange struct {
+	Action   string
+	Schedule *models.Schedule // Schedule as it will be stored
+	Diff     []string         // Changed fields of an update, such as "cron: 0 9 * * * -> 0 8 * * *"
+}
+
+// SchedulePlan is the changes that make the stored schedules match a manifest
+type SchedulePlan struct {
+	Changes   []ScheduleChange
+	Unchanged []string // Names of the manifest schedules already up to date
+}
+
+// ParseScheduleManifest decodes a manifest from YAML, rejecting fields the format does not define
+func ParseScheduleManifest(data []byte) (*ScheduleManifest, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var manifest ScheduleManifest
+	if err := decoder.Decode(&manifest); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("manifest is empty")
+		}
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// ExportManifest describes the stored schedules as a manifest, selecting prompts by template hash
+// and LLMs by provider and model
+func (s *ScheduleService) ExportManifest(ctx context.Context) (*ScheduleManifest, error) {
+	schedules, err := s.db.ListSchedules(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Name < schedules[j].Name })
+
+	manifest := &ScheduleManifest{Schedules: make([]ScheduleSpec, 0, len(schedules))}
+	for _, schedule := range schedules {
+		temperature := schedule.Temperature
+		enabled := schedule.Enabled
+		spec := ScheduleSpec{
+			Name:          schedule.Name,
+			Cron:          schedule.CronExpr,
+			Temperature:   &temperature,
+			Enabled:       &enabled,
+			Prompts:       PromptSelector{All: schedule.AllPrompts},
+			LLMs:          LLMSelector{All: schedule.AllLLMs},
+			CatchUpPolicy: schedule.CatchUpPolicy,
+			Shuffle:       schedule.Shuffle,
+			CheckLanguage: schedule.CheckLanguage,
+			Seed:          schedule.Seed,
+			SampleCount:   schedule.SampleCount,
+		}
+
+		if !schedule.AllPrompts {
+			prompts, err := s.db.GetPromptsByIDs(ctx, schedule.PromptIDs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get prompts of schedule %s: %w", schedule.Name, err)
+			}
+			for _, id := range schedule.PromptIDs {
+				if prompt, ok := prompts[id]; ok {
+					hash := shared.PromptHash(prompt.Template)[:manifestHashLength]
+					if !slices.Contains(spec.Prompts.Hashes, hash) {
+						spec.Prompts.Hashes = append(spec.Prompts.Hashes, hash)
+					}
+				}
+			}
+		}
+
+		if !schedule.AllLLMs {
+			llms, err := s.db.GetLLMsByIDs(ctx, schedule.LLMIDs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get LLMs of schedule %s: %w", schedule.Name, err)
+			}
+			for _, id := range schedule.LLMIDs {
+				if llm, ok := llms[id]; ok {
+					match := LLMMatch{Provider: llm.Provider, Model: llm.Model}
+					if !slices.Contains(spec.LLMs.Match, match) {
+						spec.LLMs.Match = append(spec.LLMs.Match, match)
+					}
+				}
+			}
+		}
+
+		manifest.Schedules = append(manifest.Schedules, spec)
+	}
+	return manifest, nil
+}
+
+// PlanManifest computes the changes that make the stored schedules match manifest. Manifest
+// schedules are matched to stored ones by name; stored schedules missing from the manifest are
+// disabled, never deleted. Selectors are resolved to prompt and LLM IDs against the database.
+func (s *ScheduleService) PlanManifest(ctx context.Context, manifest *ScheduleManifest) (*SchedulePlan, error) {
+	existing, err := s.db.ListSchedules(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+	byName := make(map[string]*models.Schedule)
+	duplicates := make(map[string]bool)
+	for _, schedule := range existing {
+		if _, ok := byName[schedule.Name]; ok {
+			duplicates[schedule.Name] = true
+		}
+		byName[schedule.Name] = schedule
+	}
+
+	prompts, err := s.db.ListPrompts(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompts: %w", err)
+	}
+	llms, err := s.db.ListLLMs(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LLMs: %w", err)
+	}
+
+	plan := &SchedulePlan{}
+	declared := make(map[string]bool)
+	for i, spec := range manifest.Schedules {
+		name := strings.TrimSpace(spec.Name)
+		if name == "" {
+			return nil, fmt.Errorf("schedules[%d]: name is required", i)
+		}
+		if declared[name] {
+			return nil, fmt.Errorf("schedule %s is declared more than once", name)
+		}
+		declared[name] = true
+		if duplicates[name] {
+			return nil, fmt.Errorf("schedule %s: several stored schedules have this name, rename them before applying", name)
+		}
+
+		current := byName[name]
+		desired, err := s.desiredSchedule(ctx, spec, current, prompts, llms)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %s: %w", name, err)
+		}
+
+		if current == nil {
+			plan.Changes = append(plan.Changes, ScheduleChange{Action: PlanCreate, Schedule: desired})
+			continue
+		}
+		if diff := scheduleDiff(current, desired); len(diff) > 0 {
+			plan.Changes = append(plan.Changes, ScheduleChange{Action: PlanUpdate, Schedule: desired, Diff: diff})
+		} else {
+			plan.Unchanged = append(plan.Unchanged, name)
+		}
+	}
+
+	for _, schedule := range existing {
+		if declared[schedule.Name] || !schedule.Enabled {
+			continue
+		}
+		disabled := *schedule
+		disabled.Enabled = false
+		plan.Changes = append(plan.Changes, ScheduleChange{Action: PlanDisable, Schedule: &disabled, Diff: []string{"enabled: true -> false"}})
+	}
+	return plan, nil
+}
+
+// desiredSchedule builds the schedule spec describes, keeping the ID, run history and the options
+// a manifest does not cover from current when the schedule exists
+func (s *ScheduleService) desiredSchedule(ctx context.Context, spec ScheduleSpec, current *models.Schedule, prompts []*models.Prompt, llms []*models.LLMConfig) (*models.Schedule, error) {
+	cronExpr, err := ParseScheduleDescriptor(spec.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron: %w", err)
+	}
+
+	var schedule models.Schedule
+	if current != nil {
+		schedule = *current
+	} else {
+		schedule = models.Schedule{ID: uuid.New().String(), Name: strings.TrimSpace(spec.Name), Owner: shared.OwnerFromContext(ctx)}
+	}
+	schedule.CronExpr = cronExpr
+	schedule.Temperature = DefaultManifestTemperature
+	if spec.Temperature != nil {
+		schedule.Temperature = *spec.Temperature
+	}
+	schedule.Enabled = spec.Enabled == nil || *spec.Enabled
+	schedule.CatchUpPolicy = spec.CatchUpPolicy
+	schedule.Shuffle = spec.Shuffle
+	schedule.CheckLanguage = spec.CheckLanguage
+	schedule.Seed = spec.Seed
+	schedule.SampleCount = spec.SampleCount
+
+	schedule.AllPrompts = spec.Prompts.All
+	schedule.PromptIDs = nil
+	if !spec.Prompts.All {
+		if schedule.PromptIDs, err = SelectPrompts(spec.Prompts, prompts); err != nil {
+			return nil, err
+		}
+	}
+	schedule.AllLLMs = spec.LLMs.All
+	schedule.LLMIDs = nil
+	if !spec.LLMs.All {
+		if schedule.LLMIDs, err = SelectLLMs(spec.LLMs, llms); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.ValidateSchedule(&schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// SelectPrompts resolves a prompt selector to the IDs of the matching prompts, in the order of
+// prompts. Each tag and hash must match at least one prompt, and a hash prefix must not match
+// different templates.
+func SelectPrompts(selector PromptSelector, prompts []*models.Prompt) ([]string, error) {
+	if len(selector.Tags) == 0 && len(selector.Hashes) == 0 {
+		return nil, fmt.Errorf("prompts: set all, tags or hashes")
+	}
+
+	selected := make(map[string]bool)
+	for _, tag := range selector.Tags {
+		matched := false
+		for _, prompt := range prompts {
+			if slices.ContainsFunc(prompt.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+				selected[prompt.ID] = true
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("prompts: no prompt is tagged %q", tag)
+		}
+	}
+	for _, hash := range selector.Hashes {
+		hash = strings.ToLower(strings.TrimSpace(hash))
+		if len(hash) < minManifestHashLength {
+			return nil, fmt.Errorf("prompts: hash %q is shorter than %d characters", hash, minManifestHashLength)
+		}
+		templates := make(map[string]bool)
+		for _, prompt := range prompts {
+			if full := shared.PromptHash(prompt.Template); strings.HasPrefix(full, hash) {
+				selected[prompt.ID] = true
+				templates[full] = true
+			}
+		}
+		switch {
+		case len(templates) == 0:
+			return nil, fmt.Errorf("prompts: no prompt template has hash %s", hash)
+		case len(templates) > 1:
+			return nil, fmt.Errorf("prompts: hash %s matches %d different templates, use a longer prefix", hash, len(templates))
+		}
+	}
+
+	ids := make([]string, 0, len(selected))
+	for _, prompt := range prompts {
+		if selected[prompt.ID] {
+			ids = append(ids, prompt.ID)
+		}
+	}
+	return ids, nil
+}
+
+// SelectLLMs resolves an LLM selector to the IDs of the matching LLMs, in the order of llms. Each
+// match must select at least one LLM.
+func SelectLLMs(selector LLMSelector, llms []*models.LLMConfig) ([]string, error) {
+	if len(selector.Match) == 0 {
+		return nil, fmt.Errorf("llms: set all or match")
+	}
+
+	selected := make(map[string]bool)
+	for _, match := range selector.Match {
+		if match.Provider == "" {
+			return nil, fmt.Errorf("llms: provider is required in every match")
+		}
+		matched := false
+		for _, llm := range llms {
+			if strings.EqualFold(llm.Provider, match.Provider) && (match.Model == "" || llm.Model == match.Model) {
+				selected[llm.ID] = true
+				matched = true
+			}
+		}
+		if !matched {
+			if match.Model == "" {
+				return nil, fmt.Errorf("llms: no %s LLM is configured", match.Provider)
+			}
+			return nil, fmt.Errorf("llms: no %s LLM uses model %s", match.Provider, match.Model)
+		}
+	}
+
+	ids := make([]string, 0, len(selected))
+	for _, llm := range llms {
+		if selected[llm.ID] {
+			ids = append(ids, llm.ID)
+		}
+	}
+	return ids, nil
+}
+
+// scheduleDiff describes the fields a manifest manages that differ between current and desired
+func scheduleDiff(current, desired *models.Schedule) []string {
+	var diff []string
+	add := func(field string, from, to interface{}) {
+		diff = append(diff, fmt.Sprintf("%s: %v -> %v", field, from, to))
+	}
+
+	if current.CronExpr != desired.CronExpr {
+		add("cron", current.CronExpr, desired.CronExpr)
+	}
+	if current.Temperature != desired.Temperature {
+		add("temperature", current.Temperature, desired.Temperature)
+	}
+	if current.Enabled != desired.Enabled {
+		add("enabled", current.Enabled, desired.Enabled)
+	}
+	if current.AllPrompts != desired.AllPrompts || !sameIDs(current.PromptIDs, desired.PromptIDs) {
+		add("prompts", describeTargets(current.AllPrompts, current.PromptIDs), describeTargets(desired.AllPrompts, desired.PromptIDs))
+	}
+	if current.AllLLMs != desired.AllLLMs || !sameIDs(current.LLMIDs, desired.LLMIDs) {
+		add("llms", describeTargets(current.AllLLMs, current.LLMIDs), describeTargets(desired.AllLLMs, desired.LLMIDs))
+	}
+	if current.CatchUpPolicy != desired.CatchUpPolicy {
+		add("catch_up_policy", current.CatchUpPolicy, desired.CatchUpPolicy)
+	}
+	if current.Shuffle != desired.Shuffle {
+		add("shuffle", current.Shuffle, desired.Shuffle)
+	}
+	if current.CheckLanguage != desired.CheckLanguage {
+		add("check_language", current.CheckLanguage, desired.CheckLanguage)
+	}
+	if !equalSeeds(current.Seed, desired.Seed) {
+		add("seed", describeSeed(current.Seed), describeSeed(desired.Seed))
+	}
+	if current.SampleCount != desired.SampleCount {
+		add("sample_count", current.SampleCount, desired.SampleCount)
+	}
+	return diff
+}
+
+// sameIDs reports whether a and b hold the same IDs in any order
+func sameIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// describeTargets summarizes the prompts or LLMs of a schedule for a plan
+func describeTargets(all bool, ids []string) string {
+	if all {
+		return "all"
+	}
+	return fmt.Sprintf("%d selected", len(ids))
+}
+
+// equalSeeds reports whether two optional seeds are equal
+func equalSeeds(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// describeSeed formats an optional seed for a plan
+func describeSeed(seed *int) string {
+	if seed == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%d", *seed)
+}
+
+// ApplyPlan makes the changes of plan, stopping at the first failure
+func (s *ScheduleService) ApplyPlan(ctx context.Context, plan *SchedulePlan) error {
+	for _, change := range plan.Changes {
+		var err error
+		switch change.Action {
+		case PlanCreate:
+			err = s.CreateSchedule(ctx, change.Schedule)
+		case PlanUpdate:
+			err = s.UpdateSchedule(ctx, change.Schedule)
+		case PlanDisable:
+			err = s.DisableSchedule(ctx, change.Schedule.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s schedule %s: %w", change.Action, change.Schedule.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+var manifestPrompts = []*models.Prompt{
+	{ID: "prompt-1", Template: "Best CRM?", Tags: []string{"crm"}},
+	{ID: "prompt-2", Template: "Best CRM for startups?", Tags: []string{"CRM", "startups"}},
+	{ID: "prompt-3", Template: "Best ERP?", Tags: []string{"erp"}},
+	{ID: "prompt-4", Template: "Best ERP?"},
+}
+
+var manifestLLMs = []*models.LLMConfig{
+	{ID: "llm-1", Provider: "openai", Model: "gpt-4o"},
+	{ID: "llm-2", Provider: "openai", Model: "gpt-4o-mini"},
+	{ID: "llm-3", Provider: "anthropic", Model: "claude"},
+}
+
+func TestSelectPrompts(t *testing.T) {
+	erpHash := shared.PromptHash("Best ERP?")
+
+	tests := []struct {
+		name     string
+		selector PromptSelector
+		want     []string
+		wantErr  string
+	}{
+		{name: "tag matches any case", selector: PromptSelector{Tags: []string{"Crm"}}, want: []string{"prompt-1", "prompt-2"}},
+		{name: "full hash selects every prompt with the template", selector: PromptSelector{Hashes: []string{erpHash}}, want: []string{"prompt-3", "prompt-4"}},
+		{name: "hash prefix", selector: PromptSelector{Hashes: []string{strings.ToUpper(erpHash[:8])}}, want: []string{"prompt-3", "prompt-4"}},
+		{name: "tags and hashes add up", selector: PromptSelector{Tags: []string{"startups"}, Hashes: []string{erpHash[:16]}}, want: []string{"prompt-2", "prompt-3", "prompt-4"}},
+		{name: "short hash", selector: PromptSelector{Hashes: []string{erpHash[:7]}}, wantErr: "shorter than 8"},
+		{name: "unknown hash", selector: PromptSelector{Hashes: []string{"0000000000000000"}}, wantErr: "no prompt template has hash"},
+		{name: "unknown tag", selector: PromptSelector{Tags: []string{"crm", "hr"}}, wantErr: `no prompt is tagged "hr"`},
+		{name: "empty", wantErr: "set all, tags or hashes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectPrompts(tt.selector, manifestPrompts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SelectPrompts() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SelectPrompts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectLLMs(t *testing.T) {
+	tests := []struct {
+		name    string
+		match   []LLMMatch
+		want    []string
+		wantErr string
+	}{
+		{name: "provider", match: []LLMMatch{{Provider: "OpenAI"}}, want: []string{"llm-1", "llm-2"}},
+		{name: "provider and model", match: []LLMMatch{{Provider: "openai", Model: "gpt-4o"}}, want: []string{"llm-1"}},
+		{name: "several matches", match: []LLMMatch{{Provider: "anthropic"}, {Provider: "openai", Model: "gpt-4o-mini"}}, want: []string{"llm-2", "llm-3"}},
+		{name: "unknown model", match: []LLMMatch{{Provider: "openai", Model: "gpt-5"}}, wantErr: "no openai LLM uses model gpt-5"},
+		{name: "unknown provider", match: []LLMMatch{{Provider: "mistral"}}, wantErr: "no mistral LLM is configured"},
+		{name: "missing provider", match: []LLMMatch{{Model: "gpt-4o"}}, wantErr: "provider is required"},
+		{name: "empty", wantErr: "set all or match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectLLMs(LLMSelector{Match: tt.match}, manifestLLMs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SelectLLMs() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SelectLLMs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newManifestDB returns a database holding the manifest prompts and LLMs and the given schedules
+func newManifestDB(schedules ...*models.Schedule) *memoryDB {
+	database := newMemoryDB()
+	for _, prompt := range manifestPrompts {
+		copied := *prompt
+		database.prompts[prompt.ID] = &copied
+	}
+	for _, llmConfig := range manifestLLMs {
+		copied := *llmConfig
+		database.llms[llmConfig.ID] = &copied
+	}
+	for _, schedule := range schedules {
+		database.schedules[schedule.ID] = schedule
+	}
+	return database
+}
+
+func TestApplyManifest(t *testing.T) {
+	database := newManifestDB(
+		&models.Schedule{ID: "schedule-1", Name: "Daily", CronExpr: "0 9 * * *", Temperature: 0.7, Enabled: true, PromptIDs: []string{"prompt-1"}, LLMIDs: []string{"llm-1"}},
+		&models.Schedule{ID: "schedule-2", Name: "Weekly", CronExpr: "0 9 * * MON", Temperature: 0.7, Enabled: true, PromptIDs: []string{"prompt-3"}, LLMIDs: []string{"llm-3"}},
+		&models.Schedule{ID: "schedule-3", Name: "Retired", CronExpr: "0 9 1 * *", Temperature: 0.7, Enabled: false, PromptIDs: []string{"prompt-3"}, LLMIDs: []string{"llm-3"}},
+	)
+	service := NewScheduleService(database)
+	ctx := context.Background()
+
+	manifest, err := ParseScheduleManifest([]byte(`
+schedules:
+  - name: Daily
+    cron: "0 8 * * *"
+    prompts:
+      tags: [crm]
+    llms:
+      match:
+        - provider: openai
+  - name: Nightly
+    cron: "0 2 * * *"
+    temperature: 0.2
+    prompts:
+      hashes: [` + shared.PromptHash("Best ERP?")[:16] + `]
+    llms:
+      match:
+        - provider: anthropic
+          model: claude
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := service.PlanManifest(ctx, manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := make(map[string]ScheduleChange)
+	for _, change := range plan.Changes {
+		changes[change.Schedule.Name] = change
+	}
+	if len(plan.Changes) != 3 || len(plan.Unchanged) != 0 {
+		t.Fatalf("plan = %d changes, unchanged %v, want 3 changes", len(plan.Changes), plan.Unchanged)
+	}
+
+	// Daily is matched by name, keeping its ID
+	daily := changes["Daily"]
+	if daily.Action != PlanUpdate || daily.Schedule.ID != "schedule-1" {
+		t.Errorf("Daily = %s of %s, want an update of schedule-1", daily.Action, daily.Schedule.ID)
+	}
+	if !slices.Equal(daily.Diff, []string{"cron: 0 9 * * * -> 0 8 * * *", "prompts: 1 selected -> 2 selected", "llms: 1 selected -> 2 selected"}) {
+		t.Errorf("Daily diff = %q", daily.Diff)
+	}
+
+	nightly := changes["Nightly"]
+	if nightly.Action != PlanCreate || nightly.Schedule.ID == "" || nightly.Schedule.Temperature != 0.2 || !nightly.Schedule.Enabled {
+		t.Errorf("Nightly = %s %+v, want an enabled schedule created at temperature 0.2", nightly.Action, nightly.Schedule)
+	}
+	if !slices.Equal(nightly.Schedule.PromptIDs, []string{"prompt-3", "prompt-4"}) || !slices.Equal(nightly.Schedule.LLMIDs, []string{"llm-3"}) {
+		t.Errorf("Nightly selects prompts %v and LLMs %v, want [prompt-3 prompt-4] and [llm-3]", nightly.Schedule.PromptIDs, nightly.Schedule.LLMIDs)
+	}
+
+	// A schedule missing from the manifest is disabled, and one already disabled is left alone
+	if weekly := changes["Weekly"]; weekly.Action != PlanDisable || weekly.Schedule.ID != "schedule-2" {
+		t.Errorf("Weekly = %s of %s, want schedule-2 disabled", weekly.Action, weekly.Schedule.ID)
+	}
+	if _, ok := changes["Retired"]; ok {
+		t.Error("plan changes the already disabled Retired schedule")
+	}
+
+	if err := service.ApplyPlan(ctx, plan); err != nil {
+		t.Fatal(err)
+	}
+	if len(database.schedules) != 4 {
+		t.Errorf("stored %d schedules, want 4 with nothing deleted", len(database.schedules))
+	}
+	if stored := database.schedules["schedule-1"]; stored.CronExpr != "0 8 * * *" || !slices.Equal(stored.PromptIDs, []string{"prompt-1", "prompt-2"}) {
+		t.Errorf("Daily stored as %+v", stored)
+	}
+	if database.schedules["schedule-2"].Enabled {
+		t.Error("Weekly still enabled after apply")
+	}
+
+	// Applying the same manifest again changes nothing
+	plan, err = service.PlanManifest(ctx, manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Changes) != 0 || !slices.Equal(plan.Unchanged, []string{"Daily", "Nightly"}) {
+		t.Errorf("second plan = %d changes, unchanged %v, want nothing to change", len(plan.Changes), plan.Unchanged)
+	}
+}
+
+func TestPlanManifestRejectsAmbiguousNames(t *testing.T) {
+	spec := ScheduleSpec{Name: "Daily", Cron: "0 9 * * *", Prompts: PromptSelector{All: true}, LLMs: LLMSelector{All: true}}
+
+	tests := []struct {
+		name      string
+		schedules []*models.Schedule
+		specs     []ScheduleSpec
+		wantErr   string
+	}{
+		{
+			name: "stored twice",
+			schedules: []*models.Schedule{
+				{ID: "schedule-1", Name: "Daily", CronExpr: "0 9 * * *", AllPrompts: true, AllLLMs: true},
+				{ID: "schedule-2", Name: "Daily", CronExpr: "0 8 * * *", AllPrompts: true, AllLLMs: true},
+			},
+			specs:   []ScheduleSpec{spec},
+			wantErr: "several stored schedules have this name",
+		},
+		{name: "declared twice", specs: []ScheduleSpec{spec, spec}, wantErr: "declared more than once"},
+		{name: "no name", specs: []ScheduleSpec{{Cron: "0 9 * * *"}}, wantErr: "name is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewScheduleService(newManifestDB(tt.schedules...))
+			_, err := service.PlanManifest(context.Background(), &ScheduleManifest{Schedules: tt.specs})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("PlanManifest() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,15 +3,27 @@ package services
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/logger"
 	"github.com/AI2HU/gego/internal/models"
 )
 
 // ScheduleService provides business logic for schedule management
 type ScheduleService struct {
-	db db.Database
+	db        db.Database
+	registrar ScheduleRegistrar
+}
+
+// ScheduleRegistrar registers a newly created schedule with an in-process cron, so that creation can
+// be rolled back when the schedule could never run
+type ScheduleRegistrar interface {
+	RegisterNewSchedule(ctx context.Context, schedule *models.Schedule) error
 }
 
 // NewScheduleService creates a new schedule service
@@ -19,45 +31,121 @@ func NewScheduleService(database db.Database) *ScheduleService {
 	return &ScheduleService{db: database}
 }
 
+// SetRegistrar registers the schedules created through this service with registrar
+func (s *ScheduleService) SetRegistrar(registrar ScheduleRegistrar) {
+	s.registrar = registrar
+}
+
 // ValidateSchedule validates schedule configuration
 func (s *ScheduleService) ValidateSchedule(schedule *models.Schedule) error {
 	if schedule.Name == "" {
 		return fmt.Errorf("schedule name is required")
 	}
-	if len(schedule.PromptIDs) == 0 {
+	if !schedule.AllPrompts && len(schedule.PromptIDs) == 0 {
 		return fmt.Errorf("at least one prompt is required")
 	}
-	if len(schedule.LLMIDs) == 0 {
+	if !schedule.AllLLMs && len(schedule.LLMIDs) == 0 {
 		return fmt.Errorf("at least one LLM is required")
 	}
-	if schedule.CronExpr == "" {
-		return fmt.Errorf("cron expression is required")
+	if err := s.ValidateCronExpression(schedule.CronExpr); err != nil {
+		return err
 	}
 	if schedule.Temperature < 0.0 || schedule.Temperature > 1.0 {
 		return fmt.Errorf("temperature must be between 0.0 and 1.0, got: %.2f", schedule.Temperature)
 	}
+	if err := ValidateCatchUpPolicy(schedule.CatchUpPolicy); err != nil {
+		return err
+	}
+	if schedule.CatchUpMax < 0 {
+		return fmt.Errorf("catch-up max must not be negative, got: %d", schedule.CatchUpMax)
+	}
+	if err := ValidatePromptSampling(schedule.PromptWeights, schedule.SampleCount); err != nil {
+		return err
+	}
 
-	for _, promptID := range schedule.PromptIDs {
-		if _, err := s.db.GetPrompt(context.Background(), promptID); err != nil {
-			return fmt.Errorf("prompt %s not found: %w", promptID, err)
+	if !schedule.AllPrompts {
+		for _, promptID := range schedule.PromptIDs {
+			if _, err := s.db.GetPrompt(context.Background(), promptID); err != nil {
+				return fmt.Errorf("prompt %s not found: %w", promptID, err)
+			}
 		}
 	}
 
-	for _, llmID := range schedule.LLMIDs {
-		if _, err := s.db.GetLLM(context.Background(), llmID); err != nil {
-			return fmt.Errorf("LLM %s not found: %w", llmID, err)
+	if !schedule.AllLLMs {
+		for _, llmID := range schedule.LLMIDs {
+			if _, err := s.db.GetLLM(context.Background(), llmID); err != nil {
+				return fmt.Errorf("LLM %s not found: %w", llmID, err)
+			}
 		}
 	}
 
 	return nil
 }
 
-// CreateSchedule creates a new schedule
+// ValidateCatchUpPolicy validates a schedule catch-up policy; empty means none
+func ValidateCatchUpPolicy(policy string) error {
+	switch policy {
+	case "", models.CatchUpNone, models.CatchUpRunOnceOnStart, models.CatchUpBackfillAll:
+		return nil
+	default:
+		return fmt.Errorf("invalid catch-up policy: %s (must be %s, %s or %s)", policy, models.CatchUpNone, models.CatchUpRunOnceOnStart, models.CatchUpBackfillAll)
+	}
+}
+
+// CreateSchedule creates a new schedule with its next run time. Creation is all or nothing: when
+// the registrar fails to register the schedule, its row is deleted again.
 func (s *ScheduleService) CreateSchedule(ctx context.Context, schedule *models.Schedule) error {
 	if err := s.ValidateSchedule(schedule); err != nil {
 		return err
 	}
-	return s.db.CreateSchedule(ctx, schedule)
+	if schedule.NextRun == nil {
+		if nextRuns, err := NextRuns(schedule.CronExpr, time.Now().UTC(), 1); err == nil && len(nextRuns) > 0 {
+			schedule.NextRun = &nextRuns[0]
+		}
+	}
+	if err := s.db.CreateSchedule(ctx, schedule); err != nil {
+		return err
+	}
+
+	if s.registrar == nil {
+		return nil
+	}
+	if err := s.registrar.RegisterNewSchedule(ctx, schedule); err != nil {
+		if deleteErr := s.db.DeleteSchedule(ctx, schedule.ID); deleteErr != nil {
+			return fmt.Errorf("failed to register schedule (%v) and to roll back its creation: %w", err, deleteErr)
+		}
+		return fmt.Errorf("failed to register schedule, creation rolled back: %w", err)
+	}
+	return nil
+}
+
+// CreateScheduleWithPrompts saves new prompts, then creates a schedule running them. The prompts
+// are deleted again when the schedule cannot be created. They carry PendingCreationTag until the
+// schedule exists, so that a creation interrupted in between leaves prompts gego doctor can find.
+func (s *ScheduleService) CreateScheduleWithPrompts(ctx context.Context, schedule *models.Schedule, prompts []*models.Prompt) error {
+	saga := NewSaga("the creation of schedule " + schedule.Name)
+	for _, prompt := range prompts {
+		prompt.Tags = append(prompt.Tags, PendingCreationTag)
+		if err := s.db.CreatePrompt(ctx, prompt); err != nil {
+			return saga.Rollback(ctx, fmt.Errorf("failed to save prompt: %w", err))
+		}
+		id := prompt.ID
+		saga.OnRollback("delete prompt "+id, func(ctx context.Context) error {
+			return s.db.DeletePrompt(ctx, id)
+		})
+	}
+
+	if err := s.CreateSchedule(ctx, schedule); err != nil {
+		return saga.Rollback(ctx, fmt.Errorf("failed to create schedule: %w", err))
+	}
+
+	for _, prompt := range prompts {
+		prompt.Tags = slices.DeleteFunc(prompt.Tags, func(tag string) bool { return tag == PendingCreationTag })
+		if err := s.db.UpdatePrompt(ctx, prompt); err != nil {
+			logger.Warning("Prompt %s keeps its %s tag, clear it with gego doctor --fix: %v", prompt.ID, PendingCreationTag, err)
+		}
+	}
+	return nil
 }
 
 // UpdateSchedule updates an existing schedule
@@ -103,6 +191,66 @@ func (s *ScheduleService) DisableSchedule(ctx context.Context, id string) error
 	return s.db.UpdateSchedule(ctx, schedule)
 }
 
+// ScheduleOverrides holds the fields to change on a cloned schedule; zero values keep the original
+type ScheduleOverrides struct {
+	Name        string
+	CronExpr    string
+	Temperature *float64
+	Enabled     *bool
+}
+
+// CloneSchedule creates a copy of a schedule with a new ID and the given overrides. The copy keeps
+// the prompts, LLMs and run options of the original but none of its run history.
+func (s *ScheduleService) CloneSchedule(ctx context.Context, id string, overrides ScheduleOverrides) (*models.Schedule, error) {
+	original, err := s.db.GetSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := &models.Schedule{
+		ID:            uuid.New().String(),
+		Name:          original.Name + " (copy)",
+		PromptIDs:     slices.Clone(original.PromptIDs),
+		LLMIDs:        slices.Clone(original.LLMIDs),
+		AllPrompts:    original.AllPrompts,
+		AllLLMs:       original.AllLLMs,
+		CronExpr:      original.CronExpr,
+		Temperature:   original.Temperature,
+		Enabled:       original.Enabled,
+		CatchUpPolicy: original.CatchUpPolicy,
+		CatchUpMax:    original.CatchUpMax,
+		Shuffle:       original.Shuffle,
+		CheckLanguage: original.CheckLanguage,
+		PromptWeights: maps.Clone(original.PromptWeights),
+		SampleCount:   original.SampleCount,
+		Owner:         original.Owner,
+	}
+	if original.Seed != nil {
+		seed := *original.Seed
+		clone.Seed = &seed
+	}
+
+	if overrides.Name != "" {
+		clone.Name = overrides.Name
+	}
+	if overrides.CronExpr != "" {
+		if clone.CronExpr, err = ParseScheduleDescriptor(overrides.CronExpr); err != nil {
+			return nil, err
+		}
+	}
+	if overrides.Temperature != nil {
+		clone.Temperature = *overrides.Temperature
+	}
+	if overrides.Enabled != nil {
+		clone.Enabled = *overrides.Enabled
+	}
+
+	if err := s.CreateSchedule(ctx, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
 // GetEnabledSchedules returns only enabled schedules
 func (s *ScheduleService) GetEnabledSchedules(ctx context.Context) ([]*models.Schedule, error) {
 	enabled := true
@@ -135,34 +283,8 @@ func (s *ScheduleService) ValidateCronExpression(cronExpr string) error {
 		return fmt.Errorf("cron expression is required")
 	}
 
-	parts := splitCronExpression(cronExpr)
-	if len(parts) != 5 {
-		return fmt.Errorf("invalid cron expression: %s (must have 5 parts)", cronExpr)
-	}
-
-	return nil
-}
-
-// splitCronExpression splits a cron expression into its components
-func splitCronExpression(cronExpr string) []string {
-	var parts []string
-	var current string
-
-	for _, char := range cronExpr {
-		if char == ' ' {
-			if current != "" {
-				parts = append(parts, current)
-				current = ""
-			}
-		} else {
-			current += string(char)
-		}
-	}
-	if current != "" {
-		parts = append(parts, current)
-	}
-
-	return parts
+	_, err := ParseCron(cronExpr)
+	return err
 }
 
 // GetScheduleExecutionPlan returns the execution plan for a schedule
@@ -180,20 +302,39 @@ func (s *ScheduleService) GetScheduleExecutionPlan(ctx context.Context, schedule
 		LLMs:         make([]*models.LLMConfig, 0, len(schedule.LLMIDs)),
 	}
 
-	for _, promptID := range schedule.PromptIDs {
-		prompt, err := s.db.GetPrompt(ctx, promptID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get prompt %s: %w", promptID, err)
+	enabled := true
+	if schedule.AllPrompts {
+		if plan.Prompts, err = s.db.ListPrompts(ctx, &enabled); err != nil {
+			return nil, fmt.Errorf("failed to list enabled prompts: %w", err)
+		}
+		if len(plan.Prompts) == 0 {
+			logger.Warning("Schedule %s runs all enabled prompts, but none are enabled", schedule.Name)
+		}
+	} else {
+		for _, promptID := range schedule.PromptIDs {
+			prompt, err := s.db.GetPrompt(ctx, promptID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get prompt %s: %w", promptID, err)
+			}
+			plan.Prompts = append(plan.Prompts, prompt)
 		}
-		plan.Prompts = append(plan.Prompts, prompt)
 	}
 
-	for _, llmID := range schedule.LLMIDs {
-		llm, err := s.db.GetLLM(ctx, llmID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get LLM %s: %w", llmID, err)
+	if schedule.AllLLMs {
+		if plan.LLMs, err = s.db.ListLLMs(ctx, &enabled); err != nil {
+			return nil, fmt.Errorf("failed to list enabled LLMs: %w", err)
+		}
+		if len(plan.LLMs) == 0 {
+			logger.Warning("Schedule %s runs all enabled LLMs, but none are enabled", schedule.Name)
+		}
+	} else {
+		for _, llmID := range schedule.LLMIDs {
+			llm, err := s.db.GetLLM(ctx, llmID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get LLM %s: %w", llmID, err)
+			}
+			plan.LLMs = append(plan.LLMs, llm)
 		}
-		plan.LLMs = append(plan.LLMs, llm)
 	}
 
 	return plan, nil
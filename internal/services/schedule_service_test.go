@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// stubRegistrar fails every registration with err, when set
+type stubRegistrar struct {
+	err        error
+	registered []string
+}
+
+func (r *stubRegistrar) RegisterNewSchedule(ctx context.Context, schedule *models.Schedule) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.registered = append(r.registered, schedule.ID)
+	return nil
+}
+
+// undeletableDB fails every schedule deletion
+type undeletableDB struct {
+	*memoryDB
+}
+
+func (u *undeletableDB) DeleteSchedule(ctx context.Context, id string) error {
+	return errors.New("database is locked")
+}
+
+func TestCreateScheduleRollsBack(t *testing.T) {
+	tests := []struct {
+		name         string
+		registrar    *stubRegistrar
+		undeletable  bool
+		wantErr      string
+		wantStored   bool
+		wantRegister bool
+	}{
+		{name: "no registrar", wantStored: true},
+		{name: "registered", registrar: &stubRegistrar{}, wantStored: true, wantRegister: true},
+		{name: "registration fails", registrar: &stubRegistrar{err: errors.New("bad spec")}, wantErr: "creation rolled back"},
+		{
+			name:        "registration and rollback fail",
+			registrar:   &stubRegistrar{err: errors.New("bad spec")},
+			undeletable: true,
+			wantErr:     "failed to register schedule (bad spec) and to roll back its creation",
+			wantStored:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := newMemoryDB()
+			var service *ScheduleService
+			if tt.undeletable {
+				service = NewScheduleService(&undeletableDB{memoryDB: memory})
+			} else {
+				service = NewScheduleService(memory)
+			}
+			if tt.registrar != nil {
+				service.SetRegistrar(tt.registrar)
+			}
+
+			schedule := &models.Schedule{ID: "schedule-1", Name: "Daily", AllPrompts: true, AllLLMs: true, CronExpr: "0 9 * * *", Enabled: true}
+			err := service.CreateSchedule(context.Background(), schedule)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("CreateSchedule: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("CreateSchedule error = %v, want %q", err, tt.wantErr)
+			}
+
+			_, getErr := memory.GetSchedule(context.Background(), schedule.ID)
+			if stored := getErr == nil; stored != tt.wantStored {
+				t.Errorf("schedule stored = %t, want %t", stored, tt.wantStored)
+			}
+			if tt.wantRegister && len(tt.registrar.registered) != 1 {
+				t.Errorf("registered %v, want the new schedule", tt.registrar.registered)
+			}
+			if schedule.NextRun == nil {
+				t.Error("next run not set")
+			}
+		})
+	}
+}
+
+func TestRegisterNewSchedule(t *testing.T) {
+	tests := []struct {
+		name        string
+		running     bool
+		enabled     bool
+		wantEntries int
+	}{
+		{name: "running scheduler registers", running: true, enabled: true, wantEntries: 1},
+		{name: "disabled schedule left to Start", running: true},
+		{name: "stopped scheduler left to Start", enabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := newMemoryDB()
+			scheduler := newTestScheduler(database, &recordingProvider{name: "openai", text: "ok"})
+			if tt.running {
+				if err := scheduler.Start(context.Background()); err != nil {
+					t.Fatalf("Start: %v", err)
+				}
+				defer scheduler.Stop()
+			}
+
+			service := NewScheduleService(database)
+			service.SetRegistrar(scheduler)
+			schedule := &models.Schedule{ID: "schedule-1", Name: "Daily", AllPrompts: true, AllLLMs: true, CronExpr: "0 9 * * *", Enabled: tt.enabled}
+			if err := service.CreateSchedule(context.Background(), schedule); err != nil {
+				t.Fatalf("CreateSchedule: %v", err)
+			}
+
+			scheduler.entriesMu.RLock()
+			entries := len(scheduler.scheduleEntries)
+			scheduler.entriesMu.RUnlock()
+			if entries != tt.wantEntries {
+				t.Errorf("registered %d cron entries, want %d", entries, tt.wantEntries)
+			}
+		})
+	}
+}
+
+func TestCloneSchedule(t *testing.T) {
+	lastRun := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	seed := 7
+	temperature := 0.2
+	disabled := false
+
+	tests := []struct {
+		name            string
+		overrides       ScheduleOverrides
+		wantName        string
+		wantCron        string
+		wantTemperature float64
+		wantEnabled     bool
+		wantErr         bool
+	}{
+		{name: "no overrides", wantName: "Daily (copy)", wantCron: "0 9 * * *", wantTemperature: 0.7, wantEnabled: true},
+		{
+			name:            "overridden fields",
+			overrides:       ScheduleOverrides{Name: "Hourly", CronExpr: "0 * * * *", Temperature: &temperature, Enabled: &disabled},
+			wantName:        "Hourly",
+			wantCron:        "0 * * * *",
+			wantTemperature: 0.2,
+		},
+		{name: "invalid schedule", overrides: ScheduleOverrides{CronExpr: "whenever"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := newMemoryDB()
+			database.prompts["prompt-1"] = &models.Prompt{ID: "prompt-1", Template: "Best tool?"}
+			database.llms["llm-1"] = &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o"}
+			original := &models.Schedule{
+				ID: "schedule-1", Name: "Daily", PromptIDs: []string{"prompt-1"}, LLMIDs: []string{"llm-1"},
+				CronExpr: "0 9 * * *", Temperature: 0.7, Enabled: true, Seed: &seed,
+				PromptWeights: map[string]float64{"prompt-1": 2}, LastRun: &lastRun, MissedRuns: 3, LastError: "bad spec",
+			}
+			database.schedules[original.ID] = original
+
+			clone, err := NewScheduleService(database).CloneSchedule(context.Background(), original.ID, tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CloneSchedule error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if len(database.schedules) != 1 {
+					t.Errorf("stored %d schedules, want only the original", len(database.schedules))
+				}
+				return
+			}
+
+			if clone.ID == original.ID || database.schedules[clone.ID] == nil {
+				t.Fatalf("clone ID = %q, want a new stored schedule", clone.ID)
+			}
+			if clone.Name != tt.wantName || clone.CronExpr != tt.wantCron || clone.Temperature != tt.wantTemperature || clone.Enabled != tt.wantEnabled {
+				t.Errorf("clone = %q %q %v enabled %t, want %q %q %v enabled %t", clone.Name, clone.CronExpr, clone.Temperature,
+					clone.Enabled, tt.wantName, tt.wantCron, tt.wantTemperature, tt.wantEnabled)
+			}
+			if clone.LastRun != nil || clone.MissedRuns != 0 || clone.LastError != "" {
+				t.Errorf("clone kept run history: last run %v, missed %d, last error %q", clone.LastRun, clone.MissedRuns, clone.LastError)
+			}
+
+			// The copy must not share mutable state with the original
+			clone.PromptIDs[0] = "changed"
+			clone.PromptWeights["prompt-1"] = 5
+			*clone.Seed = 8
+			if original.PromptIDs[0] != "prompt-1" || original.PromptWeights["prompt-1"] != 2 || *original.Seed != 7 {
+				t.Error("changing the clone changed the original")
+			}
+		})
+	}
+}
+
+// scheduleFailingDB fails every schedule creation, as the SQL store would after the prompts were
+// saved to the NoSQL store, and optionally the deletion of one prompt
+type scheduleFailingDB struct {
+	*memoryDB
+	undeletablePrompt string
+}
+
+func (f *scheduleFailingDB) CreateSchedule(ctx context.Context, schedule *models.Schedule) error {
+	return errors.New("database is locked")
+}
+
+func (f *scheduleFailingDB) DeletePrompt(ctx context.Context, id string) error {
+	if id == f.undeletablePrompt {
+		return errors.New("connection reset")
+	}
+	return f.memoryDB.DeletePrompt(ctx, id)
+}
+
+func TestCreateScheduleWithPromptsCleansUp(t *testing.T) {
+	tests := []struct {
+		name          string
+		failSchedule  bool
+		undeletable   string
+		wantErr       string
+		wantPrompts   []string
+		wantPending   bool
+		wantSchedules int
+	}{
+		{name: "created", wantPrompts: []string{"prompt-1", "prompt-2"}, wantSchedules: 1},
+		{name: "schedule fails", failSchedule: true, wantErr: "failed to create schedule: database is locked"},
+		{
+			name:         "cleanup fails",
+			failSchedule: true,
+			undeletable:  "prompt-1",
+			wantErr:      "rollback incomplete, see gego doctor",
+			wantPrompts:  []string{"prompt-1"},
+			wantPending:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := newMemoryDB()
+			service := NewScheduleService(memory)
+			if tt.failSchedule {
+				service = NewScheduleService(&scheduleFailingDB{memoryDB: memory, undeletablePrompt: tt.undeletable})
+			}
+
+			prompts := []*models.Prompt{
+				{ID: "prompt-1", Template: "Best CRM?", Tags: []string{"crm"}, Enabled: true},
+				{ID: "prompt-2", Template: "Best ERP?", Enabled: true},
+			}
+			schedule := &models.Schedule{ID: "schedule-1", Name: "Daily", PromptIDs: []string{"prompt-1", "prompt-2"}, AllLLMs: true, CronExpr: "0 9 * * *", Enabled: true}
+			err := service.CreateScheduleWithPrompts(context.Background(), schedule, prompts)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("CreateScheduleWithPrompts: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("CreateScheduleWithPrompts error = %v, want %q", err, tt.wantErr)
+			}
+
+			var stored []string
+			for id, prompt := range memory.prompts {
+				stored = append(stored, id)
+				if pending := slices.Contains(prompt.Tags, PendingCreationTag); pending != tt.wantPending {
+					t.Errorf("prompt %s tags = %v, want pending %t", id, prompt.Tags, tt.wantPending)
+				}
+			}
+			slices.Sort(stored)
+			if !slices.Equal(stored, tt.wantPrompts) {
+				t.Errorf("stored prompts %v, want %v", stored, tt.wantPrompts)
+			}
+			if len(memory.schedules) != tt.wantSchedules {
+				t.Errorf("stored %d schedules, want %d", len(memory.schedules), tt.wantSchedules)
+			}
+		})
+	}
+}
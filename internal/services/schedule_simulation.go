@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// ScheduleSimulation is what a schedule would do if the scheduler ticked at a given instant
+type ScheduleSimulation struct {
+	Schedule *models.Schedule
+	At       time.Time // Instant simulated, in UTC and truncated to the cron precision
+	Fires    bool      // The cron expression fires at At
+	NextFire time.Time // First fire after At
+	// Executions planned for a fire at At, in run order unless shuffled at run time
+	Executions []SimulatedExecution
+	Sampled    bool // Only SampleCount prompts of Executions would be drawn at run time
+	Shuffled   bool // The order of Executions would be randomized at run time
+	Warnings   []string
+}
+
+// SimulatedExecution is a prompt x LLM execution a schedule run would make
+type SimulatedExecution struct {
+	Prompt *models.Prompt
+	LLM    *models.LLMConfig
+}
+
+// CronFiresAt reports whether cronExpr fires at instant at, in UTC. Expressions without a seconds
+// field fire on the minute, so at is compared to the minute; others are compared to the second.
+// @every expressions fire relative to when the scheduler starts and cannot be checked.
+func CronFiresAt(cronExpr string, at time.Time) (bool, time.Time, error) {
+	cronSchedule, err := ParseCron(cronExpr)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	if _, ok := cronSchedule.(cron.ConstantDelaySchedule); ok {
+		return false, time.Time{}, fmt.Errorf("%s fires relative to when the scheduler starts, not at fixed times", cronExpr)
+	}
+
+	at = at.UTC().Truncate(time.Second)
+	if !hasSecondsField(cronExpr) {
+		at = at.Truncate(time.Minute)
+	}
+	fires := cronSchedule.Next(at.Add(-time.Second)).Equal(at)
+	return fires, cronSchedule.Next(at), nil
+}
+
+// hasSecondsField reports whether a cron expression has the optional leading seconds field
+func hasSecondsField(cronExpr string) bool {
+	return !strings.HasPrefix(strings.TrimSpace(cronExpr), "@") && len(strings.Fields(cronExpr)) == 6
+}
+
+// SimulateSchedule checks whether schedule would fire at instant at and lists the executions a run
+// would make with the current prompts and LLMs, without calling any provider or storing anything
+func SimulateSchedule(ctx context.Context, database db.Database, schedule *models.Schedule, at time.Time) (*ScheduleSimulation, error) {
+	fires, nextFire, err := CronFiresAt(schedule.CronExpr, at)
+	if err != nil {
+		return nil, err
+	}
+
+	simulation := &ScheduleSimulation{
+		Schedule: schedule,
+		At:       at.UTC().Truncate(time.Second),
+		Fires:    fires,
+		NextFire: nextFire,
+		Sampled:  schedule.SampleCount > 0,
+		Shuffled: schedule.Shuffle && schedule.Seed == nil,
+	}
+	if !hasSecondsField(schedule.CronExpr) {
+		simulation.At = simulation.At.Truncate(time.Minute)
+	}
+	if !schedule.Enabled {
+		simulation.Warnings = append(simulation.Warnings, "schedule is disabled, the scheduler does not run it")
+	}
+
+	status, err := CheckSchedule(ctx, database, schedule)
+	if err != nil {
+		return nil, err
+	}
+	simulation.Warnings = append(simulation.Warnings, status.ValidationWarnings...)
+
+	prompts, err := simulatedPrompts(ctx, database, schedule)
+	if err != nil {
+		return nil, err
+	}
+	llms, err := simulatedLLMs(ctx, database, schedule)
+	if err != nil {
+		return nil, err
+	}
+	for _, execution := range executionOrder(prompts, llms, schedule.Shuffle && schedule.Seed != nil, orderSeed(schedule.Seed, simulation.At)) {
+		simulation.Executions = append(simulation.Executions, SimulatedExecution{Prompt: execution.prompt, LLM: execution.llm})
+	}
+	return simulation, nil
+}
+
+// simulatedPrompts returns the prompts a run of schedule would use, as schedulePrompts does
+func simulatedPrompts(ctx context.Context, database db.Database, schedule *models.Schedule) ([]*models.Prompt, error) {
+	if schedule.AllPrompts {
+		enabled := true
+		prompts, err := database.ListPrompts(ctx, &enabled)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list enabled prompts: %w", err)
+		}
+		return prompts, nil
+	}
+
+	byID, err := database.GetPromptsByIDs(ctx, schedule.PromptIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompts: %w", err)
+	}
+	prompts := make([]*models.Prompt, 0, len(schedule.PromptIDs))
+	for _, promptID := range schedule.PromptIDs {
+		if prompt, ok := byID[promptID]; ok {
+			prompts = append(prompts, prompt)
+		}
+	}
+	return prompts, nil
+}
+
+// simulatedLLMs returns the enabled LLMs a run of schedule would use, as scheduleLLMs does
+func simulatedLLMs(ctx context.Context, database db.Database, schedule *models.Schedule) ([]*models.LLMConfig, error) {
+	enabled := true
+	if schedule.AllLLMs {
+		llms, err := database.ListLLMs(ctx, &enabled)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list enabled LLMs: %w", err)
+		}
+		return llms, nil
+	}
+
+	llms := make([]*models.LLMConfig, 0, len(schedule.LLMIDs))
+	for _, llmID := range schedule.LLMIDs {
+		llmConfig, err := database.GetLLM(ctx, llmID)
+		if err != nil || !llmConfig.Enabled {
+			continue
+		}
+		llms = append(llms, llmConfig)
+	}
+	return llms, nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestCronFiresAt(t *testing.T) {
+	at := func(hour, minute, second int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, second, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		cronExpr string
+		at       time.Time
+		want     bool
+		wantNext time.Time
+		wantErr  bool
+	}{
+		{name: "fires on the minute", cronExpr: "0 9 * * *", at: at(9, 0, 0), want: true, wantNext: at(9, 0, 0).AddDate(0, 0, 1)},
+		{name: "seconds ignored without a seconds field", cronExpr: "0 9 * * *", at: at(9, 0, 42), want: true, wantNext: at(9, 0, 0).AddDate(0, 0, 1)},
+		{name: "does not fire a minute later", cronExpr: "0 9 * * *", at: at(9, 1, 0), wantNext: at(9, 0, 0).AddDate(0, 0, 1)},
+		{name: "does not fire before", cronExpr: "0 9 * * *", at: at(8, 59, 0), wantNext: at(9, 0, 0)},
+		{name: "seconds field fires on the second", cronExpr: "30 0 9 * * *", at: at(9, 0, 30), want: true, wantNext: at(9, 0, 30).AddDate(0, 0, 1)},
+		{name: "seconds field misses the next second", cronExpr: "30 0 9 * * *", at: at(9, 0, 31), wantNext: at(9, 0, 30).AddDate(0, 0, 1)},
+		{name: "weekday only", cronExpr: "0 9 * * MON", at: at(9, 0, 0), want: true, wantNext: at(9, 0, 0).AddDate(0, 0, 7)},
+		{name: "every", cronExpr: "@every 1h", at: at(9, 0, 0), wantErr: true},
+		{name: "invalid", cronExpr: "61 * * * *", at: at(9, 0, 0), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fires, next, err := CronFiresAt(tt.cronExpr, tt.at)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CronFiresAt(%q) error = %v, wantErr %v", tt.cronExpr, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if fires != tt.want || !next.Equal(tt.wantNext) {
+				t.Errorf("CronFiresAt(%q, %v) = %t, next %v, want %t, next %v", tt.cronExpr, tt.at, fires, next, tt.want, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestSimulateSchedule(t *testing.T) {
+	database := newMemoryDB()
+	database.prompts["prompt-1"] = &models.Prompt{ID: "prompt-1", Template: "Best CRM?", Enabled: true}
+	database.prompts["prompt-2"] = &models.Prompt{ID: "prompt-2", Template: "Best ERP?", Enabled: true}
+	database.llms["llm-1"] = &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", Enabled: true}
+	database.llms["llm-2"] = &models.LLMConfig{ID: "llm-2", Name: "Claude", Provider: "anthropic", Model: "claude", Enabled: false}
+
+	tests := []struct {
+		name           string
+		enabled        bool
+		at             time.Time
+		wantFires      bool
+		wantExecutions int
+		wantWarning    bool
+	}{
+		{name: "fires", enabled: true, at: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), wantFires: true, wantExecutions: 2},
+		{name: "no fire", enabled: true, at: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC), wantExecutions: 2},
+		{name: "disabled schedule warns", at: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), wantFires: true, wantExecutions: 2, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := &models.Schedule{
+				ID: "schedule-1", Name: "Daily", PromptIDs: []string{"prompt-1", "prompt-2"}, LLMIDs: []string{"llm-1", "llm-2"},
+				CronExpr: "0 9 * * *", Enabled: tt.enabled,
+			}
+
+			simulation, err := SimulateSchedule(context.Background(), database, schedule, tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if simulation.Fires != tt.wantFires {
+				t.Errorf("fires = %t, want %t", simulation.Fires, tt.wantFires)
+			}
+			// The disabled LLM is left out of the run
+			if len(simulation.Executions) != tt.wantExecutions {
+				t.Errorf("got %d executions, want %d", len(simulation.Executions), tt.wantExecutions)
+			}
+			for _, execution := range simulation.Executions {
+				if execution.LLM.ID != "llm-1" {
+					t.Errorf("execution with %s, want only the enabled LLM", execution.LLM.ID)
+				}
+			}
+			hasWarning := false
+			for _, warning := range simulation.Warnings {
+				if warning == "schedule is disabled, the scheduler does not run it" {
+					hasWarning = true
+				}
+			}
+			if hasWarning != tt.wantWarning {
+				t.Errorf("warnings = %v, want disabled warning %t", simulation.Warnings, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// ScheduleStatus is the result of checking that a schedule can run
+type ScheduleStatus struct {
+	ScheduleID         string    `json:"schedule_id"`
+	Name               string    `json:"name"`
+	Executable         bool      `json:"executable"` // At least one enabled prompt and one enabled LLM
+	ValidationWarnings []string  `json:"validation_warnings,omitempty"`
+	CheckedAt          time.Time `json:"checked_at"`
+}
+
+// CheckSchedule checks that the prompts and LLMs a schedule references exist, and that at least one
+// of each is enabled. A schedule that is not executable still runs, but makes no provider call.
+func CheckSchedule(ctx context.Context, database db.Database, schedule *models.Schedule) (ScheduleStatus, error) {
+	status := ScheduleStatus{ScheduleID: schedule.ID, Name: schedule.Name, CheckedAt: time.Now()}
+
+	enabledPrompts := 0
+	if schedule.AllPrompts {
+		enabled := true
+		prompts, err := database.ListPrompts(ctx, &enabled)
+		if err != nil {
+			return status, fmt.Errorf("failed to list enabled prompts: %w", err)
+		}
+		enabledPrompts = len(prompts)
+	} else {
+		prompts, err := database.GetPromptsByIDs(ctx, schedule.PromptIDs)
+		if err != nil {
+			return status, fmt.Errorf("failed to get prompts: %w", err)
+		}
+		for _, promptID := range schedule.PromptIDs {
+			prompt, ok := prompts[promptID]
+			switch {
+			case !ok:
+				status.ValidationWarnings = append(status.ValidationWarnings, fmt.Sprintf("prompt %s does not exist", promptID))
+			case !prompt.Enabled:
+				status.ValidationWarnings = append(status.ValidationWarnings, fmt.Sprintf("prompt %s is disabled", promptID))
+			default:
+				enabledPrompts++
+			}
+		}
+	}
+
+	enabledLLMs := 0
+	if schedule.AllLLMs {
+		enabled := true
+		llms, err := database.ListLLMs(ctx, &enabled)
+		if err != nil {
+			return status, fmt.Errorf("failed to list enabled LLMs: %w", err)
+		}
+		enabledLLMs = len(llms)
+	} else {
+		for _, llmID := range schedule.LLMIDs {
+			llmConfig, err := database.GetLLM(ctx, llmID)
+			switch {
+			case err != nil:
+				status.ValidationWarnings = append(status.ValidationWarnings, fmt.Sprintf("LLM %s does not exist", llmID))
+			case !llmConfig.Enabled:
+				status.ValidationWarnings = append(status.ValidationWarnings, fmt.Sprintf("LLM %s (%s) is disabled", llmConfig.Name, llmID))
+			default:
+				enabledLLMs++
+			}
+		}
+	}
+
+	if enabledPrompts == 0 {
+		status.ValidationWarnings = append(status.ValidationWarnings, "no enabled prompt to run")
+	}
+	if enabledLLMs == 0 {
+		status.ValidationWarnings = append(status.ValidationWarnings, "no enabled LLM to run")
+	}
+	status.Executable = enabledPrompts > 0 && enabledLLMs > 0
+	return status, nil
+}
+
+// validateSchedule checks schedule and records its status, logging its warnings and raising an
+// error-level notification when it becomes non-executable
+func (s *SchedulerService) validateSchedule(ctx context.Context, schedule *models.Schedule) {
+	status, err := CheckSchedule(ctx, s.db, schedule)
+	if err != nil {
+		logger.Warning("Failed to validate schedule %s: %v", schedule.Name, err)
+		return
+	}
+
+	s.statusMu.Lock()
+	previous, known := s.statuses[schedule.ID]
+	s.statuses[schedule.ID] = status
+	s.statusMu.Unlock()
+
+	for _, warning := range status.ValidationWarnings {
+		logger.Warning("Schedule %s: %s", schedule.Name, warning)
+	}
+	if !status.Executable && (!known || previous.Executable) {
+		logger.Error("🚨 Schedule %s (%s) cannot run: it has no enabled prompt or no enabled LLM. Its runs make no provider call until it is fixed.", schedule.Name, schedule.ID)
+	}
+}
+
+// revalidateSchedules checks the registered schedules again, as after an LLM changed
+func (s *SchedulerService) revalidateSchedules(ctx context.Context) {
+	s.entriesMu.RLock()
+	ids := make([]string, 0, len(s.scheduleEntries))
+	for id := range s.scheduleEntries {
+		ids = append(ids, id)
+	}
+	s.entriesMu.RUnlock()
+
+	for _, id := range ids {
+		schedule, err := s.db.GetSchedule(ctx, id)
+		if err != nil {
+			logger.Warning("Failed to get schedule %s for validation: %v", id, err)
+			continue
+		}
+		s.validateSchedule(ctx, schedule)
+	}
+}
+
+// registered reports whether the schedule is registered with cron
+func (s *SchedulerService) registered(scheduleID string) bool {
+	s.entriesMu.RLock()
+	defer s.entriesMu.RUnlock()
+	_, ok := s.scheduleEntries[scheduleID]
+	return ok
+}
+
+// pruneStatuses drops the statuses of schedules no longer registered, as after a reload. Statuses
+// survive reloads otherwise, so that a schedule still broken is not reported again.
+func (s *SchedulerService) pruneStatuses() {
+	s.entriesMu.RLock()
+	defer s.entriesMu.RUnlock()
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	for id := range s.statuses {
+		if _, ok := s.scheduleEntries[id]; !ok {
+			delete(s.statuses, id)
+		}
+	}
+}
+
+// ScheduleStatuses returns the last validation of each registered schedule, ordered by name
+func (s *SchedulerService) ScheduleStatuses() []ScheduleStatus {
+	s.statusMu.RLock()
+	defer s.statusMu.RUnlock()
+
+	statuses := make([]ScheduleStatus, 0, len(s.statuses))
+	for _, status := range s.statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
@@ -1,22 +1,27 @@
 package services
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/robfig/cron/v3"
-	"golang.org/x/time/rate"
 
 	"github.com/AI2HU/gego/internal/db"
 	"github.com/AI2HU/gego/internal/llm"
 	"github.com/AI2HU/gego/internal/logger"
 	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+	"github.com/AI2HU/gego/internal/version"
 )
 
 // Retry configuration constants
@@ -30,6 +35,9 @@ const (
 	// 6 requests per minute = 1 request every 10 seconds
 	RequestsPerMinute = 6
 	RateLimitBurst    = 1
+
+	// RateLimitInterval is the time between requests to a provider once its burst is used up
+	RateLimitInterval = time.Minute / RequestsPerMinute
 )
 
 // SchedulerService manages scheduled prompt executions using robfig/cron
@@ -39,18 +47,49 @@ type SchedulerService struct {
 	cron        *cron.Cron
 	running     bool
 	mu          sync.RWMutex
-	// Rate limiters per LLM provider (keyed by provider name)
-	rateLimiters map[string]*rate.Limiter
-	rateMu       sync.RWMutex
+	// Rate limiters per LLM provider
+	rateLimiters *RateLimiters
 	// Track registered schedule IDs for management
 	scheduleEntries map[string]cron.EntryID
 	entriesMu       sync.RWMutex
+	// Last validation of each registered schedule
+	statuses map[string]ScheduleStatus
+	statusMu sync.RWMutex
+	// Serializes the runs of each schedule, so that cron and catch-up runs never overlap
+	runLocks   map[string]*sync.Mutex
+	runLocksMu sync.Mutex
+	// Missed runs are caught up by the first Start only, not by Reload
+	caughtUp bool
+	// Reuse identical responses younger than this duration (0 disables caching)
+	cacheTTL time.Duration
+	// Remove reasoning sections from stored response bodies
+	stripReasoning bool
+	// Ordered transforms applied to response bodies before storage
+	postProcessors *PostProcessorPipeline
+	// Store the raw provider requests and responses in response metadata
+	captureRaw bool
+	// Reports emailed on their own cron schedules
+	reportJobs    []ReportJob
+	reportMailer  *ReportMailer
+	reportEntries []cron.EntryID // Entries of the reports and the stats snapshot job
+	// Stats snapshot taken on its own cron schedule
+	snapshotJob *StatsSnapshotJob
+	// Judge LLM analysis of the responses of each run
+	analysis *AnalysisService
+	// Rebuilds provider clients when an LLM is updated
+	providerFactory ProviderFactory
+	// Consecutive authentication failures after which an LLM is disabled (negative never disables)
+	authFailureThreshold int
 }
 
+// ProviderFactory creates a provider client from an LLM configuration
+type ProviderFactory func(llmConfig *models.LLMConfig) (llm.Provider, error)
+
 // NewSchedulerService creates a new scheduler service with proper cron configuration
 func NewSchedulerService(database db.Database, llmRegistry *llm.Registry) *SchedulerService {
 	c := cron.New(
 		cron.WithLocation(time.UTC),
+		cron.WithParser(CronParser),
 		cron.WithLogger(cron.DefaultLogger),
 		cron.WithChain(
 			cron.Recover(cron.DefaultLogger), // Recover from panics
@@ -61,8 +100,100 @@ func NewSchedulerService(database db.Database, llmRegistry *llm.Registry) *Sched
 		db:              database,
 		llmRegistry:     llmRegistry,
 		cron:            c,
-		rateLimiters:    make(map[string]*rate.Limiter),
+		rateLimiters:    NewRateLimiters(),
 		scheduleEntries: make(map[string]cron.EntryID),
+		statuses:        make(map[string]ScheduleStatus),
+		runLocks:        make(map[string]*sync.Mutex),
+
+		authFailureThreshold: DefaultAuthFailureThreshold,
+	}
+}
+
+// SetResponseCache enables reuse of identical responses younger than ttl; 0 disables it
+func (s *SchedulerService) SetResponseCache(ttl time.Duration) {
+	s.cacheTTL = ttl
+}
+
+// SetStripReasoning removes delimited reasoning sections from stored response bodies, keeping the raw body in metadata
+func (s *SchedulerService) SetStripReasoning(enabled bool) {
+	s.stripReasoning = enabled
+}
+
+// SetPostProcessors applies pipeline to stored response bodies, keeping the raw body in metadata
+func (s *SchedulerService) SetPostProcessors(pipeline *PostProcessorPipeline) {
+	s.postProcessors = pipeline
+}
+
+// SetCaptureRaw stores the raw provider requests and responses, secrets redacted, in response metadata
+func (s *SchedulerService) SetCaptureRaw(enabled bool) {
+	s.captureRaw = enabled
+}
+
+// SetAnalysis has analysis judge the new responses after each schedule run
+func (s *SchedulerService) SetAnalysis(analysis *AnalysisService) {
+	analysis.SetRateLimiters(s.rateLimiters)
+	s.analysis = analysis
+}
+
+// SetProviderFactory rebuilds the registered provider client with factory when an LLM changes
+func (s *SchedulerService) SetProviderFactory(factory ProviderFactory) {
+	s.providerFactory = factory
+}
+
+// InvalidateLLM drops the rate limiter and provider client of the provider of llmConfig once no
+// LLM uses it anymore, and rebuilds the client from the remaining LLMs otherwise. Registered
+// schedules are validated again, as they may have lost their last enabled LLM.
+func (s *SchedulerService) InvalidateLLM(ctx context.Context, llmConfig *models.LLMConfig) {
+	defer s.revalidateSchedules(ctx)
+
+	llms, err := s.db.ListLLMs(ctx, nil)
+	if err != nil {
+		logger.Error("Failed to list LLMs after %s changed: %v", llmConfig.ID, err)
+		return
+	}
+
+	var current *models.LLMConfig
+	for _, candidate := range llms {
+		if candidate.Provider == llmConfig.Provider {
+			current = candidate
+		}
+	}
+	if current == nil {
+		s.rateLimiters.Remove(llmConfig.Provider)
+		s.llmRegistry.Unregister(llmConfig.Provider)
+		logger.Debug("Dropped provider %s: no LLM uses it anymore", llmConfig.Provider)
+		return
+	}
+
+	if s.providerFactory == nil {
+		return
+	}
+	// Like at startup, the last LLM of a provider configures its client
+	provider, err := s.providerFactory(current)
+	if err != nil {
+		logger.Error("Failed to rebuild provider %s: %v", llmConfig.Provider, err)
+		return
+	}
+	s.llmRegistry.Register(provider)
+}
+
+// pruneRateLimiters drops the rate limiters of providers no LLM uses anymore, covering LLMs
+// deleted by another process
+func (s *SchedulerService) pruneRateLimiters(ctx context.Context) {
+	llms, err := s.db.ListLLMs(ctx, nil)
+	if err != nil {
+		logger.Error("Failed to list LLMs: %v", err)
+		return
+	}
+
+	used := make(map[string]bool, len(llms))
+	for _, llmConfig := range llms {
+		used[llmConfig.Provider] = true
+	}
+	for _, provider := range s.rateLimiters.Providers() {
+		if !used[provider] {
+			s.rateLimiters.Remove(provider)
+		}
 	}
 }
 
@@ -99,14 +230,108 @@ func (s *SchedulerService) Start(ctx context.Context) error {
 	if len(schedules) > 0 {
 		logger.Info("Successfully registered %d schedule(s) with cron", registeredCount)
 	}
+	s.pruneStatuses()
+	s.registerReports()
+	s.registerStatsSnapshots()
+
+	// Missed runs are recorded before cron can run the schedules it shares them with
+	if !s.caughtUp {
+		s.caughtUp = true
+		s.catchUpMissedRuns(ctx, schedules)
+	}
 
 	s.cron.Start()
 	s.running = true
 
-	logger.Info("Scheduler started successfully")
+	logger.Info("Scheduler started successfully (%s)", version.Get().String())
 	return nil
 }
 
+// maxCountedMissedRuns bounds how many missed fire times are counted per schedule on start
+const maxCountedMissedRuns = 1000
+
+// catchUpKey marks contexts of catch-up executions with their catchUpRun
+type catchUpKey struct{}
+
+// catchUpRun is the position of a catch-up execution among those of its schedule
+type catchUpRun struct {
+	run, runs int
+}
+
+// catchUpMissedRuns records fire times missed while the scheduler was down and
+// starts catch-up executions according to each schedule's policy
+func (s *SchedulerService) catchUpMissedRuns(ctx context.Context, schedules []*models.Schedule) {
+	now := time.Now()
+
+	for _, schedule := range schedules {
+		missed, err := countMissedRuns(schedule, now)
+		if err != nil {
+			logger.Warning("Failed to check missed runs for schedule %s: %v", schedule.ID, err)
+			continue
+		}
+		if missed == 0 {
+			continue
+		}
+
+		schedule.MissedRuns += missed
+		if err := s.db.UpdateSchedule(ctx, schedule); err != nil {
+			logger.Error("Failed to record missed runs for schedule %s: %v", schedule.ID, err)
+		}
+
+		runs := 0
+		switch schedule.CatchUpPolicy {
+		case models.CatchUpRunOnceOnStart:
+			runs = 1
+		case models.CatchUpBackfillAll:
+			maxRuns := schedule.CatchUpMax
+			if maxRuns <= 0 {
+				maxRuns = models.DefaultCatchUpMax
+			}
+			runs = min(missed, maxRuns)
+		}
+
+		logger.Warning("Schedule %s missed %d run(s) while the scheduler was down (policy: %s, catch-up runs: %d)", schedule.Name, missed, schedule.CatchUpPolicy, runs)
+
+		if runs > 0 {
+			go s.runCatchUp(schedule, runs)
+		}
+	}
+}
+
+// runCatchUp executes a schedule the given number of times, flagging responses as catch-up runs
+func (s *SchedulerService) runCatchUp(schedule *models.Schedule, runs int) {
+	for i := 1; i <= runs; i++ {
+		logger.Info("Executing catch-up run %d/%d for schedule: %s", i, runs, schedule.Name)
+		ctx := context.WithValue(context.Background(), catchUpKey{}, catchUpRun{run: i, runs: runs})
+		if err := s.executeSchedule(ctx, schedule); err != nil {
+			logger.Error("Catch-up run %d/%d for schedule %s failed: %v", i, runs, schedule.ID, err)
+		}
+	}
+}
+
+// countMissedRuns counts the schedule's fire times between its last activity and now
+func countMissedRuns(schedule *models.Schedule, now time.Time) (int, error) {
+	cronSchedule, err := ParseCron(schedule.CronExpr)
+	if err != nil {
+		return 0, err
+	}
+
+	since := schedule.UpdatedAt
+	if schedule.CreatedAt.After(since) {
+		since = schedule.CreatedAt
+	}
+	if schedule.LastRun != nil && schedule.LastRun.After(since) {
+		since = *schedule.LastRun
+	}
+
+	missed := 0
+	for next := cronSchedule.Next(since.UTC()); !next.After(now) && missed < maxCountedMissedRuns; next = cronSchedule.Next(next) {
+		missed++
+	}
+
+	return missed, nil
+}
+
 // Stop stops the scheduler and removes all registered schedules
 func (s *SchedulerService) Stop() {
 	s.mu.Lock()
@@ -123,6 +348,11 @@ func (s *SchedulerService) Stop() {
 	s.scheduleEntries = make(map[string]cron.EntryID)
 	s.entriesMu.Unlock()
 
+	for _, entryID := range s.reportEntries {
+		s.cron.Remove(entryID)
+	}
+	s.reportEntries = nil
+
 	logger.Info("Scheduler stopped")
 }
 
@@ -155,6 +385,7 @@ func (s *SchedulerService) ExecuteNow(ctx context.Context, scheduleID string) er
 
 // ExecutePrompt executes a single prompt with specified LLMs
 func (s *SchedulerService) ExecutePrompt(ctx context.Context, promptID string, llmIDs []string) error {
+	ctx = logger.WithRunID(ctx, uuid.New().String())
 	prompt, err := s.db.GetPrompt(ctx, promptID)
 	if err != nil {
 		return fmt.Errorf("failed to get prompt: %w", err)
@@ -175,7 +406,7 @@ func (s *SchedulerService) ExecutePrompt(ctx context.Context, promptID string, l
 		wg.Add(1)
 		go func(l *models.LLMConfig) {
 			defer wg.Done()
-			if err := s.executePromptWithRetry(ctx, "", prompt, l, 0.7, DefaultMaxRetries, DefaultRetryDelay); err != nil {
+			if err := s.executePromptWithRetry(ctx, "", prompt, l, 0.7, nil, DefaultMaxRetries, DefaultRetryDelay); err != nil {
 				logger.Error("Failed to execute prompt %s with LLM %s after all retries: %v", prompt.ID, l.ID, err)
 			}
 		}(llmConfig)
@@ -192,8 +423,21 @@ func (s *SchedulerService) Reload(ctx context.Context) error {
 	return s.Start(ctx)
 }
 
-// registerSchedule registers a schedule with cron and stores the entry ID
-func (s *SchedulerService) registerSchedule(_ context.Context, schedule *models.Schedule) error {
+// RegisterNewSchedule registers a schedule created while the scheduler runs, so that it runs without
+// a reload. Disabled schedules, and any schedule while the scheduler is stopped, are left to Start.
+func (s *SchedulerService) RegisterNewSchedule(ctx context.Context, schedule *models.Schedule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running || !schedule.Enabled {
+		return nil
+	}
+	return s.registerSchedule(ctx, schedule)
+}
+
+// registerSchedule validates a schedule, registers it with cron and stores the entry ID. Schedules
+// that fail validation are registered anyway, with their warnings recorded in their status.
+func (s *SchedulerService) registerSchedule(ctx context.Context, schedule *models.Schedule) error {
 	jobFunc := func() {
 		logger.Info("Executing scheduled job: %s", schedule.Name)
 		if err := s.executeSchedule(context.Background(), schedule); err != nil {
@@ -203,260 +447,652 @@ func (s *SchedulerService) registerSchedule(_ context.Context, schedule *models.
 
 	entryID, err := s.cron.AddFunc(schedule.CronExpr, jobFunc)
 	if err != nil {
-		return fmt.Errorf("failed to add cron job: %w", err)
+		err = fmt.Errorf("failed to add cron job: %w", err)
+		s.recordScheduleError(ctx, schedule, err.Error())
+		return err
 	}
+	s.recordScheduleError(ctx, schedule, "")
 
 	s.entriesMu.Lock()
 	s.scheduleEntries[schedule.ID] = entryID
 	s.entriesMu.Unlock()
+	s.validateSchedule(ctx, schedule)
 
 	logger.Info("Registered schedule %s with cron expression: %s (Entry ID: %d)", schedule.ID, schedule.CronExpr, entryID)
 	return nil
 }
 
-// executeSchedule executes a schedule
+// recordScheduleError stores the registration error of schedule on its row, or clears it, so that
+// the user who created the schedule sees it in schedule list and get rather than only in the logs
+func (s *SchedulerService) recordScheduleError(ctx context.Context, schedule *models.Schedule, message string) {
+	if schedule.LastError == message {
+		return
+	}
+	if err := s.db.SetScheduleError(ctx, schedule.ID, message); err != nil {
+		logger.Warning("Failed to record the registration error of schedule %s: %v", schedule.ID, err)
+		return
+	}
+	schedule.LastError = message
+}
+
+// runLock returns the mutex serializing the runs of a schedule
+func (s *SchedulerService) runLock(scheduleID string) *sync.Mutex {
+	s.runLocksMu.Lock()
+	defer s.runLocksMu.Unlock()
+	lock, ok := s.runLocks[scheduleID]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.runLocks[scheduleID] = lock
+	}
+	return lock
+}
+
+// executeSchedule executes a schedule, waiting for any run of the same schedule to finish first
 func (s *SchedulerService) executeSchedule(ctx context.Context, schedule *models.Schedule) error {
-	logger.Info("Executing schedule: %s", schedule.ID)
-	logger.Info("Schedule has %d prompts and %d LLMs", len(schedule.PromptIDs), len(schedule.LLMIDs))
+	lock := s.runLock(schedule.ID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Every log line and response of the run carries its ID
+	ctx = logger.WithRunID(ctx, uuid.New().String())
+	logger.InfoContext(ctx, "Executing schedule: %s", schedule.ID)
+	s.pruneRateLimiters(ctx)
+	if s.registered(schedule.ID) {
+		// Prompts and LLMs may have been deleted or disabled by another process since the last check
+		s.validateSchedule(ctx, schedule)
+	}
+
+	prompts := s.schedulePrompts(ctx, schedule)
+	if schedule.SampleCount > 0 && schedule.SampleCount < len(prompts) {
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		prompts = samplePrompts(prompts, schedule.PromptWeights, schedule.SampleCount, rng)
+		logger.InfoContext(ctx, "Sampled %d prompts by weight", len(prompts))
+	}
+	llms := s.scheduleLLMs(ctx, schedule)
+
+	logger.InfoContext(ctx, "Found %d prompts and %d enabled LLMs", len(prompts), len(llms))
+	if len(prompts) == 0 || len(llms) == 0 {
+		logger.WarningContext(ctx, "Schedule %s has no enabled prompts or LLMs to run, skipping this run", schedule.Name)
+	}
+
+	// Responses are attributed to the schedule owner, and cache lookups stay within it
+	ctx = shared.WithOwner(ctx, schedule.Owner)
+	timings := &runTimings{}
+	ctx = withRunTimings(ctx, timings)
+	ctx = withQuotaTracker(ctx, &quotaTracker{})
+	ctx = withAuthFailureTracker(ctx, newAuthFailureTracker())
+	if schedule.CheckLanguage {
+		ctx = withLanguageCheck(ctx)
+	}
 
+	runStart := time.Now()
+	orderSource := orderSeed(schedule.Seed, runStart)
+	executions := executionOrder(prompts, llms, schedule.Shuffle, orderSource)
+	if schedule.Shuffle {
+		logger.DebugContext(ctx, "Shuffled the execution order of %d executions (order seed %d)", len(executions), orderSource)
+	}
+
+	var wg sync.WaitGroup
+	executionCount := 0
+	for _, execution := range executions {
+		wg.Add(1)
+		executionCount++
+		go func(p *models.Prompt, l *models.LLMConfig) {
+			defer wg.Done()
+			logger.DebugContext(ctx, "Executing prompt '%s' with LLM '%s'", p.Template, l.Name)
+
+			currentTemperature := schedule.Temperature
+			if schedule.Temperature == -1.0 { // Special value indicating "random" was selected
+				rand.Seed(time.Now().UnixNano())
+				currentTemperature = rand.Float64()
+				logger.DebugContext(ctx, "Generated random temperature %.1f for prompt '%s'", currentTemperature, p.Template)
+			}
+
+			err := s.executePromptWithRetry(ctx, schedule.ID, p, l, currentTemperature, schedule.Seed, DefaultMaxRetries, DefaultRetryDelay)
+			if errors.Is(err, errQuotaExhausted) || errors.Is(err, errLLMAutoDisabled) {
+				logger.DebugContext(ctx, "Skipped prompt %s with LLM %s: %v", p.ID, l.ID, err)
+			} else if err != nil {
+				logger.ErrorContext(ctx, "Failed to execute prompt %s with LLM %s after all retries: %v", p.ID, l.ID, err)
+			} else {
+				logger.DebugContext(ctx, "Successfully executed prompt %s with LLM %s", p.ID, l.ID)
+			}
+		}(execution.prompt, execution.llm)
+	}
+
+	logger.InfoContext(ctx, "Starting %d concurrent executions", executionCount)
+	wg.Wait()
+	logger.InfoContext(ctx, "Completed %d executions", executionCount)
+	if calls := timings.calls.Load(); calls > 0 {
+		avgQueueWait, avgLatency := timings.averages()
+		logger.InfoContext(ctx, "Schedule %s made %d provider calls: avg provider latency %v, avg queue wait %v", schedule.Name, calls, avgLatency.Round(time.Millisecond), avgQueueWait.Round(time.Millisecond))
+	}
+
+	if flusher, ok := s.db.(db.ResponseFlusher); ok {
+		if err := flusher.FlushResponses(ctx); err != nil {
+			logger.ErrorContext(ctx, "Failed to write batched responses: %v", err)
+		}
+	}
+
+	if s.analysis != nil {
+		s.analyzeRun(ctx, schedule, runStart)
+	}
+
+	now := time.Now()
+	schedule.LastRun = &now
+	if err := s.db.UpdateSchedule(ctx, schedule); err != nil {
+		logger.ErrorContext(ctx, "Failed to update schedule last run: %v", err)
+	}
+
+	logger.InfoContext(ctx, "Completed schedule: %s", schedule.ID)
+	return nil
+}
+
+// analyzeRun has the judge LLM analyze the responses of a schedule run; failures are only logged
+func (s *SchedulerService) analyzeRun(ctx context.Context, schedule *models.Schedule, runStart time.Time) {
+	result, err := s.analysis.AnalyzeSchedule(ctx, schedule.ID, runStart)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to analyze responses of schedule %s: %v", schedule.Name, err)
+		return
+	}
+	logger.InfoContext(ctx, "Analyzed %d response(s) of schedule %s (%d failed, %d tokens)", result.Analyzed, schedule.Name, result.Failed, result.TokensUsed)
+	if result.CapReached {
+		logger.WarningContext(ctx, "Analysis of schedule %s stopped at its cap", schedule.Name)
+	}
+}
+
+// schedulePrompts returns the prompts of a schedule run, or every enabled prompt when AllPrompts is set
+func (s *SchedulerService) schedulePrompts(ctx context.Context, schedule *models.Schedule) []*models.Prompt {
+	if schedule.AllPrompts {
+		enabled := true
+		prompts, err := s.db.ListPrompts(ctx, &enabled)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to list enabled prompts: %v", err)
+			return nil
+		}
+		logger.InfoContext(ctx, "Schedule runs all %d enabled prompts", len(prompts))
+		return prompts
+	}
+
+	logger.InfoContext(ctx, "Schedule has %d prompts", len(schedule.PromptIDs))
 	prompts := make([]*models.Prompt, 0, len(schedule.PromptIDs))
 	for _, promptID := range schedule.PromptIDs {
-		logger.Debug("Getting prompt: %s", promptID)
+		logger.DebugContext(ctx, "Getting prompt: %s", promptID)
 		prompt, err := s.db.GetPrompt(ctx, promptID)
 		if err != nil {
-			logger.Error("Failed to get prompt %s: %v", promptID, err)
+			logger.ErrorContext(ctx, "Failed to get prompt %s: %v", promptID, err)
 			continue
 		}
-		logger.Debug("Retrieved prompt: %s (%s)", prompt.Template, prompt.ID)
+		logger.DebugContext(ctx, "Retrieved prompt: %s (%s)", prompt.Template, prompt.ID)
 		prompts = append(prompts, prompt)
 	}
+	return prompts
+}
+
+// scheduleLLMs returns the enabled LLMs of a schedule run, or every enabled LLM when AllLLMs is set
+func (s *SchedulerService) scheduleLLMs(ctx context.Context, schedule *models.Schedule) []*models.LLMConfig {
+	if schedule.AllLLMs {
+		enabled := true
+		llms, err := s.db.ListLLMs(ctx, &enabled)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to list enabled LLMs: %v", err)
+			return nil
+		}
+		logger.InfoContext(ctx, "Schedule runs all %d enabled LLMs", len(llms))
+		return llms
+	}
 
+	logger.InfoContext(ctx, "Schedule has %d LLMs", len(schedule.LLMIDs))
 	llms := make([]*models.LLMConfig, 0, len(schedule.LLMIDs))
 	for _, llmID := range schedule.LLMIDs {
-		logger.Debug("Getting LLM: %s", llmID)
+		logger.DebugContext(ctx, "Getting LLM: %s", llmID)
 		llmConfig, err := s.db.GetLLM(ctx, llmID)
 		if err != nil {
-			logger.Error("Failed to get LLM %s: %v", llmID, err)
+			logger.ErrorContext(ctx, "Failed to get LLM %s: %v", llmID, err)
 			continue
 		}
 		if !llmConfig.Enabled {
-			logger.Warning("LLM %s is disabled, skipping", llmConfig.Name)
+			logger.WarningContext(ctx, "LLM %s is disabled, skipping", llmConfig.Name)
 			continue
 		}
-		logger.Debug("Retrieved LLM: %s (%s) - API Key: %s", llmConfig.Name, llmConfig.ID, maskAPIKey(llmConfig.APIKey))
+		logger.DebugContext(ctx, "Retrieved LLM: %s (%s) - API Key: %s", llmConfig.Name, llmConfig.ID, cmp.Or(shared.MaskAPIKey(llmConfig.APIKey), shared.APIKeyNotSet))
 		llms = append(llms, llmConfig)
 	}
+	return llms
+}
 
-	logger.Info("Found %d prompts and %d enabled LLMs", len(prompts), len(llms))
+// scheduledExecution is one prompt x LLM execution of a schedule run
+type scheduledExecution struct {
+	prompt *models.Prompt
+	llm    *models.LLMConfig
+}
 
-	var wg sync.WaitGroup
-	executionCount := 0
+// executionOrder returns the executions of a run in prompt order, then LLM order. With shuffle the
+// order is randomized from source, which orderSeed derives for each run.
+func executionOrder(prompts []*models.Prompt, llms []*models.LLMConfig, shuffle bool, source int64) []scheduledExecution {
+	executions := make([]scheduledExecution, 0, len(prompts)*len(llms))
 	for _, prompt := range prompts {
 		for _, llmConfig := range llms {
-			wg.Add(1)
-			executionCount++
-			go func(p *models.Prompt, l *models.LLMConfig) {
-				defer wg.Done()
-				logger.Debug("Executing prompt '%s' with LLM '%s'", p.Template, l.Name)
-
-				currentTemperature := schedule.Temperature
-				if schedule.Temperature == -1.0 { // Special value indicating "random" was selected
-					rand.Seed(time.Now().UnixNano())
-					currentTemperature = rand.Float64()
-					logger.Debug("Generated random temperature %.1f for prompt '%s'", currentTemperature, p.Template)
-				}
-
-				if err := s.executePromptWithRetry(ctx, schedule.ID, p, l, currentTemperature, DefaultMaxRetries, DefaultRetryDelay); err != nil {
-					logger.Error("Failed to execute prompt %s with LLM %s after all retries: %v", p.ID, l.ID, err)
-				} else {
-					logger.Debug("Successfully executed prompt %s with LLM %s", p.ID, l.ID)
-				}
-			}(prompt, llmConfig)
+			executions = append(executions, scheduledExecution{prompt: prompt, llm: llmConfig})
 		}
 	}
 
-	logger.Info("Starting %d concurrent executions", executionCount)
-	wg.Wait()
-	logger.Info("Completed %d executions", executionCount)
-
-	now := time.Now()
-	schedule.LastRun = &now
-	if err := s.db.UpdateSchedule(ctx, schedule); err != nil {
-		logger.Error("Failed to update schedule last run: %v", err)
+	if shuffle {
+		rng := rand.New(rand.NewSource(source))
+		rng.Shuffle(len(executions), func(i, j int) {
+			executions[i], executions[j] = executions[j], executions[i]
+		})
 	}
+	return executions
+}
 
-	logger.Info("Completed schedule: %s", schedule.ID)
-	return nil
+// orderSeed returns the seed of the execution order of a run started at runTime. With a schedule
+// seed it is the seed plus the run's minute, so that every run gets a different order that the
+// seed and run time reproduce; without one it is random.
+func orderSeed(seed *int, runTime time.Time) int64 {
+	if seed == nil {
+		return runTime.UnixNano()
+	}
+	return int64(*seed) + runTime.Truncate(time.Minute).Unix()
 }
 
 // executePromptWithRetry executes a prompt with retry mechanism
-func (s *SchedulerService) executePromptWithRetry(ctx context.Context, scheduleID string, prompt *models.Prompt, llmConfig *models.LLMConfig, temperature float64, maxRetries int, retryDelay time.Duration) error {
+func (s *SchedulerService) executePromptWithRetry(ctx context.Context, scheduleID string, prompt *models.Prompt, llmConfig *models.LLMConfig, temperature float64, seed *int, maxRetries int, retryDelay time.Duration) error {
 	var lastErr error
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		logger.Debug("Attempt %d/%d for prompt '%s' with LLM '%s'", attempt, maxRetries, prompt.Template[:min(50, len(prompt.Template))]+"...", llmConfig.Name)
+		logger.DebugContext(ctx, "Attempt %d/%d for prompt '%s' with LLM '%s'", attempt, maxRetries, prompt.Template[:min(50, len(prompt.Template))]+"...", llmConfig.Name)
 
-		err := s.executePromptWithLLM(ctx, scheduleID, prompt, llmConfig, temperature)
+		err := s.executePromptWithLLM(ctx, scheduleID, prompt, llmConfig, temperature, seed)
 		if err == nil {
 			if attempt > 1 {
-				logger.Info("✅ Prompt execution succeeded on attempt %d after %d previous failures", attempt, attempt-1)
+				logger.InfoContext(ctx, "✅ Prompt execution succeeded on attempt %d after %d previous failures", attempt, attempt-1)
 			}
 			return nil
 		}
 
+		if errors.Is(err, errQuotaExhausted) || errors.Is(err, errLLMAutoDisabled) {
+			return err
+		}
+
 		lastErr = err
-		logger.Warning("❌ Attempt %d/%d failed for prompt '%s' with LLM '%s': %v", attempt, maxRetries, prompt.Template[:min(50, len(prompt.Template))]+"...", llmConfig.Name, err)
+		logger.WarningContext(ctx, "❌ Attempt %d/%d failed for prompt '%s' with LLM '%s': %v", attempt, maxRetries, prompt.Template[:min(50, len(prompt.Template))]+"...", llmConfig.Name, err)
 
 		retryDelayToUse := retryDelay
-		if strings.Contains(err.Error(), "429") || strings.Contains(err.Error(), "quota") || strings.Contains(err.Error(), "rate limit") {
+		switch llm.ClassifyError(err.Error()) {
+		case llm.ErrorClassQuota:
+			reportQuotaExhausted(ctx, llmConfig, err.Error())
+			return fmt.Errorf("not retrying, provider quota exhausted: %w", err)
+		case llm.ErrorClassAuth:
+			return fmt.Errorf("not retrying, authentication failed: %w", err)
+		case llm.ErrorClassRateLimit:
 			retryDelayToUse = 2 * time.Minute // Wait 2 minutes for rate limit errors
-			logger.Info("Rate limit detected, using extended retry delay: %v", retryDelayToUse)
+			logger.InfoContext(ctx, "Rate limit detected, using extended retry delay: %v", retryDelayToUse)
 		}
 
 		if attempt < maxRetries {
-			logger.Info("⏳ Waiting %v before retry attempt %d...", retryDelayToUse, attempt+1)
-			time.Sleep(retryDelayToUse)
+			logger.InfoContext(ctx, "⏳ Waiting %v before retry attempt %d...", retryDelayToUse, attempt+1)
+			if err := sleepContext(ctx, retryDelayToUse); err != nil {
+				return fmt.Errorf("retry cancelled: %w", err)
+			}
 		}
 	}
 
-	logger.Error("💥 All %d attempts failed for prompt '%s' with LLM '%s'. Last error: %v", maxRetries, prompt.Template[:min(50, len(prompt.Template))]+"...", llmConfig.Name, lastErr)
+	logger.ErrorContext(ctx, "💥 All %d attempts failed for prompt '%s' with LLM '%s'. Last error: %v", maxRetries, prompt.Template[:min(50, len(prompt.Template))]+"...", llmConfig.Name, lastErr)
 	return fmt.Errorf("failed after %d attempts, last error: %w", maxRetries, lastErr)
 }
 
-// executePromptWithLLM executes a single prompt with a single LLM
-func (s *SchedulerService) executePromptWithLLM(ctx context.Context, scheduleID string, prompt *models.Prompt, llmConfig *models.LLMConfig, temperature float64) error {
-	logger.Info("Starting execution: prompt='%s' LLM='%s' provider='%s' temperature=%.2f", prompt.Template, llmConfig.Name, llmConfig.Provider, temperature)
+// executePromptWithLLM executes a single prompt with a single LLM; a non-nil seed overrides the LLM's configured seed
+func (s *SchedulerService) executePromptWithLLM(ctx context.Context, scheduleID string, prompt *models.Prompt, llmConfig *models.LLMConfig, temperature float64, seed *int) error {
+	logger.InfoContext(ctx, "Starting execution: prompt='%s' LLM='%s' provider='%s' temperature=%.2f", prompt.Template, llmConfig.Name, llmConfig.Provider, temperature)
 
 	provider, ok := s.llmRegistry.Get(llmConfig.Provider)
 	if !ok {
-		logger.Error("Provider not found: %s", llmConfig.Provider)
+		logger.ErrorContext(ctx, "Provider not found: %s", llmConfig.Provider)
 		return fmt.Errorf("provider not found: %s", llmConfig.Provider)
 	}
-	logger.Debug("Found provider for: %s", llmConfig.Provider)
-
-	rateLimiter := s.getRateLimiter(llmConfig.Provider)
+	logger.DebugContext(ctx, "Found provider for: %s", llmConfig.Provider)
 
-	logger.Debug("Waiting for rate limiter for provider: %s", llmConfig.Provider)
-	if err := rateLimiter.Wait(ctx); err != nil {
-		logger.Error("Rate limiter wait failed: %v", err)
-		return fmt.Errorf("rate limiter wait failed: %w", err)
-	}
+	llmConfigStruct := requestConfig(ctx, provider, llmConfig, temperature, seed)
 
-	llmConfigStruct := llm.Config{
-		Model:       llmConfig.Model,
-		Temperature: temperature,
-		MaxTokens:   1000,
-	}
+	promptText := RenderPrompt(prompt.Template, llmConfig)
+	logger.DebugContext(ctx, "Prepared config for LLM: model=%s temperature=%.2f api_key=%s base_url=%s", llmConfig.Model, temperature, cmp.Or(shared.MaskAPIKey(llmConfig.APIKey), shared.APIKeyNotSet), llmConfig.BaseURL)
 
-	if llmConfig.Config != nil {
-		if tempStr, ok := llmConfig.Config["temperature"]; ok {
-			if temp, err := strconv.ParseFloat(tempStr, 64); err == nil {
-				llmConfigStruct.Temperature = temp
-			}
-		}
-		if maxTokensStr, ok := llmConfig.Config["max_tokens"]; ok {
-			if maxTokens, err := strconv.Atoi(maxTokensStr); err == nil && maxTokens >= 1 {
-				llmConfigStruct.MaxTokens = maxTokens
-			}
-		}
-		if topPStr, ok := llmConfig.Config["top_p"]; ok {
-			if topP, err := strconv.ParseFloat(topPStr, 64); err == nil {
-				llmConfigStruct.TopP = topP
-			}
+	if cached := s.findCachedResponse(ctx, prompt, promptText, llmConfig, temperature, llmConfigStruct); cached != nil {
+		logger.InfoContext(ctx, "[%s] Reusing cached response %s from %s", llmConfig.Name, cached.ID, cached.CreatedAt.Format(time.RFC3339))
+		metadata := map[string]interface{}{
+			"from_cache":         true,
+			"cached_response_id": cached.ID,
 		}
-		if topKStr, ok := llmConfig.Config["top_k"]; ok {
-			if topK, err := strconv.Atoi(topKStr); err == nil {
-				llmConfigStruct.TopK = topK
-			}
+		if citations, ok := cached.Metadata[llm.MetadataCitations]; ok {
+			metadata[llm.MetadataCitations] = citations
 		}
-		if streamStr, ok := llmConfig.Config["stream"]; ok {
-			if stream, err := strconv.ParseBool(streamStr); err == nil {
-				llmConfigStruct.Stream = stream
-			}
+		response := &models.Response{
+			ID:           uuid.New().String(),
+			PromptID:     prompt.ID,
+			PromptText:   promptText,
+			LLMID:        llmConfig.ID,
+			LLMName:      llmConfig.Name,
+			LLMProvider:  llmConfig.Provider,
+			LLMModel:     llmConfig.Model,
+			ResponseText: cached.ResponseText,
+			Temperature:  temperature,
+			Metadata:     promptWrapMetadata(metadata, llmConfig),
+			Grounded:     cached.Grounded,
+			ScheduleID:   scheduleID,
+			Owner:        responseOwner(ctx, prompt, llmConfig),
+			TokensUsed:   0,
+			CreatedAt:    time.Now(),
 		}
+		return s.createResponse(ctx, response)
 	}
 
-	logger.Debug("Prepared config for LLM: model=%s temperature=%.2f api_key=%s base_url=%s", llmConfig.Model, temperature, maskAPIKey(llmConfig.APIKey), llmConfig.BaseURL)
+	logger.DebugContext(ctx, "Waiting for rate limiter for provider: %s", llmConfig.Provider)
+	queueStart := time.Now()
+	if err := s.rateLimiters.Wait(ctx, llmConfig.Provider); err != nil {
+		logger.ErrorContext(ctx, "Rate limiter wait failed: %v", err)
+		return fmt.Errorf("rate limiter wait failed: %w", err)
+	}
+	queueWait := time.Since(queueStart)
+	if quotaExhausted(ctx, llmConfig) {
+		return errQuotaExhausted
+	}
+	if llmAutoDisabled(ctx, llmConfig) {
+		return errLLMAutoDisabled
+	}
+	if queueWait >= time.Second {
+		logger.DebugContext(ctx, "[%s] Waited %v for the %s rate limiter", llmConfig.Name, queueWait.Round(time.Millisecond), llmConfig.Provider)
+	}
 
-	logger.Debug("[%s] Calling LLM provider with prompt: %s", llmConfig.Name, prompt.Template[:min(50, len(prompt.Template))]+"...")
+	logger.DebugContext(ctx, "[%s] Calling LLM provider with prompt: %s", llmConfig.Name, promptText[:min(50, len(promptText))]+"...")
+	ctx, requestID := llm.EnsureRequestID(ctx)
+	captureCtx, capture := startRawCapture(ctx, s.captureRaw, llmConfig)
 	startTime := time.Now()
-	resp, err := provider.Generate(ctx, prompt.Template, llmConfigStruct)
+	resp, err := provider.Generate(captureCtx, promptText, llmConfigStruct)
 	duration := time.Since(startTime)
+	recordRunTimings(ctx, queueWait, duration)
 
 	if err != nil {
-		logger.Error("[%s] LLM call failed after %v: %v", llmConfig.Name, duration, err)
+		logger.ErrorContext(ctx, "[%s] LLM call %s failed after %v: %v", llmConfig.Name, requestID, duration, err)
 		response := &models.Response{
 			ID:          uuid.New().String(),
 			PromptID:    prompt.ID,
-			PromptText:  prompt.Template,
+			PromptText:  promptText,
 			LLMID:       llmConfig.ID,
 			LLMName:     llmConfig.Name,
 			LLMProvider: llmConfig.Provider,
 			LLMModel:    llmConfig.Model,
 			Temperature: temperature,
 			Error:       err.Error(),
+			ErrorClass:  llm.ClassifyError(err.Error()),
+			Metadata:    promptWrapMetadata(rawCaptureMetadata(requestParamsMetadata(map[string]interface{}{llm.MetadataRequestID: requestID}, llmConfigStruct), capture), llmConfig),
 			ScheduleID:  scheduleID,
-			LatencyMs:   time.Since(startTime).Milliseconds(),
+			Owner:       responseOwner(ctx, prompt, llmConfig),
+			LatencyMs:   duration.Milliseconds(),
+			QueueWaitMs: queueWait.Milliseconds(),
 			CreatedAt:   time.Now(),
 		}
-		return s.db.CreateResponse(ctx, response)
+		if response.ErrorClass == llm.ErrorClassQuota {
+			reportQuotaExhausted(ctx, llmConfig, response.Error)
+		}
+		s.recordAuthResult(ctx, llmConfig, response)
+		return s.createResponse(ctx, response)
 	}
 
-	logger.Info("[%s] LLM call succeeded after %v, response length: %d", llmConfig.Name, duration, len(resp.Text))
+	logger.InfoContext(ctx, "[%s] LLM call succeeded after %v (queued %v), response length: %d", llmConfig.Name, duration, queueWait.Round(time.Millisecond), len(resp.Text))
 
 	response := &models.Response{
 		ID:           uuid.New().String(),
 		PromptID:     prompt.ID,
-		PromptText:   prompt.Template,
+		PromptText:   promptText,
 		LLMID:        llmConfig.ID,
 		LLMName:      llmConfig.Name,
 		LLMProvider:  llmConfig.Provider,
 		LLMModel:     llmConfig.Model,
 		ResponseText: resp.Text,
 		Temperature:  temperature,
+		Metadata:     promptWrapMetadata(rawCaptureMetadata(requestParamsMetadata(resp.Metadata, llmConfigStruct), capture), llmConfig),
 		ScheduleID:   scheduleID,
+		Owner:        responseOwner(ctx, prompt, llmConfig),
 		TokensUsed:   resp.TokensUsed,
 		LatencyMs:    resp.LatencyMs,
+		QueueWaitMs:  queueWait.Milliseconds(),
 		Error:        resp.Error,
+		ErrorClass:   llm.ClassifyError(resp.Error),
+		Grounded:     resp.Grounded,
 		CreatedAt:    time.Now(),
 	}
+	if response.ErrorClass == llm.ErrorClassQuota {
+		reportQuotaExhausted(ctx, llmConfig, response.Error)
+	}
+	s.recordAuthResult(ctx, llmConfig, response)
+	if s.stripReasoning {
+		stripResponseReasoning(response)
+	}
+	postProcessResponse(response, s.postProcessors)
+	if languageCheckEnabled(ctx) {
+		checkResponseLanguage(ctx, prompt, response)
+	}
 
-	return s.db.CreateResponse(ctx, response)
+	return s.createResponse(ctx, response)
 }
 
-// getRateLimiter gets or creates a rate limiter for the given provider
-func (s *SchedulerService) getRateLimiter(provider string) *rate.Limiter {
-	s.rateMu.RLock()
-	limiter, exists := s.rateLimiters[provider]
-	s.rateMu.RUnlock()
+// metadataCacheKey is the metadata key of the requestCacheKey of the request a response answered
+const metadataCacheKey = "cache_key"
 
-	if exists {
-		return limiter
+// requestParamsMetadata records request parameters worth keeping alongside a response
+func requestParamsMetadata(metadata map[string]interface{}, config llm.Config) map[string]interface{} {
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[metadataCacheKey] = requestCacheKey(config)
+	if config.Seed != nil {
+		metadata["seed"] = *config.Seed
 	}
+	return metadata
+}
 
-	s.rateMu.Lock()
-	defer s.rateMu.Unlock()
+// responseOwner attributes a response to the schedule owner in ctx, falling back to the prompt and LLM owners
+func responseOwner(ctx context.Context, prompt *models.Prompt, llmConfig *models.LLMConfig) string {
+	if owner := shared.OwnerFromContext(ctx); owner != "" {
+		return owner
+	}
+	if prompt.Owner != "" {
+		return prompt.Owner
+	}
+	return llmConfig.Owner
+}
 
-	if limiter, exists := s.rateLimiters[provider]; exists {
-		return limiter
+// requestConfig maps the settings of an LLM config onto a provider request. Malformed values and
+// settings the provider does not support are ignored with a warning; a non-nil seed overrides the
+// LLM's configured seed.
+func requestConfig(ctx context.Context, provider llm.Provider, llmConfig *models.LLMConfig, temperature float64, seed *int) llm.Config {
+	config := llm.Config{
+		Model:       llmConfig.Model,
+		Temperature: temperature,
+		MaxTokens:   MaxCompletionTokens(llmConfig),
 	}
 
-	limiter = rate.NewLimiter(rate.Every(time.Minute/RequestsPerMinute), RateLimitBurst)
-	s.rateLimiters[provider] = limiter
-	return limiter
+	if llmConfig.Config != nil {
+		if tempStr, ok := llmConfig.Config["temperature"]; ok {
+			if temp, err := strconv.ParseFloat(tempStr, 64); err == nil {
+				config.Temperature = temp
+			}
+		}
+		if topPStr, ok := llmConfig.Config["top_p"]; ok {
+			if topP, err := strconv.ParseFloat(topPStr, 64); err == nil {
+				config.TopP = topP
+			}
+		}
+		if topKStr, ok := llmConfig.Config["top_k"]; ok {
+			if topK, err := strconv.Atoi(topKStr); err == nil {
+				config.TopK = topK
+			}
+		}
+		if streamStr, ok := llmConfig.Config["stream"]; ok {
+			if stream, err := strconv.ParseBool(streamStr); err == nil {
+				config.Stream = stream
+			}
+		}
+		if seedStr, ok := llmConfig.Config["seed"]; ok && seed == nil {
+			if configSeed, err := strconv.Atoi(seedStr); err == nil {
+				seed = &configSeed
+			}
+		}
+		if stopValue, ok := llmConfig.Config["stop_sequences"]; ok {
+			stops, err := llm.ParseStopSequences(stopValue)
+			if err == nil {
+				err = llm.ValidateStopSequences(provider, stops)
+			}
+			if err != nil {
+				logger.WarningContext(ctx, "[%s] Ignoring stop_sequences: %v", llmConfig.Name, err)
+			} else {
+				config.StopSequences = stops
+			}
+		}
+		if reasoningStr, ok := llmConfig.Config["reasoning"]; ok {
+			if reasoning, err := strconv.ParseBool(reasoningStr); err == nil {
+				config.Reasoning = reasoning
+			}
+		}
+		if effortValue, ok := llmConfig.Config["reasoning_effort"]; ok {
+			if effort, err := llm.ParseReasoningEffort(effortValue); err != nil {
+				logger.WarningContext(ctx, "[%s] Ignoring reasoning_effort: %v", llmConfig.Name, err)
+			} else {
+				config.ReasoningEffort = effort
+			}
+		}
+		if webSearchStr, ok := llmConfig.Config["web_search"]; ok {
+			if webSearch, err := strconv.ParseBool(webSearchStr); err == nil && webSearch {
+				if llm.GetCapabilities(provider).WebSearch {
+					config.WebSearch = true
+				} else {
+					logger.WarningContext(ctx, "[%s] Provider %s does not support web search, ignoring web_search", llmConfig.Name, llmConfig.Provider)
+				}
+			}
+		}
+		if format, ok := llmConfig.Config["response_format"]; ok {
+			if err := llm.ValidateResponseFormat(provider, format); err != nil {
+				logger.WarningContext(ctx, "[%s] Ignoring response_format: %v", llmConfig.Name, err)
+			} else {
+				config.ResponseFormat = format
+			}
+		}
+	}
+
+	if seed != nil {
+		if llm.GetCapabilities(provider).Seed {
+			config.Seed = seed
+		} else {
+			logger.WarningContext(ctx, "[%s] Provider %s does not support seed, ignoring seed %d", llmConfig.Name, llmConfig.Provider, *seed)
+		}
+	}
+
+	return config
 }
 
-// Helper functions
-func maskAPIKey(apiKey string) string {
-	if apiKey == "" {
-		return "(not set)"
+// createResponse stores a response with the ID of its run, flagging it when produced by a catch-up run
+func (s *SchedulerService) createResponse(ctx context.Context, response *models.Response) error {
+	response.RunID = logger.RunIDFromContext(ctx)
+	if catchUp, ok := ctx.Value(catchUpKey{}).(catchUpRun); ok {
+		if response.Metadata == nil {
+			response.Metadata = make(map[string]interface{})
+		}
+		response.Metadata["catch_up"] = true
+		response.Metadata["catch_up_run"] = fmt.Sprintf("%d/%d", catchUp.run, catchUp.runs)
+	}
+
+	return s.db.CreateResponse(ctx, response)
+}
+
+// findCachedResponse returns a fresh response to the same request, if caching is enabled. Responses
+// still waiting to be written by response batching are checked first.
+func (s *SchedulerService) findCachedResponse(ctx context.Context, prompt *models.Prompt, promptText string, llmConfig *models.LLMConfig, temperature float64, config llm.Config) *models.Response {
+	if s.cacheTTL <= 0 {
+		return nil
+	}
+
+	since := time.Now().Add(-s.cacheTTL)
+	key := requestCacheKey(config)
+	reusable := func(candidate *models.Response) bool {
+		if candidate.PromptID != prompt.ID || candidate.CreatedAt.Before(since) {
+			return false
+		}
+		if config.WebSearch && !candidate.Grounded {
+			// Answers generated without a web search do not stand in for grounded ones
+			return false
+		}
+		if candidate.LLMProvider != llmConfig.Provider || candidate.LLMModel != llmConfig.Model {
+			return false
+		}
+		if !matchesPrompt(candidate, promptText) || candidate.Temperature != temperature {
+			return false
+		}
+		if candidateKey, _ := candidate.Metadata[metadataCacheKey].(string); candidateKey != key {
+			return false
+		}
+		if candidate.Error != "" || candidate.ResponseText == "" {
+			return false
+		}
+		fromCache, _ := candidate.Metadata["from_cache"].(bool)
+		return !fromCache
+	}
+
+	// Responses of the current run may still wait in the batch of unwritten responses
+	if lister, ok := s.db.(db.PendingResponseLister); ok {
+		for _, candidate := range lister.PendingResponses() {
+			if reusable(candidate) {
+				return candidate
+			}
+		}
 	}
-	if len(apiKey) <= 8 {
-		return "***"
+
+	// The query matches everything but the prompt text and temperature, so the latest match is the only candidate
+	hasError := false
+	filter := shared.ResponseFilter{
+		PromptID:      prompt.ID,
+		LLMID:         llmConfig.ID,
+		Model:         llmConfig.Model,
+		CacheKey:      key,
+		ExcludeCached: true,
+		HasError:      &hasError,
+		StartTime:     &since,
+		Limit:         1,
+	}
+	if config.WebSearch {
+		filter.Grounded = &config.WebSearch
+	}
+	candidates, err := s.db.ListResponses(ctx, filter)
+	if err != nil {
+		logger.Warning("Response cache lookup failed: %v", err)
+		return nil
 	}
-	return apiKey[:4] + "..." + apiKey[len(apiKey)-4:]
+	if len(candidates) == 0 || !reusable(candidates[0]) {
+		return nil
+	}
+	return candidates[0]
+}
+
+// requestCacheKey summarizes the request parameters beyond the prompt, model and temperature
+// that change the answer, such as the seed, stop sequences and response format, so that only
+// responses to the same request are reused
+func requestCacheKey(config llm.Config) string {
+	config.Model = ""
+	config.Temperature = 0
+	config.Stream = false
+	data, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// RateLimiters returns the per-provider rate limiters shared by scheduled executions
+func (s *SchedulerService) RateLimiters() *RateLimiters {
+	return s.rateLimiters
 }
 
+// Helper functions
 func min(a, b int) int {
 	if a < b {
 		return a
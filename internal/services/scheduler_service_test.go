@@ -0,0 +1,459 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/llm"
+	"github.com/AI2HU/gego/internal/llm/openai"
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestResponseCache(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     map[string]string // LLM config of the first call
+		second    map[string]string // LLM config of the second call
+		batching  bool
+		wantCalls int
+	}{
+		{name: "identical requests", wantCalls: 1},
+		{name: "identical requests while batching", batching: true, wantCalls: 1},
+		{name: "different seed", first: map[string]string{"seed": "1"}, second: map[string]string{"seed": "2"}, wantCalls: 2},
+		{name: "seed added", second: map[string]string{"seed": "1"}, wantCalls: 2},
+		{name: "different stop sequences", first: map[string]string{"stop_sequences": "END"}, second: map[string]string{"stop_sequences": "###"}, wantCalls: 2},
+		{name: "different response format", second: map[string]string{"response_format": "json_object"}, wantCalls: 2},
+		{name: "same settings", first: map[string]string{"seed": "7", "stop_sequences": "END"}, second: map[string]string{"stop_sequences": "END", "seed": "7"}, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			database := newMemoryDB()
+			database.batching = tt.batching
+			provider := &recordingProvider{name: "openai", text: "Acme is the leading tool."}
+			scheduler := newTestScheduler(database, provider)
+			scheduler.SetResponseCache(time.Hour)
+
+			prompt := &models.Prompt{ID: "prompt-1", Template: "What is the best tool?"}
+			for i, config := range []map[string]string{tt.first, tt.second} {
+				llmConfig := &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", Config: config}
+				if err := scheduler.executePromptWithLLM(ctx, "schedule-1", prompt, llmConfig, 0.7, nil); err != nil {
+					t.Fatalf("call %d: %v", i+1, err)
+				}
+			}
+
+			if calls := len(provider.calls()); calls != tt.wantCalls {
+				t.Errorf("provider called %d times, want %d", calls, tt.wantCalls)
+			}
+			if err := database.FlushResponses(ctx); err != nil {
+				t.Fatal(err)
+			}
+			responses := database.allResponses()
+			if len(responses) != 2 {
+				t.Fatalf("stored %d responses, want 2", len(responses))
+			}
+			fromCache, _ := responses[1].Metadata["from_cache"].(bool)
+			if fromCache != (tt.wantCalls == 1) {
+				t.Errorf("second response from_cache = %t, want %t", fromCache, tt.wantCalls == 1)
+			}
+		})
+	}
+}
+
+func TestResponseCacheSkipsOtherLLMsAndReusedAnswers(t *testing.T) {
+	ctx := context.Background()
+	database := newMemoryDB()
+	provider := &recordingProvider{name: "openai", text: "Acme is the leading tool."}
+	scheduler := newTestScheduler(database, provider)
+	scheduler.SetResponseCache(time.Hour)
+
+	prompt := &models.Prompt{ID: "prompt-1", Template: "What is the best tool?"}
+	gpt := &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o"}
+	mini := &models.LLMConfig{ID: "llm-2", Name: "GPT mini", Provider: "openai", Model: "gpt-4o-mini"}
+	// After the first call, another LLM and then a reused answer are the most recent responses
+	for i, llmConfig := range []*models.LLMConfig{gpt, mini, gpt, gpt} {
+		if err := scheduler.executePromptWithLLM(ctx, "schedule-1", prompt, llmConfig, 0.7, nil); err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+	}
+
+	if calls := len(provider.calls()); calls != 2 {
+		t.Errorf("provider called %d times, want 2", calls)
+	}
+	for i, response := range database.allResponses() {
+		fromCache, _ := response.Metadata["from_cache"].(bool)
+		if want := i >= 2; fromCache != want {
+			t.Errorf("response %d from_cache = %t, want %t", i+1, fromCache, want)
+		}
+	}
+}
+
+func TestResponseCacheDisabled(t *testing.T) {
+	database := newMemoryDB()
+	provider := &recordingProvider{name: "openai", text: "Acme"}
+	scheduler := newTestScheduler(database, provider)
+
+	prompt := &models.Prompt{ID: "prompt-1", Template: "What is the best tool?"}
+	llmConfig := &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o"}
+	for i := 0; i < 2; i++ {
+		if err := scheduler.executePromptWithLLM(context.Background(), "schedule-1", prompt, llmConfig, 0.7, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls := len(provider.calls()); calls != 2 {
+		t.Errorf("provider called %d times, want 2", calls)
+	}
+}
+
+// blockingProvider answers like recordingProvider once release is closed
+type blockingProvider struct {
+	*recordingProvider
+	release chan struct{}
+}
+
+func (p *blockingProvider) Generate(ctx context.Context, prompt string, config llm.Config) (*llm.Response, error) {
+	<-p.release
+	return p.recordingProvider.Generate(ctx, prompt, config)
+}
+
+func TestCatchUpRunsOnceOnStart(t *testing.T) {
+	ctx := context.Background()
+	database := newMemoryDB()
+	lastRun := time.Now().UTC().Truncate(time.Hour).Add(-3*time.Hour + time.Minute)
+	database.llms["llm-1"] = &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", Enabled: true}
+	database.prompts["prompt-1"] = &models.Prompt{ID: "prompt-1", Template: "What is the best tool?", Enabled: true}
+	database.schedules["schedule-1"] = &models.Schedule{
+		ID: "schedule-1", Name: "Hourly", PromptIDs: []string{"prompt-1"}, LLMIDs: []string{"llm-1"},
+		CronExpr: "0 * * * *", Temperature: 0.7, Enabled: true, LastRun: &lastRun,
+		CatchUpPolicy: models.CatchUpRunOnceOnStart, CreatedAt: lastRun, UpdatedAt: lastRun,
+	}
+
+	provider := &blockingProvider{recordingProvider: &recordingProvider{name: "openai", text: "Acme"}, release: make(chan struct{})}
+	scheduler := newTestScheduler(database, provider)
+	if err := scheduler.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer scheduler.Stop()
+
+	// The catch-up run is still waiting on the provider, so the schedule has not run since it
+	// missed its fire times
+	if err := scheduler.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+	schedule, err := database.GetSchedule(ctx, "schedule-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.MissedRuns != 3 {
+		t.Errorf("missed runs = %d after a reload, want 3", schedule.MissedRuns)
+	}
+
+	close(provider.release)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(database.allResponses()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Let a second catch-up run, if one was started, reach the provider
+	time.Sleep(100 * time.Millisecond)
+
+	responses := database.allResponses()
+	if len(responses) != 1 {
+		t.Fatalf("stored %d responses, want 1 catch-up response", len(responses))
+	}
+	if responses[0].Metadata["catch_up"] != true || responses[0].Metadata["catch_up_run"] != "1/1" {
+		t.Errorf("response metadata = %v, want catch_up true and catch_up_run 1/1", responses[0].Metadata)
+	}
+}
+
+func TestScheduleSeedOverridesLLMSeed(t *testing.T) {
+	tests := []struct {
+		name         string
+		llmSeed      string
+		scheduleSeed *int
+		want         *int
+	}{
+		{name: "no seed"},
+		{name: "LLM seed", llmSeed: "42", want: intPtr(42)},
+		{name: "schedule seed", scheduleSeed: intPtr(7), want: intPtr(7)},
+		{name: "schedule seed wins", llmSeed: "42", scheduleSeed: intPtr(7), want: intPtr(7)},
+		{name: "malformed LLM seed", llmSeed: "forty-two"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &recordingProvider{name: "openai", text: "Acme"}
+			scheduler := newTestScheduler(newMemoryDB(), provider)
+
+			llmConfig := &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o"}
+			if tt.llmSeed != "" {
+				llmConfig.Config = map[string]string{"seed": tt.llmSeed}
+			}
+			prompt := &models.Prompt{ID: "prompt-1", Template: "What is the best tool?"}
+			if err := scheduler.executePromptWithLLM(context.Background(), "schedule-1", prompt, llmConfig, 0.7, tt.scheduleSeed); err != nil {
+				t.Fatal(err)
+			}
+
+			got := provider.calls()[0].Seed
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("seed = %d, want none", *got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Errorf("seed = %v, want %d", got, *tt.want)
+			}
+		})
+	}
+}
+
+func intPtr(v int) *int { return &v }
+
+// capabilityProvider reports fixed capabilities
+type capabilityProvider struct {
+	recordingProvider
+	capabilities llm.Capabilities
+}
+
+func (p *capabilityProvider) Capabilities() llm.Capabilities { return p.capabilities }
+
+func TestRequestConfig(t *testing.T) {
+	all := llm.Capabilities{JSONMode: true, Seed: true, MaxStopSequences: llm.UnlimitedStopSequences, WebSearch: true}
+	openAILike := llm.Capabilities{JSONMode: true, Seed: true, MaxStopSequences: 4, WebSearch: true}
+
+	tests := []struct {
+		name         string
+		capabilities llm.Capabilities
+		config       map[string]string
+		check        func(t *testing.T, got llm.Config)
+	}{
+		{
+			name: "sampling settings", capabilities: all,
+			config: map[string]string{"temperature": "0.2", "top_p": "0.9", "top_k": "40", "stream": "true"},
+			check: func(t *testing.T, got llm.Config) {
+				if got.Temperature != 0.2 || got.TopP != 0.9 || got.TopK != 40 || !got.Stream {
+					t.Errorf("got temperature %v top_p %v top_k %d stream %t", got.Temperature, got.TopP, got.TopK, got.Stream)
+				}
+			},
+		},
+		{
+			name: "malformed values keep the defaults", capabilities: all,
+			config: map[string]string{"temperature": "hot", "top_k": "many", "stream": "maybe"},
+			check: func(t *testing.T, got llm.Config) {
+				if got.Temperature != 0.7 || got.TopK != 0 || got.Stream {
+					t.Errorf("got temperature %v top_k %d stream %t", got.Temperature, got.TopK, got.Stream)
+				}
+			},
+		},
+		{
+			name: "single stop sequence", capabilities: all,
+			config: map[string]string{"stop_sequences": "END"},
+			check: func(t *testing.T, got llm.Config) {
+				if len(got.StopSequences) != 1 || got.StopSequences[0] != "END" {
+					t.Errorf("stop sequences = %q", got.StopSequences)
+				}
+			},
+		},
+		{
+			name: "stop sequence array", capabilities: openAILike,
+			config: map[string]string{"stop_sequences": `["###", "END"]`},
+			check: func(t *testing.T, got llm.Config) {
+				if len(got.StopSequences) != 2 || got.StopSequences[0] != "###" {
+					t.Errorf("stop sequences = %q", got.StopSequences)
+				}
+			},
+		},
+		{
+			name: "too many stop sequences", capabilities: openAILike,
+			config: map[string]string{"stop_sequences": `["a", "b", "c", "d", "e"]`},
+			check: func(t *testing.T, got llm.Config) {
+				if got.StopSequences != nil {
+					t.Errorf("stop sequences = %q, want none", got.StopSequences)
+				}
+			},
+		},
+		{
+			name:   "stop sequences unsupported",
+			config: map[string]string{"stop_sequences": "END"},
+			check: func(t *testing.T, got llm.Config) {
+				if got.StopSequences != nil {
+					t.Errorf("stop sequences = %q, want none", got.StopSequences)
+				}
+			},
+		},
+		{
+			name: "reasoning", capabilities: all,
+			config: map[string]string{"reasoning": "true", "reasoning_effort": "High"},
+			check: func(t *testing.T, got llm.Config) {
+				if !got.Reasoning || got.ReasoningEffort != "high" {
+					t.Errorf("reasoning %t effort %q", got.Reasoning, got.ReasoningEffort)
+				}
+			},
+		},
+		{
+			name: "invalid reasoning effort", capabilities: all,
+			config: map[string]string{"reasoning_effort": "max"},
+			check: func(t *testing.T, got llm.Config) {
+				if got.ReasoningEffort != "" {
+					t.Errorf("effort = %q, want none", got.ReasoningEffort)
+				}
+			},
+		},
+		{
+			name: "web search", capabilities: all,
+			config: map[string]string{"web_search": "true"},
+			check: func(t *testing.T, got llm.Config) {
+				if !got.WebSearch {
+					t.Error("web search not enabled")
+				}
+			},
+		},
+		{
+			name:   "web search unsupported",
+			config: map[string]string{"web_search": "true"},
+			check: func(t *testing.T, got llm.Config) {
+				if got.WebSearch {
+					t.Error("web search enabled on a provider without it")
+				}
+			},
+		},
+		{
+			name: "JSON response format", capabilities: all,
+			config: map[string]string{"response_format": "json_object"},
+			check: func(t *testing.T, got llm.Config) {
+				if got.ResponseFormat != llm.ResponseFormatJSONObject {
+					t.Errorf("response format = %q", got.ResponseFormat)
+				}
+			},
+		},
+		{
+			name:   "JSON response format unsupported",
+			config: map[string]string{"response_format": "json_object"},
+			check: func(t *testing.T, got llm.Config) {
+				if got.ResponseFormat != "" {
+					t.Errorf("response format = %q, want none", got.ResponseFormat)
+				}
+			},
+		},
+		{
+			name:   "seed unsupported",
+			config: map[string]string{"seed": "42"},
+			check: func(t *testing.T, got llm.Config) {
+				if got.Seed != nil {
+					t.Errorf("seed = %d, want none", *got.Seed)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &capabilityProvider{recordingProvider: recordingProvider{name: "test"}, capabilities: tt.capabilities}
+			llmConfig := &models.LLMConfig{Name: "Test", Provider: "test", Model: "model", Config: tt.config}
+			got := requestConfig(context.Background(), provider, llmConfig, 0.7, nil)
+			if got.Model != "model" {
+				t.Errorf("model = %q", got.Model)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+// webSearchResponseJSON is a Responses API answer citing one source twice
+const webSearchResponseJSON = `{"id":"resp_1","object":"response","created_at":0,"model":"gpt-4o","status":"completed",
+"output":[{"type":"message","id":"msg_1","status":"completed","role":"assistant","content":[{"type":"output_text","text":"Acme leads.",
+"annotations":[{"type":"url_citation","url":"https://acme.example.com/","title":"Acme","start_index":0,"end_index":4},
+{"type":"url_citation","url":"https://acme.example.com/","title":"Acme","start_index":5,"end_index":10}]}]}],
+"usage":{"input_tokens":5,"output_tokens":3,"total_tokens":8,"input_tokens_details":{"cached_tokens":0},"output_tokens_details":{"reasoning_tokens":0}}}`
+
+func TestWebSearchUsesResponsesAPI(t *testing.T) {
+	var paths []string
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		paths = append(paths, r.URL.Path)
+		body = string(data)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, webSearchResponseJSON)
+	}))
+	defer server.Close()
+
+	provider, err := openai.New("sk-test", server.URL+"/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	database := newMemoryDB()
+	scheduler := newTestScheduler(database, provider)
+
+	llmConfig := &models.LLMConfig{ID: "llm-1", Name: "GPT", Provider: "openai", Model: "gpt-4o", Config: map[string]string{"web_search": "true"}}
+	prompt := &models.Prompt{ID: "prompt-1", Template: "What is the best CRM?"}
+	if err := scheduler.executePromptWithLLM(context.Background(), "", prompt, llmConfig, 0.7, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paths) != 1 || paths[0] != "/v1/responses" {
+		t.Fatalf("requested %v, want /v1/responses", paths)
+	}
+	if !strings.Contains(body, `"web_search"`) {
+		t.Errorf("request has no web search tool: %s", body)
+	}
+
+	responses := database.allResponses()
+	if len(responses) != 1 {
+		t.Fatalf("stored %d responses, want 1", len(responses))
+	}
+	response := responses[0]
+	if response.ResponseText != "Acme leads." || !response.Grounded {
+		t.Errorf("response text %q grounded %t", response.ResponseText, response.Grounded)
+	}
+	citations, _ := response.Metadata[llm.MetadataCitations].([]string)
+	if len(citations) != 1 || citations[0] != "https://acme.example.com/" {
+		t.Errorf("citations = %v, want the cited URL once", response.Metadata[llm.MetadataCitations])
+	}
+}
+
+func TestExecutionOrder(t *testing.T) {
+	var prompts []*models.Prompt
+	for i := 0; i < 5; i++ {
+		prompts = append(prompts, &models.Prompt{ID: fmt.Sprintf("prompt-%d", i)})
+	}
+	llms := []*models.LLMConfig{{ID: "llm-1"}, {ID: "llm-2"}, {ID: "llm-3"}, {ID: "llm-4"}}
+	order := func(shuffle bool, seed *int, runTime time.Time) []string {
+		var ids []string
+		for _, execution := range executionOrder(prompts, llms, shuffle, orderSeed(seed, runTime)) {
+			ids = append(ids, execution.prompt.ID+"/"+execution.llm.ID)
+		}
+		return ids
+	}
+
+	run := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	unshuffled := order(false, intPtr(42), run)
+	if unshuffled[0] != "prompt-0/llm-1" || unshuffled[1] != "prompt-0/llm-2" || unshuffled[19] != "prompt-4/llm-4" {
+		t.Errorf("unshuffled order = %v, want prompt order then LLM order", unshuffled)
+	}
+
+	first := order(true, intPtr(42), run)
+	if !slices.Equal(first, order(true, intPtr(42), run.Add(20*time.Second))) {
+		t.Error("the same seed and run minute gave different orders")
+	}
+	if slices.Equal(first, unshuffled) {
+		t.Error("shuffled order is the unshuffled order")
+	}
+	if slices.Equal(first, order(true, intPtr(42), run.Add(time.Hour))) {
+		t.Error("the next run repeats the order of the previous one")
+	}
+	if slices.Equal(first, order(true, intPtr(7), run)) {
+		t.Error("another seed gave the same order")
+	}
+
+	sorted := slices.Clone(first)
+	slices.Sort(sorted)
+	if !slices.Equal(sorted, unshuffled) {
+		t.Errorf("shuffled order = %v, want every execution once", first)
+	}
+}
@@ -14,7 +14,8 @@ import (
 
 // SearchService provides business logic for searching responses
 type SearchService struct {
-	db db.Database
+	db            db.Database
+	keywordSearch shared.KeywordSearchOptions // Scan limit and deadline of SearchKeyword
 }
 
 // NewSearchService creates a new search service
@@ -22,9 +23,22 @@ func NewSearchService(database db.Database) *SearchService {
 	return &SearchService{db: database}
 }
 
-// SearchKeyword searches for a keyword and returns statistics
+// SetKeywordSearchOptions bounds the responses scanned by SearchKeyword
+func (s *SearchService) SetKeywordSearchOptions(opts shared.KeywordSearchOptions) {
+	s.keywordSearch = opts
+}
+
+// SearchKeyword searches for a keyword and returns statistics, flagged as truncated when the scan
+// stopped at its limit or deadline
 func (s *SearchService) SearchKeyword(ctx context.Context, keyword string, startTime, endTime *time.Time) (*models.KeywordStats, error) {
-	return s.db.SearchKeyword(ctx, keyword, startTime, endTime)
+	stats, err := s.db.SearchKeyword(ctx, keyword, startTime, endTime, s.keywordSearch)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveKeywordLLMs(ctx, s.db, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
 }
 
 // ListResponses lists responses with filtering
@@ -77,6 +91,10 @@ func (s *SearchService) SearchResponses(ctx context.Context, config *SearchConfi
 	if err != nil {
 		return nil, fmt.Errorf("failed to search responses: %w", err)
 	}
+	prompts := NewPromptTextResolver(s.db)
+	if err := prompts.Resolve(ctx, responses); err != nil {
+		return nil, err
+	}
 
 	var regex *regexp.Regexp
 	if config.CaseSensitive {
@@ -87,7 +105,7 @@ func (s *SearchService) SearchResponses(ctx context.Context, config *SearchConfi
 
 	var matches []SearchMatch
 	for _, response := range responses {
-		responseMatches := s.findMatches(response, regex, config.ContextLength)
+		responseMatches := s.findMatches(response, prompts.Template(response.PromptID), regex, config.ContextLength)
 		matches = append(matches, responseMatches...)
 	}
 
@@ -95,10 +113,12 @@ func (s *SearchService) SearchResponses(ctx context.Context, config *SearchConfi
 }
 
 // findMatches finds all matches in a response
-func (s *SearchService) findMatches(response *models.Response, regex *regexp.Regexp, contextLength int) []SearchMatch {
+func (s *SearchService) findMatches(response *models.Response, promptTemplate string, regex *regexp.Regexp, contextLength int) []SearchMatch {
 	var matches []SearchMatch
 
-	indices := regex.FindAllStringIndex(response.ResponseText, -1)
+	// Match on plain text so formatted mentions such as **Netflix** or [Netflix](url) read cleanly
+	text := shared.StripMarkdown(response.ResponseText)
+	indices := regex.FindAllStringIndex(text, -1)
 
 	for _, index := range indices {
 		start := index[0]
@@ -109,15 +129,15 @@ func (s *SearchService) findMatches(response *models.Response, regex *regexp.Reg
 			contextStart = 0
 		}
 		contextEnd := end + contextLength
-		if contextEnd > len(response.ResponseText) {
-			contextEnd = len(response.ResponseText)
+		if contextEnd > len(text) {
+			contextEnd = len(text)
 		}
 
-		contextText := response.ResponseText[contextStart:contextEnd]
+		contextText := text[contextStart:contextEnd]
 
 		promptName := "Unknown Prompt"
-		if prompt, err := s.db.GetPrompt(context.Background(), response.PromptID); err == nil {
-			promptName = prompt.Template
+		if promptTemplate != "" {
+			promptName = promptTemplate
 		}
 
 		matches = append(matches, SearchMatch{
@@ -215,6 +235,21 @@ type ResponseStats struct {
 	AvgLatency     float64        `json:"avg_latency"`
 }
 
+// DropShortResponses removes responses shorter than minLength characters, returning the kept
+// responses and how many were dropped
+func DropShortResponses(responses []*models.Response, minLength int) ([]*models.Response, int) {
+	if minLength <= 0 {
+		return responses, 0
+	}
+	kept := responses[:0]
+	for _, response := range responses {
+		if !shared.TooShort(response.ResponseText, minLength) {
+			kept = append(kept, response)
+		}
+	}
+	return kept, len(responses) - len(kept)
+}
+
 // HighlightKeyword highlights a keyword in text
 func HighlightKeyword(text, keyword string, caseSensitive bool) string {
 	if caseSensitive {
@@ -3,7 +3,11 @@ package services
 import (
 	"context"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/AI2HU/gego/internal/db"
@@ -13,12 +17,13 @@ import (
 
 // StatsService provides business logic for statistics
 type StatsService struct {
-	db db.Database
+	db       db.Database
+	geoScore GEOScoreConfig
 }
 
 // NewStatsService creates a new stats service
 func NewStatsService(database db.Database) *StatsService {
-	return &StatsService{db: database}
+	return &StatsService{db: database, geoScore: DefaultGEOScoreConfig()}
 }
 
 // GetTotalResponses returns the total number of responses
@@ -66,9 +71,176 @@ func (s *StatsService) GetTopKeywords(ctx context.Context, limit int, startTime,
 	return s.db.GetTopKeywords(ctx, limit, startTime, endTime)
 }
 
-// SearchKeyword returns statistics for a specific keyword
-func (s *StatsService) SearchKeyword(ctx context.Context, keyword string, startTime, endTime *time.Time) (*models.KeywordStats, error) {
-	return s.db.SearchKeyword(ctx, keyword, startTime, endTime)
+// GetTopDomains returns the domains cited in the most responses, optionally only counting
+// responses that mention keyword
+func (s *StatsService) GetTopDomains(ctx context.Context, keyword string, limit int, startTime, endTime *time.Time) ([]models.DomainCount, error) {
+	return s.db.GetTopDomains(ctx, strings.TrimSpace(keyword), limit, startTime, endTime)
+}
+
+// GetPromptTopKeywords returns the keywords mentioned most in a prompt's responses
+func (s *StatsService) GetPromptTopKeywords(ctx context.Context, promptID string, limit int) ([]models.KeywordCount, error) {
+	return s.topResponseKeywords(ctx, shared.ResponseFilter{PromptID: promptID, HasError: shared.ErrorFilterFromContext(ctx)}, limit)
+}
+
+// GetLLMTopKeywords returns the keywords mentioned most in an LLM's responses
+func (s *StatsService) GetLLMTopKeywords(ctx context.Context, llmID string, limit int) ([]models.KeywordCount, error) {
+	return s.topResponseKeywords(ctx, shared.ResponseFilter{LLMID: llmID, HasError: shared.ErrorFilterFromContext(ctx)}, limit)
+}
+
+// topResponseKeywords counts the keywords of the responses matching filter, most mentioned first
+func (s *StatsService) topResponseKeywords(ctx context.Context, filter shared.ResponseFilter, limit int) ([]models.KeywordCount, error) {
+	responses, err := s.db.ListResponses(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list responses: %w", err)
+	}
+
+	wordCounts := make(map[string]int)
+	countMode := shared.CountModeFromContext(ctx)
+	for _, response := range responses {
+		countMode.AddWords(wordCounts, shared.ExtractCapitalizedWords(response.ResponseText))
+	}
+
+	keywords := make([]models.KeywordCount, 0, len(wordCounts))
+	for keyword, count := range wordCounts {
+		keywords = append(keywords, models.KeywordCount{Keyword: keyword, Count: count})
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Count != keywords[j].Count {
+			return keywords[i].Count > keywords[j].Count
+		}
+		return keywords[i].Keyword < keywords[j].Keyword
+	})
+
+	if limit > 0 && len(keywords) > limit {
+		keywords = keywords[:limit]
+	}
+	return keywords, nil
+}
+
+// ComparePeriods compares keyword mentions and share of voice between two time periods. Periods
+// include their start and exclude their end, so that a response created where one period ends and
+// the next starts counts in the next one only.
+func (s *StatsService) ComparePeriods(ctx context.Context, period1Start, period1End, period2Start, period2End time.Time, limit int) (*models.PeriodComparison, error) {
+	ctx = shared.WithExclusiveEnd(ctx)
+	period1, err := s.db.GetTopKeywords(ctx, math.MaxInt32, &period1Start, &period1End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keywords for first period: %w", err)
+	}
+
+	period2, err := s.db.GetTopKeywords(ctx, math.MaxInt32, &period2Start, &period2End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keywords for second period: %w", err)
+	}
+
+	comparison := CompareKeywordCounts(period1, period2, limit)
+	comparison.Period1Start = period1Start
+	comparison.Period1End = period1End
+	comparison.Period2Start = period2Start
+	comparison.Period2End = period2End
+
+	return comparison, nil
+}
+
+// CompareKeywordCounts computes per-keyword deltas between two ranked keyword lists
+func CompareKeywordCounts(period1, period2 []models.KeywordCount, limit int) *models.PeriodComparison {
+	comparison := &models.PeriodComparison{}
+
+	deltas := make(map[string]*models.KeywordDelta)
+	var order []string
+	get := func(keyword string) *models.KeywordDelta {
+		if delta, exists := deltas[keyword]; exists {
+			return delta
+		}
+		delta := &models.KeywordDelta{Keyword: keyword}
+		deltas[keyword] = delta
+		order = append(order, keyword)
+		return delta
+	}
+
+	for i, kc := range period1 {
+		delta := get(kc.Keyword)
+		delta.Period1Count = kc.Count
+		delta.Period1Rank = i + 1
+		comparison.Period1Mentions += kc.Count
+	}
+
+	for i, kc := range period2 {
+		delta := get(kc.Keyword)
+		delta.Period2Count = kc.Count
+		delta.Period2Rank = i + 1
+		comparison.Period2Mentions += kc.Count
+	}
+
+	for _, keyword := range order {
+		delta := deltas[keyword]
+		delta.Delta = delta.Period2Count - delta.Period1Count
+		if comparison.Period1Mentions > 0 {
+			delta.Period1Share = float64(delta.Period1Count) / float64(comparison.Period1Mentions) * 100
+		}
+		if comparison.Period2Mentions > 0 {
+			delta.Period2Share = float64(delta.Period2Count) / float64(comparison.Period2Mentions) * 100
+		}
+		delta.IsNew = delta.Period1Count == 0 && delta.Period2Count > 0
+		delta.IsDropped = delta.Period1Count > 0 && delta.Period2Count == 0
+		if delta.Period1Rank > 0 && delta.Period2Rank > 0 {
+			delta.RankChange = delta.Period1Rank - delta.Period2Rank
+		}
+		comparison.Keywords = append(comparison.Keywords, *delta)
+	}
+
+	sort.SliceStable(comparison.Keywords, func(i, j int) bool {
+		a, b := comparison.Keywords[i], comparison.Keywords[j]
+		if a.Period2Count != b.Period2Count {
+			return a.Period2Count > b.Period2Count
+		}
+		return a.Period1Count > b.Period1Count
+	})
+
+	if limit > 0 && len(comparison.Keywords) > limit {
+		comparison.Keywords = comparison.Keywords[:limit]
+	}
+
+	return comparison
+}
+
+// SearchKeyword returns statistics for a specific keyword, with the LLM details recorded on the
+// responses of each LLM it counts
+func (s *StatsService) SearchKeyword(ctx context.Context, keyword string, startTime, endTime *time.Time, opts shared.KeywordSearchOptions) (*models.KeywordStats, error) {
+	stats, err := s.db.SearchKeyword(ctx, keyword, startTime, endTime, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveKeywordLLMs(ctx, s.db, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// PreviousWindow returns the window of the same length as [start, end] that ends just before start
+func PreviousWindow(start, end time.Time) (time.Time, time.Time) {
+	return start.Add(-end.Sub(start)), start.Add(-time.Nanosecond)
+}
+
+// CompareKeywordStats compares the keyword stats of a window with those of the window before it
+func CompareKeywordStats(current, previous *models.KeywordStats, previousStart, previousEnd time.Time) *models.KeywordPeriodChange {
+	change := &models.KeywordPeriodChange{
+		Start:         previousStart,
+		End:           previousEnd,
+		TotalMentions: models.NewCountChange(previous.TotalMentions, current.TotalMentions),
+		UniquePrompts: models.NewCountChange(previous.UniquePrompts, current.UniquePrompts),
+		UniqueLLMs:    models.NewCountChange(previous.UniqueLLMs, current.UniqueLLMs),
+		ByProvider:    make(map[string]models.CountChange),
+		Truncated:     previous.Truncated,
+	}
+	for provider, count := range current.ByProvider {
+		change.ByProvider[provider] = models.NewCountChange(previous.ByProvider[provider], count)
+	}
+	for provider, count := range previous.ByProvider {
+		if _, ok := current.ByProvider[provider]; !ok {
+			change.ByProvider[provider] = models.NewCountChange(count, 0)
+		}
+	}
+	return change
 }
 
 // GetKeywordTrends returns keyword trends over time - placeholder for future implementation
@@ -215,6 +387,7 @@ func (s *StatsService) GetProviderStats(ctx context.Context) (map[string]*Provid
 		stats.TotalResponses++
 		stats.TotalTokens += response.TokensUsed
 		stats.TotalLatency += response.LatencyMs
+		stats.TotalQueueWait += response.QueueWaitMs
 		stats.UniquePrompts[response.PromptID] = true
 		stats.UniqueLLMs[response.LLMID] = true
 	}
@@ -223,6 +396,7 @@ func (s *StatsService) GetProviderStats(ctx context.Context) (map[string]*Provid
 		if stats.TotalResponses > 0 {
 			stats.AvgTokens = float64(stats.TotalTokens) / float64(stats.TotalResponses)
 			stats.AvgLatency = float64(stats.TotalLatency) / float64(stats.TotalResponses)
+			stats.AvgQueueWait = float64(stats.TotalQueueWait) / float64(stats.TotalResponses)
 		}
 		stats.UniquePromptCount = len(stats.UniquePrompts)
 		stats.UniqueLLMCount = len(stats.UniqueLLMs)
@@ -239,12 +413,73 @@ type ProviderStats struct {
 	TotalLatency      int64           `json:"total_latency"`
 	AvgTokens         float64         `json:"avg_tokens"`
 	AvgLatency        float64         `json:"avg_latency"`
+	TotalQueueWait    int64           `json:"total_queue_wait"`
+	AvgQueueWait      float64         `json:"avg_queue_wait"`
 	UniquePromptCount int             `json:"unique_prompt_count"`
 	UniqueLLMCount    int             `json:"unique_llm_count"`
 	UniquePrompts     map[string]bool `json:"-"`
 	UniqueLLMs        map[string]bool `json:"-"`
 }
 
+// GetLatencyStats returns the provider latency and rate limiter queue wait of each LLM, slowest
+// first. With a scheduleID only that schedule's responses are counted. Responses reused from the
+// response cache made no provider call and are skipped.
+func (s *StatsService) GetLatencyStats(ctx context.Context, scheduleID string) ([]*LatencyStats, error) {
+	responses, err := s.db.ListResponses(ctx, shared.ResponseFilter{ScheduleID: scheduleID, Limit: 10000})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get responses: %w", err)
+	}
+
+	byLLM := make(map[string]*LatencyStats)
+	var results []*LatencyStats
+	for _, response := range responses {
+		if fromCache, _ := response.Metadata["from_cache"].(bool); fromCache {
+			continue
+		}
+
+		stats := byLLM[response.LLMID]
+		if stats == nil {
+			stats = &LatencyStats{
+				LLMID:    response.LLMID,
+				LLMName:  response.LLMName,
+				Provider: response.LLMProvider,
+			}
+			byLLM[response.LLMID] = stats
+			results = append(results, stats)
+		}
+
+		stats.Calls++
+		stats.TotalLatencyMs += response.LatencyMs
+		stats.TotalQueueWaitMs += response.QueueWaitMs
+		if response.QueueWaitMs > stats.MaxQueueWaitMs {
+			stats.MaxQueueWaitMs = response.QueueWaitMs
+		}
+	}
+
+	for _, stats := range results {
+		stats.AvgLatencyMs = float64(stats.TotalLatencyMs) / float64(stats.Calls)
+		stats.AvgQueueWaitMs = float64(stats.TotalQueueWaitMs) / float64(stats.Calls)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].AvgLatencyMs+results[i].AvgQueueWaitMs > results[j].AvgLatencyMs+results[j].AvgQueueWaitMs
+	})
+
+	return results, nil
+}
+
+// LatencyStats splits the time an LLM's responses took into provider latency and rate limiter queue wait
+type LatencyStats struct {
+	LLMID            string  `json:"llm_id"`
+	LLMName          string  `json:"llm_name"`
+	Provider         string  `json:"provider"`
+	Calls            int     `json:"calls"`
+	TotalLatencyMs   int64   `json:"total_latency_ms"`
+	TotalQueueWaitMs int64   `json:"total_queue_wait_ms"`
+	AvgLatencyMs     float64 `json:"avg_latency_ms"`
+	AvgQueueWaitMs   float64 `json:"avg_queue_wait_ms"`
+	MaxQueueWaitMs   int64   `json:"max_queue_wait_ms"`
+}
+
 // GetTopPromptsByMentions returns prompts ranked by keyword mentions
 func (s *StatsService) GetTopPromptsByMentions(ctx context.Context, limit int) ([]*PromptMentionStats, error) {
 	responses, err := s.db.ListResponses(ctx, shared.ResponseFilter{Limit: 10000})
@@ -253,25 +488,24 @@ func (s *StatsService) GetTopPromptsByMentions(ctx context.Context, limit int) (
 	}
 
 	promptMentions := make(map[string]int)
-	promptNames := make(map[string]string)
-
 	for _, response := range responses {
 		promptMentions[response.PromptID]++
+	}
 
-		if _, exists := promptNames[response.PromptID]; !exists {
-			if prompt, err := s.db.GetPrompt(ctx, response.PromptID); err == nil {
-				promptNames[response.PromptID] = prompt.Template
-			} else {
-				promptNames[response.PromptID] = fmt.Sprintf("Unknown Prompt (%s)", response.PromptID[:8])
-			}
-		}
+	prompts, err := s.db.GetPromptsByIDs(ctx, slices.Collect(maps.Keys(promptMentions)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompts: %w", err)
 	}
 
 	var results []*PromptMentionStats
 	for promptID, count := range promptMentions {
+		promptName := fmt.Sprintf("Unknown Prompt (%s)", promptID[:8])
+		if prompt, ok := prompts[promptID]; ok {
+			promptName = prompt.Template
+		}
 		results = append(results, &PromptMentionStats{
 			PromptID:   promptID,
-			PromptName: promptNames[promptID],
+			PromptName: promptName,
 			Mentions:   count,
 		})
 	}
@@ -302,25 +536,28 @@ func (s *StatsService) GetTopLLMsByMentions(ctx context.Context, limit int) ([]*
 	}
 
 	llmMentions := make(map[string]int)
-	llmNames := make(map[string]string)
-
+	snapshots := make(map[string]models.LLMSnapshot)
 	for _, response := range responses {
 		llmMentions[response.LLMID]++
-
-		if _, exists := llmNames[response.LLMID]; !exists {
-			if llm, err := s.db.GetLLM(ctx, response.LLMID); err == nil {
-				llmNames[response.LLMID] = fmt.Sprintf("%s (%s)", llm.Name, llm.Provider)
-			} else {
-				llmNames[response.LLMID] = fmt.Sprintf("Unknown LLM (%s)", response.LLMID[:8])
-			}
+		// Responses are listed newest first, so the most recent snapshot wins
+		if _, ok := snapshots[response.LLMID]; !ok && !response.LLMSnapshot().Empty() {
+			snapshots[response.LLMID] = response.LLMSnapshot()
 		}
 	}
 
+	if err := ResolveLLMSnapshots(ctx, s.db, slices.Collect(maps.Keys(llmMentions)), snapshots); err != nil {
+		return nil, err
+	}
+
 	var results []*LLMMentionStats
 	for llmID, count := range llmMentions {
+		llmName := fmt.Sprintf("Unknown LLM (%s)", llmID[:8])
+		if snapshot, ok := snapshots[llmID]; ok {
+			llmName = fmt.Sprintf("%s (%s)", snapshot.Name, snapshot.Provider)
+		}
 		results = append(results, &LLMMentionStats{
 			LLMID:    llmID,
-			LLMName:  llmNames[llmID],
+			LLMName:  llmName,
 			Mentions: count,
 		})
 	}
@@ -347,3 +584,39 @@ type LLMMentionStats struct {
 func (s *StatsService) ResetAllStats(ctx context.Context) (int, error) {
 	return s.db.DeleteAllResponses(ctx)
 }
+
+// CountResponses counts responses matching the filter
+func (s *StatsService) CountResponses(ctx context.Context, filter shared.ResponseFilter) (int64, error) {
+	return s.db.CountResponses(ctx, filter)
+}
+
+// ResetStats clears the responses matching the filter, resetting statistics for that scope
+func (s *StatsService) ResetStats(ctx context.Context, filter shared.ResponseFilter) (int, error) {
+	return s.db.DeleteResponses(ctx, filter)
+}
+
+// ResetFilter builds a response filter for a scoped reset; before is a YYYY-MM-DD date and is exclusive
+func ResetFilter(scheduleID, llmID, promptID, before string) (shared.ResponseFilter, error) {
+	filter := shared.ResponseFilter{
+		ScheduleID: strings.TrimSpace(scheduleID),
+		LLMID:      strings.TrimSpace(llmID),
+		PromptID:   strings.TrimSpace(promptID),
+	}
+
+	if before = strings.TrimSpace(before); before != "" {
+		date, err := time.ParseInLocation("2006-01-02", before, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("invalid before date %q (expected YYYY-MM-DD): %w", before, err)
+		}
+		// Stored timestamps have millisecond precision, so this excludes the date itself
+		end := date.Add(-time.Millisecond)
+		filter.EndTime = &end
+	}
+
+	return filter, nil
+}
+
+// IsScopedReset reports whether a reset filter limits deletion to a subset of responses
+func IsScopedReset(filter shared.ResponseFilter) bool {
+	return filter.ScheduleID != "" || filter.LLMID != "" || filter.PromptID != "" || filter.EndTime != nil
+}
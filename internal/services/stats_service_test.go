@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/db"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// mention is one keyword found in a response created at a given time
+type mention struct {
+	keyword   string
+	createdAt time.Time
+}
+
+// mentionsDB serves GetTopKeywords from a fixed list of mentions
+type mentionsDB struct {
+	db.Database
+	mentions []mention
+}
+
+func (m *mentionsDB) GetTopKeywords(ctx context.Context, limit int, startTime, endTime *time.Time) ([]models.KeywordCount, error) {
+	counts := make(map[string]int)
+	for _, mention := range m.mentions {
+		if startTime != nil && mention.createdAt.Before(*startTime) {
+			continue
+		}
+		if endTime != nil && mention.createdAt.After(*endTime) {
+			continue
+		}
+		if endTime != nil && shared.ExclusiveEndFromContext(ctx) && mention.createdAt.Equal(*endTime) {
+			continue
+		}
+		counts[mention.keyword]++
+	}
+
+	var result []models.KeywordCount
+	for keyword, count := range counts {
+		result = append(result, models.KeywordCount{Keyword: keyword, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Keyword < result[j].Keyword
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func TestCompareKeywordCounts(t *testing.T) {
+	tests := []struct {
+		name    string
+		period1 []models.KeywordCount
+		period2 []models.KeywordCount
+		limit   int
+		want    []models.KeywordDelta
+	}{
+		{
+			name:    "gained and lost mentions",
+			period1: []models.KeywordCount{{Keyword: "Acme", Count: 6}, {Keyword: "Globex", Count: 4}},
+			period2: []models.KeywordCount{{Keyword: "Globex", Count: 8}, {Keyword: "Acme", Count: 2}},
+			want: []models.KeywordDelta{
+				{Keyword: "Globex", Period1Count: 4, Period2Count: 8, Delta: 4, Period1Share: 40, Period2Share: 80, Period1Rank: 2, Period2Rank: 1, RankChange: 1},
+				{Keyword: "Acme", Period1Count: 6, Period2Count: 2, Delta: -4, Period1Share: 60, Period2Share: 20, Period1Rank: 1, Period2Rank: 2, RankChange: -1},
+			},
+		},
+		{
+			name:    "new and dropped keywords",
+			period1: []models.KeywordCount{{Keyword: "Initech", Count: 5}},
+			period2: []models.KeywordCount{{Keyword: "Hooli", Count: 5}},
+			want: []models.KeywordDelta{
+				{Keyword: "Hooli", Period2Count: 5, Delta: 5, Period2Share: 100, Period2Rank: 1, IsNew: true},
+				{Keyword: "Initech", Period1Count: 5, Delta: -5, Period1Share: 100, Period1Rank: 1, IsDropped: true},
+			},
+		},
+		{
+			name:    "limit keeps the top of the second period",
+			period1: []models.KeywordCount{{Keyword: "Acme", Count: 1}, {Keyword: "Globex", Count: 1}},
+			period2: []models.KeywordCount{{Keyword: "Globex", Count: 3}, {Keyword: "Acme", Count: 1}},
+			limit:   1,
+			want: []models.KeywordDelta{
+				{Keyword: "Globex", Period1Count: 1, Period2Count: 3, Delta: 2, Period1Share: 50, Period2Share: 75, Period1Rank: 2, Period2Rank: 1, RankChange: 1},
+			},
+		},
+		{
+			name: "empty periods",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompareKeywordCounts(tt.period1, tt.period2, tt.limit)
+			if len(got.Keywords) != len(tt.want) {
+				t.Fatalf("got %d keywords, want %d: %+v", len(got.Keywords), len(tt.want), got.Keywords)
+			}
+			for i, want := range tt.want {
+				if got.Keywords[i] != want {
+					t.Errorf("keyword %d = %+v, want %+v", i, got.Keywords[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestComparePeriods(t *testing.T) {
+	lastWeek := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	thisWeek := lastWeek.AddDate(0, 0, 7)
+	day := 24 * time.Hour
+
+	database := &mentionsDB{mentions: []mention{
+		{"Acme", lastWeek.Add(day)},
+		{"Acme", lastWeek.Add(2 * day)},
+		{"Globex", lastWeek.Add(3 * day)},
+		// Where the periods meet: counted in the second period only
+		{"Acme", thisWeek},
+		{"Globex", thisWeek.Add(day)},
+		{"Globex", thisWeek.Add(2 * day)},
+		{"Globex", thisWeek.Add(4 * day)},
+		{"Hooli", thisWeek.Add(5 * day)},
+		// Outside both windows
+		{"Initech", thisWeek.AddDate(0, 0, 8)},
+	}}
+
+	comparison, err := NewStatsService(database).ComparePeriods(context.Background(),
+		lastWeek, thisWeek, thisWeek, thisWeek.AddDate(0, 0, 7), 0)
+	if err != nil {
+		t.Fatalf("ComparePeriods: %v", err)
+	}
+
+	if comparison.Period1Mentions != 3 || comparison.Period2Mentions != 5 {
+		t.Errorf("mentions = %d/%d, want 3/5", comparison.Period1Mentions, comparison.Period2Mentions)
+	}
+	want := map[string]struct {
+		delta, rankChange int
+		isNew             bool
+	}{
+		"Globex": {delta: 2, rankChange: 1},
+		"Acme":   {delta: -1, rankChange: -1},
+		"Hooli":  {delta: 1, isNew: true},
+	}
+	if len(comparison.Keywords) != len(want) {
+		t.Fatalf("got %d keywords, want %d: %+v", len(comparison.Keywords), len(want), comparison.Keywords)
+	}
+	if comparison.Keywords[0].Keyword != "Globex" {
+		t.Errorf("top keyword = %s, want Globex", comparison.Keywords[0].Keyword)
+	}
+	for _, delta := range comparison.Keywords {
+		w, ok := want[delta.Keyword]
+		if !ok {
+			t.Errorf("unexpected keyword %s", delta.Keyword)
+			continue
+		}
+		if delta.Delta != w.delta || delta.RankChange != w.rankChange || delta.IsNew != w.isNew {
+			t.Errorf("%s = delta %d rank change %d new %t, want %d %d %t",
+				delta.Keyword, delta.Delta, delta.RankChange, delta.IsNew, w.delta, w.rankChange, w.isNew)
+		}
+	}
+}
+
+func TestGetPromptTopKeywordsCountMode(t *testing.T) {
+	database := newMemoryDB()
+	database.responses = []*models.Response{
+		{ID: "response-1", PromptID: "prompt-1", ResponseText: "Netflix leads. Netflix has the catalog, and Netflix wins awards."},
+		{ID: "response-2", PromptID: "prompt-1", ResponseText: "Hulu is cheaper. Hulu bundles Disney."},
+		{ID: "response-3", PromptID: "prompt-2", ResponseText: "Netflix again."},
+	}
+	service := NewStatsService(database)
+
+	tests := []struct {
+		mode shared.CountMode
+		want map[string]int
+	}{
+		{mode: shared.CountModeOccurrences, want: map[string]int{"Netflix": 3, "Hulu": 2, "Disney": 1}},
+		{mode: shared.CountModeResponses, want: map[string]int{"Netflix": 1, "Hulu": 1, "Disney": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			keywords, err := service.GetPromptTopKeywords(shared.WithCountMode(context.Background(), tt.mode), "prompt-1", 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]int)
+			for _, keyword := range keywords {
+				got[keyword.Keyword] = keyword.Count
+			}
+			for keyword, want := range tt.want {
+				if got[keyword] != want {
+					t.Errorf("%s counted %d, want %d (all %v)", keyword, got[keyword], want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AI2HU/gego/internal/logger"
+	"github.com/AI2HU/gego/internal/models"
+	"github.com/AI2HU/gego/internal/shared"
+)
+
+// DefaultSnapshotKeywords is the number of top keywords a stats snapshot keeps by default
+const DefaultSnapshotKeywords = 50
+
+// StatsSnapshotJob is a stats snapshot the scheduler takes on a cron schedule
+type StatsSnapshotJob struct {
+	CronExpr string
+	Window   time.Duration // Period of responses each snapshot covers
+	Limit    int           // Top keywords kept in each snapshot
+}
+
+// TakeSnapshot computes the top keywords of the window ending at end and stores them with their
+// share of voice. Shares are measured against the mentions of every keyword of the window, not
+// only those kept.
+func (s *StatsService) TakeSnapshot(ctx context.Context, window time.Duration, end time.Time, limit int) (*models.StatsSnapshot, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("snapshot window must be positive")
+	}
+	if limit <= 0 {
+		limit = DefaultSnapshotKeywords
+	}
+
+	start := end.Add(-window)
+	keywords, err := s.db.GetTopKeywords(ctx, math.MaxInt32, &start, &end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top keywords: %w", err)
+	}
+
+	snapshot := NewStatsSnapshot(keywords, start, end, limit)
+	snapshot.CountMode = string(shared.CountModeFromContext(ctx))
+	if err := s.db.CreateStatsSnapshot(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// NewStatsSnapshot builds a snapshot of the period from start to end from its ranked keywords,
+// keeping the first limit of them
+func NewStatsSnapshot(keywords []models.KeywordCount, start, end time.Time, limit int) *models.StatsSnapshot {
+	snapshot := &models.StatsSnapshot{
+		ID:          uuid.New().String(),
+		CreatedAt:   time.Now(),
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Keywords:    []models.KeywordShare{},
+	}
+	for _, keyword := range keywords {
+		snapshot.TotalMentions += keyword.Count
+	}
+	for i, keyword := range keywords {
+		if limit > 0 && i >= limit {
+			break
+		}
+		share := models.KeywordShare{Keyword: keyword.Keyword, Count: keyword.Count}
+		if snapshot.TotalMentions > 0 {
+			share.Share = float64(keyword.Count) / float64(snapshot.TotalMentions) * 100
+		}
+		snapshot.Keywords = append(snapshot.Keywords, share)
+	}
+	return snapshot
+}
+
+// GetSnapshots returns the stored snapshots whose period ends between startTime and endTime,
+// oldest first
+func (s *StatsService) GetSnapshots(ctx context.Context, startTime, endTime *time.Time, limit int) ([]*models.StatsSnapshot, error) {
+	return s.db.ListStatsSnapshots(ctx, startTime, endTime, limit)
+}
+
+// KeywordTrends assembles the trend of each keyword across snapshots, which must be oldest first.
+// Keywords are matched case-insensitively; a snapshot not ranking a keyword adds a zero point.
+func KeywordTrends(snapshots []*models.StatsSnapshot, keywords []string) []models.KeywordTrend {
+	trends := make([]models.KeywordTrend, 0, len(keywords))
+	for _, keyword := range keywords {
+		trend := models.KeywordTrend{Keyword: keyword, Points: make([]models.KeywordTrendPoint, 0, len(snapshots))}
+		for _, snapshot := range snapshots {
+			point := models.KeywordTrendPoint{SnapshotID: snapshot.ID, Timestamp: snapshot.PeriodEnd}
+			for i, share := range snapshot.Keywords {
+				if strings.EqualFold(share.Keyword, keyword) {
+					point.Count = share.Count
+					point.Share = share.Share
+					point.Rank = i + 1
+					break
+				}
+			}
+			trend.Points = append(trend.Points, point)
+		}
+		trends = append(trends, trend)
+	}
+	return trends
+}
+
+// SetStatsSnapshots makes the scheduler take a stats snapshot on the cron schedule of job while it
+// runs
+func (s *SchedulerService) SetStatsSnapshots(job *StatsSnapshotJob) {
+	s.snapshotJob = job
+}
+
+// registerStatsSnapshots adds the stats snapshot job to cron
+func (s *SchedulerService) registerStatsSnapshots() {
+	if s.snapshotJob == nil {
+		return
+	}
+
+	job := *s.snapshotJob
+	entryID, err := s.cron.AddFunc(job.CronExpr, func() {
+		snapshot, err := NewStatsService(s.db).TakeSnapshot(context.Background(), job.Window, time.Now(), job.Limit)
+		if err != nil {
+			logger.Error("Failed to take stats snapshot: %v", err)
+			return
+		}
+		logger.Info("Took stats snapshot %s (%d keywords)", snapshot.ID, len(snapshot.Keywords))
+	})
+	if err != nil {
+		logger.Error("Failed to register stats snapshots: %v", err)
+		return
+	}
+	s.reportEntries = append(s.reportEntries, entryID)
+	logger.Info("Registered stats snapshots with cron expression: %s (window %v)", job.CronExpr, job.Window)
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// snapshotsDB serves GetTopKeywords from mentions and stores stats snapshots in memory
+type snapshotsDB struct {
+	mentionsDB
+	snapshots []*models.StatsSnapshot
+}
+
+func (s *snapshotsDB) CreateStatsSnapshot(ctx context.Context, snapshot *models.StatsSnapshot) error {
+	stored := *snapshot
+	stored.Keywords = append([]models.KeywordShare(nil), snapshot.Keywords...)
+	s.snapshots = append(s.snapshots, &stored)
+	return nil
+}
+
+func (s *snapshotsDB) ListStatsSnapshots(ctx context.Context, startTime, endTime *time.Time, limit int) ([]*models.StatsSnapshot, error) {
+	var result []*models.StatsSnapshot
+	for _, snapshot := range s.snapshots {
+		if startTime != nil && snapshot.PeriodEnd.Before(*startTime) {
+			continue
+		}
+		if endTime != nil && snapshot.PeriodEnd.After(*endTime) {
+			continue
+		}
+		result = append(result, snapshot)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].PeriodEnd.Before(result[j].PeriodEnd) })
+	if limit > 0 && len(result) > limit {
+		result = result[len(result)-limit:]
+	}
+	return result, nil
+}
+
+func TestTakeSnapshotAndKeywordTrends(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	mentions := func(d int, keyword string, count int) []mention {
+		var result []mention
+		for range count {
+			result = append(result, mention{keyword: keyword, createdAt: day(d).Add(12 * time.Hour)})
+		}
+		return result
+	}
+
+	database := &snapshotsDB{}
+	for _, m := range [][]mention{
+		mentions(1, "Netflix", 3), mentions(1, "Hulu", 1),
+		mentions(2, "Netflix", 2), mentions(2, "Hulu", 3),
+		mentions(3, "Netflix", 1), mentions(3, "Hulu", 3),
+	} {
+		database.mentions = append(database.mentions, m...)
+	}
+	service := NewStatsService(database)
+	ctx := context.Background()
+
+	if _, err := service.TakeSnapshot(ctx, 0, day(2), 10); err == nil {
+		t.Error("TakeSnapshot() with an empty window succeeded, want an error")
+	}
+
+	// Take the last snapshot first, keeping only the top keyword
+	for _, take := range []struct {
+		end   time.Time
+		limit int
+	}{{day(4), 1}, {day(2), 10}, {day(3), 10}} {
+		if _, err := service.TakeSnapshot(ctx, 24*time.Hour, take.end, take.limit); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshots, err := service.GetSnapshots(ctx, nil, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 3 {
+		t.Fatalf("read back %d snapshots, want 3", len(snapshots))
+	}
+	for i, wantEnd := range []time.Time{day(2), day(3), day(4)} {
+		if !snapshots[i].PeriodEnd.Equal(wantEnd) || !snapshots[i].PeriodStart.Equal(wantEnd.Add(-24*time.Hour)) {
+			t.Errorf("snapshot %d covers %v to %v, want the day before %v, oldest first", i, snapshots[i].PeriodStart, snapshots[i].PeriodEnd, wantEnd)
+		}
+		if snapshots[i].CountMode != "occurrences" {
+			t.Errorf("snapshot %d count mode = %q, want occurrences", i, snapshots[i].CountMode)
+		}
+	}
+	if snapshots[2].TotalMentions != 4 || len(snapshots[2].Keywords) != 1 {
+		t.Errorf("last snapshot = %d mentions, keywords %v, want 4 mentions and the top keyword only", snapshots[2].TotalMentions, snapshots[2].Keywords)
+	}
+
+	recent, err := service.GetSnapshots(ctx, nil, nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recent) != 2 || !recent[0].PeriodEnd.Equal(day(3)) || !recent[1].PeriodEnd.Equal(day(4)) {
+		t.Errorf("GetSnapshots(limit 2) = %d snapshots, want the 2 most recent, oldest first", len(recent))
+	}
+
+	type point struct {
+		count int
+		share float64
+		rank  int
+	}
+	want := map[string][]point{
+		// Shares are measured against every mention, so a keyword dropped by the limit still counts
+		"netflix": {{3, 75, 1}, {2, 40, 2}, {0, 0, 0}},
+		"Hulu":    {{1, 25, 2}, {3, 60, 1}, {3, 75, 1}},
+		"Disney":  {{0, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+	}
+
+	trends := KeywordTrends(snapshots, []string{"netflix", "Hulu", "Disney"})
+	if len(trends) != 3 {
+		t.Fatalf("got %d trends, want 3", len(trends))
+	}
+	for _, trend := range trends {
+		wantPoints := want[trend.Keyword]
+		if len(trend.Points) != len(wantPoints) {
+			t.Fatalf("%s has %d points, want %d", trend.Keyword, len(trend.Points), len(wantPoints))
+		}
+		for i, got := range trend.Points {
+			if got.SnapshotID != snapshots[i].ID || !got.Timestamp.Equal(snapshots[i].PeriodEnd) {
+				t.Errorf("%s point %d is from snapshot %s at %v, want %s at %v", trend.Keyword, i, got.SnapshotID, got.Timestamp, snapshots[i].ID, snapshots[i].PeriodEnd)
+			}
+			if got.Count != wantPoints[i].count || math.Abs(got.Share-wantPoints[i].share) > 1e-9 || got.Rank != wantPoints[i].rank {
+				t.Errorf("%s point %d = count %d, share %.2f, rank %d, want %+v", trend.Keyword, i, got.Count, got.Share, got.Rank, wantPoints[i])
+			}
+		}
+	}
+}
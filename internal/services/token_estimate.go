@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+// DefaultMaxTokens is the completion token limit used when an LLM has no max_tokens configured
+const DefaultMaxTokens = 1000
+
+// Token estimation methods reported by EstimateTokens
+const (
+	TokenMethodBPE       = "bpe-approx" // Approximation of OpenAI's cl100k BPE
+	TokenMethodHeuristic = "chars/4"    // Four characters per token
+)
+
+// estimateWindow is the period over which schedule runs are projected
+const estimateWindow = 30 * 24 * time.Hour
+
+// bpeChunkPattern mirrors the cl100k pre-tokenizer: contractions, words with their leading
+// space, numbers in groups of up to three digits, punctuation runs and whitespace
+var bpeChunkPattern = regexp.MustCompile(`(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\pL\pN]?\pL+|\pN{1,3}| ?[^\s\pL\pN]+[\r\n]*|\s+`)
+
+// LLMTokenEstimate is the estimated token footprint of a prompt on one LLM
+type LLMTokenEstimate struct {
+	LLM                 *models.LLMConfig
+	PromptTokens        int
+	MaxCompletionTokens int
+	Method              string
+}
+
+// ScheduleTokenEstimate is the projected monthly token volume of a prompt in one schedule
+type ScheduleTokenEstimate struct {
+	Schedule      *models.Schedule
+	RunsPerMonth  int
+	MonthlyTokens int // Prompt plus maximum completion tokens across the schedule's LLMs
+}
+
+// PromptTokenEstimate is the token footprint of a prompt across configured LLMs and schedules
+type PromptTokenEstimate struct {
+	Prompt        *models.Prompt
+	LLMs          []LLMTokenEstimate
+	Schedules     []ScheduleTokenEstimate
+	MonthlyTokens int
+}
+
+// EstimatePromptTokens estimates a prompt's tokens for every configured LLM and its projected monthly
+// volume in the enabled schedules that reference it. No provider is called.
+func (s *PromptManagementService) EstimatePromptTokens(ctx context.Context, promptID string) (*PromptTokenEstimate, error) {
+	prompt, err := s.db.GetPrompt(ctx, promptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt: %w", err)
+	}
+
+	llms, err := s.db.ListLLMs(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LLMs: %w", err)
+	}
+
+	estimate := &PromptTokenEstimate{Prompt: prompt}
+	byLLM := make(map[string]LLMTokenEstimate, len(llms))
+	for _, llmConfig := range llms {
+		tokens, method := EstimateTokens(llmConfig.Provider, prompt.Template)
+		llmEstimate := LLMTokenEstimate{
+			LLM:                 llmConfig,
+			PromptTokens:        tokens,
+			MaxCompletionTokens: MaxCompletionTokens(llmConfig),
+			Method:              method,
+		}
+		estimate.LLMs = append(estimate.LLMs, llmEstimate)
+		byLLM[llmConfig.ID] = llmEstimate
+	}
+
+	enabled := true
+	schedules, err := s.db.ListSchedules(ctx, &enabled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	now := time.Now()
+	for _, schedule := range schedules {
+		if !schedule.AllPrompts && !containsID(schedule.PromptIDs, promptID) {
+			continue
+		}
+
+		runs, err := CountRuns(schedule.CronExpr, now, now.Add(estimateWindow))
+		if err != nil {
+			return nil, err
+		}
+
+		perRun := 0
+		if schedule.AllLLMs {
+			for _, llmEstimate := range estimate.LLMs {
+				if llmEstimate.LLM.Enabled {
+					perRun += llmEstimate.PromptTokens + llmEstimate.MaxCompletionTokens
+				}
+			}
+		} else {
+			for _, llmID := range schedule.LLMIDs {
+				if llmEstimate, ok := byLLM[llmID]; ok {
+					perRun += llmEstimate.PromptTokens + llmEstimate.MaxCompletionTokens
+				}
+			}
+		}
+
+		scheduleEstimate := ScheduleTokenEstimate{
+			Schedule:      schedule,
+			RunsPerMonth:  runs,
+			MonthlyTokens: runs * perRun,
+		}
+		estimate.Schedules = append(estimate.Schedules, scheduleEstimate)
+		estimate.MonthlyTokens += scheduleEstimate.MonthlyTokens
+	}
+
+	return estimate, nil
+}
+
+// EstimateTokens approximates the number of tokens in text for a provider, returning the method used.
+// OpenAI models get a cl100k BPE approximation; other providers use a chars/4 heuristic.
+func EstimateTokens(provider, text string) (int, string) {
+	if text == "" {
+		return 0, TokenMethodHeuristic
+	}
+
+	if FromString(provider) != OpenAI {
+		return (utf8.RuneCountInString(text) + 3) / 4, TokenMethodHeuristic
+	}
+
+	tokens := 0
+	for _, chunk := range bpeChunkPattern.FindAllString(text, -1) {
+		tokens += bpeChunkTokens(chunk)
+	}
+	return tokens, TokenMethodBPE
+}
+
+// bpeChunkTokens approximates the tokens of one pre-tokenized chunk: common words are a single token,
+// longer ones split roughly every six ASCII characters, and non-ASCII text costs about a token per two bytes
+func bpeChunkTokens(chunk string) int {
+	if utf8.RuneCountInString(chunk) != len(chunk) {
+		return (len(chunk) + 1) / 2
+	}
+	if len(chunk) <= 6 {
+		return 1
+	}
+	return (len(chunk) + 5) / 6
+}
+
+// MaxCompletionTokens returns the LLM's configured max_tokens, or DefaultMaxTokens
+func MaxCompletionTokens(llmConfig *models.LLMConfig) int {
+	if value, ok := llmConfig.Config["max_tokens"]; ok {
+		if maxTokens, err := strconv.Atoi(value); err == nil && maxTokens >= 1 {
+			return maxTokens
+		}
+	}
+	return DefaultMaxTokens
+}
+
+// CountRuns counts the fire times of a cron expression in (from, to]
+func CountRuns(cronExpr string, from, to time.Time) (int, error) {
+	cronSchedule, err := ParseCron(cronExpr)
+	if err != nil {
+		return 0, err
+	}
+
+	runs := 0
+	for next := cronSchedule.Next(from); !next.After(to); next = cronSchedule.Next(next) {
+		runs++
+	}
+	return runs, nil
+}
+
+// containsID reports whether ids includes id
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/models"
+)
+
+func TestMaxCompletionTokens(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]string
+		want   int
+	}{
+		{name: "no config", want: DefaultMaxTokens},
+		{name: "configured", config: map[string]string{"max_tokens": "4096"}, want: 4096},
+		{name: "zero", config: map[string]string{"max_tokens": "0"}, want: DefaultMaxTokens},
+		{name: "negative", config: map[string]string{"max_tokens": "-5"}, want: DefaultMaxTokens},
+		{name: "malformed", config: map[string]string{"max_tokens": "lots"}, want: DefaultMaxTokens},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			llmConfig := &models.LLMConfig{Provider: "openai", Model: "gpt-4o", Config: tt.config}
+			if got := MaxCompletionTokens(llmConfig); got != tt.want {
+				t.Errorf("MaxCompletionTokens = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		provider   string
+		text       string
+		wantTokens int
+		wantMethod string
+	}{
+		{provider: "anthropic", text: "What is the best CRM?", wantTokens: 6, wantMethod: TokenMethodHeuristic},
+		{provider: "openai", text: "What is the best CRM?", wantTokens: 6, wantMethod: TokenMethodBPE},
+		{provider: "openai", text: "", wantTokens: 0, wantMethod: TokenMethodHeuristic},
+	}
+
+	for _, tt := range tests {
+		tokens, method := EstimateTokens(tt.provider, tt.text)
+		if tokens != tt.wantTokens || method != tt.wantMethod {
+			t.Errorf("EstimateTokens(%s, %q) = %d %s, want %d %s", tt.provider, tt.text, tokens, method, tt.wantTokens, tt.wantMethod)
+		}
+	}
+}
+
+func TestEstimatePromptTokensUsesConfiguredMaxTokens(t *testing.T) {
+	database := newMemoryDB()
+	database.prompts["prompt-1"] = &models.Prompt{ID: "prompt-1", Template: "What is the best CRM?", Enabled: true}
+	database.llms["llm-1"] = &models.LLMConfig{ID: "llm-1", Provider: "openai", Model: "gpt-4o", Enabled: true, Config: map[string]string{"max_tokens": "300"}}
+	database.llms["llm-2"] = &models.LLMConfig{ID: "llm-2", Provider: "anthropic", Model: "claude", Enabled: true}
+	database.schedules["schedule-1"] = &models.Schedule{ID: "schedule-1", PromptIDs: []string{"prompt-1"}, LLMIDs: []string{"llm-1"}, CronExpr: "0 9 * * *", Enabled: true}
+
+	estimate, err := NewPromptManagementService(database).EstimatePromptTokens(context.Background(), "prompt-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	maxTokens := map[string]int{}
+	for _, llmEstimate := range estimate.LLMs {
+		maxTokens[llmEstimate.LLM.ID] = llmEstimate.MaxCompletionTokens
+	}
+	if maxTokens["llm-1"] != 300 || maxTokens["llm-2"] != DefaultMaxTokens {
+		t.Errorf("max completion tokens = %v, want llm-1 300 and llm-2 %d", maxTokens, DefaultMaxTokens)
+	}
+
+	if len(estimate.Schedules) != 1 {
+		t.Fatalf("got %d schedules, want 1", len(estimate.Schedules))
+	}
+	schedule := estimate.Schedules[0]
+	if want := schedule.RunsPerMonth * (6 + 300); schedule.MonthlyTokens != want {
+		t.Errorf("monthly tokens = %d, want %d (%d runs)", schedule.MonthlyTokens, want, schedule.RunsPerMonth)
+	}
+}
@@ -0,0 +1,14 @@
+package shared
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// ContentHash returns the SHA-256 hex digest of a response text with runs of whitespace
+// collapsed, so answers differing only in line wrapping or trailing spaces hash the same
+func ContentHash(text string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(text), " ")))
+	return hex.EncodeToString(sum[:])
+}
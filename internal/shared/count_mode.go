@@ -0,0 +1,68 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CountMode tells keyword stats whether to count every mention of a keyword or the responses
+// mentioning it
+type CountMode string
+
+const (
+	// CountModeOccurrences counts every mention, so a response naming a keyword three times counts 3
+	CountModeOccurrences CountMode = "occurrences"
+	// CountModeResponses counts the responses mentioning a keyword, each once however often it is named
+	CountModeResponses CountMode = "responses"
+)
+
+type countModeKey struct{}
+
+// ParseCountMode parses occurrences or responses; an empty value means occurrences
+func ParseCountMode(mode string) (CountMode, error) {
+	switch CountMode(strings.ToLower(strings.TrimSpace(mode))) {
+	case "", CountModeOccurrences:
+		return CountModeOccurrences, nil
+	case CountModeResponses:
+		return CountModeResponses, nil
+	default:
+		return "", fmt.Errorf("invalid count mode %q: must be %s or %s", mode, CountModeOccurrences, CountModeResponses)
+	}
+}
+
+// WithCountMode makes keyword search and top keyword queries made with ctx count in mode
+func WithCountMode(ctx context.Context, mode CountMode) context.Context {
+	return context.WithValue(ctx, countModeKey{}, mode)
+}
+
+// CountModeFromContext returns the count mode of keyword stats queries made with ctx,
+// CountModeOccurrences by default
+func CountModeFromContext(ctx context.Context) CountMode {
+	if mode, ok := ctx.Value(countModeKey{}).(CountMode); ok && mode != "" {
+		return mode
+	}
+	return CountModeOccurrences
+}
+
+// Count returns the number of mentions of a keyword in one response as counted in mode
+func (m CountMode) Count(mentions int) int {
+	if m == CountModeResponses && mentions > 0 {
+		return 1
+	}
+	return mentions
+}
+
+// AddWords adds the keywords extracted from one response to counts, once each in responses mode
+func (m CountMode) AddWords(counts map[string]int, words []string) {
+	counted := make(map[string]bool, len(words))
+	for _, word := range words {
+		if m == CountModeResponses {
+			if counted[word] {
+				continue
+			}
+			counted[word] = true
+		}
+		counts[word]++
+	}
+}
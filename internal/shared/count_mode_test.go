@@ -0,0 +1,70 @@
+package shared
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseCountMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		want    CountMode
+		wantErr bool
+	}{
+		{mode: "", want: CountModeOccurrences},
+		{mode: "occurrences", want: CountModeOccurrences},
+		{mode: " Responses ", want: CountModeResponses},
+		{mode: "documents", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			got, err := ParseCountMode(tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCountMode(%q) error = %v, wantErr %v", tt.mode, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCountMode(%q) = %q, want %q", tt.mode, got, tt.want)
+			}
+		})
+	}
+
+	if got := CountModeFromContext(context.Background()); got != CountModeOccurrences {
+		t.Errorf("default count mode = %q, want %q", got, CountModeOccurrences)
+	}
+}
+
+func TestCountModeCountsMentions(t *testing.T) {
+	response := "Netflix leads streaming. Netflix has the largest catalog, and Netflix original series win awards. Hulu follows."
+	responses := []string{response, "Hulu is cheaper."}
+
+	tests := []struct {
+		mode        CountMode
+		wantNetflix int
+		wantWords   map[string]int
+	}{
+		{mode: CountModeOccurrences, wantNetflix: 3, wantWords: map[string]int{"Netflix": 3, "Hulu": 2}},
+		{mode: CountModeResponses, wantNetflix: 1, wantWords: map[string]int{"Netflix": 1, "Hulu": 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			if got := tt.mode.Count(CountKeyword(response, "Netflix")); got != tt.wantNetflix {
+				t.Errorf("Count(Netflix) = %d, want %d", got, tt.wantNetflix)
+			}
+			if got := tt.mode.Count(CountKeyword(response, "Disney")); got != 0 {
+				t.Errorf("Count(Disney) = %d, want 0", got)
+			}
+
+			counts := make(map[string]int)
+			for _, text := range responses {
+				tt.mode.AddWords(counts, ExtractCapitalizedWords(text))
+			}
+			for word, want := range tt.wantWords {
+				if counts[word] != want {
+					t.Errorf("AddWords counted %s %d times, want %d (all counts %v)", word, counts[word], want, counts)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package shared
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResponseCursor is the position of a response in a listing order: newest first (created_at
+// descending, then ID ascending) or its reverse. Listing after a cursor in the same order resumes
+// right after that response.
+type ResponseCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// NewResponseCursor returns the cursor of the response created at createdAt with the given ID
+func NewResponseCursor(createdAt time.Time, id string) *ResponseCursor {
+	return &ResponseCursor{CreatedAt: createdAt, ID: id}
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c *ResponseCursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixMilli(), 10) + ":" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseResponseCursor decodes a token returned by ResponseCursor.Encode
+func ParseResponseCursor(token string) (*ResponseCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", token)
+	}
+	millis, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, fmt.Errorf("invalid cursor %q", token)
+	}
+	ms, err := strconv.ParseInt(millis, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", token)
+	}
+	return &ResponseCursor{CreatedAt: time.UnixMilli(ms).UTC(), ID: id}, nil
+}
@@ -0,0 +1,37 @@
+package shared
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// linkedDomainPattern matches the host of URLs with a scheme or a www. prefix
+	linkedDomainPattern = regexp.MustCompile(`(?i)(?:\b[a-z][a-z0-9+.-]*://|\bwww\.)([a-z0-9-]+(?:\.[a-z0-9-]+)*\.[a-z]{2,24})\b`)
+	// bareDomainPattern matches bare domains such as example.com; a known TLD is required so that
+	// file names and abbreviations are not mistaken for domains
+	bareDomainPattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9_@/.-])((?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+(?:com|net|org|io|co|ai|app|dev|edu|gov|info|biz|me|tv|us|uk|de|fr|es|it|nl|eu|ch|ca|au|jp|in|br|se|no|news|shop|store|tech|xyz|blog|cloud))\b(?:[^.@a-z0-9-]|\.(?:\s|$)|$)`)
+)
+
+// ExtractDomains returns the distinct domains of the URLs and bare domains mentioned in text,
+// lowercased and without a leading "www.", in order of first mention
+func ExtractDomains(text string) []string {
+	var domains []string
+	seen := make(map[string]bool)
+	add := func(domain string) {
+		domain = strings.TrimPrefix(strings.ToLower(strings.Trim(domain, ".-")), "www.")
+		if domain == "" || !strings.Contains(domain, ".") || seen[domain] {
+			return
+		}
+		seen[domain] = true
+		domains = append(domains, domain)
+	}
+
+	for _, match := range linkedDomainPattern.FindAllStringSubmatch(text, -1) {
+		add(match[1])
+	}
+	for _, match := range bareDomainPattern.FindAllStringSubmatch(text, -1) {
+		add(match[1])
+	}
+	return domains
+}
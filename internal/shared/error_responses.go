@@ -0,0 +1,24 @@
+package shared
+
+import "context"
+
+type includeErrorsKey struct{}
+
+// WithErrorResponses makes keyword search and stats queries made with ctx count responses that
+// recorded a provider error, which they skip by default. Reliability stats always count them.
+func WithErrorResponses(ctx context.Context, include bool) context.Context {
+	if !include {
+		return ctx
+	}
+	return context.WithValue(ctx, includeErrorsKey{}, true)
+}
+
+// ErrorFilterFromContext returns the ResponseFilter.HasError of keyword stats queries made with ctx:
+// nil when error responses are counted, false otherwise
+func ErrorFilterFromContext(ctx context.Context) *bool {
+	if include, _ := ctx.Value(includeErrorsKey{}).(bool); include {
+		return nil
+	}
+	hasError := false
+	return &hasError
+}
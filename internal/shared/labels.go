@@ -0,0 +1,42 @@
+package shared
+
+import (
+	"context"
+	"strings"
+)
+
+type excludedLabelsKey struct{}
+
+// WithExcludedLabels makes keyword search and stats queries made with ctx skip responses annotated
+// with any of labels. Empty labels leave ctx unchanged.
+func WithExcludedLabels(ctx context.Context, labels []string) context.Context {
+	labels = NormalizeLabels(labels)
+	if len(labels) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, excludedLabelsKey{}, labels)
+}
+
+// ExcludedLabelsFromContext returns the annotation labels ctx excludes, if any
+func ExcludedLabelsFromContext(ctx context.Context) []string {
+	labels, _ := ctx.Value(excludedLabelsKey{}).([]string)
+	return labels
+}
+
+// NormalizeLabel trims and lowercases an annotation label
+func NormalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
+// NormalizeLabels normalizes labels, splitting comma-separated values and dropping empty ones
+func NormalizeLabels(labels []string) []string {
+	var normalized []string
+	for _, value := range labels {
+		for _, label := range strings.Split(value, ",") {
+			if label = NormalizeLabel(label); label != "" {
+				normalized = append(normalized, label)
+			}
+		}
+	}
+	return normalized
+}
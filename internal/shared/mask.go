@@ -0,0 +1,62 @@
+package shared
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// APIKeyNotSet is displayed in place of a missing API key
+const APIKeyNotSet = "(not set)"
+
+// MaskOptions controls how MaskAPIKeyWith hides an API key. Zero fields take the value of
+// DefaultMaskOptions.
+type MaskOptions struct {
+	MaskChar  rune // Repeated to stand for keys too short to show any of
+	Visible   int  // Most characters shown at each end of longer keys
+	MinLength int  // Keys with fewer characters are hidden entirely
+}
+
+// DefaultMaskOptions shows up to 4 characters at each end of keys of 9 characters or more
+var DefaultMaskOptions = MaskOptions{MaskChar: '*', Visible: 4, MinLength: 9}
+
+// MaskAPIKey masks apiKey for display with DefaultMaskOptions; an empty key stays empty
+func MaskAPIKey(apiKey string) string {
+	return MaskAPIKeyWith(apiKey, DefaultMaskOptions)
+}
+
+// MaskAPIKeyWith masks apiKey for display, counting characters rather than bytes so that
+// multi-byte keys are never cut inside a character. At most a quarter of the key is shown, so
+// short keys show fewer than opts.Visible characters at each end. Fully hidden keys always show
+// three mask characters, so that their length does not leak.
+func MaskAPIKeyWith(apiKey string, opts MaskOptions) string {
+	if apiKey == "" {
+		return ""
+	}
+	if opts.MaskChar == 0 {
+		opts.MaskChar = DefaultMaskOptions.MaskChar
+	}
+	if opts.Visible <= 0 {
+		opts.Visible = DefaultMaskOptions.Visible
+	}
+	if opts.MinLength <= 0 {
+		opts.MinLength = DefaultMaskOptions.MinLength
+	}
+
+	runes := []rune(apiKey)
+	visible := min(opts.Visible, len(runes)/8)
+	if len(runes) < opts.MinLength || visible == 0 {
+		return strings.Repeat(string(opts.MaskChar), 3)
+	}
+	return string(runes[:visible]) + "..." + string(runes[len(runes)-visible:])
+}
+
+// APIKeyFingerprint returns a SHA-256 fingerprint of apiKey, so that keys can be compared without
+// holding both in plain text; an empty key has an empty fingerprint
+func APIKeyFingerprint(apiKey string) string {
+	if apiKey == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,179 @@
+package shared
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMaskAPIKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		apiKey string
+		want   string
+	}{
+		{name: "empty", apiKey: "", want: ""},
+		{name: "single character", apiKey: "x", want: "***"},
+		{name: "below minimum length", apiKey: "sk-12345", want: "***"},
+		{name: "minimum length shows a quarter", apiKey: "sk-123456", want: "s...6"},
+		{name: "sixteen characters", apiKey: "sk-0123456789abc", want: "sk...bc"},
+		{name: "long key", apiKey: "sk-proj-0123456789abcdefghijklmnopqrstuvwxyz", want: "sk-p...wxyz"},
+		{name: "unicode at minimum length", apiKey: "ключ-тест", want: "к...т"},
+		{name: "unicode kept whole", apiKey: "ключ-ключ-ключ-тест", want: "кл...ст"},
+		{name: "emoji", apiKey: "🔑🔑🔑🔑🔑🔑🔑🔑🔑🔑🔑🔑🔑🔑🔑🔑", want: "🔑🔑...🔑🔑"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MaskAPIKey(tt.apiKey)
+			if got != tt.want {
+				t.Errorf("MaskAPIKey(%q) = %q, want %q", tt.apiKey, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("MaskAPIKey(%q) = %q, which is not valid UTF-8", tt.apiKey, got)
+			}
+		})
+	}
+}
+
+func TestMaskAPIKeyShowsAtMostAQuarter(t *testing.T) {
+	for length := 1; length <= 64; length++ {
+		apiKey := strings.Repeat("k", length)
+		shown := utf8.RuneCountInString(strings.ReplaceAll(strings.ReplaceAll(MaskAPIKey(apiKey), "...", ""), "*", ""))
+		if 4*shown > length {
+			t.Errorf("key of %d characters shows %d of them", length, shown)
+		}
+	}
+}
+
+func TestMaskAPIKeyWith(t *testing.T) {
+	tests := []struct {
+		name   string
+		apiKey string
+		opts   MaskOptions
+		want   string
+	}{
+		{name: "zero options use the defaults", apiKey: "sk-0123456789abc", want: "sk...bc"},
+		{name: "mask character", apiKey: "short", opts: MaskOptions{MaskChar: '#'}, want: "###"},
+		{name: "fewer visible characters", apiKey: "sk-proj-0123456789abcdefghijklmnopqrstuvwxyz", opts: MaskOptions{Visible: 2}, want: "sk...yz"},
+		{name: "higher minimum length", apiKey: "sk-0123456789abc", opts: MaskOptions{MinLength: 20}, want: "***"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaskAPIKeyWith(tt.apiKey, tt.opts); got != tt.want {
+				t.Errorf("MaskAPIKeyWith(%q, %+v) = %q, want %q", tt.apiKey, tt.opts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIKeyFingerprint(t *testing.T) {
+	if got := APIKeyFingerprint(""); got != "" {
+		t.Errorf("fingerprint of an empty key = %q, want empty", got)
+	}
+	first := APIKeyFingerprint("sk-first-key-0001")
+	if len(first) != 64 || strings.Contains(first, "sk-first") {
+		t.Errorf("fingerprint = %q, want a SHA-256 hex digest", first)
+	}
+	if first != APIKeyFingerprint("sk-first-key-0001") {
+		t.Error("fingerprint of the same key differs")
+	}
+	if first == APIKeyFingerprint("sk-first-key-0002") {
+		t.Error("different keys share a fingerprint")
+	}
+}
+
+// outputPackages are the packages whose calls write text a user or a log file may see
+var outputPackages = map[string]bool{"logger": true, "fmt": true, "log": true}
+
+// maskFunctions hide the API keys passed to them
+var maskFunctions = map[string]bool{"MaskAPIKey": true, "MaskAPIKeyWith": true, "APIKeyFingerprint": true}
+
+// TestAPIKeysMaskedInOutput fails on logger, fmt and log calls passed an APIKey field or an
+// apiKey variable other than through a mask function
+func TestAPIKeysMaskedInOutput(t *testing.T) {
+	fset := token.NewFileSet()
+	checked := 0
+	err := filepath.WalkDir("..", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		checked++
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || !isOutputCall(call) {
+				return true
+			}
+			for _, arg := range call.Args {
+				if leak := unmaskedAPIKey(arg); leak != nil {
+					t.Errorf("%s: API key passed to %s unmasked; wrap it in shared.MaskAPIKey", fset.Position(leak.Pos()), callName(call))
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checked == 0 {
+		t.Error("found no Go sources to check")
+	}
+}
+
+// isOutputCall reports whether call is a function of an output package, such as logger.Info
+func isOutputCall(call *ast.CallExpr) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := selector.X.(*ast.Ident)
+	return ok && outputPackages[pkg.Name] && !strings.HasPrefix(selector.Sel.Name, "Sprint")
+}
+
+// unmaskedAPIKey returns the APIKey field or apiKey variable used in expr outside of a mask function
+func unmaskedAPIKey(expr ast.Expr) ast.Node {
+	var leak ast.Node
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if leak != nil {
+			return false
+		}
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			if maskFunctions[callName(n)] {
+				return false
+			}
+		case *ast.SelectorExpr:
+			if n.Sel.Name == "APIKey" {
+				leak = n
+			}
+		case *ast.Ident:
+			if n.Name == "apiKey" {
+				leak = n
+			}
+		}
+		return true
+	})
+	return leak
+}
+
+// callName returns the name of the function call calls, without its package
+func callName(call *ast.CallExpr) string {
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	case *ast.Ident:
+		return fun.Name
+	}
+	return ""
+}
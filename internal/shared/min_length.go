@@ -0,0 +1,57 @@
+package shared
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// MinLengthFilter excludes responses shorter than Min characters, such as refusals, from keyword
+// stats and counts how many it excluded
+type MinLengthFilter struct {
+	Min      int
+	excluded atomic.Int64
+}
+
+type minLengthKey struct{}
+
+// WithMinResponseLength makes keyword stats queries made with ctx skip responses shorter than min
+// characters. The returned filter reports how many were skipped; it is nil and ctx is unchanged
+// when min is not positive.
+func WithMinResponseLength(ctx context.Context, min int) (context.Context, *MinLengthFilter) {
+	if min <= 0 {
+		return ctx, nil
+	}
+	filter := &MinLengthFilter{Min: min}
+	return context.WithValue(ctx, minLengthKey{}, filter), filter
+}
+
+// MinLengthFilterFromContext returns the minimum response length filter of ctx, if any
+func MinLengthFilterFromContext(ctx context.Context) *MinLengthFilter {
+	filter, _ := ctx.Value(minLengthKey{}).(*MinLengthFilter)
+	return filter
+}
+
+// Exclude reports whether text is too short to count, counting it as excluded if so.
+// A nil filter excludes nothing.
+func (f *MinLengthFilter) Exclude(text string) bool {
+	if f == nil || !TooShort(text, f.Min) {
+		return false
+	}
+	f.excluded.Add(1)
+	return true
+}
+
+// Excluded returns the number of responses excluded so far
+func (f *MinLengthFilter) Excluded() int {
+	if f == nil {
+		return 0
+	}
+	return int(f.excluded.Load())
+}
+
+// TooShort reports whether text, ignoring surrounding whitespace, has fewer than min characters
+func TooShort(text string, min int) bool {
+	return min > 0 && utf8.RuneCountInString(strings.TrimSpace(text)) < min
+}
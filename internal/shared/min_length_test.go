@@ -0,0 +1,51 @@
+package shared
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTooShort(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		min  int
+		want bool
+	}{
+		{name: "refusal", text: "I can't help with that.", min: 50, want: true},
+		{name: "long enough", text: "Notion, Obsidian and Evernote are the best note apps.", min: 50},
+		{name: "surrounding whitespace ignored", text: "   Notion   \n", min: 7, want: true},
+		{name: "characters not bytes", text: "été café", min: 8},
+		{name: "no minimum", text: "", min: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TooShort(tt.text, tt.min); got != tt.want {
+				t.Errorf("TooShort(%q, %d) = %t, want %t", tt.text, tt.min, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinLengthFilter(t *testing.T) {
+	if ctx, filter := WithMinResponseLength(context.Background(), 0); filter != nil || MinLengthFilterFromContext(ctx) != nil {
+		t.Fatal("a zero minimum should not install a filter")
+	}
+
+	ctx, filter := WithMinResponseLength(context.Background(), 10)
+	if MinLengthFilterFromContext(ctx) != filter {
+		t.Fatal("filter not found in context")
+	}
+	for _, text := range []string{"Sorry.", "No.", "Notion is the best note app."} {
+		filter.Exclude(text)
+	}
+	if got := filter.Excluded(); got != 2 {
+		t.Errorf("Excluded() = %d, want 2", got)
+	}
+
+	var none *MinLengthFilter
+	if none.Exclude("") || none.Excluded() != 0 {
+		t.Error("a nil filter should exclude nothing")
+	}
+}
@@ -0,0 +1,249 @@
+package shared
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/AI2HU/gego/internal/config"
+)
+
+var (
+	keywordOptions    map[string]config.KeywordOptions // Keyed by lowercased keyword
+	keywordExclusions map[string][]keywordExclusion    // Compiled exclusion patterns, keyed like keywordOptions
+	keywordOptionsMu  sync.RWMutex
+
+	// codeFencePattern matches the opening or closing line of a fenced code block
+	codeFencePattern = regexp.MustCompile("(?m)^\\s*(```|~~~)[^\\n]*$")
+	// markdownLinkPattern matches [label](url) links and images
+	markdownLinkPattern = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]*)[^)]*\)`)
+	// emphasisPattern matches bold, italic and strikethrough markers around text
+	emphasisPattern = regexp.MustCompile(`(\*\*|__|~~)(.+?)(\*\*|__|~~)|\*([^*\s][^*]*?)\*|\b_([^_\s][^_]*?)_\b`)
+	// headingPattern matches the # markers of ATX headings
+	headingPattern = regexp.MustCompile(`(?m)^ {0,3}#{1,6}[ \t]+`)
+	// blockquotePattern matches the > markers of block quotes
+	blockquotePattern = regexp.MustCompile(`(?m)^[ \t]*>[ \t]?`)
+	// ruleLinePattern matches horizontal rules such as --- or ***
+	ruleLinePattern = regexp.MustCompile(`(?m)^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	// urlPattern matches URLs and bare domains such as netflix.com
+	urlPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:com|net|org|io|co|ai|app|dev|tv|fr|de|uk|us)\b(?:/\S*)?`)
+
+	// punctuationReplacer folds typographic quotes, dashes and spaces to their ASCII forms
+	punctuationReplacer = strings.NewReplacer(
+		"‘", "'", "’", "'", "‚", "'", "‛", "'", "′", "'",
+		"“", `"`, "”", `"`, "„", `"`, "‟", `"`, "″", `"`,
+		"‐", "-", "‑", "-", "‒", "-", "–", "-", "—", "-", "―", "-", "−", "-",
+		" ", " ", " ", " ", " ", " ",
+		"`", "",
+	)
+)
+
+// KeywordPattern returns a regex matching keyword literally, with ASCII quotes, dashes and spaces
+// also matching their typographic forms. It prefilters text before CountKeyword.
+func KeywordPattern(keyword string) string {
+	var pattern strings.Builder
+	for _, r := range NormalizeText(keyword) {
+		switch r {
+		case '\'':
+			pattern.WriteString("['‘’‚‛′]")
+		case '"':
+			pattern.WriteString(`["“”„‟″]`)
+		case '-':
+			pattern.WriteString("[-‐‑‒–—―−]")
+		case ' ':
+			pattern.WriteString("[ \u00a0\u2009\u202f]")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return pattern.String()
+}
+
+// keywordExclusion is a compiled exclusion pattern of a keyword
+type keywordExclusion struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// KeywordOccurrence is a mention of a keyword in a normalized response text
+type KeywordOccurrence struct {
+	Offset   int    // Byte offset in the normalized text
+	Context  string // Text around the mention
+	Excluded bool   // The mention is inside a match of an exclusion pattern and is not counted
+	Pattern  string // Exclusion pattern that matched, when Excluded
+}
+
+// SetKeywordOptions sets the per-keyword counting options from config. Exclusion patterns are
+// case-insensitive unless the keyword is case-sensitive; patterns that do not compile are ignored,
+// as config.Load rejects them.
+func SetKeywordOptions(options map[string]config.KeywordOptions) {
+	normalized := make(map[string]config.KeywordOptions, len(options))
+	exclusions := make(map[string][]keywordExclusion)
+	for keyword, opts := range options {
+		key := strings.ToLower(NormalizeText(keyword))
+		normalized[key] = opts
+		for _, pattern := range opts.Exclude {
+			expr := pattern
+			if !opts.CaseSensitive {
+				expr = "(?i)" + expr
+			}
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				continue
+			}
+			exclusions[key] = append(exclusions[key], keywordExclusion{pattern: pattern, re: re})
+		}
+	}
+
+	keywordOptionsMu.Lock()
+	defer keywordOptionsMu.Unlock()
+	keywordOptions = normalized
+	keywordExclusions = exclusions
+}
+
+// KeywordOptionsFor returns the counting options of a keyword, or the defaults
+// (case-insensitive, URLs counted) when it has none
+func KeywordOptionsFor(keyword string) config.KeywordOptions {
+	keywordOptionsMu.RLock()
+	defer keywordOptionsMu.RUnlock()
+	return keywordOptions[strings.ToLower(NormalizeText(keyword))]
+}
+
+// exclusionsFor returns the compiled exclusion patterns of a keyword
+func exclusionsFor(keyword string) []keywordExclusion {
+	keywordOptionsMu.RLock()
+	defer keywordOptionsMu.RUnlock()
+	return keywordExclusions[strings.ToLower(NormalizeText(keyword))]
+}
+
+// excludedBy returns the first exclusion pattern with a match in text covering text[start:end]
+func excludedBy(exclusions []keywordExclusion, text string, start, end int) (string, bool) {
+	for _, exclusion := range exclusions {
+		for _, match := range exclusion.re.FindAllStringIndex(text, -1) {
+			if match[0] <= start && end <= match[1] {
+				return exclusion.pattern, true
+			}
+		}
+	}
+	return "", false
+}
+
+// NormalizeText strips markdown code fences, inline code, links and emphasis from text and folds
+// typographic quotes, dashes and spaces, so that "**Netflix**" and "Netflix’s" read as plain text.
+// Link targets are kept after their label.
+func NormalizeText(text string) string {
+	text = codeFencePattern.ReplaceAllString(text, "")
+	text = markdownLinkPattern.ReplaceAllString(text, "$1 ($2)")
+	return punctuationReplacer.Replace(stripEmphasis(text))
+}
+
+// StripMarkdown converts markdown to plain text: code fences, heading and quote markers and
+// horizontal rules are removed, links and images are flattened to their text and emphasis and
+// inline code markers are dropped. List markers are kept, as keyword positions are read from them.
+func StripMarkdown(text string) string {
+	text = codeFencePattern.ReplaceAllString(text, "")
+	text = ruleLinePattern.ReplaceAllString(text, "")
+	text = headingPattern.ReplaceAllString(text, "")
+	text = blockquotePattern.ReplaceAllString(text, "")
+	text = markdownLinkPattern.ReplaceAllString(text, "$1")
+	text = strings.ReplaceAll(text, "`", "")
+	return stripEmphasis(text)
+}
+
+// stripEmphasis removes bold, italic and strikethrough markers, including nested ones
+func stripEmphasis(text string) string {
+	for {
+		stripped := emphasisPattern.ReplaceAllString(text, "$2$4$5")
+		if stripped == text {
+			return text
+		}
+		text = stripped
+	}
+}
+
+// StripURLs removes URLs and bare domains from text
+func StripURLs(text string) string {
+	return urlPattern.ReplaceAllString(text, " ")
+}
+
+// CountKeyword counts the mentions of keyword in text after normalization, honoring the
+// keyword's case sensitivity, URL and exclusion options
+func CountKeyword(text, keyword string) int {
+	if len(exclusionsFor(keyword)) > 0 {
+		count := 0
+		for _, occurrence := range KeywordOccurrences(text, keyword) {
+			if !occurrence.Excluded {
+				count++
+			}
+		}
+		return count
+	}
+
+	opts := KeywordOptionsFor(keyword)
+
+	keyword = NormalizeText(keyword)
+	if keyword == "" {
+		return 0
+	}
+	text = NormalizeText(text)
+	if opts.ExcludeURLs {
+		text = StripURLs(text)
+	}
+	if opts.CaseSensitive {
+		return strings.Count(text, keyword)
+	}
+	return CountOccurrences(text, keyword)
+}
+
+// keywordContextLength is the number of bytes of text shown on each side of a KeywordOccurrence
+const keywordContextLength = 40
+
+// KeywordOccurrences lists the mentions of keyword in text as CountKeyword finds them, telling
+// counted mentions from those suppressed by an exclusion pattern
+func KeywordOccurrences(text, keyword string) []KeywordOccurrence {
+	opts := KeywordOptionsFor(keyword)
+	exclusions := exclusionsFor(keyword)
+
+	keyword = NormalizeText(keyword)
+	if keyword == "" {
+		return nil
+	}
+	text = NormalizeText(text)
+	if opts.ExcludeURLs {
+		text = StripURLs(text)
+	}
+	expr := regexp.QuoteMeta(keyword)
+	if !opts.CaseSensitive {
+		expr = "(?i)" + expr
+	}
+
+	var occurrences []KeywordOccurrence
+	for _, match := range regexp.MustCompile(expr).FindAllStringIndex(text, -1) {
+		occurrence := KeywordOccurrence{Offset: match[0], Context: occurrenceContext(text, match[0], match[1])}
+		occurrence.Pattern, occurrence.Excluded = excludedBy(exclusions, text, match[0], match[1])
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences
+}
+
+// occurrenceContext returns the text around text[start:end] on a single line, cut on rune boundaries
+func occurrenceContext(text string, start, end int) string {
+	from := max(start-keywordContextLength, 0)
+	for from > 0 && !utf8.RuneStart(text[from]) {
+		from--
+	}
+	to := min(end+keywordContextLength, len(text))
+	for to < len(text) && !utf8.RuneStart(text[to]) {
+		to++
+	}
+
+	context := strings.Join(strings.Fields(text[from:to]), " ")
+	if from > 0 {
+		context = "…" + context
+	}
+	if to < len(text) {
+		context += "…"
+	}
+	return context
+}
@@ -0,0 +1,100 @@
+package shared
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/AI2HU/gego/internal/config"
+)
+
+func TestCountKeyword(t *testing.T) {
+	SetKeywordOptions(map[string]config.KeywordOptions{
+		"Apple":   {CaseSensitive: true},
+		"Netflix": {ExcludeURLs: true},
+	})
+	t.Cleanup(func() { SetKeywordOptions(nil) })
+
+	tests := []struct {
+		name    string
+		text    string
+		keyword string
+		want    int
+	}{
+		{name: "plain text", text: "Notion and Notion AI", keyword: "Notion", want: 2},
+		{name: "bold", text: "The best is **Notion**.", keyword: "Notion", want: 1},
+		{name: "nested emphasis", text: "Try ***Notion*** or _**Notion**_", keyword: "Notion", want: 2},
+		{name: "strikethrough", text: "~~Evernote~~ is gone", keyword: "Evernote", want: 1},
+		{name: "inline code", text: "Install `Notion` today", keyword: "Notion", want: 1},
+		{name: "code fence", text: "```markdown\nNotion\n```", keyword: "Notion", want: 1},
+		{name: "link label and target", text: "See [Notion](https://notion.so)", keyword: "notion", want: 2},
+		{name: "curly apostrophe", text: "Notion’s editor", keyword: "Notion's", want: 1},
+		{name: "em dash", text: "Coca—Cola", keyword: "Coca-Cola", want: 1},
+		{name: "non-breaking space", text: "Google\u00a0Docs", keyword: "Google Docs", want: 1},
+		{name: "case-insensitive by default", text: "NOTION, notion", keyword: "Notion", want: 2},
+		{name: "case-sensitive keyword", text: "Apple and an apple", keyword: "Apple", want: 1},
+		{name: "URLs excluded", text: "Netflix (netflix.com) and https://www.netflix.com/browse", keyword: "Netflix", want: 1},
+		{name: "URLs counted by default", text: "Notion (notion.so) at notion.com", keyword: "Notion", want: 3},
+		{name: "empty keyword", text: "Notion", keyword: "**", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountKeyword(tt.text, tt.keyword); got != tt.want {
+				t.Errorf("CountKeyword(%q, %q) = %d, want %d", tt.text, tt.keyword, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeywordPattern(t *testing.T) {
+	tests := []struct {
+		keyword string
+		text    string
+		want    bool
+	}{
+		{keyword: "Notion's", text: "Notion’s editor", want: true},
+		{keyword: "Coca-Cola", text: "Coca–Cola", want: true},
+		{keyword: "Google Docs", text: "Google\u202fDocs", want: true},
+		{keyword: "C++", text: "C++ tools", want: true},
+		{keyword: "C++", text: "Cxx tools"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.keyword+" in "+tt.text, func(t *testing.T) {
+			re := regexp.MustCompile(KeywordPattern(tt.keyword))
+			if got := re.MatchString(tt.text); got != tt.want {
+				t.Errorf("KeywordPattern(%q) matches %q = %t, want %t", tt.keyword, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "bold", text: "Watch **Netflix** tonight", want: "Watch Netflix tonight"},
+		{name: "nested emphasis", text: "***Netflix*** and _**Hulu**_ and ~~Vine~~", want: "Netflix and Hulu and Vine"},
+		{name: "link", text: "Try [Netflix](https://www.netflix.com) first", want: "Try Netflix first"},
+		{name: "bold link", text: "Try **[Netflix](https://www.netflix.com)**", want: "Try Netflix"},
+		{name: "image", text: "![Netflix logo](logo.png)", want: "Netflix logo"},
+		{name: "heading and quote", text: "## Streaming\n> Netflix leads", want: "Streaming\nNetflix leads"},
+		{name: "inline code", text: "Run `netflix-cli`", want: "Run netflix-cli"},
+		{name: "list markers kept", text: "1. **Netflix**\n- Hulu", want: "1. Netflix\n- Hulu"},
+	}
+
+	netflix := regexp.MustCompile(KeywordPattern("Netflix"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StripMarkdown(tt.text)
+			if got != tt.want {
+				t.Errorf("StripMarkdown(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			if netflix.MatchString(tt.want) && netflix.FindString(got) != "Netflix" {
+				t.Errorf("StripMarkdown(%q) = %q, want a plain Netflix match", tt.text, got)
+			}
+		})
+	}
+}
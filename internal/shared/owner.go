@@ -0,0 +1,24 @@
+package shared
+
+import (
+	"context"
+	"strings"
+)
+
+type ownerKey struct{}
+
+// WithOwner scopes list, search and stats queries made with ctx to an owner label.
+// An empty owner leaves ctx unscoped.
+func WithOwner(ctx context.Context, owner string) context.Context {
+	owner = strings.TrimSpace(owner)
+	if owner == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ownerKey{}, owner)
+}
+
+// OwnerFromContext returns the owner label ctx is scoped to, or "" if unscoped
+func OwnerFromContext(ctx context.Context) string {
+	owner, _ := ctx.Value(ownerKey{}).(string)
+	return owner
+}
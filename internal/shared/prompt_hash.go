@@ -0,0 +1,13 @@
+package shared
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// PromptHash returns the SHA-256 hex digest of a prompt template, identifying the prompt
+// version a response was generated from
+func PromptHash(template string) string {
+	sum := sha256.Sum256([]byte(template))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,31 @@
+package shared
+
+import "strings"
+
+// NormalizeTags trims tags and lowercases them unless preserveCase is set, dropping empty tags and
+// duplicates. Duplicates are matched case-insensitively either way, keeping the first spelling.
+func NormalizeTags(tags []string, preserveCase bool) []string {
+	var normalized []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		key := strings.ToLower(tag)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if !preserveCase {
+			tag = key
+		}
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// ParseTags normalizes comma-separated tag input with NormalizeTags
+func ParseTags(input string, preserveCase bool) []string {
+	return NormalizeTags(strings.Split(input, ","), preserveCase)
+}
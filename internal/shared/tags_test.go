@@ -0,0 +1,35 @@
+package shared
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		tags         []string
+		preserveCase bool
+		want         []string
+	}{
+		{name: "case variants collapse", tags: []string{"FR", " fr ", "fr"}, want: []string{"fr"}},
+		{name: "order kept", tags: []string{"Brand", "lang-en", "BRAND", "comparison"}, want: []string{"brand", "lang-en", "comparison"}},
+		{name: "empty tags dropped", tags: []string{"", "  ", "fr"}, want: []string{"fr"}},
+		{name: "preserved case keeps the first spelling", tags: []string{"SaaS", "saas", " SAAS"}, preserveCase: true, want: []string{"SaaS"}},
+		{name: "no tags", tags: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTags(tt.tags, tt.preserveCase); !slices.Equal(got, tt.want) {
+				t.Errorf("NormalizeTags(%q, %t) = %q, want %q", tt.tags, tt.preserveCase, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	if got, want := ParseTags("FR, fr ,,Brand", false), []string{"fr", "brand"}; !slices.Equal(got, want) {
+		t.Errorf("ParseTags() = %q, want %q", got, want)
+	}
+}
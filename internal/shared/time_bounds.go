@@ -0,0 +1,18 @@
+package shared
+
+import "context"
+
+type exclusiveEndKey struct{}
+
+// WithExclusiveEnd makes time-bounded keyword stats queries made with ctx leave out responses
+// created exactly at the end of the window, so that adjacent windows sharing a bound never count a
+// response twice
+func WithExclusiveEnd(ctx context.Context) context.Context {
+	return context.WithValue(ctx, exclusiveEndKey{}, true)
+}
+
+// ExclusiveEndFromContext reports whether the window ends of ctx queries are exclusive
+func ExclusiveEndFromContext(ctx context.Context) bool {
+	exclusive, _ := ctx.Value(exclusiveEndKey{}).(bool)
+	return exclusive
+}
@@ -6,12 +6,47 @@ import (
 
 // ResponseFilter provides filtering options for listing responses
 type ResponseFilter struct {
-	PromptID   string
-	LLMID      string
-	ScheduleID string
-	Keyword    string
-	StartTime  *time.Time
-	EndTime    *time.Time
-	Limit      int
-	Offset     int
+	PromptID      string
+	LLMID         string
+	Model         string // Only responses of this model
+	ScheduleID    string
+	RunID         string // Only responses of this schedule run
+	Keyword       string
+	Owner         string
+	Label         string   // Only responses annotated with this label
+	ExcludeLabels []string // Skip responses annotated with any of these labels
+	HasError      *bool    // Only responses that recorded a provider error (true) or that did not (false)
+	Grounded      *bool    // Only responses generated with a web search (true) or without (false)
+	CacheKey      string   // Only responses whose metadata records this request cache key
+	ExcludeCached bool     // Skip responses reused from the response cache
+	StartTime     *time.Time
+	EndTime       *time.Time
+	Limit         int
+	Offset        int
+	After         *ResponseCursor // Only responses listed after this one; Offset then applies from it
+	SortAsc       bool            // List oldest first instead of newest first
+}
+
+const (
+	// DefaultKeywordMaxScan is the number of responses a keyword search scans at most by default
+	DefaultKeywordMaxScan = 100000
+	// DefaultKeywordScanTimeout is the default deadline of a keyword search
+	DefaultKeywordScanTimeout = 30 * time.Second
+)
+
+// KeywordSearchOptions bounds the responses scanned by a keyword search. Zero values use the defaults.
+type KeywordSearchOptions struct {
+	MaxScan int           // Stop after scanning this many matching responses
+	Timeout time.Duration // Stop scanning after this long, keeping partial results
+}
+
+// WithDefaults returns the options with unset values replaced by the defaults
+func (o KeywordSearchOptions) WithDefaults() KeywordSearchOptions {
+	if o.MaxScan <= 0 {
+		o.MaxScan = DefaultKeywordMaxScan
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultKeywordScanTimeout
+	}
+	return o
 }
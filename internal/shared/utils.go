@@ -145,19 +145,26 @@ func GetExclusionFilePath() string {
 	return getExclusionFilePath()
 }
 
-// ExtractCapitalizedWords extracts words that start with a capital letter
+// ExtractCapitalizedWords extracts words that start with a capital letter from the normalized text,
+// skipping the mentions of keywords suppressed by their exclusion patterns
 func ExtractCapitalizedWords(text string) []string {
 	re := regexp.MustCompile(`\b[A-Z][a-zA-Z]+(?:\s+[A-Z][a-zA-Z]+)*\b`)
-	matches := re.FindAllString(text, -1)
+	text = NormalizeText(text)
+	matches := re.FindAllStringIndex(text, -1)
 
 	// Filter common words that can be confused with brand names
 	var filtered []string
 	commonWords := getExclusionWords()
 
-	for _, word := range matches {
-		if !commonWords[word] {
-			filtered = append(filtered, word)
+	for _, match := range matches {
+		word := text[match[0]:match[1]]
+		if commonWords[word] {
+			continue
 		}
+		if _, excluded := excludedBy(exclusionsFor(word), text, match[0], match[1]); excluded {
+			continue
+		}
+		filtered = append(filtered, word)
 	}
 
 	return filtered
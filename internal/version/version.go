@@ -0,0 +1,79 @@
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build metadata, populated at build time via ldflags:
+//
+//	-X github.com/AI2HU/gego/internal/version.Version=v1.2.3
+//	-X github.com/AI2HU/gego/internal/version.Commit=abc1234
+//	-X github.com/AI2HU/gego/internal/version.BuildDate=2025-01-01T00:00:00Z
+var (
+	Version   = ""
+	Commit    = ""
+	BuildDate = ""
+)
+
+// Info holds the build metadata of the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build metadata, falling back to debug.ReadBuildInfo when ldflags were not set
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "" && buildInfo.Main.Version != "" && buildInfo.Main.Version != "(devel)" {
+			info.Version = buildInfo.Main.Version
+		}
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = setting.Value
+				}
+			}
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = "unknown"
+	}
+	if len(info.Commit) > 12 {
+		info.Commit = info.Commit[:12]
+	}
+
+	return info
+}
+
+// String returns a one-line human readable description of the build
+func (i Info) String() string {
+	return fmt.Sprintf("gego %s (commit %s, built %s, %s)", i.Version, i.Commit, i.BuildDate, i.GoVersion)
+}
+
+// UserAgent returns the User-Agent string sent to LLM providers
+func UserAgent() string {
+	return "gego/" + Get().Version
+}